@@ -29,6 +29,7 @@ require (
 	golang.org/x/text v0.7.0
 	golang.org/x/tools v0.1.12
 	google.golang.org/api v0.52.0
+	google.golang.org/genproto v0.0.0-20210803142424-70bd63adacf2
 	google.golang.org/grpc v1.39.0
 	google.golang.org/protobuf v1.27.1
 	sigs.k8s.io/yaml v1.2.0
@@ -48,7 +49,6 @@ require (
 	golang.org/x/mod v0.6.0-dev.0.20220419223038-86c51ed26bb4 // indirect
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
-	google.golang.org/genproto v0.0.0-20210803142424-70bd63adacf2 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
 
@@ -130,6 +130,7 @@ type decorCommand struct {
 	refFormat        string
 	extendsOverrides bool
 	semanticScopes   bool
+	targets          flagutil.StringSet
 }
 
 func (decorCommand) Name() string      { return "decor" }
@@ -157,6 +158,7 @@ func (c *decorCommand) SetFlags(flag *flag.FlagSet) {
 	flag.BoolVar(&c.targetDefs, "target_definitions", false, "Whether to request definitions (@targetDef@ format marker) for each reference's target")
 	flag.BoolVar(&c.extendsOverrides, "extends_overrides", false, "Whether to request extends/overrides information")
 	flag.BoolVar(&c.semanticScopes, "semantic_scopes", false, "Whether to request semantic scope information")
+	flag.Var(&c.targets, "target", "CSV set of target tickets to which decorations are limited")
 }
 func (c decorCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) error {
 	req, err := c.baseRequest(flag)
@@ -167,6 +169,7 @@ func (c decorCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) erro
 	req.TargetDefinitions = c.targetDefs
 	req.ExtendsOverrides = c.extendsOverrides
 	req.SemanticScopes = c.semanticScopes
+	req.TargetTicket = c.targets.Elements()
 	req.Filter = []string{
 		facts.NodeKind,
 		facts.Subkind,
@@ -41,6 +41,7 @@ type edgesCommand struct {
 	countOnly   bool
 	targetsOnly bool
 	edgeKinds   string
+	kindPreset  string
 	pageToken   string
 	pageSize    int
 }
@@ -52,6 +53,7 @@ func (c *edgesCommand) SetFlags(flag *flag.FlagSet) {
 	flag.BoolVar(&c.countOnly, "count_only", false, "Only print counts per edge kind")
 	flag.BoolVar(&c.targetsOnly, "targets_only", false, "Only display edge targets")
 	flag.StringVar(&c.edgeKinds, "kinds", "", "Comma-separated list of edge kinds to return (default returns all)")
+	flag.StringVar(&c.kindPreset, "kind_preset", "", "Named set of edge kinds to return (semantic, structure, all_forward, or all_reverse)")
 	flag.StringVar(&c.pageToken, "page_token", "", "Edges page token")
 	flag.IntVar(&c.pageSize, "page_size", 0, "Maximum number of edges returned (0 lets the service use a sensible default)")
 }
@@ -74,6 +76,13 @@ func (c edgesCommand) Run(ctx context.Context, flag *flag.FlagSet, api API) erro
 			req.Kind = append(req.Kind, c.expandEdgeKind(kind))
 		}
 	}
+	if c.kindPreset != "" {
+		preset, ok := gpb.EdgesRequest_KindPreset_value[strings.ToUpper(c.kindPreset)]
+		if !ok {
+			return fmt.Errorf("unknown --kind_preset: %q", c.kindPreset)
+		}
+		req.KindPreset = gpb.EdgesRequest_KindPreset(preset)
+	}
 	if c.dotGraph {
 		req.Filter = []string{"**"}
 	}
@@ -29,6 +29,10 @@ import (
 
 	"kythe.io/kythe/go/services/web"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	cpb "kythe.io/kythe/proto/common_go_proto"
 	gpb "kythe.io/kythe/proto/graph_go_proto"
 )
@@ -106,6 +110,11 @@ func AllEdges(ctx context.Context, es Service, req *gpb.EdgesRequest) (*gpb.Edge
 // the MaxTickets configuration.
 type BoundedRequests struct {
 	MaxTickets int
+
+	// MaxCountTickets, if positive, is the maximum number of tickets allowed in
+	// a count_only Edges request.  Otherwise, MaxTickets applies.
+	MaxCountTickets int
+
 	Service
 }
 
@@ -119,12 +128,58 @@ func (b BoundedRequests) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb
 
 // Edges implements part of the Service interface.
 func (b BoundedRequests) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
-	if len(req.Ticket) > b.MaxTickets {
-		return nil, fmt.Errorf("too many tickets requested: %d (max %d)", len(req.Ticket), b.MaxTickets)
+	max := b.MaxTickets
+	if req.CountOnly && b.MaxCountTickets > 0 {
+		max = b.MaxCountTickets
+	}
+	if len(req.Ticket) > max {
+		return nil, fmt.Errorf("too many tickets requested: %d (max %d)", len(req.Ticket), max)
 	}
 	return b.Service.Edges(ctx, req)
 }
 
+// Provenance stamps each reply without a build ID with the given build ID and
+// build time of the dataset serving it.  Requests selecting any other build ID
+// fail with NOT_FOUND.
+type Provenance struct {
+	BuildID   string
+	BuildTime *timestamppb.Timestamp
+	Service
+}
+
+// checkBuildID returns a NOT_FOUND error if buildID selects a build other than
+// p's.
+func (p Provenance) checkBuildID(buildID string) error {
+	if buildID != "" && buildID != p.BuildID {
+		return status.Errorf(codes.NotFound, "unknown build ID %q", buildID)
+	}
+	return nil
+}
+
+// Nodes implements part of the Service interface.
+func (p Provenance) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	if err := p.checkBuildID(req.GetBuildId()); err != nil {
+		return nil, err
+	}
+	reply, err := p.Service.Nodes(ctx, req)
+	if reply != nil && reply.BuildId == "" {
+		reply.BuildId, reply.BuildTime = p.BuildID, p.BuildTime
+	}
+	return reply, err
+}
+
+// Edges implements part of the Service interface.
+func (p Provenance) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	if err := p.checkBuildID(req.GetBuildId()); err != nil {
+		return nil, err
+	}
+	reply, err := p.Service.Edges(ctx, req)
+	if reply != nil && reply.BuildId == "" {
+		reply.BuildId, reply.BuildTime = p.BuildID, p.BuildTime
+	}
+	return reply, err
+}
+
 type webClient struct{ addr string }
 
 // Nodes implements part of the Service interface.
@@ -155,7 +210,9 @@ func WebClient(addr string) Service {
 //	  Response: JSON encoded graph.EdgesReply
 //
 // Note: /nodes, and /edges will return their responses as serialized protobufs
-// if the "proto" query parameter is set.
+// if the "proto" query parameter is set.  If the request's Accept header
+// includes web.DelimitedProtoType, /edges instead streams its response as
+// length-delimited partial EdgesReplys (see writeEdgesStream).
 func RegisterHTTPHandlers(ctx context.Context, gs Service, mux *http.ServeMux) {
 	mux.HandleFunc("/nodes", func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -193,12 +250,44 @@ func RegisterHTTPHandlers(ctx context.Context, gs Service, mux *http.ServeMux) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		if err := web.WriteResponse(w, r, reply); err != nil {
+		if web.AcceptsDelimitedProto(r) {
+			err = writeEdgesStream(w, r, reply)
+		} else {
+			err = web.WriteResponse(w, r, reply)
+		}
+		if err != nil {
 			log.Println(err)
 		}
 	})
 }
 
+// writeEdgesStream writes reply to w as a stream of length-delimited
+// EdgesReplys: one per EdgeSet, in ticket order, followed by the reply's
+// remaining fields.  Each message is flushed as it is written.  Merging the
+// messages in order (e.g. with proto.Merge) yields the original reply.
+func writeEdgesStream(w http.ResponseWriter, r *http.Request, reply *gpb.EdgesReply) error {
+	dw := web.NewDelimitedProtoWriter(w, r)
+	sets := reply.EdgeSets
+	tickets := make([]string, 0, len(sets))
+	for ticket := range sets {
+		tickets = append(tickets, ticket)
+	}
+	sort.Strings(tickets)
+	for _, ticket := range tickets {
+		if err := dw.Write(&gpb.EdgesReply{EdgeSets: map[string]*gpb.EdgeSet{ticket: sets[ticket]}}); err != nil {
+			dw.Close()
+			return err
+		}
+	}
+	reply.EdgeSets = nil
+	defer func() { reply.EdgeSets = sets }()
+	if err := dw.Write(reply); err != nil {
+		dw.Close()
+		return err
+	}
+	return dw.Close()
+}
+
 // NodesMap returns a map from each node ticket to a map of its facts.
 func NodesMap(nodes map[string]*cpb.NodeInfo) map[string]map[string][]byte {
 	m := make(map[string]map[string][]byte, len(nodes))
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package validate implements request validation shared by the Kythe serving
+// services.  A Validator accumulates every problem found in a request so that
+// they can be reported together in a single structured error.
+package validate // import "kythe.io/kythe/go/services/validate"
+
+import (
+	"fmt"
+	"strings"
+
+	"kythe.io/kythe/go/util/kytheuri"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// A Violation is a single problem with a request field.
+type Violation struct {
+	// Field is the path of the offending request field (e.g. "ticket[2]").
+	Field string
+
+	// Value is the offending value, if any.
+	Value string
+
+	// Reason describes why the value is invalid.
+	Reason string
+}
+
+// String returns a human-readable description of the violation.
+func (v *Violation) String() string {
+	if v.Value == "" {
+		return fmt.Sprintf("%s: %s", v.Field, v.Reason)
+	}
+	return fmt.Sprintf("%s: %s (value: %q)", v.Field, v.Reason, v.Value)
+}
+
+// A Validator accumulates Violations for a single request.  The zero value is
+// ready for use.
+type Validator struct {
+	violations []*Violation
+}
+
+// Addf records a violation for the given field and value.
+func (v *Validator) Addf(field, value, format string, args ...interface{}) {
+	v.violations = append(v.violations, &Violation{
+		Field:  field,
+		Value:  value,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// Violations returns the violations recorded so far.
+func (v *Validator) Violations() []*Violation { return v.violations }
+
+// Ticket validates and returns the canonical form of the given ticket.  An
+// empty ticket is reported as missing.
+func (v *Validator) Ticket(field, ticket string) string {
+	if ticket == "" {
+		v.Addf(field, "", "missing ticket")
+		return ""
+	}
+	fixed, err := kytheuri.DefaultFixCache.Fix(ticket)
+	if err != nil {
+		v.Addf(field, ticket, "invalid ticket: %v", err)
+		return ""
+	}
+	return fixed
+}
+
+// Tickets validates and returns the canonical form of each of the given
+// tickets.  At least one ticket must be given.
+func (v *Validator) Tickets(field string, tickets []string) []string {
+	if len(tickets) == 0 {
+		v.Addf(field, "", "no tickets specified")
+		return nil
+	}
+	fixed, invalid := fixTickets(field, tickets)
+	v.violations = append(v.violations, invalid...)
+	return fixed
+}
+
+// ValidTickets is like Tickets, but skips invalid tickets rather than
+// recording them: the canonical form of each valid ticket is returned along
+// with a Violation for each invalid ticket.  A violation is recorded only if
+// no valid ticket is given.
+func (v *Validator) ValidTickets(field string, tickets []string) (valid []string, invalid []*Violation) {
+	if len(tickets) == 0 {
+		v.Addf(field, "", "no tickets specified")
+		return nil, nil
+	}
+	fixed, invalid := fixTickets(field, tickets)
+	for _, ticket := range fixed {
+		if ticket != "" {
+			valid = append(valid, ticket)
+		}
+	}
+	if len(valid) == 0 {
+		v.Addf(field, "", "no valid tickets specified")
+	}
+	return valid, invalid
+}
+
+// fixTickets returns the canonical form of each of the given tickets and a
+// Violation for each that is missing or invalid.  The canonical form of an
+// invalid ticket is "".
+func fixTickets(field string, tickets []string) ([]string, []*Violation) {
+	fixed, errs := kytheuri.DefaultFixCache.FixAll(tickets)
+	var invalid []*Violation
+	for i, ticket := range tickets {
+		if ticket == "" {
+			invalid = append(invalid, &Violation{
+				Field:  fmt.Sprintf("%s[%d]", field, i),
+				Reason: "missing ticket",
+			})
+		} else if errs != nil && errs[i] != nil {
+			invalid = append(invalid, &Violation{
+				Field:  fmt.Sprintf("%s[%d]", field, i),
+				Value:  ticket,
+				Reason: fmt.Sprintf("invalid ticket: %v", errs[i]),
+			})
+		} else {
+			continue
+		}
+		fixed[i] = ""
+	}
+	return fixed, invalid
+}
+
+// PageSize validates that the given page size is non-negative.
+func (v *Validator) PageSize(field string, size int32) {
+	if size < 0 {
+		v.Addf(field, fmt.Sprint(size), "must be non-negative")
+	}
+}
+
+// PageToken records a violation for the given page token if err is non-nil.
+// err should be the result of decoding the token.
+func (v *Validator) PageToken(field, token string, err error) {
+	if err != nil {
+		v.Addf(field, token, "invalid page token: %v", err)
+	}
+}
+
+// Err returns nil if no violations were recorded.  Otherwise, an
+// InvalidArgument status error is returned describing every violation, with
+// each attached as a BadRequest field violation detail.
+func (v *Validator) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(v.violations))
+	br := &errdetails.BadRequest{}
+	for i, vi := range v.violations {
+		msgs[i] = vi.String()
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       vi.Field,
+			Description: vi.String(),
+		})
+	}
+	st := status.New(codes.InvalidArgument, "invalid request: "+strings.Join(msgs, "; "))
+	if detailed, err := st.WithDetails(br); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// FieldViolations returns the BadRequest field violations attached to the
+// given error by a Validator, if any.
+func FieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var res []*errdetails.BadRequest_FieldViolation
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			res = append(res, br.GetFieldViolations()...)
+		}
+	}
+	return res
+}
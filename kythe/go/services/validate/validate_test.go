@@ -0,0 +1,97 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidatorOK(t *testing.T) {
+	var v Validator
+	tickets := v.Tickets("ticket", []string{"kythe://corpus#sig"})
+	v.PageSize("page_size", 10)
+	v.PageToken("page_token", "", nil)
+	if err := v.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tickets) != 1 || tickets[0] != "kythe://corpus#sig" {
+		t.Errorf("Unexpected tickets: %v", tickets)
+	}
+}
+
+func TestValidatorViolations(t *testing.T) {
+	var v Validator
+	v.Tickets("ticket", []string{"kythe://corpus#sig", "bad:ticket", ""})
+	v.PageSize("page_size", -1)
+	v.PageToken("page_token", "token", errors.New("bad encoding"))
+
+	err := v.Err()
+	if c := status.Code(err); c != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument; found %v: %v", c, err)
+	}
+
+	expected := []string{"ticket[1]", "ticket[2]", "page_size", "page_token"}
+	found := FieldViolations(err)
+	if len(found) != len(expected) {
+		t.Fatalf("Expected %d violations; found %v", len(expected), found)
+	}
+	for i, f := range found {
+		if f.Field != expected[i] {
+			t.Errorf("Expected violation for %q; found %q", expected[i], f.Field)
+		}
+	}
+	if vs := v.Violations(); vs[2].Value != "-1" {
+		t.Errorf("Expected page_size value -1; found %q", vs[2].Value)
+	}
+}
+
+func TestValidatorNoTickets(t *testing.T) {
+	var v Validator
+	v.Tickets("ticket", nil)
+	if found := FieldViolations(v.Err()); len(found) != 1 || found[0].Field != "ticket" {
+		t.Errorf("Unexpected violations: %v", found)
+	}
+}
+
+func TestValidatorValidTickets(t *testing.T) {
+	var v Validator
+	valid, invalid := v.ValidTickets("ticket", []string{"bad:ticket", "kythe://corpus#sig", ""})
+	if err := v.Err(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(valid) != 1 || valid[0] != "kythe://corpus#sig" {
+		t.Errorf("Unexpected valid tickets: %v", valid)
+	}
+	var fields []string
+	for _, i := range invalid {
+		fields = append(fields, i.Field)
+	}
+	if len(fields) != 2 || fields[0] != "ticket[0]" || fields[1] != "ticket[2]" || invalid[0].Value != "bad:ticket" {
+		t.Errorf("Unexpected invalid tickets: %v", invalid)
+	}
+
+	// A violation is recorded if no ticket is valid.
+	v = Validator{}
+	v.ValidTickets("ticket", []string{"bad:ticket"})
+	if found := FieldViolations(v.Err()); len(found) != 1 || found[0].Field != "ticket" {
+		t.Errorf("Unexpected violations: %v", found)
+	}
+}
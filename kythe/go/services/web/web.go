@@ -18,11 +18,14 @@
 package web // import "kythe.io/kythe/go/services/web"
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"os"
 	"strings"
@@ -35,6 +38,10 @@ import (
 
 const jsonBodyType = "application/json; charset=utf-8"
 
+// DelimitedProtoType is the Content-Type of a stream of serialized protobufs,
+// each preceded by its varint-encoded length.
+const DelimitedProtoType = "application/x-protobuf-delimited"
+
 // JSONMarshaler is the marshaler used to encode all JSON web requests.
 var JSONMarshaler = Marshaler{
 	protojson.MarshalOptions{
@@ -138,6 +145,73 @@ func WriteProtoResponse(w http.ResponseWriter, r *http.Request, msg proto.Messag
 	return err
 }
 
+// AcceptsDelimitedProto reports whether the Accept header of r includes
+// DelimitedProtoType.
+func AcceptsDelimitedProto(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, t := range strings.Split(accept, ",") {
+			if mt, _, err := mime.ParseMediaType(strings.TrimSpace(t)); err == nil && mt == DelimitedProtoType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// A DelimitedProtoWriter streams length-delimited serialized protobufs as the
+// response to an HTTP request.
+type DelimitedProtoWriter struct {
+	w  http.ResponseWriter
+	cw io.WriteCloser
+}
+
+// NewDelimitedProtoWriter returns a DelimitedProtoWriter for w, compressed as
+// accepted by r.  The writer must be closed once the response is complete.
+func NewDelimitedProtoWriter(w http.ResponseWriter, r *http.Request) *DelimitedProtoWriter {
+	w.Header().Set("Content-Type", DelimitedProtoType)
+	return &DelimitedProtoWriter{w: w, cw: httpencoding.CompressData(w, r)}
+}
+
+// Write serializes msg to the stream and flushes it to the client.
+func (d *DelimitedProtoWriter) Write(msg proto.Message) error {
+	rec, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling proto: %v", err)
+	}
+	var size [binary.MaxVarintLen64]byte
+	if _, err := d.cw.Write(size[:binary.PutUvarint(size[:], uint64(len(rec)))]); err != nil {
+		return err
+	} else if _, err := d.cw.Write(rec); err != nil {
+		return err
+	}
+	if f, ok := d.cw.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return err
+		}
+	}
+	if f, ok := d.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// Close completes the stream.
+func (d *DelimitedProtoWriter) Close() error { return d.cw.Close() }
+
+// ReadDelimitedProto reads the next length-delimited serialized protobuf from r
+// into msg.  At the end of the stream, io.EOF is returned.
+func ReadDelimitedProto(r *bufio.Reader, msg proto.Message) error {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	rec := make([]byte, size)
+	if _, err := io.ReadFull(r, rec); err != nil {
+		return fmt.Errorf("error reading proto: %v", err)
+	}
+	return proto.Unmarshal(rec, msg)
+}
+
 // Arg returns the first query value for the named parameter or "" if it was not
 // set.
 func Arg(r *http.Request, name string) string {
@@ -20,12 +20,18 @@ package xrefs // import "kythe.io/kythe/go/services/xrefs"
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"kythe.io/kythe/go/services/graph"
 	"kythe.io/kythe/go/services/web"
 	"kythe.io/kythe/go/util/kytheuri"
 	"kythe.io/kythe/go/util/schema/edges"
@@ -33,8 +39,10 @@ import (
 	"bitbucket.org/creachadair/stringset"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
+	gpb "kythe.io/kythe/proto/graph_go_proto"
 	xpb "kythe.io/kythe/proto/xref_go_proto"
 )
 
@@ -77,13 +85,11 @@ func FixTickets(tickets []string) ([]string, error) {
 		return nil, status.Error(codes.InvalidArgument, "no tickets specified")
 	}
 
-	canonical := make([]string, len(tickets))
-	for i, ticket := range tickets {
-		fixed, err := kytheuri.Fix(ticket)
+	canonical, errs := kytheuri.DefaultFixCache.FixAll(tickets)
+	for i, err := range errs {
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid ticket %q: %v", ticket, err)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ticket %q: %v", tickets[i], err)
 		}
-		canonical[i] = fixed
 	}
 	return canonical, nil
 }
@@ -181,6 +187,33 @@ func IsCallerKind(requestedKind xpb.CrossReferencesRequest_CallerKind, edgeKind
 	}
 }
 
+// ClassifyAnchor returns the classification of an anchor with the given
+// edgeKind, consistent with IsDeclKind, IsDefKind, IsRefKind, and
+// IsCallerKind.  incomplete reports whether the anchor's node is incomplete,
+// making its definitions declarations.
+func ClassifyAnchor(edgeKind string, incomplete bool) xpb.Anchor_Classification {
+	edgeKind = edges.Canonical(edgeKind)
+	switch {
+	case IsDeclKind(xpb.CrossReferencesRequest_ALL_DECLARATIONS, edgeKind, incomplete):
+		return xpb.Anchor_DECLARATION
+	case edges.IsVariant(edgeKind, edges.Completes):
+		return xpb.Anchor_COMPLETION
+	case edgeKind == edges.DefinesBinding:
+		return xpb.Anchor_BINDING
+	case edges.IsVariant(edgeKind, edges.Defines):
+		return xpb.Anchor_DEFINITION
+	case IsRefKind(xpb.CrossReferencesRequest_CALL_REFERENCES, edgeKind),
+		IsCallerKind(xpb.CrossReferencesRequest_OVERRIDE_CALLERS, edgeKind):
+		return xpb.Anchor_CALL
+	case IsRefKind(xpb.CrossReferencesRequest_ALL_REFERENCES, edgeKind):
+		return xpb.Anchor_REFERENCE
+	case edges.IsVariant(edgeKind, edges.Documents):
+		return xpb.Anchor_DOC
+	default:
+		return xpb.Anchor_UNCLASSIFIED
+	}
+}
+
 // ConvertFilters converts each filter glob into an equivalent regexp.
 func ConvertFilters(filters []string) []*regexp.Regexp {
 	var patterns []*regexp.Regexp
@@ -235,6 +268,12 @@ func MatchesAny(str string, patterns []*regexp.Regexp) bool {
 	return false
 }
 
+// FileDigest returns the hex-encoded SHA-256 digest of the given file text.
+// Files with equal digests are considered duplicates of one another.
+func FileDigest(text []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(text))
+}
+
 // BoundedRequests guards against requests for more tickets than allowed per
 // the MaxTickets configuration.
 type BoundedRequests struct {
@@ -258,6 +297,375 @@ func (b BoundedRequests) Documentation(ctx context.Context, req *xpb.Documentati
 	return b.Service.Documentation(ctx, req)
 }
 
+// Provenance stamps each reply without a build ID with the given build ID and
+// build time of the dataset serving it.  Requests selecting any other build ID
+// fail with NOT_FOUND.
+type Provenance struct {
+	BuildID   string
+	BuildTime *timestamppb.Timestamp
+	Service
+}
+
+// checkBuildID returns a NOT_FOUND error if buildID selects a build other than
+// p's.
+func (p Provenance) checkBuildID(buildID string) error {
+	if buildID != "" && buildID != p.BuildID {
+		return status.Errorf(codes.NotFound, "unknown build ID %q", buildID)
+	}
+	return nil
+}
+
+// Decorations implements part of the Service interface.
+func (p Provenance) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	if err := p.checkBuildID(req.GetBuildId()); err != nil {
+		return nil, err
+	}
+	reply, err := p.Service.Decorations(ctx, req)
+	if reply != nil && reply.BuildId == "" {
+		reply.BuildId, reply.BuildTime = p.BuildID, p.BuildTime
+	}
+	return reply, err
+}
+
+// CrossReferences implements part of the Service interface.
+func (p Provenance) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	if err := p.checkBuildID(req.GetBuildId()); err != nil {
+		return nil, err
+	}
+	reply, err := p.Service.CrossReferences(ctx, req)
+	if reply != nil && reply.BuildId == "" {
+		reply.BuildId, reply.BuildTime = p.BuildID, p.BuildTime
+	}
+	return reply, err
+}
+
+// Documentation implements part of the Service interface.
+func (p Provenance) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
+	if err := p.checkBuildID(req.GetBuildId()); err != nil {
+		return nil, err
+	}
+	reply, err := p.Service.Documentation(ctx, req)
+	if reply != nil && reply.BuildId == "" {
+		reply.BuildId, reply.BuildTime = p.BuildID, p.BuildTime
+	}
+	return reply, err
+}
+
+// Generations is a Service routing each request to one of several serving
+// table generations by the build ID it selects.  Requests selecting no build
+// ID are served by the latest generation, i.e. the one with the latest build
+// time.  Each generation's replies are stamped with its build ID and time.
+// Graph requests are routed likewise to generations whose Service is also a
+// graph.Service.  Generations is safe for concurrent use.
+type Generations struct {
+	mu   sync.RWMutex
+	gens map[string]*generation
+
+	// reloads counts the generations added, replaced, or removed (see
+	// ReloadGeneration).
+	reloads int64
+
+	// changed, if non-nil, is closed (and cleared) when a generation is added,
+	// replaced, or removed to wake any watches.
+	changed chan struct{}
+}
+
+// A generation is a registered Provenance along with its requests in flight.
+type generation struct {
+	Provenance
+	inflight sync.WaitGroup
+}
+
+// Add registers the given generation.  The generation must have a build ID
+// not already registered; use Replace to swap in a new generation of a
+// registered build ID.
+func (g *Generations) Add(p Provenance) error {
+	if err := checkGeneration(p); err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.gens[p.BuildID]; ok {
+		return fmt.Errorf("generation %q already registered", p.BuildID)
+	}
+	g.addLocked(p)
+	return nil
+}
+
+// Replace registers the given generation, replacing any with the same build
+// ID, and returns the replaced generation's Service, if any.  Like Remove,
+// Replace blocks until the requests already routed to the replaced generation
+// finish, after which its Service may be closed.  Later requests are served
+// by the new generation.
+func (g *Generations) Replace(p Provenance) (Service, bool, error) {
+	if err := checkGeneration(p); err != nil {
+		return nil, false, err
+	}
+	g.mu.Lock()
+	old, ok := g.gens[p.BuildID]
+	g.addLocked(p)
+	g.mu.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+	old.inflight.Wait()
+	return old.Service, true, nil
+}
+
+func checkGeneration(p Provenance) error {
+	if p.BuildID == "" {
+		return errors.New("generation missing build ID")
+	} else if p.Service == nil {
+		return errors.New("generation missing Service")
+	}
+	return nil
+}
+
+// addLocked registers p, replacing any generation with the same build ID.
+// g.mu must be held for writing.
+func (g *Generations) addLocked(p Provenance) {
+	if g.gens == nil {
+		g.gens = make(map[string]*generation)
+	}
+	g.gens[p.BuildID] = &generation{Provenance: p}
+	g.notifyLocked()
+}
+
+// Remove unregisters the generation with the given build ID, returning its
+// Service.  Remove blocks until the requests already routed to the generation
+// finish, after which its Service may be closed.
+func (g *Generations) Remove(buildID string) (Service, bool) {
+	g.mu.Lock()
+	gen, ok := g.gens[buildID]
+	delete(g.gens, buildID)
+	if ok {
+		g.notifyLocked()
+	}
+	g.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	gen.inflight.Wait()
+	return gen.Service, true
+}
+
+// notifyLocked advances the reload generation and wakes the watches waiting
+// for it.  g.mu must be held for writing.
+func (g *Generations) notifyLocked() {
+	g.reloads++
+	if g.changed != nil {
+		close(g.changed)
+		g.changed = nil
+	}
+}
+
+// ReloadGeneration returns the number of times a generation has been added,
+// replaced, or removed.  It changes whenever a serving table is swapped in or out.
+func (g *Generations) ReloadGeneration() int64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.reloads
+}
+
+// BuildIDs returns the build IDs of the registered generations, from the
+// latest to the earliest.
+func (g *Generations) BuildIDs() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	gens := make([]Provenance, 0, len(g.gens))
+	for _, gen := range g.gens {
+		gens = append(gens, gen.Provenance)
+	}
+	sort.Slice(gens, func(i, j int) bool { return laterGeneration(gens[i], gens[j]) })
+	ids := make([]string, len(gens))
+	for i, p := range gens {
+		ids[i] = p.BuildID
+	}
+	return ids
+}
+
+// laterGeneration reports whether a was built after b.  Generations built at
+// the same time are ordered by descending build ID.
+func laterGeneration(a, b Provenance) bool {
+	if at, bt := a.BuildTime.AsTime(), b.BuildTime.AsTime(); !at.Equal(bt) {
+		return at.After(bt)
+	}
+	return a.BuildID > b.BuildID
+}
+
+// acquire returns the generation selected by buildID along with a func that
+// must be called once the generation has served the request.
+func (g *Generations) acquire(buildID string) (Provenance, func(), error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var gen *generation
+	if buildID != "" {
+		gen = g.gens[buildID]
+		if gen == nil {
+			return Provenance{}, nil, status.Errorf(codes.NotFound, "unknown build ID %q", buildID)
+		}
+	} else if gen = g.latestLocked(); gen == nil {
+		return Provenance{}, nil, status.Error(codes.Unavailable, "no serving table generations")
+	}
+	gen.inflight.Add(1)
+	return gen.Provenance, gen.inflight.Done, nil
+}
+
+// latestLocked returns the latest generation, or nil if there are none.  g.mu
+// must be held.
+func (g *Generations) latestLocked() *generation {
+	var latest *generation
+	for _, gen := range g.gens {
+		if latest == nil || laterGeneration(gen.Provenance, latest.Provenance) {
+			latest = gen
+		}
+	}
+	return latest
+}
+
+// watch returns the current reload generation if it is not reloads.
+// Otherwise, it returns a channel closed when the generations are next
+// reloaded.
+func (g *Generations) watch(reloads int64) (int64, <-chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.reloads != reloads {
+		return g.reloads, nil
+	}
+	if g.changed == nil {
+		g.changed = make(chan struct{})
+	}
+	return reloads, g.changed
+}
+
+// A DecorationsWatcher serves the XRefWatchService.
+type DecorationsWatcher interface {
+	// WatchDecorations waits for the serving table generations to be reloaded
+	// and then returns the decorations of a file.
+	WatchDecorations(context.Context, *xpb.WatchDecorationsRequest) (*xpb.WatchDecorationsReply, error)
+}
+
+// MaxWatchTimeout is the longest time a WatchDecorations request waits for
+// the generations to be reloaded.
+const MaxWatchTimeout = time.Minute
+
+// DecorationsWatch is a DecorationsWatcher waiting on the reload generation
+// of a set of Generations.
+type DecorationsWatch struct {
+	Generations *Generations
+
+	// Service serves the decorations of each watch once the generations are
+	// reloaded, e.g. the Generations wrapped by the server's other Services.
+	// If nil, the Generations serve them directly.
+	Service Service
+}
+
+// WatchDecorations implements the DecorationsWatcher interface.  It waits
+// until the reload generation differs from the request's generation and then
+// serves the request's decorations.  If the generations are not reloaded
+// before the request's timeout (capped at MaxWatchTimeout), a reply without
+// decorations is returned.
+func (w *DecorationsWatch) WatchDecorations(ctx context.Context, req *xpb.WatchDecorationsRequest) (*xpb.WatchDecorationsReply, error) {
+	if req.GetDecorations() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing decorations request")
+	} else if req.GetDecorations().GetBuildId() != "" {
+		return nil, status.Error(codes.InvalidArgument, "decorations request may not select a build ID")
+	}
+	timeout := MaxWatchTimeout
+	if d := req.GetTimeout().AsDuration(); req.GetTimeout() != nil && d > 0 && d < timeout {
+		timeout = d
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		reloads, changed := w.Generations.watch(req.GetGeneration())
+		if changed == nil {
+			var s Service = w.Generations
+			if w.Service != nil {
+				s = w.Service
+			}
+			reply, err := s.Decorations(ctx, req.GetDecorations())
+			if err != nil {
+				return nil, err
+			}
+			return &xpb.WatchDecorationsReply{Decorations: reply, Generation: reloads}, nil
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return &xpb.WatchDecorationsReply{Generation: reloads}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Decorations implements part of the Service interface.
+func (g *Generations) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	p, release, err := g.acquire(req.GetBuildId())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.Decorations(ctx, req)
+}
+
+// CrossReferences implements part of the Service interface.
+func (g *Generations) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	p, release, err := g.acquire(req.GetBuildId())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.CrossReferences(ctx, req)
+}
+
+// Documentation implements part of the Service interface.
+func (g *Generations) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
+	p, release, err := g.acquire(req.GetBuildId())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return p.Documentation(ctx, req)
+}
+
+// Nodes implements part of the graph.Service interface.
+func (g *Generations) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	gp, release, err := g.acquireGraph(req.GetBuildId())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return gp.Nodes(ctx, req)
+}
+
+// Edges implements part of the graph.Service interface.
+func (g *Generations) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	gp, release, err := g.acquireGraph(req.GetBuildId())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return gp.Edges(ctx, req)
+}
+
+// acquireGraph returns the graph.Service of the generation selected by
+// buildID, as by acquire, failing if the generation does not serve the graph.
+func (g *Generations) acquireGraph(buildID string) (graph.Provenance, func(), error) {
+	p, release, err := g.acquire(buildID)
+	if err != nil {
+		return graph.Provenance{}, nil, err
+	}
+	gs, ok := p.Service.(graph.Service)
+	if !ok {
+		release()
+		return graph.Provenance{}, nil, status.Errorf(codes.Unimplemented, "build %q does not serve the graph", p.BuildID)
+	}
+	return graph.Provenance{BuildID: p.BuildID, BuildTime: p.BuildTime, Service: gs}, release, nil
+}
+
 type webClient struct{ addr string }
 
 // Decorations implements part of the Service interface.
@@ -297,7 +705,10 @@ func WebClient(addr string) Service {
 //	  Response: JSON encoded xrefs.DocumentationReply
 //
 // Note: /nodes, /edges, /decorations, and /xrefs will return their responses as
-// serialized protobufs if the "proto" query parameter is set.
+// serialized protobufs if the "proto" query parameter is set.  If the request's
+// Accept header includes web.DelimitedProtoType, /xrefs instead streams its
+// response as length-delimited partial CrossReferencesReplys (see
+// writeCrossReferencesStream).
 func RegisterHTTPHandlers(ctx context.Context, xs Service, mux *http.ServeMux) {
 	mux.HandleFunc("/xrefs", func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -315,7 +726,12 @@ func RegisterHTTPHandlers(ctx context.Context, xs Service, mux *http.ServeMux) {
 			return
 		}
 
-		if err := web.WriteResponse(w, r, reply); err != nil {
+		if web.AcceptsDelimitedProto(r) {
+			err = writeCrossReferencesStream(w, r, reply)
+		} else {
+			err = web.WriteResponse(w, r, reply)
+		}
+		if err != nil {
 			log.Println(err)
 		}
 	})
@@ -361,6 +777,60 @@ func RegisterHTTPHandlers(ctx context.Context, xs Service, mux *http.ServeMux) {
 	})
 }
 
+// RegisterWatchHTTPHandlers registers a JSON HTTP handler with mux using the
+// given DecorationsWatcher.  The following method will be exposed:
+//
+//	GET /decorations/watch
+//	  Request: JSON encoded xrefs.WatchDecorationsRequest
+//	  Response: JSON encoded xrefs.WatchDecorationsReply
+func RegisterWatchHTTPHandlers(ctx context.Context, dw DecorationsWatcher, mux *http.ServeMux) {
+	mux.HandleFunc("/decorations/watch", func(w http.ResponseWriter, r *http.Request) {
+		var req xpb.WatchDecorationsRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Stop watching once the client goes away.
+		reply, err := dw.WatchDecorations(r.Context(), &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Println(err)
+		}
+	})
+}
+
+// writeCrossReferencesStream writes reply to w as a stream of length-delimited
+// CrossReferencesReplys: one per CrossReferenceSet, in ticket order, followed
+// by the reply's remaining fields.  Each message is flushed as it is written.
+// Merging the messages in order (e.g. with proto.Merge) yields the original
+// reply.
+func writeCrossReferencesStream(w http.ResponseWriter, r *http.Request, reply *xpb.CrossReferencesReply) error {
+	dw := web.NewDelimitedProtoWriter(w, r)
+	sets := reply.CrossReferences
+	tickets := make([]string, 0, len(sets))
+	for ticket := range sets {
+		tickets = append(tickets, ticket)
+	}
+	sort.Strings(tickets)
+	for _, ticket := range tickets {
+		if err := dw.Write(&xpb.CrossReferencesReply{CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{ticket: sets[ticket]}}); err != nil {
+			dw.Close()
+			return err
+		}
+	}
+	reply.CrossReferences = nil
+	defer func() { reply.CrossReferences = sets }()
+	if err := dw.Write(reply); err != nil {
+		dw.Close()
+		return err
+	}
+	return dw.Close()
+}
+
 // ByName orders a slice of facts by their fact names.
 type ByName []*cpb.Fact
 
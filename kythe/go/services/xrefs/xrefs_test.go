@@ -17,10 +17,30 @@
 package xrefs
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
+	"kythe.io/kythe/go/services/web"
+	"kythe.io/kythe/go/util/schema/edges"
 	"kythe.io/kythe/go/util/schema/facts"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
 )
 
 func TestFilterRegexp(t *testing.T) {
@@ -54,3 +74,430 @@ func TestFilterRegexp(t *testing.T) {
 		}
 	}
 }
+
+func TestClassifyAnchor(t *testing.T) {
+	tests := []struct {
+		kind       string
+		incomplete bool
+		want       xpb.Anchor_Classification
+	}{
+		{edges.Defines, false, xpb.Anchor_DEFINITION},
+		{edges.DefinesBinding, false, xpb.Anchor_BINDING},
+		{edges.Defines + "/implicit", false, xpb.Anchor_DEFINITION},
+		{edges.Defines, true, xpb.Anchor_DECLARATION},
+		{edges.DefinesBinding, true, xpb.Anchor_DECLARATION},
+		{internalDeclarationKind, false, xpb.Anchor_DECLARATION},
+		{edges.Completes, false, xpb.Anchor_COMPLETION},
+		{edges.CompletesUniquely, true, xpb.Anchor_COMPLETION},
+		{edges.Ref, false, xpb.Anchor_REFERENCE},
+		{edges.RefImports, false, xpb.Anchor_REFERENCE},
+		{edges.RefCall, false, xpb.Anchor_CALL},
+		{edges.RefCallImplicit, false, xpb.Anchor_CALL},
+		{internalCallerKindDirect, false, xpb.Anchor_CALL},
+		{internalCallerKindOverride, false, xpb.Anchor_CALL},
+		{edges.Documents, false, xpb.Anchor_DOC},
+		{edges.Mirror(edges.Ref), false, xpb.Anchor_REFERENCE},
+		{edges.ChildOf, false, xpb.Anchor_UNCLASSIFIED},
+		{"", false, xpb.Anchor_UNCLASSIFIED},
+	}
+
+	for _, test := range tests {
+		if got := ClassifyAnchor(test.kind, test.incomplete); got != test.want {
+			t.Errorf("ClassifyAnchor(%q, %v): got %v; want %v", test.kind, test.incomplete, got, test.want)
+		}
+	}
+}
+
+type staticService struct {
+	Service
+	xrefs *xpb.CrossReferencesReply
+	decor *xpb.DecorationsReply
+}
+
+func (s staticService) CrossReferences(context.Context, *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	return s.xrefs, nil
+}
+
+func (s staticService) Decorations(context.Context, *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	return proto.Clone(s.decor).(*xpb.DecorationsReply), nil
+}
+
+// graphService is a staticService that also serves empty graph replies.
+type graphService struct{ staticService }
+
+func (graphService) Nodes(context.Context, *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	return &gpb.NodesReply{}, nil
+}
+
+func (graphService) Edges(context.Context, *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	return &gpb.EdgesReply{}, nil
+}
+
+// blockingService signals entered on each CrossReferences request and then
+// blocks it until release is closed.
+type blockingService struct {
+	Service
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s blockingService) CrossReferences(context.Context, *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	s.entered <- struct{}{}
+	<-s.release
+	return &xpb.CrossReferencesReply{}, nil
+}
+
+func TestCrossReferencesDelimitedStream(t *testing.T) {
+	expected := &xpb.CrossReferencesReply{
+		CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+			"kythe:#a": {Ticket: "kythe:#a"},
+			"kythe:#b": {Ticket: "kythe:#b"},
+		},
+		Nodes:         map[string]*cpb.NodeInfo{"kythe:#a": {}},
+		NextPageToken: "next",
+	}
+	mux := http.NewServeMux()
+	RegisterHTTPHandlers(context.Background(), staticService{xrefs: proto.Clone(expected).(*xpb.CrossReferencesReply)}, mux)
+
+	req := httptest.NewRequest("GET", "/xrefs", nil)
+	req.Header.Set("Accept", "text/html, "+web.DelimitedProtoType)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if ct := rec.Header().Get("Content-Type"); ct != web.DelimitedProtoType {
+		t.Fatalf("Unexpected Content-Type: %q", ct)
+	}
+
+	found := &xpb.CrossReferencesReply{}
+	var msgs int
+	r := bufio.NewReader(rec.Body)
+	for {
+		var msg xpb.CrossReferencesReply
+		if err := web.ReadDelimitedProto(r, &msg); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("ReadDelimitedProto error: %v", err)
+		}
+		proto.Merge(found, &msg)
+		msgs++
+	}
+	if msgs != 3 {
+		t.Errorf("Expected 3 messages; found %d", msgs)
+	}
+	if !proto.Equal(expected, found) {
+		t.Errorf("Expected %v; found %v", expected, found)
+	}
+}
+
+func TestProvenance(t *testing.T) {
+	ctx := context.Background()
+	buildTime := timestamppb.New(time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC))
+	p := Provenance{
+		BuildID:   "build",
+		BuildTime: buildTime,
+		Service:   staticService{xrefs: &xpb.CrossReferencesReply{}},
+	}
+	reply, err := p.CrossReferences(ctx, &xpb.CrossReferencesRequest{})
+	if err != nil {
+		t.Fatalf("CrossReferences error: %v", err)
+	}
+	if reply.BuildId != "build" || !proto.Equal(reply.BuildTime, buildTime) {
+		t.Errorf("Expected reply stamped with build %q at %v; found %q at %v", "build", buildTime, reply.BuildId, reply.BuildTime)
+	}
+
+	// Requests selecting another build are not served.
+	if _, err := p.CrossReferences(ctx, &xpb.CrossReferencesRequest{BuildId: "other"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error for another build ID; found %v", err)
+	}
+	if _, err := p.CrossReferences(ctx, &xpb.CrossReferencesRequest{BuildId: "build"}); err != nil {
+		t.Errorf("CrossReferences error for own build ID: %v", err)
+	}
+
+	// Build IDs set by the underlying Service are kept.
+	p.Service = staticService{xrefs: &xpb.CrossReferencesReply{BuildId: "other"}}
+	reply, err = p.CrossReferences(ctx, &xpb.CrossReferencesRequest{})
+	if err != nil {
+		t.Fatalf("CrossReferences error: %v", err)
+	}
+	if reply.BuildId != "other" || reply.BuildTime != nil {
+		t.Errorf("Expected build %q to be kept; found %q at %v", "other", reply.BuildId, reply.BuildTime)
+	}
+}
+
+func TestGenerations(t *testing.T) {
+	ctx := context.Background()
+	older := timestamppb.New(time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC))
+	newer := timestamppb.New(time.Date(2023, 4, 6, 0, 0, 0, 0, time.UTC))
+	gen := func(id string, buildTime *timestamppb.Timestamp) Provenance {
+		return Provenance{
+			BuildID:   id,
+			BuildTime: buildTime,
+			Service:   staticService{xrefs: &xpb.CrossReferencesReply{}},
+		}
+	}
+
+	var g Generations
+	if _, err := g.CrossReferences(ctx, &xpb.CrossReferencesRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable error without generations; found %v", err)
+	}
+	for _, p := range []Provenance{gen("release", older), gen("head", newer)} {
+		if err := g.Add(p); err != nil {
+			t.Fatalf("Add(%q) error: %v", p.BuildID, err)
+		}
+	}
+	if err := g.Add(gen("", newer)); err == nil {
+		t.Error("Expected error adding generation without a build ID")
+	}
+	if ids := g.BuildIDs(); !reflect.DeepEqual(ids, []string{"head", "release"}) {
+		t.Errorf("Expected build IDs [head release]; found %v", ids)
+	}
+
+	tests := []struct {
+		buildID, expected string
+	}{
+		{"", "head"},
+		{"head", "head"},
+		{"release", "release"},
+	}
+	for _, test := range tests {
+		reply, err := g.CrossReferences(ctx, &xpb.CrossReferencesRequest{BuildId: test.buildID})
+		if err != nil {
+			t.Fatalf("CrossReferences(%q) error: %v", test.buildID, err)
+		}
+		if reply.BuildId != test.expected {
+			t.Errorf("CrossReferences(%q) served by %q; expected %q", test.buildID, reply.BuildId, test.expected)
+		}
+	}
+	if _, err := g.CrossReferences(ctx, &xpb.CrossReferencesRequest{BuildId: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error for unknown build ID; found %v", err)
+	}
+
+	// Removing the latest generation falls back to the next latest.
+	if _, ok := g.Remove("head"); !ok {
+		t.Error("Expected to remove generation \"head\"")
+	}
+	reply, err := g.CrossReferences(ctx, &xpb.CrossReferencesRequest{})
+	if err != nil {
+		t.Fatalf("CrossReferences error: %v", err)
+	}
+	if reply.BuildId != "release" {
+		t.Errorf("Expected latest generation %q; found %q", "release", reply.BuildId)
+	}
+}
+
+func TestGenerationsGraph(t *testing.T) {
+	ctx := context.Background()
+	var g Generations
+	if err := g.Add(Provenance{
+		BuildID:   "release",
+		BuildTime: timestamppb.New(time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC)),
+		Service:   graphService{},
+	}); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+	if err := g.Add(Provenance{
+		BuildID:   "head",
+		BuildTime: timestamppb.New(time.Date(2023, 4, 6, 0, 0, 0, 0, time.UTC)),
+		Service:   staticService{},
+	}); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+
+	nodes, err := g.Nodes(ctx, &gpb.NodesRequest{BuildId: "release"})
+	if err != nil {
+		t.Fatalf("Nodes error: %v", err)
+	} else if nodes.BuildId != "release" {
+		t.Errorf("Nodes served by %q; expected %q", nodes.BuildId, "release")
+	}
+	edges, err := g.Edges(ctx, &gpb.EdgesRequest{BuildId: "release"})
+	if err != nil {
+		t.Fatalf("Edges error: %v", err)
+	} else if edges.BuildId != "release" {
+		t.Errorf("Edges served by %q; expected %q", edges.BuildId, "release")
+	}
+
+	// The latest generation does not serve the graph.
+	if _, err := g.Edges(ctx, &gpb.EdgesRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected Unimplemented error from generation without graph; found %v", err)
+	}
+	if _, err := g.Nodes(ctx, &gpb.NodesRequest{BuildId: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error for unknown build ID; found %v", err)
+	}
+}
+
+func TestGenerationsRemoveDrains(t *testing.T) {
+	ctx := context.Background()
+	s := blockingService{entered: make(chan struct{}), release: make(chan struct{})}
+	var g Generations
+	if err := g.Add(Provenance{BuildID: "build", Service: s}); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+
+	served := make(chan error)
+	go func() {
+		_, err := g.CrossReferences(ctx, &xpb.CrossReferencesRequest{})
+		served <- err
+	}()
+	<-s.entered
+
+	removed := make(chan bool)
+	go func() {
+		_, ok := g.Remove("build")
+		removed <- ok
+	}()
+	select {
+	case <-removed:
+		t.Fatal("Remove returned before the request in flight finished")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(s.release)
+	if err := <-served; err != nil {
+		t.Errorf("CrossReferences error: %v", err)
+	}
+	if ok := <-removed; !ok {
+		t.Error("Expected to remove generation \"build\"")
+	}
+	if _, err := g.CrossReferences(ctx, &xpb.CrossReferencesRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable error after removal; found %v", err)
+	}
+}
+
+func TestGenerationsReplace(t *testing.T) {
+	ctx := context.Background()
+	s := blockingService{entered: make(chan struct{}), release: make(chan struct{})}
+	var g Generations
+	if err := g.Add(Provenance{BuildID: "build", Service: s}); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+	rebuilt := staticService{xrefs: &xpb.CrossReferencesReply{NextPageToken: "rebuilt"}}
+	if err := g.Add(Provenance{BuildID: "build", Service: rebuilt}); err == nil {
+		t.Error("Expected error adding generation with a registered build ID")
+	}
+
+	served := make(chan error)
+	go func() {
+		_, err := g.CrossReferences(ctx, &xpb.CrossReferencesRequest{})
+		served <- err
+	}()
+	<-s.entered
+
+	type replacement struct {
+		s  Service
+		ok bool
+	}
+	replaced := make(chan replacement)
+	go func() {
+		old, ok, err := g.Replace(Provenance{BuildID: "build", Service: rebuilt})
+		if err != nil {
+			t.Errorf("Replace error: %v", err)
+		}
+		replaced <- replacement{old, ok}
+	}()
+	select {
+	case <-replaced:
+		t.Fatal("Replace returned before the request in flight finished")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// The new generation serves requests while the old one drains.
+	reply, err := g.CrossReferences(ctx, &xpb.CrossReferencesRequest{BuildId: "build"})
+	if err != nil {
+		t.Fatalf("CrossReferences error: %v", err)
+	} else if reply.NextPageToken != "rebuilt" {
+		t.Errorf("Expected request served by the new generation; found %v", reply)
+	}
+
+	close(s.release)
+	if err := <-served; err != nil {
+		t.Errorf("CrossReferences error: %v", err)
+	}
+	if r := <-replaced; !r.ok || r.s != s {
+		t.Errorf("Expected Replace to return the old generation; found %v, %v", r.s, r.ok)
+	}
+	if ids := g.BuildIDs(); !reflect.DeepEqual(ids, []string{"build"}) {
+		t.Errorf("Expected build IDs [build]; found %v", ids)
+	}
+
+	if _, ok, err := g.Replace(Provenance{BuildID: "other", Service: rebuilt}); err != nil || ok {
+		t.Errorf("Replace of unregistered build ID: %v, %v; expected false, nil", ok, err)
+	}
+	if _, _, err := g.Replace(Provenance{Service: rebuilt}); err == nil {
+		t.Error("Expected error replacing generation without a build ID")
+	}
+}
+
+func TestGenerationsWatchDecorations(t *testing.T) {
+	ctx := context.Background()
+	gen := func(id string, buildTime time.Time) Provenance {
+		return Provenance{
+			BuildID:   id,
+			BuildTime: timestamppb.New(buildTime),
+			Service:   staticService{decor: &xpb.DecorationsReply{}},
+		}
+	}
+
+	var g Generations
+	dw := &DecorationsWatch{Generations: &g}
+	watch := func(generation int64, timeout time.Duration) (*xpb.WatchDecorationsReply, error) {
+		return dw.WatchDecorations(ctx, &xpb.WatchDecorationsRequest{
+			Decorations: &xpb.DecorationsRequest{},
+			Generation:  generation,
+			Timeout:     durationpb.New(timeout),
+		})
+	}
+
+	if err := g.Add(gen("release", time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC))); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+	// A caller without a known generation is served immediately.
+	reply, err := watch(0, time.Minute)
+	if err != nil {
+		t.Fatalf("WatchDecorations error: %v", err)
+	} else if id := reply.GetDecorations().GetBuildId(); id != "release" {
+		t.Errorf("Expected decorations served by %q; found %q", "release", id)
+	} else if reply.Generation != g.ReloadGeneration() {
+		t.Errorf("Expected reload generation %d; found %d", g.ReloadGeneration(), reply.Generation)
+	}
+	known := reply.Generation
+
+	// Without a reload, the watch times out with a reply without decorations.
+	if reply, err := watch(known, time.Millisecond); err != nil {
+		t.Fatalf("WatchDecorations error: %v", err)
+	} else if reply.Decorations != nil || reply.Generation != known {
+		t.Errorf("Expected reply of generation %d without decorations after timeout; found %v", known, reply)
+	}
+
+	type result struct {
+		reply *xpb.WatchDecorationsReply
+		err   error
+	}
+	done := make(chan result)
+	go func() {
+		reply, err := watch(known, time.Minute)
+		done <- result{reply, err}
+	}()
+	// Reloading the latest generation under the same build ID is a reload.
+	if _, _, err := g.Replace(gen("release", time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC))); err != nil {
+		t.Fatalf("Replace error: %v", err)
+	}
+	if res := <-done; res.err != nil {
+		t.Fatalf("WatchDecorations error: %v", res.err)
+	} else if id := res.reply.GetDecorations().GetBuildId(); id != "release" {
+		t.Errorf("Expected decorations served by %q; found %q", "release", id)
+	} else if res.reply.Generation == known {
+		t.Errorf("Expected reload generation after %d; found %d", known, res.reply.Generation)
+	}
+
+	// Decorations are served through the watch's Service, if any.
+	dw.Service = staticService{decor: &xpb.DecorationsReply{BuildId: "wrapped"}}
+	if reply, err := watch(known, time.Minute); err != nil {
+		t.Fatalf("WatchDecorations error: %v", err)
+	} else if id := reply.GetDecorations().GetBuildId(); id != "wrapped" {
+		t.Errorf("Expected decorations served by %q; found %q", "wrapped", id)
+	}
+
+	if _, err := dw.WatchDecorations(ctx, &xpb.WatchDecorationsRequest{
+		Decorations: &xpb.DecorationsRequest{BuildId: "release"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for pinned decorations request; found %v", err)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	ftsrv "kythe.io/kythe/go/serving/filetree"
 	gsrv "kythe.io/kythe/go/serving/graph"
 	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/serving/manifest"
 	xsrv "kythe.io/kythe/go/serving/xrefs"
 	"kythe.io/kythe/go/storage/leveldb"
 	"kythe.io/kythe/go/storage/table"
@@ -92,6 +93,14 @@ func ParseSpec(apiSpec string) (Interface, error) {
 		ctx := context.Background()
 		api.xs = xsrv.NewService(ctx, db)
 		api.gs = gsrv.NewService(ctx, db)
+		md, err := manifest.ReadMetadata(ctx, db)
+		if err != nil && err != manifest.ErrNoMetadata {
+			db.Close(ctx)
+			return nil, fmt.Errorf("error reading metadata of local DB at %q: %v", apiSpec, err)
+		}
+		// Requests selecting a build other than the DB's fail with NOT_FOUND.
+		api.xs = xrefs.Provenance{BuildID: md.GetBuildId(), BuildTime: md.GetBuildTime(), Service: api.xs}
+		api.gs = graph.Provenance{BuildID: md.GetBuildId(), BuildTime: md.GetBuildTime(), Service: api.gs}
 		tbl := &table.KVProto{db}
 		api.ft = &ftsrv.Table{tbl, true}
 		api.id = &identifiers.Table{tbl}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	gsrv "kythe.io/kythe/go/serving/graph"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/storage/leveldb"
+	"kythe.io/kythe/go/storage/table"
+)
+
+// Cache capacity divisors of the tables of a SplitTableDir.  Point lookup
+// tables get the full cache capacity, page tables (each page being read once
+// per request) get less, and small auxiliary tables get the least.
+const (
+	lookupCache    = 1
+	pageCache      = 4
+	auxiliaryCache = 16
+)
+
+// splitDirTables are the subdirectories of a SplitTableDir, each named after
+// its table's CombinedTable key prefix and holding the LevelDB database of a
+// single graph or xrefs SplitTable field.  If any table of a group is present,
+// each required table of the group must be.  This is the only definition of
+// the split table layout: OpenServingTable and the split/combined table
+// conversions (via SplitTableDir.DBs) read and write it through
+// OpenSplitTableDir and CreateSplitTableDir.
+var splitDirTables = []struct {
+	name     string
+	group    string
+	required bool
+	cache    int
+	set      func(*SplitTableDir, keyvalue.DB)
+	split    func(*xsrv.SplitDBs) *keyvalue.DB
+}{
+	{"edges", "graph", true, lookupCache, func(d *SplitTableDir, db keyvalue.DB) { d.Graph.Edges = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.Edges }},
+	{"edgePages", "graph", true, pageCache, func(d *SplitTableDir, db keyvalue.DB) { d.Graph.EdgePages = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.EdgePages }},
+	{"ticketIndex", "graph", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.Graph.TicketIndex = db },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.TicketIndex }},
+	{"factHashes", "graph", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.Graph.FactHashes = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.FactHashes }},
+	{"decor", "xrefs", true, lookupCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.Decorations = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.Decorations }},
+	{"decorPages", "xrefs", false, pageCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.DecorationPages = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.DecorationPages }},
+	{"decorFilter", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.DecorationsFilter = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.DecorationsFilter }},
+	{"xrefs", "xrefs", true, lookupCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.CrossReferences = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.CrossReferences }},
+	{"xrefPages", "xrefs", true, pageCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.CrossReferencePages = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.CrossReferencePages }},
+	{"docs", "xrefs", true, lookupCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.Documentation = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.Documentation }},
+	{"names", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.DisplayNames = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.DisplayNames }},
+	{"digests", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.FileDigests = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.FileDigests }},
+	{"fileRefs", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.FileReferences = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.FileReferences }},
+	{"fileRelations", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.FileRelations = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.FileRelations }},
+	{"callDegrees", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.CallDegrees = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.CallDegrees }},
+	{"members", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.ContainerMembers = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.ContainerMembers }},
+	{"rollups", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.ContainerRollups = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.ContainerRollups }},
+	{"outline", "xrefs", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) { d.XRefs.FileOutlines = &table.KVProto{db} },
+		func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.FileOutlines }},
+	// Ticket aliases are shared by the graph and xrefs tables.
+	{"aliases", "", false, auxiliaryCache, func(d *SplitTableDir, db keyvalue.DB) {
+		if d.Graph != nil {
+			d.Graph.TicketAliases = &table.KVProto{db}
+		}
+		if d.XRefs != nil {
+			d.XRefs.TicketAliases = &table.KVProto{db}
+		}
+	}, func(s *xsrv.SplitDBs) *keyvalue.DB { return &s.TicketAliases }},
+}
+
+// isSplitTableDir reports whether the given path is a directory holding any of
+// the tables of a SplitTableDir.
+func isSplitTableDir(path string) bool {
+	for _, t := range splitDirTables {
+		if isDir(filepath.Join(path, t.name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// A SplitTableDir is a set of split serving tables opened from a directory
+// holding a LevelDB database per table kind (see OpenSplitTableDir).
+type SplitTableDir struct {
+	// Graph holds the graph serving tables, or is nil if the directory has no
+	// graph tables.  It is served by gsrv.NewSplitTable.
+	Graph *gsrv.SplitTable
+
+	// XRefs holds the xrefs serving tables, or is nil if the directory has no
+	// xrefs tables.  It is served by xsrv.NewSplitTable.
+	XRefs *xsrv.SplitTable
+
+	dbs    []keyvalue.DB
+	byName map[string]keyvalue.DB
+}
+
+// DBs returns the databases underlying d's tables, e.g. for conversion to or
+// from a combined table with xsrv.SplitToCombined or xsrv.CombinedToSplit.
+// Databases of absent tables are nil.
+func (d *SplitTableDir) DBs() *xsrv.SplitDBs {
+	dbs := &xsrv.SplitDBs{}
+	for _, t := range splitDirTables {
+		if db := d.byName[t.name]; db != nil && t.split != nil {
+			*t.split(dbs) = db
+		}
+	}
+	return dbs
+}
+
+// OpenSplitTableDir opens the split serving tables under the given directory.
+// Each table is a LevelDB database in a subdirectory named after the table's
+// CombinedTable key prefix: "edges" and "edgePages" for the graph tables and
+// "decor", "xrefs", "xrefPages", and "docs" for the xrefs tables, along with
+// the optional tables of each (e.g. "decorPages", "names", or "aliases").
+// Each database's cache capacity is a share of the CacheCapacity Option (or
+// of the LevelDB default) befitting its table: point lookup tables get the
+// full capacity and page and auxiliary tables get less.  CacheCapacity is the
+// only supported Option.
+func OpenSplitTableDir(path string, opts ...Option) (*SplitTableDir, error) {
+	capacity, err := splitDirCacheCapacity(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[string]bool)
+	groups := make(map[string]bool)
+	for _, t := range splitDirTables {
+		if isDir(filepath.Join(path, t.name)) {
+			present[t.name] = true
+			groups[t.group] = true
+		}
+	}
+	if !groups["graph"] && !groups["xrefs"] {
+		return nil, fmt.Errorf("no split serving tables found at %q", path)
+	}
+	for _, t := range splitDirTables {
+		if t.required && groups[t.group] && !present[t.name] {
+			return nil, fmt.Errorf("split serving table at %q missing %q table", path, t.name)
+		}
+	}
+	return openSplitTableDir(path, present, groups, capacity, true)
+}
+
+// CreateSplitTableDir creates (or opens, if they exist) every table of a
+// SplitTableDir under the given directory, e.g. as the destination of a
+// conversion from a combined table.  CacheCapacity is the only supported
+// Option.
+func CreateSplitTableDir(path string, opts ...Option) (*SplitTableDir, error) {
+	capacity, err := splitDirCacheCapacity(opts)
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[string]bool)
+	for _, t := range splitDirTables {
+		present[t.name] = true
+	}
+	return openSplitTableDir(path, present, map[string]bool{"graph": true, "xrefs": true}, capacity, false)
+}
+
+func splitDirCacheCapacity(opts []Option) (int, error) {
+	capacity := leveldb.DefaultOptions.CacheCapacity
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case cacheCapacity:
+			capacity = int(opt)
+		default:
+			return 0, fmt.Errorf("unsupported Option type: %T", opt)
+		}
+	}
+	return capacity, nil
+}
+
+// openSplitTableDir opens the given tables under path, populating the tables
+// of the given groups.
+func openSplitTableDir(path string, tables, groups map[string]bool, capacity int, mustExist bool) (*SplitTableDir, error) {
+	d := &SplitTableDir{byName: make(map[string]keyvalue.DB)}
+	if groups["graph"] {
+		d.Graph = &gsrv.SplitTable{}
+	}
+	if groups["xrefs"] {
+		d.XRefs = &xsrv.SplitTable{}
+	}
+	ctx := context.Background()
+	for _, t := range splitDirTables {
+		if !tables[t.name] {
+			continue
+		}
+		dir := filepath.Join(path, t.name)
+		dbOpts := *leveldb.DefaultOptions
+		dbOpts.MustExist = mustExist
+		dbOpts.CacheCapacity = capacity / t.cache
+		db, err := leveldb.Open(dir, &dbOpts)
+		if err != nil {
+			d.Close(ctx)
+			return nil, fmt.Errorf("error opening %q table at %q: %v", t.name, dir, err)
+		}
+		d.dbs = append(d.dbs, db)
+		d.byName[t.name] = db
+		t.set(d, db)
+	}
+	return d, nil
+}
+
+// Close releases the underlying LevelDB databases.
+func (d *SplitTableDir) Close(ctx context.Context) error {
+	var firstErr error
+	for _, db := range d.dbs {
+		if err := db.Close(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/test/testutil"
+)
+
+func TestOpenSplitTableDir(t *testing.T) {
+	dir := t.TempDir()
+	d, err := CreateSplitTableDir(dir)
+	testutil.Fatalf(t, "CreateSplitTableDir error: %v", err)
+	testutil.Fatalf(t, "Close error: %v", d.Close(ctx))
+
+	if !isSplitTableDir(dir) {
+		t.Fatalf("isSplitTableDir(%q) = false", dir)
+	}
+	d, err = OpenSplitTableDir(dir, CacheCapacity(1<<20))
+	testutil.Fatalf(t, "OpenSplitTableDir error: %v", err)
+	defer d.Close(ctx)
+
+	if d.Graph == nil || d.XRefs == nil {
+		t.Fatalf("Missing tables: graph %v; xrefs %v", d.Graph, d.XRefs)
+	}
+	if d.Graph.Edges == nil || d.Graph.EdgePages == nil || d.Graph.TicketAliases == nil {
+		t.Errorf("Missing graph tables: %+v", d.Graph)
+	}
+	if d.XRefs.Decorations == nil || d.XRefs.CrossReferences == nil || d.XRefs.FileOutlines == nil || d.XRefs.TicketAliases == nil {
+		t.Errorf("Missing xrefs tables: %+v", d.XRefs)
+	}
+	dbs := d.DBs()
+	if dbs.Edges == nil || dbs.CrossReferencePages == nil || dbs.TicketAliases == nil {
+		t.Errorf("Missing databases: %+v", dbs)
+	}
+	if len(d.dbs) != len(splitDirTables) {
+		t.Errorf("Opened %d databases; expected %d", len(d.dbs), len(splitDirTables))
+	}
+}
+
+func TestOpenSplitTableDirPartial(t *testing.T) {
+	// A directory may hold only the xrefs tables and some of their optional
+	// tables.
+	dir := t.TempDir()
+	createSplitTables(t, dir, append(xrefsTables, "names", "aliases")...)
+
+	d, err := OpenSplitTableDir(dir)
+	testutil.Fatalf(t, "OpenSplitTableDir error: %v", err)
+	defer d.Close(ctx)
+
+	if d.Graph != nil {
+		t.Errorf("Unexpected graph tables: %+v", d.Graph)
+	}
+	if d.XRefs == nil {
+		t.Fatal("Missing xrefs tables")
+	}
+	if d.XRefs.CrossReferencePages == nil || d.XRefs.DisplayNames == nil || d.XRefs.TicketAliases == nil {
+		t.Errorf("Missing xrefs tables: %+v", d.XRefs)
+	}
+	if d.XRefs.DecorationPages != nil || d.XRefs.FileDigests != nil || d.XRefs.FileOutlines != nil {
+		t.Errorf("Unexpected xrefs tables: %+v", d.XRefs)
+	}
+	if dbs := d.DBs(); dbs.Edges != nil || dbs.FileDigests != nil || dbs.DisplayNames == nil {
+		t.Errorf("Unexpected databases: %+v", dbs)
+	}
+}
+
+func TestOpenSplitTableDirErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tables []string
+		opts   []Option
+		err    string
+	}{
+		{"empty", nil, nil, "no split serving tables found"},
+		{"missing_graph_table", append([]string{"edges"}, xrefsTables...), nil, `missing "edgePages" table`},
+		{"missing_xrefs_table", []string{"decor", "names"}, nil, `missing "xrefs" table`},
+		{"unsupported_option", xrefsTables, []Option{VerifyManifest(FailFast)}, "unsupported Option type"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			createSplitTables(t, dir, test.tables...)
+			d, err := OpenSplitTableDir(dir, test.opts...)
+			if err == nil {
+				d.Close(ctx)
+				t.Fatalf("OpenSplitTableDir succeeded; expected error containing %q", test.err)
+			} else if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("OpenSplitTableDir error: %v; expected error containing %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestOpenSplitTableDirFallback(t *testing.T) {
+	// A directory holding a single combined table is not a split table
+	// directory and OpenServingTable opens it as a single table.
+	dir := t.TempDir()
+	createDB(t, dir, "xrefs:ticket", "")
+
+	if isSplitTableDir(dir) {
+		t.Fatalf("isSplitTableDir(%q) = true", dir)
+	}
+	if d, err := OpenSplitTableDir(dir); err == nil {
+		d.Close(ctx)
+		t.Fatal("OpenSplitTableDir succeeded on a combined table")
+	}
+
+	tbl, err := OpenServingTable(dir)
+	testutil.Fatalf(t, "OpenServingTable error: %v", err)
+	defer tbl.Close(ctx)
+	st, ok := tbl.(*servingTable)
+	if !ok {
+		t.Fatalf("OpenServingTable returned %T", tbl)
+	} else if len(st.dbs) != 1 {
+		t.Errorf("Opened %d databases; expected a single table", len(st.dbs))
+	}
+}
@@ -0,0 +1,350 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/xrefs"
+	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/manifest"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/storage/leveldb"
+	"kythe.io/kythe/go/util/log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// columnarFormatVersion is the only supported value of the
+// xsrv.ColumnarTableKeyMarker key in a columnar serving table.
+const columnarFormatVersion = "v1"
+
+// A ServingTable is an xrefs.Service backed by an opened serving table.  It
+// is also a graph.Service whose requests fail with UNIMPLEMENTED if the table
+// holds no graph tables.
+type ServingTable interface {
+	xrefs.Service
+	graph.Service
+
+	// Close releases the underlying LevelDB databases.
+	Close(context.Context) error
+
+	// Verify checks each of the underlying LevelDB databases against the
+	// manifest written when it was built (see the manifest package), returning
+	// the first failure.  Verify reads every database in full; it is safe to
+	// call while serving, e.g. periodically from a background goroutine.
+	Verify(context.Context) error
+
+	// Degraded returns the failure of the table's most recent verification, if
+	// any, or nil if the table has not failed verification.
+	Degraded() error
+
+	// Metadata returns the metadata describing the table's build (see
+	// manifest.ReadMetadata), or nil if the table has none.  Each reply served
+	// by a table with metadata carries its build ID and build time.
+	Metadata() *srvpb.TableMetadata
+
+	// ListKinds returns the summary of the node and edge kinds of the graph
+	// from which the table was built (see manifest.ReadKinds).  It fails with
+	// NOT_FOUND if the table has no summary.  Each generation of a table has
+	// its own summary, read when the table is opened.
+	ListKinds(context.Context) (*srvpb.KindsSummary, error)
+}
+
+// An Option configures the behavior of OpenServingTable.
+type Option interface{ isOption() }
+
+type cacheCapacity int
+
+func (cacheCapacity) isOption() {}
+
+// CacheCapacity returns an Option that sets the LevelDB cache capacity, in
+// bytes, of each opened serving table.
+func CacheCapacity(bytes int) Option { return cacheCapacity(bytes) }
+
+// A VerifyPolicy determines how OpenServingTable handles a serving table
+// failing verification against its manifest.
+type VerifyPolicy int
+
+// Supported VerifyPolicy values.
+const (
+	// FailFast fails to open a table failing verification.
+	FailFast VerifyPolicy = iota
+
+	// ServeDegraded opens a table failing verification, logging the failure
+	// and reporting it from the table's Degraded method.
+	ServeDegraded
+)
+
+func (VerifyPolicy) isOption() {}
+
+// VerifyManifest returns an Option that verifies each opened serving table
+// against its manifest before serving it, handling failures with the given
+// policy.  Verification reads the entire table and fails for tables without
+// a manifest.
+func VerifyManifest(policy VerifyPolicy) Option { return policy }
+
+// OpenServingTable opens the serving table at the given path and returns an
+// xrefs.Service backed by it.  The path is either a single LevelDB combined
+// (or columnar) serving table or a directory of split serving tables laid out
+// as read by OpenSplitTableDir, which must hold the xrefs tables, but not
+// both.  A columnar table must be of a supported format version.
+func OpenServingTable(path string, opts ...Option) (ServingTable, error) {
+	dbOpts := *leveldb.DefaultOptions
+	dbOpts.MustExist = true
+	var verify *VerifyPolicy
+	var splitOpts []Option
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case cacheCapacity:
+			dbOpts.CacheCapacity = int(opt)
+			splitOpts = append(splitOpts, opt)
+		case VerifyPolicy:
+			verify = &opt
+		default:
+			return nil, fmt.Errorf("unknown Option type: %T", opt)
+		}
+	}
+
+	if stat, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("error opening serving table: %v", err)
+	} else if !stat.IsDir() {
+		return nil, fmt.Errorf("serving table %q is not a directory", path)
+	}
+
+	ctx := context.Background()
+	if isSplitTableDir(path) {
+		if isLevelDB(path) {
+			return nil, fmt.Errorf("serving table at %q holds both a combined table and split tables", path)
+		}
+		st, err := openSplitTable(ctx, path, splitOpts)
+		if err != nil {
+			return nil, err
+		}
+		if err := st.loadMetadata(ctx); err != nil {
+			st.Close(ctx)
+			return nil, fmt.Errorf("serving table at %q: %v", path, err)
+		}
+		return st, st.verifyOnOpen(ctx, path, verify)
+	}
+
+	db, err := leveldb.Open(path, &dbOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error opening serving table at %q: %v", path, err)
+	}
+	st := &servingTable{dbs: []keyvalue.DB{db}}
+	if err := checkFormatVersion(ctx, db); err != nil {
+		st.Close(ctx)
+		return nil, fmt.Errorf("serving table at %q: %v", path, err)
+	}
+	st.Service = xsrv.NewService(ctx, db)
+	st.graph = gsrv.NewService(ctx, db)
+	if err := st.loadMetadata(ctx); err != nil {
+		st.Close(ctx)
+		return nil, fmt.Errorf("serving table at %q: %v", path, err)
+	}
+	return st, st.verifyOnOpen(ctx, path, verify)
+}
+
+func openSplitTable(ctx context.Context, path string, opts []Option) (*servingTable, error) {
+	d, err := OpenSplitTableDir(path, opts...)
+	if err != nil {
+		return nil, err
+	} else if d.XRefs == nil {
+		d.Close(ctx)
+		return nil, fmt.Errorf("split serving table at %q has no xrefs tables", path)
+	}
+	st := &servingTable{Service: xsrv.NewSplitTable(d.XRefs), dbs: d.dbs}
+	if d.Graph != nil {
+		st.graph = gsrv.NewSplitTable(d.Graph)
+	}
+	return st, nil
+}
+
+// checkFormatVersion returns an error if db is a columnar serving table of an
+// unsupported format version.
+func checkFormatVersion(ctx context.Context, db keyvalue.DB) error {
+	v, err := db.Get(ctx, []byte(xsrv.ColumnarTableKeyMarker), nil)
+	if err == nil && string(v) != columnarFormatVersion {
+		return fmt.Errorf("unsupported columnar format version: %q", v)
+	}
+	return nil
+}
+
+func isDir(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && stat.IsDir()
+}
+
+// isLevelDB reports whether the given directory holds a LevelDB database.
+func isLevelDB(path string) bool {
+	_, err := os.Stat(filepath.Join(path, "CURRENT"))
+	return err == nil
+}
+
+type servingTable struct {
+	xrefs.Service
+	graph graph.Service // nil if the table has no graph tables
+	dbs   []keyvalue.DB
+
+	metadata *srvpb.TableMetadata
+	kinds    *srvpb.KindsSummary
+
+	mu       sync.Mutex
+	degraded error
+}
+
+// loadMetadata reads the table's metadata from the first of its databases
+// holding any and, if found, stamps each reply with its build ID and time.
+// Requests selecting any other build ID fail with NOT_FOUND.
+// The table's kinds summary is likewise read from the first database holding
+// one.
+func (t *servingTable) loadMetadata(ctx context.Context) error {
+	for _, db := range t.dbs {
+		kinds, err := manifest.ReadKinds(ctx, db)
+		if err == manifest.ErrNoKinds {
+			continue
+		} else if err != nil {
+			return err
+		}
+		t.kinds = kinds
+		break
+	}
+	for _, db := range t.dbs {
+		md, err := manifest.ReadMetadata(ctx, db)
+		if err == manifest.ErrNoMetadata {
+			continue
+		} else if err != nil {
+			return err
+		}
+		t.metadata = md
+		break
+	}
+	// A table without metadata has no build ID, so it serves no request
+	// selecting one.
+	t.Service = xrefs.Provenance{
+		BuildID:   t.metadata.GetBuildId(),
+		BuildTime: t.metadata.GetBuildTime(),
+		Service:   t.Service,
+	}
+	if t.graph != nil {
+		t.graph = graph.Provenance{
+			BuildID:   t.metadata.GetBuildId(),
+			BuildTime: t.metadata.GetBuildTime(),
+			Service:   t.graph,
+		}
+	}
+	return nil
+}
+
+// Nodes implements part of the graph.Service interface.
+func (t *servingTable) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	if t.graph == nil {
+		return nil, status.Error(codes.Unimplemented, "serving table has no graph tables")
+	}
+	return t.graph.Nodes(ctx, req)
+}
+
+// Edges implements part of the graph.Service interface.
+func (t *servingTable) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	if t.graph == nil {
+		return nil, status.Error(codes.Unimplemented, "serving table has no graph tables")
+	}
+	return t.graph.Edges(ctx, req)
+}
+
+// Metadata implements part of the ServingTable interface.
+func (t *servingTable) Metadata() *srvpb.TableMetadata { return t.metadata }
+
+// ListKinds implements part of the ServingTable interface.
+func (t *servingTable) ListKinds(context.Context) (*srvpb.KindsSummary, error) {
+	if t.kinds == nil {
+		return nil, status.Error(codes.NotFound, "serving table has no kinds summary")
+	}
+	return t.kinds, nil
+}
+
+// AddGeneration registers t with g as the generation named by its metadata's
+// build ID.  Tables without metadata cannot be registered.  Closing t remains
+// the caller's responsibility once it is removed from g.
+func AddGeneration(g *xrefs.Generations, t ServingTable) error {
+	md := t.Metadata()
+	if md == nil {
+		return errors.New("serving table has no build metadata")
+	}
+	return g.Add(xrefs.Provenance{BuildID: md.BuildId, BuildTime: md.BuildTime, Service: t})
+}
+
+// verifyOnOpen verifies the table according to the given policy, if any.  If
+// the table must not be served, it is closed and an error is returned.
+func (t *servingTable) verifyOnOpen(ctx context.Context, path string, policy *VerifyPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	err := t.Verify(ctx)
+	if err == nil {
+		return nil
+	} else if *policy == ServeDegraded {
+		log.Warningf(ctx, "serving table at %q failed verification; serving degraded: %v", path, err)
+		return nil
+	}
+	t.Close(ctx)
+	return fmt.Errorf("serving table at %q failed verification: %v", path, err)
+}
+
+// Verify implements part of the ServingTable interface.
+func (t *servingTable) Verify(ctx context.Context) error {
+	var err error
+	for _, db := range t.dbs {
+		if err = manifest.Verify(ctx, db); err != nil {
+			break
+		}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.degraded = err
+	return err
+}
+
+// Degraded implements part of the ServingTable interface.
+func (t *servingTable) Degraded() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.degraded
+}
+
+// Close implements part of the ServingTable interface.
+func (t *servingTable) Close(ctx context.Context) error {
+	var firstErr error
+	for _, db := range t.dbs {
+		if err := db.Close(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kythe.io/kythe/go/serving/manifest"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/leveldb"
+	"kythe.io/kythe/go/test/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+var ctx = context.Background()
+
+// The required tables of each group of a SplitTableDir.
+var (
+	graphTables = []string{"edges", "edgePages"}
+	xrefsTables = []string{"decor", "xrefs", "xrefPages", "docs"}
+)
+
+// createDB creates a LevelDB database at path holding the given keys and
+// values.
+func createDB(t *testing.T, path string, kvs ...string) {
+	t.Helper()
+	db, err := leveldb.Open(path, nil)
+	testutil.Fatalf(t, "Open error: %v", err)
+	w, err := db.Writer(ctx)
+	testutil.Fatalf(t, "Writer error: %v", err)
+	for i := 0; i+1 < len(kvs); i += 2 {
+		testutil.Fatalf(t, "Write error: %v", w.Write([]byte(kvs[i]), []byte(kvs[i+1])))
+	}
+	testutil.Fatalf(t, "Close error: %v", w.Close())
+	testutil.Fatalf(t, "Close error: %v", db.Close(ctx))
+}
+
+// createSplitTables creates an empty LevelDB database for each of the named
+// tables under dir.
+func createSplitTables(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		createDB(t, filepath.Join(dir, name))
+	}
+}
+
+func TestOpenServingTable(t *testing.T) {
+	tests := []struct {
+		name   string
+		create func(t *testing.T, dir string)
+
+		// err is a substring of the expected error, if any.
+		err   string
+		graph bool
+	}{{
+		name:   "combined",
+		create: func(t *testing.T, dir string) { createDB(t, dir, "xrefs:ticket", "") },
+		graph:  true,
+	}, {
+		name: "columnar",
+		create: func(t *testing.T, dir string) {
+			createDB(t, dir, xsrv.ColumnarTableKeyMarker, columnarFormatVersion)
+		},
+		graph: true,
+	}, {
+		name: "split",
+		create: func(t *testing.T, dir string) {
+			createSplitTables(t, dir, append(graphTables, xrefsTables...)...)
+		},
+		graph: true,
+	}, {
+		name:   "split_xrefs_only",
+		create: func(t *testing.T, dir string) { createSplitTables(t, dir, xrefsTables...) },
+	}, {
+		name:   "missing_path",
+		create: func(t *testing.T, dir string) { testutil.Fatalf(t, "RemoveAll error: %v", os.RemoveAll(dir)) },
+		err:    "error opening serving table",
+	}, {
+		name: "not_a_directory",
+		create: func(t *testing.T, dir string) {
+			testutil.Fatalf(t, "RemoveAll error: %v", os.RemoveAll(dir))
+			testutil.Fatalf(t, "WriteFile error: %v", os.WriteFile(dir, nil, 0644))
+		},
+		err: "is not a directory",
+	}, {
+		name: "unsupported_columnar_version",
+		create: func(t *testing.T, dir string) {
+			createDB(t, dir, xsrv.ColumnarTableKeyMarker, "v0")
+		},
+		err: "unsupported columnar format version",
+	}, {
+		name:   "split_missing_xrefs_tables",
+		create: func(t *testing.T, dir string) { createSplitTables(t, dir, graphTables...) },
+		err:    "has no xrefs tables",
+	}, {
+		name:   "split_missing_table",
+		create: func(t *testing.T, dir string) { createSplitTables(t, dir, xrefsTables[1:]...) },
+		err:    `missing "decor" table`,
+	}, {
+		name: "mixed_layouts",
+		create: func(t *testing.T, dir string) {
+			createDB(t, dir, "xrefs:ticket", "")
+			createSplitTables(t, dir, xrefsTables...)
+		},
+		err: "holds both a combined table and split tables",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "table")
+			testutil.Fatalf(t, "Mkdir error: %v", os.Mkdir(dir, 0755))
+			test.create(t, dir)
+
+			tbl, err := OpenServingTable(dir)
+			if test.err != "" {
+				if err == nil {
+					tbl.Close(ctx)
+					t.Fatalf("OpenServingTable succeeded; expected error containing %q", test.err)
+				} else if !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("OpenServingTable error: %v; expected error containing %q", err, test.err)
+				}
+				return
+			}
+			testutil.Fatalf(t, "OpenServingTable error: %v", err)
+			defer tbl.Close(ctx)
+
+			if md := tbl.Metadata(); md != nil {
+				t.Errorf("Unexpected metadata: %v", md)
+			}
+			_, err = tbl.Nodes(ctx, &gpb.NodesRequest{Ticket: []string{"kythe:#node"}})
+			if code := status.Code(err); test.graph && err != nil {
+				t.Errorf("Nodes error: %v", err)
+			} else if !test.graph && code != codes.Unimplemented {
+				t.Errorf("Nodes error: %v; expected UNIMPLEMENTED", err)
+			}
+		})
+	}
+}
+
+func TestOpenServingTableMetadata(t *testing.T) {
+	md := &srvpb.TableMetadata{BuildId: "build"}
+
+	combined := t.TempDir()
+	createDB(t, combined)
+	db, err := leveldb.Open(combined, nil)
+	testutil.Fatalf(t, "Open error: %v", err)
+	testutil.Fatalf(t, "WriteMetadata error: %v", manifest.WriteMetadata(ctx, db, md))
+	testutil.Fatalf(t, "Close error: %v", db.Close(ctx))
+
+	// The metadata of a split table is read from whichever table holds it.
+	split := t.TempDir()
+	createSplitTables(t, split, xrefsTables...)
+	db, err = leveldb.Open(filepath.Join(split, "docs"), nil)
+	testutil.Fatalf(t, "Open error: %v", err)
+	testutil.Fatalf(t, "WriteMetadata error: %v", manifest.WriteMetadata(ctx, db, md))
+	testutil.Fatalf(t, "Close error: %v", db.Close(ctx))
+
+	for _, dir := range []string{combined, split} {
+		tbl, err := OpenServingTable(dir)
+		testutil.Fatalf(t, "OpenServingTable error: %v", err)
+		if found := tbl.Metadata(); found.GetBuildId() != md.BuildId {
+			t.Errorf("OpenServingTable(%q) metadata: %v; expected build ID %q", dir, found, md.BuildId)
+		}
+		testutil.Fatalf(t, "Close error: %v", tbl.Close(ctx))
+	}
+}
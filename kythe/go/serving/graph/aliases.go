@@ -0,0 +1,167 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// A BatchLookupError is returned by Nodes and Edges when looking up a batch of
+// requested tickets fails partway.  Clients may retry just its Unserved
+// tickets.  Its gRPC status code is that of Err (or of its context error),
+// or INTERNAL if Err has none, and its Reply, if any, is attached to the
+// status as a detail.
+type BatchLookupError struct {
+	// Served are the requested tickets whose lookups completed, including
+	// those found to be missing, in request order.
+	Served []string
+
+	// Unserved are the requested tickets whose lookups failed or were never
+	// made, in request order.
+	Unserved []string
+
+	// Err is the first lookup failure.
+	Err error
+
+	// Reply is the NodesReply or EdgesReply for the Served tickets, as if they
+	// alone had been requested, or nil if the lookups stopped early.
+	Reply protoiface.MessageV1
+}
+
+// Error implements the error interface.
+func (e *BatchLookupError) Error() string {
+	return fmt.Sprintf("%v (%d of %d tickets unserved)", e.Err, len(e.Unserved), len(e.Served)+len(e.Unserved))
+}
+
+// Unwrap returns the underlying lookup failure.
+func (e *BatchLookupError) Unwrap() error { return e.Err }
+
+// GRPCStatus returns the gRPC status of the error.
+func (e *BatchLookupError) GRPCStatus() *status.Status {
+	code := status.Code(e.Err)
+	if code == codes.Unknown {
+		code = status.FromContextError(e.Err).Code()
+	}
+	if code == codes.Unknown {
+		code = codes.Internal
+	}
+	st := status.New(code, e.Error())
+	if e.Reply != nil {
+		if detailed, err := st.WithDetails(e.Reply); err == nil {
+			st = detailed
+		}
+	}
+	return st
+}
+
+// newBatchLookupError returns a BatchLookupError for the given tickets' lookup
+// results, which may end early, failing with err.
+func newBatchLookupError(tickets []string, results []edgeSetResult, err error) *BatchLookupError {
+	e := &BatchLookupError{Err: err}
+	for i, ticket := range tickets {
+		if i >= len(results) || (results[i].Err != nil && results[i].Err != table.ErrNoSuchKey) {
+			e.Unserved = append(e.Unserved, ticket)
+		} else {
+			e.Served = append(e.Served, ticket)
+		}
+	}
+	return e
+}
+
+// lookupEdgeSets returns the PagedEdgeSet result for each of the given
+// tickets, in order, as read by pagedEdgeSets.  Unknown tickets with a ticket
+// alias are resolved to their current tickets, which are returned keyed by the
+// unknown tickets.
+func (t *Table) lookupEdgeSets(ctx context.Context, tickets []string, kindFilter func(string) bool, countOnly bool) ([]edgeSetResult, map[string]string, error) {
+	results, err := t.collectEdgeSets(ctx, tickets, kindFilter, countOnly)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requested := make(map[string]bool, len(tickets))
+	for _, ticket := range tickets {
+		requested[ticket] = true
+	}
+	var (
+		redirected map[string]string
+		aliased    []int    // indices of results to replace
+		current    []string // current tickets of the aliased results
+	)
+	for i, r := range results {
+		if r.Err != table.ErrNoSuchKey {
+			continue
+		}
+		a, err := t.ticketAlias(ctx, tickets[i])
+		if err == table.ErrNoSuchKey || (err == nil && a.Ticket == tickets[i]) {
+			continue
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("ticket alias lookup error (ticket %q): %v", tickets[i], err)
+		}
+		tracePrintf(ctx, "Redirecting %s to %s", tickets[i], a.Ticket)
+		if redirected == nil {
+			redirected = make(map[string]string)
+		}
+		redirected[tickets[i]] = a.Ticket
+		if requested[a.Ticket] {
+			// The current ticket's results are already being returned.
+			results[i] = edgeSetResult{}
+			continue
+		}
+		aliased = append(aliased, i)
+		current = append(current, a.Ticket)
+	}
+	if len(current) == 0 {
+		return results, redirected, nil
+	}
+
+	currentResults, err := t.collectEdgeSets(ctx, current, kindFilter, countOnly)
+	if err != nil {
+		return nil, nil, err
+	}
+	for j, r := range currentResults {
+		if i := aliased[j]; r.Err == table.ErrNoSuchKey {
+			delete(redirected, tickets[i])
+		} else {
+			results[i] = r
+		}
+	}
+	return results, redirected, nil
+}
+
+// collectEdgeSets returns the pagedEdgeSets results for the given tickets.
+func (t *Table) collectEdgeSets(ctx context.Context, tickets []string, kindFilter func(string) bool, countOnly bool) ([]edgeSetResult, error) {
+	rs, err := t.pagedEdgeSets(ctx, tickets, kindFilter, countOnly)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]edgeSetResult, 0, len(tickets))
+	for r := range rs {
+		results = append(results, r)
+	}
+	if err := ctx.Err(); err != nil {
+		// The results are incomplete if the lookups stopped early.
+		return nil, newBatchLookupError(tickets, results, err)
+	}
+	return results, nil
+}
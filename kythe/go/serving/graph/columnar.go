@@ -21,7 +21,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"regexp"
 
 	"kythe.io/kythe/go/services/graph"
@@ -31,10 +30,13 @@ import (
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/util/keys"
 	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/log"
 	"kythe.io/kythe/go/util/schema"
 	"kythe.io/kythe/go/util/schema/facts"
 
 	"bitbucket.org/creachadair/stringset"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
@@ -52,7 +54,7 @@ const ColumnarTableKeyMarker = "kythe:columnar"
 func NewService(ctx context.Context, t keyvalue.DB) graph.Service {
 	_, err := t.Get(ctx, []byte(ColumnarTableKeyMarker), nil)
 	if err == nil {
-		log.Println("WARNING: detected a experimental columnar graph table")
+		log.Warningf(ctx, "detected a experimental columnar graph table")
 		return NewColumnarTable(t)
 	}
 	return NewCombinedTable(&table.KVProto{t})
@@ -66,6 +68,9 @@ type ColumnarTable struct{ keyvalue.DB }
 
 // Nodes implements part of the graph.Service interface.
 func (c *ColumnarTable) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	if req.ChangedSinceBuildId != "" {
+		return nil, status.Error(codes.Unimplemented, "changed_since_build_id is not supported by columnar tables")
+	}
 	reply := &gpb.NodesReply{Nodes: make(map[string]*cpb.NodeInfo, len(req.Ticket))}
 
 	filters := req.Filter
@@ -111,7 +116,7 @@ func (c *ColumnarTable) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.
 }
 
 // processTicket loads values associated with the search ticket and adds them to the reply.
-func (c *ColumnarTable) processTicket(ctx context.Context, ticket string, patterns []*regexp.Regexp, allowedKinds stringset.Set, reply *gpb.EdgesReply) error {
+func (c *ColumnarTable) processTicket(ctx context.Context, ticket string, patterns []*regexp.Regexp, allowKind func(string) bool, reply *gpb.EdgesReply) error {
 	srcURI, err := kytheuri.Parse(ticket)
 	if err != nil {
 		return err
@@ -179,7 +184,7 @@ func (c *ColumnarTable) processTicket(ctx context.Context, ticket string, patter
 				kind = "%" + kind
 			}
 
-			if len(allowedKinds) != 0 && !allowedKinds.Contains(kind) {
+			if !allowKind(kind) {
 				continue
 			}
 
@@ -220,6 +225,9 @@ func (c *ColumnarTable) processTicket(ctx context.Context, ticket string, patter
 
 // Edges implements part of the graph.Service interface.
 func (c *ColumnarTable) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	if len(req.KindFilter) > 0 {
+		return nil, status.Error(codes.Unimplemented, "kind_filter is not supported by columnar tables")
+	}
 	// TODO(schroederc): implement edge paging
 	reply := &gpb.EdgesReply{
 		EdgeSets: make(map[string]*gpb.EdgeSet, len(req.Ticket)),
@@ -229,9 +237,13 @@ func (c *ColumnarTable) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.
 	}
 	patterns := xrefs.ConvertFilters(req.Filter)
 	allowedKinds := stringset.New(req.Kind...)
+	inPreset := kindPresetFilter(req.KindPreset)
+	allowKind := func(kind string) bool {
+		return (inPreset == nil || inPreset(kind)) && (allowedKinds.Empty() || allowedKinds.Contains(kind))
+	}
 
 	for _, ticket := range req.Ticket {
-		err := c.processTicket(ctx, ticket, patterns, allowedKinds, reply)
+		err := c.processTicket(ctx, ticket, patterns, allowKind, reply)
 		if err != nil {
 			return nil, err
 		}
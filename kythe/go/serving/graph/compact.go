@@ -0,0 +1,210 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"kythe.io/kythe/go/serving/pagekey"
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/util/log"
+
+	"bitbucket.org/creachadair/stringset"
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// compactionBatchSize is the number of compacted PagedEdgeSets buffered before
+// being written back to the table.
+const compactionBatchSize = 64
+
+// CompactOptions control CompactEdgeSets.
+type CompactOptions struct {
+	// PageSize is the maximum number of edges kept in any single EdgeGroup of a
+	// PagedEdgeSet or EdgePage.  If <= 0, defaultPageSize is used.
+	PageSize int
+}
+
+// CompactionStats summarizes the work done by CompactEdgeSets.
+type CompactionStats struct {
+	// EdgeSets is the number of PagedEdgeSets scanned.
+	EdgeSets int
+
+	// Compacted is the number of PagedEdgeSets rewritten.
+	Compacted int
+
+	// PagesWritten is the number of new EdgePages written.
+	PagesWritten int
+}
+
+type compactedEdgeSet struct {
+	key   []byte
+	set   *srvpb.PagedEdgeSet
+	pages []*srvpb.EdgePage
+}
+
+// CompactEdgeSets scans the PagedEdgeSets of the given combined graph table and
+// re-splits any EdgeGroup holding more than the target page size into
+// EdgePages, writing the corrected entries back to the table.  The table must
+// not be concurrently served; this is intended to be run between serving
+// generations.
+func CompactEdgeSets(ctx context.Context, db keyvalue.DB, opts *CompactOptions) (*CompactionStats, error) {
+	pageSize := defaultPageSize
+	if opts != nil && opts.PageSize > 0 {
+		pageSize = opts.PageSize
+	}
+
+	stats := &CompactionStats{}
+	start := []byte(edgeSetsTablePrefix)
+	for start != nil {
+		batch, next, err := scanOversizedEdgeSets(ctx, db, start, pageSize, stats)
+		if err != nil {
+			return stats, err
+		}
+		if err := writeCompactedEdgeSets(ctx, db, batch); err != nil {
+			return stats, err
+		}
+		for _, c := range batch {
+			stats.Compacted++
+			stats.PagesWritten += len(c.pages)
+		}
+		start = next
+	}
+	log.Infof(ctx, "Compacted %d/%d PagedEdgeSets (%d new EdgePages)", stats.Compacted, stats.EdgeSets, stats.PagesWritten)
+	return stats, nil
+}
+
+// scanOversizedEdgeSets scans the PagedEdgeSets starting at the given key
+// until compactionBatchSize sets have been compacted.  The key at which to
+// resume scanning is returned, or nil if the scan is complete.
+func scanOversizedEdgeSets(ctx context.Context, db keyvalue.DB, start []byte, pageSize int, stats *CompactionStats) ([]*compactedEdgeSet, []byte, error) {
+	iter, err := db.ScanRange(ctx, &keyvalue.Range{
+		Start: start,
+		End:   []byte(edgeSetsTablePrefix + "\xff"),
+	}, &keyvalue.Options{LargeRead: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error scanning edge sets: %v", err)
+	}
+	defer iter.Close()
+
+	var batch []*compactedEdgeSet
+	for {
+		key, val, err := iter.Next()
+		if errors.Is(err, io.EOF) {
+			return batch, nil, nil
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("error scanning edge sets: %v", err)
+		}
+		stats.EdgeSets++
+
+		var pes srvpb.PagedEdgeSet
+		if err := proto.Unmarshal(val, &pes); err != nil {
+			return nil, nil, fmt.Errorf("error unmarshaling PagedEdgeSet %q: %v", key, err)
+		}
+		if pages := compactEdgeSet(&pes, pageSize); len(pages) > 0 {
+			batch = append(batch, &compactedEdgeSet{key: key, set: &pes, pages: pages})
+			if len(batch) >= compactionBatchSize {
+				// Resume just after the current key.
+				return batch, append(key, 0), nil
+			}
+		}
+	}
+}
+
+// compactEdgeSet splits each EdgeGroup of pes with more than pageSize edges,
+// keeping the first pageSize edges in the set and moving the remainder, in
+// order, into new EdgePages.  The new EdgePages are returned.
+func compactEdgeSet(pes *srvpb.PagedEdgeSet, pageSize int) []*srvpb.EdgePage {
+	src := pes.GetSource().GetTicket()
+	keys := stringset.New()
+	for _, idx := range pes.PageIndex {
+		keys.Add(idx.PageKey)
+	}
+	nextPage := len(pes.PageIndex)
+	nextKey := func(group string) string {
+		for {
+			key := pagekey.New(pagekey.EdgePage, src, group, nextPage)
+			nextPage++
+			if keys.Add(key) {
+				return key
+			}
+		}
+	}
+
+	var pages []*srvpb.EdgePage
+	for _, grp := range pes.Group {
+		if len(grp.Edge) <= pageSize {
+			continue
+		}
+		rest := grp.Edge[pageSize:]
+		grp.Edge = grp.Edge[:pageSize]
+		for len(rest) > 0 {
+			n := pageSize
+			if n > len(rest) {
+				n = len(rest)
+			}
+			page := &srvpb.EdgePage{
+				PageKey:      nextKey(grp.Kind),
+				SourceTicket: src,
+				EdgesGroup: &srvpb.EdgeGroup{
+					Kind: grp.Kind,
+					Edge: rest[:n],
+				},
+			}
+			rest = rest[n:]
+			pes.PageIndex = append(pes.PageIndex, &srvpb.PageIndex{
+				PageKey:          page.PageKey,
+				EdgeKind:         grp.Kind,
+				EdgeCount:        int32(n),
+				UncompressedSize: int64(proto.Size(page)),
+			})
+			pages = append(pages, page)
+		}
+	}
+	return pages
+}
+
+func writeCompactedEdgeSets(ctx context.Context, db keyvalue.DB, batch []*compactedEdgeSet) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	wr := keyvalue.NewPool(db, nil)
+	for _, c := range batch {
+		for _, p := range c.pages {
+			rec, err := proto.Marshal(p)
+			if err != nil {
+				return fmt.Errorf("error marshaling EdgePage: %v", err)
+			}
+			if err := wr.Write(ctx, EdgePageKey(p.PageKey), rec); err != nil {
+				return fmt.Errorf("error writing EdgePage: %v", err)
+			}
+		}
+		rec, err := proto.Marshal(c.set)
+		if err != nil {
+			return fmt.Errorf("error marshaling PagedEdgeSet: %v", err)
+		}
+		if err := wr.Write(ctx, c.key, rec); err != nil {
+			return fmt.Errorf("error writing PagedEdgeSet: %v", err)
+		}
+		tracePrintf(ctx, "Compacted PagedEdgeSet: %s", c.set.GetSource().GetTicket())
+	}
+	return wr.Flush()
+}
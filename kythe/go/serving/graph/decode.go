@@ -0,0 +1,145 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// noEdgeKinds is a kind filter allowing no edge kinds, for lookups needing only
+// the source nodes of PagedEdgeSets.
+func noEdgeKinds(string) bool { return false }
+
+// lookupPagedEdgeSet returns the PagedEdgeSet stored in t under the given key.
+// If kindFilter is non-nil, the inline groups with kinds it disallows may be
+// omitted; unless t is a *table.KVProto, the PagedEdgeSet is decoded whole.
+// If counts is non-nil, the inline groups are omitted and the number of edges
+// of each allowed group is added to counts instead.
+func lookupPagedEdgeSet(ctx context.Context, t table.Proto, key []byte, kindFilter func(string) bool, counts map[string]int64) (*srvpb.PagedEdgeSet, error) {
+	kv, ok := t.(*table.KVProto)
+	if !ok || (kindFilter == nil && counts == nil) {
+		var pes srvpb.PagedEdgeSet
+		if err := t.Lookup(ctx, key, &pes); err != nil {
+			return nil, err
+		}
+		if counts != nil {
+			countGroups(&pes, kindFilter, counts)
+		}
+		return &pes, nil
+	}
+
+	rec, err := kv.Get(ctx, key, nil)
+	if errors.Is(err, io.EOF) {
+		return nil, table.ErrNoSuchKey
+	} else if err != nil {
+		return nil, err
+	}
+	return decodePagedEdgeSet(rec, kindFilter, counts)
+}
+
+// countGroups moves the inline groups of pes allowed by kindFilter, if
+// non-nil, into counts as their numbers of edges.
+func countGroups(pes *srvpb.PagedEdgeSet, kindFilter func(string) bool, counts map[string]int64) {
+	for _, grp := range pes.Group {
+		if kindFilter == nil || kindFilter(grp.Kind) {
+			counts[grp.Kind] += int64(len(grp.Edge))
+		}
+	}
+	pes.Group = nil
+}
+
+// decodePagedEdgeSet decodes the PagedEdgeSet encoded in rec, omitting the
+// inline groups with kinds disallowed by kindFilter, if non-nil.  The edges of
+// omitted groups, which make up the bulk of large PagedEdgeSets, are skipped
+// without being decoded.  If counts is non-nil, every group is omitted and the
+// edges of allowed groups are counted into it by kind, still without being
+// decoded.
+func decodePagedEdgeSet(rec []byte, kindFilter func(string) bool, counts map[string]int64) (*srvpb.PagedEdgeSet, error) {
+	const (
+		groupField = 2
+		edgeField  = 2
+	)
+	var (
+		header []byte // every field but the groups
+		groups []*srvpb.EdgeGroup
+	)
+	for len(rec) > 0 {
+		num, typ, n := protowire.ConsumeTag(rec)
+		if n < 0 {
+			return nil, fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+		}
+		m := protowire.ConsumeFieldValue(num, typ, rec[n:])
+		if m < 0 {
+			return nil, fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(m))
+		}
+		field := rec[:n+m]
+		rec = rec[n+m:]
+		if num != groupField || typ != protowire.BytesType {
+			header = append(header, field...)
+			continue
+		}
+
+		group, _ := protowire.ConsumeBytes(field[n:])
+		kind, err := lastField(group, 1)
+		if err != nil {
+			return nil, err
+		} else if kindFilter != nil && !kindFilter(string(kind)) {
+			continue
+		} else if counts != nil {
+			var n int64
+			if err := scanFields(group, edgeField, func([]byte) error { n++; return nil }); err != nil {
+				return nil, err
+			}
+			counts[string(kind)] += n
+			continue
+		}
+		var g srvpb.EdgeGroup
+		if err := proto.Unmarshal(group, &g); err != nil {
+			return nil, fmt.Errorf("proto unmarshal error: %v", err)
+		}
+		groups = append(groups, &g)
+	}
+
+	var pes srvpb.PagedEdgeSet
+	if err := proto.Unmarshal(header, &pes); err != nil {
+		return nil, fmt.Errorf("proto unmarshal error: %v", err)
+	}
+	pes.Group = groups
+	return &pes, nil
+}
+
+// lastField returns the value of the last occurrence of the given
+// length-delimited field in the encoded message rec, which takes precedence
+// for singular fields.
+func lastField(rec []byte, field protowire.Number) ([]byte, error) {
+	var val []byte
+	err := scanFields(rec, field, func(v []byte) error {
+		val = v
+		return nil
+	})
+	return val, err
+}
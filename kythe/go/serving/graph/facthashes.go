@@ -0,0 +1,82 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// factHashSize is the number of bytes kept of each fact value's SHA-256 hash.
+const factHashSize = 16
+
+// FactHash returns the hash of the given fact value as stored in a
+// srvpb.NodeFactHashes.
+func FactHash(value []byte) []byte {
+	h := sha256.Sum256(value)
+	return h[:factHashSize]
+}
+
+// NodeFactHashes returns the srvpb.NodeFactHashes of the given node's facts.
+func NodeFactHashes(n *srvpb.Node) *srvpb.NodeFactHashes {
+	h := &srvpb.NodeFactHashes{Ticket: n.Ticket}
+	for _, f := range n.Fact {
+		h.Fact = append(h.Fact, &srvpb.NodeFactHashes_Fact{Name: f.Name, Hash: FactHash(f.Value)})
+	}
+	sort.Slice(h.Fact, func(i, j int) bool { return h.Fact[i].Name < h.Fact[j].Name })
+	return h
+}
+
+// checkFactHashesGeneration returns a FAILED_PRECONDITION error unless the
+// table stores the fact hashes of the given build.
+func (t *Table) checkFactHashesGeneration(ctx context.Context, buildID string) error {
+	g, err := t.factHashesGeneration(ctx)
+	if err == table.ErrNoSuchKey {
+		return status.Errorf(codes.FailedPrecondition, "no fact hashes stored for build %q", buildID)
+	} else if err != nil {
+		return fmt.Errorf("fact hashes generation lookup error: %v", err)
+	} else if g.BuildId != buildID {
+		return status.Errorf(codes.FailedPrecondition, "no fact hashes stored for build %q (stored build: %q)", buildID, g.BuildId)
+	}
+	return nil
+}
+
+// previousFactHashes returns the hashes of the given node's fact values in the
+// table's fact hashes generation, keyed by fact name.  A node unknown to that
+// generation has no previous facts.
+func (t *Table) previousFactHashes(ctx context.Context, ticket string) (map[string][]byte, error) {
+	h, err := t.nodeFactHashes(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return map[string][]byte{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("fact hashes lookup error (ticket %q): %v", ticket, err)
+	}
+	prev := make(map[string][]byte, len(h.Fact))
+	for _, f := range h.Fact {
+		prev[f.Name] = f.Hash
+	}
+	return prev, nil
+}
@@ -21,21 +21,44 @@
 //
 //	edgeSets:<ticket>      -> srvpb.PagedEdgeSet
 //	edgePages:<page_key>   -> srvpb.EdgePage
+//	aliases:<ticket>       -> srvpb.TicketAlias
+//	ticketIndex:<key>      -> srvpb.TicketIndexEntry
+//	factHashes:<ticket>    -> srvpb.NodeFactHashes
+//	factHashesGeneration   -> srvpb.FactHashesGeneration
+//
+// Ticket index keys are the NUL-separated corpus, root, path, language, and
+// signature of each node.
 package graph // import "kythe.io/kythe/go/serving/graph"
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
+	"kythe.io/kythe/go/services/validate"
 	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/pagekey"
+	"kythe.io/kythe/go/serving/quota"
+	"kythe.io/kythe/go/serving/tablecheck"
+	"kythe.io/kythe/go/storage/keyvalue"
 	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/compare"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/log"
+	"kythe.io/kythe/go/util/schema/edges"
 
 	"bitbucket.org/creachadair/stringset"
 	"golang.org/x/net/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
@@ -51,13 +74,17 @@ func tracePrintf(ctx context.Context, msg string, args ...interface{}) {
 }
 
 func nodeToInfo(patterns []*regexp.Regexp, n *srvpb.Node) *cpb.NodeInfo {
-	ni := &cpb.NodeInfo{Facts: make(map[string][]byte, len(n.Fact))}
+	ni := &cpb.NodeInfo{
+		Facts:              make(map[string][]byte, len(n.Fact)),
+		Deprecated:         n.GetDeprecated(),
+		DeprecationMessage: n.GetDeprecationMessage(),
+	}
 	for _, f := range n.Fact {
 		if xrefs.MatchesAny(f.Name, patterns) {
 			ni.Facts[f.Name] = f.Value
 		}
 	}
-	if len(ni.Facts) == 0 {
+	if len(ni.Facts) == 0 && !ni.Deprecated {
 		return nil
 	}
 	return ni
@@ -67,17 +94,41 @@ func nodeToInfo(patterns []*regexp.Regexp, n *srvpb.Node) *cpb.NodeInfo {
 const (
 	edgeSetsTablePrefix  = "edgeSets:"
 	edgePagesTablePrefix = "edgePages:"
+
+	// ticketAliasTablePrefix is shared with the xrefs combined table.
+	ticketAliasTablePrefix = "aliases:"
+
+	ticketIndexTablePrefix = "ticketIndex:"
+
+	factHashesTablePrefix = "factHashes:"
 )
 
 type edgeSetResult struct {
 	PagedEdgeSet *srvpb.PagedEdgeSet
 
+	// GroupCounts holds the number of edges of each inline group of a
+	// count-only lookup, whose PagedEdgeSet has no groups.
+	GroupCounts map[string]int64
+
 	Err error
 }
 
 type staticLookupTables interface {
-	pagedEdgeSets(ctx context.Context, tickets []string) (<-chan edgeSetResult, error)
+	// pagedEdgeSets returns the PagedEdgeSets of the given tickets, in order.
+	// If kindFilter is non-nil, the inline groups with kinds it disallows may
+	// be omitted to save decoding them.  If countOnly is set, the inline groups
+	// are instead returned as GroupCounts.
+	pagedEdgeSets(ctx context.Context, tickets []string, kindFilter func(string) bool, countOnly bool) (<-chan edgeSetResult, error)
 	edgePage(ctx context.Context, key string) (*srvpb.EdgePage, error)
+	ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error)
+
+	// ticketIndex calls f, in key order, with each indexed ticket whose
+	// ticketIndexKey has the given prefix.  If f returns an error, the scan
+	// stops and that error is returned.
+	ticketIndex(ctx context.Context, prefix []byte, f func(ticket string) error) error
+
+	nodeFactHashes(ctx context.Context, ticket string) (*srvpb.NodeFactHashes, error)
+	factHashesGeneration(ctx context.Context) (*srvpb.FactHashesGeneration, error)
 }
 
 // SplitTable implements the graph Service interface using separate static
@@ -88,27 +139,63 @@ type SplitTable struct {
 
 	// EdgePages is a table of srvpb.EdgePages keyed by their page keys.
 	EdgePages table.Proto
+
+	// TicketAliases is an optional table of srvpb.TicketAliases keyed by their
+	// aliased tickets.
+	TicketAliases table.Proto
+
+	// TicketIndex is an optional sorted table of srvpb.TicketIndexEntries.
+	// Unlike the other tables, it must support prefix scans.
+	TicketIndex keyvalue.DB
+
+	// FactHashes is an optional table of srvpb.NodeFactHashes keyed by their
+	// node tickets along with their srvpb.FactHashesGeneration keyed by
+	// FactHashesGenerationKey.
+	FactHashes table.Proto
 }
 
-func lookupPagedEdgeSets(ctx context.Context, tbl table.Proto, keys [][]byte) (<-chan edgeSetResult, error) {
+// lookupPagedEdgeSets returns a channel of the PagedEdgeSets stored in tbl under
+// the given keys, in order, as read by lookupPagedEdgeSet.  Once ctx is done,
+// no further lookups are made and the channel is closed; consumers that stop
+// reading early should cancel ctx rather than drain the channel.
+func lookupPagedEdgeSets(ctx context.Context, tbl table.Proto, keys [][]byte, kindFilter func(string) bool, countOnly bool) (<-chan edgeSetResult, error) {
 	ch := make(chan edgeSetResult)
 	go func() {
 		defer close(ch)
+		send := func(r edgeSetResult) bool {
+			select {
+			case ch <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
 		for _, key := range keys {
-			var pes srvpb.PagedEdgeSet
-			if err := tbl.Lookup(ctx, key, &pes); err == table.ErrNoSuchKey {
-				log.Printf("Could not locate edges with key %q", key)
-				ch <- edgeSetResult{Err: err}
+			if ctx.Err() != nil {
+				return
+			}
+			var counts map[string]int64
+			if countOnly {
+				counts = make(map[string]int64)
+			}
+			pes, err := lookupPagedEdgeSet(ctx, tbl, key, kindFilter, counts)
+			if err == table.ErrNoSuchKey {
+				log.Warningf(ctx, "Could not locate edges with key %q", key)
+				if !send(edgeSetResult{Err: err}) {
+					return
+				}
 				continue
 			} else if err != nil {
 				ticket := strings.TrimPrefix(string(key), edgeSetsTablePrefix)
-				ch <- edgeSetResult{
-					Err: fmt.Errorf("edges lookup error (ticket %q): %v", ticket, err),
+				if !send(edgeSetResult{Err: fmt.Errorf("edges lookup error (ticket %q): %v", ticket, err)}) {
+					return
 				}
 				continue
 			}
 
-			ch <- edgeSetResult{PagedEdgeSet: &pes}
+			if !send(edgeSetResult{PagedEdgeSet: pes, GroupCounts: counts}) {
+				return
+			}
 		}
 	}()
 	return ch, nil
@@ -127,77 +214,419 @@ const (
 	maxPageSize     = 10000
 )
 
-func (s *SplitTable) pagedEdgeSets(ctx context.Context, tickets []string) (<-chan edgeSetResult, error) {
+func (s *SplitTable) pagedEdgeSets(ctx context.Context, tickets []string, kindFilter func(string) bool, countOnly bool) (<-chan edgeSetResult, error) {
 	tracePrintf(ctx, "Reading PagedEdgeSets: %s", tickets)
-	return lookupPagedEdgeSets(ctx, s.Edges, toKeys(tickets))
+	return lookupPagedEdgeSets(ctx, s.Edges, toKeys(tickets), kindFilter, countOnly)
 }
 func (s *SplitTable) edgePage(ctx context.Context, key string) (*srvpb.EdgePage, error) {
 	tracePrintf(ctx, "Reading EdgePage: %s", key)
 	var ep srvpb.EdgePage
 	return &ep, s.EdgePages.Lookup(ctx, []byte(key), &ep)
 }
+func (s *SplitTable) ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error) {
+	if s.TicketAliases == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading TicketAlias: %s", ticket)
+	var a srvpb.TicketAlias
+	return &a, s.TicketAliases.Lookup(ctx, []byte(ticket), &a)
+}
+
+func (s *SplitTable) ticketIndex(ctx context.Context, prefix []byte, f func(string) error) error {
+	if s.TicketIndex == nil {
+		return errNoTicketIndex
+	}
+	return scanTicketIndex(ctx, s.TicketIndex, prefix, f)
+}
+func (s *SplitTable) nodeFactHashes(ctx context.Context, ticket string) (*srvpb.NodeFactHashes, error) {
+	if s.FactHashes == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading NodeFactHashes: %s", ticket)
+	var h srvpb.NodeFactHashes
+	return &h, s.FactHashes.Lookup(ctx, []byte(ticket), &h)
+}
+func (s *SplitTable) factHashesGeneration(ctx context.Context) (*srvpb.FactHashesGeneration, error) {
+	if s.FactHashes == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	var g srvpb.FactHashesGeneration
+	return &g, s.FactHashes.Lookup(ctx, FactHashesGenerationKey, &g)
+}
+
+// scanTicketIndex calls f with each ticket indexed in db under the given key
+// prefix.
+func scanTicketIndex(ctx context.Context, db keyvalue.DB, prefix []byte, f func(string) error) error {
+	iter, err := db.ScanPrefix(ctx, prefix, &keyvalue.Options{LargeRead: true})
+	if err != nil {
+		return fmt.Errorf("error scanning ticket index: %v", err)
+	}
+	defer iter.Close()
+	for {
+		key, val, err := iter.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error scanning ticket index: %v", err)
+		}
+		var e srvpb.TicketIndexEntry
+		if err := proto.Unmarshal(val, &e); err != nil {
+			return fmt.Errorf("error unmarshaling TicketIndexEntry %q: %v", key, err)
+		}
+		if err := f(e.Ticket); err != nil {
+			return err
+		}
+	}
+}
+
+var defaultMaxFactBytes = flag.Int("default_max_node_fact_bytes", 1<<20, "Maximum size of each fact value returned by a NodesRequest with an empty filter and no max_fact_bytes; non-positive values disable the limit")
 
 // Table implements the GraphService interface using static lookup tables.
-type Table struct{ staticLookupTables }
+type Table struct {
+	staticLookupTables
+
+	// Paranoid, if true, validates each PagedEdgeSet and EdgePage as it is
+	// read, failing requests that read a corrupt entry with a
+	// *tablecheck.CorruptionError.
+	Paranoid bool
+
+	// Health, if set, records every Nodes and Edges request (e.g. an
+	// xrefs.HealthMonitor shared with the xrefs table).
+	Health HealthRecorder
+
+	// ReadQuota bounds the table reads made to serve each Nodes or Edges
+	// request: each ticket's lookup and each EdgePage read is charged to it.
+	// Requests exceeding it fail with a RESOURCE_EXHAUSTED error carrying the
+	// partial reply served within the quota.
+	ReadQuota quota.ReadQuota
+
+	// ReadLimiter, if set, is waited on before every charged table read, e.g.
+	// to bound the table's read rate across requests.
+	ReadLimiter quota.ReadLimiter
+}
+
+// A HealthRecorder records the outcome of served requests.
+type HealthRecorder interface {
+	// RecordRequest records a request for the given tickets that completed
+	// with the given latency and error.
+	RecordRequest(tickets []string, latency time.Duration, err error)
+}
+
+// pagedEdgeSets returns the PagedEdgeSets of the given tickets, validating
+// each if t.Paranoid is set.  Each ticket's lookup is charged to the request's
+// read budget; the tickets beyond it fail with a *quota.ExceededError without
+// being read.
+func (t *Table) pagedEdgeSets(ctx context.Context, tickets []string, kindFilter func(string) bool, countOnly bool) (<-chan edgeSetResult, error) {
+	charged := len(tickets)
+	var chargeErr error
+	for i := range tickets {
+		if err := quota.Charge(ctx, t.ReadLimiter, false); err != nil {
+			charged, chargeErr = i, err
+			break
+		}
+	}
+	rs, err := t.staticLookupTables.pagedEdgeSets(ctx, tickets[:charged], kindFilter, countOnly)
+	if err != nil || (!t.Paranoid && chargeErr == nil) {
+		return rs, err
+	}
+	ch := make(chan edgeSetResult)
+	go func() {
+		defer close(ch)
+		send := func(r edgeSetResult) bool {
+			select {
+			case ch <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for r := range rs {
+			if t.Paranoid && r.Err == nil && r.PagedEdgeSet != nil {
+				if err := tablecheck.ValidatePagedEdgeSet(r.PagedEdgeSet); err != nil {
+					r = edgeSetResult{Err: err}
+				}
+			}
+			if !send(r) {
+				return
+			}
+		}
+		for range tickets[charged:] {
+			if !send(edgeSetResult{Err: chargeErr}) {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
 
 // Nodes implements part of the graph Service interface.
-func (t *Table) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
-	tickets, err := xrefs.FixTickets(req.Ticket)
-	if err != nil {
+func (t *Table) Nodes(ctx context.Context, req *gpb.NodesRequest) (_ *gpb.NodesReply, err error) {
+	ctx = log.EnsureRequestID(ctx)
+	ctx, _ = quota.NewContext(ctx, t.ReadQuota)
+	if t.Health != nil {
+		defer func(start time.Time) { t.Health.RecordRequest(req.Ticket, time.Since(start), err) }(time.Now())
+	}
+	var v validate.Validator
+	var pattern *ticketPattern
+	if req.TicketPattern != "" {
+		var err error
+		pattern, err = parseTicketPattern(req.TicketPattern)
+		if err != nil {
+			v.Addf("ticket_pattern", req.TicketPattern, "invalid ticket pattern: %v", err)
+		}
+	}
+	var tickets []string
+	if len(req.Ticket) > 0 || req.TicketPattern == "" {
+		tickets = v.Tickets("ticket", req.Ticket)
+	}
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
+	delta := req.ChangedSinceBuildId != ""
+	if delta {
+		if err := t.checkFactHashesGeneration(ctx, req.ChangedSinceBuildId); err != nil {
+			return nil, err
+		}
+	}
 
-	rs, err := t.pagedEdgeSets(ctx, tickets)
+	var truncated bool
+	if pattern != nil {
+		matched, trunc, err := t.matchTickets(ctx, pattern)
+		if err != nil {
+			return nil, err
+		}
+		truncated = trunc
+		requested := stringset.New(tickets...)
+		for _, ticket := range matched {
+			if requested.Add(ticket) {
+				tickets = append(tickets, ticket)
+			}
+		}
+	}
+
+	rs, redirected, err := t.lookupEdgeSets(ctx, tickets, noEdgeKinds, false)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		// drain channel in case of errors
-		for range rs {
-		}
-	}()
 
-	reply := &gpb.NodesReply{Nodes: make(map[string]*cpb.NodeInfo, len(req.Ticket))}
+	reply := &gpb.NodesReply{
+		Nodes:            make(map[string]*cpb.NodeInfo, len(tickets)),
+		RedirectedTicket: redirected,
+		PatternTruncated: truncated,
+	}
 	patterns := xrefs.ConvertFilters(req.Filter)
 
-	for r := range rs {
+	maxBytes := int(req.MaxFactBytes)
+	if maxBytes == 0 && len(patterns) == 0 {
+		maxBytes = *defaultMaxFactBytes
+	}
+
+	setStatus := func(ticket string, code gpb.NodesReply_TicketStatus_Code, msg string) {
+		if req.TicketStatuses {
+			reply.TicketStatus[ticket] = &gpb.NodesReply_TicketStatus{Code: code, Message: msg}
+		}
+	}
+	if req.TicketStatuses {
+		reply.TicketStatus = make(map[string]*gpb.NodesReply_TicketStatus, len(tickets))
+	}
+	if delta {
+		reply.RemovedFacts = make(map[string]*gpb.NodesReply_RemovedFacts)
+	}
+
+	var lookupErr error // the first failed lookup, without TicketStatuses
+	for i, r := range rs {
 		if r.Err == table.ErrNoSuchKey {
+			reply.MissingTicket = append(reply.MissingTicket, tickets[i])
+			setStatus(tickets[i], gpb.NodesReply_TicketStatus_NOT_FOUND, "")
 			continue
 		} else if r.Err != nil {
-			return nil, r.Err
+			if !req.TicketStatuses {
+				if lookupErr == nil {
+					lookupErr = r.Err
+				}
+				continue
+			}
+			log.Warningf(ctx, "error reading node %q: %v", tickets[i], r.Err)
+			setStatus(tickets[i], gpb.NodesReply_TicketStatus_ERROR, r.Err.Error())
+			continue
+		} else if r.PagedEdgeSet == nil {
+			// The ticket was redirected to another requested ticket.
+			setStatus(tickets[i], gpb.NodesReply_TicketStatus_FOUND, "")
+			continue
 		}
 		node := r.PagedEdgeSet.Source
-		ni := &cpb.NodeInfo{Facts: make(map[string][]byte, len(node.Fact))}
+		var prev map[string][]byte
+		if delta {
+			var err error
+			prev, err = t.previousFactHashes(ctx, node.Ticket)
+			if err != nil {
+				if !req.TicketStatuses {
+					return nil, err
+				}
+				log.Warningf(ctx, "error reading fact hashes of %q: %v", tickets[i], err)
+				setStatus(tickets[i], gpb.NodesReply_TicketStatus_ERROR, err.Error())
+				continue
+			}
+		}
+		ni := &cpb.NodeInfo{
+			Facts:              make(map[string][]byte, len(node.Fact)),
+			Deprecated:         node.GetDeprecated(),
+			DeprecationMessage: node.GetDeprecationMessage(),
+		}
 		for _, f := range node.Fact {
 			if len(patterns) == 0 || xrefs.MatchesAny(f.Name, patterns) {
-				ni.Facts[f.Name] = f.Value
+				if delta {
+					unchanged := bytes.Equal(prev[f.Name], FactHash(f.Value))
+					delete(prev, f.Name)
+					if unchanged {
+						continue
+					}
+				}
+				val := f.Value
+				if maxBytes > 0 && len(val) > maxBytes {
+					val = val[:maxBytes]
+					ni.TruncatedFacts = append(ni.TruncatedFacts, f.Name)
+				}
+				ni.Facts[f.Name] = val
 			}
 		}
-		if len(ni.Facts) > 0 {
+		if delta {
+			// The remaining matching previous facts were removed.
+			removed := &gpb.NodesReply_RemovedFacts{}
+			for name := range prev {
+				if len(patterns) == 0 || xrefs.MatchesAny(name, patterns) {
+					removed.Name = append(removed.Name, name)
+				}
+			}
+			sort.Strings(removed.Name)
+			if len(removed.Name) > 0 {
+				reply.RemovedFacts[node.Ticket] = removed
+			}
+			if len(ni.Facts) > 0 {
+				reply.Nodes[node.Ticket] = ni
+			}
+			if len(ni.Facts) > 0 || len(removed.Name) > 0 {
+				setStatus(tickets[i], gpb.NodesReply_TicketStatus_FOUND, "")
+			} else {
+				setStatus(tickets[i], gpb.NodesReply_TicketStatus_UNCHANGED, "")
+			}
+		} else if len(ni.Facts) > 0 || ni.Deprecated {
 			reply.Nodes[node.Ticket] = ni
+			setStatus(tickets[i], gpb.NodesReply_TicketStatus_FOUND, "")
+		} else {
+			setStatus(tickets[i], gpb.NodesReply_TicketStatus_NO_MATCHING_FACTS, "")
 		}
 	}
+	if req.Strict && len(reply.MissingTicket) > 0 {
+		return nil, missingTicketsError(reply.MissingTicket)
+	} else if lookupErr != nil {
+		e := newBatchLookupError(tickets, rs, lookupErr)
+		e.Reply = reply
+		return nil, e
+	}
 	return reply, nil
 }
 
+// missingTicketsError returns a NOT_FOUND error for the given unknown tickets.
+func missingTicketsError(tickets []string) error {
+	return status.Errorf(codes.NotFound, "tickets not found: %q", tickets)
+}
+
 // Edges implements part of the graph Service interface.
-func (t *Table) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
-	tickets, err := xrefs.FixTickets(req.Ticket)
-	if err != nil {
+func (t *Table) Edges(ctx context.Context, req *gpb.EdgesRequest) (_ *gpb.EdgesReply, err error) {
+	ctx = log.EnsureRequestID(ctx)
+	ctx, _ = quota.NewContext(ctx, t.ReadQuota)
+	if t.Health != nil {
+		defer func(start time.Time) { t.Health.RecordRequest(req.Ticket, time.Since(start), err) }(time.Now())
+	}
+	var v validate.Validator
+	tickets := v.Tickets("ticket", req.Ticket)
+	v.PageSize("page_size", req.PageSize)
+	_, err = parsePageToken(req.PageToken)
+	v.PageToken("page_token", req.PageToken, err)
+	if _, ok := gpb.EdgesRequest_KindPreset_name[int32(req.KindPreset)]; !ok {
+		v.Addf("kind_preset", req.KindPreset.String(), "unknown kind preset")
+	}
+	var kindFilters map[string][]string
+	for i, kf := range req.KindFilter {
+		field := fmt.Sprintf("kind_filter[%d].kind", i)
+		if kf.GetKind() == "" {
+			v.Addf(field, "", "missing kind")
+		} else if _, ok := kindFilters[kf.Kind]; ok {
+			v.Addf(field, kf.Kind, "duplicate kind")
+		} else {
+			if kindFilters == nil {
+				kindFilters = make(map[string][]string)
+			}
+			kindFilters[kf.Kind] = kf.Filter
+		}
+	}
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
 
 	allowedKinds := stringset.New(req.Kind...)
-	return t.edges(ctx, edgesRequest{
+	inPreset := kindPresetFilter(req.KindPreset)
+	var targetFilters func(string) ([]string, bool)
+	if len(kindFilters) > 0 {
+		targetFilters = func(kind string) ([]string, bool) {
+			if req.CanonicalKinds {
+				kind, _, _ = edges.ParseOrdinal(kind)
+			}
+			filters, ok := kindFilters[kind]
+			return filters, ok
+		}
+	}
+	reply, err := t.edges(ctx, edgesRequest{
 		Tickets: tickets,
 		Filters: req.Filter,
 		Kinds: func(kind string) bool {
+			if inPreset != nil && !inPreset(kind) {
+				return false
+			}
+			if req.CanonicalKinds {
+				kind, _, _ = edges.ParseOrdinal(kind)
+			}
 			return allowedKinds.Empty() || allowedKinds.Contains(kind)
 		},
+		TargetFilters: targetFilters,
 
 		PageSize:  int(req.PageSize),
 		PageToken: req.PageToken,
+		TotalOnly: req.CountOnly,
+		Strict:    req.Strict,
 	})
+	if req.CanonicalKinds {
+		var batchErr *BatchLookupError
+		var quotaErr *quota.ExceededError
+		if err == nil {
+			canonicalizeEdgeKinds(reply)
+		} else if errors.As(err, &batchErr) && batchErr.Reply != nil {
+			canonicalizeEdgeKinds(batchErr.Reply.(*gpb.EdgesReply))
+		} else if errors.As(err, &quotaErr) && quotaErr.Reply != nil {
+			canonicalizeEdgeKinds(quotaErr.Reply.(*gpb.EdgesReply))
+		}
+	}
+	return reply, err
+}
+
+// parsePageToken returns the number of edges to skip encoded in the given
+// EdgesRequest page token.  An empty token skips no edges.
+func parsePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	rec, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	var t ipb.PageToken
+	if err := proto.Unmarshal(rec, &t); err != nil {
+		return 0, err
+	} else if t.Index < 0 {
+		return 0, fmt.Errorf("negative index: %d", t.Index)
+	}
+	return int(t.Index), nil
 }
 
 type edgesRequest struct {
@@ -205,9 +634,16 @@ type edgesRequest struct {
 	Filters []string
 	Kinds   func(string) bool
 
+	// TargetFilters, if set, returns the filters overriding Filters for the
+	// targets of edges of the given kind, if there is an override.
+	TargetFilters func(kind string) ([]string, bool)
+
 	TotalOnly bool
 	PageSize  int
 	PageToken string
+
+	// Strict causes the request to fail if any of the tickets are unknown.
+	Strict bool
 }
 
 func (t *Table) edges(ctx context.Context, req edgesRequest) (*gpb.EdgesReply, error) {
@@ -224,141 +660,296 @@ func (t *Table) edges(ctx context.Context, req edgesRequest) (*gpb.EdgesReply, e
 		stats.max = maxPageSize
 	}
 
-	if req.PageToken != "" {
-		rec, err := base64.StdEncoding.DecodeString(req.PageToken)
-		if err != nil {
-			return nil, fmt.Errorf("invalid page_token: %q", req.PageToken)
-		}
-		var t ipb.PageToken
-		if err := proto.Unmarshal(rec, &t); err != nil || t.Index < 0 {
-			return nil, fmt.Errorf("invalid page_token: %q", req.PageToken)
-		}
-		stats.skip = int(t.Index)
+	skip, err := parsePageToken(req.PageToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page_token: %q", req.PageToken)
 	}
+	stats.skip = skip
 	pageToken := stats.skip
 
 	var nodeTickets stringset.Set
+	var sets []*srvpb.PagedEdgeSet
 
-	rs, err := t.pagedEdgeSets(ctx, req.Tickets)
+	rs, redirected, err := t.lookupEdgeSets(ctx, req.Tickets, req.Kinds, req.TotalOnly)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		// drain channel in case of errors or early return
-		for range rs {
-		}
-	}()
 
 	patterns := xrefs.ConvertFilters(req.Filters)
+	kindPatterns := make(map[string][]*regexp.Regexp)
+	// targetPatterns returns the fact patterns for the targets of edges of the
+	// given kind along with a key distinguishing them from the patterns of
+	// other kinds ("" for the default patterns).
+	targetPatterns := func(kind string) (string, []*regexp.Regexp) {
+		if req.TargetFilters == nil {
+			return "", patterns
+		}
+		filters, ok := req.TargetFilters(kind)
+		if !ok {
+			return "", patterns
+		}
+		ps, ok := kindPatterns[kind]
+		if !ok {
+			ps = xrefs.ConvertFilters(filters)
+			kindPatterns[kind] = ps
+		}
+		return kind, ps
+	}
 
 	reply := &gpb.EdgesReply{
 		EdgeSets: make(map[string]*gpb.EdgeSet),
 		Nodes:    make(map[string]*cpb.NodeInfo),
 
 		TotalEdgesByKind: make(map[string]int64),
+		RedirectedTicket: redirected,
+	}
+	budget := newReplyBudget()
+	// addNode adds the facts of n matching patterns to the reply.  Each node is
+	// matched at most once against the patterns with the given key.
+	addNode := func(n *srvpb.Node, key string, patterns []*regexp.Regexp) error {
+		if len(patterns) == 0 || nodeTickets.Contains(key+"\n"+n.Ticket) {
+			return nil
+		}
+		nodeTickets.Add(key + "\n" + n.Ticket)
+		info := nodeToInfo(patterns, n)
+		if info == nil {
+			return nil
+		}
+		prev, ok := reply.Nodes[n.Ticket]
+		if !ok {
+			reply.Nodes[n.Ticket] = info
+			return budget.chargeMessage(info)
+		}
+		// Merge the facts selected for another edge kind.
+		added := &cpb.NodeInfo{Facts: make(map[string][]byte)}
+		for name, value := range info.Facts {
+			if _, ok := prev.Facts[name]; !ok {
+				prev.Facts[name] = value
+				added.Facts[name] = value
+			}
+		}
+		return budget.chargeMessage(added)
 	}
-	for r := range rs {
+	// addGroup charges the edges of ng to the budget, unless they were already
+	// charged while being scanned, and adds their target nodes to the reply.
+	addGroup := func(kind string, ng *gpb.EdgeSet_Group, ns []*srvpb.Node, charged bool) error {
+		if !charged {
+			if err := budget.chargeEdges(ng.Edge); err != nil {
+				return err
+			}
+		}
+		key, patterns := targetPatterns(kind)
+		for _, n := range ns {
+			if err := addNode(n, key, patterns); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	scanner, _ := t.staticLookupTables.(edgePageScanner)
+	if t.Paranoid {
+		// Pages are read whole so that they can be validated.
+		scanner = nil
+	}
+	var lookupErr error // the first failed lookup; its ticket is skipped
+	var quotaErr *quota.ExceededError
+	for i, r := range rs {
 		if r.Err == table.ErrNoSuchKey {
+			reply.MissingTicket = append(reply.MissingTicket, req.Tickets[i])
 			continue
 		} else if r.Err != nil {
-			return nil, r.Err
+			if lookupErr == nil {
+				lookupErr = r.Err
+			}
+			continue
+		} else if r.PagedEdgeSet == nil {
+			continue
 		}
 		pes := r.PagedEdgeSet
-		countEdgeKinds(pes, req.Kinds, reply.TotalEdgesByKind)
+		sets = append(sets, pes)
+		if req.TotalOnly {
+			// Count-only lookups skip decoding the inline groups' edges;
+			// their sizes are returned alongside the page indices' counts.
+			counts := make(map[string]int64)
+			countEdgeKinds(pes, req.Kinds, counts)
+			for kind, n := range r.GroupCounts {
+				counts[kind] += n
+			}
+			for kind, n := range counts {
+				reply.TotalEdgesByKind[kind] += n
+			}
+			if len(counts) > 0 {
+				if reply.EdgeCounts == nil {
+					reply.EdgeCounts = make(map[string]*gpb.EdgeCounts)
+				}
+				reply.EdgeCounts[pes.Source.Ticket] = &gpb.EdgeCounts{ByKind: counts}
+			}
+		} else {
+			countEdgeKinds(pes, req.Kinds, reply.TotalEdgesByKind)
+		}
 
 		// Don't scan the EdgeSet_Groups if we're already at the specified page_size.
 		if stats.total == stats.max {
 			continue
 		}
 
+		// Each kind's inline group and EdgePages (e.g. those split off by
+		// CompactEdgeSets) are merged into a single EdgeSet_Group.
 		groups := make(map[string]*gpb.EdgeSet_Group)
-		for _, grp := range pes.Group {
-			if req.Kinds == nil || req.Kinds(grp.Kind) {
-				ng, ns := stats.filter(grp)
-				if ng != nil {
-					for _, n := range ns {
-						if len(patterns) > 0 && !nodeTickets.Contains(n.Ticket) {
-							nodeTickets.Add(n.Ticket)
-							if info := nodeToInfo(patterns, n); info != nil {
-								reply.Nodes[n.Ticket] = info
-							}
-						}
-					}
-					groups[grp.Kind] = ng
-					if stats.total == stats.max {
-						break
-					}
-				}
+		for _, seg := range edgeSegments(pes, req.Kinds) {
+			if stats.total == stats.max {
+				break
 			}
-		}
-
-		// TODO(schroederc): ensure that pes.EdgeSet.Groups and pes.PageIndexes of
-		// the same kind are grouped together in the EdgesReply
-
-		if stats.total != stats.max {
-			for _, idx := range pes.PageIndex {
-				if req.Kinds == nil || req.Kinds(idx.EdgeKind) {
-					if stats.skipPage(idx) {
-						log.Printf("Skipping EdgePage: %s", idx.PageKey)
-						continue
-					}
+			var ng *gpb.EdgeSet_Group
+			var ns []*srvpb.Node
+			var scanned bool
+			if seg.group != nil {
+				ng, ns = stats.filter(seg.group)
+			} else {
+				idx := seg.page
+				if stats.skipPage(idx) {
+					log.Debugf(ctx, "Skipping EdgePage: %s", idx.PageKey)
+					continue
+				}
 
-					log.Printf("Retrieving EdgePage: %s", idx.PageKey)
-					ep, err := t.edgePage(ctx, idx.PageKey)
-					if err == table.ErrNoSuchKey {
-						return nil, fmt.Errorf("internal error: missing edge page: %q", idx.PageKey)
-					} else if err != nil {
-						return nil, fmt.Errorf("edge page lookup error (page key: %q): %v", idx.PageKey, err)
+				log.Debugf(ctx, "Retrieving EdgePage: %s", idx.PageKey)
+				if err := pagekey.Validate(idx.PageKey, pagekey.EdgePage, pes.GetSource().GetTicket(), idx.EdgeKind); err != nil {
+					return nil, fmt.Errorf("internal error: %v", err)
+				}
+				if err := quota.Charge(ctx, t.ReadLimiter, true); errors.As(err, &quotaErr) {
+					// End the page here; the remaining tickets' totals are
+					// still counted.
+					stats.max = stats.total
+					break
+				} else if err != nil {
+					return nil, err
+				}
+				var err error
+				if scanner != nil {
+					// Only the requested edges of the page are decoded.
+					ng, ns, err = stats.scan(ctx, scanner, idx.PageKey, budget)
+					scanned = true
+				} else {
+					var ep *srvpb.EdgePage
+					if ep, err = t.edgePage(ctx, idx.PageKey); err == nil && t.Paranoid {
+						err = tablecheck.ValidateEdgePage(ep, idx.PageKey)
 					}
-
-					ng, ns := stats.filter(ep.EdgesGroup)
-					if ng != nil {
-						for _, n := range ns {
-							if len(patterns) > 0 && !nodeTickets.Contains(n.Ticket) {
-								nodeTickets.Add(n.Ticket)
-								if info := nodeToInfo(patterns, n); info != nil {
-									reply.Nodes[n.Ticket] = info
-								}
-							}
-						}
-						groups[ep.EdgesGroup.Kind] = ng
-						if stats.total == stats.max {
-							break
-						}
+					if err == nil {
+						ng, ns = stats.filter(ep.EdgesGroup)
 					}
 				}
+				var tooLarge *ReplyTooLargeError
+				var corrupt *tablecheck.CorruptionError
+				if err == table.ErrNoSuchKey {
+					return nil, fmt.Errorf("internal error: missing edge page: %q", idx.PageKey)
+				} else if errors.As(err, &tooLarge) || errors.As(err, &corrupt) {
+					return nil, err
+				} else if err != nil {
+					return nil, fmt.Errorf("edge page lookup error (page key: %q): %v", idx.PageKey, err)
+				}
+			}
+			if ng == nil {
+				continue
+			}
+			if err := addGroup(seg.kind, ng, ns, scanned); err != nil {
+				return nil, err
+			}
+			if g, ok := groups[seg.kind]; ok {
+				g.Edge = append(g.Edge, ng.Edge...)
+			} else {
+				groups[seg.kind] = ng
 			}
 		}
 
 		if len(groups) > 0 {
+			for _, g := range groups {
+				sortEdges(g.Edge)
+			}
 			reply.EdgeSets[pes.Source.Ticket] = &gpb.EdgeSet{Groups: groups}
-
-			if len(patterns) > 0 && !nodeTickets.Contains(pes.Source.Ticket) {
-				nodeTickets.Add(pes.Source.Ticket)
-				if info := nodeToInfo(patterns, pes.Source); info != nil {
-					reply.Nodes[pes.Source.Ticket] = info
-				}
+			if err := addNode(pes.Source, "", patterns); err != nil {
+				return nil, err
 			}
 		}
 	}
+	if req.Strict && len(reply.MissingTicket) > 0 {
+		return nil, missingTicketsError(reply.MissingTicket)
+	}
+
 	totalEdgesPossible := int(sumEdgeKinds(reply.TotalEdgesByKind))
 	if stats.total > stats.max {
-		log.Panicf("totalEdges greater than maxEdges: %d > %d", stats.total, stats.max)
+		log.Panicf(ctx, "totalEdges greater than maxEdges: %d > %d", stats.total, stats.max)
 	} else if pageToken+stats.total > totalEdgesPossible && pageToken <= totalEdgesPossible {
-		log.Panicf("pageToken+totalEdges greater than totalEdgesPossible: %d+%d > %d", pageToken, stats.total, totalEdgesPossible)
+		log.Panicf(ctx, "pageToken+totalEdges greater than totalEdgesPossible: %d+%d > %d", pageToken, stats.total, totalEdgesPossible)
 	}
 
-	if pageToken+stats.total != totalEdgesPossible && stats.total != 0 {
+	if pageToken+stats.total != totalEdgesPossible && (stats.total != 0 || quotaErr != nil) {
 		rec, err := proto.Marshal(&ipb.PageToken{Index: int32(pageToken + stats.total)})
 		if err != nil {
 			return nil, fmt.Errorf("internal error: error marshalling page token: %v", err)
 		}
 		reply.NextPageToken = base64.StdEncoding.EncodeToString(rec)
+
+		if p, ok := t.staticLookupTables.(pagePrefetcher); ok {
+			p.prefetchEdgePages(ctx, upcomingEdgePages(sets, req.Kinds, pageToken+stats.total, p.readaheadDepth()))
+		}
 	}
 
+	if lookupErr != nil {
+		e := newBatchLookupError(req.Tickets, rs, lookupErr)
+		e.Reply = reply
+		return nil, e
+	} else if quotaErr != nil {
+		quotaErr.Reply = reply
+		return nil, quotaErr
+	}
 	return reply, nil
 }
 
+// An edgeSegment is a contiguous run of a PagedEdgeSet's edges of a single
+// kind: either one of its inline groups or one of its EdgePages.
+type edgeSegment struct {
+	kind  string
+	group *srvpb.EdgeGroup
+	page  *srvpb.PageIndex
+}
+
+// edgeSegments returns the segments of pes with kinds allowed by kindFilter in
+// the order their edges are paged through: kinds are ordered by their first
+// inline group or EdgePage and, within each kind, the inline group is followed
+// by the kind's EdgePages in index order.  Page tokens index into the edges of
+// this sequence, so each kind's edges are contiguous across pages.
+func edgeSegments(pes *srvpb.PagedEdgeSet, kindFilter func(string) bool) []edgeSegment {
+	var segs []edgeSegment
+	for _, grp := range pes.Group {
+		if kindFilter == nil || kindFilter(grp.Kind) {
+			segs = append(segs, edgeSegment{kind: grp.Kind, group: grp})
+		}
+	}
+	for _, idx := range pes.PageIndex {
+		if kindFilter == nil || kindFilter(idx.EdgeKind) {
+			segs = append(segs, edgeSegment{kind: idx.EdgeKind, page: idx})
+		}
+	}
+	order := make(map[string]int)
+	for _, seg := range segs {
+		if _, ok := order[seg.kind]; !ok {
+			order[seg.kind] = len(order)
+		}
+	}
+	sort.SliceStable(segs, func(i, j int) bool { return order[segs[i].kind] < order[segs[j].kind] })
+	return segs
+}
+
+// sortEdges sorts the edges of a merged group by ordinal and then by target
+// ticket, the order in which the pipeline writes each kind's edges, so that
+// the edges of a kind's segments are ordered in each reply.
+func sortEdges(edges []*gpb.EdgeSet_Group_Edge) {
+	sort.SliceStable(edges, func(i, j int) bool {
+		return compare.Compare(edges[i].Ordinal, edges[j].Ordinal).
+			AndThen(edges[i].TargetTicket, edges[j].TargetTicket) == compare.LT
+	})
+}
+
 func countEdgeKinds(pes *srvpb.PagedEdgeSet, kindFilter func(string) bool, totals map[string]int64) {
 	for _, grp := range pes.Group {
 		if kindFilter == nil || kindFilter(grp.Kind) {
@@ -418,6 +1009,36 @@ func (s *filterStats) filter(g *srvpb.EdgeGroup) (*gpb.EdgeSet_Group, []*srvpb.N
 	}, targets
 }
 
+// scan is like filter for the EdgePage with the given key, but decodes only the
+// edges it returns, charging each to budget as it is decoded so that an
+// oversized reply is abandoned early.  The returned edges need not be charged
+// again.
+func (s *filterStats) scan(ctx context.Context, sc edgePageScanner, key string, budget *replyBudget) (*gpb.EdgeSet_Group, []*srvpb.Node, error) {
+	skip := s.skip
+	s.skip = 0
+	var edges []*srvpb.EdgeGroup_Edge
+	err := sc.scanEdgePage(ctx, key, skip, func(e *srvpb.EdgeGroup_Edge) error {
+		if s.total >= s.max {
+			return errStopScan
+		}
+		edges = append(edges, e)
+		s.total++
+		return budget.chargeEdges(e2e([]*srvpb.EdgeGroup_Edge{e}))
+	})
+	if err == errStopScan {
+		err = nil
+	}
+	if err != nil || len(edges) == 0 {
+		return nil, nil, err
+	}
+
+	targets := make([]*srvpb.Node, len(edges))
+	for i, e := range edges {
+		targets[i] = e.Target
+	}
+	return &gpb.EdgeSet_Group{Edge: e2e(edges)}, targets, nil
+}
+
 func e2e(es []*srvpb.EdgeGroup_Edge) []*gpb.EdgeSet_Group_Edge {
 	edges := make([]*gpb.EdgeSet_Group_Edge, len(es))
 	for i, e := range es {
@@ -431,12 +1052,12 @@ func e2e(es []*srvpb.EdgeGroup_Edge) []*gpb.EdgeSet_Group_Edge {
 
 // NewSplitTable returns a table based on the given serving tables for each API
 // component.
-func NewSplitTable(c *SplitTable) *Table { return &Table{c} }
+func NewSplitTable(c *SplitTable) *Table { return &Table{staticLookupTables: c} }
 
 // NewCombinedTable returns a table for the given combined graph lookup table.
 // The table's keys are expected to be constructed using only the EdgeSetKey,
 // EdgePageKey, and DecorationsKey functions.
-func NewCombinedTable(t table.Proto) *Table { return &Table{&combinedTable{t}} }
+func NewCombinedTable(t table.Proto) *Table { return &Table{staticLookupTables: &combinedTable{t}} }
 
 // EdgeSetKey returns the edgeset CombinedTable key for the given source ticket.
 func EdgeSetKey(ticket string) []byte {
@@ -448,16 +1069,66 @@ func EdgePageKey(key string) []byte {
 	return []byte(edgePagesTablePrefix + key)
 }
 
+// TicketAliasKey returns the ticket alias CombinedTable key for the given
+// aliased ticket.
+func TicketAliasKey(ticket string) []byte {
+	return []byte(ticketAliasTablePrefix + ticket)
+}
+
+// FactHashesKey returns the NodeFactHashes CombinedTable key for the given node
+// ticket.
+func FactHashesKey(ticket string) []byte {
+	return []byte(factHashesTablePrefix + ticket)
+}
+
+// FactHashesGenerationKey is the key of the srvpb.FactHashesGeneration in both
+// a CombinedTable and a SplitTable.FactHashes table.
+var FactHashesGenerationKey = []byte("factHashesGeneration")
+
+// TicketIndexKeyPrefix is the prefix of every ticket index CombinedTable key.
+var TicketIndexKeyPrefix = []byte(ticketIndexTablePrefix)
+
+// TicketIndexKey returns the ticket index CombinedTable key for the given
+// node ticket.
+func TicketIndexKey(ticket string) ([]byte, error) {
+	uri, err := kytheuri.Parse(ticket)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(ticketIndexTablePrefix + ticketIndexKey(uri)), nil
+}
+
 type combinedTable struct{ table.Proto }
 
-func (c *combinedTable) pagedEdgeSets(ctx context.Context, tickets []string) (<-chan edgeSetResult, error) {
+func (c *combinedTable) pagedEdgeSets(ctx context.Context, tickets []string, kindFilter func(string) bool, countOnly bool) (<-chan edgeSetResult, error) {
 	keys := make([][]byte, len(tickets))
 	for i, ticket := range tickets {
 		keys[i] = EdgeSetKey(ticket)
 	}
-	return lookupPagedEdgeSets(ctx, c, keys)
+	return lookupPagedEdgeSets(ctx, c.Proto, keys, kindFilter, countOnly)
 }
 func (c *combinedTable) edgePage(ctx context.Context, key string) (*srvpb.EdgePage, error) {
 	var ep srvpb.EdgePage
 	return &ep, c.Lookup(ctx, EdgePageKey(key), &ep)
 }
+func (c *combinedTable) ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error) {
+	var a srvpb.TicketAlias
+	return &a, c.Lookup(ctx, TicketAliasKey(ticket), &a)
+}
+
+func (c *combinedTable) nodeFactHashes(ctx context.Context, ticket string) (*srvpb.NodeFactHashes, error) {
+	var h srvpb.NodeFactHashes
+	return &h, c.Lookup(ctx, FactHashesKey(ticket), &h)
+}
+func (c *combinedTable) factHashesGeneration(ctx context.Context) (*srvpb.FactHashesGeneration, error) {
+	var g srvpb.FactHashesGeneration
+	return &g, c.Lookup(ctx, FactHashesGenerationKey, &g)
+}
+
+func (c *combinedTable) ticketIndex(ctx context.Context, prefix []byte, f func(string) error) error {
+	kv, ok := c.Proto.(*table.KVProto)
+	if !ok {
+		return errNoTicketIndex
+	}
+	return scanTicketIndex(ctx, kv.DB, append([]byte(ticketIndexTablePrefix), prefix...), f)
+}
@@ -18,8 +18,22 @@ package graph
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/serving/pagekey"
+	"kythe.io/kythe/go/serving/quota"
+	"kythe.io/kythe/go/serving/readahead"
+	"kythe.io/kythe/go/storage/inmemory"
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/test/testutil"
 	"kythe.io/kythe/go/util/kytheuri"
@@ -28,10 +42,13 @@ import (
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/unicode"
 	"golang.org/x/text/transform"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
 	gpb "kythe.io/kythe/proto/graph_go_proto"
+	ipb "kythe.io/kythe/proto/internal_go_proto"
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
 )
 
@@ -294,6 +311,78 @@ func TestNodes(t *testing.T) {
 	}
 }
 
+// recordedRequests is a HealthRecorder keeping each recorded request.
+type recordedRequests struct {
+	tickets [][]string
+	errs    []error
+}
+
+func (r *recordedRequests) RecordRequest(tickets []string, latency time.Duration, err error) {
+	r.tickets = append(r.tickets, tickets)
+	r.errs = append(r.errs, err)
+}
+
+func TestHealthRecorder(t *testing.T) {
+	const ticket = "kythe://someCorpus?lang=otpl#signature"
+	health := &recordedRequests{}
+	st := tbl.Construct(t)
+	st.Health = health
+
+	_, err := st.Nodes(ctx, &gpb.NodesRequest{Ticket: []string{ticket}})
+	testutil.Fatalf(t, "NodesRequest error: %v", err)
+	_, err = st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{ticket}})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	_, edgesErr := st.Edges(ctx, &gpb.EdgesRequest{})
+	if edgesErr == nil {
+		t.Fatal("Expected error for EdgesRequest without tickets")
+	}
+
+	if err := testutil.DeepEqual([][]string{{ticket}, {ticket}, nil}, health.tickets); err != nil {
+		t.Error(err)
+	}
+	if len(health.errs) != 3 || health.errs[0] != nil || health.errs[1] != nil || health.errs[2] != edgesErr {
+		t.Errorf("Unexpected recorded errors: %v", health.errs)
+	}
+}
+
+func TestNodesMaxFactBytes(t *testing.T) {
+	const ticket = "kythe://someCorpus?lang=otpl?path=/some/valid/path#a83md71"
+	const text = "; some file content here\nfinal line\n"
+	st := tbl.Construct(t)
+
+	val := flag.Lookup("default_max_node_fact_bytes").Value.String()
+	testutil.Fatalf(t, "flag.Set: %v", flag.Set("default_max_node_fact_bytes", "6"))
+	defer flag.Set("default_max_node_fact_bytes", val)
+
+	tests := []struct {
+		filter    []string
+		maxBytes  int32
+		text      string
+		truncated []string
+	}{
+		{text: text[:6], truncated: []string{"/kythe/text"}},
+		{filter: []string{"/kythe/text"}, text: text},
+		{filter: []string{"/kythe/text"}, maxBytes: 5, text: text[:5], truncated: []string{"/kythe/text"}},
+		{maxBytes: -1, text: text},
+	}
+	for _, test := range tests {
+		reply, err := st.Nodes(ctx, &gpb.NodesRequest{
+			Ticket:       []string{ticket},
+			Filter:       test.filter,
+			MaxFactBytes: test.maxBytes,
+		})
+		testutil.Fatalf(t, "NodesRequest error: %v", err)
+
+		ni := reply.Nodes[ticket]
+		if found := string(ni.GetFacts()["/kythe/text"]); found != test.text {
+			t.Errorf("Nodes(filter: %v, max_fact_bytes: %d): expected text %q; found %q", test.filter, test.maxBytes, test.text, found)
+		}
+		if err := testutil.DeepEqual(test.truncated, ni.GetTruncatedFacts()); err != nil {
+			t.Errorf("Nodes(filter: %v, max_fact_bytes: %d): %v", test.filter, test.maxBytes, err)
+		}
+	}
+}
+
 func TestNodesMissing(t *testing.T) {
 	st := tbl.Construct(t)
 	reply, err := st.Nodes(ctx, &gpb.NodesRequest{
@@ -303,6 +392,178 @@ func TestNodesMissing(t *testing.T) {
 
 	if len(reply.Nodes) > 0 {
 		t.Fatalf("Received unexpected reply for missing node: {%v}", reply)
+	} else if err := testutil.DeepEqual([]string{"kythe:#someMissingTicket"}, reply.MissingTicket); err != nil {
+		t.Error(err)
+	}
+
+	_, err = st.Nodes(ctx, &gpb.NodesRequest{
+		Ticket: []string{"kythe:#someMissingTicket"},
+		Strict: true,
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error for strict request; found %v", err)
+	}
+}
+
+func TestNodesTicketStatuses(t *testing.T) {
+	const (
+		found    = "kythe://c?lang=l#found"
+		noFacts  = "kythe://c?lang=l#noFacts"
+		corrupt  = "kythe://c?lang=l#corrupt"
+		notFound = "kythe://c?lang=l#notFound"
+	)
+	db := inmemory.NewKeyValueDB()
+	p := &table.KVProto{db}
+	testutil.Fatalf(t, "Error writing edge set: %v", p.Put(ctx, EdgeSetKey(found), &srvpb.PagedEdgeSet{
+		Source: &srvpb.Node{
+			Ticket: found,
+			Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("record")}},
+		},
+	}))
+	testutil.Fatalf(t, "Error writing edge set: %v", p.Put(ctx, EdgeSetKey(noFacts), &srvpb.PagedEdgeSet{
+		Source: &srvpb.Node{Ticket: noFacts},
+	}))
+	w, err := db.Writer(ctx)
+	testutil.Fatalf(t, "Writer error: %v", err)
+	testutil.Fatalf(t, "Write error: %v", w.Write(EdgeSetKey(corrupt), []byte("\xffnot a proto")))
+	testutil.Fatalf(t, "Close error: %v", w.Close())
+	st := NewCombinedTable(p)
+
+	req := &gpb.NodesRequest{Ticket: []string{found, noFacts, corrupt, notFound}}
+	if _, err := st.Nodes(ctx, req); err == nil {
+		t.Fatal("Expected error for corrupt node without ticket statuses")
+	}
+
+	req.TicketStatuses = true
+	reply, err := st.Nodes(ctx, req)
+	testutil.Fatalf(t, "NodesRequest error: %v", err)
+	statuses := make(map[string]gpb.NodesReply_TicketStatus_Code)
+	for ticket, s := range reply.TicketStatus {
+		statuses[ticket] = s.Code
+		if (s.Code == gpb.NodesReply_TicketStatus_ERROR) != (s.Message != "") {
+			t.Errorf("Unexpected message for %q: %v", ticket, s)
+		}
+	}
+	if err := testutil.DeepEqual(map[string]gpb.NodesReply_TicketStatus_Code{
+		found:    gpb.NodesReply_TicketStatus_FOUND,
+		noFacts:  gpb.NodesReply_TicketStatus_NO_MATCHING_FACTS,
+		corrupt:  gpb.NodesReply_TicketStatus_ERROR,
+		notFound: gpb.NodesReply_TicketStatus_NOT_FOUND,
+	}, statuses); err != nil {
+		t.Error(err)
+	}
+	if _, ok := reply.Nodes[found]; !ok || len(reply.Nodes) != 1 {
+		t.Errorf("Expected only %q in nodes; found %v", found, reply.Nodes)
+	}
+	if err := testutil.DeepEqual([]string{notFound}, reply.MissingTicket); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNodesChangedSince(t *testing.T) {
+	const (
+		changed   = "kythe://c?lang=l#changed"
+		unchanged = "kythe://c?lang=l#unchanged"
+		added     = "kythe://c?lang=l#added"
+		buildID   = "build-1"
+	)
+	fact := func(name, val string) *cpb.Fact { return &cpb.Fact{Name: name, Value: []byte(val)} }
+	p := make(testProtoTable)
+	put := func(key []byte, msg proto.Message) {
+		testutil.Fatalf(t, "Put error: %v", p.Put(ctx, key, msg))
+	}
+	put(FactHashesGenerationKey, &srvpb.FactHashesGeneration{BuildId: buildID})
+	put(FactHashesKey(changed), NodeFactHashes(&srvpb.Node{
+		Ticket: changed,
+		Fact: []*cpb.Fact{
+			fact("/kythe/node/kind", "record"),
+			fact("/kythe/subkind", "class"),
+			fact("/kythe/text", "old"),
+			fact("/kythe/complete", "definition"),
+		},
+	}))
+	put(FactHashesKey(unchanged), NodeFactHashes(&srvpb.Node{
+		Ticket: unchanged,
+		Fact:   []*cpb.Fact{fact("/kythe/node/kind", "file")},
+	}))
+	for _, n := range []*srvpb.Node{{
+		Ticket: changed,
+		Fact: []*cpb.Fact{
+			fact("/kythe/node/kind", "record"),
+			fact("/kythe/text", "new"),
+			fact("/kythe/loc/start", "4"),
+		},
+	}, {
+		Ticket: unchanged,
+		Fact:   []*cpb.Fact{fact("/kythe/node/kind", "file")},
+	}, {
+		Ticket: added,
+		Fact:   []*cpb.Fact{fact("/kythe/node/kind", "function")},
+	}} {
+		put(EdgeSetKey(n.Ticket), &srvpb.PagedEdgeSet{Source: n})
+	}
+	st := NewCombinedTable(p)
+
+	reply, err := st.Nodes(ctx, &gpb.NodesRequest{
+		Ticket:              []string{changed, unchanged, added},
+		ChangedSinceBuildId: buildID,
+		TicketStatuses:      true,
+	})
+	testutil.Fatalf(t, "NodesRequest error: %v", err)
+	if err := testutil.DeepEqual(map[string]*cpb.NodeInfo{
+		changed: {Facts: map[string][]byte{
+			"/kythe/text":      []byte("new"),
+			"/kythe/loc/start": []byte("4"),
+		}},
+		added: {Facts: map[string][]byte{"/kythe/node/kind": []byte("function")}},
+	}, reply.Nodes); err != nil {
+		t.Errorf("Nodes: %v", err)
+	}
+	if err := testutil.DeepEqual(map[string]*gpb.NodesReply_RemovedFacts{
+		changed: {Name: []string{"/kythe/complete", "/kythe/subkind"}},
+	}, reply.RemovedFacts); err != nil {
+		t.Errorf("RemovedFacts: %v", err)
+	}
+	statuses := make(map[string]gpb.NodesReply_TicketStatus_Code)
+	for ticket, s := range reply.TicketStatus {
+		statuses[ticket] = s.Code
+	}
+	if err := testutil.DeepEqual(map[string]gpb.NodesReply_TicketStatus_Code{
+		changed:   gpb.NodesReply_TicketStatus_FOUND,
+		unchanged: gpb.NodesReply_TicketStatus_UNCHANGED,
+		added:     gpb.NodesReply_TicketStatus_FOUND,
+	}, statuses); err != nil {
+		t.Errorf("TicketStatus: %v", err)
+	}
+
+	// Filters apply to removed facts as well as changed facts.
+	reply, err = st.Nodes(ctx, &gpb.NodesRequest{
+		Ticket:              []string{changed},
+		Filter:              []string{"/kythe/subkind", "/kythe/node/kind"},
+		ChangedSinceBuildId: buildID,
+	})
+	testutil.Fatalf(t, "NodesRequest error: %v", err)
+	if len(reply.Nodes) != 0 {
+		t.Errorf("Unexpected nodes for filtered request: %v", reply.Nodes)
+	}
+	if err := testutil.DeepEqual(map[string]*gpb.NodesReply_RemovedFacts{
+		changed: {Name: []string{"/kythe/subkind"}},
+	}, reply.RemovedFacts); err != nil {
+		t.Errorf("Filtered RemovedFacts: %v", err)
+	}
+
+	for _, build := range []string{"build-0", buildID} {
+		st := NewCombinedTable(p)
+		if build == buildID {
+			st = tbl.Construct(t) // no stored fact hashes
+		}
+		_, err := st.Nodes(ctx, &gpb.NodesRequest{
+			Ticket:              []string{changed},
+			ChangedSinceBuildId: build,
+		})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("Nodes(changed_since_build_id: %q): expected FailedPrecondition error; found %v", build, err)
+		}
 	}
 }
 
@@ -446,6 +707,330 @@ func TestEdgesMissing(t *testing.T) {
 
 	if len(reply.EdgeSets) > 0 || len(reply.Nodes) > 0 || reply.NextPageToken != "" {
 		t.Fatalf("Received unexpected reply for missing edges: {%v}", reply)
+	} else if err := testutil.DeepEqual([]string{"kythe:#someMissingTicket"}, reply.MissingTicket); err != nil {
+		t.Error(err)
+	}
+
+	_, err = st.Edges(ctx, &gpb.EdgesRequest{
+		Ticket: []string{tbl.EdgeSets[0].Source.Ticket, "kythe:#someMissingTicket"},
+		Strict: true,
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error for strict request; found %v", err)
+	}
+}
+
+func TestEdgesCountOnly(t *testing.T) {
+	st := tbl.Construct(t)
+	for _, pes := range tbl.EdgeSets {
+		req := &gpb.EdgesRequest{
+			Ticket: []string{pes.Source.Ticket},
+			Filter: []string{"**"},
+		}
+		full, err := st.Edges(ctx, req)
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+
+		req.CountOnly = true
+		reply, err := st.Edges(ctx, req)
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+
+		expected := &gpb.EdgesReply{
+			EdgeSets:         make(map[string]*gpb.EdgeSet),
+			Nodes:            make(map[string]*cpb.NodeInfo),
+			TotalEdgesByKind: full.TotalEdgesByKind,
+		}
+		if len(full.TotalEdgesByKind) > 0 {
+			expected.EdgeCounts = map[string]*gpb.EdgeCounts{
+				pes.Source.Ticket: {ByKind: full.TotalEdgesByKind},
+			}
+		}
+		if err := testutil.DeepEqual(expected, reply); err != nil {
+			t.Errorf("CountOnly(%s): %v", pes.Source.Ticket, err)
+		}
+	}
+}
+
+func TestEdgesCountOnlyPerSource(t *testing.T) {
+	st := tbl.Construct(t)
+	req := &gpb.EdgesRequest{Filter: []string{"**"}, CountOnly: true}
+	expected := make(map[string]*gpb.EdgeCounts)
+	totals := make(map[string]int64)
+	for _, pes := range tbl.EdgeSets {
+		req.Ticket = append(req.Ticket, pes.Source.Ticket)
+		reply, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{pes.Source.Ticket}, CountOnly: true})
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+		if len(reply.TotalEdgesByKind) > 0 {
+			expected[pes.Source.Ticket] = &gpb.EdgeCounts{ByKind: reply.TotalEdgesByKind}
+		}
+		for kind, n := range reply.TotalEdgesByKind {
+			totals[kind] += n
+		}
+	}
+
+	reply, err := st.Edges(ctx, req)
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	if err := testutil.DeepEqual(expected, reply.EdgeCounts); err != nil {
+		t.Errorf("EdgeCounts: %v", err)
+	}
+	if err := testutil.DeepEqual(totals, reply.TotalEdgesByKind); err != nil {
+		t.Errorf("TotalEdgesByKind: %v", err)
+	}
+}
+
+func TestEdgeKinds(t *testing.T) {
+	counter := &countingTables{staticLookupTables: tbl.Construct(t).staticLookupTables}
+	st := &Table{staticLookupTables: counter}
+
+	tickets := []string{"kythe:#someMissingTicket"}
+	expected := make(map[string]map[string]int64)
+	for _, pes := range tbl.EdgeSets {
+		tickets = append(tickets, pes.Source.Ticket)
+		reply, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{pes.Source.Ticket}, CountOnly: true})
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+		expected[pes.Source.Ticket] = reply.TotalEdgesByKind
+	}
+
+	kinds, err := st.EdgeKinds(ctx, tickets)
+	testutil.Fatalf(t, "EdgeKinds error: %v", err)
+	if err := testutil.DeepEqual(expected, kinds); err != nil {
+		t.Error(err)
+	}
+	if len(counter.reads) != 0 {
+		t.Errorf("Unexpected EdgePage reads: %v", counter.reads)
+	}
+
+	if _, err := st.EdgeKinds(ctx, nil); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for empty tickets; found %v", err)
+	}
+}
+
+func TestEdgesCanonicalKinds(t *testing.T) {
+	const (
+		fn = "kythe://c?lang=go#fn"
+		p0 = "kythe://c?lang=go#p0"
+		p1 = "kythe://c?lang=go#p1"
+	)
+	edge := func(ticket string) *srvpb.EdgeGroup_Edge {
+		return &srvpb.EdgeGroup_Edge{Target: &srvpb.Node{Ticket: ticket}}
+	}
+	st := (&testTable{EdgeSets: []*srvpb.PagedEdgeSet{{
+		Source: &srvpb.Node{Ticket: fn},
+		Group: []*srvpb.EdgeGroup{
+			{Kind: "/kythe/edge/param.1", Edge: []*srvpb.EdgeGroup_Edge{edge(p1)}},
+			{Kind: "/kythe/edge/param.0", Edge: []*srvpb.EdgeGroup_Edge{edge(p0)}},
+			{Kind: "/kythe/edge/childof", Edge: []*srvpb.EdgeGroup_Edge{edge(p0)}},
+		},
+	}}}).Construct(t)
+
+	reply, err := st.Edges(ctx, &gpb.EdgesRequest{
+		Ticket:         []string{fn},
+		Kind:           []string{"/kythe/edge/param"},
+		CanonicalKinds: true,
+	})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+
+	expected := &gpb.EdgesReply{
+		EdgeSets: map[string]*gpb.EdgeSet{fn: {Groups: map[string]*gpb.EdgeSet_Group{
+			"/kythe/edge/param": {Edge: []*gpb.EdgeSet_Group_Edge{
+				{TargetTicket: p0},
+				{TargetTicket: p1, Ordinal: 1},
+			}},
+		}}},
+		Nodes:            make(map[string]*cpb.NodeInfo),
+		TotalEdgesByKind: map[string]int64{"/kythe/edge/param": 2},
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+
+	reply, err = st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{fn}})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	if _, ok := reply.EdgeSets[fn].GetGroups()["/kythe/edge/param.1"]; !ok {
+		t.Errorf("Expected raw kinds; found %v", reply)
+	}
+}
+
+func TestEdgesKindFilter(t *testing.T) {
+	const (
+		fn     = "kythe://c?lang=go#fn"
+		parent = "kythe://c?lang=go#parent"
+		used   = "kythe://c?lang=go#used"
+		param  = "kythe://c?lang=go#param"
+	)
+	node := func(ticket, kind string) *srvpb.Node {
+		return &srvpb.Node{
+			Ticket: ticket,
+			Fact:   makeFactList("/kythe/node/kind", kind, "/kythe/text", "text of "+kind),
+		}
+	}
+	edge := func(n *srvpb.Node) []*srvpb.EdgeGroup_Edge {
+		return []*srvpb.EdgeGroup_Edge{{Target: n}}
+	}
+	st := (&testTable{EdgeSets: []*srvpb.PagedEdgeSet{{
+		Source: node(fn, "function"),
+		Group: []*srvpb.EdgeGroup{
+			{Kind: "/kythe/edge/childof", Edge: edge(node(parent, "record"))},
+			{Kind: "/kythe/edge/param.0", Edge: edge(node(param, "variable"))},
+			{Kind: "/kythe/edge/ref", Edge: edge(node(used, "constant"))},
+			{Kind: "/kythe/edge/ref/call", Edge: edge(node(param, "variable"))},
+			{Kind: "/kythe/edge/typed", Edge: edge(node(parent, "record"))},
+		},
+	}}}).Construct(t)
+
+	reply, err := st.Edges(ctx, &gpb.EdgesRequest{
+		Ticket: []string{fn},
+		Filter: []string{"/kythe/node/kind"},
+		KindFilter: []*gpb.EdgesRequest_KindFilter{
+			{Kind: "/kythe/edge/childof", Filter: []string{"**"}},
+			{Kind: "/kythe/edge/ref"},
+			{Kind: "/kythe/edge/ref/call"},
+		},
+	})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+
+	kindOnly := func(kind string) *cpb.NodeInfo {
+		return &cpb.NodeInfo{Facts: map[string][]byte{"/kythe/node/kind": []byte(kind)}}
+	}
+	expected := map[string]*cpb.NodeInfo{
+		fn: kindOnly("function"),
+		// Facts selected for childof and typed edges are merged.
+		parent: {Facts: map[string][]byte{
+			"/kythe/node/kind": []byte("record"),
+			"/kythe/text":      []byte("text of record"),
+		}},
+		param: kindOnly("variable"),
+	}
+	if err := testutil.DeepEqual(expected, reply.Nodes); err != nil {
+		t.Error(err)
+	}
+
+	_, err = st.Edges(ctx, &gpb.EdgesRequest{
+		Ticket: []string{fn},
+		KindFilter: []*gpb.EdgesRequest_KindFilter{
+			{Kind: "/kythe/edge/ref"},
+			{Kind: "/kythe/edge/ref"},
+		},
+	})
+	var fields []string
+	for _, v := range validate.FieldViolations(err) {
+		fields = append(fields, v.Field)
+	}
+	if err := testutil.DeepEqual([]string{"kind_filter[1].kind"}, fields); err != nil {
+		t.Errorf("%v: %v", err, fields)
+	}
+}
+
+func TestEdgesParanoid(t *testing.T) {
+	const fn = "kythe://c?lang=go#fn"
+	st := (&testTable{EdgeSets: []*srvpb.PagedEdgeSet{{
+		Source: &srvpb.Node{Ticket: fn},
+		Group: []*srvpb.EdgeGroup{
+			{Kind: "/kythe/edge/childof", Edge: []*srvpb.EdgeGroup_Edge{{}}},
+		},
+	}}}).Construct(t)
+
+	st.Paranoid = true
+	req := &gpb.EdgesRequest{Ticket: []string{fn}}
+	if _, err := st.Edges(ctx, req); status.Code(err) != codes.DataLoss {
+		t.Errorf("Expected DataLoss error for corrupt PagedEdgeSet; found %v", err)
+	}
+
+	// EdgePages are validated as well.
+	const kind = "/kythe/edge/childof"
+	key := pagekey.New(pagekey.EdgePage, fn, kind, 0)
+	st = (&testTable{
+		EdgeSets: []*srvpb.PagedEdgeSet{{
+			Source:    &srvpb.Node{Ticket: fn},
+			PageIndex: []*srvpb.PageIndex{{PageKey: key, EdgeKind: kind, EdgeCount: 1}},
+		}},
+		EdgePages: []*srvpb.EdgePage{{
+			PageKey:      key,
+			SourceTicket: fn,
+			EdgesGroup:   &srvpb.EdgeGroup{Kind: kind, Edge: []*srvpb.EdgeGroup_Edge{{}}},
+		}},
+	}).Construct(t)
+	st.Paranoid = true
+	if _, err := st.Edges(ctx, req); status.Code(err) != codes.DataLoss {
+		t.Errorf("Expected DataLoss error for corrupt EdgePage; found %v", err)
+	}
+}
+
+func TestEdgesKindPreset(t *testing.T) {
+	const (
+		fn  = "kythe://c?lang=go#fn"
+		tgt = "kythe://c?lang=go#tgt"
+	)
+	kinds := []string{
+		"/kythe/edge/typed",
+		"/kythe/edge/childof",
+		"/kythe/edge/param.0",
+		"%/kythe/edge/overrides",
+		"%/kythe/edge/childof/context",
+		"%/kythe/edge/ref/call",
+		"%/kythe/edge/defines/binding",
+		"%/kythe/edge/tagged",
+	}
+	var groups []*srvpb.EdgeGroup
+	for _, kind := range kinds {
+		groups = append(groups, &srvpb.EdgeGroup{
+			Kind: kind,
+			Edge: []*srvpb.EdgeGroup_Edge{{Target: &srvpb.Node{Ticket: tgt}}},
+		})
+	}
+	st := (&testTable{EdgeSets: []*srvpb.PagedEdgeSet{{
+		Source: &srvpb.Node{Ticket: fn},
+		Group:  groups,
+	}}}).Construct(t)
+
+	tests := []struct {
+		preset gpb.EdgesRequest_KindPreset
+		kind   []string
+		want   []string
+	}{
+		{gpb.EdgesRequest_ALL_KINDS, nil, kinds},
+		{gpb.EdgesRequest_SEMANTIC, nil, []string{"%/kythe/edge/overrides", "/kythe/edge/typed"}},
+		{gpb.EdgesRequest_STRUCTURE, nil, []string{"%/kythe/edge/childof/context", "/kythe/edge/childof", "/kythe/edge/param.0"}},
+		{gpb.EdgesRequest_ALL_FORWARD, nil, []string{"/kythe/edge/childof", "/kythe/edge/param.0", "/kythe/edge/typed"}},
+		{gpb.EdgesRequest_ALL_REVERSE, []string{"/kythe/edge/typed", "%/kythe/edge/tagged"}, []string{"%/kythe/edge/tagged"}},
+	}
+	for _, test := range tests {
+		reply, err := st.Edges(ctx, &gpb.EdgesRequest{
+			Ticket:     []string{fn},
+			Kind:       test.kind,
+			KindPreset: test.preset,
+		})
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+		var found []string
+		for kind := range reply.EdgeSets[fn].GetGroups() {
+			found = append(found, kind)
+		}
+		sort.Strings(found)
+		want := append([]string(nil), test.want...)
+		sort.Strings(want)
+		if err := testutil.DeepEqual(want, found); err != nil {
+			t.Errorf("%v: %v", test.preset, err)
+		}
+	}
+
+	_, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{fn}, KindPreset: 42})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error for unknown preset; found %v", err)
+	}
+}
+
+func TestEdgesInvalidRequest(t *testing.T) {
+	st := tbl.Construct(t)
+	_, err := st.Edges(ctx, &gpb.EdgesRequest{
+		Ticket:    []string{"bad:ticket"},
+		PageSize:  -5,
+		PageToken: "!notbase64",
+	})
+	var fields []string
+	for _, v := range validate.FieldViolations(err) {
+		fields = append(fields, v.Field)
+	}
+	if err := testutil.DeepEqual([]string{"ticket[0]", "page_size", "page_token"}, fields); err != nil {
+		t.Errorf("%v: %v", err, fields)
 	}
 }
 
@@ -493,10 +1078,640 @@ func edgeSet(kinds []string, pes *srvpb.PagedEdgeSet, pages []*srvpb.EdgePage) *
 	return es
 }
 
+func TestCompactEdgeSets(t *testing.T) {
+	db := inmemory.NewKeyValueDB()
+	p := &table.KVProto{db}
+
+	src := getNode("kythe://someCorpus?lang=der#big")
+	var edges []*srvpb.EdgeGroup_Edge
+	for i := 0; i < 5; i++ {
+		edges = append(edges, &srvpb.EdgeGroup_Edge{
+			Target:  getNode(fmt.Sprintf("kythe://someCorpus?lang=der#target%d", i)),
+			Ordinal: int32(i),
+		})
+	}
+	big := &srvpb.PagedEdgeSet{
+		Source: src,
+		Group: []*srvpb.EdgeGroup{{
+			Kind: "someEdgeKind",
+			Edge: edges,
+		}},
+	}
+	small := &srvpb.PagedEdgeSet{
+		Source: getNode("kythe://someCorpus?lang=der#small"),
+		Group: []*srvpb.EdgeGroup{{
+			Kind: "someEdgeKind",
+			Edge: edges[:1],
+		}},
+	}
+	for _, es := range []*srvpb.PagedEdgeSet{big, small} {
+		testutil.Fatalf(t, "Error writing edge set: %v", p.Put(ctx, EdgeSetKey(es.Source.Ticket), es))
+	}
+
+	stats, err := CompactEdgeSets(ctx, db, &CompactOptions{PageSize: 2})
+	testutil.Fatalf(t, "CompactEdgeSets error: %v", err)
+	if err := testutil.DeepEqual(&CompactionStats{EdgeSets: 2, Compacted: 1, PagesWritten: 2}, stats); err != nil {
+		t.Error(err)
+	}
+
+	var compacted srvpb.PagedEdgeSet
+	testutil.Fatalf(t, "Error reading edge set: %v", p.Lookup(ctx, EdgeSetKey(src.Ticket), &compacted))
+	if n := len(compacted.Group[0].Edge); n != 2 {
+		t.Errorf("Expected 2 edges in compacted group; found %d", n)
+	}
+	if n := len(compacted.PageIndex); n != 2 {
+		t.Errorf("Expected 2 page indices; found %d", n)
+	}
+
+	reply, err := NewCombinedTable(p).Edges(ctx, &gpb.EdgesRequest{Ticket: []string{src.Ticket}})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	var found []string
+	for _, e := range reply.EdgeSets[src.Ticket].Groups["someEdgeKind"].Edge {
+		found = append(found, e.TargetTicket)
+	}
+	var expected []string
+	for _, e := range edges {
+		expected = append(expected, e.Target.Ticket)
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Error(err)
+	}
+
+	// Compaction is idempotent.
+	stats, err = CompactEdgeSets(ctx, db, &CompactOptions{PageSize: 2})
+	testutil.Fatalf(t, "CompactEdgeSets error: %v", err)
+	if stats.Compacted != 0 {
+		t.Errorf("Expected no further compaction; found %+v", stats)
+	}
+}
+
+func TestEdgesPagedStreaming(t *testing.T) {
+	db := inmemory.NewKeyValueDB()
+	p := &table.KVProto{db}
+
+	src := getNode("kythe://someCorpus?lang=der#src")
+	var edges []*srvpb.EdgeGroup_Edge
+	for i := 0; i < 6; i++ {
+		edges = append(edges, &srvpb.EdgeGroup_Edge{
+			Target:  getNode(fmt.Sprintf("kythe://someCorpus?lang=der#target%d", i)),
+			Ordinal: int32(i),
+		})
+	}
+	pes := &srvpb.PagedEdgeSet{
+		Source: src,
+		PageIndex: []*srvpb.PageIndex{
+			{PageKey: "page0", EdgeKind: "someEdgeKind", EdgeCount: 3},
+			{PageKey: "page1", EdgeKind: "someEdgeKind", EdgeCount: 3},
+		},
+	}
+	testutil.Fatalf(t, "Error writing edge set: %v", p.Put(ctx, EdgeSetKey(src.Ticket), pes))
+	for i, key := range []string{"page0", "page1"} {
+		testutil.Fatalf(t, "Error writing edge page: %v", p.Put(ctx, EdgePageKey(key), &srvpb.EdgePage{
+			PageKey:      key,
+			SourceTicket: src.Ticket,
+			EdgesGroup:   &srvpb.EdgeGroup{Kind: "someEdgeKind", Edge: edges[3*i : 3*i+3]},
+		}))
+	}
+	st := NewCombinedTable(p)
+
+	var scanned []int32
+	testutil.Fatalf(t, "scanEdgePage error: %v", st.staticLookupTables.(edgePageScanner).scanEdgePage(ctx, "page1", 1, func(e *srvpb.EdgeGroup_Edge) error {
+		scanned = append(scanned, e.Ordinal)
+		return nil
+	}))
+	if err := testutil.DeepEqual([]int32{4, 5}, scanned); err != nil {
+		t.Errorf("scanEdgePage: %v", err)
+	}
+
+	rec, err := proto.Marshal(&ipb.PageToken{Index: 2})
+	testutil.Fatalf(t, "Error marshaling page token: %v", err)
+	reply, err := st.Edges(ctx, &gpb.EdgesRequest{
+		Ticket:    []string{src.Ticket},
+		PageSize:  3,
+		PageToken: base64.StdEncoding.EncodeToString(rec),
+	})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	var found []int32
+	for _, e := range reply.EdgeSets[src.Ticket].Groups["someEdgeKind"].Edge {
+		found = append(found, e.Ordinal)
+	}
+	if err := testutil.DeepEqual([]int32{2, 3, 4}, found); err != nil {
+		t.Error(err)
+	}
+
+	val := flag.Lookup("max_edges_reply_bytes").Value.String()
+	testutil.Fatalf(t, "flag.Set: %v", flag.Set("max_edges_reply_bytes", "64"))
+	defer flag.Set("max_edges_reply_bytes", val)
+
+	reply, err = st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{src.Ticket}, Filter: []string{"**"}})
+	var tooLarge *ReplyTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 64 {
+		t.Fatalf("Expected ReplyTooLargeError; found reply %v, error %v", reply, err)
+	} else if c := status.Code(err); c != codes.ResourceExhausted {
+		t.Errorf("Expected %v; found %v", codes.ResourceExhausted, c)
+	}
+
+	// Scanned edges are charged once, including when read through a readahead
+	// table.
+	testutil.Fatalf(t, "flag.Set: %v", flag.Set("max_edges_reply_bytes", "0"))
+	req := &gpb.EdgesRequest{Ticket: []string{src.Ticket}}
+	reply, err = st.Edges(ctx, req)
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	var size int
+	for _, e := range reply.EdgeSets[src.Ticket].Groups["someEdgeKind"].Edge {
+		size += proto.Size(e)
+	}
+	for _, tbl := range []*Table{st, NewReadaheadTable(st, nil)} {
+		testutil.Fatalf(t, "flag.Set: %v", flag.Set("max_edges_reply_bytes", strconv.Itoa(size)))
+		if _, err := tbl.Edges(ctx, req); err != nil {
+			t.Errorf("Expected reply within %d bytes; found error %v", size, err)
+		}
+		testutil.Fatalf(t, "flag.Set: %v", flag.Set("max_edges_reply_bytes", strconv.Itoa(size-1)))
+		if _, err := tbl.Edges(ctx, req); !errors.As(err, &tooLarge) {
+			t.Errorf("Expected ReplyTooLargeError; found %v", err)
+		}
+	}
+}
+
+func TestEdgesMergedKindPaging(t *testing.T) {
+	db := inmemory.NewKeyValueDB()
+	p := &table.KVProto{db}
+
+	src := getNode("kythe://someCorpus?lang=der#src")
+	edge := func(name string) *srvpb.EdgeGroup_Edge {
+		return &srvpb.EdgeGroup_Edge{Target: getNode("kythe://someCorpus?lang=der#" + name)}
+	}
+	pes := &srvpb.PagedEdgeSet{
+		Source: src,
+		Group: []*srvpb.EdgeGroup{
+			{Kind: "kindA", Edge: []*srvpb.EdgeGroup_Edge{edge("a0"), edge("a2")}},
+			{Kind: "kindB", Edge: []*srvpb.EdgeGroup_Edge{edge("b1")}},
+		},
+		PageIndex: []*srvpb.PageIndex{
+			{PageKey: "pageB", EdgeKind: "kindB", EdgeCount: 1},
+			{PageKey: "pageA", EdgeKind: "kindA", EdgeCount: 2},
+		},
+	}
+	testutil.Fatalf(t, "Error writing edge set: %v", p.Put(ctx, EdgeSetKey(src.Ticket), pes))
+	for _, ep := range []*srvpb.EdgePage{{
+		PageKey:      "pageA",
+		SourceTicket: src.Ticket,
+		EdgesGroup:   &srvpb.EdgeGroup{Kind: "kindA", Edge: []*srvpb.EdgeGroup_Edge{edge("a1"), edge("a3")}},
+	}, {
+		PageKey:      "pageB",
+		SourceTicket: src.Ticket,
+		EdgesGroup:   &srvpb.EdgeGroup{Kind: "kindB", Edge: []*srvpb.EdgeGroup_Edge{edge("b0")}},
+	}} {
+		testutil.Fatalf(t, "Error writing edge page: %v", p.Put(ctx, EdgePageKey(ep.PageKey), ep))
+	}
+	st := NewCombinedTable(p)
+
+	// Each kind's inline group and EdgePages are paged through as one sequence
+	// and each page's edges of a kind are sorted.
+	var pages [][]string
+	req := &gpb.EdgesRequest{Ticket: []string{src.Ticket}, PageSize: 3}
+	for {
+		reply, err := st.Edges(ctx, req)
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+		var page []string
+		for _, kind := range []string{"kindA", "kindB"} {
+			for _, e := range reply.EdgeSets[src.Ticket].GetGroups()[kind].GetEdge() {
+				page = append(page, kind+" "+strings.TrimPrefix(e.TargetTicket, "kythe://someCorpus?lang=der#"))
+			}
+		}
+		pages = append(pages, page)
+		if reply.NextPageToken == "" {
+			break
+		} else if len(pages) > 3 {
+			t.Fatalf("Too many pages: %v", pages)
+		}
+		req.PageToken = reply.NextPageToken
+	}
+	if err := testutil.DeepEqual([][]string{
+		{"kindA a0", "kindA a1", "kindA a2"},
+		{"kindA a3", "kindB b0", "kindB b1"},
+	}, pages); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEdgesReadahead(t *testing.T) {
+	counter := &countingTables{staticLookupTables: tbl.Construct(t).staticLookupTables}
+	prefetched := make(chan string, 4)
+	st := NewReadaheadTable(&Table{staticLookupTables: counter}, &readahead.Options{
+		MaxPages:   2,
+		Prefetched: func(key string) { prefetched <- key },
+	})
+	ra := st.staticLookupTables.(*readaheadTables)
+	ticket := tbl.EdgeSets[1].Source.Ticket
+
+	// The first page is served entirely from the PagedEdgeSet's groups.
+	reply, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{ticket}, PageSize: 3})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	if reply.NextPageToken == "" {
+		t.Fatalf("Missing next_page_token in EdgesReply: {%v}", reply)
+	}
+	waitForPrefetch(t, prefetched, "firstPage")
+
+	reply, err = st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{ticket}, PageSize: 2, PageToken: reply.NextPageToken})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	if reply.NextPageToken == "" {
+		t.Fatalf("Missing next_page_token in EdgesReply: {%v}", reply)
+	}
+	if n := counter.count("firstPage"); n != 1 {
+		t.Errorf("Expected firstPage to be read once; found %d", n)
+	}
+	if d := ra.readaheadDepth(); d != 2 {
+		t.Errorf("Expected readahead depth to grow to 2; found %d", d)
+	}
+	waitForPrefetch(t, prefetched, "secondPage")
+}
+
+func waitForPrefetch(t *testing.T, prefetched <-chan string, key string) {
+	t.Helper()
+	select {
+	case found := <-prefetched:
+		if found != key {
+			t.Fatalf("Expected EdgePage %q to be prefetched; found %q", key, found)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("EdgePage %q was not prefetched", key)
+	}
+}
+
+func TestUpcomingEdgePages(t *testing.T) {
+	pes := tbl.EdgeSets[1] // 3 grouped edges; firstPage (2 edges); secondPage (1 edge)
+	tests := []struct {
+		skip, n  int
+		kinds    func(string) bool
+		expected []string
+	}{
+		{skip: 0, n: 1, expected: []string{"firstPage"}},
+		{skip: 3, n: 2, expected: []string{"firstPage", "secondPage"}},
+		{skip: 4, n: 2, expected: []string{"firstPage", "secondPage"}},
+		{skip: 5, n: 2, expected: []string{"secondPage"}},
+		{skip: 6, n: 2, expected: nil},
+		{skip: 0, n: 2, kinds: func(k string) bool { return k == "anotherEdge" }, expected: []string{"secondPage"}},
+	}
+	for _, test := range tests {
+		found := upcomingEdgePages([]*srvpb.PagedEdgeSet{pes}, test.kinds, test.skip, test.n)
+		if err := testutil.DeepEqual(test.expected, found); err != nil {
+			t.Errorf("upcomingEdgePages(skip: %d, n: %d): %v", test.skip, test.n, err)
+		}
+	}
+}
+
+type countingTables struct {
+	staticLookupTables
+
+	mu    sync.Mutex
+	reads map[string]int
+}
+
+func (c *countingTables) edgePage(ctx context.Context, key string) (*srvpb.EdgePage, error) {
+	c.mu.Lock()
+	if c.reads == nil {
+		c.reads = make(map[string]int)
+	}
+	c.reads[key]++
+	c.mu.Unlock()
+	return c.staticLookupTables.edgePage(ctx, key)
+}
+
+func (c *countingTables) count(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reads[key]
+}
+
+type countingProto struct {
+	table.Proto
+
+	mu      sync.Mutex
+	lookups int
+}
+
+func (c *countingProto) Lookup(ctx context.Context, key []byte, msg proto.Message) error {
+	c.mu.Lock()
+	c.lookups++
+	c.mu.Unlock()
+	return c.Proto.Lookup(ctx, key, msg)
+}
+
+func TestLookupPagedEdgeSetsCanceled(t *testing.T) {
+	tbl := &countingProto{Proto: &table.KVProto{inmemory.NewKeyValueDB()}}
+	var keys [][]byte
+	for i := 0; i < 10; i++ {
+		ticket := fmt.Sprintf("kythe:#%d", i)
+		testutil.Fatalf(t, "Put error: %v", tbl.Put(ctx, EdgeSetKey(ticket), &srvpb.PagedEdgeSet{Source: &srvpb.Node{Ticket: ticket}}))
+		keys = append(keys, EdgeSetKey(ticket))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	rs, err := lookupPagedEdgeSets(ctx, tbl, keys, nil, false)
+	testutil.Fatalf(t, "lookupPagedEdgeSets error: %v", err)
+	if r := <-rs; r.Err != nil {
+		t.Fatalf("Unexpected error: %v", r.Err)
+	}
+	cancel()
+
+	var received int
+	timeout := time.After(10 * time.Second)
+	for done := false; !done; {
+		select {
+		case _, ok := <-rs:
+			if ok {
+				received++
+			} else {
+				done = true
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for the canceled lookups to stop")
+		}
+	}
+	// At most one result may have been pending when ctx was canceled.
+	if received > 1 {
+		t.Errorf("Received %d results after cancellation", received)
+	}
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	if tbl.lookups > 3 {
+		t.Errorf("Expected lookups to stop after cancellation; found %d lookups", tbl.lookups)
+	}
+}
+
+// largeEdgeSet returns a PagedEdgeSet with an inline group of n edges of each
+// of the given kinds.
+func largeEdgeSet(n int, kinds ...string) *srvpb.PagedEdgeSet {
+	pes := &srvpb.PagedEdgeSet{
+		Source:    &srvpb.Node{Ticket: "kythe:#source", Fact: makeFactList("/kythe/node/kind", "record")},
+		PageIndex: []*srvpb.PageIndex{{PageKey: "page", EdgeKind: "%/kythe/edge/childof", EdgeCount: 1}},
+	}
+	for _, kind := range kinds {
+		g := &srvpb.EdgeGroup{Kind: kind}
+		for i := 0; i < n; i++ {
+			g.Edge = append(g.Edge, &srvpb.EdgeGroup_Edge{
+				Target:  &srvpb.Node{Ticket: fmt.Sprintf("kythe:#%s%d", kind, i), Fact: makeFactList("/kythe/node/kind", "function")},
+				Ordinal: int32(i),
+			})
+		}
+		pes.Group = append(pes.Group, g)
+	}
+	return pes
+}
+
+func TestDecodePagedEdgeSet(t *testing.T) {
+	pes := largeEdgeSet(3, "%/kythe/edge/childof", "/kythe/edge/ref", "%/kythe/edge/ref")
+	rec, err := proto.Marshal(pes)
+	testutil.Fatalf(t, "Error marshaling PagedEdgeSet: %v", err)
+
+	found, err := decodePagedEdgeSet(rec, func(kind string) bool { return strings.HasSuffix(kind, "/ref") }, nil)
+	testutil.Fatalf(t, "decodePagedEdgeSet error: %v", err)
+	expected := proto.Clone(pes).(*srvpb.PagedEdgeSet)
+	expected.Group = expected.Group[1:]
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Errorf("decodePagedEdgeSet: %v", err)
+	}
+
+	found, err = decodePagedEdgeSet(rec, noEdgeKinds, nil)
+	testutil.Fatalf(t, "decodePagedEdgeSet error: %v", err)
+	expected.Group = nil
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Errorf("decodePagedEdgeSet: %v", err)
+	}
+
+	if _, err := decodePagedEdgeSet(rec[:len(rec)-1], noEdgeKinds, nil); err == nil {
+		t.Error("Expected error decoding truncated PagedEdgeSet")
+	}
+
+	// Count-only decoding omits every group, counting the allowed ones.
+	counts := make(map[string]int64)
+	found, err = decodePagedEdgeSet(rec, func(kind string) bool { return strings.HasSuffix(kind, "/ref") }, counts)
+	testutil.Fatalf(t, "decodePagedEdgeSet error: %v", err)
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Errorf("decodePagedEdgeSet: %v", err)
+	}
+	if err := testutil.DeepEqual(map[string]int64{"/kythe/edge/ref": 3, "%/kythe/edge/ref": 3}, counts); err != nil {
+		t.Errorf("decodePagedEdgeSet counts: %v", err)
+	}
+
+	// Lookups through a KVProto decode only the requested groups.
+	tbl := &table.KVProto{inmemory.NewKeyValueDB()}
+	testutil.Fatalf(t, "Put error: %v", tbl.Put(ctx, EdgeSetKey(pes.Source.Ticket), pes))
+	reply, err := NewCombinedTable(tbl).Edges(ctx, &gpb.EdgesRequest{
+		Ticket: []string{pes.Source.Ticket},
+		Kind:   []string{"/kythe/edge/ref"},
+	})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	if n := len(reply.EdgeSets[pes.Source.Ticket].GetGroups()["/kythe/edge/ref"].GetEdge()); n != 3 {
+		t.Errorf("Expected 3 ref edges; found %d", n)
+	}
+}
+
+// BenchmarkDecodePagedEdgeSet compares decoding a PagedEdgeSet whole with
+// decoding only the groups of a single edge kind, as for an EdgesRequest
+// filtered by kind.
+func BenchmarkDecodePagedEdgeSet(b *testing.B) {
+	pes := largeEdgeSet(5000, "%/kythe/edge/childof", "/kythe/edge/ref", "%/kythe/edge/ref", "/kythe/edge/defines")
+	rec, err := proto.Marshal(pes)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("whole", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var pes srvpb.PagedEdgeSet
+			if err := proto.Unmarshal(rec, &pes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("kind", func(b *testing.B) {
+		b.ReportAllocs()
+		kind := func(k string) bool { return k == "%/kythe/edge/childof" }
+		for i := 0; i < b.N; i++ {
+			if _, err := decodePagedEdgeSet(rec, kind, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("source", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := decodePagedEdgeSet(rec, noEdgeKinds, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestWriter(t *testing.T) {
+	src := getNode("kythe://someCorpus?lang=der#writer")
+	var edges []*srvpb.EdgeGroup_Edge
+	for i := 0; i < 5; i++ {
+		edges = append(edges, &srvpb.EdgeGroup_Edge{
+			Target:  getNode(fmt.Sprintf("kythe://someCorpus?lang=der#target%d", i)),
+			Ordinal: int32(i),
+		})
+	}
+	pes := &srvpb.PagedEdgeSet{
+		Source: src,
+		Group:  []*srvpb.EdgeGroup{{Kind: "someEdgeKind", Edge: edges}},
+	}
+
+	combined := make(testProtoTable)
+	split := &SplitTable{Edges: make(testProtoTable), EdgePages: make(testProtoTable)}
+	for _, w := range []*Writer{NewCombinedWriter(combined), NewSplitWriter(split)} {
+		w.PageSize = 2
+		testutil.Fatalf(t, "WriteEdgeSet error: %v", w.WriteEdgeSet(ctx, pes))
+	}
+	if len(pes.PageIndex) != 0 || len(pes.Group[0].Edge) != 5 {
+		t.Errorf("WriteEdgeSet modified its input: %v", pes)
+	}
+
+	for _, st := range []*Table{NewCombinedTable(combined), NewSplitTable(split)} {
+		reply, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{src.Ticket}})
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+		if err := testutil.DeepEqual(edgeSet(nil, pes, nil), reply.EdgeSets[src.Ticket]); err != nil {
+			t.Error(err)
+		}
+	}
+	if n := len(split.EdgePages.(testProtoTable)); n != 2 {
+		t.Errorf("Expected 2 EdgePages; found %d", n)
+	}
+}
+
+func TestMemoryTable(t *testing.T) {
+	m := NewMemoryTables()
+	for _, pes := range tbl.EdgeSets {
+		testutil.Fatalf(t, "PutEdgeSet error: %v", m.PutEdgeSet(pes))
+	}
+	for _, ep := range tbl.EdgePages {
+		m.PutEdgePage(ep)
+	}
+	if err := m.PutEdgeSet(&srvpb.PagedEdgeSet{Source: &srvpb.Node{Ticket: "bad:ticket"}}); err == nil {
+		t.Error("Expected error for invalid ticket")
+	}
+
+	st := NewMemoryTable(m)
+	for _, pes := range tbl.EdgeSets {
+		reply, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{pes.Source.Ticket}})
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+		expected, err := tbl.Construct(t).Edges(ctx, &gpb.EdgesRequest{Ticket: []string{pes.Source.Ticket}})
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+		if err := testutil.DeepEqual(expected, reply); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNodesTicketPattern(t *testing.T) {
+	tickets := []string{
+		"kythe://c?path=dir/a.go#x",
+		"kythe://c?path=dir/b.go#y",
+		"kythe://c?path=other/c.go#z",
+		"kythe://c?lang=go?path=dir/a.go#w",
+		"kythe://d?path=dir/a.go#x",
+	}
+	db := inmemory.NewKeyValueDB()
+	w := NewCombinedWriter(&table.KVProto{db})
+	m := NewMemoryTables()
+	for _, ticket := range tickets {
+		es := &srvpb.PagedEdgeSet{Source: &srvpb.Node{
+			Ticket: ticket,
+			Fact:   []*cpb.Fact{{Name: "/kythe/node/kind", Value: []byte("record")}},
+		}}
+		testutil.Fatalf(t, "Error writing edge set: %v", w.WriteEdgeSet(ctx, es))
+		testutil.Fatalf(t, "Error writing edge set: %v", m.PutEdgeSet(es))
+	}
+
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"kythe://c?path=dir/*", []string{tickets[0], tickets[1], tickets[3]}},
+		{"kythe://c?lang=go?path=dir/*", []string{tickets[3]}},
+		{"kythe://*?path=dir/a.go#x", []string{tickets[0], tickets[4]}},
+		{"kythe://c?path=*/c.go", []string{tickets[2]}},
+		{"kythe://c?path=nothing/*", nil},
+	}
+
+	for name, st := range map[string]*Table{
+		"combined": NewCombinedTable(&table.KVProto{db}),
+		"memory":   NewMemoryTable(m),
+	} {
+		for _, test := range tests {
+			reply, err := st.Nodes(ctx, &gpb.NodesRequest{TicketPattern: test.pattern})
+			testutil.Fatalf(t, "NodesRequest error: %v", err)
+			var found []string
+			for ticket := range reply.Nodes {
+				found = append(found, ticket)
+			}
+			sort.Strings(found)
+			expected := append([]string(nil), test.expected...)
+			sort.Strings(expected)
+			if err := testutil.DeepEqual(expected, found); err != nil {
+				t.Errorf("%s: Nodes(%q): %v", name, test.pattern, err)
+			}
+			if reply.PatternTruncated {
+				t.Errorf("%s: Nodes(%q): unexpected truncation", name, test.pattern)
+			}
+		}
+
+		if _, err := st.Nodes(ctx, &gpb.NodesRequest{TicketPattern: "kythe://c?path=[dir"}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument for invalid pattern; found %v", name, err)
+		}
+	}
+
+	if _, err := tbl.Construct(t).Nodes(ctx, &gpb.NodesRequest{TicketPattern: "kythe://c?path=*"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected Unimplemented for table without ticket index; found %v", err)
+	}
+}
+
+func TestTicketAliases(t *testing.T) {
+	const (
+		old      = "kythe://someCorpus?lang=otpl#old"
+		dangling = "kythe://someCorpus?lang=otpl#dangling"
+		unknown  = "kythe://someCorpus?lang=otpl#unknown"
+	)
+	current := mustFix(t, tbl.EdgeSets[0].Source.Ticket)
+	st := (&testTable{
+		Nodes:     tbl.Nodes,
+		EdgePages: tbl.EdgePages,
+		EdgeSets:  tbl.EdgeSets,
+		TicketAliases: []*srvpb.TicketAlias{
+			{Alias: old, Ticket: current},
+			{Alias: dangling, Ticket: "kythe://someCorpus?lang=otpl#gone"},
+		},
+	}).Construct(t)
+
+	expectedNodes, err := st.Nodes(ctx, &gpb.NodesRequest{Ticket: []string{current}})
+	testutil.Fatalf(t, "NodesRequest error: %v", err)
+	expectedNodes.MissingTicket = []string{dangling, unknown}
+	expectedNodes.RedirectedTicket = map[string]string{old: current}
+	nodes, err := st.Nodes(ctx, &gpb.NodesRequest{Ticket: []string{old, dangling, unknown}})
+	testutil.Fatalf(t, "NodesRequest error: %v", err)
+	if err := testutil.DeepEqual(expectedNodes, nodes); err != nil {
+		t.Error(err)
+	}
+
+	expectedEdges, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{current}})
+	testutil.Fatalf(t, "EdgesRequest error: %v", err)
+	expectedEdges.RedirectedTicket = map[string]string{old: current}
+	for _, tickets := range [][]string{{old}, {old, current}, {current, old}} {
+		edges, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: tickets})
+		testutil.Fatalf(t, "EdgesRequest error: %v", err)
+		if err := testutil.DeepEqual(expectedEdges, edges); err != nil {
+			t.Errorf("Edges(%v): %v", tickets, err)
+		}
+	}
+}
+
 type testTable struct {
-	Nodes     []*srvpb.Node
-	EdgePages []*srvpb.EdgePage
-	EdgeSets  []*srvpb.PagedEdgeSet
+	Nodes         []*srvpb.Node
+	EdgePages     []*srvpb.EdgePage
+	EdgeSets      []*srvpb.PagedEdgeSet
+	TicketAliases []*srvpb.TicketAlias
 }
 
 func (tbl *testTable) Construct(t *testing.T) *Table {
@@ -519,6 +1734,9 @@ func (tbl *testTable) Construct(t *testing.T) *Table {
 	for _, ep := range tbl.EdgePages {
 		testutil.Fatalf(t, "Error writing edge page: %v", p.Put(ctx, EdgePageKey(ep.PageKey), ep))
 	}
+	for _, a := range tbl.TicketAliases {
+		testutil.Fatalf(t, "Error writing ticket alias: %v", p.Put(ctx, TicketAliasKey(mustFix(t, a.Alias)), a))
+	}
 	return NewCombinedTable(p)
 }
 
@@ -549,3 +1767,144 @@ func (t testProtoTable) Lookup(_ context.Context, key []byte, msg proto.Message)
 func (t testProtoTable) Buffered() table.BufferedProto { panic("UNIMPLEMENTED") }
 
 func (t testProtoTable) Close(_ context.Context) error { return nil }
+
+type failingProto struct {
+	table.Proto
+	fail string
+}
+
+func (f failingProto) Lookup(ctx context.Context, key []byte, msg proto.Message) error {
+	if string(key) == f.fail {
+		return errors.New("lookup failure")
+	}
+	return f.Proto.Lookup(ctx, key, msg)
+}
+
+func TestBatchLookupError(t *testing.T) {
+	const (
+		found   = "kythe://c?lang=go#found"
+		failing = "kythe://c?lang=go#failing"
+		missing = "kythe://c?lang=go#missing"
+	)
+	edges := make(testProtoTable)
+	for _, ticket := range []string{found, failing} {
+		testutil.Fatalf(t, "Put error: %v", edges.Put(ctx, []byte(ticket), &srvpb.PagedEdgeSet{
+			Source: &srvpb.Node{Ticket: ticket},
+		}))
+	}
+	st := NewSplitTable(&SplitTable{
+		Edges:     failingProto{Proto: edges, fail: failing},
+		EdgePages: make(testProtoTable),
+	})
+
+	tickets := []string{found, failing, missing}
+	_, edgesErr := st.Edges(ctx, &gpb.EdgesRequest{Ticket: tickets})
+	_, nodesErr := st.Nodes(ctx, &gpb.NodesRequest{Ticket: tickets})
+	for _, err := range []error{edgesErr, nodesErr} {
+		var batchErr *BatchLookupError
+		if !errors.As(err, &batchErr) {
+			t.Errorf("Expected BatchLookupError; found %v", err)
+			continue
+		}
+		if err := testutil.DeepEqual([]string{found, missing}, batchErr.Served); err != nil {
+			t.Errorf("Served: %v", err)
+		}
+		if err := testutil.DeepEqual([]string{failing}, batchErr.Unserved); err != nil {
+			t.Errorf("Unserved: %v", err)
+		}
+		if code := status.Code(err); code != codes.Internal {
+			t.Errorf("Expected INTERNAL; found %v", code)
+		}
+		if details := status.Convert(err).Details(); len(details) != 1 {
+			t.Errorf("Expected partial reply detail; found %v", details)
+		}
+	}
+
+	// The partial replies hold the served tickets' results.
+	var batchErr *BatchLookupError
+	if errors.As(edgesErr, &batchErr) {
+		if reply, ok := batchErr.Reply.(*gpb.EdgesReply); !ok {
+			t.Errorf("Expected partial EdgesReply; found %v", batchErr.Reply)
+		} else if err := testutil.DeepEqual([]string{missing}, reply.MissingTicket); err != nil {
+			t.Errorf("Partial EdgesReply: %v", err)
+		}
+	}
+	if errors.As(nodesErr, &batchErr) {
+		if reply, ok := batchErr.Reply.(*gpb.NodesReply); !ok {
+			t.Errorf("Expected partial NodesReply; found %v", batchErr.Reply)
+		} else if err := testutil.DeepEqual([]string{missing}, reply.MissingTicket); err != nil {
+			t.Errorf("Partial NodesReply: %v", err)
+		}
+	}
+}
+
+func TestEdgesReadQuota(t *testing.T) {
+	db := inmemory.NewKeyValueDB()
+	p := &table.KVProto{db}
+
+	src := getNode("kythe://someCorpus?lang=der#src")
+	other := getNode("kythe://someCorpus?lang=der#other")
+	pes := &srvpb.PagedEdgeSet{
+		Source: src,
+		Group: []*srvpb.EdgeGroup{{
+			Kind: "someEdgeKind",
+			Edge: []*srvpb.EdgeGroup_Edge{{Target: getNode("kythe://someCorpus?lang=der#target0")}},
+		}},
+		PageIndex: []*srvpb.PageIndex{
+			{PageKey: "page0", EdgeKind: "someEdgeKind", EdgeCount: 1},
+		},
+	}
+	testutil.Fatalf(t, "Error writing edge set: %v", p.Put(ctx, EdgeSetKey(src.Ticket), pes))
+	testutil.Fatalf(t, "Error writing edge set: %v", p.Put(ctx, EdgeSetKey(other.Ticket), &srvpb.PagedEdgeSet{Source: other}))
+	testutil.Fatalf(t, "Error writing edge page: %v", p.Put(ctx, EdgePageKey("page0"), &srvpb.EdgePage{
+		PageKey:      "page0",
+		SourceTicket: src.Ticket,
+		EdgesGroup: &srvpb.EdgeGroup{
+			Kind: "someEdgeKind",
+			Edge: []*srvpb.EdgeGroup_Edge{{Target: getNode("kythe://someCorpus?lang=der#target1"), Ordinal: 1}},
+		},
+	}))
+	st := NewCombinedTable(p)
+	st.ReadQuota = quota.ReadQuota{MaxLookups: 1}
+
+	// The EdgePage read exceeds the quota; the inline edges are still served.
+	_, err := st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{src.Ticket}})
+	var quotaErr *quota.ExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected quota.ExceededError; found %v", err)
+	} else if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Errorf("Expected RESOURCE_EXHAUSTED; found %v", code)
+	}
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("Expected partial reply detail; found %v", details)
+	}
+	reply, ok := details[0].(*gpb.EdgesReply)
+	if !ok {
+		t.Fatalf("Expected partial EdgesReply; found %v", details[0])
+	}
+	if n := len(reply.EdgeSets[src.Ticket].GetGroups()["someEdgeKind"].GetEdge()); n != 1 {
+		t.Errorf("Expected 1 partial edge; found %d", n)
+	}
+	if n := reply.TotalEdgesByKind["someEdgeKind"]; n != 2 {
+		t.Errorf("Expected 2 total edges; found %d", n)
+	}
+	if reply.NextPageToken == "" {
+		t.Error("Expected a next page token for the unread page")
+	}
+
+	// The ticket lookups beyond the quota are unserved.
+	_, err = st.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{other.Ticket, src.Ticket}})
+	var batchErr *BatchLookupError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected BatchLookupError; found %v", err)
+	} else if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Errorf("Expected RESOURCE_EXHAUSTED; found %v", code)
+	}
+	if err := testutil.DeepEqual([]string{src.Ticket}, batchErr.Unserved); err != nil {
+		t.Errorf("Unserved: %v", err)
+	}
+	if details := status.Convert(err).Details(); len(details) != 1 {
+		t.Errorf("Expected partial reply detail; found %v", details)
+	}
+}
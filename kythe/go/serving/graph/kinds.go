@@ -0,0 +1,148 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"sort"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/log"
+	"kythe.io/kythe/go/util/schema/edges"
+
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+)
+
+// EdgeKinds returns the number of outbound edges of each kind for each of the
+// given nodes, keyed by their canonical tickets.  Only the headers of each
+// PagedEdgeSet are read; no edges are materialized and no EdgePages are read.
+// Unknown nodes are omitted.
+func (t *Table) EdgeKinds(ctx context.Context, tickets []string) (map[string]map[string]int64, error) {
+	ctx = log.EnsureRequestID(ctx)
+	var v validate.Validator
+	tickets = v.Tickets("ticket", tickets)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stop reading edge sets in case of errors
+	rs, err := t.pagedEdgeSets(ctx, tickets, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make(map[string]map[string]int64, len(tickets))
+	for r := range rs {
+		if r.Err == table.ErrNoSuchKey {
+			continue
+		} else if r.Err != nil {
+			return nil, r.Err
+		}
+		counts := make(map[string]int64)
+		countEdgeKinds(r.PagedEdgeSet, nil, counts)
+		kinds[r.PagedEdgeSet.Source.Ticket] = counts
+	}
+	return kinds, nil
+}
+
+// structureKinds are the edge kinds, and their variants, of the STRUCTURE
+// preset.
+var structureKinds = []string{edges.ChildOf, edges.Param, edges.TParam}
+
+// kindPresetFilter returns a function reporting whether an edge kind is in
+// the given preset, or nil if the preset includes every kind.
+func kindPresetFilter(preset gpb.EdgesRequest_KindPreset) func(string) bool {
+	switch preset {
+	case gpb.EdgesRequest_SEMANTIC:
+		return func(kind string) bool {
+			return !edges.IsAnchorEdge(kind) && !edges.IsVariant(edges.Canonical(kind), edges.Tagged) && !isStructureEdge(kind)
+		}
+	case gpb.EdgesRequest_STRUCTURE:
+		return isStructureEdge
+	case gpb.EdgesRequest_ALL_FORWARD:
+		return edges.IsForward
+	case gpb.EdgesRequest_ALL_REVERSE:
+		return edges.IsReverse
+	default:
+		return nil
+	}
+}
+
+// isStructureEdge reports whether kind is in the STRUCTURE preset.
+func isStructureEdge(kind string) bool {
+	base, _, _ := edges.ParseOrdinal(edges.Canonical(kind))
+	for _, k := range structureKinds {
+		if edges.IsVariant(base, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalizeEdgeKinds replaces each legacy edge kind with an ordinal suffix
+// in the given reply by its base kind, merging its edges into the group of the
+// base kind and recording the suffix as each edge's ordinal.
+func canonicalizeEdgeKinds(reply *gpb.EdgesReply) {
+	for _, set := range reply.EdgeSets {
+		var merged []string
+		for kind, grp := range set.Groups {
+			base, ordinal, ok := edges.ParseOrdinal(kind)
+			if !ok {
+				continue
+			}
+			for _, e := range grp.Edge {
+				if e.Ordinal == 0 {
+					e.Ordinal = int32(ordinal)
+				}
+			}
+			delete(set.Groups, kind)
+			if g, ok := set.Groups[base]; ok {
+				g.Edge = append(g.Edge, grp.Edge...)
+			} else {
+				set.Groups[base] = grp
+			}
+			merged = append(merged, base)
+		}
+		for _, kind := range merged {
+			es := set.Groups[kind].Edge
+			sort.SliceStable(es, func(i, j int) bool {
+				if es[i].Ordinal != es[j].Ordinal {
+					return es[i].Ordinal < es[j].Ordinal
+				}
+				return es[i].TargetTicket < es[j].TargetTicket
+			})
+		}
+	}
+
+	reply.TotalEdgesByKind = canonicalCounts(reply.TotalEdgesByKind)
+	for _, counts := range reply.EdgeCounts {
+		counts.ByKind = canonicalCounts(counts.ByKind)
+	}
+}
+
+// canonicalCounts returns the given edge counts by kind with the counts of
+// legacy kinds added to those of their base kinds.
+func canonicalCounts(counts map[string]int64) map[string]int64 {
+	res := make(map[string]int64, len(counts))
+	for kind, n := range counts {
+		base, _, _ := edges.ParseOrdinal(kind)
+		res[base] += n
+	}
+	return res
+}
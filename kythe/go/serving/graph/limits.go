@@ -0,0 +1,179 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+var maxEdgesReplyBytes = flag.Int("max_edges_reply_bytes", 0, "Maximum encoded size of the edges and nodes in an EdgesReply; larger replies fail with a ReplyTooLargeError.  Non-positive values (the default) disable the limit")
+
+// ReplyTooLargeError is returned by Edges when its reply would exceed
+// --max_edges_reply_bytes.  Its gRPC status code is RESOURCE_EXHAUSTED.
+type ReplyTooLargeError struct {
+	// Limit is the maximum reply size, in bytes.
+	Limit int
+}
+
+// Error implements the error interface.
+func (e *ReplyTooLargeError) Error() string {
+	return fmt.Sprintf("reply too large: exceeds %d bytes; use a smaller page_size or a narrower filter", e.Limit)
+}
+
+// GRPCStatus returns the gRPC status of the error.
+func (e *ReplyTooLargeError) GRPCStatus() *status.Status {
+	return status.New(codes.ResourceExhausted, e.Error())
+}
+
+// A replyBudget accounts for the memory held by a single reply.
+type replyBudget struct {
+	limit, used int
+}
+
+func newReplyBudget() *replyBudget { return &replyBudget{limit: *maxEdgesReplyBytes} }
+
+// charge adds n bytes to the reply, returning a *ReplyTooLargeError if it
+// exceeds the budget's limit.
+func (b *replyBudget) charge(n int) error {
+	b.used += n
+	if b.limit > 0 && b.used > b.limit {
+		return &ReplyTooLargeError{Limit: b.limit}
+	}
+	return nil
+}
+
+// chargeMessage adds the encoded size of msg to the reply.
+func (b *replyBudget) chargeMessage(msg proto.Message) error { return b.charge(proto.Size(msg)) }
+
+// chargeEdges adds the encoded size of the given reply edges to the reply.
+func (b *replyBudget) chargeEdges(es []*gpb.EdgeSet_Group_Edge) error {
+	for _, e := range es {
+		if err := b.chargeMessage(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// An edgePageScanner is a staticLookupTables that can decode the edges of an
+// EdgePage incrementally, rather than holding its entire EdgeGroup in memory.
+type edgePageScanner interface {
+	// scanEdgePage calls f, in order, with each edge of the given EdgePage
+	// after the first skip.  If f returns an error, the scan stops and that
+	// error is returned.
+	scanEdgePage(ctx context.Context, key string, skip int, f func(*srvpb.EdgeGroup_Edge) error) error
+}
+
+func (s *SplitTable) scanEdgePage(ctx context.Context, key string, skip int, f func(*srvpb.EdgeGroup_Edge) error) error {
+	tracePrintf(ctx, "Scanning EdgePage: %s", key)
+	return scanEdgePage(ctx, s.EdgePages, []byte(key), skip, f)
+}
+
+func (c *combinedTable) scanEdgePage(ctx context.Context, key string, skip int, f func(*srvpb.EdgeGroup_Edge) error) error {
+	return scanEdgePage(ctx, c.Proto, EdgePageKey(key), skip, f)
+}
+
+// scanEdgePage implements edgePageScanner for the EdgePage stored in t under
+// the given key.  Unless t is a *table.KVProto, the page is decoded whole.
+func scanEdgePage(ctx context.Context, t table.Proto, key []byte, skip int, f func(*srvpb.EdgeGroup_Edge) error) error {
+	kv, ok := t.(*table.KVProto)
+	if !ok {
+		var ep srvpb.EdgePage
+		if err := t.Lookup(ctx, key, &ep); err != nil {
+			return err
+		}
+		return scanEdges(&ep, skip, f)
+	}
+
+	rec, err := kv.Get(ctx, key, nil)
+	if errors.Is(err, io.EOF) {
+		return table.ErrNoSuchKey
+	} else if err != nil {
+		return err
+	}
+	// Occurrences of the (non-repeated) edges_group field are merged, so their
+	// edges are concatenated in order.
+	return scanFields(rec, 3, func(group []byte) error {
+		return scanFields(group, 2, func(rec []byte) error {
+			if skip > 0 {
+				skip--
+				return nil
+			}
+			var e srvpb.EdgeGroup_Edge
+			if err := proto.Unmarshal(rec, &e); err != nil {
+				return fmt.Errorf("proto unmarshal error: %v", err)
+			}
+			return f(&e)
+		})
+	})
+}
+
+// scanEdges calls f, in order, with each edge of the decoded EdgePage after
+// the first skip.
+func scanEdges(ep *srvpb.EdgePage, skip int, f func(*srvpb.EdgeGroup_Edge) error) error {
+	for _, e := range ep.GetEdgesGroup().GetEdge() {
+		if skip > 0 {
+			skip--
+		} else if err := f(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanFields calls f with the value of each occurrence of the given
+// length-delimited field in the encoded message rec.
+func scanFields(rec []byte, field protowire.Number, f func([]byte) error) error {
+	for len(rec) > 0 {
+		num, typ, n := protowire.ConsumeTag(rec)
+		if n < 0 {
+			return fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+		}
+		rec = rec[n:]
+		if num == field && typ == protowire.BytesType {
+			val, n := protowire.ConsumeBytes(rec)
+			if n < 0 {
+				return fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+			}
+			rec = rec[n:]
+			if err := f(val); err != nil {
+				return err
+			}
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, rec)
+		if n < 0 {
+			return fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+		}
+		rec = rec[n:]
+	}
+	return nil
+}
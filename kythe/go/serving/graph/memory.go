@@ -0,0 +1,205 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/log"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// MemoryTables is an in-memory set of graph serving tables.  It is meant for
+// small tools and tests that want to serve the graph without an on-disk table.
+// Tickets are canonicalized when values are added.  The zero value is not
+// ready for use; see NewMemoryTables.  MemoryTables is safe for concurrent
+// use.
+type MemoryTables struct {
+	mu        sync.RWMutex
+	edgeSets  map[string]*srvpb.PagedEdgeSet
+	edgePages map[string]*srvpb.EdgePage
+	aliases   map[string]*srvpb.TicketAlias
+	hashes    map[string]*srvpb.NodeFactHashes
+
+	generation *srvpb.FactHashesGeneration
+}
+
+// NewMemoryTables returns an empty MemoryTables.
+func NewMemoryTables() *MemoryTables {
+	return &MemoryTables{
+		edgeSets:  make(map[string]*srvpb.PagedEdgeSet),
+		edgePages: make(map[string]*srvpb.EdgePage),
+		aliases:   make(map[string]*srvpb.TicketAlias),
+		hashes:    make(map[string]*srvpb.NodeFactHashes),
+	}
+}
+
+// NewMemoryTable returns a table based on the given in-memory tables.  Values
+// added to m after the table is constructed are visible to the table.
+func NewMemoryTable(m *MemoryTables) *Table { return &Table{staticLookupTables: m} }
+
+// PutEdgeSet adds the given PagedEdgeSet keyed by its source ticket.
+func (m *MemoryTables) PutEdgeSet(pes *srvpb.PagedEdgeSet) error {
+	ticket, err := kytheuri.Fix(pes.GetSource().GetTicket())
+	if err != nil {
+		return fmt.Errorf("invalid PagedEdgeSet source ticket %q: %v", pes.GetSource().GetTicket(), err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.edgeSets[ticket] = proto.Clone(pes).(*srvpb.PagedEdgeSet)
+	return nil
+}
+
+// PutEdgePage adds the given EdgePage keyed by its page key.
+func (m *MemoryTables) PutEdgePage(ep *srvpb.EdgePage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.edgePages[ep.PageKey] = proto.Clone(ep).(*srvpb.EdgePage)
+}
+
+// PutTicketAlias adds the given TicketAlias keyed by its aliased ticket.
+func (m *MemoryTables) PutTicketAlias(a *srvpb.TicketAlias) error {
+	ticket, err := kytheuri.Fix(a.Alias)
+	if err != nil {
+		return fmt.Errorf("invalid TicketAlias ticket %q: %v", a.Alias, err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aliases[ticket] = proto.Clone(a).(*srvpb.TicketAlias)
+	return nil
+}
+
+// PutNodeFactHashes adds the given NodeFactHashes keyed by its node ticket.
+func (m *MemoryTables) PutNodeFactHashes(h *srvpb.NodeFactHashes) error {
+	ticket, err := kytheuri.Fix(h.Ticket)
+	if err != nil {
+		return fmt.Errorf("invalid NodeFactHashes ticket %q: %v", h.Ticket, err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hashes[ticket] = proto.Clone(h).(*srvpb.NodeFactHashes)
+	return nil
+}
+
+// SetFactHashesGeneration sets the generation of the tables' NodeFactHashes.
+func (m *MemoryTables) SetFactHashesGeneration(g *srvpb.FactHashesGeneration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.generation = proto.Clone(g).(*srvpb.FactHashesGeneration)
+}
+
+func (m *MemoryTables) pagedEdgeSets(ctx context.Context, tickets []string, kindFilter func(string) bool, countOnly bool) (<-chan edgeSetResult, error) {
+	tracePrintf(ctx, "Reading PagedEdgeSets: %s", tickets)
+	ch := make(chan edgeSetResult, len(tickets))
+	defer close(ch)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, ticket := range tickets {
+		pes, ok := m.edgeSets[ticket]
+		if !ok {
+			log.Warningf(ctx, "Could not locate edges with key %q", ticket)
+			ch <- edgeSetResult{Err: table.ErrNoSuchKey}
+			continue
+		}
+		r := edgeSetResult{PagedEdgeSet: proto.Clone(pes).(*srvpb.PagedEdgeSet)}
+		if countOnly {
+			r.GroupCounts = make(map[string]int64)
+			countGroups(r.PagedEdgeSet, kindFilter, r.GroupCounts)
+		}
+		ch <- r
+	}
+	return ch, nil
+}
+
+func (m *MemoryTables) edgePage(ctx context.Context, key string) (*srvpb.EdgePage, error) {
+	tracePrintf(ctx, "Reading EdgePage: %s", key)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ep, ok := m.edgePages[key]
+	if !ok {
+		return nil, table.ErrNoSuchKey
+	}
+	return proto.Clone(ep).(*srvpb.EdgePage), nil
+}
+
+func (m *MemoryTables) ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error) {
+	tracePrintf(ctx, "Reading TicketAlias: %s", ticket)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	a, ok := m.aliases[ticket]
+	if !ok {
+		return nil, table.ErrNoSuchKey
+	}
+	return proto.Clone(a).(*srvpb.TicketAlias), nil
+}
+
+func (m *MemoryTables) nodeFactHashes(ctx context.Context, ticket string) (*srvpb.NodeFactHashes, error) {
+	tracePrintf(ctx, "Reading NodeFactHashes: %s", ticket)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	h, ok := m.hashes[ticket]
+	if !ok {
+		return nil, table.ErrNoSuchKey
+	}
+	return proto.Clone(h).(*srvpb.NodeFactHashes), nil
+}
+
+func (m *MemoryTables) factHashesGeneration(ctx context.Context) (*srvpb.FactHashesGeneration, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.generation == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	return proto.Clone(m.generation).(*srvpb.FactHashesGeneration), nil
+}
+
+func (m *MemoryTables) ticketIndex(ctx context.Context, prefix []byte, f func(string) error) error {
+	m.mu.RLock()
+	keys := make(map[string]string)
+	for ticket := range m.edgeSets {
+		uri, err := kytheuri.Parse(ticket)
+		if err != nil {
+			continue
+		}
+		if key := ticketIndexKey(uri); strings.HasPrefix(key, string(prefix)) {
+			keys[key] = ticket
+		}
+	}
+	m.mu.RUnlock()
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		if err := f(keys[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"path"
+	"strings"
+
+	"kythe.io/kythe/go/util/kytheuri"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var maxTicketPatternNodes = flag.Int("max_ticket_pattern_nodes", 10000, "Maximum number of nodes matched by a NodesRequest ticket_pattern")
+
+// errNoTicketIndex is returned by tables lacking a ticket index.
+var errNoTicketIndex = status.Error(codes.Unimplemented, "ticket patterns are unsupported: serving table has no ticket index")
+
+// errStopScan stops a scan of the ticket index.
+var errStopScan = errors.New("stop scan")
+
+// ticketIndexKey returns the ticket index key for the given node URI.  Its
+// components are ordered so that the nodes of a corpus, root, directory, or
+// file share a key prefix.
+func ticketIndexKey(uri *kytheuri.URI) string {
+	return strings.Join([]string{uri.Corpus, uri.Root, uri.Path, uri.Language, uri.Signature}, "\x00")
+}
+
+// A ticketPattern matches node tickets whose components each match the
+// corresponding glob pattern.
+type ticketPattern struct {
+	// corpus, root, path, language, and signature globs
+	globs [5]string
+}
+
+// parseTicketPattern parses the given ticket pattern.
+func parseTicketPattern(pattern string) (*ticketPattern, error) {
+	uri, err := kytheuri.Parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	p := &ticketPattern{globs: [5]string{uri.Corpus, uri.Root, uri.Path, uri.Language, uri.Signature}}
+	for _, g := range p.globs {
+		if _, err := path.Match(g, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %v", g, err)
+		}
+	}
+	return p, nil
+}
+
+// prefix returns the longest ticket index key prefix shared by all tickets
+// matching the pattern.
+func (p *ticketPattern) prefix() []byte {
+	var prefix strings.Builder
+	for i, g := range p.globs {
+		if i >= 3 && g == "" {
+			break // empty language and signature globs match anything
+		} else if j := strings.IndexAny(g, `*?[\`); j >= 0 {
+			prefix.WriteString(g[:j])
+			break
+		}
+		prefix.WriteString(g)
+		if i < len(p.globs)-1 {
+			prefix.WriteByte(0)
+		}
+	}
+	return []byte(prefix.String())
+}
+
+// matches reports whether the given node URI matches the pattern.
+func (p *ticketPattern) matches(uri *kytheuri.URI) bool {
+	for i, val := range []string{uri.Corpus, uri.Root, uri.Path, uri.Language, uri.Signature} {
+		g := p.globs[i]
+		if i >= 3 && g == "" {
+			continue
+		} else if ok, _ := path.Match(g, val); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTickets returns the indexed tickets matching the given pattern, up to
+// max_ticket_pattern_nodes, and whether more tickets matched.
+func (t *Table) matchTickets(ctx context.Context, p *ticketPattern) (tickets []string, truncated bool, err error) {
+	prefix := p.prefix()
+	tracePrintf(ctx, "Scanning ticket index: %q", prefix)
+	err = t.ticketIndex(ctx, prefix, func(ticket string) error {
+		uri, err := kytheuri.Parse(ticket)
+		if err != nil {
+			return fmt.Errorf("invalid indexed ticket %q: %v", ticket, err)
+		} else if !p.matches(uri) {
+			return nil
+		} else if len(tickets) >= *maxTicketPatternNodes {
+			truncated = true
+			return errStopScan
+		}
+		tickets = append(tickets, ticket)
+		return nil
+	})
+	if err == errStopScan {
+		err = nil
+	}
+	return tickets, truncated, err
+}
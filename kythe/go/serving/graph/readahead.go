@@ -0,0 +1,127 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/serving/readahead"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// NewReadaheadTable returns a table serving the same data as t that, after
+// each Edges reply with a next page token, asynchronously prefetches the
+// EdgePages needed by the following page into an in-memory cache.
+func NewReadaheadTable(t *Table, opts *readahead.Options) *Table {
+	return &Table{
+		staticLookupTables: &readaheadTables{
+			staticLookupTables: t.staticLookupTables,
+			cache:              readahead.New[*srvpb.EdgePage](opts),
+		},
+		Paranoid:    t.Paranoid,
+		Health:      t.Health,
+		ReadQuota:   t.ReadQuota,
+		ReadLimiter: t.ReadLimiter,
+	}
+}
+
+// InvalidateEdgePages drops the given EdgePages from t's readahead cache, if
+// any, so that rewritten pages are read anew from the underlying table.
+func (t *Table) InvalidateEdgePages(keys []string) {
+	r, ok := t.staticLookupTables.(*readaheadTables)
+	if !ok {
+		return
+	}
+	for _, key := range keys {
+		r.cache.Invalidate(key)
+	}
+}
+
+// FlushEdgePages drops every EdgePage from t's readahead cache, if any.
+func (t *Table) FlushEdgePages() {
+	if r, ok := t.staticLookupTables.(*readaheadTables); ok {
+		r.cache.Flush()
+	}
+}
+
+// A pagePrefetcher is a staticLookupTables that can asynchronously load the
+// given EdgePages ahead of their use.
+type pagePrefetcher interface {
+	prefetchEdgePages(ctx context.Context, keys []string)
+
+	// readaheadDepth returns the number of EdgePages to prefetch.
+	readaheadDepth() int
+}
+
+type readaheadTables struct {
+	staticLookupTables
+
+	cache *readahead.Cache[*srvpb.EdgePage]
+}
+
+func (r *readaheadTables) edgePage(ctx context.Context, key string) (*srvpb.EdgePage, error) {
+	if ep, ok := r.cache.Get(key); ok {
+		tracePrintf(ctx, "Prefetched EdgePage: %s", key)
+		return ep, nil
+	}
+	return r.staticLookupTables.edgePage(ctx, key)
+}
+
+func (r *readaheadTables) scanEdgePage(ctx context.Context, key string, skip int, f func(*srvpb.EdgeGroup_Edge) error) error {
+	if ep, ok := r.cache.Get(key); ok {
+		tracePrintf(ctx, "Prefetched EdgePage: %s", key)
+		return scanEdges(ep, skip, f)
+	} else if sc, ok := r.staticLookupTables.(edgePageScanner); ok {
+		return sc.scanEdgePage(ctx, key, skip, f)
+	}
+	ep, err := r.staticLookupTables.edgePage(ctx, key)
+	if err != nil {
+		return err
+	}
+	return scanEdges(ep, skip, f)
+}
+
+func (r *readaheadTables) readaheadDepth() int { return r.cache.Depth() }
+
+func (r *readaheadTables) prefetchEdgePages(ctx context.Context, keys []string) {
+	r.cache.Prefetch(ctx, keys, r.staticLookupTables.edgePage)
+}
+
+// upcomingEdgePages returns the keys of (at most n) EdgePages of the given
+// PagedEdgeSets that would be read by an Edges request skipping the given
+// number of edges.
+func upcomingEdgePages(sets []*srvpb.PagedEdgeSet, kinds func(string) bool, skip, n int) []string {
+	var keys []string
+	for _, pes := range sets {
+		for _, seg := range edgeSegments(pes, kinds) {
+			if idx := seg.page; idx == nil {
+				skip -= len(seg.group.Edge)
+				continue
+			} else if skip >= int(idx.EdgeCount) {
+				skip -= int(idx.EdgeCount)
+				continue
+			}
+			skip = 0
+			keys = append(keys, seg.page.PageKey)
+			if len(keys) >= n {
+				return keys
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"kythe.io/kythe/go/serving/pagekey"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// A Writer writes serving data in the format expected by a Table.
+type Writer struct {
+	// PageSize is the maximum number of edges kept in any single EdgeGroup of a
+	// PagedEdgeSet or EdgePage; larger groups are split into EdgePages.  If
+	// <= 0, defaultPageSize is used.
+	PageSize int
+
+	edgeSets, edgePages table.Proto
+	ticketIndex         table.Proto
+	factHashes          table.Proto
+	combined            bool
+}
+
+// NewCombinedWriter returns a Writer for a table read by NewCombinedTable.
+func NewCombinedWriter(t table.Proto) *Writer {
+	return &Writer{edgeSets: t, edgePages: t, ticketIndex: t, factHashes: t, combined: true}
+}
+
+// NewSplitWriter returns a Writer for the tables of a SplitTable.  If the
+// SplitTable has no TicketIndex, no ticket index entries are written.
+func NewSplitWriter(s *SplitTable) *Writer {
+	w := &Writer{edgeSets: s.Edges, edgePages: s.EdgePages, factHashes: s.FactHashes}
+	if s.TicketIndex != nil {
+		w.ticketIndex = &table.KVProto{s.TicketIndex}
+	}
+	return w
+}
+
+// WriteEdgeSet writes the given PagedEdgeSet keyed by the canonical form of its
+// source ticket.  Any EdgeGroup larger than the Writer's PageSize is split into
+// EdgePages, which are written along with the set.  The given set is not
+// modified.
+func (w *Writer) WriteEdgeSet(ctx context.Context, pes *srvpb.PagedEdgeSet) error {
+	ticket, err := kytheuri.Fix(pes.GetSource().GetTicket())
+	if err != nil {
+		return fmt.Errorf("invalid PagedEdgeSet source ticket %q: %v", pes.GetSource().GetTicket(), err)
+	}
+	pageSize := w.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	pes = proto.Clone(pes).(*srvpb.PagedEdgeSet)
+	for _, ep := range compactEdgeSet(pes, pageSize) {
+		if err := w.WriteEdgePage(ctx, ep); err != nil {
+			return err
+		}
+	}
+	key := []byte(ticket)
+	if w.combined {
+		key = EdgeSetKey(ticket)
+	}
+	if err := w.edgeSets.Put(ctx, key, pes); err != nil {
+		return fmt.Errorf("error writing PagedEdgeSet %q: %v", ticket, err)
+	}
+	return w.writeTicketIndexEntry(ctx, ticket)
+}
+
+// writeTicketIndexEntry adds the given ticket to the Writer's ticket index, if
+// any.
+func (w *Writer) writeTicketIndexEntry(ctx context.Context, ticket string) error {
+	if w.ticketIndex == nil {
+		return nil
+	}
+	key, err := TicketIndexKey(ticket)
+	if err != nil {
+		return fmt.Errorf("invalid ticket %q: %v", ticket, err)
+	}
+	if !w.combined {
+		key = key[len(ticketIndexTablePrefix):]
+	}
+	if err := w.ticketIndex.Put(ctx, key, &srvpb.TicketIndexEntry{Ticket: ticket}); err != nil {
+		return fmt.Errorf("error writing TicketIndexEntry %q: %v", ticket, err)
+	}
+	return nil
+}
+
+// WriteEdgePage writes the given EdgePage keyed by its page key.
+func (w *Writer) WriteEdgePage(ctx context.Context, ep *srvpb.EdgePage) error {
+	if err := pagekey.Validate(ep.PageKey, pagekey.EdgePage, ep.SourceTicket, ep.GetEdgesGroup().GetKind()); err != nil {
+		return fmt.Errorf("invalid EdgePage: %v", err)
+	}
+	key := []byte(ep.PageKey)
+	if w.combined {
+		key = EdgePageKey(ep.PageKey)
+	}
+	if err := w.edgePages.Put(ctx, key, ep); err != nil {
+		return fmt.Errorf("error writing EdgePage %q: %v", ep.PageKey, err)
+	}
+	return nil
+}
+
+// WriteNodeFactHashes writes the given NodeFactHashes keyed by the canonical
+// form of its node ticket.
+func (w *Writer) WriteNodeFactHashes(ctx context.Context, h *srvpb.NodeFactHashes) error {
+	if w.factHashes == nil {
+		return errors.New("no fact hashes table")
+	}
+	ticket, err := kytheuri.Fix(h.Ticket)
+	if err != nil {
+		return fmt.Errorf("invalid NodeFactHashes ticket %q: %v", h.Ticket, err)
+	}
+	key := []byte(ticket)
+	if w.combined {
+		key = FactHashesKey(ticket)
+	}
+	if err := w.factHashes.Put(ctx, key, h); err != nil {
+		return fmt.Errorf("error writing NodeFactHashes %q: %v", ticket, err)
+	}
+	return nil
+}
+
+// WriteFactHashesGeneration writes the generation of the table's
+// NodeFactHashes.
+func (w *Writer) WriteFactHashesGeneration(ctx context.Context, g *srvpb.FactHashesGeneration) error {
+	if w.factHashes == nil {
+		return errors.New("no fact hashes table")
+	}
+	if err := w.factHashes.Put(ctx, FactHashesGenerationKey, g); err != nil {
+		return fmt.Errorf("error writing FactHashesGeneration: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"kythe.io/kythe/go/storage/keyvalue"
+	"kythe.io/kythe/go/util/schema/edges"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// KindsKey is the key of the srvpb.KindsSummary entry in a serving table.
+const KindsKey = "kythe:kinds"
+
+// ErrNoKinds is returned by ReadKinds for a table without a kinds summary.
+var ErrNoKinds = errors.New("serving table has no kinds summary")
+
+// A KindsCounter accumulates a srvpb.KindsSummary.  Its zero value is ready
+// for use.
+type KindsCounter struct{ summary srvpb.KindsSummary }
+
+// AddNode counts a node of the given kind.
+func (c *KindsCounter) AddNode(kind string) {
+	if c.summary.NodeKinds == nil {
+		c.summary.NodeKinds = make(map[string]int64)
+	}
+	c.summary.NodeKinds[kind]++
+}
+
+// AddEdge counts an edge of the given kind.  Edges differing only by ordinal
+// (e.g. /kythe/edge/param.0 and /kythe/edge/param.1) are of the same kind.
+func (c *KindsCounter) AddEdge(kind string) {
+	if c.summary.EdgeKinds == nil {
+		c.summary.EdgeKinds = make(map[string]int64)
+	}
+	kind, _, _ = edges.ParseOrdinal(kind)
+	c.summary.EdgeKinds[kind]++
+}
+
+// Summary returns the kinds counted so far.
+func (c *KindsCounter) Summary() *srvpb.KindsSummary {
+	return proto.Clone(&c.summary).(*srvpb.KindsSummary)
+}
+
+// WriteKinds writes the given kinds summary to the table.  It should be called
+// before Write so that the summary is covered by the table's manifest.
+func WriteKinds(ctx context.Context, db keyvalue.DB, kinds *srvpb.KindsSummary) error {
+	rec, err := proto.Marshal(kinds)
+	if err != nil {
+		return fmt.Errorf("error encoding kinds summary: %v", err)
+	}
+	w, err := db.Writer(ctx)
+	if err != nil {
+		return err
+	}
+	if err := w.Write([]byte(KindsKey), rec); err != nil {
+		w.Close()
+		return fmt.Errorf("error writing kinds summary: %v", err)
+	}
+	return w.Close()
+}
+
+// ReadKinds returns the kinds summary of the given table or ErrNoKinds if it
+// has none.
+func ReadKinds(ctx context.Context, db keyvalue.DB) (*srvpb.KindsSummary, error) {
+	rec, err := db.Get(ctx, []byte(KindsKey), nil)
+	if err == io.EOF {
+		return nil, ErrNoKinds
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading kinds summary: %v", err)
+	}
+	var kinds srvpb.KindsSummary
+	if err := proto.Unmarshal(rec, &kinds); err != nil {
+		return nil, fmt.Errorf("error decoding kinds summary: %v", err)
+	}
+	return &kinds, nil
+}
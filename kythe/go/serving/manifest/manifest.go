@@ -0,0 +1,138 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package manifest writes and verifies serving table manifests: whole-table
+// checksums stored in the table at build time so that a server can detect a
+// torn or corrupted copy (e.g. one read from a network filesystem) before or
+// while serving it.  It also writes and reads table metadata describing each
+// table's build.
+package manifest // import "kythe.io/kythe/go/serving/manifest"
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"kythe.io/kythe/go/storage/keyvalue"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// Key is the key of the srvpb.TableManifest entry in a serving table.
+const Key = "kythe:manifest"
+
+// ErrNoManifest is returned by Verify for a table without a manifest.
+var ErrNoManifest = errors.New("serving table has no manifest")
+
+// A MismatchError is returned by Verify when a table's entries do not match
+// its manifest.
+type MismatchError struct {
+	Expected, Found *srvpb.TableManifest
+}
+
+// Error implements the error interface.
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("serving table does not match its manifest: expected %d entries with checksum %x; found %d entries with checksum %x",
+		e.Expected.GetEntries(), e.Expected.GetChecksum(), e.Found.GetEntries(), e.Found.GetChecksum())
+}
+
+// Compute returns the manifest of the given table's current entries.
+func Compute(ctx context.Context, db keyvalue.DB) (*srvpb.TableManifest, error) {
+	snap := db.NewSnapshot(ctx)
+	if snap != nil {
+		defer snap.Close()
+	}
+	it, err := db.ScanPrefix(ctx, nil, &keyvalue.Options{LargeRead: true, Snapshot: snap})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	h := sha256.New()
+	m := &srvpb.TableManifest{}
+	var buf []byte
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		key, val, err := it.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		} else if bytes.Equal(key, []byte(Key)) {
+			continue
+		}
+		buf = protowire.AppendBytes(buf[:0], key)
+		buf = protowire.AppendBytes(buf, val)
+		h.Write(buf)
+		m.Entries++
+	}
+	m.Checksum = h.Sum(nil)
+	return m, nil
+}
+
+// Write computes the manifest of the given table and writes it to the table.
+// It should be called once the table is otherwise complete.
+func Write(ctx context.Context, db keyvalue.DB) error {
+	m, err := Compute(ctx, db)
+	if err != nil {
+		return fmt.Errorf("error computing manifest: %v", err)
+	}
+	rec, err := proto.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error encoding manifest: %v", err)
+	}
+	w, err := db.Writer(ctx)
+	if err != nil {
+		return err
+	}
+	if err := w.Write([]byte(Key), rec); err != nil {
+		w.Close()
+		return fmt.Errorf("error writing manifest: %v", err)
+	}
+	return w.Close()
+}
+
+// Verify returns ErrNoManifest if the given table has no manifest and a
+// *MismatchError if its entries do not match its manifest.  Verify reads the
+// entire table.
+func Verify(ctx context.Context, db keyvalue.DB) error {
+	rec, err := db.Get(ctx, []byte(Key), nil)
+	if err == io.EOF {
+		return ErrNoManifest
+	} else if err != nil {
+		return fmt.Errorf("error reading manifest: %v", err)
+	}
+	var expected srvpb.TableManifest
+	if err := proto.Unmarshal(rec, &expected); err != nil {
+		return fmt.Errorf("error decoding manifest: %v", err)
+	}
+	found, err := Compute(ctx, db)
+	if err != nil {
+		return fmt.Errorf("error computing manifest: %v", err)
+	}
+	if found.Entries != expected.Entries || !bytes.Equal(found.Checksum, expected.Checksum) {
+		return &MismatchError{Expected: &expected, Found: found}
+	}
+	return nil
+}
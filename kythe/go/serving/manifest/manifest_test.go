@@ -0,0 +1,143 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/test/testutil"
+)
+
+var ctx = context.Background()
+
+func put(t *testing.T, db *inmemory.KeyValueDB, key, val string) {
+	t.Helper()
+	w, err := db.Writer(ctx)
+	testutil.Fatalf(t, "Writer error: %v", err)
+	testutil.Fatalf(t, "Write error: %v", w.Write([]byte(key), []byte(val)))
+	testutil.Fatalf(t, "Close error: %v", w.Close())
+}
+
+func TestVerify(t *testing.T) {
+	db := inmemory.NewKeyValueDB()
+	put(t, db, "decor:a", "1")
+	put(t, db, "xrefs:b", "2")
+
+	if err := Verify(ctx, db); err != ErrNoManifest {
+		t.Fatalf("Expected ErrNoManifest; found %v", err)
+	}
+
+	testutil.Fatalf(t, "Write error: %v", Write(ctx, db))
+	if err := Verify(ctx, db); err != nil {
+		t.Fatalf("Verify error: %v", err)
+	}
+
+	// Rewriting the manifest does not change it.
+	m, err := Compute(ctx, db)
+	testutil.Fatalf(t, "Compute error: %v", err)
+	if m.Entries != 2 {
+		t.Errorf("Expected 2 entries; found %d", m.Entries)
+	}
+	testutil.Fatalf(t, "Write error: %v", Write(ctx, db))
+	if err := Verify(ctx, db); err != nil {
+		t.Fatalf("Verify error: %v", err)
+	}
+
+	// A changed value is detected.
+	put(t, db, "xrefs:b", "3")
+	var mismatch *MismatchError
+	if err := Verify(ctx, db); !errors.As(err, &mismatch) {
+		t.Fatalf("Expected MismatchError; found %v", err)
+	} else if mismatch.Expected.Entries != 2 || mismatch.Found.Entries != 2 {
+		t.Errorf("Unexpected mismatch: %v", mismatch)
+	}
+
+	// Moving bytes between a key and its value is detected.
+	db = inmemory.NewKeyValueDB()
+	put(t, db, "ab", "c")
+	testutil.Fatalf(t, "Write error: %v", Write(ctx, db))
+	expected, err := Compute(ctx, db)
+	testutil.Fatalf(t, "Compute error: %v", err)
+	db = inmemory.NewKeyValueDB()
+	put(t, db, "a", "bc")
+	found, err := Compute(ctx, db)
+	testutil.Fatalf(t, "Compute error: %v", err)
+	if string(found.Checksum) == string(expected.Checksum) {
+		t.Error("Expected differing checksums")
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	db := inmemory.NewKeyValueDB()
+	if _, err := ReadMetadata(ctx, db); err != ErrNoMetadata {
+		t.Fatalf("Expected ErrNoMetadata; found %v", err)
+	}
+
+	buildTime := time.Date(2023, 4, 5, 6, 7, 8, 9, time.UTC)
+	md := NewMetadata("", buildTime)
+	if md.BuildId != "20230405T060708.000000009Z" {
+		t.Errorf("Unexpected derived build ID: %q", md.BuildId)
+	}
+	md = NewMetadata("build-1234", buildTime)
+	testutil.Fatalf(t, "WriteMetadata error: %v", WriteMetadata(ctx, db, md))
+	testutil.Fatalf(t, "Write error: %v", Write(ctx, db))
+
+	found, err := ReadMetadata(ctx, db)
+	testutil.Fatalf(t, "ReadMetadata error: %v", err)
+	if err := testutil.DeepEqual(md, found); err != nil {
+		t.Error(err)
+	} else if !found.BuildTime.AsTime().Equal(buildTime) {
+		t.Errorf("Expected build time %v; found %v", buildTime, found.BuildTime.AsTime())
+	}
+	if err := Verify(ctx, db); err != nil {
+		t.Errorf("Verify error: %v", err)
+	}
+}
+
+func TestKinds(t *testing.T) {
+	db := inmemory.NewKeyValueDB()
+	if _, err := ReadKinds(ctx, db); err != ErrNoKinds {
+		t.Fatalf("Expected ErrNoKinds; found %v", err)
+	}
+
+	var c KindsCounter
+	c.AddNode("file")
+	c.AddNode("anchor")
+	c.AddNode("anchor")
+	c.AddEdge("/kythe/edge/ref")
+	c.AddEdge("/kythe/edge/param.0")
+	c.AddEdge("/kythe/edge/param.1")
+	kinds := c.Summary()
+	testutil.Fatalf(t, "WriteKinds error: %v", WriteKinds(ctx, db, kinds))
+	testutil.Fatalf(t, "Write error: %v", Write(ctx, db))
+
+	found, err := ReadKinds(ctx, db)
+	testutil.Fatalf(t, "ReadKinds error: %v", err)
+	if err := testutil.DeepEqual(map[string]int64{"file": 1, "anchor": 2}, found.NodeKinds); err != nil {
+		t.Error(err)
+	}
+	if err := testutil.DeepEqual(map[string]int64{"/kythe/edge/ref": 1, "/kythe/edge/param": 2}, found.EdgeKinds); err != nil {
+		t.Error(err)
+	}
+	if err := Verify(ctx, db); err != nil {
+		t.Errorf("Verify error: %v", err)
+	}
+}
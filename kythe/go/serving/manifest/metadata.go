@@ -0,0 +1,84 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"kythe.io/kythe/go/storage/keyvalue"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// MetadataKey is the key of the srvpb.TableMetadata entry in a serving table.
+const MetadataKey = "kythe:meta"
+
+// ErrNoMetadata is returned by ReadMetadata for a table without metadata.
+var ErrNoMetadata = errors.New("serving table has no metadata")
+
+// NewMetadata returns the metadata of a table with the given build ID built at
+// the given time.  If buildID is empty, one is derived from the build time.
+func NewMetadata(buildID string, buildTime time.Time) *srvpb.TableMetadata {
+	if buildID == "" {
+		buildID = buildTime.UTC().Format("20060102T150405.000000000Z")
+	}
+	return &srvpb.TableMetadata{
+		BuildId:   buildID,
+		BuildTime: timestamppb.New(buildTime),
+	}
+}
+
+// WriteMetadata writes the given metadata to the table.  It should be called
+// before Write so that the metadata is covered by the table's manifest.
+func WriteMetadata(ctx context.Context, db keyvalue.DB, md *srvpb.TableMetadata) error {
+	rec, err := proto.Marshal(md)
+	if err != nil {
+		return fmt.Errorf("error encoding metadata: %v", err)
+	}
+	w, err := db.Writer(ctx)
+	if err != nil {
+		return err
+	}
+	if err := w.Write([]byte(MetadataKey), rec); err != nil {
+		w.Close()
+		return fmt.Errorf("error writing metadata: %v", err)
+	}
+	return w.Close()
+}
+
+// ReadMetadata returns the metadata of the given table or ErrNoMetadata if it
+// has none.
+func ReadMetadata(ctx context.Context, db keyvalue.DB) (*srvpb.TableMetadata, error) {
+	rec, err := db.Get(ctx, []byte(MetadataKey), nil)
+	if err == io.EOF {
+		return nil, ErrNoMetadata
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading metadata: %v", err)
+	}
+	var md srvpb.TableMetadata
+	if err := proto.Unmarshal(rec, &md); err != nil {
+		return nil, fmt.Errorf("error decoding metadata: %v", err)
+	}
+	return &md, nil
+}
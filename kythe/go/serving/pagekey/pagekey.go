@@ -0,0 +1,126 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package pagekey implements the structured keys of serving table pages.
+//
+// A page key has the form
+//
+//	p1/<kind>/<ticket>/<group>/<index>
+//
+// where kind names the type of page, ticket is the ticket of the paged set,
+// group is the kind of the page's group (e.g. its edge kind; empty for
+// decorations pages), and index is the page's zero-padded decimal index within
+// the set.  The ticket and group are path-escaped, so the keys of distinct
+// sets never collide.
+//
+// The opaque keys written before structured keys were introduced (generally
+// "<ticket>.<index>") are accepted by Validate so that existing serving tables
+// remain readable until rebuilt.
+package pagekey // import "kythe.io/kythe/go/serving/pagekey"
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// A Kind is a type of serving table page.
+type Kind string
+
+// Kinds of serving table pages.
+const (
+	EdgePage            Kind = "edges"
+	CrossReferencesPage Kind = "xrefs"
+	DecorationsPage     Kind = "decor"
+)
+
+const prefix = "p1/"
+
+// A Key is a parsed structured page key.
+type Key struct {
+	Kind   Kind
+	Ticket string
+	Group  string
+	Index  int
+}
+
+// String returns the encoded key.
+func (k Key) String() string {
+	return fmt.Sprintf("%s%s/%s/%s/%.10d", prefix, k.Kind, url.PathEscape(k.Ticket), url.PathEscape(k.Group), k.Index)
+}
+
+// New returns the key of the given kind of page with the given index in the
+// set of the given ticket, holding a group of the given kind.
+func New(kind Kind, ticket, group string, index int) string {
+	return Key{Kind: kind, Ticket: ticket, Group: group, Index: index}.String()
+}
+
+// IsStructured reports whether key is a structured page key, rather than a
+// legacy opaque key.  It does not check that key is well-formed.
+func IsStructured(key string) bool {
+	return strings.HasPrefix(key, prefix)
+}
+
+// Parse parses the given structured page key.
+func Parse(key string) (Key, error) {
+	if !IsStructured(key) {
+		return Key{}, fmt.Errorf("not a structured page key: %q", key)
+	}
+	parts := strings.Split(key[len(prefix):], "/")
+	if len(parts) != 4 {
+		return Key{}, fmt.Errorf("malformed page key: %q", key)
+	}
+	k := Key{Kind: Kind(parts[0])}
+	switch k.Kind {
+	case EdgePage, CrossReferencesPage, DecorationsPage:
+	default:
+		return Key{}, fmt.Errorf("unknown page kind in key %q", key)
+	}
+	var err error
+	if k.Ticket, err = url.PathUnescape(parts[1]); err != nil || k.Ticket == "" {
+		return Key{}, fmt.Errorf("malformed ticket in page key %q", key)
+	} else if k.Group, err = url.PathUnescape(parts[2]); err != nil {
+		return Key{}, fmt.Errorf("malformed group in page key %q", key)
+	}
+	index, err := strconv.Atoi(parts[3])
+	if err != nil || index < 0 {
+		return Key{}, fmt.Errorf("malformed index in page key %q", key)
+	}
+	k.Index = index
+	return k, nil
+}
+
+// Validate returns an error if key is a structured page key that is
+// malformed or does not belong to the set of the given kind and ticket or to
+// a group of the given kind.  If ticket or group is empty, it is not checked.
+// Legacy opaque keys are always valid.
+func Validate(key string, kind Kind, ticket, group string) error {
+	if !IsStructured(key) {
+		return nil
+	}
+	k, err := Parse(key)
+	if err != nil {
+		return err
+	} else if k.Kind != kind {
+		return fmt.Errorf("page key %q is not of kind %q", key, kind)
+	} else if ticket != "" && k.Ticket != ticket {
+		return fmt.Errorf("page key %q does not belong to %q", key, ticket)
+	} else if group != "" && k.Group != group {
+		return fmt.Errorf("page key %q does not belong to a %q group", key, group)
+	}
+	return nil
+}
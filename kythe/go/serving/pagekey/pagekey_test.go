@@ -0,0 +1,82 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pagekey
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	ticket = "kythe://corpus?lang=go#sig"
+	group  = "/kythe/edge/ref"
+)
+
+func TestRoundTrip(t *testing.T) {
+	key := New(EdgePage, ticket, group, 12)
+	if !strings.HasPrefix(key, "p1/edges/") || !strings.HasSuffix(key, "/0000000012") {
+		t.Errorf("Unexpected key format: %q", key)
+	}
+	k, err := Parse(key)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", key, err)
+	}
+	if k.Kind != EdgePage || k.Ticket != ticket || k.Group != group || k.Index != 12 || k.String() != key {
+		t.Errorf("Parse(%q) = %+v", key, k)
+	}
+	for _, other := range []string{
+		New(EdgePage, ticket, group, 13),
+		New(EdgePage, ticket+"2", group, 12),
+		New(EdgePage, ticket, group+"2", 12),
+		New(EdgePage, ticket+"/"+group, "", 12),
+	} {
+		if other == key {
+			t.Errorf("Page keys collide with %q", key)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		key    string
+		kind   Kind
+		ticket string
+		group  string
+		valid  bool
+	}{
+		{New(CrossReferencesPage, ticket, group, 0), CrossReferencesPage, ticket, group, true},
+		{New(CrossReferencesPage, ticket, group, 0), EdgePage, ticket, group, false},
+		{New(CrossReferencesPage, ticket, group, 0), CrossReferencesPage, "kythe://other", group, false},
+		{New(CrossReferencesPage, ticket, group, 0), CrossReferencesPage, ticket, "/kythe/edge/defines", false},
+		{New(CrossReferencesPage, ticket, group, 0), CrossReferencesPage, ticket, "", true},
+		{New(CrossReferencesPage, ticket, group, 0), CrossReferencesPage, "", "", true},
+		{New(DecorationsPage, ticket, "", 0), DecorationsPage, ticket, "", true},
+		{ticket + ".0000000000", DecorationsPage, ticket, "", true}, // legacy
+		{"opaquePage", DecorationsPage, ticket, "", true},           // legacy
+		{"p1/pages/t/g/0000000000", EdgePage, ticket, "", false},
+		{"p1/edges/%zz/g/0000000000", EdgePage, ticket, "", false},
+		{"p1/edges//g/0000000000", EdgePage, ticket, "", false},
+		{"p1/edges/t/%zz/0000000000", EdgePage, ticket, "", false},
+		{"p1/edges/t/g/x", EdgePage, ticket, "", false},
+		{"p1/edges/t/0000000000", EdgePage, ticket, "", false},
+	}
+	for _, test := range tests {
+		if err := Validate(test.key, test.kind, test.ticket, test.group); (err == nil) != test.valid {
+			t.Errorf("Validate(%q, %q, %q, %q): expected valid %v; found error %v", test.key, test.kind, test.ticket, test.group, test.valid, err)
+		}
+	}
+}
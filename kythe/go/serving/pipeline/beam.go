@@ -22,17 +22,24 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 
+	"kythe.io/kythe/go/services/xrefs"
+	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/manifest"
 	"kythe.io/kythe/go/serving/pipeline/nodes"
 	"kythe.io/kythe/go/serving/xrefs/assemble"
+	"kythe.io/kythe/go/util/bloom"
 	"kythe.io/kythe/go/util/compare"
 	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/markedsource"
 	"kythe.io/kythe/go/util/schema"
 	"kythe.io/kythe/go/util/schema/edges"
 	"kythe.io/kythe/go/util/schema/facts"
 	kinds "kythe.io/kythe/go/util/schema/nodes"
 	"kythe.io/kythe/go/util/span"
 
+	"bitbucket.org/creachadair/stringset"
 	"github.com/apache/beam/sdks/go/pkg/beam"
 	"github.com/apache/beam/sdks/go/pkg/beam/transforms/filter"
 	"google.golang.org/protobuf/proto"
@@ -47,8 +54,13 @@ import (
 )
 
 func init() {
+	beam.RegisterFunction(addCommitTimes)
+	beam.RegisterFunction(addDecorationsFilterKey)
+	beam.RegisterFunction(addFileRevision)
+	beam.RegisterFunction(addKindsKey)
 	beam.RegisterFunction(bareRevEdge)
 	beam.RegisterFunction(callEdge)
+	beam.RegisterFunction(callEdgeToDegrees)
 	beam.RegisterFunction(combineEdgesIndex)
 	beam.RegisterFunction(completeDocument)
 	beam.RegisterFunction(constructCaller)
@@ -58,53 +70,95 @@ func init() {
 	beam.RegisterFunction(edgeToCrossRefRelation)
 	beam.RegisterFunction(emitRelatedDefs)
 	beam.RegisterFunction(fileToDecorPiece)
+	beam.RegisterFunction(fileToDigest)
 	beam.RegisterFunction(fileToTags)
 	beam.RegisterFunction(filterAnchorNodes)
+	beam.RegisterFunction(groupCallDegrees)
+	beam.RegisterFunction(groupContainerMembers)
+	beam.RegisterFunction(groupContainerRollup)
 	beam.RegisterFunction(groupCrossRefs)
 	beam.RegisterFunction(groupEdges)
+	beam.RegisterFunction(groupFileDigests)
+	beam.RegisterFunction(groupFileOutline)
+	beam.RegisterFunction(groupFileRefs)
+	beam.RegisterFunction(groupFileRelations)
+	beam.RegisterFunction(keyBlameByFile)
 	beam.RegisterFunction(keyByPath)
 	beam.RegisterFunction(keyCrossRef)
 	beam.RegisterFunction(keyNode)
 	beam.RegisterFunction(keyRef)
+	beam.RegisterFunction(keyRefByFile)
+	beam.RegisterFunction(markedSourceToDisplayName)
 	beam.RegisterFunction(moveSourceToKey)
 	beam.RegisterFunction(nodeToChildren)
 	beam.RegisterFunction(nodeToDecorPiece)
 	beam.RegisterFunction(nodeToDiagnostic)
 	beam.RegisterFunction(nodeToDocs)
 	beam.RegisterFunction(nodeToEdges)
+	beam.RegisterFunction(nodeToFactHashes)
 	beam.RegisterFunction(nodeToReverseEdges)
+	beam.RegisterFunction(overriddenByToDecor)
 	beam.RegisterFunction(overriddenToDecor)
 	beam.RegisterFunction(overridingToFile)
 	beam.RegisterFunction(parseMarkedSource)
 	beam.RegisterFunction(refToCallsite)
 	beam.RegisterFunction(refToCrossRef)
 	beam.RegisterFunction(refToDecorPiece)
+	beam.RegisterFunction(refToFileRef)
+	beam.RegisterFunction(refToFileRelations)
 	beam.RegisterFunction(refToTag)
+	beam.RegisterFunction(refToTargetCount)
+	beam.RegisterFunction(referenceCountToFile)
 	beam.RegisterFunction(reverseEdge)
 	beam.RegisterFunction(splitEdge)
 	beam.RegisterFunction(targetToFile)
+	beam.RegisterFunction(toContainerMembers)
 	beam.RegisterFunction(toDefinition)
 	beam.RegisterFunction(toFiles)
+	beam.RegisterFunction(toOutlineSymbol)
 	beam.RegisterFunction(toRefs)
+	beam.RegisterFunction(toRollupTargets)
+	beam.RegisterFunction(vcsToRevision)
+	beam.RegisterFunction(vnameToTicket)
 
 	beam.RegisterType(reflect.TypeOf((*combineDecorPieces)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*combineExistenceFilter)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*combineKinds)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*factHashesGeneration)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*ticketKey)(nil)).Elem())
 
 	beam.RegisterType(reflect.TypeOf((*cpb.Diagnostic)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*cpb.MarkedSource)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*ppb.DecorationPiece)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*ppb.FileBlame)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*ppb.FileBlame_Hunk)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*ppb.Reference)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*scpb.Edge)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*scpb.Node)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*spb.Entry)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*spb.VName)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.CallDegrees)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.ContainerMembers)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.ContainerMembers_Member)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.ContainerRollup)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.ContainerRollup_Target)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.CorpusRoots)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.DisplayName)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.Document)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.EdgePage)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.ExistenceFilter)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.ExpandedAnchor)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FactHashesGeneration)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.File)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.FileDecorations)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FileDigest)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.FileDirectory)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FileOutline)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FileOutline_Symbol)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FileReferences)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.FileRelations)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.KindsSummary)(nil)).Elem())
+	beam.RegisterType(reflect.TypeOf((*srvpb.NodeFactHashes)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedCrossReferences)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedCrossReferences_Page)(nil)).Elem())
 	beam.RegisterType(reflect.TypeOf((*srvpb.PagedEdgeSet)(nil)).Elem())
@@ -124,6 +178,8 @@ type KytheBeam struct {
 	markedSources beam.PCollection // KV<*spb.VName, *cpb.MarkedSource>
 
 	anchorBuildConfigs beam.PCollection // KV<*spb.VName, string>
+
+	blame beam.PCollection // *ppb.FileBlame
 }
 
 // FromNodes creates a KytheBeam pipeline from an input collection of
@@ -138,6 +194,15 @@ func FromEntries(s beam.Scope, entries beam.PCollection) *KytheBeam {
 	return FromNodes(s, nodes.FromEntries(s, entries))
 }
 
+// WithBlame sets the *ppb.FileBlames from which the commit time of each anchor
+// (see srvpb.ExpandedAnchor.commit_time) is derived, e.g. as read from the
+// corpus's VCS.  It must be called before any table is derived.  Anchors of
+// files without a FileBlame have no commit time.
+func (k *KytheBeam) WithBlame(blame beam.PCollection) *KytheBeam {
+	k.blame = blame
+	return k
+}
+
 func keyNode(n *scpb.Node) (*spb.VName, *scpb.Node) { return n.Source, n }
 
 // SplitCrossReferences returns a columnar Kythe cross-references table derived
@@ -390,7 +455,8 @@ func groupCrossRefs(
 			AndThen(set.Group[i].Kind, set.Group[j].Kind) == compare.LT
 	})
 	for _, g := range set.Group {
-		sort.Slice(g.Anchor, func(i, j int) bool { return g.Anchor[i].Ticket < g.Anchor[j].Ticket })
+		sortExpandedAnchors(g.Anchor)
+		sortCallers(g.Caller)
 		for _, caller := range g.Caller {
 			sort.Slice(caller.Callsite, func(i, j int) bool { return caller.Callsite[i].Ticket < caller.Callsite[j].Ticket })
 		}
@@ -399,6 +465,41 @@ func groupCrossRefs(
 	emitSet("xrefs:"+set.SourceTicket, set)
 }
 
+// sortExpandedAnchors sorts the given anchors by their file's corpus, root,
+// and path and then by their span within each file.  The xrefs service merges
+// the anchors of every group in this order to page through them by location.
+func sortExpandedAnchors(as []*srvpb.ExpandedAnchor) {
+	sortByAnchor(as, func(a *srvpb.ExpandedAnchor) *srvpb.ExpandedAnchor { return a })
+}
+
+// sortCallers sorts the given callers by their anchors, as sortExpandedAnchors
+// sorts anchors.
+func sortCallers(cs []*srvpb.PagedCrossReferences_Caller) {
+	sortByAnchor(cs, (*srvpb.PagedCrossReferences_Caller).GetCaller)
+}
+
+func sortByAnchor[T any](xs []T, anchor func(T) *srvpb.ExpandedAnchor) {
+	uris := make(map[*srvpb.ExpandedAnchor]*kytheuri.URI, len(xs))
+	for _, x := range xs {
+		a := anchor(x)
+		u, err := kytheuri.Parse(a.GetTicket())
+		if err != nil {
+			u = &kytheuri.URI{Signature: a.GetTicket()}
+		}
+		uris[a] = u
+	}
+	sort.Slice(xs, func(i, j int) bool {
+		x, y := anchor(xs[i]), anchor(xs[j])
+		a, b := uris[x], uris[y]
+		return compare.Strings(a.Corpus, b.Corpus).
+			AndThen(a.Root, b.Root).
+			AndThen(a.Path, b.Path).
+			AndThen(x.GetSpan().GetStart().GetByteOffset(), y.GetSpan().GetStart().GetByteOffset()).
+			AndThen(x.GetSpan().GetEnd().GetByteOffset(), y.GetSpan().GetEnd().GetByteOffset()).
+			AndThen(x.GetTicket(), y.GetTicket()) == compare.LT
+	})
+}
+
 func keyRef(r *ppb.Reference) (*spb.VName, *ppb.Reference) {
 	return r.Source, &ppb.Reference{
 		Kind:   r.Kind,
@@ -422,16 +523,82 @@ func (k *KytheBeam) decorationPieces(s beam.Scope) beam.PCollection {
 	defs := beam.ParDo(s, defToDecorPiece,
 		beam.CoGroupByKey(s, k.directDefinitions(), targets))
 	overrides := k.overrides(targets)
+	refCounts := beam.ParDo(s, referenceCountToFile,
+		beam.CoGroupByKey(s, targets, beam.ParDo(s, refToTargetCount, k.References())))
 	decorDiagnostics := k.diagnostics()
 
-	return beam.Flatten(s, decor, files, targetNodes, defs, decorDiagnostics, overrides)
+	return beam.Flatten(s, decor, files, targetNodes, defs, decorDiagnostics, overrides, refCounts)
+}
+
+// refToTargetCount emits a count of 1 for the target of each reference edge.
+func refToTargetCount(r *ppb.Reference, emit func(*spb.VName, int64)) {
+	if edges.IsVariant(refKind(r), edges.Ref) {
+		emit(r.Source, 1)
+	}
+}
+
+// referenceCountToFile emits the total number of references to target to
+// each file decorating it.
+func referenceCountToFile(target *spb.VName, files func(**spb.VName) bool, counts func(*int64) bool, emit func(*spb.VName, *ppb.DecorationPiece)) {
+	var total, count int64
+	for counts(&count) {
+		total += count
+	}
+	if total == 0 {
+		return
+	}
+	seen := make(map[string]bool)
+	var file *spb.VName
+	for files(&file) {
+		if key := kytheuri.ToString(file); !seen[key] {
+			seen[key] = true
+			emit(file, &ppb.DecorationPiece{
+				Piece: &ppb.DecorationPiece_ReferenceCount_{&ppb.DecorationPiece_ReferenceCount{
+					Node:  target,
+					Count: total,
+				}},
+			})
+		}
+	}
 }
 
 func (k *KytheBeam) overrides(targets beam.PCollection) beam.PCollection {
 	s := k.s.Scope("Overrides")
 	overriddenToEdge := beam.Seq(s, k.Nodes(), &nodes.Filter{IncludeEdges: []string{edges.Overrides, edges.Extends, edges.OverridesTransitive, edges.Satisfies}}, nodeToEdges)
 	overridingToDecor := beam.ParDo(s, overriddenToDecor, beam.CoGroupByKey(s, k.directDefinitions(), overriddenToEdge))
-	return beam.ParDo(s, overridingToFile, beam.CoGroupByKey(s, targets, overridingToDecor))
+	overriddenByDecor := beam.ParDo(s, overriddenByToDecor, overriddenToEdge)
+	return beam.Flatten(s,
+		beam.ParDo(s, overridingToFile, beam.CoGroupByKey(s, targets, overridingToDecor)),
+		beam.ParDo(s, overridingToFile, beam.CoGroupByKey(s, targets, overriddenByDecor)))
+}
+
+// overrideKind returns the kind of override represented by the given edge.
+func overrideKind(e *scpb.Edge) (srvpb.FileDecorations_Override_Kind, bool) {
+	edgeKindString := schema.EdgeKindString(e.GetKytheKind())
+	if edges.IsVariant(edgeKindString, edges.Overrides) {
+		return srvpb.FileDecorations_Override_OVERRIDES, true
+	} else if edges.IsVariant(edgeKindString, edges.Extends) || edges.IsVariant(edgeKindString, edges.Satisfies) {
+		return srvpb.FileDecorations_Override_EXTENDS, true
+	}
+	return 0, false
+}
+
+// overriddenByToDecor emits an overridden-by DecorationPiece for the edge's
+// overridden node.
+func overriddenByToDecor(overridden *spb.VName, e *scpb.Edge, emit func(*spb.VName, *ppb.DecorationPiece)) {
+	kind, ok := overrideKind(e)
+	if !ok {
+		return
+	}
+	emit(overridden, &ppb.DecorationPiece{
+		Piece: &ppb.DecorationPiece_TargetOverriddenBy{
+			TargetOverriddenBy: &xspb.FileDecorations_TargetOverride{
+				Overriding: e.Source,
+				Overridden: e.Target,
+				Kind:       kind,
+			},
+		},
+	})
 }
 
 func overriddenToDecor(overridden *spb.VName, overriddenAnchors func(**srvpb.ExpandedAnchor) bool, edgeStream func(**scpb.Edge) bool, emit func(*spb.VName, *ppb.DecorationPiece)) {
@@ -441,13 +608,8 @@ func overriddenToDecor(overridden *spb.VName, overriddenAnchors func(**srvpb.Exp
 		return
 	}
 	for edgeStream(&e) {
-		var kind srvpb.FileDecorations_Override_Kind
-		edgeKindString := schema.EdgeKindString(e.GetKytheKind())
-		if edges.IsVariant(edgeKindString, edges.Overrides) {
-			kind = srvpb.FileDecorations_Override_OVERRIDES
-		} else if edges.IsVariant(edgeKindString, edges.Extends) || edges.IsVariant(edgeKindString, edges.Satisfies) {
-			kind = srvpb.FileDecorations_Override_EXTENDS
-		} else {
+		kind, ok := overrideKind(e)
+		if !ok {
 			continue
 		}
 		emit(e.Source, &ppb.DecorationPiece{
@@ -595,6 +757,14 @@ func (c *combineDecorPieces) MergeAccumulators(accum, n *srvpb.FileDecorations)
 	accum.Target = append(accum.Target, n.Target...)
 	accum.TargetDefinitions = append(accum.TargetDefinitions, n.TargetDefinitions...)
 	accum.Diagnostic = append(accum.Diagnostic, n.Diagnostic...)
+	accum.TargetOverride = append(accum.TargetOverride, n.TargetOverride...)
+	accum.TargetOverriddenBy = append(accum.TargetOverriddenBy, n.TargetOverriddenBy...)
+	for ticket, count := range n.TargetReferenceCount {
+		if accum.TargetReferenceCount == nil {
+			accum.TargetReferenceCount = make(map[string]int64)
+		}
+		accum.TargetReferenceCount[ticket] = count
+	}
 	return accum
 }
 
@@ -614,6 +784,9 @@ func (c *combineDecorPieces) AddInput(accum *srvpb.FileDecorations, p *ppb.Decor
 		})
 	case *ppb.DecorationPiece_File:
 		accum.File = p.File
+		if p.File.Info != nil {
+			accum.FileInfo = append(accum.FileInfo, p.File.Info)
+		}
 	case *ppb.DecorationPiece_Node:
 		accum.Target = append(accum.Target, convertPipelineNode(p.Node))
 	case *ppb.DecorationPiece_Definition_:
@@ -636,6 +809,17 @@ func (c *combineDecorPieces) AddInput(accum *srvpb.FileDecorations, p *ppb.Decor
 			OverriddenDefinition: p.TargetOverride.OverridingDefinition.Ticket,
 			Kind:                 p.TargetOverride.Kind,
 		})
+	case *ppb.DecorationPiece_TargetOverriddenBy:
+		accum.TargetOverriddenBy = append(accum.TargetOverriddenBy, &srvpb.FileDecorations_Override{
+			Overriding: kytheuri.ToString(p.TargetOverriddenBy.Overriding),
+			Overridden: kytheuri.ToString(p.TargetOverriddenBy.Overridden),
+			Kind:       p.TargetOverriddenBy.Kind,
+		})
+	case *ppb.DecorationPiece_ReferenceCount_:
+		if accum.TargetReferenceCount == nil {
+			accum.TargetReferenceCount = make(map[string]int64)
+		}
+		accum.TargetReferenceCount[kytheuri.ToString(p.ReferenceCount.Node)] = p.ReferenceCount.Count
 	default:
 		panic(fmt.Errorf("unhandled DecorationPiece: %T", p))
 	}
@@ -663,6 +847,7 @@ func convertPipelineNode(node *scpb.Node) *srvpb.Node {
 		})
 	}
 	sort.Slice(n.Fact, func(i, j int) bool { return n.Fact[i].Name < n.Fact[j].Name })
+	assemble.SetDeprecation(n)
 	return n
 }
 
@@ -697,6 +882,7 @@ func (c *combineDecorPieces) ExtractOutput(fd *srvpb.FileDecorations) *srvpb.Fil
 		}
 		return fd.Decoration[i].Target < fd.Decoration[j].Target
 	})
+	assemble.AddAnchorFingerprints(fd)
 	sort.Slice(fd.Target, func(i, j int) bool { return fd.Target[i].Ticket < fd.Target[j].Ticket })
 	sort.Slice(fd.TargetDefinitions, func(i, j int) bool { return fd.TargetDefinitions[i].Ticket < fd.TargetDefinitions[j].Ticket })
 
@@ -813,21 +999,95 @@ func (k *KytheBeam) References() beam.PCollection {
 			},
 		}, k.nodes))
 	k.refs = beam.ParDo(s, toRefs, beam.CoGroupByKey(s, k.getFiles(), anchors))
+	if k.blame.IsValid() {
+		k.refs = beam.ParDo(s, addCommitTimes, beam.CoGroupByKey(s,
+			beam.ParDo(s, keyRefByFile, k.refs),
+			beam.ParDo(s, keyBlameByFile, k.blame)))
+	}
 	return k.refs
 }
 
+// keyRefByFile keys a *ppb.Reference by the VName of its anchor's file.
+func keyRefByFile(r *ppb.Reference) (*spb.VName, *ppb.Reference, error) {
+	file, err := anchorToFileVName(r.GetAnchor().GetTicket())
+	return file, r, err
+}
+
+func keyBlameByFile(b *ppb.FileBlame) (*spb.VName, *ppb.FileBlame) { return b.File, b }
+
+// addCommitTimes sets the commit time of each of a file's references' anchors
+// to that of the blame hunk containing the anchor's first line, if any.
+func addCommitTimes(file *spb.VName, refs func(**ppb.Reference) bool, blames func(**ppb.FileBlame) bool, emit func(*ppb.Reference)) {
+	var hunks []*ppb.FileBlame_Hunk
+	var b *ppb.FileBlame
+	for blames(&b) {
+		hunks = append(hunks, b.Hunk...)
+	}
+	sort.Slice(hunks, func(i, j int) bool { return hunks[i].StartLine < hunks[j].StartLine })
+
+	var r *ppb.Reference
+	for refs(&r) {
+		line := r.GetAnchor().GetSpan().GetStart().GetLineNumber()
+		i := sort.Search(len(hunks), func(i int) bool { return hunks[i].StartLine > line }) - 1
+		if i < 0 || hunks[i].EndLine < line {
+			emit(r)
+			continue
+		}
+		r = proto.Clone(r).(*ppb.Reference)
+		r.Anchor.CommitTime = hunks[i].CommitTime
+		emit(r)
+	}
+}
+
 func (k *KytheBeam) getFiles() beam.PCollection {
 	if !k.files.IsValid() {
 		fileNodes := beam.ParDo(k.s,
 			&nodes.Filter{
 				FilterByKind: []string{kinds.File},
-				IncludeFacts: []string{facts.Text, facts.TextEncoding},
+				IncludeFacts: []string{facts.Text, facts.TextEncoding, facts.Generated},
+			}, k.nodes)
+		vcsNodes := beam.ParDo(k.s,
+			&nodes.Filter{
+				FilterByKind: []string{kinds.VCS},
+				IncludeFacts: []string{facts.VCSID},
 			}, k.nodes)
-		k.files = beam.ParDo(k.s, toFiles, fileNodes)
+		revisions := beam.ParDo(k.s, vcsToRevision, vcsNodes)
+		k.files = beam.ParDo(k.s, addFileRevision, beam.ParDo(k.s, toFiles, fileNodes), beam.SideInput{Input: revisions})
 	}
 	return k.files
 }
 
+// vcsToRevision returns the corpus named by a vcs node and its revision.
+func vcsToRevision(n *scpb.Node, emit func(string, string)) {
+	for _, fact := range n.Fact {
+		if fact.GetGenericName() == facts.VCSID {
+			emit(n.Source.GetCorpus(), string(fact.Value))
+			return
+		}
+	}
+}
+
+// addFileRevision attaches the FileInfo of a file whose corpus has a known
+// revision.
+func addFileRevision(src *spb.VName, f *srvpb.File, revisions func(*string, *string) bool) (*spb.VName, *srvpb.File) {
+	var corpus, rev string
+	for revisions(&corpus, &rev) {
+		if corpus == src.GetCorpus() {
+			return src, &srvpb.File{
+				Ticket:   f.Ticket,
+				Text:     f.Text,
+				Encoding: f.Encoding,
+				Info: &srvpb.FileInfo{
+					CorpusPath: &cpb.CorpusPath{Corpus: src.Corpus, Root: src.Root, Path: src.Path},
+					Revision:   rev,
+					Generated:  f.GetInfo().GetGenerated(),
+				},
+			}
+		}
+	}
+	return src, f
+}
+
 func keyByPath(n *scpb.Node) (*spb.VName, *scpb.Node) {
 	return &spb.VName{Corpus: n.Source.Corpus, Root: n.Source.Root, Path: n.Source.Path}, n
 }
@@ -848,6 +1108,16 @@ func toFiles(n *scpb.Node) (*spb.VName, *srvpb.File) {
 			f.Text = fact.Value
 		case scpb.FactName_TEXT_ENCODING:
 			f.Encoding = string(fact.Value)
+		default:
+			if fact.GetGenericName() == facts.Generated && string(fact.Value) == "true" {
+				// Mark files generated per their indexer so that their anchors may
+				// be excluded from cross-references.
+				src := n.Source
+				f.Info = &srvpb.FileInfo{
+					CorpusPath: &cpb.CorpusPath{Corpus: src.Corpus, Root: src.Root, Path: src.Path},
+					Generated:  true,
+				}
+			}
 		}
 	}
 	return n.Source, &f
@@ -866,6 +1136,7 @@ func normalizeAnchors(file *srvpb.File, anchor func(**scpb.Node) bool, emit func
 			log.Printf("error expanding anchor {%+v}: %v", raw, err)
 			break
 		}
+		a.FileInfo = file.Info
 
 		var parent *spb.VName
 		for _, e := range n.Edge {
@@ -1222,6 +1493,554 @@ func parseMarkedSource(n *scpb.Node, emit func(*spb.VName, *cpb.MarkedSource)) e
 	return nil
 }
 
+// DisplayNames returns a Kythe display name table derived from the Kythe input
+// graph.  The beam.PCollection has elements of type KV<string,
+// *srvpb.DisplayName>.
+func (k *KytheBeam) DisplayNames() beam.PCollection {
+	s := k.s.Scope("DisplayNames")
+	return beam.ParDo(s, markedSourceToDisplayName, k.getMarkedSources())
+}
+
+// markedSourceToDisplayName renders a short display name (e.g. "pkg.Foo(x)")
+// from a node's MarkedSource.  No name is emitted if the MarkedSource has no
+// identifier.
+func markedSourceToDisplayName(src *spb.VName, ms *cpb.MarkedSource, emit func(string, *srvpb.DisplayName)) {
+	name := renderDisplayName(ms)
+	if name == "" {
+		return
+	}
+	ticket := kytheuri.ToString(src)
+	emit("names:"+ticket, &srvpb.DisplayName{Ticket: ticket, Name: name})
+}
+
+// renderDisplayName returns the display name of a node with the given
+// MarkedSource, or "" if it has no identifier.
+func renderDisplayName(ms *cpb.MarkedSource) string {
+	info := markedsource.RenderQualifiedName(ms)
+	name := info.GetQualifiedName()
+	if name == "" {
+		name = info.GetBaseName()
+	}
+	if name == "" {
+		name = markedsource.RenderSimpleIdentifier(ms)
+	}
+	if name == "" {
+		return ""
+	}
+	if params := markedsource.RenderSimpleParams(ms); len(params) > 0 {
+		name += "(" + strings.Join(params, ", ") + ")"
+	}
+	return name
+}
+
+// FileDigests returns a Kythe file digest table derived from the Kythe input
+// graph.  The beam.PCollection has elements of type KV<string,
+// *srvpb.FileDigest>.  Only digests shared by more than one file are emitted.
+func (k *KytheBeam) FileDigests() beam.PCollection {
+	s := k.s.Scope("FileDigests")
+	digests := beam.ParDo(s, fileToDigest, k.getFiles())
+	return beam.ParDo(s, groupFileDigests, beam.GroupByKey(s, digests))
+}
+
+// fileToDigest emits the content digest of a file along with its ticket.
+func fileToDigest(src *spb.VName, f *srvpb.File) (string, string) {
+	return xrefs.FileDigest(f.Text), kytheuri.ToString(src)
+}
+
+// groupFileDigests emits a *srvpb.FileDigest for each digest shared by more
+// than one file ticket.
+func groupFileDigests(digest string, ticketIter func(*string) bool, emit func(string, *srvpb.FileDigest)) {
+	var tickets []string
+	var ticket string
+	for ticketIter(&ticket) {
+		tickets = append(tickets, ticket)
+	}
+	if len(tickets) < 2 {
+		return
+	}
+	sort.Strings(tickets)
+	emit("digests:"+digest, &srvpb.FileDigest{Digest: digest, Ticket: tickets})
+}
+
+// FileReferences returns a Kythe file references table derived from the Kythe
+// input graph.  The beam.PCollection has elements of type KV<string,
+// *srvpb.FileReferences>.
+func (k *KytheBeam) FileReferences() beam.PCollection {
+	s := k.s.Scope("FileReferences")
+	refs := beam.ParDo(s, refToFileRef, k.References())
+	return beam.ParDo(s, groupFileRefs, beam.GroupByKey(s, refs))
+}
+
+// refToFileRef emits the ticket of the file containing a reference's anchor
+// along with the ticket of the referenced node.
+func refToFileRef(r *ppb.Reference, emit func(string, string)) error {
+	if r.GetKytheKind() == scpb.EdgeKind_TAGGED {
+		return nil
+	}
+	file, err := anchorToFileVName(r.Anchor.Ticket)
+	if err != nil {
+		return err
+	}
+	emit(kytheuri.ToString(file), kytheuri.ToString(r.Source))
+	return nil
+}
+
+// groupFileRefs emits a *srvpb.FileReferences counting the references to each
+// node from within a file.
+func groupFileRefs(file string, ticketIter func(*string) bool, emit func(string, *srvpb.FileReferences)) {
+	counts := make(map[string]int32)
+	var ticket string
+	for ticketIter(&ticket) {
+		counts[ticket]++
+	}
+	refs := &srvpb.FileReferences{File: file}
+	for ticket, count := range counts {
+		refs.Node = append(refs.Node, &srvpb.FileReferences_Node{Ticket: ticket, Count: count})
+	}
+	sort.Slice(refs.Node, func(i, j int) bool { return refs.Node[i].Ticket < refs.Node[j].Ticket })
+	emit("fileRefs:"+file, refs)
+}
+
+// FileRelations returns a Kythe file relations table derived from the Kythe
+// input graph.  The beam.PCollection has elements of type KV<string,
+// *srvpb.FileRelations>.
+func (k *KytheBeam) FileRelations() beam.PCollection {
+	s := k.s.Scope("FileRelations")
+	rels := beam.ParDo(s, refToFileRelations, k.References())
+	return beam.ParDo(s, groupFileRelations, beam.GroupByKey(s, rels))
+}
+
+// refToFileRelations emits an outgoing relation for the file containing an
+// includes or imports reference's anchor and an incoming relation for the
+// referenced file.  References to nodes other than files (e.g. imported
+// packages) are ignored.
+func refToFileRelations(r *ppb.Reference, emit func(string, *srvpb.FileRelations)) error {
+	var kind string
+	switch r.GetKytheKind() {
+	case scpb.EdgeKind_REF_INCLUDES:
+		kind = edges.RefIncludes
+	case scpb.EdgeKind_REF_IMPORTS:
+		kind = edges.RefImports
+	default:
+		return nil
+	}
+	if r.Source.GetPath() == "" || r.Source.GetSignature() != "" || r.Source.GetLanguage() != "" {
+		return nil
+	}
+	file, err := anchorToFileVName(r.Anchor.Ticket)
+	if err != nil {
+		return err
+	}
+	src, tgt := kytheuri.ToString(file), kytheuri.ToString(r.Source)
+	emit(src, &srvpb.FileRelations{Outgoing: []*srvpb.FileRelations_Relation{{Ticket: tgt, Kind: kind}}})
+	emit(tgt, &srvpb.FileRelations{Incoming: []*srvpb.FileRelations_Relation{{Ticket: src, Kind: kind}}})
+	return nil
+}
+
+// groupFileRelations emits the union of a file's *srvpb.FileRelations.
+func groupFileRelations(file string, relsIter func(**srvpb.FileRelations) bool, emit func(string, *srvpb.FileRelations)) {
+	type relation struct{ kind, ticket string }
+	outgoing := make(map[relation]bool)
+	incoming := make(map[relation]bool)
+	add := func(set map[relation]bool, rels []*srvpb.FileRelations_Relation) {
+		for _, r := range rels {
+			set[relation{r.Kind, r.Ticket}] = true
+		}
+	}
+	sorted := func(set map[relation]bool) []*srvpb.FileRelations_Relation {
+		var rels []*srvpb.FileRelations_Relation
+		for r := range set {
+			rels = append(rels, &srvpb.FileRelations_Relation{Ticket: r.ticket, Kind: r.kind})
+		}
+		sort.Slice(rels, func(i, j int) bool {
+			return compare.Strings(rels[i].Kind, rels[j].Kind).AndThen(rels[i].Ticket, rels[j].Ticket) == compare.LT
+		})
+		return rels
+	}
+
+	var r *srvpb.FileRelations
+	for relsIter(&r) {
+		add(outgoing, r.Outgoing)
+		add(incoming, r.Incoming)
+	}
+	emit("fileRelations:"+file, &srvpb.FileRelations{
+		File:     file,
+		Outgoing: sorted(outgoing),
+		Incoming: sorted(incoming),
+	})
+}
+
+// CallDegrees returns a Kythe call degrees table derived from the Kythe input
+// graph.  The beam.PCollection has elements of type KV<string,
+// *srvpb.CallDegrees>.
+func (k *KytheBeam) CallDegrees() beam.PCollection {
+	s := k.s.Scope("CallDegrees")
+	callsites := beam.ParDo(s, refToCallsite, k.References())
+	calls := filter.Distinct(s, beam.ParDo(s, callEdge, callsites))
+	return beam.ParDo(s, groupCallDegrees, beam.GroupByKey(s, beam.ParDo(s, callEdgeToDegrees, calls)))
+}
+
+// callEdgeToDegrees emits a callee for the ticket of a call edge's caller and a
+// caller for the ticket of its callee.
+func callEdgeToDegrees(e *scpb.Edge, emit func(string, *srvpb.CallDegrees)) {
+	emit(kytheuri.ToString(e.Source), &srvpb.CallDegrees{Callees: 1})
+	emit(kytheuri.ToString(e.Target), &srvpb.CallDegrees{Callers: 1})
+}
+
+// groupCallDegrees emits the sum of a ticket's *srvpb.CallDegrees.
+func groupCallDegrees(ticket string, degreesIter func(**srvpb.CallDegrees) bool, emit func(string, *srvpb.CallDegrees)) {
+	res := &srvpb.CallDegrees{Ticket: ticket}
+	var d *srvpb.CallDegrees
+	for degreesIter(&d) {
+		res.Callers += d.Callers
+		res.Callees += d.Callees
+	}
+	emit("callDegrees:"+ticket, res)
+}
+
+// Members returns a Kythe container members table derived from the Kythe input
+// graph.  Each node is a member of its /kythe/edge/childof parents and of the
+// file defining it.  The beam.PCollection has elements of type KV<string,
+// *srvpb.ContainerMembers>.
+func (k *KytheBeam) Members() beam.PCollection {
+	s := k.s.Scope("Members")
+	memberNodes := beam.ParDo(s, moveSourceToKey, beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.ChildOf},
+	}))
+	members := beam.ParDo(s, toContainerMembers, beam.CoGroupByKey(s, memberNodes, k.getMarkedSources(), k.directDefinitions()))
+	return beam.ParDo(s, groupContainerMembers, beam.GroupByKey(s, members))
+}
+
+// toContainerMembers emits a *srvpb.ContainerMembers_Member for a node, keyed
+// by the table key of each of its containers.  Anchors and files are not
+// members.
+func toContainerMembers(key *spb.VName, nodeStream func(**scpb.Node) bool, msStream func(**cpb.MarkedSource) bool, defStream func(**srvpb.ExpandedAnchor) bool, emit func(string, *srvpb.ContainerMembers_Member)) error {
+	var n *scpb.Node
+	if !nodeStream(&n) {
+		return nil
+	}
+	kind := schema.GetNodeKind(n)
+	if kind == "" || kind == kinds.Anchor || kind == kinds.File {
+		return nil
+	}
+
+	m := &srvpb.ContainerMembers_Member{
+		Ticket:  kytheuri.ToString(key),
+		Subkind: schema.GetSubkind(n),
+	}
+	var ms *cpb.MarkedSource
+	if msStream(&ms) {
+		m.DisplayName = renderDisplayName(ms)
+	}
+	m.Definition = firstDefinition(defStream)
+
+	containers, err := nodeContainers(n, m.Definition)
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		emit("members:"+kind+":"+c, m)
+	}
+	return nil
+}
+
+// firstDefinition returns the definition with the least ticket, if any, so
+// that the choice between multiple definitions is deterministic.
+func firstDefinition(defStream func(**srvpb.ExpandedAnchor) bool) *srvpb.ExpandedAnchor {
+	var first, def *srvpb.ExpandedAnchor
+	for defStream(&def) {
+		if first == nil || def.Ticket < first.Ticket {
+			first = def
+		}
+	}
+	return first
+}
+
+// childOfParents returns the tickets of a node's /kythe/edge/childof parents.
+func childOfParents(n *scpb.Node) stringset.Set {
+	parents := stringset.New()
+	for _, e := range n.Edge {
+		if e.GetKytheKind() == scpb.EdgeKind_CHILD_OF {
+			parents.Add(kytheuri.ToString(e.Target))
+		}
+	}
+	return parents
+}
+
+// nodeContainers returns the sorted tickets of the containers of a node: its
+// /kythe/edge/childof parents and the file of its given definition, if any.
+func nodeContainers(n *scpb.Node, def *srvpb.ExpandedAnchor) ([]string, error) {
+	containers := childOfParents(n)
+	if def != nil {
+		file, err := anchorToFileVName(def.Ticket)
+		if err != nil {
+			return nil, err
+		}
+		containers.Add(kytheuri.ToString(file))
+	}
+	return containers.Elements(), nil
+}
+
+// DecorationsFilter returns the single *srvpb.ExistenceFilter key-value of the
+// tickets of the files with decorations (i.e. each file node).  The
+// beam.PCollection has elements of type KV<string, *srvpb.ExistenceFilter>.
+func (k *KytheBeam) DecorationsFilter() beam.PCollection {
+	s := k.s.Scope("DecorationsFilter")
+	files := beam.ParDo(s, vnameToTicket, k.getFileVNames())
+	return beam.ParDo(s, addDecorationsFilterKey, beam.Combine(s, &combineExistenceFilter{}, files))
+}
+
+// vnameToTicket returns the ticket of the given VName.
+func vnameToTicket(v *spb.VName) string { return kytheuri.ToString(v) }
+
+// addDecorationsFilterKey returns the given value with the decorations filter
+// key constant.
+func addDecorationsFilterKey(val beam.T) (string, beam.T) { return "decorFilter", val }
+
+// combineExistenceFilter combines tickets into a *srvpb.ExistenceFilter.  The
+// filter is sized once every ticket is known, so all tickets are accumulated.
+type combineExistenceFilter struct{}
+
+func (combineExistenceFilter) AddInput(accum []string, ticket string) []string {
+	return append(accum, ticket)
+}
+
+func (combineExistenceFilter) MergeAccumulators(accum, tickets []string) []string {
+	return append(accum, tickets...)
+}
+
+func (combineExistenceFilter) ExtractOutput(tickets []string) *srvpb.ExistenceFilter {
+	tickets = removeDuplicates(tickets)
+	f := bloom.New(len(tickets), existenceFilterFPRate)
+	for _, ticket := range tickets {
+		f.Add(ticket)
+	}
+	return &srvpb.ExistenceFilter{
+		Bits:      f.Bits(),
+		HashCount: int32(f.Hashes()),
+		Size:      int64(len(tickets)),
+	}
+}
+
+// existenceFilterFPRate is the false positive rate of each
+// *srvpb.ExistenceFilter.
+const existenceFilterFPRate = 0.01
+
+// FactHashes returns a Kythe node fact hashes table recording the hashes of
+// the facts of each node in the Kythe input graph as the generation with the
+// given build ID.  Written into the serving table of a later generation, it
+// lets the later table serve the node facts changed since this generation.
+// The beam.PCollections have elements of type KV<string,
+// *srvpb.NodeFactHashes> and KV<string, *srvpb.FactHashesGeneration>,
+// respectively.
+func (k *KytheBeam) FactHashes(buildID string) (beam.PCollection, beam.PCollection) {
+	s := k.s.Scope("FactHashes")
+	hashes := beam.ParDo(s, nodeToFactHashes, k.nodes)
+	generation := beam.ParDo(s, &factHashesGeneration{BuildID: buildID}, beam.Impulse(s))
+	return hashes, generation
+}
+
+// nodeToFactHashes returns the *srvpb.NodeFactHashes of the given node's
+// served facts.
+func nodeToFactHashes(n *scpb.Node) (string, *srvpb.NodeFactHashes) {
+	h := gsrv.NodeFactHashes(convertPipelineNode(n))
+	return "factHashes:" + h.Ticket, h
+}
+
+// factHashesGeneration emits the *srvpb.FactHashesGeneration of a fact hashes
+// table.
+type factHashesGeneration struct{ BuildID string }
+
+func (g *factHashesGeneration) ProcessElement(_ []byte, emit func(string, *srvpb.FactHashesGeneration)) {
+	emit(string(gsrv.FactHashesGenerationKey), &srvpb.FactHashesGeneration{BuildId: g.BuildID})
+}
+
+// Kinds returns a summary of the node and edge kinds of the Kythe input graph
+// (see manifest.ReadKinds).  Edges differing only by ordinal are of the same
+// kind.  The beam.PCollection has a single element of type KV<string,
+// *srvpb.KindsSummary>.
+func (k *KytheBeam) Kinds() beam.PCollection {
+	s := k.s.Scope("Kinds")
+	return beam.ParDo(s, addKindsKey, beam.Combine(s, &combineKinds{}, k.nodes))
+}
+
+// addKindsKey returns the given kinds summary with the kinds summary key.
+func addKindsKey(summary *srvpb.KindsSummary) (string, *srvpb.KindsSummary) {
+	return manifest.KindsKey, summary
+}
+
+// combineKinds counts the kinds of nodes and their forward edges into a
+// *srvpb.KindsSummary.
+type combineKinds struct{}
+
+func (combineKinds) CreateAccumulator() *srvpb.KindsSummary {
+	return &srvpb.KindsSummary{
+		NodeKinds: make(map[string]int64),
+		EdgeKinds: make(map[string]int64),
+	}
+}
+
+func (combineKinds) AddInput(accum *srvpb.KindsSummary, n *scpb.Node) *srvpb.KindsSummary {
+	if kind := schema.GetNodeKind(n); kind != "" {
+		accum.NodeKinds[kind]++
+	}
+	for _, e := range n.Edge {
+		if kind := schema.GetEdgeKind(e); edges.IsForward(kind) {
+			kind, _, _ = edges.ParseOrdinal(kind)
+			accum.EdgeKinds[kind]++
+		}
+	}
+	return accum
+}
+
+func (combineKinds) MergeAccumulators(accum, summary *srvpb.KindsSummary) *srvpb.KindsSummary {
+	for kind, n := range summary.NodeKinds {
+		accum.NodeKinds[kind] += n
+	}
+	for kind, n := range summary.EdgeKinds {
+		accum.EdgeKinds[kind] += n
+	}
+	return accum
+}
+
+// groupContainerMembers emits a *srvpb.ContainerMembers for each container
+// members table key.
+func groupContainerMembers(key string, memberIter func(**srvpb.ContainerMembers_Member) bool, emit func(string, *srvpb.ContainerMembers)) {
+	kind, container, _ := strings.Cut(strings.TrimPrefix(key, "members:"), ":")
+	cm := &srvpb.ContainerMembers{Container: container, Kind: kind}
+	var m *srvpb.ContainerMembers_Member
+	for memberIter(&m) {
+		cm.Member = append(cm.Member, m)
+	}
+	assemble.SortMembers(cm.Member)
+	emit(key, cm)
+}
+
+// Rollups returns a Kythe container rollups table derived from the Kythe input
+// graph.  The references to each node are rolled up into each of its
+// containers, as in Members.  The beam.PCollection has elements of type
+// KV<string, *srvpb.ContainerRollup>.
+func (k *KytheBeam) Rollups() beam.PCollection {
+	s := k.s.Scope("Rollups")
+	memberNodes := beam.ParDo(s, moveSourceToKey, beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.ChildOf},
+	}))
+	counts := beam.ParDo(s, refToTargetCount, k.References())
+	targets := beam.ParDo(s, toRollupTargets, beam.CoGroupByKey(s, memberNodes, k.directDefinitions(), counts))
+	return beam.ParDo(s, groupContainerRollup, beam.GroupByKey(s, targets))
+}
+
+// toRollupTargets emits a *srvpb.ContainerRollup_Target for a referenced node,
+// keyed by the table key of each of its containers.  Anchors and files are not
+// rolled up.
+func toRollupTargets(key *spb.VName, nodeStream func(**scpb.Node) bool, defStream func(**srvpb.ExpandedAnchor) bool, counts func(*int64) bool, emit func(string, *srvpb.ContainerRollup_Target)) error {
+	var n *scpb.Node
+	if !nodeStream(&n) {
+		return nil
+	}
+	kind := schema.GetNodeKind(n)
+	if kind == "" || kind == kinds.Anchor || kind == kinds.File {
+		return nil
+	}
+	var total, count int64
+	for counts(&count) {
+		total += count
+	}
+	if total == 0 {
+		return nil
+	}
+
+	containers, err := nodeContainers(n, firstDefinition(defStream))
+	if err != nil {
+		return err
+	}
+	t := &srvpb.ContainerRollup_Target{
+		Ticket:         kytheuri.ToString(key),
+		Kind:           kind,
+		ReferenceCount: total,
+	}
+	for _, c := range containers {
+		emit("rollups:"+c, t)
+	}
+	return nil
+}
+
+// groupContainerRollup emits the *srvpb.ContainerRollup of a container's
+// referenced members.
+func groupContainerRollup(key string, targetIter func(**srvpb.ContainerRollup_Target) bool, emit func(string, *srvpb.ContainerRollup)) {
+	r := &srvpb.ContainerRollup{Container: strings.TrimPrefix(key, "rollups:")}
+	var t *srvpb.ContainerRollup_Target
+	for targetIter(&t) {
+		r.Target = append(r.Target, t)
+		r.ReferenceCount += t.ReferenceCount
+	}
+	assemble.SortRollupTargets(r.Target)
+	emit(key, r)
+}
+
+// Outlines returns a Kythe file outline table derived from the Kythe input
+// graph.  Each node with a definition is a symbol of the file defining it,
+// along with its /kythe/edge/childof parents.  The beam.PCollection has
+// elements of type KV<string, *srvpb.FileOutline>.
+func (k *KytheBeam) Outlines() beam.PCollection {
+	s := k.s.Scope("Outlines")
+	symbolNodes := beam.ParDo(s, moveSourceToKey, beam.Seq(s, k.nodes, &nodes.Filter{
+		IncludeFacts: []string{},
+		IncludeEdges: []string{edges.ChildOf},
+	}))
+	symbols := beam.ParDo(s, toOutlineSymbol, beam.CoGroupByKey(s, symbolNodes, k.getMarkedSources(), k.directDefinitions()))
+	return beam.ParDo(s, groupFileOutline, beam.GroupByKey(s, symbols))
+}
+
+// toOutlineSymbol emits a *srvpb.FileOutline_Symbol for a defined node, keyed
+// by the table key of the file of its definition.  Anchors and files are not
+// symbols.
+func toOutlineSymbol(key *spb.VName, nodeStream func(**scpb.Node) bool, msStream func(**cpb.MarkedSource) bool, defStream func(**srvpb.ExpandedAnchor) bool, emit func(string, *srvpb.FileOutline_Symbol)) error {
+	var n *scpb.Node
+	if !nodeStream(&n) {
+		return nil
+	}
+	kind := schema.GetNodeKind(n)
+	if kind == "" || kind == kinds.Anchor || kind == kinds.File {
+		return nil
+	}
+	def := firstDefinition(defStream)
+	if def == nil {
+		return nil
+	}
+	file, err := anchorToFileVName(def.Ticket)
+	if err != nil {
+		return err
+	}
+
+	sym := &srvpb.FileOutline_Symbol{
+		Ticket:     kytheuri.ToString(key),
+		Kind:       kind,
+		Subkind:    schema.GetSubkind(n),
+		Definition: def,
+		Parent:     childOfParents(n).Elements(),
+	}
+	var ms *cpb.MarkedSource
+	if msStream(&ms) {
+		sym.DisplayName = renderDisplayName(ms)
+	}
+	emit("outline:"+kytheuri.ToString(file), sym)
+	return nil
+}
+
+// groupFileOutline emits the *srvpb.FileOutline of a file's symbols.
+func groupFileOutline(key string, symbolIter func(**srvpb.FileOutline_Symbol) bool, emit func(string, *srvpb.FileOutline)) {
+	o := &srvpb.FileOutline{File: strings.TrimPrefix(key, "outline:")}
+	var sym *srvpb.FileOutline_Symbol
+	for symbolIter(&sym) {
+		o.Symbol = append(o.Symbol, sym)
+	}
+	assemble.SortOutlineSymbols(o.Symbol)
+	emit(key, o)
+}
+
 // nodeToChildren emits a (parent, child) pair for each /kythe/edge/childof edge
 // per *scpb.Node.
 func nodeToChildren(n *scpb.Node, emit func(*spb.VName, *spb.VName)) {
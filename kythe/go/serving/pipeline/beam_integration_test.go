@@ -313,7 +313,8 @@ func TestServingSimpleDecorations(t *testing.T) {
 						LineNumber:   1,
 					},
 				},
-				Snippet: "def",
+				Snippet:          "def",
+				SnippetHighlight: &xpb.Anchor_SnippetRange{End: 3},
 				SnippetSpan: &cpb.Span{
 					Start: &cpb.Point{
 						LineNumber: 1,
@@ -485,7 +486,8 @@ func TestServingSimpleCrossReferences(t *testing.T) {
 								LineNumber:   1,
 							},
 						},
-						Snippet: "blah blah",
+						Snippet:          "blah blah",
+						SnippetHighlight: &xpb.Anchor_SnippetRange{Start: 5, End: 9},
 						SnippetSpan: &cpb.Span{
 							Start: &cpb.Point{
 								LineNumber: 1,
@@ -569,7 +571,8 @@ func TestServingSimpleCrossReferences(t *testing.T) {
 						LineNumber:   1,
 					},
 				},
-				Snippet: "blah blah",
+				Snippet:          "blah blah",
+				SnippetHighlight: &xpb.Anchor_SnippetRange{Start: 5, End: 9},
 				SnippetSpan: &cpb.Span{
 					Start: &cpb.Point{
 						LineNumber: 1,
@@ -607,7 +610,8 @@ func TestServingSimpleCrossReferences(t *testing.T) {
 								LineNumber:   1,
 							},
 						},
-						Snippet: "blah blah",
+						Snippet:          "blah blah",
+						SnippetHighlight: &xpb.Anchor_SnippetRange{End: 4},
 						SnippetSpan: &cpb.Span{
 							Start: &cpb.Point{
 								LineNumber: 1,
@@ -648,7 +652,8 @@ func TestServingSimpleCrossReferences(t *testing.T) {
 								LineNumber:   1,
 							},
 						},
-						Snippet: "blah blah",
+						Snippet:          "blah blah",
+						SnippetHighlight: &xpb.Anchor_SnippetRange{Start: 5, End: 9},
 						SnippetSpan: &cpb.Span{
 							Start: &cpb.Point{
 								LineNumber: 1,
@@ -674,7 +679,8 @@ func TestServingSimpleCrossReferences(t *testing.T) {
 								LineNumber:   1,
 							},
 						},
-						Snippet: "blah blah",
+						Snippet:          "blah blah",
+						SnippetHighlight: &xpb.Anchor_SnippetRange{End: 4},
 						SnippetSpan: &cpb.Span{
 							Start: &cpb.Point{
 								LineNumber: 1,
@@ -716,7 +722,8 @@ func TestServingSimpleCrossReferences(t *testing.T) {
 								LineNumber:   1,
 							},
 						},
-						Snippet: "blah blah",
+						Snippet:          "blah blah",
+						SnippetHighlight: &xpb.Anchor_SnippetRange{Start: 5, End: 9},
 						SnippetSpan: &cpb.Span{
 							Start: &cpb.Point{
 								LineNumber: 1,
@@ -742,7 +749,8 @@ func TestServingSimpleCrossReferences(t *testing.T) {
 								LineNumber:   1,
 							},
 						},
-						Snippet: "blah blah",
+						Snippet:          "blah blah",
+						SnippetHighlight: &xpb.Anchor_SnippetRange{End: 4},
 						SnippetSpan: &cpb.Span{
 							Start: &cpb.Point{
 								LineNumber: 1,
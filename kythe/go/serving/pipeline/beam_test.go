@@ -18,8 +18,13 @@ package pipeline
 
 import (
 	"testing"
+	"time"
 
+	"kythe.io/kythe/go/services/xrefs"
+	gsrv "kythe.io/kythe/go/serving/graph"
 	"kythe.io/kythe/go/serving/pipeline/beamtest"
+	"kythe.io/kythe/go/serving/xrefs/assemble"
+	"kythe.io/kythe/go/util/bloom"
 
 	"github.com/apache/beam/sdks/go/pkg/beam"
 	"github.com/apache/beam/sdks/go/pkg/beam/testing/passert"
@@ -28,6 +33,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
 	ppb "kythe.io/kythe/proto/pipeline_go_proto"
@@ -151,6 +157,78 @@ func TestReferences(t *testing.T) {
 	ptest.RunAndValidate(t, p)
 }
 
+func TestReferences_commitTime(t *testing.T) {
+	anchor := func(sig, start, end string) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Path: "path", Signature: sig},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_START},
+				Value: []byte(start),
+			}, {
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_END},
+				Value: []byte(end),
+			}},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_REF},
+				Target: &spb.VName{Signature: "node"},
+			}},
+		}
+	}
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Path: "path"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("ab\ncd\n"),
+		}},
+	}, anchor("line1", "0", "2"), anchor("line2", "3", "5")}
+	committed := timestamppb.New(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	blame := []*ppb.FileBlame{{
+		File: &spb.VName{Path: "path"},
+		Hunk: []*ppb.FileBlame_Hunk{{StartLine: 2, EndLine: 3, Revision: "abc", CommitTime: committed}},
+	}}
+
+	ref := func(sig, text string, line int32) *ppb.Reference {
+		start := 3 * (line - 1)
+		return &ppb.Reference{
+			Source: &spb.VName{Signature: "node"},
+			Kind:   &ppb.Reference_KytheKind{scpb.EdgeKind_REF},
+			Anchor: &srvpb.ExpandedAnchor{
+				Ticket: "kythe:?path=path#" + sig,
+				Text:   text,
+				Span: &cpb.Span{
+					Start: &cpb.Point{ByteOffset: start, LineNumber: line},
+					End:   &cpb.Point{ByteOffset: start + 2, LineNumber: line, ColumnOffset: 2},
+				},
+				Snippet: text,
+				SnippetSpan: &cpb.Span{
+					Start: &cpb.Point{ByteOffset: start, LineNumber: line},
+					End:   &cpb.Point{ByteOffset: start + 2, LineNumber: line, ColumnOffset: 2},
+				},
+			},
+		}
+	}
+	blamed := ref("line2", "cd", 2)
+	blamed.Anchor.CommitTime = committed
+	expected := []*ppb.Reference{ref("line1", "ab", 1), blamed}
+
+	beam.Init()
+	p, s, nodes := ptest.CreateList(testNodes)
+	refs := FromNodes(s, nodes).WithBlame(beam.CreateList(s, blame)).References()
+	debug.Print(s, refs)
+	passert.Equals(s, refs, beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestReferences_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).WithBlame(beam.CreateList(s, []*ppb.FileBlame{{}})).References()
+	beamtest.CheckRegistrations(t, p)
+}
+
 func TestDecorations_targetNode(t *testing.T) {
 	testNodes := []*scpb.Node{{
 		Source: &spb.VName{Path: "path", Signature: "anchor1"},
@@ -190,6 +268,7 @@ func TestDecorations_targetNode(t *testing.T) {
 			Anchor: &srvpb.RawAnchor{
 				StartOffset: 5,
 				EndOffset:   9,
+				Fingerprint: assemble.AnchorFingerprint("kythe:#node1", []byte("text"), 0),
 			},
 			Kind:   "/kythe/edge/ref",
 			Target: "kythe:#node1",
@@ -204,6 +283,7 @@ func TestDecorations_targetNode(t *testing.T) {
 				Value: []byte("something"),
 			}},
 		}},
+		TargetReferenceCount: map[string]int64{"kythe:#node1": 1},
 	}}
 
 	beam.Init()
@@ -247,10 +327,110 @@ func TestDecorations_decoration(t *testing.T) {
 			Anchor: &srvpb.RawAnchor{
 				StartOffset: 5,
 				EndOffset:   9,
+				Fingerprint: assemble.AnchorFingerprint("kythe:#node1", []byte("text"), 0),
 			},
 			Kind:   "/kythe/edge/ref",
 			Target: "kythe:#node1",
 		}},
+		TargetReferenceCount: map[string]int64{"kythe:#node1": 1},
+	}}
+
+	beam.Init()
+	p, s, nodes := ptest.CreateList(testNodes)
+	decor := FromNodes(s, nodes).Decorations()
+	debug.Print(s, decor)
+	passert.Equals(s, beam.DropKey(s, decor), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestDecorations_fileRevision(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Corpus: "corpus", Path: "path"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("some text\n"),
+		}},
+	}, {
+		Source: &spb.VName{Corpus: "corpus"},
+		Kind:   &scpb.Node_GenericKind{"vcs"},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_GenericName{"/kythe/vcs/id"},
+			Value: []byte("abc123"),
+		}},
+	}}
+
+	info := &srvpb.FileInfo{
+		CorpusPath: &cpb.CorpusPath{Corpus: "corpus", Path: "path"},
+		Revision:   "abc123",
+	}
+	expected := []*srvpb.FileDecorations{{
+		File: &srvpb.File{
+			Text: []byte("some text\n"),
+			Info: info,
+		},
+		FileInfo: []*srvpb.FileInfo{info},
+	}}
+
+	beam.Init()
+	p, s, nodes := ptest.CreateList(testNodes)
+	decor := FromNodes(s, nodes).Decorations()
+	debug.Print(s, decor)
+	passert.Equals(s, beam.DropKey(s, decor), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestDecorations_generatedFile(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Corpus: "corpus", Path: "gen"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("generated text\n"),
+		}, {
+			Name:  &scpb.Fact_GenericName{"/kythe/generated"},
+			Value: []byte("true"),
+		}},
+	}, {
+		// Files are only marked as generated by their indexer, not by their root.
+		Source: &spb.VName{Corpus: "corpus", Root: "bazel-out", Path: "out"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("output text\n"),
+		}},
+	}, {
+		Source: &spb.VName{Corpus: "corpus"},
+		Kind:   &scpb.Node_GenericKind{"vcs"},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_GenericName{"/kythe/vcs/id"},
+			Value: []byte("abc123"),
+		}},
+	}}
+
+	info := &srvpb.FileInfo{
+		CorpusPath: &cpb.CorpusPath{Corpus: "corpus", Path: "gen"},
+		Revision:   "abc123",
+		Generated:  true,
+	}
+	outInfo := &srvpb.FileInfo{
+		CorpusPath: &cpb.CorpusPath{Corpus: "corpus", Root: "bazel-out", Path: "out"},
+		Revision:   "abc123",
+	}
+	expected := []*srvpb.FileDecorations{{
+		File: &srvpb.File{
+			Text: []byte("generated text\n"),
+			Info: info,
+		},
+		FileInfo: []*srvpb.FileInfo{info},
+	}, {
+		File: &srvpb.File{
+			Text: []byte("output text\n"),
+			Info: outInfo,
+		},
+		FileInfo: []*srvpb.FileInfo{outInfo},
 	}}
 
 	beam.Init()
@@ -415,18 +595,21 @@ func TestDecorations_targetDefinition(t *testing.T) {
 			Anchor: &srvpb.RawAnchor{
 				StartOffset: 5,
 				EndOffset:   9,
+				Fingerprint: assemble.AnchorFingerprint("kythe:#node1", []byte("text"), 0),
 			},
 			Kind:             "/kythe/edge/ref",
 			Target:           "kythe:#node1",
 			TargetDefinition: "kythe:?path=path2#def1",
 		}},
-		TargetDefinitions: []*srvpb.ExpandedAnchor{def},
+		TargetDefinitions:    []*srvpb.ExpandedAnchor{def},
+		TargetReferenceCount: map[string]int64{"kythe:#node1": 1},
 	}, {
 		File: &srvpb.File{Text: []byte("some def\n")},
 		Decoration: []*srvpb.FileDecorations_Decoration{{
 			Anchor: &srvpb.RawAnchor{
 				StartOffset: 5,
 				EndOffset:   8,
+				Fingerprint: assemble.AnchorFingerprint("kythe:#node1", []byte("def"), 0),
 			},
 			Kind:   "/kythe/edge/defines/binding",
 			Target: "kythe:#node1",
@@ -434,7 +617,8 @@ func TestDecorations_targetDefinition(t *testing.T) {
 			// TODO(schroederc): ellide TargetDefinition for actual definitions
 			TargetDefinition: "kythe:?path=path2#def1",
 		}},
-		TargetDefinitions: []*srvpb.ExpandedAnchor{def},
+		TargetDefinitions:    []*srvpb.ExpandedAnchor{def},
+		TargetReferenceCount: map[string]int64{"kythe:#node1": 1},
 	}}
 
 	beam.Init()
@@ -501,12 +685,12 @@ func TestDecorations_overrides(t *testing.T) {
 	expected := []*srvpb.FileDecorations{{
 		File: &srvpb.File{Text: []byte("some def\n")},
 		Decoration: []*srvpb.FileDecorations_Decoration{{
-			Anchor:           &srvpb.RawAnchor{StartOffset: 4, EndOffset: 7},
+			Anchor:           &srvpb.RawAnchor{StartOffset: 4, EndOffset: 7, Fingerprint: assemble.AnchorFingerprint("kythe:#node2", []byte("de"), 0)},
 			Kind:             "/kythe/edge/defines/binding",
 			Target:           "kythe:#node2",
 			TargetDefinition: "kythe:?path=path2#def2",
 		}, {
-			Anchor:           &srvpb.RawAnchor{StartOffset: 5, EndOffset: 8},
+			Anchor:           &srvpb.RawAnchor{StartOffset: 5, EndOffset: 8, Fingerprint: assemble.AnchorFingerprint("kythe:#node1", []byte("def"), 0)},
 			Kind:             "/kythe/edge/defines/binding",
 			Target:           "kythe:#node1",
 			TargetDefinition: "kythe:?path=path2#def1",
@@ -545,6 +729,10 @@ func TestDecorations_overrides(t *testing.T) {
 			Overridden:           "kythe:#node1",
 			OverriddenDefinition: "kythe:?path=path2#def1",
 		}},
+		TargetOverriddenBy: []*srvpb.FileDecorations_Override{{
+			Overriding: "kythe:#node2",
+			Overridden: "kythe:#node1",
+		}},
 	}, {
 		File: &srvpb.File{Text: []byte("some text\n")},
 	}}
@@ -1062,6 +1250,682 @@ func TestDocuments_registrations(t *testing.T) {
 	beamtest.CheckRegistrations(t, p)
 }
 
+func TestDisplayNames(t *testing.T) {
+	ms := &cpb.MarkedSource{
+		Kind: cpb.MarkedSource_BOX,
+		Child: []*cpb.MarkedSource{{
+			Kind:          cpb.MarkedSource_CONTEXT,
+			PostChildText: ".",
+			Child: []*cpb.MarkedSource{{
+				Kind:    cpb.MarkedSource_IDENTIFIER,
+				PreText: "pkg",
+			}},
+		}, {
+			Kind:    cpb.MarkedSource_IDENTIFIER,
+			PreText: "Func",
+		}, {
+			Kind: cpb.MarkedSource_PARAMETER,
+			Child: []*cpb.MarkedSource{{
+				Kind:    cpb.MarkedSource_IDENTIFIER,
+				PreText: "x",
+			}},
+		}},
+	}
+	rec, err := proto.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Signature: "node1"},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_CODE},
+			Value: rec,
+		}},
+	}, {
+		Source: &spb.VName{Signature: "node2"},
+	}}
+	expected := []*srvpb.DisplayName{{
+		Ticket: "kythe:#node1",
+		Name:   "pkg.Func(x)",
+	}}
+
+	beam.Init()
+	p, s, nodes := ptest.CreateList(testNodes)
+	names := FromNodes(s, nodes).DisplayNames()
+	debug.Print(s, names)
+	passert.Equals(s, beam.DropKey(s, names), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestDisplayNames_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).DisplayNames()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestFileDigests(t *testing.T) {
+	file := func(path, text string) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Corpus: "corpus", Path: path},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+				Value: []byte(text),
+			}},
+		}
+	}
+	testNodes := []*scpb.Node{
+		file("vendor/a/lib.go", "package lib\n"),
+		file("lib.go", "package lib\n"),
+		file("main.go", "package main\n"),
+	}
+	expected := []*srvpb.FileDigest{{
+		Digest: xrefs.FileDigest([]byte("package lib\n")),
+		Ticket: []string{"kythe://corpus?path=lib.go", "kythe://corpus?path=vendor/a/lib.go"},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	digests := FromNodes(s, nodes).FileDigests()
+	debug.Print(s, digests)
+	passert.Equals(s, beam.DropKey(s, digests), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestFileDigests_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).FileDigests()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestFileReferences(t *testing.T) {
+	anchor := func(sig, start, end string, kind scpb.EdgeKind, target string) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Corpus: "corpus", Path: "main.go", Signature: sig},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_START},
+				Value: []byte(start),
+			}, {
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_END},
+				Value: []byte(end),
+			}},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{kind},
+				Target: &spb.VName{Corpus: "corpus", Signature: target},
+			}},
+		}
+	}
+	testNodes := []*scpb.Node{
+		{
+			Source: &spb.VName{Corpus: "corpus", Path: "main.go"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+				Value: []byte("f(g(f()))\n"),
+			}},
+		},
+		anchor("a0", "0", "1", scpb.EdgeKind_REF_CALL, "f"),
+		anchor("a1", "2", "3", scpb.EdgeKind_REF_CALL, "g"),
+		anchor("a2", "4", "5", scpb.EdgeKind_REF_CALL, "f"),
+		anchor("a3", "0", "9", scpb.EdgeKind_TAGGED, "diag"),
+	}
+	expected := []*srvpb.FileReferences{{
+		File: "kythe://corpus?path=main.go",
+		Node: []*srvpb.FileReferences_Node{
+			{Ticket: "kythe://corpus#f", Count: 2},
+			{Ticket: "kythe://corpus#g", Count: 1},
+		},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	refs := FromNodes(s, nodes).FileReferences()
+	debug.Print(s, refs)
+	passert.Equals(s, beam.DropKey(s, refs), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestFileReferences_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).FileReferences()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestFileRelations(t *testing.T) {
+	ref := func(file, sig string, kind scpb.EdgeKind, target *spb.VName) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Corpus: "corpus", Path: file, Signature: sig},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_START},
+				Value: []byte("0"),
+			}, {
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_END},
+				Value: []byte("1"),
+			}},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{kind},
+				Target: target,
+			}},
+		}
+	}
+	file := func(path string) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Corpus: "corpus", Path: path},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+				Value: []byte("#include\n"),
+			}},
+		}
+	}
+	header := &spb.VName{Corpus: "corpus", Path: "a.h"}
+	testNodes := []*scpb.Node{
+		file("a.h"), file("a.cc"), file("b.cc"),
+		ref("a.cc", "a0", scpb.EdgeKind_REF_INCLUDES, header),
+		ref("a.cc", "a1", scpb.EdgeKind_REF_INCLUDES, header), // duplicate includes are listed once
+		ref("b.cc", "a2", scpb.EdgeKind_REF_IMPORTS, header),
+		ref("b.cc", "a3", scpb.EdgeKind_REF_IMPORTS, &spb.VName{Corpus: "corpus", Signature: "pkg"}),
+		ref("b.cc", "a4", scpb.EdgeKind_REF, header),
+	}
+	expected := []*srvpb.FileRelations{{
+		File: "kythe://corpus?path=a.h",
+		Incoming: []*srvpb.FileRelations_Relation{
+			{Ticket: "kythe://corpus?path=b.cc", Kind: "/kythe/edge/ref/imports"},
+			{Ticket: "kythe://corpus?path=a.cc", Kind: "/kythe/edge/ref/includes"},
+		},
+	}, {
+		File:     "kythe://corpus?path=a.cc",
+		Outgoing: []*srvpb.FileRelations_Relation{{Ticket: "kythe://corpus?path=a.h", Kind: "/kythe/edge/ref/includes"}},
+	}, {
+		File:     "kythe://corpus?path=b.cc",
+		Outgoing: []*srvpb.FileRelations_Relation{{Ticket: "kythe://corpus?path=a.h", Kind: "/kythe/edge/ref/imports"}},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	rels := FromNodes(s, nodes).FileRelations()
+	debug.Print(s, rels)
+	passert.Equals(s, beam.DropKey(s, rels), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestFileRelations_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).FileRelations()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestCallDegrees(t *testing.T) {
+	call := func(sig, start, end, caller, callee string) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Corpus: "corpus", Path: "main.go", Signature: sig},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_START},
+				Value: []byte(start),
+			}, {
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_END},
+				Value: []byte(end),
+			}},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+				Target: &spb.VName{Corpus: "corpus", Signature: caller},
+			}, {
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_REF_CALL},
+				Target: &spb.VName{Corpus: "corpus", Signature: callee},
+			}},
+		}
+	}
+	testNodes := []*scpb.Node{
+		{
+			Source: &spb.VName{Corpus: "corpus", Path: "main.go"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+				Value: []byte("f(g(f()))\n"),
+			}},
+		},
+		call("a0", "0", "1", "main", "f"),
+		call("a1", "2", "3", "main", "g"),
+		call("a2", "4", "5", "main", "f"), // duplicate calls are counted once
+		call("a3", "4", "5", "g", "f"),
+	}
+	expected := []*srvpb.CallDegrees{
+		{Ticket: "kythe://corpus#main", Callees: 2},
+		{Ticket: "kythe://corpus#f", Callers: 2},
+		{Ticket: "kythe://corpus#g", Callers: 1, Callees: 1},
+	}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	degrees := FromNodes(s, nodes).CallDegrees()
+	debug.Print(s, degrees)
+	passert.Equals(s, beam.DropKey(s, degrees), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestCallDegrees_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).CallDegrees()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestMembers(t *testing.T) {
+	ms := &cpb.MarkedSource{
+		Kind:    cpb.MarkedSource_IDENTIFIER,
+		PreText: "field",
+	}
+	rec, err := proto.Marshal(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Path: "path"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("some def\n"),
+		}},
+	}, {
+		Source: &spb.VName{Path: "path", Signature: "def1"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_START},
+			Value: []byte("5"),
+		}, {
+			Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_END},
+			Value: []byte("8"),
+		}},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_DEFINES_BINDING},
+			Target: &spb.VName{Signature: "struct"},
+		}},
+	}, {
+		Source:  &spb.VName{Signature: "struct"},
+		Kind:    &scpb.Node_KytheKind{scpb.NodeKind_RECORD},
+		Subkind: &scpb.Node_KytheSubkind{scpb.Subkind_STRUCT},
+	}, {
+		Source: &spb.VName{Signature: "field"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_CODE},
+			Value: rec,
+		}},
+		Edge: []*scpb.Edge{{
+			Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+			Target: &spb.VName{Signature: "struct"},
+		}},
+	}}
+
+	expected := []*srvpb.ContainerMembers{{
+		Container: "kythe:?path=path",
+		Kind:      "record",
+		Member: []*srvpb.ContainerMembers_Member{{
+			Ticket:  "kythe:#struct",
+			Subkind: "struct",
+			Definition: &srvpb.ExpandedAnchor{
+				Ticket: "kythe:?path=path#def1",
+				Text:   "def",
+				Span: &cpb.Span{
+					Start: &cpb.Point{
+						ByteOffset:   5,
+						LineNumber:   1,
+						ColumnOffset: 5,
+					},
+					End: &cpb.Point{
+						ByteOffset:   8,
+						LineNumber:   1,
+						ColumnOffset: 8,
+					},
+				},
+				Snippet: "some def",
+				SnippetSpan: &cpb.Span{
+					Start: &cpb.Point{
+						LineNumber: 1,
+					},
+					End: &cpb.Point{
+						ByteOffset:   8,
+						LineNumber:   1,
+						ColumnOffset: 8,
+					},
+				},
+			},
+		}},
+	}, {
+		Container: "kythe:#struct",
+		Kind:      "variable",
+		Member: []*srvpb.ContainerMembers_Member{{
+			Ticket:      "kythe:#field",
+			DisplayName: "field",
+		}},
+	}}
+
+	beam.Init()
+	p, s, nodes := ptest.CreateList(testNodes)
+	members := FromNodes(s, nodes).Members()
+	debug.Print(s, members)
+	passert.Equals(s, beam.DropKey(s, members), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestMembers_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Members()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestOutlines(t *testing.T) {
+	anchor := func(sig, start, end, target string) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Path: "path", Signature: sig},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_START},
+				Value: []byte(start),
+			}, {
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_END},
+				Value: []byte(end),
+			}},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_DEFINES_BINDING},
+				Target: &spb.VName{Signature: target},
+			}},
+		}
+	}
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Path: "path"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("struct S { f }\n"),
+		}},
+	},
+		anchor("def1", "7", "8", "struct"),
+		anchor("def2", "11", "12", "field"),
+		{
+			Source:  &spb.VName{Signature: "struct"},
+			Kind:    &scpb.Node_KytheKind{scpb.NodeKind_RECORD},
+			Subkind: &scpb.Node_KytheSubkind{scpb.Subkind_STRUCT},
+		}, {
+			Source: &spb.VName{Signature: "field"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+				Target: &spb.VName{Signature: "struct"},
+			}},
+		}, {
+			// Undefined nodes are not symbols.
+			Source: &spb.VName{Signature: "undefined"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		}}
+
+	def := func(sig string, start, end int32) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{
+			Ticket: "kythe:?path=path#" + sig,
+			Text:   "struct S { f }"[start:end],
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: start, LineNumber: 1, ColumnOffset: start},
+				End:   &cpb.Point{ByteOffset: end, LineNumber: 1, ColumnOffset: end},
+			},
+			Snippet: "struct S { f }",
+			SnippetSpan: &cpb.Span{
+				Start: &cpb.Point{LineNumber: 1},
+				End:   &cpb.Point{ByteOffset: 14, LineNumber: 1, ColumnOffset: 14},
+			},
+		}
+	}
+	expected := []*srvpb.FileOutline{{
+		File: "kythe:?path=path",
+		Symbol: []*srvpb.FileOutline_Symbol{{
+			Ticket:     "kythe:#struct",
+			Kind:       "record",
+			Subkind:    "struct",
+			Definition: def("def1", 7, 8),
+		}, {
+			Ticket:     "kythe:#field",
+			Kind:       "variable",
+			Definition: def("def2", 11, 12),
+			Parent:     []string{"kythe:#struct"},
+		}},
+	}}
+
+	beam.Init()
+	p, s, nodes := ptest.CreateList(testNodes)
+	outlines := FromNodes(s, nodes).Outlines()
+	debug.Print(s, outlines)
+	passert.Equals(s, beam.DropKey(s, outlines), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestOutlines_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Outlines()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestRollups(t *testing.T) {
+	anchor := func(sig, start, end string, kind scpb.EdgeKind, target string) *scpb.Node {
+		return &scpb.Node{
+			Source: &spb.VName{Path: "path", Signature: sig},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_ANCHOR},
+			Fact: []*scpb.Fact{{
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_START},
+				Value: []byte(start),
+			}, {
+				Name:  &scpb.Fact_KytheName{scpb.FactName_LOC_END},
+				Value: []byte(end),
+			}},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{kind},
+				Target: &spb.VName{Signature: target},
+			}},
+		}
+	}
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Path: "path"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_KytheName{scpb.FactName_TEXT},
+			Value: []byte("some def; def; def.field\n"),
+		}},
+	},
+		anchor("def1", "5", "8", scpb.EdgeKind_DEFINES_BINDING, "struct"),
+		anchor("ref1", "10", "13", scpb.EdgeKind_REF, "struct"),
+		anchor("ref2", "15", "18", scpb.EdgeKind_REF, "struct"),
+		anchor("ref3", "19", "24", scpb.EdgeKind_REF, "field"),
+		{
+			Source: &spb.VName{Signature: "struct"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_RECORD},
+		}, {
+			Source: &spb.VName{Signature: "field"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+				Target: &spb.VName{Signature: "struct"},
+			}},
+		}, {
+			Source: &spb.VName{Signature: "unreferenced"},
+			Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+			Edge: []*scpb.Edge{{
+				Kind:   &scpb.Edge_KytheKind{scpb.EdgeKind_CHILD_OF},
+				Target: &spb.VName{Signature: "struct"},
+			}},
+		}}
+
+	expected := []*srvpb.ContainerRollup{{
+		Container:      "kythe:?path=path",
+		ReferenceCount: 2,
+		Target: []*srvpb.ContainerRollup_Target{{
+			Ticket:         "kythe:#struct",
+			Kind:           "record",
+			ReferenceCount: 2,
+		}},
+	}, {
+		Container:      "kythe:#struct",
+		ReferenceCount: 1,
+		Target: []*srvpb.ContainerRollup_Target{{
+			Ticket:         "kythe:#field",
+			Kind:           "variable",
+			ReferenceCount: 1,
+		}},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	rollups := FromNodes(s, nodes).Rollups()
+	debug.Print(s, rollups)
+	passert.Equals(s, beam.DropKey(s, rollups), beam.CreateList(s, expected))
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestRollups_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Rollups()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestDecorationsFilter(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Corpus: "corpus", Path: "a.go"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+	}, {
+		Source: &spb.VName{Corpus: "corpus", Path: "b.go"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FILE},
+	}, {
+		Source: &spb.VName{Corpus: "corpus", Signature: "node"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_RECORD},
+	}}
+
+	expected := bloom.New(2, existenceFilterFPRate)
+	expected.Add("kythe://corpus?path=a.go")
+	expected.Add("kythe://corpus?path=b.go")
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	filter := FromNodes(s, nodes).DecorationsFilter()
+	debug.Print(s, filter)
+	passert.Equals(s, beam.DropKey(s, filter), &srvpb.ExistenceFilter{
+		Bits:      expected.Bits(),
+		HashCount: int32(expected.Hashes()),
+		Size:      2,
+	})
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestDecorationsFilter_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).DecorationsFilter()
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestFactHashes(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Signature: "a"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_RECORD},
+		Fact: []*scpb.Fact{{
+			Name:  &scpb.Fact_GenericName{"/custom/fact"},
+			Value: []byte("value"),
+		}},
+	}, {
+		Source: &spb.VName{Signature: "b"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+	}}
+
+	expected := []*srvpb.NodeFactHashes{{
+		Ticket: "kythe:#a",
+		Fact: []*srvpb.NodeFactHashes_Fact{{
+			Name: "/custom/fact",
+			Hash: gsrv.FactHash([]byte("value")),
+		}, {
+			Name: "/kythe/node/kind",
+			Hash: gsrv.FactHash([]byte("record")),
+		}},
+	}, {
+		Ticket: "kythe:#b",
+		Fact: []*srvpb.NodeFactHashes_Fact{{
+			Name: "/kythe/node/kind",
+			Hash: gsrv.FactHash([]byte("function")),
+		}},
+	}}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	hashes, generation := FromNodes(s, nodes).FactHashes("build-1")
+	debug.Print(s, hashes)
+	passert.Equals(s, beam.DropKey(s, hashes), beam.CreateList(s, expected))
+	passert.Equals(s, beam.DropKey(s, generation), &srvpb.FactHashesGeneration{BuildId: "build-1"})
+
+	ptest.RunAndValidate(t, p)
+}
+
+func TestFactHashes_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).FactHashes("build")
+	beamtest.CheckRegistrations(t, p)
+}
+
+func TestKinds(t *testing.T) {
+	testNodes := []*scpb.Node{{
+		Source: &spb.VName{Signature: "f"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+		Edge: []*scpb.Edge{{
+			Kind:    &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Target:  &spb.VName{Signature: "p0"},
+			Ordinal: 0,
+		}, {
+			Kind:    &scpb.Edge_KytheKind{scpb.EdgeKind_PARAM},
+			Target:  &spb.VName{Signature: "p1"},
+			Ordinal: 1,
+		}, {
+			Kind:   &scpb.Edge_GenericKind{"/custom/edge.2"},
+			Target: &spb.VName{Signature: "c"},
+		}, {
+			Kind:   &scpb.Edge_GenericKind{"%/kythe/edge/childof"},
+			Target: &spb.VName{Signature: "r"},
+		}},
+	}, {
+		Source: &spb.VName{Signature: "p0"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+	}, {
+		Source: &spb.VName{Signature: "p1"},
+		Kind:   &scpb.Node_KytheKind{scpb.NodeKind_VARIABLE},
+	}}
+
+	expected := &srvpb.KindsSummary{
+		NodeKinds: map[string]int64{"function": 1, "variable": 2},
+		EdgeKinds: map[string]int64{"/kythe/edge/param": 2, "/custom/edge": 1},
+	}
+
+	p, s, nodes := ptest.CreateList(testNodes)
+	kinds := FromNodes(s, nodes).Kinds()
+	debug.Print(s, kinds)
+	passert.Equals(s, beam.DropKey(s, kinds), expected)
+	ptest.RunAndValidate(t, p)
+}
+
+func TestKinds_registrations(t *testing.T) {
+	testNodes := []*scpb.Node{{}}
+	p, s, nodes := ptest.CreateList(testNodes)
+	FromNodes(s, nodes).Kinds()
+	beamtest.CheckRegistrations(t, p)
+}
+
 func TestCombineDecorPieces_mergeAccumulators(t *testing.T) {
 	accum := &srvpb.FileDecorations{
 		Decoration: []*srvpb.FileDecorations_Decoration{{
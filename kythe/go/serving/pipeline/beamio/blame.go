@@ -0,0 +1,64 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package beamio
+
+import (
+	"context"
+	"io"
+
+	"kythe.io/kythe/go/platform/delimited"
+
+	"github.com/apache/beam/sdks/go/pkg/beam"
+	"github.com/apache/beam/sdks/go/pkg/beam/io/filesystem"
+
+	ppb "kythe.io/kythe/proto/pipeline_go_proto"
+)
+
+func init() {
+	beam.RegisterFunction(readBlameFile)
+}
+
+// ReadFileBlames reads a set of *ppb.FileBlame messages into a PCollection
+// from the given delimited protobuf stream.  The file can be part of any
+// filesystem registered with the beam/io/filesystem package.
+func ReadFileBlames(s beam.Scope, file string) beam.PCollection {
+	return beam.ParDo(s, readBlameFile, beam.Create(s, file))
+}
+
+func readBlameFile(ctx context.Context, filename string, emit func(*ppb.FileBlame)) error {
+	fs, err := filesystem.New(ctx, filename)
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+	f, err := fs.OpenRead(ctx, filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rd := delimited.NewReader(f)
+	for {
+		var b ppb.FileBlame
+		if err := rd.NextProto(&b); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		emit(&b)
+	}
+}
@@ -98,6 +98,10 @@ func encodeDecorPiece(file *spb.VName, p *ppb.DecorationPiece, emit func([]byte,
 		return encodeDecorDiagnostic(file, p.Diagnostic, emit)
 	case *ppb.DecorationPiece_TargetOverride:
 		return encodeDecorTargetOverride(file, p.TargetOverride, emit)
+	case *ppb.DecorationPiece_TargetOverriddenBy, *ppb.DecorationPiece_ReferenceCount_:
+		// Overridden-by markers and reference counts are not yet stored in the
+		// columnar format.
+		return nil
 	default:
 		return fmt.Errorf("unknown DecorationPiece: %T", p)
 	}
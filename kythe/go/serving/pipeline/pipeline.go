@@ -31,6 +31,7 @@ import (
 	"kythe.io/kythe/go/services/graphstore"
 	ftsrv "kythe.io/kythe/go/serving/filetree"
 	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/manifest"
 	xsrv "kythe.io/kythe/go/serving/xrefs"
 	"kythe.io/kythe/go/serving/xrefs/assemble"
 	"kythe.io/kythe/go/storage/keyvalue"
@@ -82,11 +83,13 @@ func (o *Options) diskSorter(l sortutil.Lesser, m disksort.Marshaler) (disksort.
 const chBuf = 512
 
 type servingOutput struct {
-	xs table.Proto
+	xs    table.Proto
+	kinds manifest.KindsCounter
 }
 
 // Run writes the xrefs and filetree serving tables to db based on the given
-// entries (in GraphStore-order).
+// entries (in GraphStore-order), along with a summary of their node and edge
+// kinds (see manifest.ReadKinds).
 func Run(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, opts *Options) error {
 	if opts == nil {
 		opts = new(Options)
@@ -147,8 +150,10 @@ func Run(ctx context.Context, rd stream.EntryReader, db keyvalue.DB, opts *Optio
 	wg.Wait()
 	if pErr != nil {
 		return pErr
+	} else if fErr != nil {
+		return fErr
 	}
-	return fErr
+	return manifest.WriteKinds(ctx, db, out.kinds.Summary())
 }
 
 func combineNodesAndEdges(ctx context.Context, opts *Options, out *servingOutput, rdIn stream.EntryReader) (disksort.Interface, error) {
@@ -157,9 +162,14 @@ func combineNodesAndEdges(ctx context.Context, opts *Options, out *servingOutput
 	tree := filetree.NewMap()
 	rd := func(f func(*spb.Entry) error) error {
 		return rdIn(func(e *spb.Entry) error {
-			if e.FactName == facts.NodeKind && string(e.FactValue) == nodes.File {
-				tree.AddFile(e.Source)
-				// TODO(schroederc): evict finished directories (based on GraphStore order)
+			if e.FactName == facts.NodeKind {
+				out.kinds.AddNode(string(e.FactValue))
+				if string(e.FactValue) == nodes.File {
+					tree.AddFile(e.Source)
+					// TODO(schroederc): evict finished directories (based on GraphStore order)
+				}
+			} else if e.EdgeKind != "" && e.FactName == "/" {
+				out.kinds.AddEdge(e.EdgeKind)
 			}
 			return f(e)
 		})
@@ -302,7 +312,14 @@ func writePagedEdges(ctx context.Context, edges <-chan *srvpb.Edge, out table.Pr
 	esb := &assemble.EdgeSetBuilder{
 		MaxEdgePageSize: opts.MaxPageSize,
 		Output: func(ctx context.Context, pes *srvpb.PagedEdgeSet) error {
-			return buffer.Put(ctx, gsrv.EdgeSetKey(pes.Source.Ticket), pes)
+			if err := buffer.Put(ctx, gsrv.EdgeSetKey(pes.Source.Ticket), pes); err != nil {
+				return err
+			}
+			key, err := gsrv.TicketIndexKey(pes.Source.Ticket)
+			if err != nil {
+				return fmt.Errorf("invalid PagedEdgeSet source ticket %q: %v", pes.Source.Ticket, err)
+			}
+			return buffer.Put(ctx, key, &srvpb.TicketIndexEntry{Ticket: pes.Source.Ticket})
 		},
 		OutputPage: func(ctx context.Context, ep *srvpb.EdgePage) error {
 			return buffer.Put(ctx, gsrv.EdgePageKey(ep.PageKey), ep)
@@ -529,6 +546,7 @@ func writeDecor(ctx context.Context, t table.BufferedProto, decor *srvpb.FileDec
 		decor.Target = append(decor.Target, n)
 	}
 	sort.Sort(assemble.ByOffset(decor.Decoration))
+	assemble.AddAnchorFingerprints(decor)
 	sort.Sort(assemble.ByTicket(decor.Target))
 	sort.Sort(assemble.ByAnchorTicket(decor.TargetDefinitions))
 	return t.Put(ctx, xsrv.DecorationsKey(decor.File.Ticket), decor)
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package quota bounds the serving table reads made by single requests and
+// throttles them across requests.
+package quota // import "kythe.io/kythe/go/serving/quota"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// A ReadQuota bounds the table reads made to serve a single request.  A limit
+// <= 0 is unlimited.
+type ReadQuota struct {
+	// MaxLookups is the maximum number of table lookups, including page
+	// lookups.
+	MaxLookups int
+
+	// MaxPages is the maximum number of page lookups.
+	MaxPages int
+}
+
+// Unlimited reports whether q imposes no limits.
+func (q ReadQuota) Unlimited() bool { return q.MaxLookups <= 0 && q.MaxPages <= 0 }
+
+// A ReadLimiter throttles table reads across requests.  A *rate.Limiter from
+// golang.org/x/time/rate is a ReadLimiter.
+type ReadLimiter interface {
+	// Wait blocks until a single table read may proceed or returns an error if
+	// it cannot, e.g. because ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// An ExceededError is returned by a request whose table reads exceed its
+// ReadQuota.  Its gRPC status code is RESOURCE_EXHAUSTED and its Reply, if
+// any, is attached to the status as a detail.
+type ExceededError struct {
+	// Limit names the exceeded limit: "lookups" or "pages".
+	Limit string
+
+	// Max is the value of the exceeded limit.
+	Max int
+
+	// Reply is the partial reply served within the quota, or nil if the
+	// request could not be served at all.
+	Reply proto.Message
+}
+
+// Error implements the error interface.
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("read quota exceeded: more than %d %s", e.Max, e.Limit)
+}
+
+// GRPCStatus returns the gRPC status of the error.
+func (e *ExceededError) GRPCStatus() *status.Status {
+	st := status.New(codes.ResourceExhausted, e.Error())
+	if reply, ok := e.Reply.(protoiface.MessageV1); ok {
+		if detailed, err := st.WithDetails(reply); err == nil {
+			st = detailed
+		}
+	}
+	return st
+}
+
+// IsExceeded reports whether err is or wraps an *ExceededError.
+func IsExceeded(err error) bool {
+	var e *ExceededError
+	return errors.As(err, &e)
+}
+
+// A Budget tracks the table reads charged to a single request.
+type Budget struct {
+	quota ReadQuota
+
+	mu       sync.Mutex
+	lookups  int
+	pages    int
+	exceeded *ExceededError
+}
+
+type budgetKey struct{}
+
+// NewContext returns a context carrying a new Budget for q, which is also
+// returned, or ctx and a nil Budget if q is unlimited.
+func NewContext(ctx context.Context, q ReadQuota) (context.Context, *Budget) {
+	if q.Unlimited() {
+		return ctx, nil
+	}
+	b := &Budget{quota: q}
+	return context.WithValue(ctx, budgetKey{}, b), b
+}
+
+// charge records a single table read, failing if it would exceed the quota.
+// Exceeding the page limit only fails further page reads.
+func (b *Budget) charge(page bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var err *ExceededError
+	if max := b.quota.MaxLookups; max > 0 && b.lookups >= max {
+		err = &ExceededError{Limit: "lookups", Max: max}
+	} else if max := b.quota.MaxPages; page && max > 0 && b.pages >= max {
+		err = &ExceededError{Limit: "pages", Max: max}
+	} else {
+		b.lookups++
+		if page {
+			b.pages++
+		}
+		return nil
+	}
+	if b.exceeded == nil {
+		b.exceeded = err
+	}
+	return err
+}
+
+// Exceeded returns a new *ExceededError for the first limit of b that a read
+// exceeded, or nil if none has been.  A nil Budget is never exceeded.
+func (b *Budget) Exceeded() *ExceededError {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.exceeded == nil {
+		return nil
+	}
+	return &ExceededError{Limit: b.exceeded.Limit, Max: b.exceeded.Max}
+}
+
+// Charge charges a single table read to the Budget carried by ctx, if any, and
+// then waits for l, if non-nil.
+func Charge(ctx context.Context, l ReadLimiter, page bool) error {
+	if b, ok := ctx.Value(budgetKey{}).(*Budget); ok {
+		if err := b.charge(page); err != nil {
+			return err
+		}
+	}
+	if l != nil {
+		return l.Wait(ctx)
+	}
+	return nil
+}
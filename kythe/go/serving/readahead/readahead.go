@@ -0,0 +1,233 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package readahead implements an adaptive cache of serving table pages
+// prefetched ahead of paginated requests.
+package readahead // import "kythe.io/kythe/go/serving/readahead"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"kythe.io/kythe/go/util/log"
+)
+
+// Options configures the prefetching of pages for paginated requests.
+type Options struct {
+	// MaxPages is the maximum number of pages prefetched after a reply with a
+	// next page token.  The number of pages actually prefetched adapts to
+	// usage: it grows while prefetched pages are requested and shrinks as
+	// prefetched pages expire unused.  If <= 0, 4 is used.
+	MaxPages int
+
+	// CacheSize is the maximum number of prefetched pages retained.  If <= 0,
+	// 1024 is used.
+	CacheSize int
+
+	// TTL is how long a prefetched page is retained before expiring.  If <= 0,
+	// 1 minute is used.
+	TTL time.Duration
+
+	// Prefetched, if non-nil, is called with the key of each page after it is
+	// prefetched into the cache.
+	Prefetched func(key string)
+}
+
+func (o *Options) maxPages() int {
+	if o == nil || o.MaxPages <= 0 {
+		return 4
+	}
+	return o.MaxPages
+}
+
+func (o *Options) cacheSize() int {
+	if o == nil || o.CacheSize <= 0 {
+		return 1024
+	}
+	return o.CacheSize
+}
+
+func (o *Options) ttl() time.Duration {
+	if o == nil || o.TTL <= 0 {
+		return time.Minute
+	}
+	return o.TTL
+}
+
+// Cache is a size- and time-bounded LRU cache of prefetched pages of type T.
+// Prefetched pages are only expected to be used once.
+type Cache[T any] struct {
+	prefetched func(string)
+
+	mu       sync.Mutex
+	depth    int
+	maxDepth int
+	size     int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	lru      *list.List
+
+	now func() time.Time
+}
+
+type entry[T any] struct {
+	key     string
+	page    T
+	expires time.Time
+}
+
+// New returns a new Cache configured by the given options.
+func New[T any](opts *Options) *Cache[T] {
+	var prefetched func(string)
+	if opts != nil {
+		prefetched = opts.Prefetched
+	}
+	return &Cache[T]{
+		prefetched: prefetched,
+		depth:      1,
+		maxDepth:   opts.maxPages(),
+		size:       opts.cacheSize(),
+		ttl:        opts.ttl(),
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// Depth returns the number of pages to prefetch after a reply.
+func (c *Cache[T]) Depth() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.depth
+}
+
+// Get returns and removes the given page from the cache.  Each use of a
+// prefetched page grows the readahead depth.
+func (c *Cache[T]) Get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero T
+	e, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	c.remove(e)
+	ent := e.Value.(*entry[T])
+	if c.now().After(ent.expires) {
+		return zero, false
+	}
+	if c.depth < c.maxDepth {
+		c.depth++
+	}
+	return ent.page, true
+}
+
+// Prefetch asynchronously reads each of the given pages not already cached
+// using read and adds them to the cache.  Prefetching stops at the first read
+// error.
+func (c *Cache[T]) Prefetch(ctx context.Context, keys []string, read func(context.Context, string) (T, error)) {
+	var missing []string
+	for _, key := range keys {
+		if !c.Contains(key) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	// The request's context will be canceled once its reply is sent.
+	reqID := log.RequestID(ctx)
+	go func() {
+		ctx := log.WithRequestID(context.Background(), reqID)
+		for _, key := range missing {
+			page, err := read(ctx, key)
+			if err != nil {
+				log.Warningf(ctx, "Error prefetching page %q: %v", key, err)
+				return
+			}
+			c.put(key, page)
+			if c.prefetched != nil {
+				c.prefetched(key)
+			}
+		}
+	}()
+}
+
+// Invalidate removes the given page from the cache, if present, so that a
+// rewritten page is read anew.
+func (c *Cache[T]) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// Flush removes every page from the cache.
+func (c *Cache[T]) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+// Contains reports whether the given page is cached.
+func (c *Cache[T]) Contains(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[key]
+	return ok
+}
+
+// put adds the given page to the cache, returning the keys of any pages
+// evicted unused, either due to expiry or to make room.  Each eviction shrinks
+// the readahead depth.
+func (c *Cache[T]) put(key string, page T) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+
+	var evicted []string
+	for e := c.lru.Back(); e != nil; e = c.lru.Back() {
+		ent := e.Value.(*entry[T])
+		if len(c.entries) < c.size && now.Before(ent.expires) {
+			break
+		}
+		c.remove(e)
+		evicted = append(evicted, ent.key)
+		if c.depth > 1 {
+			c.depth--
+		}
+	}
+
+	c.entries[key] = c.lru.PushFront(&entry[T]{
+		key:     key,
+		page:    page,
+		expires: now.Add(c.ttl),
+	})
+	return evicted
+}
+
+func (c *Cache[T]) remove(e *list.Element) {
+	c.lru.Remove(e)
+	delete(c.entries, e.Value.(*entry[T]).key)
+}
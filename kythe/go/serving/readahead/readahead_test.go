@@ -0,0 +1,93 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package readahead
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := New[string](&Options{CacheSize: 2, MaxPages: 2})
+	c.now = func() time.Time { return now }
+
+	c.put("a", "A")
+	c.put("b", "B")
+	if evicted := c.put("c", "C"); len(evicted) != 1 || evicted[0] != "a" {
+		t.Errorf("Expected eviction of least recent page; found %v", evicted)
+	}
+	if p, ok := c.Get("b"); !ok || p != "B" {
+		t.Errorf("Expected cached page b; found %v", p)
+	}
+	if c.Contains("b") {
+		t.Error("Expected page b to be removed after use")
+	}
+	if d := c.Depth(); d != 2 {
+		t.Errorf("Expected readahead depth to grow to 2; found %d", d)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("c"); ok {
+		t.Error("Expected page c to expire")
+	}
+	c.put("d", "D")
+	if evicted := c.put("e", "E"); len(evicted) != 0 {
+		t.Errorf("Unexpected evictions: %v", evicted)
+	}
+
+	c.Invalidate("d")
+	if c.Contains("d") {
+		t.Error("Expected page d to be invalidated")
+	}
+
+	c.Flush()
+	if c.Contains("e") {
+		t.Error("Expected page e to be flushed")
+	}
+	if evicted := c.put("f", "F"); len(evicted) != 0 {
+		t.Errorf("Unexpected evictions after flush: %v", evicted)
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	prefetched := make(chan string, 3)
+	c := New[string](&Options{Prefetched: func(key string) { prefetched <- key }})
+	read := func(_ context.Context, key string) (string, error) {
+		if key == "bad" {
+			return "", errors.New("bad page")
+		}
+		return key + "!", nil
+	}
+
+	c.Prefetch(context.Background(), []string{"a", "b", "bad", "c"}, read)
+	for _, expected := range []string{"a", "b"} {
+		select {
+		case key := <-prefetched:
+			if key != expected {
+				t.Errorf("Expected page %q to be prefetched; found %q", expected, key)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Page %q was not prefetched", expected)
+		}
+	}
+	if p, ok := c.Get("a"); !ok || p != "a!" {
+		t.Errorf("Expected prefetched page a; found %q", p)
+	}
+}
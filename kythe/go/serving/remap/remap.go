@@ -0,0 +1,393 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package remap serves existing serving tables under renamed corpora.  A
+// Corpora mapping rewrites the tickets and corpus names of each request from
+// their served corpora to the corpora stored in the table and those of each
+// reply back again, so that a table built for corpus "oldrepo" may be served
+// as corpus "newrepo" without being rebuilt.  The xrefs, graph, filetree, and
+// identifiers services are each wrapped; corpus regexps of CrossReferences
+// corpus path filters are rewritten to match the stored corpora.
+package remap // import "kythe.io/kythe/go/serving/remap"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"kythe.io/kythe/go/services/filetree"
+	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/util/kytheuri"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	ftpb "kythe.io/kythe/proto/filetree_go_proto"
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// Corpora is a bidirectional mapping between served and stored corpus names.
+type Corpora struct {
+	toStored, toServed map[string]string
+}
+
+// New returns a Corpora mapping each served corpus name (a key of the given
+// map) to its stored corpus name.  The mapping must be one-to-one.
+func New(servedToStored map[string]string) (*Corpora, error) {
+	c := &Corpora{
+		toStored: make(map[string]string, len(servedToStored)),
+		toServed: make(map[string]string, len(servedToStored)),
+	}
+	for served, stored := range servedToStored {
+		if other, ok := c.toServed[stored]; ok {
+			return nil, fmt.Errorf("stored corpus %q mapped from both %q and %q", stored, other, served)
+		}
+		c.toStored[served] = stored
+		c.toServed[stored] = served
+	}
+	return c, nil
+}
+
+// Parse returns a Corpora mapping from the given "served=stored" pairs.
+func Parse(pairs []string) (*Corpora, error) {
+	m := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		i := strings.Index(p, "=")
+		if i <= 0 || i == len(p)-1 {
+			return nil, fmt.Errorf("invalid corpus mapping %q: expected served=stored", p)
+		}
+		served, stored := p[:i], p[i+1:]
+		if _, ok := m[served]; ok {
+			return nil, fmt.Errorf("served corpus %q mapped more than once", served)
+		}
+		m[served] = stored
+	}
+	return New(m)
+}
+
+// StoredTicket returns the given ticket with its served corpus replaced by
+// its stored corpus.  Tickets of unmapped corpora are returned unchanged.
+func (c *Corpora) StoredTicket(ticket string) string { return rewriteTicket(ticket, c.toStored) }
+
+// ServedTicket returns the given ticket with its stored corpus replaced by
+// its served corpus.  Tickets of unmapped corpora are returned unchanged.
+func (c *Corpora) ServedTicket(ticket string) string { return rewriteTicket(ticket, c.toServed) }
+
+func rewriteTicket(ticket string, corpora map[string]string) string {
+	if !strings.HasPrefix(ticket, kytheuri.Scheme+"//") {
+		return ticket
+	}
+	uri, err := kytheuri.Parse(ticket)
+	if err != nil {
+		return ticket
+	}
+	corpus, ok := corpora[uri.Corpus]
+	if !ok {
+		return ticket
+	}
+	uri.Corpus = corpus
+	return uri.String()
+}
+
+// toStoredMessage rewrites msg in place from served to stored corpora.
+func (c *Corpora) toStoredMessage(msg proto.Message) {
+	rewriteMessage(msg.ProtoReflect(), c.toStored)
+}
+
+// toServedMessage rewrites msg in place from stored to served corpora.
+func (c *Corpora) toServedMessage(msg proto.Message) {
+	rewriteMessage(msg.ProtoReflect(), c.toServed)
+}
+
+// corpusFilters is the name of the message holding corpus path filters, whose
+// corpus patterns are regexps rather than corpus names.
+const corpusFilters protoreflect.FullName = "kythe.proto.CorpusPathFilters"
+
+// isCorpusName reports whether the string field fd holds corpus names: it is
+// named "corpus" (e.g. of a CorpusPath or FindRequest) or it is the name of a
+// CorpusRootsReply corpus.
+func isCorpusName(fd protoreflect.FieldDescriptor) bool {
+	return fd.Name() == "corpus" || fd.FullName() == "kythe.proto.CorpusRootsReply.Corpus.name"
+}
+
+// rewriteString returns s, a value of the string field fd, with its mapped
+// corpus rewritten.
+func rewriteString(fd protoreflect.FieldDescriptor, s string, corpora map[string]string) string {
+	if corpus, ok := corpora[s]; ok && isCorpusName(fd) {
+		return corpus
+	}
+	return rewriteTicket(s, corpora)
+}
+
+// rewriteMessage rewrites each ticket within msg: every string field, map key,
+// or map value holding a Kythe URI with a mapped corpus.  Each string field
+// holding corpus names (see isCorpusName) is rewritten as corpus names.
+// CorpusPathFilters are left unchanged.
+func rewriteMessage(msg protoreflect.Message, corpora map[string]string) {
+	if msg.Descriptor().FullName() == corpusFilters {
+		return
+	}
+	var updates []func()
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			rewriteMap(fd, v.Map(), corpora)
+		case fd.IsList():
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				if fd.Message() != nil {
+					rewriteMessage(l.Get(i).Message(), corpora)
+				} else if fd.Kind() == protoreflect.StringKind {
+					l.Set(i, protoreflect.ValueOfString(rewriteString(fd, l.Get(i).String(), corpora)))
+				}
+			}
+		case fd.Message() != nil:
+			rewriteMessage(v.Message(), corpora)
+		case fd.Kind() == protoreflect.StringKind:
+			s := v.String()
+			if t := rewriteString(fd, s, corpora); t != s {
+				updates = append(updates, func() { msg.Set(fd, protoreflect.ValueOfString(t)) })
+			}
+		}
+		return true
+	})
+	for _, update := range updates {
+		update()
+	}
+}
+
+// rewriteMap rewrites each ticket within the keys and values of m, a map
+// field described by fd.
+func rewriteMap(fd protoreflect.FieldDescriptor, m protoreflect.Map, corpora map[string]string) {
+	type entry struct {
+		key, newKey protoreflect.MapKey
+		val         protoreflect.Value
+		changed     bool
+	}
+	var rewritten []entry
+	m.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		if fd.MapValue().Message() != nil {
+			rewriteMessage(v.Message(), corpora)
+		}
+		e := entry{key: k, newKey: k, val: v}
+		if fd.MapKey().Kind() == protoreflect.StringKind {
+			if t := rewriteTicket(k.String(), corpora); t != k.String() {
+				e.newKey, e.changed = protoreflect.ValueOfString(t).MapKey(), true
+			}
+		}
+		if fd.MapValue().Kind() == protoreflect.StringKind {
+			if t := rewriteTicket(v.String(), corpora); t != v.String() {
+				e.val, e.changed = protoreflect.ValueOfString(t), true
+			}
+		}
+		if e.changed {
+			rewritten = append(rewritten, e)
+		}
+		return true
+	})
+	// Clear every rewritten key before setting any so that keys swapped with
+	// one another are not lost.
+	for _, e := range rewritten {
+		m.Clear(e.key)
+	}
+	for _, e := range rewritten {
+		m.Set(e.newKey, e.val)
+	}
+}
+
+// storedFilters returns the given corpus path filters, whose corpus patterns
+// match served corpora, rewritten to match the same files by their stored
+// corpora.  Each pattern additionally matches the stored corpora whose served
+// names it matches.  Stored corpora that a pattern matches only by their
+// stored names are excluded from an INCLUDE_ONLY or DEFAULT filter by an added
+// EXCLUDE filter; since EXCLUDE filters cannot be narrowed in the same way,
+// an InvalidArgument error is returned for them.
+func (c *Corpora) storedFilters(fs *xpb.CorpusPathFilters) (*xpb.CorpusPathFilters, error) {
+	if len(fs.GetFilter()) == 0 {
+		return fs, nil
+	}
+	res := &xpb.CorpusPathFilters{}
+	for _, f := range fs.GetFilter() {
+		if f.GetCorpus() == "" {
+			res.Filter = append(res.Filter, f)
+			continue
+		}
+		p, err := regexp.Compile(f.GetCorpus())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid corpus pattern %q: %v", f.GetCorpus(), err)
+		}
+		var matched, hidden []string // stored corpora matched by served/stored name only
+		for stored, served := range c.toServed {
+			if p.MatchString(served) {
+				matched = append(matched, stored)
+			} else if p.MatchString(stored) {
+				hidden = append(hidden, stored)
+			}
+		}
+		f = proto.Clone(f).(*xpb.CorpusPathFilter)
+		if len(matched) > 0 {
+			f.Corpus = exactPattern(matched) + "|(?:" + f.Corpus + ")"
+		}
+		res.Filter = append(res.Filter, f)
+		if len(hidden) == 0 {
+			continue
+		} else if f.GetType() == xpb.CorpusPathFilter_EXCLUDE {
+			return nil, status.Errorf(codes.InvalidArgument, "EXCLUDE corpus pattern %q cannot be remapped: it matches remapped corpora by their stored names", p)
+		}
+		res.Filter = append(res.Filter, &xpb.CorpusPathFilter{
+			Type:   xpb.CorpusPathFilter_EXCLUDE,
+			Corpus: exactPattern(hidden),
+		})
+	}
+	return res, nil
+}
+
+// exactPattern returns a regexp matching exactly the given strings.
+func exactPattern(ss []string) string {
+	sort.Strings(ss)
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = regexp.QuoteMeta(s)
+	}
+	return "^(?:" + strings.Join(quoted, "|") + ")$"
+}
+
+// XRefs is an xrefs.Service serving the underlying Service's stored corpora
+// under their served names.
+type XRefs struct {
+	*Corpora
+	xrefs.Service
+}
+
+// Decorations implements part of the xrefs.Service interface.
+func (x XRefs) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	req = proto.Clone(req).(*xpb.DecorationsRequest)
+	x.toStoredMessage(req)
+	reply, err := x.Service.Decorations(ctx, req)
+	if reply != nil {
+		x.toServedMessage(reply)
+	}
+	return reply, err
+}
+
+// CrossReferences implements part of the xrefs.Service interface.
+func (x XRefs) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	req = proto.Clone(req).(*xpb.CrossReferencesRequest)
+	x.toStoredMessage(req)
+	filters, err := x.storedFilters(req.CorpusPathFilters)
+	if err != nil {
+		return nil, err
+	}
+	req.CorpusPathFilters = filters
+	reply, err := x.Service.CrossReferences(ctx, req)
+	if reply != nil {
+		x.toServedMessage(reply)
+	}
+	return reply, err
+}
+
+// Documentation implements part of the xrefs.Service interface.
+func (x XRefs) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
+	req = proto.Clone(req).(*xpb.DocumentationRequest)
+	x.toStoredMessage(req)
+	reply, err := x.Service.Documentation(ctx, req)
+	if reply != nil {
+		x.toServedMessage(reply)
+	}
+	return reply, err
+}
+
+// Graph is a graph.Service serving the underlying Service's stored corpora
+// under their served names.
+type Graph struct {
+	*Corpora
+	graph.Service
+}
+
+// Nodes implements part of the graph.Service interface.
+func (g Graph) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	req = proto.Clone(req).(*gpb.NodesRequest)
+	g.toStoredMessage(req)
+	reply, err := g.Service.Nodes(ctx, req)
+	if reply != nil {
+		g.toServedMessage(reply)
+	}
+	return reply, err
+}
+
+// Edges implements part of the graph.Service interface.
+func (g Graph) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	req = proto.Clone(req).(*gpb.EdgesRequest)
+	g.toStoredMessage(req)
+	reply, err := g.Service.Edges(ctx, req)
+	if reply != nil {
+		g.toServedMessage(reply)
+	}
+	return reply, err
+}
+
+// FileTree is a filetree.Service serving the underlying Service's stored
+// corpora under their served names.
+type FileTree struct {
+	*Corpora
+	filetree.Service
+}
+
+// CorpusRoots implements part of the filetree.Service interface.
+func (f FileTree) CorpusRoots(ctx context.Context, req *ftpb.CorpusRootsRequest) (*ftpb.CorpusRootsReply, error) {
+	reply, err := f.Service.CorpusRoots(ctx, req)
+	if reply != nil {
+		f.toServedMessage(reply)
+	}
+	return reply, err
+}
+
+// Directory implements part of the filetree.Service interface.
+func (f FileTree) Directory(ctx context.Context, req *ftpb.DirectoryRequest) (*ftpb.DirectoryReply, error) {
+	req = proto.Clone(req).(*ftpb.DirectoryRequest)
+	f.toStoredMessage(req)
+	reply, err := f.Service.Directory(ctx, req)
+	if reply != nil {
+		f.toServedMessage(reply)
+	}
+	return reply, err
+}
+
+// Identifiers is an identifiers.Service serving the underlying Service's
+// stored corpora under their served names.
+type Identifiers struct {
+	*Corpora
+	identifiers.Service
+}
+
+// Find implements the identifiers.Service interface.
+func (i Identifiers) Find(ctx context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	req = proto.Clone(req).(*ipb.FindRequest)
+	i.toStoredMessage(req)
+	reply, err := i.Service.Find(ctx, req)
+	if reply != nil {
+		i.toServedMessage(reply)
+	}
+	return reply, err
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remap
+
+import (
+	"context"
+	"testing"
+
+	"kythe.io/kythe/go/services/filetree"
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/test/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	ftpb "kythe.io/kythe/proto/filetree_go_proto"
+	ipb "kythe.io/kythe/proto/identifier_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+var ctx = context.Background()
+
+type xrefsService struct {
+	xrefs.Service
+
+	req   *xpb.CrossReferencesRequest
+	reply *xpb.CrossReferencesReply
+}
+
+func (s *xrefsService) CrossReferences(_ context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	s.req = req
+	return s.reply, nil
+}
+
+type fileTreeService struct {
+	filetree.Service
+
+	req   *ftpb.DirectoryRequest
+	reply *ftpb.DirectoryReply
+	roots *ftpb.CorpusRootsReply
+}
+
+func (s *fileTreeService) Directory(_ context.Context, req *ftpb.DirectoryRequest) (*ftpb.DirectoryReply, error) {
+	s.req = req
+	return s.reply, nil
+}
+
+func (s *fileTreeService) CorpusRoots(context.Context, *ftpb.CorpusRootsRequest) (*ftpb.CorpusRootsReply, error) {
+	return s.roots, nil
+}
+
+type identifiersService struct {
+	identifiers.Service
+
+	req   *ipb.FindRequest
+	reply *ipb.FindReply
+}
+
+func (s *identifiersService) Find(_ context.Context, req *ipb.FindRequest) (*ipb.FindReply, error) {
+	s.req = req
+	return s.reply, nil
+}
+
+func TestParse(t *testing.T) {
+	if _, err := Parse([]string{"new=old", "other=old"}); err == nil {
+		t.Error("Expected error for many-to-one mapping")
+	}
+	if _, err := Parse([]string{"new=old", "new=older"}); err == nil {
+		t.Error("Expected error for one-to-many mapping")
+	}
+	for _, p := range []string{"new", "=old", "new="} {
+		if _, err := Parse([]string{p}); err == nil {
+			t.Errorf("Expected error for %q", p)
+		}
+	}
+
+	c, err := Parse([]string{"newrepo=oldrepo"})
+	testutil.Fatalf(t, "Parse error: %v", err)
+	tests := []struct{ served, stored string }{
+		{"kythe://newrepo?lang=go?path=a/b#sig", "kythe://oldrepo?lang=go?path=a/b#sig"},
+		{"kythe://other?path=a/b", "kythe://other?path=a/b"},
+		{"kythe:?path=a/b", "kythe:?path=a/b"},
+		{"not a ticket", "not a ticket"},
+	}
+	for _, test := range tests {
+		if found := c.StoredTicket(test.served); found != test.stored {
+			t.Errorf("StoredTicket(%q): expected %q; found %q", test.served, test.stored, found)
+		}
+		if found := c.ServedTicket(test.stored); found != test.served {
+			t.Errorf("ServedTicket(%q): expected %q; found %q", test.stored, test.served, found)
+		}
+	}
+}
+
+func TestXRefs(t *testing.T) {
+	const (
+		oldNode   = "kythe://oldrepo?lang=go#node"
+		oldAnchor = "kythe://oldrepo?lang=go?path=a.go#anchor"
+		oldFile   = "kythe://oldrepo?path=a.go"
+		newNode   = "kythe://newrepo?lang=go#node"
+		newAnchor = "kythe://newrepo?lang=go?path=a.go#anchor"
+		newFile   = "kythe://newrepo?path=a.go"
+		other     = "kythe://other?lang=go#node"
+	)
+	reply := func(node, anchor, file string) *xpb.CrossReferencesReply {
+		return &xpb.CrossReferencesReply{
+			CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+				node: {
+					Ticket: node,
+					Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{
+						Anchor: &xpb.Anchor{Ticket: anchor, Parent: file},
+					}},
+				},
+				other: {Ticket: other},
+			},
+			Nodes: map[string]*cpb.NodeInfo{node: {}},
+			DefinitionLocations: map[string]*xpb.Anchor{
+				anchor: {Ticket: anchor, Parent: file},
+			},
+			NextPageToken: "token",
+		}
+	}
+
+	c, err := New(map[string]string{"newrepo": "oldrepo"})
+	testutil.Fatalf(t, "New error: %v", err)
+	s := &xrefsService{reply: reply(oldNode, oldAnchor, oldFile)}
+	x := XRefs{c, s}
+
+	req := &xpb.CrossReferencesRequest{Ticket: []string{newNode, other}}
+	found, err := x.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+
+	if err := testutil.DeepEqual([]string{oldNode, other}, s.req.Ticket); err != nil {
+		t.Errorf("Request tickets: %v", err)
+	}
+	if err := testutil.DeepEqual([]string{newNode, other}, req.Ticket); err != nil {
+		t.Errorf("Caller's request modified: %v", err)
+	}
+	if err := testutil.DeepEqual(reply(newNode, newAnchor, newFile), found); err != nil {
+		t.Errorf("Reply: %v", err)
+	}
+}
+
+func TestCorpusFields(t *testing.T) {
+	c, err := New(map[string]string{"newrepo": "oldrepo"})
+	testutil.Fatalf(t, "New error: %v", err)
+	cp := &cpb.CorpusPath{Corpus: "oldrepo", Root: "oldrepo", Path: "a.go"}
+	c.toServedMessage(cp)
+	if err := testutil.DeepEqual(&cpb.CorpusPath{Corpus: "newrepo", Root: "oldrepo", Path: "a.go"}, cp); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCorpusPathFilters(t *testing.T) {
+	c, err := New(map[string]string{"newrepo": "oldrepo", "renamed": "other"})
+	testutil.Fatalf(t, "New error: %v", err)
+	s := &xrefsService{reply: &xpb.CrossReferencesReply{}}
+	x := XRefs{c, s}
+
+	filters := &xpb.CorpusPathFilters{Filter: []*xpb.CorpusPathFilter{
+		{Type: xpb.CorpusPathFilter_INCLUDE_ONLY, Corpus: "repo", Path: "a/"},
+		{Type: xpb.CorpusPathFilter_EXCLUDE, Path: "testdata/"},
+	}}
+	_, err = x.CrossReferences(ctx, &xpb.CrossReferencesRequest{CorpusPathFilters: filters})
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+	expected := &xpb.CorpusPathFilters{Filter: []*xpb.CorpusPathFilter{
+		{Type: xpb.CorpusPathFilter_INCLUDE_ONLY, Corpus: "^(?:oldrepo)$|(?:repo)", Path: "a/"},
+		{Type: xpb.CorpusPathFilter_EXCLUDE, Path: "testdata/"},
+	}}
+	if err := testutil.DeepEqual(expected, s.req.CorpusPathFilters); err != nil {
+		t.Errorf("Corpus path filters: %v", err)
+	}
+
+	// Stored corpora matched only by their stored names are excluded.
+	filters.Filter[0].Corpus = "^o"
+	_, err = x.CrossReferences(ctx, &xpb.CrossReferencesRequest{CorpusPathFilters: filters})
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+	expected.Filter[0].Corpus = "^o"
+	expected.Filter = []*xpb.CorpusPathFilter{
+		expected.Filter[0],
+		{Type: xpb.CorpusPathFilter_EXCLUDE, Corpus: "^(?:oldrepo|other)$"},
+		expected.Filter[1],
+	}
+	if err := testutil.DeepEqual(expected, s.req.CorpusPathFilters); err != nil {
+		t.Errorf("Corpus path filters: %v", err)
+	}
+
+	filters.Filter[0].Type = xpb.CorpusPathFilter_EXCLUDE
+	if _, err := x.CrossReferences(ctx, &xpb.CrossReferencesRequest{CorpusPathFilters: filters}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for unmappable EXCLUDE filter; found %v", err)
+	}
+}
+
+func TestFileTree(t *testing.T) {
+	c, err := New(map[string]string{"newrepo": "oldrepo"})
+	testutil.Fatalf(t, "New error: %v", err)
+	s := &fileTreeService{
+		reply: &ftpb.DirectoryReply{Corpus: "oldrepo", Path: "a/"},
+		roots: &ftpb.CorpusRootsReply{Corpus: []*ftpb.CorpusRootsReply_Corpus{
+			{Name: "oldrepo", Root: []string{"oldrepo"}},
+			{Name: "other"},
+		}},
+	}
+	f := FileTree{c, s}
+
+	reply, err := f.Directory(ctx, &ftpb.DirectoryRequest{Corpus: "newrepo", Path: "a/"})
+	testutil.Fatalf(t, "Directory error: %v", err)
+	if s.req.Corpus != "oldrepo" {
+		t.Errorf("Expected request for corpus %q; found %q", "oldrepo", s.req.Corpus)
+	}
+	if err := testutil.DeepEqual(&ftpb.DirectoryReply{Corpus: "newrepo", Path: "a/"}, reply); err != nil {
+		t.Errorf("Directory reply: %v", err)
+	}
+
+	roots, err := f.CorpusRoots(ctx, &ftpb.CorpusRootsRequest{})
+	testutil.Fatalf(t, "CorpusRoots error: %v", err)
+	expected := &ftpb.CorpusRootsReply{Corpus: []*ftpb.CorpusRootsReply_Corpus{
+		{Name: "newrepo", Root: []string{"oldrepo"}},
+		{Name: "other"},
+	}}
+	if err := testutil.DeepEqual(expected, roots); err != nil {
+		t.Errorf("CorpusRoots reply: %v", err)
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	c, err := New(map[string]string{"newrepo": "oldrepo"})
+	testutil.Fatalf(t, "New error: %v", err)
+	s := &identifiersService{reply: &ipb.FindReply{Matches: []*ipb.FindReply_Match{{
+		Ticket:        "kythe://oldrepo?lang=go#F",
+		QualifiedName: "pkg.F",
+	}}}}
+	i := Identifiers{c, s}
+
+	reply, err := i.Find(ctx, &ipb.FindRequest{Identifier: "pkg.F", Corpus: []string{"newrepo", "other"}})
+	testutil.Fatalf(t, "Find error: %v", err)
+	if err := testutil.DeepEqual([]string{"oldrepo", "other"}, s.req.Corpus); err != nil {
+		t.Errorf("Request corpora: %v", err)
+	}
+	expected := &ipb.FindReply{Matches: []*ipb.FindReply_Match{{
+		Ticket:        "kythe://newrepo?lang=go#F",
+		QualifiedName: "pkg.F",
+	}}}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Errorf("Find reply: %v", err)
+	}
+}
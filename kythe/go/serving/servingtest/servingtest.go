@@ -0,0 +1,381 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package servingtest builds in-memory serving tables from compact
+// declarative fixtures so that tests can exercise the real graph and xrefs
+// serving implementations without hand-building serving protos.
+package servingtest // import "kythe.io/kythe/go/serving/servingtest"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/serving/xrefs/assemble"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/schema/nodes"
+	"kythe.io/kythe/go/util/span"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// A Fixture is a declarative description of a small Kythe graph.  Tickets may
+// be given in any valid form; they are canonicalized when the tables are built.
+type Fixture struct {
+	Nodes   []*Node
+	Edges   []*Edge
+	Files   []*File
+	Anchors []*Anchor
+}
+
+// A Node is a graph node with a set of facts.  Nodes referenced by an Edge or
+// Anchor need not be declared.
+type Node struct {
+	Ticket string
+
+	// Kind is shorthand for the /kythe/node/kind fact.
+	Kind string
+
+	Facts map[string]string
+}
+
+// An Edge is a forward edge between two nodes.  Its reverse edge is added
+// automatically.
+type Edge struct {
+	Source, Kind, Target string
+	Ordinal              int
+}
+
+// A File is a file node with the given text.
+type File struct {
+	Ticket string
+	Text   string
+}
+
+// An Anchor is a span of a File's text related to a target node by the given
+// edge kind (e.g. /kythe/edge/ref).  An anchor's ticket is derived from its
+// file's ticket and its span.
+type Anchor struct {
+	File        string
+	Start, End  int
+	Kind        string
+	Target      string
+	BuildConfig string
+}
+
+// ticket returns the ticket of the anchor, given the canonical ticket of its
+// file.
+func (a *Anchor) ticket(file string) (string, error) {
+	u, err := kytheuri.Parse(file)
+	if err != nil {
+		return "", err
+	}
+	u.Signature = fmt.Sprintf("@%d:%d", a.Start, a.End)
+	return u.String(), nil
+}
+
+// Tables are the serving tables built from a Fixture.
+type Tables struct {
+	// Proto is the combined table holding all of the serving data.
+	Proto table.Proto
+
+	Graph *graph.Table
+	XRefs *xrefs.Table
+}
+
+type builder struct {
+	nodes map[string]*srvpb.Node
+
+	// source ticket -> edge kind -> edges
+	edges map[string]map[string][]*srvpb.EdgeGroup_Edge
+
+	// source ticket -> kind -> related nodes
+	related map[string]map[string][]*srvpb.PagedCrossReferences_RelatedNode
+
+	// target ticket -> kind -> build config -> anchors
+	refs map[string]map[string]map[string][]*srvpb.ExpandedAnchor
+
+	// file ticket -> decorations
+	decor map[string]*srvpb.FileDecorations
+	norms map[string]*span.Normalizer
+}
+
+// Build returns the serving tables for the Fixture.
+func (f *Fixture) Build(ctx context.Context) (*Tables, error) {
+	b := &builder{
+		nodes:   make(map[string]*srvpb.Node),
+		edges:   make(map[string]map[string][]*srvpb.EdgeGroup_Edge),
+		related: make(map[string]map[string][]*srvpb.PagedCrossReferences_RelatedNode),
+		refs:    make(map[string]map[string]map[string][]*srvpb.ExpandedAnchor),
+		decor:   make(map[string]*srvpb.FileDecorations),
+		norms:   make(map[string]*span.Normalizer),
+	}
+	for _, n := range f.Nodes {
+		node, err := b.node(n.Ticket)
+		if err != nil {
+			return nil, err
+		}
+		if n.Kind != "" {
+			node.Fact = append(node.Fact, &cpb.Fact{Name: facts.NodeKind, Value: []byte(n.Kind)})
+		}
+		for _, name := range sortedKeys(n.Facts) {
+			node.Fact = append(node.Fact, &cpb.Fact{Name: name, Value: []byte(n.Facts[name])})
+		}
+	}
+	for _, file := range f.Files {
+		if err := b.addFile(file); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range f.Edges {
+		if err := b.addEdge(e); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range f.Anchors {
+		if err := b.addAnchor(a); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &table.KVProto{DB: inmemory.NewKeyValueDB()}
+	if err := b.write(ctx, p); err != nil {
+		return nil, err
+	}
+	return &Tables{
+		Proto: p,
+		Graph: graph.NewCombinedTable(p),
+		XRefs: xrefs.NewCombinedTable(p),
+	}, nil
+}
+
+func (b *builder) node(ticket string) (*srvpb.Node, error) {
+	fixed, err := kytheuri.Fix(ticket)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket %q: %v", ticket, err)
+	}
+	n, ok := b.nodes[fixed]
+	if !ok {
+		n = &srvpb.Node{Ticket: fixed}
+		b.nodes[fixed] = n
+	}
+	return n, nil
+}
+
+func (b *builder) addFile(file *File) error {
+	n, err := b.node(file.Ticket)
+	if err != nil {
+		return err
+	}
+	n.Fact = append(n.Fact,
+		&cpb.Fact{Name: facts.NodeKind, Value: []byte(nodes.File)},
+		&cpb.Fact{Name: facts.Text, Value: []byte(file.Text)},
+		&cpb.Fact{Name: facts.TextEncoding, Value: []byte(facts.DefaultTextEncoding)},
+	)
+	b.decor[n.Ticket] = &srvpb.FileDecorations{
+		File: &srvpb.File{
+			Ticket:   n.Ticket,
+			Text:     []byte(file.Text),
+			Encoding: facts.DefaultTextEncoding,
+		},
+	}
+	b.norms[n.Ticket] = span.NewNormalizer([]byte(file.Text))
+	return nil
+}
+
+func (b *builder) addEdge(e *Edge) error {
+	src, err := b.node(e.Source)
+	if err != nil {
+		return err
+	}
+	tgt, err := b.node(e.Target)
+	if err != nil {
+		return err
+	}
+	b.addGraphEdge(src.Ticket, e.Kind, tgt, e.Ordinal)
+	b.addGraphEdge(tgt.Ticket, edges.Mirror(e.Kind), src, e.Ordinal)
+
+	if b.related[src.Ticket] == nil {
+		b.related[src.Ticket] = make(map[string][]*srvpb.PagedCrossReferences_RelatedNode)
+	}
+	b.related[src.Ticket][e.Kind] = append(b.related[src.Ticket][e.Kind], &srvpb.PagedCrossReferences_RelatedNode{
+		Node:    tgt,
+		Ordinal: int32(e.Ordinal),
+	})
+	return nil
+}
+
+func (b *builder) addGraphEdge(src, kind string, tgt *srvpb.Node, ordinal int) {
+	if b.edges[src] == nil {
+		b.edges[src] = make(map[string][]*srvpb.EdgeGroup_Edge)
+	}
+	b.edges[src][kind] = append(b.edges[src][kind], &srvpb.EdgeGroup_Edge{
+		Target:  tgt,
+		Ordinal: int32(ordinal),
+	})
+}
+
+func (b *builder) addAnchor(a *Anchor) error {
+	file, err := b.node(a.File)
+	if err != nil {
+		return err
+	}
+	fd, ok := b.decor[file.Ticket]
+	if !ok {
+		return fmt.Errorf("anchor in undeclared file %q", a.File)
+	}
+	tgt, err := b.node(a.Target)
+	if err != nil {
+		return err
+	}
+	ticket, err := a.ticket(file.Ticket)
+	if err != nil {
+		return err
+	}
+
+	raw := &srvpb.RawAnchor{
+		Ticket:             ticket,
+		StartOffset:        int32(a.Start),
+		EndOffset:          int32(a.End),
+		BuildConfiguration: a.BuildConfig,
+	}
+	ea, err := assemble.ExpandAnchor(raw, fd.File, b.norms[file.Ticket], a.Kind)
+	if err != nil {
+		return fmt.Errorf("invalid anchor %s: %v", ticket, err)
+	}
+	ea.BuildConfiguration = a.BuildConfig
+
+	fd.Decoration = append(fd.Decoration, &srvpb.FileDecorations_Decoration{
+		Anchor: raw,
+		Kind:   a.Kind,
+		Target: tgt.Ticket,
+	})
+
+	if b.refs[tgt.Ticket] == nil {
+		b.refs[tgt.Ticket] = make(map[string]map[string][]*srvpb.ExpandedAnchor)
+	}
+	if b.refs[tgt.Ticket][a.Kind] == nil {
+		b.refs[tgt.Ticket][a.Kind] = make(map[string][]*srvpb.ExpandedAnchor)
+	}
+	b.refs[tgt.Ticket][a.Kind][a.BuildConfig] = append(b.refs[tgt.Ticket][a.Kind][a.BuildConfig], ea)
+	return nil
+}
+
+// definition returns the first defining anchor of the given target, if any.
+func (b *builder) definition(target string) *srvpb.ExpandedAnchor {
+	for _, kind := range sortedKeys(b.refs[target]) {
+		if !edges.IsVariant(kind, edges.Defines) {
+			continue
+		}
+		for _, config := range sortedKeys(b.refs[target][kind]) {
+			if as := b.refs[target][kind][config]; len(as) > 0 {
+				return as[0]
+			}
+		}
+	}
+	return nil
+}
+
+func (b *builder) write(ctx context.Context, p table.Proto) error {
+	gw := graph.NewCombinedWriter(p)
+	xw := xrefs.NewCombinedWriter(p)
+
+	for _, ticket := range sortedKeys(b.nodes) {
+		pes := &srvpb.PagedEdgeSet{Source: b.nodes[ticket]}
+		for _, kind := range sortedKeys(b.edges[ticket]) {
+			pes.Group = append(pes.Group, &srvpb.EdgeGroup{
+				Kind: kind,
+				Edge: b.edges[ticket][kind],
+			})
+		}
+		if err := gw.WriteEdgeSet(ctx, pes); err != nil {
+			return err
+		}
+
+		cr := &srvpb.PagedCrossReferences{
+			SourceTicket: ticket,
+			SourceNode:   b.nodes[ticket],
+		}
+		for _, kind := range sortedKeys(b.refs[ticket]) {
+			for _, config := range sortedKeys(b.refs[ticket][kind]) {
+				as := b.refs[ticket][kind][config]
+				sort.Slice(as, func(i, j int) bool { return as[i].Ticket < as[j].Ticket })
+				cr.Group = append(cr.Group, &srvpb.PagedCrossReferences_Group{
+					Kind:        kind,
+					BuildConfig: config,
+					Anchor:      as,
+				})
+			}
+		}
+		for _, kind := range sortedKeys(b.related[ticket]) {
+			cr.Group = append(cr.Group, &srvpb.PagedCrossReferences_Group{
+				Kind:        kind,
+				RelatedNode: b.related[ticket][kind],
+			})
+		}
+		if len(cr.Group) > 0 {
+			if err := xw.WriteCrossReferences(ctx, cr); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, ticket := range sortedKeys(b.decor) {
+		fd := b.decor[ticket]
+		sort.SliceStable(fd.Decoration, func(i, j int) bool {
+			a, b := fd.Decoration[i].Anchor, fd.Decoration[j].Anchor
+			if a.StartOffset != b.StartOffset {
+				return a.StartOffset < b.StartOffset
+			}
+			return a.EndOffset < b.EndOffset
+		})
+		targets := make(map[string]bool)
+		defs := make(map[string]bool)
+		for _, d := range fd.Decoration {
+			if !targets[d.Target] {
+				targets[d.Target] = true
+				fd.Target = append(fd.Target, b.nodes[d.Target])
+			}
+			if def := b.definition(d.Target); def != nil {
+				d.TargetDefinition = def.Ticket
+				if !defs[def.Ticket] {
+					defs[def.Ticket] = true
+					fd.TargetDefinitions = append(fd.TargetDefinitions, def)
+				}
+			}
+		}
+		if err := xw.WriteDecorations(ctx, fd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
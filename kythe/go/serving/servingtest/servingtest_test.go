@@ -0,0 +1,115 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servingtest
+
+import (
+	"context"
+	"testing"
+
+	"kythe.io/kythe/go/test/testutil"
+
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+const (
+	file  = "kythe://corpus?path=main.go"
+	fn    = "kythe://corpus?lang=go#f"
+	param = "kythe://corpus?lang=go#x"
+)
+
+var fixture = &Fixture{
+	Nodes: []*Node{
+		{Ticket: fn, Kind: "function"},
+		{Ticket: param, Kind: "variable", Facts: map[string]string{"/kythe/subkind": "param"}},
+	},
+	Edges: []*Edge{{Source: fn, Kind: "/kythe/edge/param", Target: param}},
+	Files: []*File{{Ticket: file, Text: "func f(x int) {}\nf(1)\n"}},
+	Anchors: []*Anchor{
+		{File: file, Start: 5, End: 6, Kind: "/kythe/edge/defines/binding", Target: fn},
+		{File: file, Start: 17, End: 18, Kind: "/kythe/edge/ref", Target: fn},
+	},
+}
+
+func TestFixture(t *testing.T) {
+	ctx := context.Background()
+	tbls, err := fixture.Build(ctx)
+	testutil.Fatalf(t, "Build error: %v", err)
+
+	nodes, err := tbls.Graph.Nodes(ctx, &gpb.NodesRequest{Ticket: []string{param}})
+	testutil.Fatalf(t, "Nodes error: %v", err)
+	if err := testutil.DeepEqual(map[string][]byte{
+		"/kythe/node/kind": []byte("variable"),
+		"/kythe/subkind":   []byte("param"),
+	}, nodes.Nodes[param].GetFacts()); err != nil {
+		t.Error(err)
+	}
+
+	es, err := tbls.Graph.Edges(ctx, &gpb.EdgesRequest{Ticket: []string{param}})
+	testutil.Fatalf(t, "Edges error: %v", err)
+	if g := es.EdgeSets[param].GetGroups()["%/kythe/edge/param"]; len(g.GetEdge()) != 1 || g.Edge[0].TargetTicket != fn {
+		t.Errorf("Missing reverse param edge: %v", es)
+	}
+
+	decor, err := tbls.XRefs.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:          &xpb.Location{Ticket: file},
+		References:        true,
+		TargetDefinitions: true,
+	})
+	testutil.Fatalf(t, "Decorations error: %v", err)
+	if len(decor.Reference) != 2 {
+		t.Fatalf("Expected 2 references; found %v", decor.Reference)
+	}
+	def := decor.Reference[0].TargetDefinition
+	for _, r := range decor.Reference {
+		if r.TargetTicket != fn || r.TargetDefinition != def {
+			t.Errorf("Unexpected reference: %v", r)
+		}
+	}
+
+	xrefs, err := tbls.XRefs.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:          []string{fn},
+		DefinitionKind:  xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		ReferenceKind:   xpb.CrossReferencesRequest_ALL_REFERENCES,
+		Filter:          []string{"/kythe/node/kind"},
+		RelatedNodeKind: []string{"/kythe/edge/param"},
+		Snippets:        xpb.SnippetsKind_DEFAULT,
+	})
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+	crs := xrefs.CrossReferences[fn]
+	if len(crs.GetDefinition()) != 1 || crs.Definition[0].Anchor.Ticket != def {
+		t.Errorf("Unexpected definitions: %v", crs.GetDefinition())
+	}
+	if len(crs.GetReference()) != 1 || crs.Reference[0].Anchor.Snippet != "f(1)" {
+		t.Errorf("Unexpected references: %v", crs.GetReference())
+	}
+	if len(crs.GetRelatedNode()) != 1 || crs.RelatedNode[0].Ticket != param {
+		t.Errorf("Unexpected related nodes: %v", crs.GetRelatedNode())
+	}
+}
+
+func TestFixtureErrors(t *testing.T) {
+	for _, f := range []*Fixture{
+		{Nodes: []*Node{{Ticket: "bad:ticket"}}},
+		{Anchors: []*Anchor{{File: file, Target: fn}}},
+		{Files: []*File{{Ticket: file, Text: "short"}}, Anchors: []*Anchor{{File: file, Start: 2, End: 20, Target: fn}}},
+	} {
+		if _, err := f.Build(context.Background()); err == nil {
+			t.Errorf("Expected error building %+v", f)
+		}
+	}
+}
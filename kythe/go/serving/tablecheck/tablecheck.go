@@ -0,0 +1,347 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tablecheck validates the structure of serving table entries.  It
+// reports malformed or internally inconsistent entries (e.g. those corrupted
+// in storage) as a typed *CorruptionError rather than letting them surface as
+// panics or confusing errors deep within request handling.  Its entry points
+// are also suitable targets for fuzzing the serving read path.
+package tablecheck // import "kythe.io/kythe/go/serving/tablecheck"
+
+import (
+	"fmt"
+
+	"kythe.io/kythe/go/serving/pagekey"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// A CorruptionError reports a malformed serving table entry.  Its gRPC status
+// code is DATA_LOSS.
+type CorruptionError struct {
+	// Entry names the type of the corrupt entry (e.g. "PagedEdgeSet").
+	Entry string
+
+	// Ticket is the ticket of the entry's source node or file, if known.
+	Ticket string
+
+	// Field is the path of the corrupt field within the entry (e.g.
+	// "group[2].anchor[0]"), or empty if the entry itself is missing.
+	Field string
+
+	// Reason describes the corruption.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *CorruptionError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("corrupt %s for %q: %s", e.Entry, e.Ticket, e.Reason)
+	}
+	return fmt.Sprintf("corrupt %s for %q: %s: %s", e.Entry, e.Ticket, e.Field, e.Reason)
+}
+
+// GRPCStatus returns the gRPC status of the error.
+func (e *CorruptionError) GRPCStatus() *status.Status {
+	return status.New(codes.DataLoss, e.Error())
+}
+
+// A checker records the first problem found in a single entry.
+type checker struct {
+	entry, ticket string
+	err           *CorruptionError
+}
+
+func (c *checker) failf(field, format string, args ...interface{}) {
+	if c.err == nil {
+		c.err = &CorruptionError{
+			Entry:  c.entry,
+			Ticket: c.ticket,
+			Field:  field,
+			Reason: fmt.Sprintf(format, args...),
+		}
+	}
+}
+
+// result returns the first recorded problem, if any, as an error.
+func (c *checker) result() error {
+	if c.err == nil {
+		return nil
+	}
+	return c.err
+}
+
+func (c *checker) pageKey(field, key string, kind pagekey.Kind, ticket, group string) {
+	if key == "" {
+		c.failf(field, "missing page key")
+	} else if err := pagekey.Validate(key, kind, ticket, group); err != nil {
+		c.failf(field, "%v", err)
+	}
+}
+
+func (c *checker) count(field string, n int32) {
+	if n < 0 {
+		c.failf(field, "negative count %d", n)
+	}
+}
+
+// page checks that a page stored under the given key records that key.
+func (c *checker) page(key, stored string) {
+	if key != "" && stored != key {
+		c.failf("page_key", "page stored under key %q", key)
+	}
+}
+
+// offsets checks a span, and that it lies within text if text is non-empty.
+func (c *checker) offsets(field string, start, end int32, text []byte) {
+	if start < 0 || end < start {
+		c.failf(field, "invalid span [%d, %d)", start, end)
+	} else if len(text) > 0 && int(end) > len(text) {
+		c.failf(field, "span [%d, %d) exceeds file length %d", start, end, len(text))
+	}
+}
+
+func (c *checker) edgeGroup(field string, g *srvpb.EdgeGroup) {
+	if g == nil {
+		c.failf(field, "missing group")
+		return
+	} else if g.Kind == "" {
+		c.failf(field, "missing edge kind")
+	}
+	for j, e := range g.Edge {
+		if e.GetTarget().GetTicket() == "" {
+			c.failf(fmt.Sprintf("%s.edge[%d]", field, j), "missing target ticket")
+		}
+	}
+}
+
+func (c *checker) decoration(field string, d *srvpb.FileDecorations_Decoration, text []byte) {
+	if d == nil {
+		c.failf(field, "missing decoration")
+		return
+	} else if d.Anchor == nil {
+		c.failf(field, "missing anchor")
+		return
+	} else if d.Kind == "" {
+		c.failf(field, "missing edge kind")
+	} else if d.Target == "" {
+		c.failf(field, "missing target")
+	}
+	c.offsets(field+".anchor", d.Anchor.StartOffset, d.Anchor.EndOffset, text)
+}
+
+func (c *checker) anchors(field string, as []*srvpb.ExpandedAnchor) {
+	for i, a := range as {
+		if a.GetTicket() == "" {
+			c.failf(fmt.Sprintf("%s[%d]", field, i), "missing anchor ticket")
+		}
+	}
+}
+
+func (c *checker) crossReferencesGroup(field string, g *srvpb.PagedCrossReferences_Group) {
+	if g == nil {
+		c.failf(field, "missing group")
+		return
+	} else if g.Kind == "" {
+		c.failf(field, "missing edge kind")
+	}
+	c.anchors(field+".anchor", g.Anchor)
+	for j, rn := range g.RelatedNode {
+		if rn.GetNode().GetTicket() == "" {
+			c.failf(fmt.Sprintf("%s.related_node[%d]", field, j), "missing node ticket")
+		}
+	}
+	for j, ca := range g.Caller {
+		f := fmt.Sprintf("%s.caller[%d]", field, j)
+		if ca.GetCaller().GetTicket() == "" {
+			c.failf(f, "missing caller anchor")
+		}
+		c.anchors(f+".callsite", ca.GetCallsite())
+	}
+	for j, sr := range g.ScopedReference {
+		c.anchors(fmt.Sprintf("%s.scoped_reference[%d].reference", field, j), sr.GetReference())
+	}
+}
+
+// ValidatePagedEdgeSet returns a *CorruptionError if es is malformed.
+func ValidatePagedEdgeSet(es *srvpb.PagedEdgeSet) error {
+	c := &checker{entry: "PagedEdgeSet", ticket: es.GetSource().GetTicket()}
+	if es == nil {
+		c.failf("", "missing entry")
+		return c.result()
+	} else if c.ticket == "" {
+		c.failf("source", "missing source ticket")
+	}
+	for i, g := range es.Group {
+		c.edgeGroup(fmt.Sprintf("group[%d]", i), g)
+	}
+	for i, idx := range es.PageIndex {
+		field := fmt.Sprintf("page_index[%d]", i)
+		if idx == nil {
+			c.failf(field, "missing page index")
+			continue
+		} else if idx.EdgeKind == "" {
+			c.failf(field, "missing edge kind")
+		}
+		c.count(field+".edge_count", idx.EdgeCount)
+		c.pageKey(field+".page_key", idx.PageKey, pagekey.EdgePage, c.ticket, idx.EdgeKind)
+	}
+	return c.result()
+}
+
+// ValidateFileDecorations returns a *CorruptionError if fd is malformed.  Its
+// file may be missing only if it has diagnostics explaining why.  Anchor
+// offsets are checked against the file's text only if it is stored.
+func ValidateFileDecorations(fd *srvpb.FileDecorations) error {
+	c := &checker{entry: "FileDecorations", ticket: fd.GetFile().GetTicket()}
+	if fd == nil {
+		c.failf("", "missing entry")
+		return c.result()
+	} else if fd.File == nil && len(fd.Diagnostic) == 0 {
+		c.failf("file", "missing file without diagnostics")
+	}
+	text := fd.GetFile().GetText()
+	for i, d := range fd.Decoration {
+		c.decoration(fmt.Sprintf("decoration[%d]", i), d, text)
+	}
+	for i, n := range fd.Target {
+		if n.GetTicket() == "" {
+			c.failf(fmt.Sprintf("target[%d]", i), "missing ticket")
+		}
+	}
+	for i, def := range fd.TargetDefinitions {
+		if def.GetTicket() == "" {
+			c.failf(fmt.Sprintf("target_definitions[%d]", i), "missing ticket")
+		}
+	}
+	for i, p := range fd.DecorationPage {
+		field := fmt.Sprintf("decoration_page[%d]", i)
+		if p == nil {
+			c.failf(field, "missing page")
+			continue
+		}
+		c.offsets(field, p.StartOffset, p.EndOffset, text)
+		c.pageKey(field+".page_key", p.PageKey, pagekey.DecorationsPage, c.ticket, "")
+	}
+	return c.result()
+}
+
+// ValidatePagedCrossReferences returns a *CorruptionError if cr is malformed.
+func ValidatePagedCrossReferences(cr *srvpb.PagedCrossReferences) error {
+	c := &checker{entry: "PagedCrossReferences", ticket: cr.GetSourceTicket()}
+	if cr == nil {
+		c.failf("", "missing entry")
+		return c.result()
+	} else if c.ticket == "" {
+		c.failf("source_ticket", "missing source ticket")
+	}
+	for i, g := range cr.Group {
+		c.crossReferencesGroup(fmt.Sprintf("group[%d]", i), g)
+	}
+	for i, idx := range cr.PageIndex {
+		field := fmt.Sprintf("page_index[%d]", i)
+		if idx == nil {
+			c.failf(field, "missing page index")
+			continue
+		} else if idx.Kind == "" {
+			c.failf(field, "missing edge kind")
+		}
+		c.count(field+".count", idx.Count)
+		c.pageKey(field+".page_key", idx.PageKey, pagekey.CrossReferencesPage, c.ticket, idx.Kind)
+	}
+	if idx := cr.PageSearchIndex; idx != nil {
+		pages := len(cr.PageIndex)
+		postings := []struct {
+			name string
+			ps   *srvpb.PagedCrossReferences_PageSearchIndex_Postings
+		}{
+			{"by_corpus", idx.ByCorpus},
+			{"by_root", idx.ByRoot},
+			{"by_path", idx.ByPath},
+			{"by_resolved_path", idx.ByResolvedPath},
+		}
+		for _, p := range postings {
+			for tri, list := range p.ps.GetIndex() {
+				for _, i := range list.GetPageIndex() {
+					if int(i) >= pages {
+						c.failf(fmt.Sprintf("page_search_index.%s[%d]", p.name, tri), "page index %d out of range [0, %d)", i, pages)
+					}
+				}
+			}
+		}
+	}
+	return c.result()
+}
+
+// ValidateEdgePage returns a *CorruptionError if ep, as stored under the
+// given key, is malformed.  If key is empty, it is not checked.
+func ValidateEdgePage(ep *srvpb.EdgePage, key string) error {
+	c := &checker{entry: "EdgePage", ticket: ep.GetSourceTicket()}
+	if ep == nil {
+		c.failf("", "missing entry")
+		return c.result()
+	} else if c.ticket == "" {
+		c.failf("source_ticket", "missing source ticket")
+	}
+	c.page(key, ep.PageKey)
+	c.pageKey("page_key", ep.PageKey, pagekey.EdgePage, c.ticket, ep.GetEdgesGroup().GetKind())
+	c.edgeGroup("edges_group", ep.EdgesGroup)
+	return c.result()
+}
+
+// ValidateFileDecorationsPage returns a *CorruptionError if p, as stored under
+// the given key for the file with the given ticket, is malformed.  If key or
+// ticket is empty, it is not checked.  Anchor offsets are checked against text
+// only if it is non-empty.
+func ValidateFileDecorationsPage(p *srvpb.FileDecorationsPage, key, ticket string, text []byte) error {
+	c := &checker{entry: "FileDecorationsPage", ticket: ticket}
+	if p == nil {
+		c.failf("", "missing entry")
+		return c.result()
+	}
+	c.page(key, p.PageKey)
+	c.pageKey("page_key", p.PageKey, pagekey.DecorationsPage, c.ticket, "")
+	for i, d := range p.Decoration {
+		c.decoration(fmt.Sprintf("decoration[%d]", i), d, text)
+	}
+	return c.result()
+}
+
+// ValidateCrossReferencesPage returns a *CorruptionError if p, as stored under
+// the given key for the PagedCrossReferences of the given ticket, is
+// malformed.  If key is empty, it is not checked.  If ticket is empty, the
+// page's own source ticket is trusted.
+func ValidateCrossReferencesPage(p *srvpb.PagedCrossReferences_Page, key, ticket string) error {
+	if ticket == "" {
+		ticket = p.GetSourceTicket()
+	}
+	c := &checker{entry: "PagedCrossReferences_Page", ticket: ticket}
+	if p == nil {
+		c.failf("", "missing entry")
+		return c.result()
+	} else if p.SourceTicket == "" {
+		c.failf("source_ticket", "missing source ticket")
+	} else if p.SourceTicket != ticket {
+		c.failf("source_ticket", "page of %q", p.SourceTicket)
+	}
+	c.page(key, p.PageKey)
+	c.pageKey("page_key", p.PageKey, pagekey.CrossReferencesPage, c.ticket, p.GetGroup().GetKind())
+	c.crossReferencesGroup("group", p.Group)
+	return c.result()
+}
@@ -0,0 +1,366 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tablecheck
+
+import (
+	"errors"
+	"testing"
+
+	"kythe.io/kythe/go/serving/pagekey"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+const ticket = "kythe://corpus?lang=go#sig"
+
+// checkResult fails t unless err is nil, if field is empty, or is a
+// *CorruptionError for field.
+func checkResult(t *testing.T, name string, err error, field string) {
+	t.Helper()
+	if field == "" {
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		return
+	}
+	var ce *CorruptionError
+	if !errors.As(err, &ce) {
+		t.Errorf("%s: expected CorruptionError for %s; found %v", name, field, err)
+	} else if ce.Field != field {
+		t.Errorf("%s: expected corrupt field %s; found %v", name, field, err)
+	} else if code := status.Code(err); code != codes.DataLoss {
+		t.Errorf("%s: expected DataLoss code; found %v", name, code)
+	}
+}
+
+func TestValidatePagedEdgeSet(t *testing.T) {
+	valid := func() *srvpb.PagedEdgeSet {
+		return &srvpb.PagedEdgeSet{
+			Source: &srvpb.Node{Ticket: ticket},
+			Group: []*srvpb.EdgeGroup{{
+				Kind: "/kythe/edge/childof",
+				Edge: []*srvpb.EdgeGroup_Edge{{Target: &srvpb.Node{Ticket: "kythe:#parent"}}},
+			}},
+			PageIndex: []*srvpb.PageIndex{{
+				EdgeKind:  "/kythe/edge/param",
+				EdgeCount: 2,
+				PageKey:   pagekey.New(pagekey.EdgePage, ticket, "/kythe/edge/param", 0),
+			}},
+		}
+	}
+	tests := []struct {
+		name    string
+		corrupt func(*srvpb.PagedEdgeSet)
+		field   string
+	}{
+		{"valid", func(*srvpb.PagedEdgeSet) {}, ""},
+		{"no source", func(es *srvpb.PagedEdgeSet) { es.Source = nil }, "source"},
+		{"nil group", func(es *srvpb.PagedEdgeSet) { es.Group[0] = nil }, "group[0]"},
+		{"nil target", func(es *srvpb.PagedEdgeSet) { es.Group[0].Edge[0].Target = nil }, "group[0].edge[0]"},
+		{"negative count", func(es *srvpb.PagedEdgeSet) { es.PageIndex[0].EdgeCount = -1 }, "page_index[0].edge_count"},
+		{"foreign page", func(es *srvpb.PagedEdgeSet) {
+			es.PageIndex[0].PageKey = pagekey.New(pagekey.EdgePage, "kythe:#other", "/kythe/edge/param", 0)
+		}, "page_index[0].page_key"},
+		{"foreign group", func(es *srvpb.PagedEdgeSet) {
+			es.PageIndex[0].PageKey = pagekey.New(pagekey.EdgePage, ticket, "/kythe/edge/childof", 0)
+		}, "page_index[0].page_key"},
+	}
+	for _, test := range tests {
+		es := valid()
+		test.corrupt(es)
+		checkResult(t, test.name, ValidatePagedEdgeSet(es), test.field)
+	}
+	if err := ValidatePagedEdgeSet(nil); status.Code(err) != codes.DataLoss {
+		t.Errorf("Expected DataLoss error for nil PagedEdgeSet; found %v", err)
+	}
+}
+
+func TestValidateFileDecorations(t *testing.T) {
+	valid := func() *srvpb.FileDecorations {
+		return &srvpb.FileDecorations{
+			File: &srvpb.File{Ticket: ticket, Text: []byte("some text")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: ticket + "#a", StartOffset: 5, EndOffset: 9},
+				Kind:   "/kythe/edge/ref",
+				Target: "kythe:#text",
+			}},
+			DecorationPage: []*srvpb.FileDecorations_DecorationPage{{
+				PageKey:     pagekey.New(pagekey.DecorationsPage, ticket, "", 0),
+				StartOffset: 0,
+				EndOffset:   4,
+			}},
+		}
+	}
+	tests := []struct {
+		name    string
+		corrupt func(*srvpb.FileDecorations)
+		field   string
+	}{
+		{"valid", func(*srvpb.FileDecorations) {}, ""},
+		{"unstored text", func(fd *srvpb.FileDecorations) { fd.File.Text = nil }, ""},
+		{"no file", func(fd *srvpb.FileDecorations) { fd.File = nil }, "file"},
+		{"diagnosed missing file", func(fd *srvpb.FileDecorations) {
+			fd.File = nil
+			fd.Diagnostic = []*cpb.Diagnostic{{Message: "file too large"}}
+		}, ""},
+		{"no anchor", func(fd *srvpb.FileDecorations) { fd.Decoration[0].Anchor = nil }, "decoration[0]"},
+		{"inverted span", func(fd *srvpb.FileDecorations) { fd.Decoration[0].Anchor.EndOffset = 2 }, "decoration[0].anchor"},
+		{"span past text", func(fd *srvpb.FileDecorations) { fd.Decoration[0].Anchor.EndOffset = 99 }, "decoration[0].anchor"},
+		{"missing page key", func(fd *srvpb.FileDecorations) { fd.DecorationPage[0].PageKey = "" }, "decoration_page[0].page_key"},
+	}
+	for _, test := range tests {
+		fd := valid()
+		test.corrupt(fd)
+		checkResult(t, test.name, ValidateFileDecorations(fd), test.field)
+	}
+}
+
+func TestValidatePagedCrossReferences(t *testing.T) {
+	valid := func() *srvpb.PagedCrossReferences {
+		return &srvpb.PagedCrossReferences{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe:?path=f#a"}},
+			}, {
+				Kind: "#internal/ref/call/direct",
+				Caller: []*srvpb.PagedCrossReferences_Caller{{
+					Caller:   &srvpb.ExpandedAnchor{Ticket: "kythe:?path=f#caller"},
+					Callsite: []*srvpb.ExpandedAnchor{{Ticket: "kythe:?path=f#site"}},
+				}},
+			}},
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+				Kind:    "/kythe/edge/ref",
+				Count:   10,
+				PageKey: pagekey.New(pagekey.CrossReferencesPage, ticket, "/kythe/edge/ref", 0),
+			}},
+			PageSearchIndex: &srvpb.PagedCrossReferences_PageSearchIndex{
+				ByPath: &srvpb.PagedCrossReferences_PageSearchIndex_Postings{
+					Index: map[uint32]*srvpb.PagedCrossReferences_PageSearchIndex_Pages{
+						7: {PageIndex: []uint32{0}},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		corrupt func(*srvpb.PagedCrossReferences)
+		field   string
+	}{
+		{"valid", func(*srvpb.PagedCrossReferences) {}, ""},
+		{"no source", func(cr *srvpb.PagedCrossReferences) { cr.SourceTicket = "" }, "source_ticket"},
+		{"no kind", func(cr *srvpb.PagedCrossReferences) { cr.Group[0].Kind = "" }, "group[0]"},
+		{"nil anchor", func(cr *srvpb.PagedCrossReferences) { cr.Group[0].Anchor[0] = nil }, "group[0].anchor[0]"},
+		{"nil caller", func(cr *srvpb.PagedCrossReferences) { cr.Group[1].Caller[0].Caller = nil }, "group[1].caller[0]"},
+		{"nil callsite", func(cr *srvpb.PagedCrossReferences) {
+			cr.Group[1].Caller[0].Callsite[0] = nil
+		}, "group[1].caller[0].callsite[0]"},
+		{"page kind", func(cr *srvpb.PagedCrossReferences) {
+			cr.PageIndex[0].PageKey = pagekey.New(pagekey.EdgePage, ticket, "/kythe/edge/ref", 0)
+		}, "page_index[0].page_key"},
+		{"search index range", func(cr *srvpb.PagedCrossReferences) {
+			cr.PageSearchIndex.ByPath.Index[7].PageIndex = []uint32{1}
+		}, "page_search_index.by_path[7]"},
+	}
+	for _, test := range tests {
+		cr := valid()
+		test.corrupt(cr)
+		checkResult(t, test.name, ValidatePagedCrossReferences(cr), test.field)
+	}
+}
+
+func TestValidatePages(t *testing.T) {
+	const kind = "/kythe/edge/ref"
+	edgeKey := pagekey.New(pagekey.EdgePage, ticket, kind, 0)
+	validEdgePage := func() *srvpb.EdgePage {
+		return &srvpb.EdgePage{
+			PageKey:      edgeKey,
+			SourceTicket: ticket,
+			EdgesGroup: &srvpb.EdgeGroup{
+				Kind: kind,
+				Edge: []*srvpb.EdgeGroup_Edge{{Target: &srvpb.Node{Ticket: "kythe:#target"}}},
+			},
+		}
+	}
+	edgeTests := []struct {
+		name    string
+		corrupt func(*srvpb.EdgePage)
+		field   string
+	}{
+		{"valid", func(*srvpb.EdgePage) {}, ""},
+		{"no source", func(ep *srvpb.EdgePage) { ep.SourceTicket = "" }, "source_ticket"},
+		{"misplaced", func(ep *srvpb.EdgePage) { ep.PageKey = pagekey.New(pagekey.EdgePage, ticket, kind, 1) }, "page_key"},
+		{"no group", func(ep *srvpb.EdgePage) { ep.EdgesGroup = nil }, "edges_group"},
+		{"nil target", func(ep *srvpb.EdgePage) { ep.EdgesGroup.Edge[0].Target = nil }, "edges_group.edge[0]"},
+	}
+	for _, test := range edgeTests {
+		ep := validEdgePage()
+		test.corrupt(ep)
+		checkResult(t, "EdgePage "+test.name, ValidateEdgePage(ep, edgeKey), test.field)
+	}
+
+	decorKey := pagekey.New(pagekey.DecorationsPage, ticket, "", 0)
+	validDecorPage := func() *srvpb.FileDecorationsPage {
+		return &srvpb.FileDecorationsPage{
+			PageKey: decorKey,
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{StartOffset: 1, EndOffset: 3},
+				Kind:   kind,
+				Target: "kythe:#target",
+			}},
+		}
+	}
+	decorTests := []struct {
+		name    string
+		corrupt func(*srvpb.FileDecorationsPage)
+		field   string
+	}{
+		{"valid", func(*srvpb.FileDecorationsPage) {}, ""},
+		{"misplaced", func(p *srvpb.FileDecorationsPage) { p.PageKey = "" }, "page_key"},
+		{"no anchor", func(p *srvpb.FileDecorationsPage) { p.Decoration[0].Anchor = nil }, "decoration[0]"},
+		{"crossed span", func(p *srvpb.FileDecorationsPage) { p.Decoration[0].Anchor.EndOffset = 0 }, "decoration[0].anchor"},
+	}
+	for _, test := range decorTests {
+		p := validDecorPage()
+		test.corrupt(p)
+		checkResult(t, "FileDecorationsPage "+test.name, ValidateFileDecorationsPage(p, decorKey, ticket, nil), test.field)
+	}
+	checkResult(t, "FileDecorationsPage past EOF", ValidateFileDecorationsPage(validDecorPage(), decorKey, ticket, []byte("ab")), "decoration[0].anchor")
+	checkResult(t, "FileDecorationsPage of another file", ValidateFileDecorationsPage(validDecorPage(), decorKey, "kythe:#other", nil), "page_key")
+
+	xrefsKey := pagekey.New(pagekey.CrossReferencesPage, ticket, kind, 0)
+	validXRefsPage := func() *srvpb.PagedCrossReferences_Page {
+		return &srvpb.PagedCrossReferences_Page{
+			PageKey:      xrefsKey,
+			SourceTicket: ticket,
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   kind,
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe:#anchor"}},
+			},
+		}
+	}
+	xrefsTests := []struct {
+		name    string
+		corrupt func(*srvpb.PagedCrossReferences_Page)
+		field   string
+	}{
+		{"valid", func(*srvpb.PagedCrossReferences_Page) {}, ""},
+		{"no source", func(p *srvpb.PagedCrossReferences_Page) { p.SourceTicket = "" }, "source_ticket"},
+		{"foreign set", func(p *srvpb.PagedCrossReferences_Page) {
+			p.SourceTicket = "kythe:#other"
+			p.PageKey = pagekey.New(pagekey.CrossReferencesPage, p.SourceTicket, kind, 0)
+		}, "source_ticket"},
+		{"foreign group", func(p *srvpb.PagedCrossReferences_Page) { p.Group.Kind = "/kythe/edge/defines" }, "page_key"},
+		{"no anchor ticket", func(p *srvpb.PagedCrossReferences_Page) { p.Group.Anchor[0].Ticket = "" }, "group.anchor[0]"},
+	}
+	for _, test := range xrefsTests {
+		p := validXRefsPage()
+		test.corrupt(p)
+		checkResult(t, "PagedCrossReferences_Page "+test.name, ValidateCrossReferencesPage(p, xrefsKey, ticket), test.field)
+	}
+}
+
+// fuzzValidate fuzzes a validator with the wire encodings of the given seed
+// entries, checking that it never panics and reports only CorruptionErrors.
+func fuzzValidate[M proto.Message](f *testing.F, validate func(M) error, seeds ...M) {
+	for _, seed := range seeds {
+		rec, err := proto.Marshal(seed)
+		if err != nil {
+			f.Fatalf("Error marshaling seed: %v", err)
+		}
+		f.Add(rec)
+	}
+	f.Fuzz(func(t *testing.T, rec []byte) {
+		var m M
+		msg := m.ProtoReflect().New().Interface().(M)
+		if proto.Unmarshal(rec, msg) != nil {
+			return
+		}
+		var ce *CorruptionError
+		if err := validate(msg); err != nil && !errors.As(err, &ce) {
+			t.Errorf("Unexpected error type: %v", err)
+		}
+	})
+}
+
+func FuzzValidatePagedEdgeSet(f *testing.F) {
+	fuzzValidate(f, ValidatePagedEdgeSet, &srvpb.PagedEdgeSet{
+		Source: &srvpb.Node{Ticket: ticket},
+		Group:  []*srvpb.EdgeGroup{{Kind: "/kythe/edge/childof", Edge: []*srvpb.EdgeGroup_Edge{{Target: &srvpb.Node{Ticket: "kythe:#parent"}}}}},
+		PageIndex: []*srvpb.PageIndex{{
+			EdgeKind:  "/kythe/edge/param",
+			EdgeCount: 2,
+			PageKey:   pagekey.New(pagekey.EdgePage, ticket, "/kythe/edge/param", 0),
+		}},
+	})
+}
+
+func FuzzValidateFileDecorations(f *testing.F) {
+	fuzzValidate(f, ValidateFileDecorations, &srvpb.FileDecorations{
+		File: &srvpb.File{Ticket: ticket, Text: []byte("text")},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: &srvpb.RawAnchor{StartOffset: 0, EndOffset: 4},
+			Kind:   "/kythe/edge/ref",
+			Target: "kythe:#target",
+		}},
+	})
+}
+
+func FuzzValidatePagedCrossReferences(f *testing.F) {
+	fuzzValidate(f, ValidatePagedCrossReferences, &srvpb.PagedCrossReferences{
+		SourceTicket: ticket,
+		Group:        []*srvpb.PagedCrossReferences_Group{{Kind: "/kythe/edge/ref", Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe:#anchor"}}}},
+		PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+			Kind:    "/kythe/edge/ref",
+			Count:   1,
+			PageKey: pagekey.New(pagekey.CrossReferencesPage, ticket, "/kythe/edge/ref", 0),
+		}},
+	})
+}
+
+func FuzzValidateEdgePage(f *testing.F) {
+	fuzzValidate(f, func(ep *srvpb.EdgePage) error { return ValidateEdgePage(ep, "") }, &srvpb.EdgePage{
+		PageKey:      pagekey.New(pagekey.EdgePage, ticket, "/kythe/edge/ref", 0),
+		SourceTicket: ticket,
+		EdgesGroup:   &srvpb.EdgeGroup{Kind: "/kythe/edge/ref", Edge: []*srvpb.EdgeGroup_Edge{{Target: &srvpb.Node{Ticket: "kythe:#target"}}}},
+	})
+}
+
+func FuzzValidateFileDecorationsPage(f *testing.F) {
+	fuzzValidate(f, func(p *srvpb.FileDecorationsPage) error { return ValidateFileDecorationsPage(p, "", "", nil) }, &srvpb.FileDecorationsPage{
+		PageKey: pagekey.New(pagekey.DecorationsPage, ticket, "", 0),
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: &srvpb.RawAnchor{StartOffset: 0, EndOffset: 4},
+			Kind:   "/kythe/edge/ref",
+			Target: "kythe:#target",
+		}},
+	})
+}
+
+func FuzzValidateCrossReferencesPage(f *testing.F) {
+	fuzzValidate(f, func(p *srvpb.PagedCrossReferences_Page) error { return ValidateCrossReferencesPage(p, "", "") }, &srvpb.PagedCrossReferences_Page{
+		PageKey:      pagekey.New(pagekey.CrossReferencesPage, ticket, "/kythe/edge/ref", 0),
+		SourceTicket: ticket,
+		Group:        &srvpb.PagedCrossReferences_Group{Kind: "/kythe/edge/ref", Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe:#anchor"}}},
+	})
+}
@@ -0,0 +1,375 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tablediff compares two generations of a combined serving table
+// (e.g. as written by the serving pipeline on consecutive days).
+//
+// Columnar serving tables are not supported.
+package tablediff // import "kythe.io/kythe/go/serving/tablediff"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/keyvalue"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// Table names used to label each Difference.
+const (
+	EdgeSets            = "edgeSets"
+	EdgePages           = "edgePages"
+	TicketAliases       = "aliases"
+	TicketIndex         = "ticketIndex"
+	FactHashes          = "factHashes"
+	FactHashGeneration  = "factHashesGeneration"
+	Decorations         = "decor"
+	DecorationPages     = "decorPages"
+	DecorationsFilter   = "decorFilter"
+	CrossReferences     = "xrefs"
+	CrossReferencePages = "xrefPages"
+	Documentation       = "docs"
+	DisplayNames        = "names"
+	FileDigests         = "digests"
+	FileReferences      = "fileRefs"
+	FileRelations       = "fileRelations"
+	CallDegrees         = "callDegrees"
+	Members             = "members"
+	Rollups             = "rollups"
+	Outlines            = "outline"
+	Unknown             = "unknown"
+)
+
+// ticketIndexKeyPrefix is the key prefix of graph.TicketIndexKey.
+const ticketIndexKeyPrefix = "ticketIndex:"
+
+// membersKeyPrefix is the key prefix of xrefs.MembersKey.
+const membersKeyPrefix = "members:"
+
+// servingTables are the known tables of a combined serving table.
+var servingTables = []struct {
+	name   string
+	prefix string
+	msg    func() proto.Message
+}{
+	{EdgeSets, string(graph.EdgeSetKey("")), func() proto.Message { return new(srvpb.PagedEdgeSet) }},
+	{EdgePages, string(graph.EdgePageKey("")), func() proto.Message { return new(srvpb.EdgePage) }},
+	{TicketAliases, string(xrefs.TicketAliasKey("")), func() proto.Message { return new(srvpb.TicketAlias) }},
+	{TicketIndex, ticketIndexKeyPrefix, func() proto.Message { return new(srvpb.TicketIndexEntry) }},
+	{FactHashes, string(graph.FactHashesKey("")), func() proto.Message { return new(srvpb.NodeFactHashes) }},
+	{FactHashGeneration, string(graph.FactHashesGenerationKey), func() proto.Message { return new(srvpb.FactHashesGeneration) }},
+	{Decorations, string(xrefs.DecorationsKey("")), func() proto.Message { return new(srvpb.FileDecorations) }},
+	{DecorationPages, string(xrefs.DecorationsPageKey("")), func() proto.Message { return new(srvpb.FileDecorationsPage) }},
+	{DecorationsFilter, string(xrefs.DecorationsFilterKey), func() proto.Message { return new(srvpb.ExistenceFilter) }},
+	{CrossReferences, string(xrefs.CrossReferencesKey("")), func() proto.Message { return new(srvpb.PagedCrossReferences) }},
+	{CrossReferencePages, string(xrefs.CrossReferencesPageKey("")), func() proto.Message { return new(srvpb.PagedCrossReferences_Page) }},
+	{Documentation, string(xrefs.DocumentationKey("")), func() proto.Message { return new(srvpb.Document) }},
+	{DisplayNames, string(xrefs.DisplayNameKey("")), func() proto.Message { return new(srvpb.DisplayName) }},
+	{FileDigests, string(xrefs.FileDigestKey("")), func() proto.Message { return new(srvpb.FileDigest) }},
+	{FileReferences, string(xrefs.FileReferencesKey("")), func() proto.Message { return new(srvpb.FileReferences) }},
+	{FileRelations, string(xrefs.FileRelationsKey("")), func() proto.Message { return new(srvpb.FileRelations) }},
+	{CallDegrees, string(xrefs.CallDegreesKey("")), func() proto.Message { return new(srvpb.CallDegrees) }},
+	{Members, membersKeyPrefix, func() proto.Message { return new(srvpb.ContainerMembers) }},
+	{Rollups, string(xrefs.RollupsKey("")), func() proto.Message { return new(srvpb.ContainerRollup) }},
+	{Outlines, string(xrefs.OutlineKey("")), func() proto.Message { return new(srvpb.FileOutline) }},
+}
+
+// Kind is the kind of a Difference.
+type Kind int
+
+// Kinds of Differences.
+const (
+	Added Kind = iota
+	Removed
+	Changed
+)
+
+// String implements the fmt.Stringer interface.
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// A Difference is a single key whose value differs between two serving
+// tables.
+type Difference struct {
+	// Table is the name of the table containing the key (e.g. EdgeSets or
+	// CrossReferences).
+	Table string
+
+	// Key is the table key without its table prefix (e.g. a node ticket).
+	Key string
+
+	Kind Kind
+
+	// Old and New are the key's decoded values in the old and new tables, or
+	// nil if the key is absent or its Table is Unknown.
+	Old, New proto.Message
+
+	// XRefCountDelta is the change in the number of cross-references (anchors,
+	// callers, and related nodes) of a CrossReferences key.
+	XRefCountDelta int
+}
+
+// Options control the behavior of Diff.
+type Options struct {
+	// Tables restricts the comparison to the named tables.  If empty, all
+	// tables are compared.
+	Tables []string
+}
+
+// TableStats summarizes the differences within a single table.
+type TableStats struct {
+	Added, Removed, Changed, Unchanged int
+}
+
+// Stats summarizes the differences between two serving tables.
+type Stats struct {
+	// Tables holds the TableStats for each compared table with at least one
+	// key, keyed by table name.
+	Tables map[string]*TableStats
+
+	// NodesAdded and NodesRemoved count the EdgeSets keys only present in the
+	// new and old tables, respectively.
+	NodesAdded, NodesRemoved int
+
+	// XRefCountDelta is the total change in the number of cross-references.
+	XRefCountDelta int
+}
+
+// Diff compares the combined serving tables oldTable and newTable, calling f
+// (if non-nil), in key order, with each key whose value differs.  If f returns
+// an error, the comparison stops and the error is returned.  Values are
+// compared after decoding, so differing encodings of equal messages are not
+// reported.
+func Diff(ctx context.Context, oldTable, newTable keyvalue.DB, opts *Options, f func(*Difference) error) (*Stats, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	tables := make(map[string]bool, len(opts.Tables))
+	for _, t := range opts.Tables {
+		tables[t] = true
+	}
+
+	oldIter, err := oldTable.ScanPrefix(ctx, nil, &keyvalue.Options{LargeRead: true})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning old table: %v", err)
+	}
+	defer oldIter.Close()
+	newIter, err := newTable.ScanPrefix(ctx, nil, &keyvalue.Options{LargeRead: true})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning new table: %v", err)
+	}
+	defer newIter.Close()
+
+	stats := &Stats{Tables: make(map[string]*TableStats)}
+	oldEntry, err := nextEntry(oldIter)
+	if err != nil {
+		return nil, fmt.Errorf("error reading old table: %v", err)
+	}
+	newEntry, err := nextEntry(newIter)
+	if err != nil {
+		return nil, fmt.Errorf("error reading new table: %v", err)
+	}
+	for oldEntry != nil || newEntry != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var key []byte
+		var oldVal, newVal []byte
+		switch c := compareEntries(oldEntry, newEntry); {
+		case c < 0:
+			key, oldVal = oldEntry.key, oldEntry.val
+		case c > 0:
+			key, newVal = newEntry.key, newEntry.val
+		default:
+			key, oldVal, newVal = oldEntry.key, oldEntry.val, newEntry.val
+		}
+
+		name, prefix, msg := lookupTable(key)
+		if len(tables) == 0 || tables[name] {
+			d, err := diffEntry(name, strings.TrimPrefix(string(key), prefix), msg, oldVal, newVal)
+			if err != nil {
+				return nil, err
+			}
+			stats.add(name, d)
+			if d != nil && f != nil {
+				if err := f(d); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if oldVal != nil {
+			if oldEntry, err = nextEntry(oldIter); err != nil {
+				return nil, fmt.Errorf("error reading old table: %v", err)
+			}
+		}
+		if newVal != nil {
+			if newEntry, err = nextEntry(newIter); err != nil {
+				return nil, fmt.Errorf("error reading new table: %v", err)
+			}
+		}
+	}
+	return stats, nil
+}
+
+type entry struct{ key, val []byte }
+
+// nextEntry returns the next entry of iter or nil at its end.
+func nextEntry(iter keyvalue.Iterator) (*entry, error) {
+	key, val, err := iter.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		val = []byte{}
+	}
+	return &entry{key, val}, nil
+}
+
+// compareEntries orders entries by key, with nil entries last.
+func compareEntries(a, b *entry) int {
+	switch {
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	default:
+		return bytes.Compare(a.key, b.key)
+	}
+}
+
+// lookupTable returns the name, key prefix, and message constructor of the
+// table containing key.
+func lookupTable(key []byte) (string, string, func() proto.Message) {
+	for _, t := range servingTables {
+		if bytes.HasPrefix(key, []byte(t.prefix)) {
+			return t.name, t.prefix, t.msg
+		}
+	}
+	return Unknown, "", nil
+}
+
+// diffEntry returns the Difference between the given old and new values of a
+// key, either of which may be nil if absent, or nil if they are equal.
+func diffEntry(table, key string, msg func() proto.Message, oldVal, newVal []byte) (*Difference, error) {
+	d := &Difference{Table: table, Key: key}
+	switch {
+	case oldVal == nil:
+		d.Kind = Added
+	case newVal == nil:
+		d.Kind = Removed
+	case bytes.Equal(oldVal, newVal):
+		return nil, nil
+	default:
+		d.Kind = Changed
+	}
+	if msg == nil {
+		return d, nil
+	}
+
+	if oldVal != nil {
+		d.Old = msg()
+		if err := proto.Unmarshal(oldVal, d.Old); err != nil {
+			return nil, fmt.Errorf("error decoding old %s value for %q: %v", table, key, err)
+		}
+	}
+	if newVal != nil {
+		d.New = msg()
+		if err := proto.Unmarshal(newVal, d.New); err != nil {
+			return nil, fmt.Errorf("error decoding new %s value for %q: %v", table, key, err)
+		}
+	}
+	if d.Kind == Changed && proto.Equal(d.Old, d.New) {
+		return nil, nil
+	}
+
+	if table == CrossReferences {
+		d.XRefCountDelta = xrefCount(d.New) - xrefCount(d.Old)
+	}
+	return d, nil
+}
+
+// xrefCount returns the number of cross-references in a PagedCrossReferences
+// message, including those in its pages.
+func xrefCount(msg proto.Message) int {
+	cr, ok := msg.(*srvpb.PagedCrossReferences)
+	if !ok {
+		return 0
+	}
+	var n int
+	for _, g := range cr.Group {
+		n += len(g.Anchor) + len(g.Caller) + len(g.RelatedNode)
+		for _, refs := range g.ScopedReference {
+			n += len(refs.Reference)
+		}
+	}
+	for _, idx := range cr.PageIndex {
+		n += int(idx.Count)
+	}
+	return n
+}
+
+// add records the given Difference, or an unchanged key if d is nil.
+func (s *Stats) add(table string, d *Difference) {
+	ts := s.Tables[table]
+	if ts == nil {
+		ts = &TableStats{}
+		s.Tables[table] = ts
+	}
+	if d == nil {
+		ts.Unchanged++
+		return
+	}
+	switch d.Kind {
+	case Added:
+		ts.Added++
+	case Removed:
+		ts.Removed++
+	case Changed:
+		ts.Changed++
+	}
+	if table == EdgeSets {
+		switch d.Kind {
+		case Added:
+			s.NodesAdded++
+		case Removed:
+			s.NodesRemoved++
+		}
+	}
+	s.XRefCountDelta += d.XRefCountDelta
+}
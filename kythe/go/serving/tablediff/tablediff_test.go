@@ -0,0 +1,99 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tablediff
+
+import (
+	"context"
+	"testing"
+
+	"kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/test/testutil"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+var ctx = context.Background()
+
+func edgeSet(ticket string) *srvpb.PagedEdgeSet {
+	return &srvpb.PagedEdgeSet{Source: &srvpb.Node{Ticket: ticket}}
+}
+
+func xrefSet(ticket string, anchors int, pageCount int32) *srvpb.PagedCrossReferences {
+	cr := &srvpb.PagedCrossReferences{SourceTicket: ticket}
+	grp := &srvpb.PagedCrossReferences_Group{Kind: "%/kythe/edge/ref"}
+	for i := 0; i < anchors; i++ {
+		grp.Anchor = append(grp.Anchor, &srvpb.ExpandedAnchor{Ticket: "kythe:#anchor"})
+	}
+	cr.Group = append(cr.Group, grp)
+	if pageCount > 0 {
+		cr.PageIndex = append(cr.PageIndex, &srvpb.PagedCrossReferences_PageIndex{PageKey: "page", Count: pageCount})
+	}
+	return cr
+}
+
+func TestDiff(t *testing.T) {
+	oldDB, newDB := inmemory.NewKeyValueDB(), inmemory.NewKeyValueDB()
+	oldTable, newTable := &table.KVProto{oldDB}, &table.KVProto{newDB}
+	put := func(p *table.KVProto, key []byte, msg proto.Message) {
+		testutil.Fatalf(t, "Put error: %v", p.Put(ctx, key, msg))
+	}
+
+	put(oldTable, graph.EdgeSetKey("kythe:#kept"), edgeSet("kythe:#kept"))
+	put(newTable, graph.EdgeSetKey("kythe:#kept"), edgeSet("kythe:#kept"))
+	put(oldTable, graph.EdgeSetKey("kythe:#removed"), edgeSet("kythe:#removed"))
+	put(newTable, graph.EdgeSetKey("kythe:#added"), edgeSet("kythe:#added"))
+	put(oldTable, xrefs.CrossReferencesKey("kythe:#kept"), xrefSet("kythe:#kept", 2, 0))
+	put(newTable, xrefs.CrossReferencesKey("kythe:#kept"), xrefSet("kythe:#kept", 1, 10))
+
+	var found []Difference
+	stats, err := Diff(ctx, oldDB, newDB, nil, func(d *Difference) error {
+		found = append(found, Difference{Table: d.Table, Key: d.Key, Kind: d.Kind, XRefCountDelta: d.XRefCountDelta})
+		return nil
+	})
+	testutil.Fatalf(t, "Diff error: %v", err)
+
+	expected := []Difference{
+		{Table: EdgeSets, Key: "kythe:#added", Kind: Added},
+		{Table: EdgeSets, Key: "kythe:#removed", Kind: Removed},
+		{Table: CrossReferences, Key: "kythe:#kept", Kind: Changed, XRefCountDelta: 9},
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Errorf("Unexpected differences: %v", err)
+	}
+	if err := testutil.DeepEqual(&Stats{
+		Tables: map[string]*TableStats{
+			EdgeSets:        {Added: 1, Removed: 1, Unchanged: 1},
+			CrossReferences: {Changed: 1},
+		},
+		NodesAdded:     1,
+		NodesRemoved:   1,
+		XRefCountDelta: 9,
+	}, stats); err != nil {
+		t.Errorf("Unexpected stats: %v", err)
+	}
+
+	stats, err = Diff(ctx, oldDB, newDB, &Options{Tables: []string{CrossReferences}}, nil)
+	testutil.Fatalf(t, "Diff error: %v", err)
+	if err := testutil.DeepEqual(map[string]*TableStats{CrossReferences: {Changed: 1}}, stats.Tables); err != nil {
+		t.Errorf("Unexpected stats for restricted tables: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tablescan enumerates the tickets keyed in a combined or split
+// serving table (e.g. to drive an exporter over every node with
+// cross-references).  Values are never decoded.
+//
+// Columnar serving tables are not supported.
+package tablescan // import "kythe.io/kythe/go/serving/tablescan"
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/keyvalue"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Names of the scannable tables.
+const (
+	CrossReferences = "xrefs"
+	Decorations     = "decor"
+	EdgeSets        = "edgeSets"
+)
+
+// tablePrefixes maps each scannable table to its combined table key prefix.
+var tablePrefixes = map[string]string{
+	CrossReferences: string(xrefs.CrossReferencesKey("")),
+	Decorations:     string(xrefs.DecorationsKey("")),
+	EdgeSets:        string(graph.EdgeSetKey("")),
+}
+
+// splitTables maps each scannable table to its split table database.
+var splitTables = map[string]func(*xrefs.SplitDBs) keyvalue.DB{
+	CrossReferences: func(s *xrefs.SplitDBs) keyvalue.DB { return s.CrossReferences },
+	Decorations:     func(s *xrefs.SplitDBs) keyvalue.DB { return s.Decorations },
+	EdgeSets:        func(s *xrefs.SplitDBs) keyvalue.DB { return s.Edges },
+}
+
+// splitKeyPrefix is the prefix of each key of a split table's scannable
+// tables, which are keyed by ticket alone.  No combined table key has it.
+const splitKeyPrefix = "kythe:"
+
+const (
+	// DefaultPageSize is the number of tickets returned for a Request without a
+	// PageSize.
+	DefaultPageSize = 1000
+
+	// MaxPageSize is the largest number of tickets returned for a Request.
+	MaxPageSize = 100000
+)
+
+// A Request selects a page of the tickets keyed in a single table.
+type Request struct {
+	// Table is the name of the table to scan (e.g. CrossReferences).
+	Table string
+
+	// Prefix restricts the scan to tickets with the given prefix (e.g.
+	// "kythe://corpus?lang=go").
+	Prefix string
+
+	// PageSize is the maximum number of tickets to return.  If <= 0,
+	// DefaultPageSize is used; it is capped at MaxPageSize.
+	PageSize int
+
+	// PageToken is the NextPageToken of the Reply to the previous page of the
+	// same Table and Prefix, if any.
+	PageToken string
+}
+
+// A Reply is a page of tickets, in key order.
+type Reply struct {
+	Tickets []string
+
+	// NextPageToken, if non-empty, is the PageToken of the next page.
+	NextPageToken string
+}
+
+// Scan returns the page of tickets keyed in the combined serving table db
+// selected by req.  It fails with FAILED_PRECONDITION if db is instead a table
+// of a split serving table (see ScanSplit).
+func Scan(ctx context.Context, db keyvalue.DB, req *Request) (*Reply, error) {
+	tablePrefix, ok := tablePrefixes[req.Table]
+	if !ok {
+		return nil, fmt.Errorf("unknown table: %q", req.Table)
+	}
+	reply, err := scan(ctx, db, tablePrefix, req)
+	if err != nil {
+		return nil, err
+	} else if len(reply.Tickets) == 0 && req.PageToken == "" {
+		// An empty table may be a split table's, whose keys lack the prefix.
+		if split, err := hasPrefix(ctx, db, splitKeyPrefix); err != nil {
+			return nil, err
+		} else if split {
+			return nil, status.Error(codes.FailedPrecondition, "split serving table cannot be scanned as a combined table")
+		}
+	}
+	return reply, nil
+}
+
+// ScanSplit returns the page of tickets keyed in the split serving table dbs
+// (e.g. as returned by api.SplitTableDir.DBs) selected by req.  It fails with
+// NOT_FOUND if dbs lacks the selected table.
+func ScanSplit(ctx context.Context, dbs *xrefs.SplitDBs, req *Request) (*Reply, error) {
+	table, ok := splitTables[req.Table]
+	if !ok {
+		return nil, fmt.Errorf("unknown table: %q", req.Table)
+	}
+	db := table(dbs)
+	if db == nil {
+		return nil, status.Errorf(codes.NotFound, "split serving table has no %q table", req.Table)
+	}
+	return scan(ctx, db, "", req)
+}
+
+// hasPrefix reports whether db has any key with the given prefix.
+func hasPrefix(ctx context.Context, db keyvalue.DB, prefix string) (bool, error) {
+	it, err := db.ScanPrefix(ctx, []byte(prefix), nil)
+	if err != nil {
+		return false, err
+	}
+	defer it.Close()
+	if _, _, err := it.Next(); err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// scan returns the page of tickets selected by req keyed in db with the given
+// table prefix.
+func scan(ctx context.Context, db keyvalue.DB, tablePrefix string, req *Request) (*Reply, error) {
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	} else if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	var last string
+	if req.PageToken != "" {
+		rec, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+		if err != nil {
+			return nil, fmt.Errorf("invalid page token: %v", err)
+		} else if last = string(rec); !strings.HasPrefix(last, req.Prefix) {
+			return nil, errors.New("invalid page token: not within prefix")
+		}
+	}
+
+	it, err := db.ScanPrefix(ctx, []byte(tablePrefix+req.Prefix), &keyvalue.Options{LargeRead: true})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	reply := &Reply{}
+	if last != "" {
+		// Seek just past the previous page's last ticket.
+		if err := it.Seek([]byte(tablePrefix + last + "\x00")); err == io.EOF {
+			return reply, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	for {
+		key, _, err := it.Next()
+		if err == io.EOF {
+			return reply, nil
+		} else if err != nil {
+			return nil, err
+		} else if len(reply.Tickets) == pageSize {
+			reply.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(reply.Tickets[pageSize-1]))
+			return reply, nil
+		}
+		reply.Tickets = append(reply.Tickets, strings.TrimPrefix(string(key), tablePrefix))
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tablescan
+
+import (
+	"context"
+	"testing"
+
+	"kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/test/testutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ctx = context.Background()
+
+func TestScan(t *testing.T) {
+	db := inmemory.NewKeyValueDB()
+	wr, err := db.Writer(ctx)
+	testutil.Fatalf(t, "Writer error: %v", err)
+	for _, key := range [][]byte{
+		xrefs.CrossReferencesKey("kythe://a#1"),
+		xrefs.CrossReferencesKey("kythe://a#2"),
+		xrefs.CrossReferencesKey("kythe://a#3"),
+		xrefs.CrossReferencesKey("kythe://b#1"),
+		xrefs.CrossReferencesPageKey("page"),
+		xrefs.DecorationsKey("kythe://a?path=file"),
+		graph.EdgeSetKey("kythe://a#1"),
+	} {
+		testutil.Fatalf(t, "Write error: %v", wr.Write(key, []byte("undecoded")))
+	}
+	testutil.Fatalf(t, "Close error: %v", wr.Close())
+
+	scanAll := func(req *Request) [][]string {
+		var pages [][]string
+		for {
+			reply, err := Scan(ctx, db, req)
+			testutil.Fatalf(t, "Scan error: %v", err)
+			pages = append(pages, reply.Tickets)
+			if reply.NextPageToken == "" {
+				return pages
+			} else if len(pages) > 10 {
+				t.Fatalf("Too many pages: %v", pages)
+			}
+			req.PageToken = reply.NextPageToken
+		}
+	}
+
+	tests := []struct {
+		req      *Request
+		expected [][]string
+	}{
+		{&Request{Table: CrossReferences}, [][]string{{"kythe://a#1", "kythe://a#2", "kythe://a#3", "kythe://b#1"}}},
+		{&Request{Table: CrossReferences, PageSize: 2}, [][]string{{"kythe://a#1", "kythe://a#2"}, {"kythe://a#3", "kythe://b#1"}}},
+		{&Request{Table: CrossReferences, Prefix: "kythe://a", PageSize: 2}, [][]string{{"kythe://a#1", "kythe://a#2"}, {"kythe://a#3"}}},
+		{&Request{Table: CrossReferences, Prefix: "kythe://c"}, [][]string{nil}},
+		{&Request{Table: Decorations}, [][]string{{"kythe://a?path=file"}}},
+		{&Request{Table: EdgeSets}, [][]string{{"kythe://a#1"}}},
+	}
+	for _, test := range tests {
+		if err := testutil.DeepEqual(test.expected, scanAll(test.req)); err != nil {
+			t.Errorf("Scan(%+v): %v", test.req, err)
+		}
+	}
+
+	if _, err := Scan(ctx, db, &Request{Table: "unknown"}); err == nil {
+		t.Error("Expected error for unknown table")
+	}
+	reply, err := Scan(ctx, db, &Request{Table: CrossReferences, PageSize: 1})
+	testutil.Fatalf(t, "Scan error: %v", err)
+	if _, err := Scan(ctx, db, &Request{Table: CrossReferences, Prefix: "kythe://b", PageToken: reply.NextPageToken}); err == nil {
+		t.Error("Expected error for page token outside of prefix")
+	}
+}
+
+func TestScanSplit(t *testing.T) {
+	xrefsDB := inmemory.NewKeyValueDB()
+	wr, err := xrefsDB.Writer(ctx)
+	testutil.Fatalf(t, "Writer error: %v", err)
+	for _, key := range []string{"kythe://a#1", "kythe://a#2", "kythe://b#1"} {
+		testutil.Fatalf(t, "Write error: %v", wr.Write([]byte(key), []byte("undecoded")))
+	}
+	testutil.Fatalf(t, "Close error: %v", wr.Close())
+	dbs := &xrefs.SplitDBs{CrossReferences: xrefsDB, Decorations: inmemory.NewKeyValueDB()}
+
+	reply, err := ScanSplit(ctx, dbs, &Request{Table: CrossReferences, Prefix: "kythe://a"})
+	testutil.Fatalf(t, "ScanSplit error: %v", err)
+	if err := testutil.DeepEqual([]string{"kythe://a#1", "kythe://a#2"}, reply.Tickets); err != nil {
+		t.Errorf("ScanSplit: %v", err)
+	}
+	reply, err = ScanSplit(ctx, dbs, &Request{Table: Decorations})
+	testutil.Fatalf(t, "ScanSplit error: %v", err)
+	if len(reply.Tickets) != 0 {
+		t.Errorf("Expected no decorations; found %v", reply.Tickets)
+	}
+	if _, err := ScanSplit(ctx, dbs, &Request{Table: EdgeSets}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error for missing table; found %v", err)
+	}
+
+	// A split table's database is not mistaken for an empty combined table.
+	if _, err := Scan(ctx, xrefsDB, &Request{Table: CrossReferences}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition error scanning split table; found %v", err)
+	}
+}
@@ -19,26 +19,39 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
+	"errors"
+	"expvar"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"kythe.io/kythe/go/services/filetree"
 	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/web"
 	"kythe.io/kythe/go/services/xrefs"
 	ftsrv "kythe.io/kythe/go/serving/filetree"
 	gsrv "kythe.io/kythe/go/serving/graph"
 	"kythe.io/kythe/go/serving/identifiers"
+	"kythe.io/kythe/go/serving/manifest"
+	"kythe.io/kythe/go/serving/remap"
 	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/storage/keyvalue"
 	"kythe.io/kythe/go/storage/leveldb"
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/util/flagutil"
 
 	"golang.org/x/net/http2"
 
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+
 	_ "kythe.io/kythe/go/services/graphstore/proxy"
 )
 
@@ -53,10 +66,28 @@ var (
 	tlsCertFile      = flag.String("tls_cert_file", "", "Path to file with concatenation of TLS certificates")
 	tlsKeyFile       = flag.String("tls_key_file", "", "Path to file with TLS private key")
 
-	maxTicketsPerRequest = flag.Int("max_tickets_per_request", 20, "Maximum number of tickets allowed per request")
+	corpusRemap flagutil.StringList
+
+	maxTicketsPerRequest      = flag.Int("max_tickets_per_request", 20, "Maximum number of tickets allowed per request")
+	maxCountTicketsPerRequest = flag.Int("max_count_tickets_per_request", 1000, "Maximum number of tickets allowed per count_only edges request")
+
+	paranoid = flag.Bool("paranoid", false, "Validate each serving table entry as it is read, failing requests for corrupt entries with a DATA_LOSS error")
+
+	edgeFallback = flag.Bool("edge_fallback", false, "Serve CrossReferences requests setting edge_fallback by reconstructing the anchors of tickets missing from the xrefs table from their edges in the serving table (a slow path)")
+
+	negativeCacheSize = flag.Int("negative_cache_size", 0, "If > 0, the number of missing xrefs table lookups remembered so that repeated requests for missing tickets do not read the table")
+	negativeCacheTTL  = flag.Duration("negative_cache_ttl", time.Minute, "How long a missing xrefs table lookup is remembered (see --negative_cache_size)")
+
+	adminTokenFile = flag.String("admin_token_file", "", "If set, path of a file holding a secret token: the administrative interface (see the Admin type of the xrefs serving package) is served at /admin to POST requests with an \"Authorization: Bearer <token>\" header.  Its swap command replaces the served xrefs and graph tables, which requires serving tables with a build ID; the filetree and identifiers services and the xrefs table's own endpoints keep serving --serving_table")
+
+	warmingManifest         = flag.String("warming_manifest", "", "If set, path of a JSON manifest of the hottest xrefs keys: if it exists at startup, its keys are read to warm the serving table's caches, and it is rewritten every --warming_manifest_interval with the keys most requested since startup; tables swapped in by the administrative interface are warmed with those keys")
+	warmingManifestInterval = flag.Duration("warming_manifest_interval", 10*time.Minute, "How often --warming_manifest is rewritten")
+	warmingTopN             = flag.Int("warming_top_n", 1000, "Number of the hottest keys of each xrefs method recorded in --warming_manifest")
+	warmingBudget           = flag.Duration("warming_budget", 30*time.Second, "Maximum time spent warming the caches of each serving table opened with --warming_manifest")
 )
 
 func init() {
+	flag.Var(&corpusRemap, "corpus_remap", "Serve the stored corpus of each given served=stored pair under its served name, rewriting request and reply tickets (may be repeated)")
 	flag.Usage = flagutil.SimpleUsage("Exposes HTTP interfaces for the xrefs and filetree services",
 		"(--graphstore spec | --serving_table path) [--listen addr] [--public_resources dir]")
 }
@@ -81,26 +112,87 @@ func main() {
 	)
 
 	ctx := context.Background()
-	db, err := leveldb.Open(*servingTable, &leveldb.Options{MustExist: true})
+	if *negativeCacheSize > 0 {
+		negativeCache = xsrv.NewNegativeCache(&xsrv.NegativeCacheOptions{
+			Size: *negativeCacheSize,
+			TTL:  *negativeCacheTTL,
+		})
+		// The cache's hit rates are served with the process's other
+		// variables at /debug/vars.
+		expvar.Publish("negative_cache", expvar.Func(func() interface{} { return negativeCache.Stats() }))
+	}
+	if *warmingManifest != "" {
+		hotKeys = xsrv.NewHotKeys(0)
+	}
+	st, err := openTable(ctx, *servingTable)
 	if err != nil {
-		log.Fatalf("Error opening db at %q: %v", *servingTable, err)
+		log.Fatal(err)
 	}
+	db := st.db
 	defer db.Close(ctx)
-	xs = xsrv.NewService(ctx, db)
-	gs = gsrv.NewService(ctx, db)
+	xs, gs = st.Service, st.graph
+	if hotKeys != nil {
+		if err := warmCaches(ctx, st.Service.(*xsrv.Table), *warmingManifest); err != nil {
+			log.Fatal(err)
+		}
+		go saveWarmingManifests(hotKeys, *warmingManifest)
+	}
+	xt, _ := xs.(*xsrv.Table) // nil for columnar serving tables
+	md, err := manifest.ReadMetadata(ctx, db)
+	if err == manifest.ErrNoMetadata {
+		log.Printf("WARNING: serving table at %q has no metadata", *servingTable)
+		md = &srvpb.TableMetadata{}
+	} else if err != nil {
+		log.Fatalf("Error reading metadata of %q: %v", *servingTable, err)
+	}
+	// Requests selecting a build other than the table's fail with NOT_FOUND.
+	// Tables with a build ID are served as a generation so that clients may
+	// watch for their reloads.
+	var gens *xrefs.Generations
+	if md.BuildId == "" {
+		log.Printf("WARNING: serving table at %q has no build ID; /decorations/watch is disabled", *servingTable)
+		xs = xrefs.Provenance{BuildTime: md.BuildTime, Service: xs}
+		gs = graph.Provenance{BuildTime: md.BuildTime, Service: gs}
+	} else {
+		gens = &xrefs.Generations{}
+		if err := gens.Add(xrefs.Provenance{
+			BuildID:   md.BuildId,
+			BuildTime: md.BuildTime,
+			Service:   st,
+		}); err != nil {
+			log.Fatalf("Error serving table at %q: %v", *servingTable, err)
+		}
+		xs, gs = gens, gens
+	}
+	var admin *xsrv.Admin
+	if *adminTokenFile != "" {
+		admin = newAdmin(*adminTokenFile, gens, st)
+		xs = admin.Middleware()(xs)
+	}
+	tbl := &table.KVProto{db}
+	ft = &ftsrv.Table{Proto: tbl, PrefixedKeys: true}
+	it = &identifiers.Table{tbl}
+	if len(corpusRemap) > 0 {
+		corpora, err := remap.Parse(corpusRemap)
+		if err != nil {
+			flagutil.UsageErrorf("invalid --corpus_remap: %v", err)
+		}
+		xs = remap.XRefs{Corpora: corpora, Service: xs}
+		gs = remap.Graph{Corpora: corpora, Service: gs}
+		ft = remap.FileTree{Corpora: corpora, Service: ft}
+		it = remap.Identifiers{Corpora: corpora, Service: it}
+	}
 	if *maxTicketsPerRequest > 0 {
 		xs = xrefs.BoundedRequests{
 			Service:    xs,
 			MaxTickets: *maxTicketsPerRequest,
 		}
 		gs = graph.BoundedRequests{
-			Service:    gs,
-			MaxTickets: *maxTicketsPerRequest,
+			Service:         gs,
+			MaxTickets:      *maxTicketsPerRequest,
+			MaxCountTickets: *maxCountTicketsPerRequest,
 		}
 	}
-	tbl := &table.KVProto{db}
-	ft = &ftsrv.Table{Proto: tbl, PrefixedKeys: true}
-	it = &identifiers.Table{tbl}
 
 	if *httpListeningAddr != "" || *tlsListeningAddr != "" {
 		apiMux := http.NewServeMux()
@@ -112,9 +204,25 @@ func main() {
 		})
 
 		xrefs.RegisterHTTPHandlers(ctx, xs, apiMux)
+		if gens != nil {
+			xrefs.RegisterWatchHTTPHandlers(ctx, &xrefs.DecorationsWatch{Generations: gens, Service: xs}, apiMux)
+		}
+		if xt != nil && len(corpusRemap) == 0 {
+			// The Table's own endpoints do not remap corpora, so they are
+			// only served for unmapped tables.
+			xsrv.RegisterHTTPHandlers(ctx, xt, apiMux)
+		}
 		graph.RegisterHTTPHandlers(ctx, gs, apiMux)
 		identifiers.RegisterHTTPHandlers(ctx, it, apiMux)
 		filetree.RegisterHTTPHandlers(ctx, ft, apiMux)
+		if admin != nil {
+			apiMux.Handle("/admin", admin)
+		}
+		apiMux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+			if err := web.WriteResponse(w, r, md); err != nil {
+				log.Println(err)
+			}
+		})
 		if *publicResources != "" {
 			log.Println("Serving public resources at", *publicResources)
 			if s, err := os.Stat(*publicResources); err != nil {
@@ -137,6 +245,178 @@ func main() {
 	select {} // block forever
 }
 
+// negativeCache is shared by each opened serving table, if set.
+var negativeCache *xsrv.NegativeCache
+
+// hotKeys is sent the requests of each opened serving table, if set, along
+// with any other RequestLog of the table.
+var hotKeys *xsrv.HotKeys
+
+// An openedTable holds the services of an opened serving table, served
+// together as a generation.
+type openedTable struct {
+	xrefs.Service
+	graph graph.Service
+	db    keyvalue.DB
+}
+
+// Nodes implements part of the graph.Service interface.
+func (t *openedTable) Nodes(ctx context.Context, req *gpb.NodesRequest) (*gpb.NodesReply, error) {
+	return t.graph.Nodes(ctx, req)
+}
+
+// Edges implements part of the graph.Service interface.
+func (t *openedTable) Edges(ctx context.Context, req *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	return t.graph.Edges(ctx, req)
+}
+
+// openTable opens the serving table at path, configured by the server's
+// flags.
+func openTable(ctx context.Context, path string) (*openedTable, error) {
+	db, err := leveldb.Open(path, &leveldb.Options{MustExist: true})
+	if err != nil {
+		return nil, fmt.Errorf("error opening db at %q: %v", path, err)
+	}
+	t := &openedTable{
+		Service: xsrv.NewService(ctx, db),
+		graph:   gsrv.NewService(ctx, db),
+		db:      db,
+	}
+	xt, xok := t.Service.(*xsrv.Table)
+	gt, gok := t.graph.(*gsrv.Table)
+	var unsupported string
+	switch {
+	case *paranoid:
+		unsupported = "--paranoid"
+	case *edgeFallback:
+		unsupported = "--edge_fallback"
+	case negativeCache != nil:
+		unsupported = "--negative_cache_size"
+	case hotKeys != nil:
+		unsupported = "--warming_manifest"
+	}
+	if unsupported != "" && (!xok || !gok) {
+		db.Close(ctx)
+		return nil, fmt.Errorf("%s is unsupported for the columnar serving table at %q", unsupported, path)
+	}
+	if *paranoid {
+		xt.Paranoid, gt.Paranoid = true, true
+	}
+	if *edgeFallback {
+		xt.EdgeFallback = gt
+	}
+	if negativeCache != nil {
+		xt.NegativeCache = negativeCache
+	}
+	if hotKeys != nil {
+		xt.RequestLog = xsrv.MultiRequestLog(xt.RequestLog, hotKeys)
+	}
+	return t, nil
+}
+
+// newAdmin returns an Admin authorizing the requests bearing the token in the
+// file at tokenFile.  It swaps the generations of gens, which must not be nil,
+// and closes each retired table other than startup.
+func newAdmin(tokenFile string, gens *xrefs.Generations, startup *openedTable) *xsrv.Admin {
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		log.Fatalf("Error reading --admin_token_file: %v", err)
+	} else if token = bytes.TrimSpace(token); len(token) == 0 {
+		log.Fatalf("Empty --admin_token_file: %q", tokenFile)
+	} else if gens == nil {
+		log.Fatalf("--admin_token_file requires a serving table with a build ID")
+	}
+	expected := append([]byte("Bearer "), token...)
+	return &xsrv.Admin{
+		Authorize: func(r *http.Request) error {
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+				return errors.New("invalid admin token")
+			}
+			return nil
+		},
+		Generations: gens,
+		OpenTable: func(ctx context.Context, path string) (xrefs.Provenance, error) {
+			t, err := openTable(ctx, path)
+			if err != nil {
+				return xrefs.Provenance{}, err
+			}
+			md, err := manifest.ReadMetadata(ctx, t.db)
+			if err != nil {
+				t.db.Close(ctx)
+				return xrefs.Provenance{}, fmt.Errorf("error reading metadata of %q: %v", path, err)
+			}
+			return xrefs.Provenance{BuildID: md.BuildId, BuildTime: md.BuildTime, Service: t}, nil
+		},
+		// Retired tables are closed once drained, except for the startup table,
+		// which still serves the filetree and identifiers services.
+		Retire: func(s xrefs.Service) {
+			if t, ok := s.(*openedTable); ok && t != startup {
+				if err := t.db.Close(context.Background()); err != nil {
+					log.Printf("WARNING: error closing retired serving table: %v", err)
+				}
+			}
+		},
+		// Swapped in tables are warmed with the keys most requested of the
+		// tables they replace.
+		Warm: func(ctx context.Context, p xrefs.Provenance) {
+			if hotKeys == nil {
+				return
+			}
+			xt := p.Service.(*openedTable).Service.(*xsrv.Table)
+			stats := xt.Warm(ctx, hotKeys.Manifest(*warmingTopN), &xsrv.WarmingBudget{Timeout: *warmingBudget})
+			log.Printf("Warmed %d keys (%d missing, %d errors) of swapped in serving table in %s", stats.Keys, stats.Missing, stats.Errors, stats.Duration)
+		},
+		NegativeCache: negativeCache,
+	}
+}
+
+// warmCaches warms the caches of xt with the keys of the warming manifest at
+// path, if it exists.
+func warmCaches(ctx context.Context, xt *xsrv.Table, path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		log.Printf("No warming manifest at %q; starting cold", path)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error opening warming manifest: %v", err)
+	}
+	defer f.Close()
+	m, err := xsrv.ReadWarmingManifest(f)
+	if err != nil {
+		log.Printf("WARNING: ignoring warming manifest %q: %v", path, err)
+		return nil
+	}
+	stats := xt.Warm(ctx, m, &xsrv.WarmingBudget{Timeout: *warmingBudget})
+	log.Printf("Warmed %d keys (%d missing, %d errors) from %q in %s", stats.Keys, stats.Missing, stats.Errors, path, stats.Duration)
+	return nil
+}
+
+// saveWarmingManifests rewrites the warming manifest at path with the hottest
+// keys of hot every --warming_manifest_interval.
+func saveWarmingManifests(hot *xsrv.HotKeys, path string) {
+	for range time.Tick(*warmingManifestInterval) {
+		if err := saveWarmingManifest(hot.Manifest(*warmingTopN), path); err != nil {
+			log.Printf("WARNING: error saving warming manifest: %v", err)
+		}
+	}
+}
+
+// saveWarmingManifest atomically replaces the warming manifest at path with m.
+func saveWarmingManifest(m *xsrv.WarmingManifest, path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := m.Write(f); err != nil {
+		f.Close()
+		return err
+	} else if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
 func startHTTP() {
 	log.Printf("HTTP server listening on %q", *httpListeningAddr)
 	log.Fatal(http.ListenAndServe(*httpListeningAddr, nil))
@@ -28,10 +28,12 @@ import (
 	"github.com/apache/beam/sdks/go/pkg/beam/transforms/stats"
 	"kythe.io/kythe/go/platform/vfs"
 	"kythe.io/kythe/go/services/graphstore"
+	"kythe.io/kythe/go/serving/manifest"
 	"kythe.io/kythe/go/serving/pipeline"
 	"kythe.io/kythe/go/serving/pipeline/beamio"
 	"kythe.io/kythe/go/serving/xrefs"
 	"kythe.io/kythe/go/storage/gsutil"
+	"kythe.io/kythe/go/storage/keyvalue"
 	"kythe.io/kythe/go/storage/leveldb"
 	"kythe.io/kythe/go/storage/stream"
 	"kythe.io/kythe/go/util/flagutil"
@@ -69,6 +71,11 @@ var (
 	beamInternalSharding     flagutil.IntList
 	experimentalColumnarData = flag.Bool("experimental_beam_columnar_data", false, "Whether to emit columnar data from the Beam pipeline implementation")
 	compactTable             = flag.Bool("compact_table", false, "Whether to compact the output LevelDB after its creation")
+	writeManifest            = flag.Bool("write_manifest", false, "Whether to write a manifest of the output LevelDB's entries for verification by servers")
+	buildID                  = flag.String("build_id", "", "Build ID recorded in the output LevelDB's metadata and reported by servers in each reply (defaults to one derived from the build time)")
+	previousEntries          = flag.String("previous_entries", "", "In beam mode: path to the entries of the previous build (as for --entries) whose node fact hashes are stored in the output table so that it can serve the facts changed since that build; requires --previous_build_id")
+	previousBuildID          = flag.String("previous_build_id", "", "Build ID of the --previous_entries build")
+	blameFile                = flag.String("blame", "", "In beam mode: path to a delimited stream of kythe.proto.pipeline.FileBlame messages whose commit times are recorded on cross-reference anchors so that they can be filtered by age")
 )
 
 func init() {
@@ -87,6 +94,14 @@ func main() {
 		if err := runExperimentalBeamPipeline(ctx); err != nil {
 			log.Fatalf("Pipeline error: %v", err)
 		}
+		db, err := leveldb.Open(*tablePath, nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		finishTable(ctx, db)
+		if err := db.Close(ctx); err != nil {
+			log.Fatal(err)
+		}
 		if *compactTable {
 			if err := compactLevelDB(*tablePath); err != nil {
 				log.Fatalf("Error compacting LevelDB: %v", err)
@@ -101,6 +116,8 @@ func main() {
 		flagutil.UsageError("--graphstore and --entries are mutually exclusive")
 	} else if *tablePath == "" {
 		flagutil.UsageError("missing required --out flag")
+	} else if *previousEntries != "" {
+		flagutil.UsageError("--previous_entries requires --experimental_beam_pipeline")
 	}
 
 	db, err := leveldb.Open(*tablePath, nil)
@@ -138,6 +155,8 @@ func main() {
 		log.Fatal("FATAL ERROR: ", err)
 	}
 
+	finishTable(ctx, db)
+
 	if *compactTable {
 		if err := compactLevelDB(*tablePath); err != nil {
 			log.Fatalf("Error compacting LevelDB: %v", err)
@@ -145,6 +164,20 @@ func main() {
 	}
 }
 
+// finishTable writes the metadata of the completed table and, if requested,
+// its manifest.
+func finishTable(ctx context.Context, db keyvalue.DB) {
+	md := manifest.NewMetadata(*buildID, time.Now())
+	if err := manifest.WriteMetadata(ctx, db, md); err != nil {
+		log.Fatalf("Error writing metadata: %v", err)
+	}
+	if *writeManifest {
+		if err := manifest.Write(ctx, db); err != nil {
+			log.Fatalf("Error writing manifest: %v", err)
+		}
+	}
+}
+
 func compactLevelDB(path string) error {
 	defer func(start time.Time) { log.Printf("Compaction completed in %s", time.Since(start)) }(time.Now())
 	return leveldb.CompactRange(*tablePath, nil)
@@ -161,6 +194,12 @@ func runExperimentalBeamPipeline(ctx context.Context) error {
 		return errors.New("--entries file path required")
 	} else if *tablePath == "" {
 		return errors.New("--out table path required")
+	} else if (*previousEntries == "") != (*previousBuildID == "") {
+		return errors.New("--previous_entries and --previous_build_id must be given together")
+	} else if *previousEntries != "" && *experimentalColumnarData {
+		return errors.New("--previous_entries not supported with --experimental_beam_columnar_data")
+	} else if *blameFile != "" && *experimentalColumnarData {
+		return errors.New("--blame not supported with --experimental_beam_columnar_data")
 	}
 
 	p, s := beam.NewPipelineWithRoot()
@@ -169,6 +208,9 @@ func runExperimentalBeamPipeline(ctx context.Context) error {
 		log.Fatal("Error reading entries: ", err)
 	}
 	k := pipeline.FromEntries(s, entries)
+	if *blameFile != "" {
+		k = k.WithBlame(beamio.ReadFileBlames(s.Scope("Blame"), *blameFile))
+	}
 	shards := *beamShards
 	if shards <= 0 {
 		// TODO(schroederc): better determine number of shards
@@ -188,22 +230,51 @@ func runExperimentalBeamPipeline(ctx context.Context) error {
 			createColumnarMetadata(s),
 			k.SplitCrossReferences(),
 			k.SplitDecorations(),
+			k.DecorationsFilter(),
 			k.CorpusRoots(),
 			k.Directories(),
 			k.Documents(),
+			k.FileDigests(),
+			k.FileReferences(),
+			k.FileRelations(),
+			k.CallDegrees(),
+			k.Members(),
+			k.Rollups(),
+			k.Outlines(),
 			k.SplitEdges(),
+			k.Kinds(),
 		)
 	} else {
 		edgeSets, edgePages := k.Edges()
 		xrefSets, xrefPages := k.CrossReferences()
-		beamio.WriteLevelDB(s, *tablePath, opts,
+		tables := []beam.PCollection{
 			k.CorpusRoots(),
 			k.Decorations(),
+			k.DecorationsFilter(),
 			k.Directories(),
+			k.DisplayNames(),
 			k.Documents(),
+			k.FileDigests(),
+			k.FileReferences(),
+			k.FileRelations(),
+			k.CallDegrees(),
+			k.Members(),
+			k.Rollups(),
+			k.Outlines(),
+			k.Kinds(),
 			xrefSets, xrefPages,
 			edgeSets, edgePages,
-		)
+		}
+		if *previousEntries != "" {
+			prev := s.Scope("Previous")
+			prevEntries, err := beamio.ReadEntries(ctx, prev, *previousEntries)
+			if err != nil {
+				log.Fatal("Error reading previous entries: ", err)
+			}
+			hashes, generation := pipeline.FromEntries(prev, prevEntries).FactHashes(*previousBuildID)
+			tables = append(tables, hashes, generation)
+		}
+		beamio.WriteLevelDB(s, *tablePath, opts, tables...)
 	}
 
 	return beamx.Run(ctx, p)
@@ -0,0 +1,354 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package update applies the serving data of a single reindexed file to a
+// writable combined serving table without rebuilding the whole table.
+//
+// Updates only rewrite the entries they replace; pages made unreachable by an
+// update (e.g. trailing EdgePages of a shrunken PagedEdgeSet) are left in the
+// table until its next full rebuild.
+package update // import "kythe.io/kythe/go/serving/update"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	gsrv "kythe.io/kythe/go/serving/graph"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/serving/xrefs/assemble"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/span"
+
+	"bitbucket.org/creachadair/stringset"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// A FileUpdate is the freshly indexed serving data of a single file.
+type FileUpdate struct {
+	// Decorations replace the file's previous FileDecorations.  The file's
+	// FileReferences and, if it has one, its FileOutline are recomputed from
+	// them.  Their anchors' fingerprints
+	// are assigned by UpdateFile, which sorts the decorations by offset.
+	Decorations *srvpb.FileDecorations
+
+	// EdgeSets replace the PagedEdgeSets of the nodes whose edges changed.
+	EdgeSets []*srvpb.PagedEdgeSet
+
+	// CrossReferences replace, for each node referenced from the file before
+	// or after it was reindexed, the node's cross-references located in the
+	// file.
+	CrossReferences []*CrossReferencesDelta
+}
+
+// A CrossReferencesDelta is the set of cross-references of a single node
+// located in an updated file.
+type CrossReferencesDelta struct {
+	// Ticket is the ticket of the referenced node.
+	Ticket string
+
+	// Group holds the node's anchors, callers, and scoped references within the
+	// file.  Groups are merged into the node's existing groups of the same
+	// kind and build configuration.  An empty delta removes the node's
+	// cross-references in the file.
+	Group []*srvpb.PagedCrossReferences_Group
+}
+
+// An Updater applies FileUpdates to a writable combined serving table.  Its
+// methods are safe for concurrent use; updates are applied one at a time.
+type Updater struct {
+	// XRefs and Graph, if non-nil, are the Tables serving the combined table.
+	// Their cached entries made stale by an update are invalidated.
+	XRefs *xsrv.Table
+	Graph *gsrv.Table
+
+	mu    sync.Mutex
+	tbl   table.Proto
+	xrefs *xsrv.Writer
+	graph *gsrv.Writer
+}
+
+// NewUpdater returns an Updater for the combined serving table t, as read by
+// xrefs.NewCombinedTable and graph.NewCombinedTable.
+func NewUpdater(t table.Proto) *Updater {
+	return &Updater{
+		tbl:   t,
+		xrefs: xsrv.NewCombinedWriter(t),
+		graph: gsrv.NewCombinedWriter(t),
+	}
+}
+
+// UpdateFile writes the given FileUpdate to the table.  Readers may observe a
+// partially applied update.
+func (u *Updater) UpdateFile(ctx context.Context, up *FileUpdate) error {
+	file, err := kytheuri.Fix(up.Decorations.GetFile().GetTicket())
+	if err != nil {
+		return fmt.Errorf("invalid file ticket %q: %v", up.Decorations.GetFile().GetTicket(), err)
+	}
+
+	for _, d := range up.Decorations.Decoration {
+		if d.Anchor == nil {
+			return fmt.Errorf("decoration of %q in %q has no anchor", d.Target, file)
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, d := range up.CrossReferences {
+		if err := u.updateCrossReferences(ctx, file, d); err != nil {
+			return err
+		}
+	}
+
+	var stalePages []string
+	for _, pes := range up.EdgeSets {
+		keys, err := u.edgePageKeys(ctx, pes.GetSource().GetTicket())
+		if err != nil {
+			return err
+		}
+		stalePages = append(stalePages, keys...)
+		if err := u.graph.WriteEdgeSet(ctx, pes); err != nil {
+			return err
+		}
+	}
+	if u.Graph != nil {
+		u.Graph.InvalidateEdgePages(stalePages)
+	}
+
+	// Fingerprint the reindexed anchors as a full rebuild would, so links
+	// saved against them survive the update.
+	sort.Stable(assemble.ByOffset(up.Decorations.Decoration))
+	assemble.AddAnchorFingerprints(up.Decorations)
+	if err := u.xrefs.WriteDecorations(ctx, up.Decorations); err != nil {
+		return err
+	} else if err := u.xrefs.WriteFileReferences(ctx, fileReferences(file, up.Decorations)); err != nil {
+		return err
+	} else if err := u.updateOutline(ctx, file, up.Decorations); err != nil {
+		return err
+	} else if err := u.xrefs.AddDecoratedFiles(ctx, file); err != nil {
+		return err
+	}
+	if u.XRefs != nil {
+		u.XRefs.InvalidateDecorationsFilter()
+	}
+	if u.XRefs != nil && u.XRefs.DecorationOverrides != nil {
+		u.XRefs.DecorationOverrides.Invalidate(file)
+	}
+	if u.XRefs != nil && u.XRefs.NegativeCache != nil {
+		written := []string{file}
+		for _, d := range up.CrossReferences {
+			if ticket, err := kytheuri.Fix(d.Ticket); err == nil {
+				written = append(written, ticket)
+			}
+		}
+		u.XRefs.NegativeCache.Invalidate(written...)
+	}
+	return nil
+}
+
+// edgePageKeys returns the EdgePage keys of the given node's current
+// PagedEdgeSet, if any.
+func (u *Updater) edgePageKeys(ctx context.Context, ticket string) ([]string, error) {
+	fixed, err := kytheuri.Fix(ticket)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PagedEdgeSet source ticket %q: %v", ticket, err)
+	}
+	var pes srvpb.PagedEdgeSet
+	if err := u.tbl.Lookup(ctx, gsrv.EdgeSetKey(fixed), &pes); err == table.ErrNoSuchKey {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading PagedEdgeSet %q: %v", fixed, err)
+	}
+	var keys []string
+	for _, idx := range pes.PageIndex {
+		keys = append(keys, idx.PageKey)
+	}
+	return keys, nil
+}
+
+// updateCrossReferences replaces the cross-references of d.Ticket located in
+// the given file with those of d.  The node's pages are inlined into its
+// PagedCrossReferences, which is then repaged by the Writer.
+func (u *Updater) updateCrossReferences(ctx context.Context, file string, d *CrossReferencesDelta) error {
+	ticket, err := kytheuri.Fix(d.Ticket)
+	if err != nil {
+		return fmt.Errorf("invalid cross-references ticket %q: %v", d.Ticket, err)
+	}
+	cr := &srvpb.PagedCrossReferences{SourceTicket: ticket}
+	if err := u.tbl.Lookup(ctx, xsrv.CrossReferencesKey(ticket), cr); err != nil && err != table.ErrNoSuchKey {
+		return fmt.Errorf("error reading cross-references %q: %v", ticket, err)
+	}
+	for _, idx := range cr.PageIndex {
+		var p srvpb.PagedCrossReferences_Page
+		if err := u.tbl.Lookup(ctx, xsrv.CrossReferencesPageKey(idx.PageKey), &p); err != nil {
+			return fmt.Errorf("error reading cross-references page %q: %v", idx.PageKey, err)
+		}
+		if g := findGroup(cr, p.Group); g != nil {
+			appendGroup(g, p.Group)
+		} else {
+			cr.Group = append(cr.Group, p.Group)
+		}
+	}
+	cr.PageIndex = nil
+
+	inFile := func(a *srvpb.ExpandedAnchor) bool { return anchorFile(a.GetTicket()) == file }
+	groups := cr.Group[:0]
+	for _, g := range cr.Group {
+		removeAnchors(g, inFile)
+		if !isEmpty(g) {
+			groups = append(groups, g)
+		}
+	}
+	cr.Group = groups
+	for _, dg := range d.Group {
+		if g := findGroup(cr, dg); g != nil {
+			appendGroup(g, dg)
+		} else {
+			cr.Group = append(cr.Group, dg)
+		}
+	}
+	return u.xrefs.WriteCrossReferences(ctx, cr)
+}
+
+// findGroup returns the group of cr with the same kind and build
+// configuration as g, if any.
+func findGroup(cr *srvpb.PagedCrossReferences, g *srvpb.PagedCrossReferences_Group) *srvpb.PagedCrossReferences_Group {
+	for _, cg := range cr.Group {
+		if cg.Kind == g.Kind && cg.BuildConfig == g.BuildConfig {
+			return cg
+		}
+	}
+	return nil
+}
+
+func appendGroup(dst, src *srvpb.PagedCrossReferences_Group) {
+	dst.Anchor = append(dst.Anchor, src.Anchor...)
+	dst.RelatedNode = append(dst.RelatedNode, src.RelatedNode...)
+	dst.Caller = append(dst.Caller, src.Caller...)
+	dst.ScopedReference = append(dst.ScopedReference, src.ScopedReference...)
+}
+
+// removeAnchors removes the anchors, callsites, and scoped references of g
+// matching f along with any callers and scopes left without references.
+func removeAnchors(g *srvpb.PagedCrossReferences_Group, f func(*srvpb.ExpandedAnchor) bool) {
+	filter := func(as []*srvpb.ExpandedAnchor) []*srvpb.ExpandedAnchor {
+		res := as[:0]
+		for _, a := range as {
+			if !f(a) {
+				res = append(res, a)
+			}
+		}
+		return res
+	}
+	g.Anchor = filter(g.Anchor)
+	callers := g.Caller[:0]
+	for _, c := range g.Caller {
+		if c.Callsite = filter(c.Callsite); len(c.Callsite) > 0 {
+			callers = append(callers, c)
+		}
+	}
+	g.Caller = callers
+	scopes := g.ScopedReference[:0]
+	for _, s := range g.ScopedReference {
+		if s.Reference = filter(s.Reference); len(s.Reference) > 0 {
+			scopes = append(scopes, s)
+		}
+	}
+	g.ScopedReference = scopes
+}
+
+func isEmpty(g *srvpb.PagedCrossReferences_Group) bool {
+	return len(g.Anchor) == 0 && len(g.RelatedNode) == 0 && len(g.Caller) == 0 && len(g.ScopedReference) == 0
+}
+
+// anchorFile returns the ticket of the file containing the given anchor or ""
+// if the ticket is invalid.
+func anchorFile(ticket string) string {
+	uri, err := kytheuri.Parse(ticket)
+	if err != nil {
+		return ""
+	}
+	uri.Signature = ""
+	uri.Language = ""
+	return uri.String()
+}
+
+// fileReferences returns the FileReferences of the given file derived from its
+// decorations.
+func fileReferences(file string, decor *srvpb.FileDecorations) *srvpb.FileReferences {
+	counts := make(map[string]int32)
+	for _, d := range decor.Decoration {
+		if d.Kind != edges.Tagged {
+			counts[d.Target]++
+		}
+	}
+	refs := &srvpb.FileReferences{File: file}
+	for ticket, count := range counts {
+		refs.Node = append(refs.Node, &srvpb.FileReferences_Node{Ticket: ticket, Count: count})
+	}
+	sort.Slice(refs.Node, func(i, j int) bool { return refs.Node[i].Ticket < refs.Node[j].Ticket })
+	return refs
+}
+
+// updateOutline rewrites the FileOutline of the given file, if it has one,
+// from its updated decorations.  The outline's symbols are the targets of the
+// file's defines/binding decorations; those already in the outline keep their
+// kinds, display names, and parents, which the decorations do not carry.
+func (u *Updater) updateOutline(ctx context.Context, file string, decor *srvpb.FileDecorations) error {
+	var prev srvpb.FileOutline
+	if err := u.tbl.Lookup(ctx, xsrv.OutlineKey(file), &prev); err == table.ErrNoSuchKey {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading file outline %q: %v", file, err)
+	}
+	known := make(map[string]*srvpb.FileOutline_Symbol, len(prev.Symbol))
+	for _, sym := range prev.Symbol {
+		known[sym.Ticket] = sym
+	}
+	targets := make(map[string]*srvpb.Node, len(decor.Target))
+	for _, n := range decor.Target {
+		targets[n.Ticket] = n
+	}
+
+	norm := span.NewNormalizer(decor.GetFile().GetText())
+	outline := &srvpb.FileOutline{File: file}
+	seen := stringset.New()
+	for _, d := range decor.Decoration {
+		if d.Kind != edges.DefinesBinding || !seen.Add(d.Target) {
+			continue
+		}
+		def, err := assemble.ExpandAnchor(d.Anchor, decor.File, norm, d.Kind)
+		if err != nil {
+			return fmt.Errorf("error expanding definition of %q in %q: %v", d.Target, file, err)
+		}
+		sym := &srvpb.FileOutline_Symbol{Ticket: d.Target, Definition: def}
+		if p := known[d.Target]; p != nil {
+			sym.Kind, sym.Subkind, sym.DisplayName, sym.Parent = p.Kind, p.Subkind, p.DisplayName, p.Parent
+		} else if n := targets[d.Target]; n != nil {
+			sym.Kind = string(assemble.GetFact(n.Fact, facts.NodeKind))
+			sym.Subkind = string(assemble.GetFact(n.Fact, facts.Subkind))
+		}
+		outline.Symbol = append(outline.Symbol, sym)
+	}
+	assemble.SortOutlineSymbols(outline.Symbol)
+	return u.xrefs.WriteFileOutline(ctx, outline)
+}
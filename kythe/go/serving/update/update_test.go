@@ -0,0 +1,235 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package update
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gsrv "kythe.io/kythe/go/serving/graph"
+	xsrv "kythe.io/kythe/go/serving/xrefs"
+	"kythe.io/kythe/go/serving/xrefs/assemble"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/test/testutil"
+	"kythe.io/kythe/go/util/bloom"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+var ctx = context.Background()
+
+const (
+	mainFile  = "kythe://c?path=main.go"
+	otherFile = "kythe://c?path=other.go"
+	nodeF     = "kythe://c?lang=go#f"
+	nodeG     = "kythe://c?lang=go#g"
+)
+
+func anchor(file, sig string) *srvpb.ExpandedAnchor {
+	return &srvpb.ExpandedAnchor{Ticket: file + "?lang=go#" + sig}
+}
+
+func refs(anchors ...*srvpb.ExpandedAnchor) []*srvpb.PagedCrossReferences_Group {
+	return []*srvpb.PagedCrossReferences_Group{{Kind: "%/kythe/edge/ref", Anchor: anchors}}
+}
+
+func TestUpdateFile(t *testing.T) {
+	tbl := &table.KVProto{inmemory.NewKeyValueDB()}
+	w := xsrv.NewCombinedWriter(tbl)
+	w.PageSize = 1
+	testutil.Fatalf(t, "WriteCrossReferences error: %v", w.WriteCrossReferences(ctx, &srvpb.PagedCrossReferences{
+		SourceTicket: nodeF,
+		Group:        refs(anchor(mainFile, "a0"), anchor(otherFile, "a1")),
+	}))
+	testutil.Fatalf(t, "WriteDecorations error: %v", w.WriteDecorations(ctx, &srvpb.FileDecorations{
+		File:       &srvpb.File{Ticket: mainFile, Text: []byte("f()\n")},
+		Decoration: []*srvpb.FileDecorations_Decoration{{Kind: edges.Ref, Target: nodeF}},
+	}))
+
+	filter := bloom.New(2, 0.01)
+	filter.Add(otherFile)
+	testutil.Fatalf(t, "Put error: %v", tbl.Put(ctx, xsrv.DecorationsFilterKey, &srvpb.ExistenceFilter{
+		Bits:      filter.Bits(),
+		HashCount: int32(filter.Hashes()),
+	}))
+
+	xt := xsrv.NewCombinedTable(tbl)
+	unavailable := func(ticket string) map[string]bool {
+		reply, err := xt.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:               []string{ticket},
+			ReferenceKind:        xpb.CrossReferencesRequest_ALL_REFERENCES,
+			MarkUnavailableFiles: true,
+		})
+		testutil.Fatalf(t, "CrossReferences error: %v", err)
+		res := make(map[string]bool)
+		for _, ra := range reply.CrossReferences[ticket].GetReference() {
+			res[ra.Anchor.Ticket] = ra.Anchor.FileUnavailable
+		}
+		return res
+	}
+	// mainFile is missing from the table's decorations filter until it is
+	// updated.
+	if err := testutil.DeepEqual(map[string]bool{
+		anchor(mainFile, "a0").Ticket:  true,
+		anchor(otherFile, "a1").Ticket: false,
+	}, unavailable(nodeF)); err != nil {
+		t.Errorf("Unavailable files before update: %v", err)
+	}
+
+	xt.DecorationOverrides = xsrv.NewDecorationOverrides()
+	xt.DecorationOverrides.Put(&srvpb.FileDecorations{File: &srvpb.File{Ticket: mainFile}}, 0)
+	var invalidated []string
+	xt.DecorationOverrides.OnInvalidate(func(ticket string) { invalidated = append(invalidated, ticket) })
+
+	// nodeG has no cross-references before the update.
+	xt.NegativeCache = xsrv.NewNegativeCache(nil)
+	_, err := xt.CrossReferences(ctx, &xpb.CrossReferencesRequest{Ticket: []string{nodeG}})
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+	if n := xt.NegativeCache.Stats().Entries; n == 0 {
+		t.Fatal("Expected negative cache entries for nodeG")
+	}
+
+	u := NewUpdater(tbl)
+	u.XRefs = xt
+	testutil.Fatalf(t, "UpdateFile error: %v", u.UpdateFile(ctx, &FileUpdate{
+		Decorations: &srvpb.FileDecorations{
+			File: &srvpb.File{Ticket: mainFile, Text: []byte("g()\n")},
+			Decoration: []*srvpb.FileDecorations_Decoration{
+				{Anchor: &srvpb.RawAnchor{StartOffset: 0, EndOffset: 3}, Kind: edges.Tagged, Target: "kythe://c?lang=go#diag"},
+				{Anchor: &srvpb.RawAnchor{StartOffset: 0, EndOffset: 1}, Kind: edges.Ref, Target: nodeG},
+			},
+		},
+		EdgeSets: []*srvpb.PagedEdgeSet{{Source: &srvpb.Node{Ticket: nodeG}}},
+		CrossReferences: []*CrossReferencesDelta{
+			{Ticket: nodeF},
+			{Ticket: nodeG, Group: refs(anchor(mainFile, "a2"))},
+		},
+	}))
+
+	for ticket, expected := range map[string][]*srvpb.PagedCrossReferences_Group{
+		nodeF: refs(anchor(otherFile, "a1")),
+		nodeG: refs(anchor(mainFile, "a2")),
+	} {
+		var cr srvpb.PagedCrossReferences
+		testutil.Fatalf(t, "Lookup error: %v", tbl.Lookup(ctx, xsrv.CrossReferencesKey(ticket), &cr))
+		if err := testutil.DeepEqual(expected, cr.Group); err != nil {
+			t.Errorf("%s cross-references: %v", ticket, err)
+		}
+		if len(cr.PageIndex) != 0 {
+			t.Errorf("Unexpected %s cross-references pages: %v", ticket, cr.PageIndex)
+		}
+	}
+
+	// The updated file is added to the decorations filter.
+	if err := testutil.DeepEqual(map[string]bool{
+		anchor(mainFile, "a2").Ticket: false,
+	}, unavailable(nodeG)); err != nil {
+		t.Errorf("Unavailable files after update: %v", err)
+	}
+
+	if n := xt.NegativeCache.Stats().Entries; n != 0 {
+		t.Errorf("Expected negative cache entries to be invalidated; found %d", n)
+	}
+
+	fileRefs, err := xt.FileReferencedNodes(ctx, mainFile)
+	testutil.Fatalf(t, "FileReferencedNodes error: %v", err)
+	if err := testutil.DeepEqual([]*srvpb.FileReferences_Node{{Ticket: nodeG, Count: 1}}, fileRefs); err != nil {
+		t.Errorf("FileReferencedNodes: %v", err)
+	}
+
+	// The reindexed anchors are fingerprinted.
+	var decor srvpb.FileDecorations
+	testutil.Fatalf(t, "Lookup error: %v", tbl.Lookup(ctx, xsrv.DecorationsKey(mainFile), &decor))
+	var fingerprints []string
+	for _, d := range decor.Decoration {
+		fingerprints = append(fingerprints, d.GetAnchor().GetFingerprint())
+	}
+	if err := testutil.DeepEqual([]string{
+		assemble.AnchorFingerprint(nodeG, []byte("g"), 0),
+		assemble.AnchorFingerprint("kythe://c?lang=go#diag", []byte("g()"), 0),
+	}, fingerprints); err != nil {
+		t.Errorf("Anchor fingerprints: %v", err)
+	}
+
+	var pes srvpb.PagedEdgeSet
+	testutil.Fatalf(t, "Lookup error: %v", tbl.Lookup(ctx, gsrv.EdgeSetKey(nodeG), &pes))
+
+	if err := testutil.DeepEqual([]string{mainFile}, invalidated); err != nil {
+		t.Errorf("Invalidated overrides: %v", err)
+	}
+}
+
+func TestUpdateFileOutline(t *testing.T) {
+	tbl := &table.KVProto{inmemory.NewKeyValueDB()}
+	w := xsrv.NewCombinedWriter(tbl)
+	testutil.Fatalf(t, "WriteFileOutline error: %v", w.WriteFileOutline(ctx, &srvpb.FileOutline{
+		File: mainFile,
+		Symbol: []*srvpb.FileOutline_Symbol{{
+			Ticket:      nodeF,
+			Kind:        "function",
+			DisplayName: "f()",
+			Definition:  &srvpb.ExpandedAnchor{Ticket: mainFile + "#old", Text: "f"},
+			Parent:      []string{mainFile},
+		}},
+	}))
+
+	u := NewUpdater(tbl)
+	testutil.Fatalf(t, "UpdateFile error: %v", u.UpdateFile(ctx, &FileUpdate{
+		Decorations: &srvpb.FileDecorations{
+			File: &srvpb.File{Ticket: mainFile, Text: []byte("g()\nf()\n")},
+			Decoration: []*srvpb.FileDecorations_Decoration{
+				{Anchor: &srvpb.RawAnchor{Ticket: mainFile + "#f", StartOffset: 4, EndOffset: 5}, Kind: edges.DefinesBinding, Target: nodeF},
+				{Anchor: &srvpb.RawAnchor{Ticket: mainFile + "#g", StartOffset: 0, EndOffset: 1}, Kind: edges.DefinesBinding, Target: nodeG},
+				{Anchor: &srvpb.RawAnchor{Ticket: mainFile + "#fref", StartOffset: 4, EndOffset: 5}, Kind: edges.Ref, Target: nodeF},
+			},
+			Target: []*srvpb.Node{{
+				Ticket: nodeG,
+				Fact:   []*cpb.Fact{{Name: facts.NodeKind, Value: []byte("variable")}},
+			}},
+		},
+	}))
+
+	// The outline's definitions are moved and its new symbols added, keeping
+	// the kinds, display names, and parents of its existing symbols.
+	var outline srvpb.FileOutline
+	testutil.Fatalf(t, "Lookup error: %v", tbl.Lookup(ctx, xsrv.OutlineKey(mainFile), &outline))
+	var found []string
+	for _, sym := range outline.Symbol {
+		def := sym.GetDefinition()
+		found = append(found, fmt.Sprintf("%s %s %q %v %s@%d", sym.Ticket, sym.Kind, sym.DisplayName, sym.Parent, def.GetText(), def.GetSpan().GetStart().GetByteOffset()))
+	}
+	if err := testutil.DeepEqual([]string{
+		nodeG + ` variable "" [] g@0`,
+		nodeF + ` function "f()" [` + mainFile + `] f@4`,
+	}, found); err != nil {
+		t.Errorf("Updated outline: %v", err)
+	}
+
+	// Files without an outline are not given one.
+	testutil.Fatalf(t, "UpdateFile error: %v", u.UpdateFile(ctx, &FileUpdate{
+		Decorations: &srvpb.FileDecorations{File: &srvpb.File{Ticket: otherFile}},
+	}))
+	if err := tbl.Lookup(ctx, xsrv.OutlineKey(otherFile), &outline); err != table.ErrNoSuchKey {
+		t.Errorf("Expected no outline for %s; found %v", otherFile, err)
+	}
+}
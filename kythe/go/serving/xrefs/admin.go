@@ -0,0 +1,359 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"kythe.io/kythe/go/services/xrefs"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// Supported AdminCommand names.
+const (
+	// AdminSwap opens the serving table at the command's Path, warms it if
+	// the Admin has a Warm func, and replaces every serving table generation
+	// with it, then drops the entries of every cache as AdminFlush.
+	AdminSwap = "swap"
+
+	// AdminFlush drops the entries of every cache.
+	AdminFlush = "flush"
+
+	// AdminLenient sets whether requests are served leniently, i.e. as if
+	// each CrossReferencesRequest set skip_missing_pages.
+	AdminLenient = "lenient"
+
+	// AdminPageLimits sets the maximum page size and number of tickets of
+	// each request.
+	AdminPageLimits = "page_limits"
+
+	// AdminStats reports the server's settings and request counts.
+	AdminStats = "stats"
+)
+
+// An AdminCommand is a single administrative operation on a running server.
+type AdminCommand struct {
+	// Command is the name of the operation, e.g. AdminSwap.
+	Command string `json:"command"`
+
+	// Path is the serving table to open for AdminSwap.
+	Path string `json:"path,omitempty"`
+
+	// Lenient is the lenient mode set by AdminLenient.
+	Lenient bool `json:"lenient,omitempty"`
+
+	// MaxPageSize and MaxTickets are the limits set by AdminPageLimits.  A
+	// limit <= 0 is unlimited.
+	MaxPageSize int32 `json:"max_page_size,omitempty"`
+	MaxTickets  int   `json:"max_tickets,omitempty"`
+}
+
+// An AdminStatus reports the state of a running server after an AdminCommand.
+type AdminStatus struct {
+	Lenient     bool  `json:"lenient"`
+	MaxPageSize int32 `json:"max_page_size,omitempty"`
+	MaxTickets  int   `json:"max_tickets,omitempty"`
+
+	// BuildIDs are the build IDs of the serving table generations, from the
+	// latest to the earliest.
+	BuildIDs []string `json:"build_ids,omitempty"`
+
+	// Requests and Errors count the requests served through the Admin's
+	// Middleware, and those that failed, by method name.
+	Requests map[string]int64 `json:"requests,omitempty"`
+	Errors   map[string]int64 `json:"errors,omitempty"`
+
+	// Health is the report of the Admin's HealthMonitor, if any.
+	Health []*CorpusHealth `json:"health,omitempty"`
+
+	// NegativeCache reports the Admin's NegativeCache, if any.
+	NegativeCache *NegativeCacheStats `json:"negative_cache,omitempty"`
+}
+
+// An Admin performs administrative operations on a running server, separately
+// from the xrefs.Service it serves, so that routine changes do not require a
+// restart.  Its lenient mode and page limits apply to the requests served
+// through its Middleware.  An Admin is safe for concurrent use and, as an
+// http.Handler, runs the JSON-encoded AdminCommand of each POST request
+// allowed by its Authorize hook, replying with the resulting JSON-encoded
+// AdminStatus.
+type Admin struct {
+	// Authorize is called with each HTTP request before its command is run
+	// and returns an error if the request may not administer the server.
+	// ServeHTTP requires it: without an Authorize hook, every HTTP request is
+	// rejected.
+	Authorize func(*http.Request) error
+
+	// Generations, if set, holds the serving table generations replaced by
+	// AdminSwap and reported by AdminStats.
+	Generations *xrefs.Generations
+
+	// OpenTable opens the serving table at the given path as a generation.  It
+	// is required by AdminSwap.
+	OpenTable func(ctx context.Context, path string) (xrefs.Provenance, error)
+
+	// Retire, if set, is called with the Service of each generation replaced
+	// by AdminSwap once the requests in flight on it finish (see
+	// xrefs.Generations.Replace and Remove), e.g. to close it.  It is also
+	// called with the Service of a newly opened generation that cannot be
+	// added.
+	Retire func(xrefs.Service)
+
+	// Warm, if set, is called by AdminSwap with each newly opened generation
+	// before it serves requests, e.g. to read the keys of a WarmingManifest
+	// with Table.Warm.
+	Warm func(ctx context.Context, p xrefs.Provenance)
+
+	// Caches are each called by AdminFlush and AdminSwap to drop the entries
+	// of a cache, e.g. a graph Table's FlushEdgePages.
+	Caches []func()
+
+	// NegativeCache, if set, is the NegativeCache shared by the served Tables.
+	// It is flushed along with Caches and its statistics are reported by
+	// AdminStats.
+	NegativeCache *NegativeCache
+
+	// Health, if set, is reported by AdminStats.
+	Health *HealthMonitor
+
+	// swapMu serializes swaps.
+	swapMu sync.Mutex
+
+	mu          sync.Mutex
+	lenient     bool
+	maxPageSize int32
+	maxTickets  int
+	requests    map[string]int64
+	errors      map[string]int64
+}
+
+// Run performs the given command and returns the resulting status.
+func (a *Admin) Run(ctx context.Context, cmd *AdminCommand) (*AdminStatus, error) {
+	switch cmd.Command {
+	case AdminSwap:
+		if err := a.swap(ctx, cmd.Path); err != nil {
+			return nil, err
+		}
+	case AdminFlush:
+		a.flush()
+	case AdminLenient:
+		a.mu.Lock()
+		a.lenient = cmd.Lenient
+		a.mu.Unlock()
+	case AdminPageLimits:
+		a.mu.Lock()
+		a.maxPageSize, a.maxTickets = cmd.MaxPageSize, cmd.MaxTickets
+		a.mu.Unlock()
+	case AdminStats:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown admin command: %q", cmd.Command)
+	}
+	return a.status(), nil
+}
+
+// swap opens the serving table at path and replaces every generation with it.
+// Concurrent swaps are run one at a time so that each replaces the generations
+// left by the last.
+func (a *Admin) swap(ctx context.Context, path string) error {
+	if a.Generations == nil || a.OpenTable == nil {
+		return status.Error(codes.FailedPrecondition, "serving tables cannot be swapped")
+	} else if path == "" {
+		return status.Error(codes.InvalidArgument, "missing serving table path")
+	}
+	a.swapMu.Lock()
+	defer a.swapMu.Unlock()
+	p, err := a.OpenTable(ctx, path)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "error opening serving table %q: %v", path, err)
+	}
+	if a.Warm != nil {
+		a.Warm(ctx, p)
+	}
+	old := a.Generations.BuildIDs()
+	replaced, ok, err := a.Generations.Replace(p)
+	if err != nil {
+		if a.Retire != nil {
+			a.Retire(p.Service)
+		}
+		return status.Errorf(codes.InvalidArgument, "serving table %q: %v", path, err)
+	} else if ok && a.Retire != nil {
+		a.Retire(replaced)
+	}
+	for _, id := range old {
+		if id == p.BuildID {
+			continue
+		}
+		if s, ok := a.Generations.Remove(id); ok && a.Retire != nil {
+			a.Retire(s)
+		}
+	}
+	a.flush()
+	return nil
+}
+
+// flush drops the entries of every cache.
+func (a *Admin) flush() {
+	for _, flush := range a.Caches {
+		flush()
+	}
+	if a.NegativeCache != nil {
+		a.NegativeCache.Flush()
+	}
+}
+
+func (a *Admin) status() *AdminStatus {
+	a.mu.Lock()
+	s := &AdminStatus{
+		Lenient:     a.lenient,
+		MaxPageSize: a.maxPageSize,
+		MaxTickets:  a.maxTickets,
+		Requests:    copyCounts(a.requests),
+		Errors:      copyCounts(a.errors),
+	}
+	a.mu.Unlock()
+	if a.Generations != nil {
+		s.BuildIDs = a.Generations.BuildIDs()
+	}
+	if a.Health != nil {
+		s.Health = a.Health.Health()
+	}
+	if a.NegativeCache != nil {
+		s.NegativeCache = a.NegativeCache.Stats()
+	}
+	return s
+}
+
+func copyCounts(m map[string]int64) map[string]int64 {
+	if len(m) == 0 {
+		return nil
+	}
+	res := make(map[string]int64, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (a *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "admin commands must be POSTed", http.StatusMethodNotAllowed)
+		return
+	} else if a.Authorize == nil {
+		http.Error(w, "admin commands are not authorized", http.StatusForbidden)
+		return
+	} else if err := a.Authorize(r); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	var cmd AdminCommand
+	if err := json.NewDecoder(r.Body).Decode(&cmd); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s, err := a.Run(r.Context(), &cmd)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Middleware returns a Middleware applying the Admin's lenient mode and page
+// limits to each request and counting the requests for AdminStats.
+func (a *Admin) Middleware() Middleware {
+	return func(s xrefs.Service) xrefs.Service { return &adminService{a, s} }
+}
+
+// settings returns the Admin's current lenient mode and page limits.
+func (a *Admin) settings() (lenient bool, maxPageSize int32, maxTickets int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lenient, a.maxPageSize, a.maxTickets
+}
+
+// count records a request for the given method that failed with err, if any.
+func (a *Admin) count(method string, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.requests == nil {
+		a.requests = make(map[string]int64)
+		a.errors = make(map[string]int64)
+	}
+	a.requests[method]++
+	if err != nil {
+		a.errors[method]++
+	}
+}
+
+// checkTickets returns an error if more than maxTickets tickets are requested.
+func checkTickets(tickets []string, maxTickets int) error {
+	if maxTickets > 0 && len(tickets) > maxTickets {
+		return status.Errorf(codes.InvalidArgument, "too many tickets requested: %d (max %d)", len(tickets), maxTickets)
+	}
+	return nil
+}
+
+type adminService struct {
+	admin *Admin
+	xrefs.Service
+}
+
+// Decorations implements part of the xrefs.Service interface.
+func (s *adminService) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (reply *xpb.DecorationsReply, err error) {
+	defer func() { s.admin.count("Decorations", err) }()
+	return s.Service.Decorations(ctx, req)
+}
+
+// CrossReferences implements part of the xrefs.Service interface.
+func (s *adminService) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (reply *xpb.CrossReferencesReply, err error) {
+	defer func() { s.admin.count("CrossReferences", err) }()
+	lenient, maxPageSize, maxTickets := s.admin.settings()
+	if err := checkTickets(req.Ticket, maxTickets); err != nil {
+		return nil, err
+	}
+	capPage := maxPageSize > 0 && (req.PageSize <= 0 || req.PageSize > maxPageSize)
+	if (lenient && !req.SkipMissingPages) || capPage {
+		req = proto.Clone(req).(*xpb.CrossReferencesRequest)
+		req.SkipMissingPages = req.SkipMissingPages || lenient
+		if capPage {
+			req.PageSize = maxPageSize
+		}
+	}
+	return s.Service.CrossReferences(ctx, req)
+}
+
+// Documentation implements part of the xrefs.Service interface.
+func (s *adminService) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (reply *xpb.DocumentationReply, err error) {
+	defer func() { s.admin.count("Documentation", err) }()
+	_, _, maxTickets := s.admin.settings()
+	if err := checkTickets(req.Ticket, maxTickets); err != nil {
+		return nil, err
+	}
+	return s.Service.Documentation(ctx, req)
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/storage/table"
+)
+
+// resolveTicketAlias returns the current ticket of the given unknown ticket,
+// if it has a ticket alias.  Otherwise, "" is returned.
+func (t *Table) resolveTicketAlias(ctx context.Context, ticket string) (string, error) {
+	a, err := t.ticketAlias(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return "", nil
+	} else if err != nil {
+		return "", canonicalError(err, "ticket alias", ticket)
+	}
+	if a.Ticket == ticket {
+		return "", nil
+	}
+	tracePrintf(ctx, "Redirecting %s to %s", ticket, a.Ticket)
+	return a.Ticket, nil
+}
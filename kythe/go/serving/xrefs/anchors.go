@@ -0,0 +1,485 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/tickets"
+	"kythe.io/kythe/go/util/span"
+
+	"bitbucket.org/creachadair/stringset"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// A ResolvedAnchor is the location of an anchor along with the target of its
+// file decoration.
+type ResolvedAnchor struct {
+	// Anchor is the anchor's resolved location, including its parent file,
+	// span, text, and snippet.
+	Anchor *xpb.Anchor
+
+	// TargetTicket is the ticket of the node referenced by the anchor.
+	TargetTicket string
+}
+
+// ResolveAnchors resolves each of the given anchor tickets into its location
+// and target by reading the decorations of the anchor's parent file.  Each
+// parent file is read at most once.  Anchors whose files or decorations cannot
+// be found are omitted from the result, which is keyed by the fixed form of
+// each anchor ticket, as are anchors whose targets are not authorized.
+func (t *Table) ResolveAnchors(ctx context.Context, anchorTickets []string) (map[string]*ResolvedAnchor, error) {
+	res := make(map[string]*ResolvedAnchor, len(anchorTickets))
+	var targets stringset.Set
+	err := t.readAnchorDecorations(ctx, anchorTickets, func(decor *srvpb.FileDecorations, norm *span.Normalizer, a *xpb.Anchor, d *srvpb.FileDecorations_Decoration) {
+		res[a.Ticket] = &ResolvedAnchor{Anchor: a, TargetTicket: d.Target}
+		targets.Add(d.Target)
+	})
+	if err != nil {
+		return nil, err
+	}
+	denied, err := t.authorize(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+	for ticket, r := range res {
+		if denied.Contains(r.TargetTicket) {
+			delete(res, ticket)
+		}
+	}
+	tracePrintf(ctx, "Resolved anchors: %d/%d", len(res), len(anchorTickets))
+	return res, nil
+}
+
+// An AnchorContext is an anchor along with the source lines surrounding it.
+type AnchorContext struct {
+	// Anchor is the anchor's resolved location.
+	Anchor *xpb.Anchor
+
+	// Text holds the lines spanning the anchor along with the requested number
+	// of lines before and after them, excluding the final trailing newline.
+	Text string
+
+	// Span is the location of Text within the anchor's parent file.
+	Span *cpb.Span
+}
+
+// GetAnchorContext returns, for each of the given anchor tickets, the anchor's
+// location along with the given number of source lines surrounding it.  As
+// with ResolveAnchors, each parent file's decorations and text are read at
+// most once, anchors that cannot be found are omitted, and the result is keyed
+// by the fixed form of each anchor ticket.
+func (t *Table) GetAnchorContext(ctx context.Context, anchorTickets []string, lines int) (map[string]*AnchorContext, error) {
+	if lines < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid number of context lines: %d", lines)
+	}
+	res := make(map[string]*AnchorContext, len(anchorTickets))
+	err := t.readAnchorDecorations(ctx, anchorTickets, func(decor *srvpb.FileDecorations, norm *span.Normalizer, a *xpb.Anchor, d *srvpb.FileDecorations_Decoration) {
+		text := decor.File.Text
+		start, end := clampOffsets(text, d.Anchor.StartOffset, d.Anchor.EndOffset)
+		start, end = contextBounds(text, start, end, lines)
+		res[a.Ticket] = &AnchorContext{
+			Anchor: a,
+			Text:   string(text[start:end]),
+			Span:   norm.SpanOffsets(start, end),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	tracePrintf(ctx, "Anchor contexts: %d/%d", len(res), len(anchorTickets))
+	return res, nil
+}
+
+// A LocationTarget is a node referenced by an anchor spanning a resolved
+// location.
+type LocationTarget struct {
+	// Anchor is the anchor spanning the location.
+	Anchor *xpb.Anchor
+
+	// Kind is the kind of the edge from the anchor to its target.
+	Kind string
+
+	// Ticket is the ticket of the referenced node.
+	Ticket string
+
+	// Definition is the ticket of the target's definition anchor, if known.
+	Definition string
+
+	// SemanticScope is the ticket of the node enclosing the anchor (e.g. the
+	// function in which a call appears), if known.
+	SemanticScope string
+}
+
+// ResolveLocation returns the targets of the innermost anchors of the given
+// file spanning the given byte offset, in decoration order.  Several
+// decorations may share the innermost span (e.g. a ref and a ref/call).  If no
+// anchor spans the offset, nil is returned.
+func (t *Table) ResolveLocation(ctx context.Context, ticket string, offset int32) ([]*LocationTarget, error) {
+	var v validate.Validator
+	ticket = v.Ticket("ticket", ticket)
+	if offset < 0 {
+		v.Addf("offset", fmt.Sprint(offset), "must be non-negative")
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	} else if err := t.authorizeRequest(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	decor, err := t.decorationsWithOverride(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return nil, xrefs.ErrDecorationsNotFound
+	} else if err != nil {
+		return nil, canonicalError(err, "file decorations", ticket)
+	} else if decor.File == nil {
+		return nil, xrefs.ErrDecorationsNotFound
+	}
+	if err := t.readDecorationPages(ctx, decor, func(p *srvpb.FileDecorations_DecorationPage) bool {
+		return p.StartOffset <= offset && offset < p.EndOffset
+	}); err != nil {
+		return nil, err
+	}
+	decorations := decor.Decoration
+	startOffset := func(i int) int32 { return decorations[i].GetAnchor().GetStartOffset() }
+	if !sort.SliceIsSorted(decorations, func(i, j int) bool { return startOffset(i) < startOffset(j) }) {
+		sort.SliceStable(decorations, func(i, j int) bool { return startOffset(i) < startOffset(j) })
+	}
+
+	// Only the decorations starting at or before the offset may span it.
+	var spanning []*srvpb.FileDecorations_Decoration
+	innermost := int32(-1)
+	for _, d := range decorations[:sort.Search(len(decorations), func(i int) bool { return startOffset(i) > offset })] {
+		a := d.GetAnchor()
+		if a.GetEndOffset() <= offset {
+			continue
+		}
+		switch size := a.GetEndOffset() - a.GetStartOffset(); {
+		case innermost < 0 || size < innermost:
+			innermost = size
+			spanning = []*srvpb.FileDecorations_Decoration{d}
+		case size == innermost:
+			spanning = append(spanning, d)
+		}
+	}
+	if len(spanning) == 0 {
+		return nil, nil
+	}
+
+	var targets stringset.Set
+	for _, d := range spanning {
+		targets.Add(d.Target)
+	}
+	denied, err := t.authorize(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	text := decor.File.Text
+	norm := span.NewNormalizer(text)
+	revision := makeFileInfoMap(decor.FileInfo)[ticket].GetRevision()
+	var res []*LocationTarget
+	for _, d := range spanning {
+		if denied.Contains(d.Target) {
+			continue
+		}
+		res = append(res, &LocationTarget{
+			Anchor:        resolveRawAnchor(norm, text, ticket, revision, d.Kind, d.Anchor),
+			Kind:          edges.Canonical(d.Kind),
+			Ticket:        d.Target,
+			Definition:    d.TargetDefinition,
+			SemanticScope: d.SemanticScope,
+		})
+	}
+	tracePrintf(ctx, "Resolved location %s:%d: %d targets", ticket, offset, len(res))
+	return res, nil
+}
+
+// ResolveFingerprint resolves an anchor fingerprint (see
+// assemble.AnchorFingerprint) within the given file into the anchor currently
+// holding it, e.g. to follow a link saved before the file's serving data was
+// rebuilt.  A NotFound error is returned if no anchor of the file has the
+// fingerprint.
+func (t *Table) ResolveFingerprint(ctx context.Context, ticket, fingerprint string) (*ResolvedAnchor, error) {
+	var v validate.Validator
+	ticket = v.Ticket("ticket", ticket)
+	if fingerprint == "" {
+		v.Addf("fingerprint", "", "missing fingerprint")
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	} else if err := t.authorizeRequest(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	decor, err := t.decorationsWithOverride(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return nil, xrefs.ErrDecorationsNotFound
+	} else if err != nil {
+		return nil, canonicalError(err, "file decorations", ticket)
+	} else if decor.File == nil {
+		return nil, xrefs.ErrDecorationsNotFound
+	} else if err := t.resolveFileContent(ctx, ticket, decor); err != nil {
+		return nil, err
+	} else if err := t.readDecorationPages(ctx, decor, nil); err != nil {
+		return nil, err
+	}
+
+	for _, d := range decor.Decoration {
+		a := d.GetAnchor()
+		if a.GetFingerprint() != fingerprint {
+			continue
+		}
+		denied, err := t.authorize(ctx, stringset.New(d.Target))
+		if err != nil {
+			return nil, err
+		} else if !denied.Empty() {
+			break
+		}
+		text := decor.File.Text
+		revision := makeFileInfoMap(decor.FileInfo)[ticket].GetRevision()
+		tracePrintf(ctx, "Resolved fingerprint %s: %s", fingerprint, a.Ticket)
+		return &ResolvedAnchor{
+			Anchor:       resolveRawAnchor(span.NewNormalizer(text), text, ticket, revision, d.Kind, a),
+			TargetTicket: d.Target,
+		}, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "no anchor with fingerprint %q in %q", fingerprint, ticket)
+}
+
+// addAnchorFingerprints populates the fingerprint of each anchor of the given
+// reply from the decorations of its parent file.  Anchors without a ticket
+// (e.g. call sites whose tickets were cleared) or whose decorations store no
+// fingerprint are left unchanged.
+func (t *Table) addAnchorFingerprints(ctx context.Context, reply *xpb.CrossReferencesReply) error {
+	var anchors []*xpb.Anchor
+	for _, crs := range reply.GetCrossReferences() {
+		for _, ras := range [][]*xpb.CrossReferencesReply_RelatedAnchor{crs.Definition, crs.Declaration, crs.Reference, crs.Caller} {
+			for _, ra := range ras {
+				anchors = append(anchors, ra.Anchor)
+				anchors = append(anchors, ra.Site...)
+			}
+		}
+	}
+	for _, a := range reply.GetDefinitionLocations() {
+		anchors = append(anchors, a)
+	}
+
+	byTicket := make(map[string][]*xpb.Anchor)
+	var anchorTickets []string
+	for _, a := range anchors {
+		if a.GetTicket() == "" {
+			continue
+		}
+		ticket, err := kytheuri.Fix(a.Ticket)
+		if err != nil {
+			continue
+		}
+		if _, ok := byTicket[ticket]; !ok {
+			anchorTickets = append(anchorTickets, ticket)
+		}
+		byTicket[ticket] = append(byTicket[ticket], a)
+	}
+	if len(anchorTickets) == 0 {
+		return nil
+	}
+	return t.readAnchorDecorations(ctx, anchorTickets, func(_ *srvpb.FileDecorations, _ *span.Normalizer, _ *xpb.Anchor, d *srvpb.FileDecorations_Decoration) {
+		if fingerprint := d.GetAnchor().GetFingerprint(); fingerprint != "" {
+			for _, a := range byTicket[d.Anchor.Ticket] {
+				a.Fingerprint = fingerprint
+			}
+		}
+	})
+}
+
+// readAnchorDecorations calls f with each of the given anchor tickets'
+// resolved anchor and decoration, reading each parent file's decorations at
+// most once.  Anchors whose files or decorations cannot be found are skipped.
+// If any of the anchors' files is not authorized, xrefs.ErrPermissionDenied is
+// returned.
+func (t *Table) readAnchorDecorations(ctx context.Context, anchorTickets []string, f func(*srvpb.FileDecorations, *span.Normalizer, *xpb.Anchor, *srvpb.FileDecorations_Decoration)) error {
+	fixed, err := xrefs.FixTickets(anchorTickets)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Group the anchors by their parent file to read each file only once.
+	var files []string
+	byFile := make(map[string][]string)
+	for _, ticket := range fixed {
+		file, err := tickets.AnchorFile(ticket)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid anchor ticket %q: %v", ticket, err)
+		}
+		if _, ok := byFile[file]; !ok {
+			files = append(files, file)
+		}
+		byFile[file] = append(byFile[file], ticket)
+	}
+	if err := t.authorizeRequest(ctx, files...); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		decor, err := t.fileDecorations(ctx, file)
+		if err == table.ErrNoSuchKey {
+			continue
+		} else if err != nil {
+			return canonicalError(err, "file decorations", file)
+		} else if decor.File == nil {
+			continue
+		} else if err := t.resolveFileContent(ctx, file, decor); err != nil {
+			return err
+		} else if err := t.readDecorationPages(ctx, decor, nil); err != nil {
+			return err
+		}
+
+		wanted := make(map[string]bool, len(byFile[file]))
+		for _, ticket := range byFile[file] {
+			wanted[ticket] = true
+		}
+
+		text := decor.File.Text
+		norm := span.NewNormalizer(text)
+		revision := makeFileInfoMap(decor.FileInfo)[file].GetRevision()
+		for _, d := range decor.Decoration {
+			a := d.GetAnchor()
+			if !wanted[a.GetTicket()] {
+				continue
+			}
+			// Only the first decoration of each anchor is used.
+			delete(wanted, a.Ticket)
+			f(decor, norm, resolveRawAnchor(norm, text, file, revision, d.Kind, a), d)
+		}
+	}
+	return nil
+}
+
+// resolveRawAnchor converts a RawAnchor into an Anchor using the text of its
+// parent file.  If the RawAnchor has no snippet, the line containing the start
+// of the anchor is used.
+func resolveRawAnchor(norm *span.Normalizer, text []byte, parent, revision, kind string, a *srvpb.RawAnchor) *xpb.Anchor {
+	start, end := clampOffsets(text, a.StartOffset, a.EndOffset)
+	snippetStart, snippetEnd := a.SnippetStart, a.SnippetEnd
+	if snippetStart >= snippetEnd {
+		snippetStart, snippetEnd = lineBounds(text, start)
+	}
+	snippetStart, snippetEnd = clampOffsets(text, snippetStart, snippetEnd)
+	res := &xpb.Anchor{
+		Ticket:      a.Ticket,
+		Kind:        edges.Canonical(kind),
+		Parent:      parent,
+		Text:        string(text[start:end]),
+		Span:        norm.SpanOffsets(start, end),
+		Snippet:     string(text[snippetStart:snippetEnd]),
+		SnippetSpan: norm.SpanOffsets(snippetStart, snippetEnd),
+		BuildConfig: a.BuildConfiguration,
+		Revision:    revision,
+		Fingerprint: a.Fingerprint,
+	}
+	res.SnippetHighlight = snippetHighlight(res.Span, res.SnippetSpan)
+	return res
+}
+
+// refreshSnippetHighlight recomputes the snippet highlight of the given
+// anchor, if it has one, e.g. after its spans were patched.
+func refreshSnippetHighlight(a *xpb.Anchor) {
+	if a.GetSnippetHighlight() != nil {
+		a.SnippetHighlight = snippetHighlight(a.Span, a.SnippetSpan)
+	}
+}
+
+// snippetHighlight returns the range of the given anchor span within the
+// snippet of the given span, clipped to the snippet, or nil if they do not
+// overlap.
+func snippetHighlight(anchor, snippet *cpb.Span) *xpb.Anchor_SnippetRange {
+	if anchor == nil || snippet == nil {
+		return nil
+	}
+	base := snippet.GetStart().GetByteOffset()
+	n := snippet.GetEnd().GetByteOffset() - base
+	start := anchor.GetStart().GetByteOffset() - base
+	end := anchor.GetEnd().GetByteOffset() - base
+	if start < 0 {
+		start = 0
+	}
+	if end > n {
+		end = n
+	}
+	if start >= end {
+		return nil
+	}
+	return &xpb.Anchor_SnippetRange{Start: start, End: end}
+}
+
+// clampOffsets restricts the given byte offsets to the bounds of text.
+func clampOffsets(text []byte, start, end int32) (int32, int32) {
+	n := int32(len(text))
+	if start < 0 {
+		start = 0
+	} else if start > n {
+		start = n
+	}
+	if end < start {
+		end = start
+	} else if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// lineBounds returns the byte offsets of the line containing the given offset,
+// excluding its trailing newline.
+func lineBounds(text []byte, offset int32) (int32, int32) {
+	if offset < 0 || int(offset) > len(text) {
+		return 0, 0
+	}
+	start := int32(bytes.LastIndexByte(text[:offset], '\n') + 1)
+	end := int32(len(text))
+	if i := bytes.IndexByte(text[offset:], '\n'); i >= 0 {
+		end = offset + int32(i)
+	}
+	return start, end
+}
+
+// contextBounds returns the byte offsets of the lines spanning [start, end)
+// extended by the given number of lines before and after, excluding the final
+// trailing newline.
+func contextBounds(text []byte, start, end int32, lines int) (int32, int32) {
+	ctxStart, _ := lineBounds(text, start)
+	for i := 0; i < lines && ctxStart > 0; i++ {
+		ctxStart, _ = lineBounds(text, ctxStart-1)
+	}
+	_, ctxEnd := lineBounds(text, end)
+	for i := 0; i < lines && int(ctxEnd) < len(text); i++ {
+		_, ctxEnd = lineBounds(text, ctxEnd+1)
+	}
+	return ctxStart, ctxEnd
+}
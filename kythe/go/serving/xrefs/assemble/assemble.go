@@ -19,7 +19,10 @@
 package assemble // import "kythe.io/kythe/go/serving/xrefs/assemble"
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -28,6 +31,7 @@ import (
 
 	"kythe.io/kythe/go/services/graphstore"
 	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/pagekey"
 	"kythe.io/kythe/go/storage/stream"
 	"kythe.io/kythe/go/util/compare"
 	"kythe.io/kythe/go/util/encoding/text"
@@ -39,6 +43,8 @@ import (
 	"kythe.io/kythe/go/util/schema/tickets"
 	"kythe.io/kythe/go/util/span"
 
+	"google.golang.org/protobuf/proto"
+
 	cpb "kythe.io/kythe/proto/common_go_proto"
 	ipb "kythe.io/kythe/proto/internal_go_proto"
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
@@ -52,10 +58,25 @@ func Node(s *ipb.Source) *srvpb.Node {
 		facts = append(facts, &cpb.Fact{Name: name, Value: value})
 	}
 	sort.Sort(xrefs.ByName(facts))
-	return &srvpb.Node{
+	n := &srvpb.Node{
 		Ticket: s.Ticket,
 		Fact:   facts,
 	}
+	SetDeprecation(n)
+	return n
+}
+
+// SetDeprecation sets the deprecated and deprecation_message fields of n from
+// its /kythe/tag/deprecated fact, if it has one, so that they are kept when the
+// node's facts are filtered.
+func SetDeprecation(n *srvpb.Node) {
+	for _, f := range n.Fact {
+		if f.Name == facts.Deprecated {
+			n.Deprecated = true
+			n.DeprecationMessage = string(f.Value)
+			return
+		}
+	}
 }
 
 // AppendEntry adds the given Entry to the Source's facts or edges.  It is
@@ -193,8 +214,10 @@ func PartialReverseEdges(src *ipb.Source) []*srvpb.Edge {
 // FilterTextFacts returns a new Node without any text facts.
 func FilterTextFacts(n *srvpb.Node) *srvpb.Node {
 	res := &srvpb.Node{
-		Ticket: n.Ticket,
-		Fact:   make([]*cpb.Fact, 0, len(n.Fact)),
+		Ticket:             n.Ticket,
+		Fact:               make([]*cpb.Fact, 0, len(n.Fact)),
+		Deprecated:         n.Deprecated,
+		DeprecationMessage: n.DeprecationMessage,
 	}
 	for _, f := range n.Fact {
 		switch f.Name {
@@ -363,6 +386,56 @@ func (s ByOffset) Less(i, j int) bool {
 	return s[i].Anchor.StartOffset < s[j].Anchor.StartOffset
 }
 
+// AnchorFingerprint returns a fingerprint of an anchor that is stable across
+// index rebuilds that shift its offsets: a digest of its target ticket, its
+// text with each run of whitespace collapsed to a single space, and its
+// ordinal among the anchors of its file sharing the same target and
+// normalized text.
+func AnchorFingerprint(target string, text []byte, ordinal int) string {
+	return anchorFingerprint(target, normalizeSpelling(text), ordinal)
+}
+
+func anchorFingerprint(target, spelling string, ordinal int) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d", target, spelling, ordinal)
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+func normalizeSpelling(text []byte) string {
+	return string(bytes.Join(bytes.Fields(text), []byte(" ")))
+}
+
+// AddAnchorFingerprints sets the fingerprint of each decoration's anchor in
+// fd, whose decorations must be sorted ByOffset.  Ordinals are assigned in
+// offset order; several decorations of the same anchor (e.g. a ref and a
+// ref/call) with the same target share a fingerprint.  Anchors outside of the
+// file's text are left without a fingerprint.
+func AddAnchorFingerprints(fd *srvpb.FileDecorations) {
+	type key struct{ target, spelling string }
+	type lastAnchor struct {
+		start, end int32
+		ordinal    int
+	}
+	last := make(map[key]*lastAnchor)
+	text := fd.GetFile().GetText()
+	for _, d := range fd.Decoration {
+		a := d.GetAnchor()
+		if a == nil || a.StartOffset < 0 || a.EndOffset < a.StartOffset || int(a.EndOffset) > len(text) {
+			continue
+		}
+		k := key{d.Target, normalizeSpelling(text[a.StartOffset:a.EndOffset])}
+		l, ok := last[k]
+		if !ok {
+			l = &lastAnchor{start: a.StartOffset, end: a.EndOffset}
+			last[k] = l
+		} else if l.start != a.StartOffset || l.end != a.EndOffset {
+			l.start, l.end = a.StartOffset, a.EndOffset
+			l.ordinal++
+		}
+		a.Fingerprint = anchorFingerprint(k.target, k.spelling, l.ordinal)
+	}
+}
+
 // ByTicket sorts nodes by their ticket.
 type ByTicket []*srvpb.Node
 
@@ -452,20 +525,22 @@ func (b *EdgeSetBuilder) constructPager() *pager.SetPager {
 			eviction := g.(*srvpb.EdgeGroup)
 
 			src := pes.Source.Ticket
-			key := newPageKey(src, len(pes.PageIndex))
+			key := pagekey.New(pagekey.EdgePage, src, eviction.Kind, len(pes.PageIndex))
 
 			// Output the EdgePage and add it to the page indices
-			if err := b.OutputPage(ctx, &srvpb.EdgePage{
+			ep := &srvpb.EdgePage{
 				PageKey:      key,
 				SourceTicket: src,
 				EdgesGroup:   eviction,
-			}); err != nil {
+			}
+			if err := b.OutputPage(ctx, ep); err != nil {
 				return fmt.Errorf("error emitting EdgePage: %v", err)
 			}
 			pes.PageIndex = append(pes.PageIndex, &srvpb.PageIndex{
-				PageKey:   key,
-				EdgeKind:  eviction.Kind,
-				EdgeCount: int32(len(eviction.Edge)),
+				PageKey:          key,
+				EdgeKind:         eviction.Kind,
+				EdgeCount:        int32(len(eviction.Edge)),
+				UncompressedSize: int64(proto.Size(ep)),
 			})
 			return nil
 		},
@@ -570,7 +645,7 @@ func (b *CrossReferencesBuilder) constructPager() *pager.SetPager {
 		OutputPage: func(ctx context.Context, s pager.Set, g pager.Group) error {
 			xs, xg := s.(*srvpb.PagedCrossReferences), g.(*srvpb.PagedCrossReferences_Group)
 
-			key := newPageKey(xs.SourceTicket, len(xs.PageIndex))
+			key := pagekey.New(pagekey.CrossReferencesPage, xs.SourceTicket, xg.Kind, len(xs.PageIndex))
 
 			pg := &srvpb.PagedCrossReferences_Page{
 				PageKey:      key,
@@ -578,9 +653,10 @@ func (b *CrossReferencesBuilder) constructPager() *pager.SetPager {
 				Group:        xg,
 			}
 			xs.PageIndex = append(xs.PageIndex, &srvpb.PagedCrossReferences_PageIndex{
-				PageKey: key,
-				Kind:    xg.Kind,
-				Count:   int32(len(xg.Anchor)),
+				PageKey:          key,
+				Kind:             xg.Kind,
+				Count:            int32(len(xg.Anchor)),
+				UncompressedSize: int64(proto.Size(pg)),
 			})
 			return b.OutputPage(ctx, pg)
 		},
@@ -607,8 +683,6 @@ func (b *CrossReferencesBuilder) AddGroup(ctx context.Context, g *srvpb.PagedCro
 // *srvpb.PagedCrossReferences_Page currently being built.
 func (b *CrossReferencesBuilder) Flush(ctx context.Context) error { return b.pager.Flush(ctx) }
 
-func newPageKey(src string, n int) string { return fmt.Sprintf("%s.%.10d", src, n) }
-
 // CrossReference returns a (Referent, TargetAnchor) *ipb.CrossReference
 // equivalent to the given decoration.  The decoration's anchor is expanded
 // given its parent file and associated Normalizer.
@@ -815,3 +889,33 @@ func (s byOrdinal) Less(i, j int) bool {
 	}
 	return s[i].Ordinal < s[j].Ordinal
 }
+
+// SortMembers sorts the given container members by display name and then by
+// ticket.
+func SortMembers(ms []*srvpb.ContainerMembers_Member) {
+	sort.Slice(ms, func(i, j int) bool {
+		return compare.Strings(ms[i].GetDisplayName(), ms[j].GetDisplayName()).
+			AndThen(ms[i].GetTicket(), ms[j].GetTicket()) == compare.LT
+	})
+}
+
+// SortRollupTargets sorts the given container rollup targets by descending
+// reference count and then by ticket.
+func SortRollupTargets(ts []*srvpb.ContainerRollup_Target) {
+	sort.Slice(ts, func(i, j int) bool {
+		return compare.Ints(int(ts[j].GetReferenceCount()), int(ts[i].GetReferenceCount())).
+			AndThen(ts[i].GetTicket(), ts[j].GetTicket()) == compare.LT
+	})
+}
+
+// SortOutlineSymbols sorts the given file outline symbols by the start of
+// their definitions, then by descending end (so that enclosing definitions
+// precede those they enclose), and then by ticket.
+func SortOutlineSymbols(ss []*srvpb.FileOutline_Symbol) {
+	sort.Slice(ss, func(i, j int) bool {
+		a, b := ss[i].GetDefinition().GetSpan(), ss[j].GetDefinition().GetSpan()
+		return compare.Ints(int(a.GetStart().GetByteOffset()), int(b.GetStart().GetByteOffset())).
+			AndThen(int(b.GetEnd().GetByteOffset()), int(a.GetEnd().GetByteOffset())).
+			AndThen(ss[i].GetTicket(), ss[j].GetTicket()) == compare.LT
+	})
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"testing"
 
+	"kythe.io/kythe/go/serving/pagekey"
 	"kythe.io/kythe/go/test/testutil"
+	"kythe.io/kythe/go/util/schema/facts"
 
 	"google.golang.org/protobuf/proto"
 
@@ -235,7 +237,7 @@ func TestEdgeSetBuilder(t *testing.T) {
 
 		edgePages: []*srvpb.EdgePage{{
 			SourceTicket: "aThirdSource",
-			PageKey:      "aThirdSource.0000000000",
+			PageKey:      pagekey.New(pagekey.EdgePage, "aThirdSource", "edgeKind123", 0),
 			EdgesGroup: &srvpb.EdgeGroup{
 				Kind: "edgeKind123",
 				Edge: getEdgeTargets(
@@ -255,7 +257,7 @@ func TestEdgeSetBuilder(t *testing.T) {
 
 		edgePages: []*srvpb.EdgePage{{
 			SourceTicket: "aThirdSource",
-			PageKey:      "aThirdSource.0000000001",
+			PageKey:      pagekey.New(pagekey.EdgePage, "aThirdSource", "edgeKind123", 1),
 			EdgesGroup: &srvpb.EdgeGroup{
 				Kind: "edgeKind123",
 				Edge: getEdgeTargets(
@@ -264,7 +266,7 @@ func TestEdgeSetBuilder(t *testing.T) {
 			},
 		}, {
 			SourceTicket: "aThirdSource",
-			PageKey:      "aThirdSource.0000000002",
+			PageKey:      pagekey.New(pagekey.EdgePage, "aThirdSource", "edgeKind123", 2),
 			EdgesGroup: &srvpb.EdgeGroup{
 				Kind: "edgeKind123",
 				Edge: getEdgeTargets(
@@ -289,7 +291,7 @@ func TestEdgeSetBuilder(t *testing.T) {
 
 		edgePages: []*srvpb.EdgePage{{
 			SourceTicket: "aThirdSource",
-			PageKey:      "aThirdSource.0000000003",
+			PageKey:      pagekey.New(pagekey.EdgePage, "aThirdSource", "edgeKind123", 3),
 			EdgesGroup: &srvpb.EdgeGroup{
 				Kind: "edgeKind123",
 				Edge: getEdgeTargets(
@@ -319,19 +321,19 @@ func TestEdgeSetBuilder(t *testing.T) {
 			}},
 
 			PageIndex: []*srvpb.PageIndex{{
-				PageKey:   "aThirdSource.0000000000",
+				PageKey:   pagekey.New(pagekey.EdgePage, "aThirdSource", "edgeKind123", 0),
 				EdgeKind:  "edgeKind123",
 				EdgeCount: 3,
 			}, {
-				PageKey:   "aThirdSource.0000000001",
+				PageKey:   pagekey.New(pagekey.EdgePage, "aThirdSource", "edgeKind123", 1),
 				EdgeKind:  "edgeKind123",
 				EdgeCount: 3,
 			}, {
-				PageKey:   "aThirdSource.0000000002",
+				PageKey:   pagekey.New(pagekey.EdgePage, "aThirdSource", "edgeKind123", 2),
 				EdgeKind:  "edgeKind123",
 				EdgeCount: 3,
 			}, {
-				PageKey:   "aThirdSource.0000000003",
+				PageKey:   pagekey.New(pagekey.EdgePage, "aThirdSource", "edgeKind123", 3),
 				EdgeKind:  "edgeKind123",
 				EdgeCount: 3,
 			}},
@@ -355,6 +357,15 @@ func TestEdgeSetBuilder(t *testing.T) {
 		}
 
 		if test.edgeSet != nil {
+			// Each page index records the size of its emitted EdgePage.
+			for _, idx := range test.edgeSet.PageIndex {
+				for _, ep := range tESB.EdgePages {
+					if ep.PageKey == idx.PageKey {
+						idx.UncompressedSize = int64(proto.Size(ep))
+					}
+				}
+			}
+
 			// Expected a new PagedEdgeSet
 			if edgeSets+1 != len(tESB.PagedEdgeSets) {
 				t.Fatalf("Missing expected PagedEdgeSet: %v", test.edgeSet)
@@ -380,3 +391,86 @@ func TestEdgeSetBuilder(t *testing.T) {
 		}
 	}
 }
+
+func TestAddAnchorFingerprints(t *testing.T) {
+	decor := func(start, end int32, kind, target string) *srvpb.FileDecorations_Decoration {
+		return &srvpb.FileDecorations_Decoration{
+			Anchor: &srvpb.RawAnchor{StartOffset: start, EndOffset: end},
+			Kind:   kind,
+			Target: target,
+		}
+	}
+	fingerprints := func(fd *srvpb.FileDecorations) []string {
+		var res []string
+		for _, d := range fd.Decoration {
+			res = append(res, d.Anchor.Fingerprint)
+		}
+		return res
+	}
+
+	fd := &srvpb.FileDecorations{
+		File: &srvpb.File{Text: []byte("a a\nb a")},
+		Decoration: []*srvpb.FileDecorations_Decoration{
+			decor(0, 1, "/kythe/edge/ref", "kythe:#T"),
+			decor(0, 1, "/kythe/edge/ref/call", "kythe:#T"),
+			decor(2, 3, "/kythe/edge/ref", "kythe:#T"),
+			decor(4, 5, "/kythe/edge/ref", "kythe:#T"),
+			decor(6, 7, "/kythe/edge/ref", "kythe:#U"),
+			decor(6, 99, "/kythe/edge/ref", "kythe:#U"),
+		},
+	}
+	AddAnchorFingerprints(fd)
+	expected := []string{
+		AnchorFingerprint("kythe:#T", []byte("a"), 0),
+		AnchorFingerprint("kythe:#T", []byte("a"), 0),
+		AnchorFingerprint("kythe:#T", []byte("a"), 1),
+		AnchorFingerprint("kythe:#T", []byte("b"), 0),
+		AnchorFingerprint("kythe:#U", []byte("a"), 0),
+		"",
+	}
+	if err := testutil.DeepEqual(expected, fingerprints(fd)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Fingerprints survive shifted offsets and reformatted whitespace.
+	shifted := &srvpb.FileDecorations{
+		File: &srvpb.File{Text: []byte("// new line\nf(\n  x)")},
+		Decoration: []*srvpb.FileDecorations_Decoration{
+			decor(12, 19, "/kythe/edge/ref/call", "kythe:#f"),
+		},
+	}
+	AddAnchorFingerprints(shifted)
+	if found, want := shifted.Decoration[0].Anchor.Fingerprint, AnchorFingerprint("kythe:#f", []byte("f( x)"), 0); found != want {
+		t.Errorf("Found fingerprint %q; expected %q", found, want)
+	}
+}
+
+func TestNodeDeprecation(t *testing.T) {
+	n := Node(&ipb.Source{
+		Ticket: "kythe:#old",
+		Facts: map[string][]byte{
+			facts.NodeKind:   []byte("function"),
+			facts.Deprecated: []byte("use kythe:#new"),
+		},
+	})
+	if !n.Deprecated || n.DeprecationMessage != "use kythe:#new" {
+		t.Errorf("Expected deprecated node with message; found %v", n)
+	}
+
+	// An empty deprecation fact still marks the node.
+	n = Node(&ipb.Source{
+		Ticket: "kythe:#old",
+		Facts:  map[string][]byte{facts.Deprecated: nil},
+	})
+	if !n.Deprecated || n.DeprecationMessage != "" {
+		t.Errorf("Expected deprecated node without message; found %v", n)
+	}
+
+	n = Node(&ipb.Source{
+		Ticket: "kythe:#new",
+		Facts:  map[string][]byte{facts.NodeKind: []byte("function")},
+	})
+	if n.Deprecated {
+		t.Errorf("Unexpected deprecated node: %v", n)
+	}
+}
@@ -0,0 +1,356 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"fmt"
+
+	"kythe.io/kythe/go/services/xrefs"
+
+	"bitbucket.org/creachadair/stringset"
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// An Authorizer decides which tickets may be served for a request, e.g. to
+// restrict access to certain corpora or paths to certain users.
+type Authorizer interface {
+	// Authorize reports, for each of the given tickets, whether it may be
+	// served in response to the request associated with ctx.  The returned
+	// slice must be the same length as tickets.  Tickets are node, anchor, and
+	// file tickets as they appear in requests and replies.
+	Authorize(ctx context.Context, tickets []string) ([]bool, error)
+}
+
+// authorize returns the subset of the given tickets that are denied by
+// t.Authorizer.  All tickets are evaluated in a single batch.
+func (t *Table) authorize(ctx context.Context, tickets stringset.Set) (denied stringset.Set, err error) {
+	if t.Authorizer == nil || tickets.Empty() {
+		return nil, nil
+	}
+	ts := tickets.Elements()
+	stop := startPhase(ctx, PhaseAuthorize)
+	allowed, err := t.Authorizer.Authorize(ctx, ts)
+	stop()
+	if err != nil {
+		return nil, fmt.Errorf("authorization error: %v", err)
+	} else if len(allowed) != len(ts) {
+		return nil, fmt.Errorf("authorization error: %d results for %d tickets", len(allowed), len(ts))
+	}
+	for i, ok := range allowed {
+		if !ok {
+			denied.Add(ts[i])
+		}
+	}
+	tracePrintf(ctx, "Authorization denied: %d/%d", len(denied), len(ts))
+	return denied, nil
+}
+
+// authorizeRequest returns xrefs.ErrPermissionDenied if any of the requested
+// tickets are denied by t.Authorizer.
+func (t *Table) authorizeRequest(ctx context.Context, tickets ...string) error {
+	denied, err := t.authorize(ctx, stringset.New(tickets...))
+	if err != nil {
+		return err
+	} else if !denied.Empty() {
+		return xrefs.ErrPermissionDenied
+	}
+	return nil
+}
+
+// authorizedDecorations serves the DecorationsRequest if its file is
+// authorized, removing the references to unauthorized nodes from the reply.
+func (t *Table) authorizedDecorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	if t.Authorizer == nil {
+		return t.serveDecorations(ctx, req)
+	} else if err := t.authorizeRequest(ctx, req.GetLocation().GetTicket()); err != nil {
+		return nil, err
+	}
+	reply, err := t.serveDecorations(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets stringset.Set
+	for _, r := range reply.Reference {
+		tickets.Add(r.TargetTicket)
+		if r.TargetDefinition != "" {
+			tickets.Add(r.TargetDefinition)
+		}
+	}
+	addMapKeys(&tickets, reply.Nodes)
+	addMapKeys(&tickets, reply.DefinitionLocations)
+	denied, err := t.authorize(ctx, tickets)
+	if err != nil {
+		return nil, err
+	} else if denied.Empty() {
+		return reply, nil
+	}
+
+	refs := reply.Reference[:0]
+	for _, r := range reply.Reference {
+		if denied.Contains(r.TargetTicket) {
+			continue
+		} else if denied.Contains(r.TargetDefinition) {
+			r.TargetDefinition = ""
+		}
+		refs = append(refs, r)
+	}
+	reply.Reference = refs
+	deleteMapKeys(denied, reply.Nodes)
+	deleteMapKeys(denied, reply.DefinitionLocations)
+	deleteMapKeys(denied, reply.ExtendsOverrides)
+	return reply, nil
+}
+
+// authorizedCrossReferences serves the CrossReferencesRequest if its tickets
+// are authorized, removing the anchors in unauthorized files and the
+// unauthorized related nodes from the reply.  Each anchor's file is derived
+// from its ticket, which is populated regardless of the request's anchor mask.
+// Reply totals are not adjusted.
+func (t *Table) authorizedCrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	if t.Authorizer == nil {
+		return t.serveCrossReferences(ctx, req)
+	} else if err := t.authorizeRequest(ctx, req.GetTicket()...); err != nil {
+		return nil, err
+	}
+	reply, err := t.serveCrossReferences(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets stringset.Set
+	addFiles := func(as []*xpb.Anchor) {
+		for _, a := range as {
+			if file := anchorFile(a.GetTicket()); file != "" {
+				tickets.Add(file)
+			}
+		}
+	}
+	addAnchors := func(as []*xpb.CrossReferencesReply_RelatedAnchor) {
+		for _, a := range as {
+			addFiles([]*xpb.Anchor{a.GetAnchor()})
+			addFiles(a.Site)
+			if a.Ticket != "" {
+				tickets.Add(a.Ticket)
+			}
+		}
+	}
+	for _, crs := range reply.CrossReferences {
+		addAnchors(crs.Definition)
+		addAnchors(crs.Declaration)
+		addAnchors(crs.Reference)
+		addAnchors(crs.Caller)
+		for _, g := range crs.ReferenceGroup {
+			addAnchors(g.Reference)
+		}
+		for _, c := range crs.CallerCallSites {
+			addFiles([]*xpb.Anchor{c.GetCallerAnchor()})
+			addFiles(c.CallSite)
+			if c.Caller != "" {
+				tickets.Add(c.Caller)
+			}
+		}
+		for _, rn := range crs.RelatedNode {
+			tickets.Add(rn.Ticket)
+		}
+	}
+	addMapKeys(&tickets, reply.Nodes)
+	addMapKeys(&tickets, reply.DefinitionLocations)
+	denied, err := t.authorize(ctx, tickets)
+	if err != nil {
+		return nil, err
+	} else if denied.Empty() {
+		return reply, nil
+	}
+
+	deniedAnchor := func(a *xpb.Anchor) bool { return denied.Contains(anchorFile(a.GetTicket())) }
+	filterSites := func(as []*xpb.Anchor) []*xpb.Anchor {
+		res := as[:0]
+		for _, a := range as {
+			if !deniedAnchor(a) {
+				res = append(res, a)
+			}
+		}
+		return res
+	}
+	filterAnchors := func(as []*xpb.CrossReferencesReply_RelatedAnchor) []*xpb.CrossReferencesReply_RelatedAnchor {
+		res := as[:0]
+		for _, a := range as {
+			if !deniedAnchor(a.GetAnchor()) && !denied.Contains(a.Ticket) {
+				a.Site = filterSites(a.Site)
+				res = append(res, a)
+			}
+		}
+		return res
+	}
+	for _, crs := range reply.CrossReferences {
+		crs.Definition = filterAnchors(crs.Definition)
+		crs.Declaration = filterAnchors(crs.Declaration)
+		crs.Reference = filterAnchors(crs.Reference)
+		crs.Caller = filterAnchors(crs.Caller)
+		for _, g := range crs.ReferenceGroup {
+			g.Reference = filterAnchors(g.Reference)
+		}
+		callers := crs.CallerCallSites[:0]
+		for _, c := range crs.CallerCallSites {
+			if !deniedAnchor(c.GetCallerAnchor()) && !denied.Contains(c.Caller) {
+				c.CallSite = filterSites(c.CallSite)
+				callers = append(callers, c)
+			}
+		}
+		crs.CallerCallSites = callers
+		rns := crs.RelatedNode[:0]
+		for _, rn := range crs.RelatedNode {
+			if !denied.Contains(rn.Ticket) {
+				rns = append(rns, rn)
+			}
+		}
+		crs.RelatedNode = rns
+		for _, l := range crs.RelatedNodeList {
+			ts := l.Ticket[:0]
+			for _, ticket := range l.Ticket {
+				if !denied.Contains(ticket) {
+					ts = append(ts, ticket)
+				}
+			}
+			l.Ticket = ts
+		}
+	}
+	deleteMapKeys(denied, reply.Nodes)
+	deleteMapKeys(denied, reply.DefinitionLocations)
+	return reply, nil
+}
+
+// authorizedDocumentation serves the DocumentationRequest if its tickets are
+// authorized, removing unauthorized child documents and nodes from the reply.
+func (t *Table) authorizedDocumentation(ctx context.Context, req *xpb.DocumentationRequest, lookupDocument documentLookup) (*xpb.DocumentationReply, error) {
+	if t.Authorizer == nil {
+		return t.serveDocumentation(ctx, req, lookupDocument)
+	} else if err := t.authorizeRequest(ctx, req.GetTicket()...); err != nil {
+		return nil, err
+	}
+	reply, err := t.serveDocumentation(ctx, req, lookupDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets stringset.Set
+	for _, doc := range reply.Document {
+		for _, child := range doc.Children {
+			tickets.Add(child.Ticket)
+		}
+	}
+	addMapKeys(&tickets, reply.Nodes)
+	addMapKeys(&tickets, reply.DefinitionLocations)
+	denied, err := t.authorize(ctx, tickets)
+	if err != nil {
+		return nil, err
+	} else if denied.Empty() {
+		return reply, nil
+	}
+
+	for _, doc := range reply.Document {
+		children := doc.Children[:0]
+		for _, child := range doc.Children {
+			if !denied.Contains(child.Ticket) {
+				children = append(children, child)
+			}
+		}
+		doc.Children = children
+	}
+	deleteMapKeys(denied, reply.Nodes)
+	deleteMapKeys(denied, reply.DefinitionLocations)
+	return reply, nil
+}
+
+// authorizedMembers returns the given container members that are authorized,
+// clearing the definitions of those defined in unauthorized files.
+func (t *Table) authorizedMembers(ctx context.Context, members []*srvpb.ContainerMembers_Member) ([]*srvpb.ContainerMembers_Member, error) {
+	if t.Authorizer == nil {
+		return members, nil
+	}
+	var tickets stringset.Set
+	for _, m := range members {
+		tickets.Add(m.Ticket)
+		if file := anchorFile(m.Definition.GetTicket()); file != "" {
+			tickets.Add(file)
+		}
+	}
+	denied, err := t.authorize(ctx, tickets)
+	if err != nil {
+		return nil, err
+	} else if denied.Empty() {
+		return members, nil
+	}
+
+	allowed := make([]*srvpb.ContainerMembers_Member, 0, len(members))
+	for _, m := range members {
+		if denied.Contains(m.Ticket) {
+			continue
+		}
+		if m.Definition != nil && denied.Contains(anchorFile(m.Definition.Ticket)) {
+			m = proto.Clone(m).(*srvpb.ContainerMembers_Member)
+			m.Definition = nil
+		}
+		allowed = append(allowed, m)
+	}
+	return allowed, nil
+}
+
+// authorizedRollup returns the given ContainerRollup without its
+// unauthorized targets, whose references are not counted.
+func (t *Table) authorizedRollup(ctx context.Context, r *srvpb.ContainerRollup) (*srvpb.ContainerRollup, error) {
+	if t.Authorizer == nil {
+		return r, nil
+	}
+	var tickets stringset.Set
+	for _, target := range r.Target {
+		tickets.Add(target.Ticket)
+	}
+	denied, err := t.authorize(ctx, tickets)
+	if err != nil {
+		return nil, err
+	} else if denied.Empty() {
+		return r, nil
+	}
+
+	allowed := &srvpb.ContainerRollup{Container: r.Container, ReferenceCount: r.ReferenceCount}
+	for _, target := range r.Target {
+		if denied.Contains(target.Ticket) {
+			allowed.ReferenceCount -= target.ReferenceCount
+		} else {
+			allowed.Target = append(allowed.Target, target)
+		}
+	}
+	return allowed, nil
+}
+
+func addMapKeys[V any](s *stringset.Set, m map[string]V) {
+	for k := range m {
+		s.Add(k)
+	}
+}
+
+func deleteMapKeys[V any](keys stringset.Set, m map[string]V) {
+	for k := range keys {
+		delete(m, k)
+	}
+}
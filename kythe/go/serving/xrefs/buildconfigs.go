@@ -0,0 +1,95 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"sort"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// groupDecorationsByBuildConfig moves the references of the given reply into
+// BuildConfigGroups by anchor build config, ordered by build config.  Each
+// group keeps the file order of its references.  Build configs are cleared
+// afterwards if they are excluded by mask.
+func groupDecorationsByBuildConfig(reply *xpb.DecorationsReply, mask fieldMask) {
+	var groups []*xpb.DecorationsReply_BuildConfigGroup
+	byConfig := make(map[string]*xpb.DecorationsReply_BuildConfigGroup)
+	for _, r := range reply.Reference {
+		g, ok := byConfig[r.BuildConfig]
+		if !ok {
+			g = &xpb.DecorationsReply_BuildConfigGroup{BuildConfig: r.BuildConfig}
+			byConfig[r.BuildConfig] = g
+			groups = append(groups, g)
+		}
+		g.Reference = append(g.Reference, r)
+		if !mask.includes("build_config") {
+			r.BuildConfig = ""
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].BuildConfig < groups[j].BuildConfig })
+	reply.BuildConfigGroup = groups
+	reply.Reference = nil
+}
+
+// A relatedAnchorField selects one of a BuildConfigGroup's anchor lists.
+type relatedAnchorField func(*xpb.CrossReferencesReply_BuildConfigGroup) *[]*xpb.CrossReferencesReply_RelatedAnchor
+
+func groupDefinitions(g *xpb.CrossReferencesReply_BuildConfigGroup) *[]*xpb.CrossReferencesReply_RelatedAnchor {
+	return &g.Definition
+}
+
+func groupDeclarations(g *xpb.CrossReferencesReply_BuildConfigGroup) *[]*xpb.CrossReferencesReply_RelatedAnchor {
+	return &g.Declaration
+}
+
+func groupReferences(g *xpb.CrossReferencesReply_BuildConfigGroup) *[]*xpb.CrossReferencesReply_RelatedAnchor {
+	return &g.Reference
+}
+
+// groupCrossReferencesByBuildConfig moves the definitions, declarations, and
+// references of each CrossReferenceSet in the given reply into
+// BuildConfigGroups by anchor build config, ordered by build config.  Each
+// group keeps the relative order of its anchors.  Build configs are cleared
+// afterwards if they are excluded by mask.
+func groupCrossReferencesByBuildConfig(reply *xpb.CrossReferencesReply, mask fieldMask) {
+	for _, crs := range reply.CrossReferences {
+		var groups []*xpb.CrossReferencesReply_BuildConfigGroup
+		byConfig := make(map[string]*xpb.CrossReferencesReply_BuildConfigGroup)
+		group := func(ras []*xpb.CrossReferencesReply_RelatedAnchor, field relatedAnchorField) {
+			for _, ra := range ras {
+				config := ra.GetAnchor().GetBuildConfig()
+				g, ok := byConfig[config]
+				if !ok {
+					g = &xpb.CrossReferencesReply_BuildConfigGroup{BuildConfig: config}
+					byConfig[config] = g
+					groups = append(groups, g)
+				}
+				*field(g) = append(*field(g), ra)
+				if !mask.includes("build_config") && ra.Anchor != nil {
+					ra.Anchor.BuildConfig = ""
+				}
+			}
+		}
+		group(crs.Definition, groupDefinitions)
+		group(crs.Declaration, groupDeclarations)
+		group(crs.Reference, groupReferences)
+		sort.Slice(groups, func(i, j int) bool { return groups[i].BuildConfig < groups[j].BuildConfig })
+		crs.BuildConfigGroup = groups
+		crs.Definition, crs.Declaration, crs.Reference = nil, nil, nil
+	}
+}
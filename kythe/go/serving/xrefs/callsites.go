@@ -0,0 +1,69 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"sort"
+
+	"kythe.io/kythe/go/util/compare"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// groupCallSitesByCaller moves the callers of each CrossReferenceSet in the
+// given reply into its CallerCallSites.  Callers sharing a semantic ticket (or,
+// lacking one, an anchor) are merged into the first, keeping the distinct
+// union of their call sites ordered by file and span.  Callers keep their
+// relative order.
+func groupCallSitesByCaller(reply *xpb.CrossReferencesReply, o *anchorOrder) {
+	for _, crs := range reply.CrossReferences {
+		var callers []*xpb.CrossReferencesReply_CallerCallSites
+		byCaller := make(map[string]*xpb.CrossReferencesReply_CallerCallSites)
+		sites := make(map[*xpb.CrossReferencesReply_CallerCallSites]map[string]bool)
+		for _, ra := range crs.Caller {
+			key := ra.GetTicket()
+			if key == "" {
+				key = ra.GetAnchor().GetTicket()
+			}
+			c, ok := byCaller[key]
+			if !ok {
+				c = &xpb.CrossReferencesReply_CallerCallSites{
+					Caller:       ra.GetTicket(),
+					CallerAnchor: ra.GetAnchor(),
+					MarkedSource: ra.GetMarkedSource(),
+				}
+				byCaller[key] = c
+				sites[c] = make(map[string]bool)
+				callers = append(callers, c)
+			} else if c.MarkedSource == nil {
+				c.MarkedSource = ra.GetMarkedSource()
+			}
+			for _, site := range ra.Site {
+				if !sites[c][site.GetTicket()] {
+					sites[c][site.GetTicket()] = true
+					c.CallSite = append(c.CallSite, site)
+				}
+			}
+		}
+		for _, c := range callers {
+			sort.SliceStable(c.CallSite, func(i, j int) bool { return o.Compare(c.CallSite[i], c.CallSite[j]) == compare.LT })
+			c.CallSiteCount = int32(len(c.CallSite))
+		}
+		crs.CallerCallSites = callers
+		crs.Caller = nil
+	}
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// canonicalNode returns the entry for ticket in nodes, adding an empty
+// NodeInfo if there is none.
+func canonicalNode(nodes map[string]*cpb.NodeInfo, ticket string) *cpb.NodeInfo {
+	n := nodes[ticket]
+	if n == nil {
+		n = &cpb.NodeInfo{}
+		nodes[ticket] = n
+	}
+	return n
+}
+
+// canonicalizeDecorationsNodes moves each reference's target display name and
+// deprecation into the reply's nodes map.
+func canonicalizeDecorationsNodes(reply *xpb.DecorationsReply) {
+	for _, r := range reply.GetReference() {
+		if r.TargetDisplayName == "" && !r.TargetDeprecated {
+			continue
+		}
+		if reply.Nodes == nil {
+			reply.Nodes = make(map[string]*cpb.NodeInfo)
+		}
+		n := canonicalNode(reply.Nodes, r.TargetTicket)
+		if r.TargetDisplayName != "" {
+			n.DisplayName = r.TargetDisplayName
+		}
+		if r.TargetDeprecated {
+			n.Deprecated = true
+			n.DeprecationMessage = r.TargetDeprecationMessage
+		}
+		r.TargetDisplayName = ""
+		r.TargetDeprecated = false
+		r.TargetDeprecationMessage = ""
+	}
+}
+
+// canonicalizeCrossReferencesNodes moves each related node's display name and
+// deprecation into the reply's nodes map.
+func canonicalizeCrossReferencesNodes(reply *xpb.CrossReferencesReply) {
+	for _, crs := range reply.GetCrossReferences() {
+		for _, rn := range crs.RelatedNode {
+			if rn.DisplayName == "" && !rn.Deprecated {
+				continue
+			}
+			if reply.Nodes == nil {
+				reply.Nodes = make(map[string]*cpb.NodeInfo)
+			}
+			n := canonicalNode(reply.Nodes, rn.Ticket)
+			if rn.DisplayName != "" {
+				n.DisplayName = rn.DisplayName
+			}
+			if rn.Deprecated {
+				n.Deprecated = true
+				n.DeprecationMessage = rn.DeprecationMessage
+			}
+			rn.DisplayName = ""
+			rn.Deprecated = false
+			rn.DeprecationMessage = ""
+		}
+	}
+}
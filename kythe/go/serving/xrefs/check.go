@@ -0,0 +1,95 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"fmt"
+
+	"kythe.io/kythe/go/serving/pagekey"
+	"kythe.io/kythe/go/serving/tablecheck"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// An edgeLabelRewriter is a staticLookupTables whose entries are served with
+// rewritten edge labels.  Its lookups return entries as they are stored.
+type edgeLabelRewriter interface {
+	// edgeLabelRewriter returns the function rewriting the edge labels of the
+	// entries read for a request, or nil if they are served as stored.
+	edgeLabelRewriter(ctx context.Context) func(string) string
+}
+
+// checkEntry prepares e, an entry just read by one of t's lookups, to be
+// served.  e is first checked as it is stored, before any rewrite.  The page
+// keys of cross-references pages must match their stored source ticket and
+// group kind; as the key is checked to belong to the requesting set before
+// the page is read, a page of another set stored under the key is caught.  If
+// t.Paranoid is set, FileDecorations, PagedCrossReferences, and their pages
+// are instead validated in full, pages against the key they were read under
+// and the ticket of the file or set that requested them.  Afterwards, e's
+// edge labels are rewritten if t's tables rewrite them.
+func (t *Table) checkEntry(ctx context.Context, ticket, key string, e proto.Message) error {
+	var err error
+	switch e := e.(type) {
+	case *srvpb.FileDecorations:
+		if t.Paranoid {
+			err = tablecheck.ValidateFileDecorations(e)
+		}
+	case *srvpb.FileDecorationsPage:
+		if t.Paranoid {
+			err = tablecheck.ValidateFileDecorationsPage(e, key, ticket, nil)
+		}
+	case *srvpb.PagedCrossReferences:
+		if t.Paranoid {
+			err = tablecheck.ValidatePagedCrossReferences(e)
+		}
+	case *srvpb.PagedCrossReferences_Page:
+		if t.Paranoid {
+			err = tablecheck.ValidateCrossReferencesPage(e, key, ticket)
+		} else if err = pagekey.Validate(key, pagekey.CrossReferencesPage, e.SourceTicket, e.GetGroup().GetKind()); err != nil {
+			err = fmt.Errorf("internal error: %v", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	r, ok := t.staticLookupTables.(edgeLabelRewriter)
+	if !ok {
+		return nil
+	}
+	f := r.edgeLabelRewriter(ctx)
+	if f == nil {
+		return nil
+	}
+	switch e := e.(type) {
+	case *srvpb.FileDecorations:
+		rewriteDecorations(e.Decoration, f)
+	case *srvpb.FileDecorationsPage:
+		rewriteDecorations(e.Decoration, f)
+	case *srvpb.PagedCrossReferences:
+		for _, g := range e.Group {
+			rewriteCrossReferencesGroup(g, f)
+		}
+	case *srvpb.PagedCrossReferences_Page:
+		rewriteCrossReferencesGroup(e.Group, f)
+	}
+	return nil
+}
@@ -21,7 +21,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"regexp"
 
 	"kythe.io/kythe/go/services/xrefs"
@@ -30,6 +29,7 @@ import (
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/util/keys"
 	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/log"
 	"kythe.io/kythe/go/util/schema"
 	"kythe.io/kythe/go/util/schema/facts"
 	"kythe.io/kythe/go/util/span"
@@ -56,7 +56,7 @@ const ColumnarTableKeyMarker = "kythe:columnar"
 func NewService(ctx context.Context, t keyvalue.DB) xrefs.Service {
 	_, err := t.Get(ctx, []byte(ColumnarTableKeyMarker), nil)
 	if err == nil {
-		log.Println("WARNING: detected a experimental columnar xrefs table")
+		log.Warningf(ctx, "detected a experimental columnar xrefs table")
 		return NewColumnarTable(t)
 	}
 	return NewCombinedTable(&table.KVProto{t})
@@ -81,6 +81,14 @@ func (c *ColumnarTable) Decorations(ctx context.Context, req *xpb.DecorationsReq
 		return nil, status.Errorf(codes.InvalidArgument, "invalid ticket %q: %v", req.GetLocation().Ticket, err)
 	} else if req.Location.Kind == xpb.Location_SPAN && req.Location.Span == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "missing requested Location span: %v", req.Location)
+	} else if req.TranscodeToUtf8 {
+		return nil, status.Error(codes.Unimplemented, "transcode_to_utf8 is not supported by columnar tables")
+	} else if req.GroupByBuildConfig {
+		return nil, status.Error(codes.Unimplemented, "group_by_build_config is not supported by columnar tables")
+	}
+	refMask, err := newFieldMask(req.GetReferenceMask(), &xpb.DecorationsReply_Reference{})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid reference_mask: %v", err)
 	}
 
 	// TODO(schroederc): handle SPAN requests
@@ -128,8 +136,17 @@ func (c *ColumnarTable) Decorations(ctx context.Context, req *xpb.DecorationsReq
 	var norm *span.Normalizer                                          // span normalizer for references
 	refsByTarget := make(map[string][]*xpb.DecorationsReply_Reference) // target -> set<Reference>
 	defs := stringset.New()                                            // set<needed definition tickets>
+	overridden := stringset.New()                                      // set<tickets overridden by returned targets>
 	reply.ExtendsOverrides = make(map[string]*xpb.DecorationsReply_Overrides)
 	buildConfigs := stringset.New(req.BuildConfig...)
+	targets := stringset.New()
+	for _, target := range req.TargetTicket {
+		fixed, err := kytheuri.Fix(target)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid target ticket %q: %v", target, err)
+		}
+		targets.Add(fixed)
+	}
 	patterns := xrefs.ConvertFilters(req.Filter)
 	emitSnippets := req.Snippets != xpb.SnippetsKind_NONE
 
@@ -190,6 +207,9 @@ func (c *ColumnarTable) Decorations(ctx context.Context, req *xpb.DecorationsReq
 					reply.SourceText = text[loc.Span.Start.ByteOffset:loc.Span.End.ByteOffset]
 				}
 			}
+			if req.LineTable {
+				reply.LineStart, reply.LineLength = norm.LineTable()
+			}
 		case *xspb.FileDecorations_Target_:
 			if !req.References {
 				// TODO(schroederc): seek to next group
@@ -200,6 +220,10 @@ func (c *ColumnarTable) Decorations(ctx context.Context, req *xpb.DecorationsReq
 			if len(buildConfigs) != 0 && !buildConfigs.Contains(t.BuildConfig) {
 				continue
 			}
+			// Filter decorations by requested targets.
+			if len(targets) != 0 && !targets.Contains(kytheuri.ToString(t.Target)) {
+				continue
+			}
 			kind := t.GetGenericKind()
 			if kind == "" {
 				kind = schema.EdgeKindString(t.GetKytheKind())
@@ -210,16 +234,28 @@ func (c *ColumnarTable) Decorations(ctx context.Context, req *xpb.DecorationsReq
 			if !exists || !span.InBounds(spanKind, start, end, startBoundary, endBoundary) {
 				continue
 			}
-			ref := &xpb.DecorationsReply_Reference{
-				TargetTicket: kytheuri.ToString(t.Target),
-				BuildConfig:  t.BuildConfig,
-				Kind:         kind,
-				Span:         norm.SpanOffsets(start, end),
+			ref := &xpb.DecorationsReply_Reference{TargetTicket: kytheuri.ToString(t.Target)}
+			if refMask.includes("build_config") {
+				ref.BuildConfig = t.BuildConfig
+			}
+			if refMask.includes("kind") {
+				ref.Kind = kind
+			}
+			if refMask.includes("span") {
+				ref.Span = norm.SpanOffsets(start, end)
+			}
+			if refMask.names("classification") {
+				// Reclassified below if the target node is incomplete.
+				ref.Classification = xrefs.ClassifyAnchor(kind, false)
 			}
 			refsByTarget[ref.TargetTicket] = append(refsByTarget[ref.TargetTicket], ref)
 			reply.Reference = append(reply.Reference, ref)
 		case *xspb.FileDecorations_TargetOverride_:
 			overridingTicket := kytheuri.ToString(e.TargetOverride.Overriding)
+			if len(targets) != 0 && !targets.Contains(overridingTicket) {
+				continue
+			}
+			overridden.Add(kytheuri.ToString(e.TargetOverride.Overridden))
 			t, ok := reply.ExtendsOverrides[overridingTicket]
 			if !ok {
 				t = &xpb.DecorationsReply_Overrides{}
@@ -238,20 +274,34 @@ func (c *ColumnarTable) Decorations(ctx context.Context, req *xpb.DecorationsReq
 				TargetDefinition: e.TargetOverride.OverridingDefinition.Ticket,
 			})
 			if req.TargetDefinitions {
-				reply.DefinitionLocations[e.TargetOverride.OverridingDefinition.Ticket] = a2a(e.TargetOverride.OverridingDefinition, nil, false).Anchor
+				reply.DefinitionLocations[e.TargetOverride.OverridingDefinition.Ticket] = a2a(e.TargetOverride.OverridingDefinition, nil, false, nil).Anchor
 			}
 		case *xspb.FileDecorations_TargetNode_:
+			if refMask.names("classification") && isIncompleteNode(e.TargetNode.Node) {
+				// The definitions of incomplete nodes are declarations.
+				for _, ref := range refsByTarget[kytheuri.ToString(e.TargetNode.Node.Source)] {
+					switch ref.Classification {
+					case xpb.Anchor_DEFINITION, xpb.Anchor_BINDING:
+						ref.Classification = xpb.Anchor_DECLARATION
+					}
+				}
+			}
 			if len(patterns) == 0 {
 				// TODO(schroederc): seek to next group
 				continue
 			}
 			n := e.TargetNode.Node
+			// Like the Table, return the nodes of the requested targets along with
+			// those of the targets they override.
+			if ticket := kytheuri.ToString(n.Source); len(targets) != 0 && !targets.Contains(ticket) && !overridden.Contains(ticket) {
+				continue
+			}
 			c := filterNode(patterns, n)
 			if c != nil && len(c.Facts) > 0 {
 				reply.Nodes[kytheuri.ToString(n.Source)] = c
 			}
 		case *xspb.FileDecorations_TargetDefinition_:
-			if !req.TargetDefinitions {
+			if !req.TargetDefinitions || !refMask.includes("target_definition") {
 				continue
 			}
 			def := e.TargetDefinition
@@ -274,7 +324,7 @@ func (c *ColumnarTable) Decorations(ctx context.Context, req *xpb.DecorationsReq
 			if !defs.Contains(def.Location.Ticket) {
 				continue
 			}
-			reply.DefinitionLocations[def.Location.Ticket] = a2a(def.Location, nil, emitSnippets).Anchor
+			reply.DefinitionLocations[def.Location.Ticket] = a2a(def.Location, nil, emitSnippets, nil).Anchor
 		case *xspb.FileDecorations_Override_:
 			// TODO(schroederc): handle
 		case *xspb.FileDecorations_Diagnostic_:
@@ -322,6 +372,17 @@ func addXRefNode(reply *xpb.CrossReferencesReply, patterns []*regexp.Regexp, n *
 
 // CrossReferences implements part of the xrefs.Service interface.
 func (c *ColumnarTable) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	if req.GetExcludeGenerated() {
+		// Columnar tables do not record file infos.
+		return nil, status.Error(codes.Unimplemented, "exclude_generated is not supported by columnar tables")
+	} else if req.GetGroupByBuildConfig() {
+		return nil, status.Error(codes.Unimplemented, "group_by_build_config is not supported by columnar tables")
+	}
+	anchorMask, err := newFieldMask(req.GetAnchorMask(), &xpb.Anchor{})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid anchor_mask: %v", err)
+	}
+
 	reply := &xpb.CrossReferencesReply{
 		CrossReferences: make(map[string]*xpb.CrossReferencesReply_CrossReferenceSet),
 	}
@@ -336,6 +397,7 @@ func (c *ColumnarTable) CrossReferences(ctx context.Context, req *xpb.CrossRefer
 		reply.DefinitionLocations = make(map[string]*xpb.Anchor)
 	}
 	emitSnippets := req.Snippets != xpb.SnippetsKind_NONE
+	ac := &anchorConverter{anchorText: emitSnippets, mask: anchorMask, resolvePaths: req.ResolveAnchorPaths}
 
 	// TODO(schroederc): file infos
 	// TODO(schroederc): implement paging xrefs in large CrossReferencesReply messages
@@ -411,8 +473,11 @@ func (c *ColumnarTable) CrossReferences(ctx context.Context, req *xpb.CrossRefer
 					anchors = &set.Reference
 				}
 				if anchors != nil {
-					a := a2a(ref.Location, nil, emitSnippets).Anchor
+					a := ac.Convert(ref.Location).Anchor
 					a.Ticket = ""
+					if req.GroupReferencesByKind {
+						a.Kind = kind
+					}
 					ra := &xpb.CrossReferencesReply_RelatedAnchor{Anchor: a}
 					*anchors = append(*anchors, ra)
 				}
@@ -451,7 +516,7 @@ func (c *ColumnarTable) CrossReferences(ctx context.Context, req *xpb.CrossRefer
 				if node := reply.Nodes[relatedNode]; node != nil {
 					loc := e.NodeDefinition.Location
 					node.Definition = loc.Ticket
-					a := a2a(loc, nil, emitSnippets).Anchor
+					a := ac.Convert(loc).Anchor
 					reply.DefinitionLocations[loc.Ticket] = a
 				}
 			case *xspb.CrossReferences_Caller_:
@@ -459,7 +524,7 @@ func (c *ColumnarTable) CrossReferences(ctx context.Context, req *xpb.CrossRefer
 					continue
 				}
 				c := e.Caller
-				a := a2a(c.Location, nil, emitSnippets).Anchor
+				a := ac.Convert(c.Location).Anchor
 				a.Ticket = ""
 				callerTicket := kytheuri.ToString(c.Caller)
 				caller := &xpb.CrossReferencesReply_RelatedAnchor{
@@ -477,10 +542,10 @@ func (c *ColumnarTable) CrossReferences(ctx context.Context, req *xpb.CrossRefer
 				}
 				caller := callers[kytheuri.ToString(c.Caller)]
 				if caller == nil {
-					log.Printf("WARNING: missing Caller for callsite: %+v", c)
+					log.Warningf(ctx, "missing Caller for callsite: %+v", c)
 					continue
 				}
-				a := a2a(c.Location, nil, emitSnippets).Anchor
+				a := ac.Convert(c.Location).Anchor
 				a.Ticket = ""
 				// TODO(schroederc): set anchor kind to differentiate kinds?
 				caller.Site = append(caller.Site, a)
@@ -490,6 +555,23 @@ func (c *ColumnarTable) CrossReferences(ctx context.Context, req *xpb.CrossRefer
 		}
 	}
 
+	if req.AnchorFingerprints && anchorMask.includes("fingerprint") {
+		if err := c.Table.addAnchorFingerprints(ctx, reply); err != nil {
+			return nil, err
+		}
+	}
+	if req.MarkUnavailableFiles {
+		if err := c.Table.markUnavailableFiles(ctx, reply); err != nil {
+			return nil, err
+		}
+	}
+	if req.GroupReferencesByKind {
+		groupReferencesByKind(reply, anchorMask)
+	}
+	if req.CallSitesByCaller {
+		groupCallSitesByCaller(reply, newAnchorOrder())
+	}
+
 	return reply, nil
 }
 
@@ -517,7 +599,17 @@ func filterNode(patterns []*regexp.Regexp, n *scpb.Node) *cpb.NodeInfo {
 	return c
 }
 
-func a2a(a *srvpb.ExpandedAnchor, fileInfos map[string]*srvpb.FileInfo, anchorText bool) *xpb.CrossReferencesReply_RelatedAnchor {
-	c := &anchorConverter{fileInfos: fileInfos, anchorText: anchorText}
+// isIncompleteNode reports whether the given node is marked as incomplete.
+func isIncompleteNode(n *scpb.Node) bool {
+	for _, f := range n.Fact {
+		if schema.GetFactName(f) == facts.Complete {
+			return string(f.Value) == "incomplete"
+		}
+	}
+	return false
+}
+
+func a2a(a *srvpb.ExpandedAnchor, fileInfos map[string]*srvpb.FileInfo, anchorText bool, mask fieldMask) *xpb.CrossReferencesReply_RelatedAnchor {
+	c := &anchorConverter{fileInfos: fileInfos, anchorText: anchorText, mask: mask}
 	return c.Convert(a)
 }
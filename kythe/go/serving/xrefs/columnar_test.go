@@ -335,6 +335,107 @@ func TestServingDecorations(t *testing.T) {
 	// TODO(schroederc): test diagnostics (w/ or w/o span)
 }
 
+func TestServingDecorationsTargetTickets(t *testing.T) {
+	ctx := context.Background()
+	db := inmemory.NewKeyValueDB()
+	w, err := db.Writer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Mark table as columnar
+	mustWrite(t, w, []byte(ColumnarTableKeyMarker), []byte{})
+
+	file := &spb.VName{Path: "overrides"}
+	binding := func(start, end int32, target string) *xspb.FileDecorations {
+		return &xspb.FileDecorations{
+			File: file,
+			Entry: &xspb.FileDecorations_Target_{&xspb.FileDecorations_Target{
+				StartOffset: start,
+				EndOffset:   end,
+				Kind:        &xspb.FileDecorations_Target_KytheKind{scpb.EdgeKind_DEFINES_BINDING},
+				Target:      &spb.VName{Signature: target},
+			}},
+		}
+	}
+	override := func(overriding, overridden string) *xspb.FileDecorations {
+		return &xspb.FileDecorations{
+			File: file,
+			Entry: &xspb.FileDecorations_TargetOverride_{&xspb.FileDecorations_TargetOverride{
+				Overridden:           &spb.VName{Signature: overridden},
+				Kind:                 srvpb.FileDecorations_Override_OVERRIDES,
+				Overriding:           &spb.VName{Signature: overriding},
+				OverridingDefinition: &srvpb.ExpandedAnchor{Ticket: "kythe:#" + overriding + "Def"},
+			}},
+		}
+	}
+	node := func(signature string) *xspb.FileDecorations {
+		return &xspb.FileDecorations{
+			File: file,
+			Entry: &xspb.FileDecorations_TargetNode_{&xspb.FileDecorations_TargetNode{
+				Node: &scpb.Node{
+					Source: &spb.VName{Signature: signature},
+					Kind:   &scpb.Node_KytheKind{scpb.NodeKind_FUNCTION},
+				},
+			}},
+		}
+	}
+	decor := []*xspb.FileDecorations{{
+		File: file,
+		Entry: &xspb.FileDecorations_Index_{&xspb.FileDecorations_Index{
+			TextEncoding: "ascii",
+		}},
+	}, {
+		File: file,
+		Entry: &xspb.FileDecorations_Text_{&xspb.FileDecorations_Text{
+			Text: []byte("sub other"),
+		}},
+	},
+		binding(0, 3, "sub"),
+		binding(4, 9, "other"),
+		override("sub", "base"),
+		override("other", "otherBase"),
+		node("sub"),
+		node("base"),
+		node("other"),
+		node("otherBase"),
+	}
+	for _, fd := range decor {
+		mustWriteDecor(t, w, fd)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	xs := NewService(ctx, db)
+	reply, err := xs.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:         &xpb.Location{Ticket: kytheuri.ToString(file)},
+		References:       true,
+		Filter:           []string{"**"},
+		ExtendsOverrides: true,
+		TargetTicket:     []string{"kythe:#sub"},
+	})
+	if err != nil {
+		t.Fatalf("Decorations error: %v", err)
+	}
+
+	// Like the Table, only the overrides of requested targets are returned,
+	// along with the nodes of the targets they override.
+	if len(reply.Reference) != 1 || reply.Reference[0].TargetTicket != "kythe:#sub" {
+		t.Errorf("Unexpected references: %v", reply.Reference)
+	}
+	var overrides []string
+	for overriding := range reply.ExtendsOverrides {
+		overrides = append(overrides, overriding)
+	}
+	if len(overrides) != 1 || overrides[0] != "kythe:#sub" {
+		t.Errorf("Unexpected overrides: %v", reply.ExtendsOverrides)
+	}
+	if len(reply.Nodes) != 2 || reply.Nodes["kythe:#sub"] == nil || reply.Nodes["kythe:#base"] == nil {
+		t.Errorf("Unexpected nodes: %v", reply.Nodes)
+	}
+}
+
 func makeDecorTestCase(ctx context.Context, xs xrefs.Service, req *xpb.DecorationsRequest, expected *xpb.DecorationsReply) func(*testing.T) {
 	return func(t *testing.T) {
 		reply, err := xs.Decorations(ctx, req)
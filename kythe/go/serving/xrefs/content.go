@@ -0,0 +1,56 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// A FileContentResolver supplies the text of files whose content is not
+// stored in the serving table, e.g. files generated at build time.
+type FileContentResolver interface {
+	// ResolveFileContent returns the text and encoding of the file with the
+	// given ticket.  It returns nil text if it cannot supply the file.
+	ResolveFileContent(ctx context.Context, ticket string) (text []byte, encoding string, err error)
+}
+
+// resolveFileContent fills in the text of decor's file from t.ContentResolver
+// if the file's text is not stored.  decor.File is replaced rather than
+// modified, since it may be shared.
+func (t *Table) resolveFileContent(ctx context.Context, ticket string, decor *srvpb.FileDecorations) error {
+	if t.ContentResolver == nil || decor.GetFile() == nil || len(decor.File.Text) > 0 {
+		return nil
+	}
+	text, encoding, err := t.ContentResolver.ResolveFileContent(ctx, ticket)
+	if err != nil {
+		return canonicalError(err, "file content", ticket)
+	} else if text == nil {
+		return nil
+	}
+	tracePrintf(ctx, "Resolved file content: %s (%d bytes)", ticket, len(text))
+	file := proto.Clone(decor.File).(*srvpb.File)
+	file.Text = text
+	if file.Encoding == "" {
+		file.Encoding = encoding
+	}
+	decor.File = file
+	return nil
+}
@@ -0,0 +1,315 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/storage/keyvalue"
+)
+
+// SplitDBs are the databases underlying the tables of a SplitTable, one per
+// SplitTable field, along with those of the graph SplitTable sharing the
+// combined table.  Optional databases may be nil.
+type SplitDBs struct {
+	Decorations, DecorationPages, DecorationsFilter keyvalue.DB
+	CrossReferences, CrossReferencePages            keyvalue.DB
+	Documentation, DisplayNames, FileDigests        keyvalue.DB
+	FileReferences, FileRelations, CallDegrees      keyvalue.DB
+	ContainerMembers, ContainerRollups              keyvalue.DB
+	TicketAliases, FileOutlines                     keyvalue.DB
+
+	// The databases of the graph SplitTable fields of the same names.
+	Edges, EdgePages, TicketIndex, FactHashes keyvalue.DB
+}
+
+// A ConvertCheckpoint is a position reached by a conversion between the split
+// and combined table layouts.  Every entry of the tables converted before
+// Table, and of Table up to and including the entry with Key, has been
+// written to the destination.
+type ConvertCheckpoint struct {
+	// Table is the name of the table being converted: its CombinedTable key
+	// prefix, which also names its subdirectory in the split table layout
+	// opened by api.OpenSplitTableDir.
+	Table string
+
+	// Key is the SplitTable key of the last entry written.
+	Key []byte
+
+	// Entries is the number of entries written so far, including those written
+	// before a resumed conversion's checkpoint.
+	Entries int64
+}
+
+// ConvertOptions control a conversion between the split and combined table
+// layouts.
+type ConvertOptions struct {
+	// CheckpointInterval is the number of entries written between checkpoints.
+	// Writes are flushed at each checkpoint and at the end of each table.  If
+	// <= 0, a default of 10000 is used.
+	CheckpointInterval int
+
+	// Progress, if set, is called with each checkpoint once its writes are
+	// flushed.  The last checkpoint passed may be given as Resume to continue
+	// an interrupted conversion.
+	Progress func(*ConvertCheckpoint)
+
+	// Resume, if set, skips every entry written before the given checkpoint.
+	Resume *ConvertCheckpoint
+}
+
+func (o *ConvertOptions) checkpointInterval() int {
+	if o == nil || o.CheckpointInterval <= 0 {
+		return 10000
+	}
+	return o.CheckpointInterval
+}
+
+// A convertTable is a table of a split table with the key prefix of its
+// entries in a combined table.  Its name matches the table's split table
+// directory (see api.OpenSplitTableDir).
+type convertTable struct {
+	name   string
+	prefix string
+	db     func(*SplitDBs) *keyvalue.DB
+
+	// key, if set, is the single key of an unprefixed table, which is the same
+	// in both layouts.  Such a table may share its split database with another
+	// table, which must list the key as its except key.
+	key, except []byte
+}
+
+// combinedKey returns the combined table key of the given SplitTable key.
+func (t *convertTable) combinedKey(key []byte) []byte {
+	return append([]byte(t.prefix), key...)
+}
+
+// splitKey returns the SplitTable key of the given combined table key.
+func (t *convertTable) splitKey(key []byte) []byte { return key[len(t.prefix):] }
+
+// combinedPrefix returns the prefix of the table's keys in a combined table.
+func (t *convertTable) combinedPrefix() []byte {
+	if t.key != nil {
+		return t.key
+	}
+	return []byte(t.prefix)
+}
+
+func identityKey(key []byte) []byte { return key }
+
+// convertTables are the tables of a split table in conversion order.
+var convertTables = []*convertTable{
+	{name: "decor", prefix: decorTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.Decorations }},
+	{name: "decorPages", prefix: decorPageTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.DecorationPages }},
+	// The existence filter has the same key in both layouts.
+	{name: "decorFilter", db: func(s *SplitDBs) *keyvalue.DB { return &s.DecorationsFilter }, key: DecorationsFilterKey},
+	{name: "xrefs", prefix: crossRefTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.CrossReferences }},
+	{name: "xrefPages", prefix: crossRefPageTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.CrossReferencePages }},
+	{name: "docs", prefix: documentationTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.Documentation }},
+	{name: "names", prefix: displayNameTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.DisplayNames }},
+	{name: "digests", prefix: fileDigestTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.FileDigests }},
+	{name: "fileRefs", prefix: fileRefsTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.FileReferences }},
+	{name: "fileRelations", prefix: fileRelationsTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.FileRelations }},
+	{name: "callDegrees", prefix: callDegreesTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.CallDegrees }},
+	{name: "members", prefix: membersTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.ContainerMembers }},
+	{name: "rollups", prefix: rollupsTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.ContainerRollups }},
+	{name: "aliases", prefix: ticketAliasTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.TicketAliases }},
+	{name: "outline", prefix: outlineTablePrefix, db: func(s *SplitDBs) *keyvalue.DB { return &s.FileOutlines }},
+	{name: "edges", prefix: string(gsrv.EdgeSetKey("")), db: func(s *SplitDBs) *keyvalue.DB { return &s.Edges }},
+	{name: "edgePages", prefix: string(gsrv.EdgePageKey("")), db: func(s *SplitDBs) *keyvalue.DB { return &s.EdgePages }},
+	{name: "ticketIndex", prefix: string(gsrv.TicketIndexKeyPrefix), db: func(s *SplitDBs) *keyvalue.DB { return &s.TicketIndex }},
+	{name: "factHashes", prefix: string(gsrv.FactHashesKey("")), db: func(s *SplitDBs) *keyvalue.DB { return &s.FactHashes }, except: gsrv.FactHashesGenerationKey},
+	// The fact hashes generation is stored alongside the fact hashes in a
+	// split table.
+	{name: "factHashesGeneration", db: func(s *SplitDBs) *keyvalue.DB { return &s.FactHashes }, key: gsrv.FactHashesGenerationKey},
+}
+
+// SplitToCombined copies the entries of the split table in src into the
+// combined table dst, as read by NewSplitTable and NewCombinedTable (and their
+// graph counterparts) respectively.  Values are copied without being decoded.
+func SplitToCombined(ctx context.Context, src *SplitDBs, dst keyvalue.DB, opts *ConvertOptions) error {
+	c, err := newConverter(opts)
+	if err != nil {
+		return err
+	}
+	for _, t := range convertTables {
+		db := *t.db(src)
+		if db == nil || c.skipTable(t.name) {
+			continue
+		}
+		s := &convertScan{
+			src:      db,
+			prefix:   t.key,
+			except:   t.except,
+			srcKey:   identityKey,
+			splitKey: identityKey,
+			dst:      keyvalue.NewPool(dst, nil),
+			dstKey:   t.combinedKey,
+		}
+		if err := c.convert(ctx, t.name, s); err != nil {
+			return fmt.Errorf("error converting %s: %v", t.name, err)
+		}
+	}
+	return nil
+}
+
+// CombinedToSplit copies the xrefs and graph entries of the combined table in
+// src into the split table dst, as read by NewCombinedTable and NewSplitTable
+// (and their graph counterparts) respectively.  Values are copied without
+// being decoded.  It is an error for src to have entries of a table unset in
+// dst.
+func CombinedToSplit(ctx context.Context, src keyvalue.DB, dst *SplitDBs, opts *ConvertOptions) error {
+	c, err := newConverter(opts)
+	if err != nil {
+		return err
+	}
+	for _, t := range convertTables {
+		if c.skipTable(t.name) {
+			continue
+		}
+		s := &convertScan{
+			src:      src,
+			prefix:   t.combinedPrefix(),
+			srcKey:   t.combinedKey,
+			splitKey: t.splitKey,
+			dstKey:   identityKey,
+		}
+		if db := *t.db(dst); db != nil {
+			s.dst = keyvalue.NewPool(db, nil)
+		}
+		if err := c.convert(ctx, t.name, s); err != nil {
+			return fmt.Errorf("error converting %s: %v", t.name, err)
+		}
+	}
+	return nil
+}
+
+// A convertScan copies the entries of a single table from src to dst.
+type convertScan struct {
+	src    keyvalue.DB
+	prefix []byte
+	except []byte // SplitTable key of an entry not to copy, if any
+
+	// srcKey and splitKey map between SplitTable keys and src keys.
+	srcKey, splitKey func([]byte) []byte
+
+	// dst is nil if the destination has no such table.
+	dst    *keyvalue.WritePool
+	dstKey func(splitKey []byte) []byte
+}
+
+// converter tracks the progress of a conversion.
+type converter struct {
+	opts     *ConvertOptions
+	interval int
+	resume   *ConvertCheckpoint
+	entries  int64
+}
+
+func newConverter(opts *ConvertOptions) (*converter, error) {
+	c := &converter{opts: opts, interval: opts.checkpointInterval()}
+	if opts == nil || opts.Resume == nil {
+		return c, nil
+	}
+	for _, t := range convertTables {
+		if t.name == opts.Resume.Table {
+			c.resume = opts.Resume
+			c.entries = opts.Resume.Entries
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown checkpoint table: %q", opts.Resume.Table)
+}
+
+// skipTable reports whether the named table was fully converted before the
+// conversion's resume checkpoint.
+func (c *converter) skipTable(name string) bool {
+	if c.resume == nil || c.resume.Table == name {
+		return false
+	}
+	for _, t := range convertTables {
+		if t.name == c.resume.Table {
+			return false
+		} else if t.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// convert copies the entries of the named table described by s, resuming
+// after the conversion's checkpoint if it is within the table.
+func (c *converter) convert(ctx context.Context, name string, s *convertScan) error {
+	it, err := s.src.ScanPrefix(ctx, s.prefix, &keyvalue.Options{LargeRead: true})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	if r := c.resume; r != nil && r.Table == name {
+		c.resume = nil
+		// Seek just past the checkpoint's key.
+		if err := it.Seek(append(s.srcKey(r.Key), 0)); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	var written int
+	var last []byte
+	checkpoint := func() error {
+		if written == 0 {
+			return nil
+		} else if err := s.dst.Flush(); err != nil {
+			return err
+		}
+		written = 0
+		if c.opts != nil && c.opts.Progress != nil {
+			c.opts.Progress(&ConvertCheckpoint{Table: name, Key: last, Entries: c.entries})
+		}
+		return nil
+	}
+	for {
+		key, val, err := it.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		} else if s.except != nil && bytes.Equal(s.splitKey(key), s.except) {
+			continue
+		} else if s.dst == nil {
+			return fmt.Errorf("no destination table for key %q", key)
+		}
+		last = append([]byte{}, s.splitKey(key)...)
+		if err := s.dst.Write(ctx, s.dstKey(last), val); err != nil {
+			return err
+		}
+		c.entries++
+		written++
+		if written >= c.interval {
+			if err := checkpoint(); err != nil {
+				return err
+			}
+		}
+	}
+	return checkpoint()
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// A groupFilter reports whether the group of cr with the given kind and build
+// config is needed.  Only cr's fields other than its groups are set when it is
+// called.
+type groupFilter func(cr *srvpb.PagedCrossReferences, kind, buildConfig string) bool
+
+// A partialCrossReferencesReader is a staticLookupTables that can decode only
+// the needed groups of a PagedCrossReferences.  The anchors of the others,
+// which make up the bulk of large PagedCrossReferences, are skipped without
+// being decoded.
+type partialCrossReferencesReader interface {
+	// partialCrossReferences returns the PagedCrossReferences of the given
+	// ticket, omitting the groups disallowed by want.
+	partialCrossReferences(ctx context.Context, ticket string, want groupFilter) (*srvpb.PagedCrossReferences, error)
+}
+
+func (s *SplitTable) partialCrossReferences(ctx context.Context, ticket string, want groupFilter) (*srvpb.PagedCrossReferences, error) {
+	tracePrintf(ctx, "Reading PagedCrossReferences: %s", ticket)
+	if s.RewriteEdgeLabel != nil {
+		// Groups are filtered by their rewritten kinds.
+		f, wantKind := s.RewriteEdgeLabel(ctx), want
+		want = func(cr *srvpb.PagedCrossReferences, kind, buildConfig string) bool {
+			return wantKind(cr, f(kind), buildConfig)
+		}
+	}
+	return lookupCrossReferences(ctx, s.CrossReferences, []byte(ticket), want)
+}
+
+func (c *combinedTable) partialCrossReferences(ctx context.Context, ticket string, want groupFilter) (*srvpb.PagedCrossReferences, error) {
+	return lookupCrossReferences(ctx, c.Proto, CrossReferencesKey(ticket), want)
+}
+
+// lookupCrossReferences returns the PagedCrossReferences stored in t under the
+// given key, omitting the groups disallowed by want.  Unless t is a
+// *table.KVProto, the PagedCrossReferences is decoded whole.
+func lookupCrossReferences(ctx context.Context, t table.Proto, key []byte, want groupFilter) (*srvpb.PagedCrossReferences, error) {
+	kv, ok := t.(*table.KVProto)
+	if !ok {
+		var cr srvpb.PagedCrossReferences
+		if err := t.Lookup(ctx, key, &cr); err != nil {
+			return nil, err
+		}
+		return &cr, nil
+	}
+
+	rec, err := kv.Get(ctx, key, nil)
+	if errors.Is(err, io.EOF) {
+		return nil, table.ErrNoSuchKey
+	} else if err != nil {
+		return nil, err
+	}
+	return decodeCrossReferences(rec, want)
+}
+
+// decodeCrossReferences decodes the PagedCrossReferences encoded in rec,
+// omitting the groups disallowed by want.  Its other fields, including its
+// PageIndex, are decoded before any group.
+func decodeCrossReferences(rec []byte, want groupFilter) (*srvpb.PagedCrossReferences, error) {
+	const groupField = 2
+	var (
+		header []byte   // every field but the groups
+		groups [][]byte // the encoded groups
+	)
+	for len(rec) > 0 {
+		num, typ, n := protowire.ConsumeTag(rec)
+		if n < 0 {
+			return nil, fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+		}
+		m := protowire.ConsumeFieldValue(num, typ, rec[n:])
+		if m < 0 {
+			return nil, fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(m))
+		}
+		if num == groupField && typ == protowire.BytesType {
+			group, _ := protowire.ConsumeBytes(rec[n : n+m])
+			groups = append(groups, group)
+		} else {
+			header = append(header, rec[:n+m]...)
+		}
+		rec = rec[n+m:]
+	}
+
+	var cr srvpb.PagedCrossReferences
+	if err := proto.Unmarshal(header, &cr); err != nil {
+		return nil, fmt.Errorf("proto unmarshal error: %v", err)
+	}
+	var decoded []*srvpb.PagedCrossReferences_Group
+	for _, group := range groups {
+		kind, buildConfig, err := decodeGroupHeader(group)
+		if err != nil {
+			return nil, err
+		} else if !want(&cr, kind, buildConfig) {
+			continue
+		}
+		var g srvpb.PagedCrossReferences_Group
+		if err := proto.Unmarshal(group, &g); err != nil {
+			return nil, fmt.Errorf("proto unmarshal error: %v", err)
+		}
+		decoded = append(decoded, &g)
+	}
+	cr.Group = decoded
+	return &cr, nil
+}
+
+// decodeGroupHeader returns the kind and build config of the encoded
+// PagedCrossReferences_Group rec without decoding its anchors.
+func decodeGroupHeader(rec []byte) (kind, buildConfig string, err error) {
+	const (
+		kindField        = 1
+		buildConfigField = 5
+	)
+	for len(rec) > 0 {
+		num, typ, n := protowire.ConsumeTag(rec)
+		if n < 0 {
+			return "", "", fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+		}
+		rec = rec[n:]
+		if (num == kindField || num == buildConfigField) && typ == protowire.BytesType {
+			// The last occurrence of a singular field takes precedence.
+			val, n := protowire.ConsumeString(rec)
+			if n < 0 {
+				return "", "", fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+			}
+			if num == kindField {
+				kind = val
+			} else {
+				buildConfig = val
+			}
+			rec = rec[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, rec)
+		if n < 0 {
+			return "", "", fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+		}
+		rec = rec[n:]
+	}
+	return kind, buildConfig, nil
+}
+
+// A fileReader is a staticLookupTables that can decode only the File of a
+// FileDecorations, skipping its decorations and targets without decoding them.
+type fileReader interface {
+	// file returns the File of the FileDecorations of the given ticket, which
+	// is nil if the FileDecorations has none.
+	file(ctx context.Context, ticket string) (*srvpb.File, error)
+}
+
+func (s *SplitTable) file(ctx context.Context, ticket string) (*srvpb.File, error) {
+	tracePrintf(ctx, "Reading File: %s", ticket)
+	return lookupFile(ctx, s.Decorations, []byte(ticket))
+}
+
+func (c *combinedTable) file(ctx context.Context, ticket string) (*srvpb.File, error) {
+	return lookupFile(ctx, c.Proto, DecorationsKey(ticket))
+}
+
+// lookupFile returns the File of the FileDecorations stored in t under the
+// given key.  Unless t is a *table.KVProto, the FileDecorations is decoded
+// whole.
+func lookupFile(ctx context.Context, t table.Proto, key []byte) (*srvpb.File, error) {
+	kv, ok := t.(*table.KVProto)
+	if !ok {
+		var fd srvpb.FileDecorations
+		if err := t.Lookup(ctx, key, &fd); err != nil {
+			return nil, err
+		}
+		return fd.File, nil
+	}
+
+	rec, err := kv.Get(ctx, key, nil)
+	if errors.Is(err, io.EOF) {
+		return nil, table.ErrNoSuchKey
+	} else if err != nil {
+		return nil, err
+	}
+	return decodeFile(rec)
+}
+
+// A decorationsChecker is a staticLookupTables that can check whether a file
+// has FileDecorations without decoding them.
+type decorationsChecker interface {
+	// hasDecorations reports whether the given file has FileDecorations.
+	hasDecorations(ctx context.Context, ticket string) (bool, error)
+}
+
+func (s *SplitTable) hasDecorations(ctx context.Context, ticket string) (bool, error) {
+	return lookupExists(ctx, s.Decorations, []byte(ticket))
+}
+
+func (c *combinedTable) hasDecorations(ctx context.Context, ticket string) (bool, error) {
+	return lookupExists(ctx, c.Proto, DecorationsKey(ticket))
+}
+
+// lookupExists reports whether t holds a FileDecorations under the given key.
+// Unless t is a *table.KVProto, only its File is decoded, as by lookupFile.
+func lookupExists(ctx context.Context, t table.Proto, key []byte) (bool, error) {
+	var err error
+	if kv, ok := t.(*table.KVProto); ok {
+		if _, err = kv.Get(ctx, key, nil); errors.Is(err, io.EOF) {
+			err = table.ErrNoSuchKey
+		}
+	} else {
+		_, err = lookupFile(ctx, t, key)
+	}
+	if err == table.ErrNoSuchKey {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// decodeFile decodes only the File of the FileDecorations encoded in rec.
+func decodeFile(rec []byte) (*srvpb.File, error) {
+	const fileField = 1
+	var file []byte // the concatenated encodings of the File, merged on decode
+	found := false
+	for len(rec) > 0 {
+		num, typ, n := protowire.ConsumeTag(rec)
+		if n < 0 {
+			return nil, fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(n))
+		}
+		m := protowire.ConsumeFieldValue(num, typ, rec[n:])
+		if m < 0 {
+			return nil, fmt.Errorf("proto unmarshal error: %v", protowire.ParseError(m))
+		}
+		if num == fileField && typ == protowire.BytesType {
+			v, _ := protowire.ConsumeBytes(rec[n : n+m])
+			file = append(file, v...)
+			found = true
+		}
+		rec = rec[n+m:]
+	}
+	if !found {
+		return nil, nil
+	}
+	var f srvpb.File
+	if err := proto.Unmarshal(file, &f); err != nil {
+		return nil, fmt.Errorf("proto unmarshal error: %v", err)
+	}
+	return &f, nil
+}
@@ -0,0 +1,412 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"flag"
+	"sort"
+
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/compare"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+
+	"bitbucket.org/creachadair/stringset"
+	"golang.org/x/sync/errgroup"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// defaultDefinitionPreference is the definition preference used when a
+// DecorationsRequest does not specify one.
+var defaultDefinitionPreference = []xpb.TargetDefinitionKind{
+	xpb.TargetDefinitionKind_FULL_DEFINITION,
+	xpb.TargetDefinitionKind_COMPLETION,
+	xpb.TargetDefinitionKind_DECLARATION,
+}
+
+var (
+	defaultMaxDefinitionJumps = flag.Int("default_max_definition_jumps", 0, "Default maximum number of merge_with jumps to follow when selecting a Decorations target's definition; overridden by DecorationsRequest.max_definition_jumps")
+	definitionReadConcurrency = flag.Int("definition_read_concurrency", 8, "Maximum number of Decorations targets whose definitions are read concurrently; values < 1 are treated as 1")
+)
+
+// classifyDefinition returns the kind of definition represented by an anchor
+// with the given edge kind targeting a node with the given completeness.
+func classifyDefinition(kind string, incomplete bool) xpb.TargetDefinitionKind {
+	kind = edges.Canonical(kind)
+	switch {
+	case edges.IsVariant(kind, edges.Completes):
+		return xpb.TargetDefinitionKind_COMPLETION
+	case edges.IsVariant(kind, edges.Defines) && incomplete:
+		return xpb.TargetDefinitionKind_DECLARATION
+	case edges.IsVariant(kind, edges.Defines):
+		return xpb.TargetDefinitionKind_FULL_DEFINITION
+	default:
+		return xpb.TargetDefinitionKind_UNKNOWN_TARGET_DEFINITION
+	}
+}
+
+// isIncomplete reports whether the given node is marked as incomplete.
+func isIncomplete(n *srvpb.Node) bool {
+	for _, f := range n.GetFact() {
+		if f.Name == facts.Complete {
+			return string(f.Value) == "incomplete"
+		}
+	}
+	return false
+}
+
+// A definitionSelector selects the definition of each Decorations target
+// according to a preference ordering of definition kinds.  Alternative
+// definitions are only read from the cross-references table when the stored
+// definition is not the most preferred kind.  Targets lacking a definition
+// may jump through their merge_with nodes, up to maxJumps times, to find one.
+type definitionSelector struct {
+	t          *Table
+	rank       map[xpb.TargetDefinitionKind]int
+	explicit   bool
+	maxJumps   int
+	incomplete map[string]bool
+	ac         *anchorConverter
+
+	selected   map[string]*selectedDefinition
+	candidates map[string][]*selectedDefinition
+
+	// prefetched holds the definitions read by Prefetch; missing tickets map
+	// to nil.
+	prefetched map[string]*srvpb.PagedCrossReferences
+
+	// pages holds the definition pages read by Select and Candidates.
+	pages map[string]*srvpb.PagedCrossReferences_Page
+}
+
+type selectedDefinition struct {
+	anchor *xpb.Anchor
+	kind   xpb.TargetDefinitionKind
+	jumps  int
+}
+
+func (t *Table) newDefinitionSelector(preference []xpb.TargetDefinitionKind, maxJumps int, targets []*srvpb.Node, ac *anchorConverter) *definitionSelector {
+	explicit := len(preference) > 0
+	if !explicit {
+		preference = defaultDefinitionPreference
+	}
+	if maxJumps == 0 {
+		maxJumps = *defaultMaxDefinitionJumps
+	}
+	rank := make(map[xpb.TargetDefinitionKind]int, len(preference))
+	for _, k := range preference {
+		if _, ok := rank[k]; !ok {
+			rank[k] = len(rank)
+		}
+	}
+	incomplete := make(map[string]bool)
+	for _, n := range targets {
+		if isIncomplete(n) {
+			incomplete[n.Ticket] = true
+		}
+	}
+	return &definitionSelector{
+		t:          t,
+		rank:       rank,
+		explicit:   explicit,
+		maxJumps:   maxJumps,
+		incomplete: incomplete,
+		ac:         ac,
+		selected:   make(map[string]*selectedDefinition),
+		candidates: make(map[string][]*selectedDefinition),
+		prefetched: make(map[string]*srvpb.PagedCrossReferences),
+		pages:      make(map[string]*srvpb.PagedCrossReferences_Page),
+	}
+}
+
+// rankOf returns the preference rank of the given kind; lower is better.
+func (s *definitionSelector) rankOf(k xpb.TargetDefinitionKind) int {
+	if r, ok := s.rank[k]; ok {
+		return r
+	}
+	return len(s.rank)
+}
+
+// Select returns the preferred definition for the given target along with its
+// kind.  stored is the target's definition from the serving table, if any.
+func (s *definitionSelector) Select(ctx context.Context, target string, stored *xpb.Anchor) (*xpb.Anchor, xpb.TargetDefinitionKind, error) {
+	if sel, ok := s.selected[target]; ok {
+		return sel.anchor, sel.kind, nil
+	}
+
+	best := &selectedDefinition{kind: xpb.TargetDefinitionKind_UNKNOWN_TARGET_DEFINITION}
+	if stored != nil {
+		best.anchor = stored
+		best.kind = classifyDefinition(stored.Kind, s.incomplete[target])
+	}
+	s.selected[target] = best
+	if !s.searches(target, stored) {
+		return best.anchor, best.kind, nil
+	}
+
+	for ticket, jumps := target, 0; ; jumps++ {
+		cr, err := s.definitions(ctx, ticket)
+		if err == table.ErrNoSuchKey {
+			break
+		} else if err != nil {
+			return nil, 0, err
+		}
+		if err := s.consider(ctx, best, cr); err != nil {
+			return nil, 0, err
+		}
+		if best.anchor != nil {
+			best.jumps = jumps
+			break
+		} else if jumps >= s.maxJumps || len(cr.MergeWith) == 0 {
+			break
+		}
+		ticket = cr.MergeWith[0]
+	}
+	tracePrintf(ctx, "Selected %s definition for %s after %d jumps", best.kind, target, best.jumps)
+	return best.anchor, best.kind, nil
+}
+
+// Jumps returns the number of merge_with jumps taken to find the selected
+// definition of the given target.
+func (s *definitionSelector) Jumps(target string) int { return s.selected[target].GetJumps() }
+
+// GetJumps returns the number of jumps taken to find the definition or 0 if
+// sd is nil.
+func (sd *selectedDefinition) GetJumps() int {
+	if sd == nil {
+		return 0
+	}
+	return sd.jumps
+}
+
+// searches reports whether Select searches the cross-references of the given
+// target for a definition preferred to stored.  Only stored definitions that
+// may be improved upon are searched for alternatives.  Without an explicit
+// preference, only declarations are replaced and missing definitions are only
+// searched for by jumping.
+func (s *definitionSelector) searches(target string, stored *xpb.Anchor) bool {
+	if stored == nil {
+		return s.explicit || s.maxJumps > 0
+	}
+	kind := classifyDefinition(stored.Kind, s.incomplete[target])
+	return s.rankOf(kind) != 0 && (s.explicit || kind == xpb.TargetDefinitionKind_DECLARATION)
+}
+
+// consider updates best with any definition in cr more preferred than best.
+// Definition pages are only read if their kind is preferred to best's.
+func (s *definitionSelector) consider(ctx context.Context, best *selectedDefinition, cr *srvpb.PagedCrossReferences) error {
+	consider := func(grp *srvpb.PagedCrossReferences_Group) {
+		kind := classifyDefinition(grp.Kind, cr.Incomplete)
+		if kind == xpb.TargetDefinitionKind_UNKNOWN_TARGET_DEFINITION || len(grp.Anchor) == 0 {
+			return
+		}
+		if best.anchor == nil || s.rankOf(kind) < s.rankOf(best.kind) {
+			a := s.ac.Convert(grp.Anchor[0]).Anchor
+			a.Kind = edges.Canonical(grp.Kind)
+			best.anchor, best.kind = a, kind
+		}
+	}
+	for _, grp := range cr.Group {
+		consider(grp)
+	}
+	for _, idx := range cr.PageIndex {
+		kind := classifyDefinition(idx.Kind, cr.Incomplete)
+		if best.anchor != nil && s.rankOf(kind) >= s.rankOf(best.kind) {
+			continue
+		}
+		p, err := s.page(ctx, cr, idx)
+		if err != nil {
+			return err
+		}
+		consider(p.Group)
+	}
+	return nil
+}
+
+// Prefetch concurrently reads the definitions of each of the given targets,
+// mapped to their stored definitions, that Select (or Candidates, if all is
+// true) will search, so that they are not read one at a time.  Only the
+// targets' own cross-references are read: their definition pages are read by
+// Select and Candidates as needed, and the merge_with nodes that Select jumps
+// through are still read one at a time.
+func (s *definitionSelector) Prefetch(ctx context.Context, targets map[string]*xpb.Anchor, all bool) error {
+	var tickets []string
+	for target, stored := range targets {
+		if _, ok := s.prefetched[target]; !ok && (all || s.searches(target, stored)) {
+			tickets = append(tickets, target)
+		}
+	}
+	if len(tickets) == 0 {
+		return nil
+	}
+	sort.Strings(tickets)
+	tracePrintf(ctx, "Prefetching definitions of %d targets", len(tickets))
+	crs := make([]*srvpb.PagedCrossReferences, len(tickets))
+	g, gctx := errgroup.WithContext(ctx)
+	if n := *definitionReadConcurrency; n > 0 {
+		g.SetLimit(n)
+	} else {
+		g.SetLimit(1)
+	}
+	for i, ticket := range tickets {
+		i, ticket := i, ticket
+		g.Go(func() error {
+			cr, err := s.t.definitionCrossReferences(gctx, ticket)
+			if err == table.ErrNoSuchKey {
+				return nil
+			} else if err != nil {
+				return canonicalError(err, "cross-references", ticket)
+			}
+			crs[i] = cr
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	for i, ticket := range tickets {
+		s.prefetched[ticket] = crs[i]
+	}
+	return nil
+}
+
+// definitions returns the definition groups of the given ticket's
+// cross-references, as read by Prefetch or otherwise read on demand.
+func (s *definitionSelector) definitions(ctx context.Context, ticket string) (*srvpb.PagedCrossReferences, error) {
+	if cr, ok := s.prefetched[ticket]; ok {
+		if cr == nil {
+			return nil, table.ErrNoSuchKey
+		}
+		return cr, nil
+	}
+	cr, err := s.t.definitionCrossReferences(ctx, ticket)
+	if err != nil && err != table.ErrNoSuchKey {
+		return nil, canonicalError(err, "cross-references", ticket)
+	}
+	return cr, err
+}
+
+// page returns the definition page of cr with the given index, reading it at
+// most once.
+func (s *definitionSelector) page(ctx context.Context, cr *srvpb.PagedCrossReferences, idx *srvpb.PagedCrossReferences_PageIndex) (*srvpb.PagedCrossReferences_Page, error) {
+	if p, ok := s.pages[idx.PageKey]; ok {
+		return p, nil
+	}
+	p, err := s.t.crossReferencesPage(ctx, cr.GetSourceTicket(), idx)
+	if err != nil {
+		return nil, crossReferencesPageError(idx.PageKey, err)
+	}
+	s.pages[idx.PageKey] = p
+	return p, nil
+}
+
+// definitionCrossReferences returns the cross-references of the given ticket
+// holding only its definition groups and the index of its definition pages,
+// which are left unread.  The anchors of other groups are not decoded where
+// the table allows it.
+func (t *Table) definitionCrossReferences(ctx context.Context, ticket string) (*srvpb.PagedCrossReferences, error) {
+	isDefinition := func(kind string, incomplete bool) bool {
+		return classifyDefinition(kind, incomplete) != xpb.TargetDefinitionKind_UNKNOWN_TARGET_DEFINITION
+	}
+	cr, err := t.partialCrossReferences(ctx, ticket, func(cr *srvpb.PagedCrossReferences, kind, _ string) bool {
+		return isDefinition(kind, cr.Incomplete)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &srvpb.PagedCrossReferences{
+		SourceTicket: cr.SourceTicket,
+		Incomplete:   cr.Incomplete,
+		MergeWith:    cr.MergeWith,
+	}
+	for _, grp := range cr.Group {
+		if isDefinition(grp.Kind, cr.Incomplete) {
+			res.Group = append(res.Group, grp)
+		}
+	}
+	for _, idx := range cr.PageIndex {
+		if isDefinition(idx.Kind, cr.Incomplete) {
+			res.PageIndex = append(res.PageIndex, idx)
+		}
+	}
+	return res, nil
+}
+
+// Candidates returns every known definition of the given target, best first:
+// definitions are ranked by preference, then by whether their file is in the
+// given corpus, then with bindings ahead of other definitions, and finally by
+// ticket.  stored is the target's definition from the serving table, if any.
+func (s *definitionSelector) Candidates(ctx context.Context, target, corpus string, stored *xpb.Anchor) ([]*selectedDefinition, error) {
+	if cs, ok := s.candidates[target]; ok {
+		return cs, nil
+	}
+
+	var cs []*selectedDefinition
+	seen := stringset.New()
+	add := func(a *xpb.Anchor, kind xpb.TargetDefinitionKind) {
+		if kind != xpb.TargetDefinitionKind_UNKNOWN_TARGET_DEFINITION && seen.Add(a.Ticket) {
+			cs = append(cs, &selectedDefinition{anchor: a, kind: kind})
+		}
+	}
+	if stored != nil {
+		add(stored, classifyDefinition(stored.Kind, s.incomplete[target]))
+	}
+
+	cr, err := s.definitions(ctx, target)
+	if err != nil && err != table.ErrNoSuchKey {
+		return nil, err
+	} else if err == nil {
+		addGroup := func(grp *srvpb.PagedCrossReferences_Group) {
+			kind := classifyDefinition(grp.Kind, cr.Incomplete)
+			for _, a := range grp.Anchor {
+				anchor := s.ac.Convert(a).Anchor
+				anchor.Kind = edges.Canonical(grp.Kind)
+				add(anchor, kind)
+			}
+		}
+		for _, grp := range cr.Group {
+			addGroup(grp)
+		}
+		for _, idx := range cr.PageIndex {
+			p, err := s.page(ctx, cr, idx)
+			if err != nil {
+				return nil, err
+			}
+			addGroup(p.Group)
+		}
+	}
+
+	otherCorpus := func(a *xpb.Anchor) bool {
+		uri, err := kytheuri.Parse(a.GetParent())
+		return err != nil || uri.Corpus != corpus
+	}
+	notBinding := func(a *xpb.Anchor) bool { return a.GetKind() != edges.DefinesBinding }
+	sort.SliceStable(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		return compare.Ints(s.rankOf(a.kind), s.rankOf(b.kind)).
+			AndThen(otherCorpus(a.anchor), otherCorpus(b.anchor)).
+			AndThen(notBinding(a.anchor), notBinding(b.anchor)).
+			AndThen(a.anchor.Ticket, b.anchor.Ticket) == compare.LT
+	})
+	s.candidates[target] = cs
+	tracePrintf(ctx, "Found %d definition candidates for %s", len(cs), target)
+	return cs, nil
+}
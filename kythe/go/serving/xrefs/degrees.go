@@ -0,0 +1,57 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/storage/table"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// CallDegrees returns the number of distinct callers and callees of each of
+// the given function tickets, as precomputed in the serving table's call
+// degrees index.  The result is keyed by the fixed form of each ticket;
+// tickets without an entry in the index are omitted.  If any of the tickets is
+// not authorized, xrefs.ErrPermissionDenied is returned.
+func (t *Table) CallDegrees(ctx context.Context, tickets []string) (map[string]*srvpb.CallDegrees, error) {
+	var v validate.Validator
+	tickets = v.Tickets("tickets", tickets)
+	if err := v.Err(); err != nil {
+		return nil, err
+	} else if err := t.authorizeRequest(ctx, tickets...); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*srvpb.CallDegrees, len(tickets))
+	for _, ticket := range tickets {
+		if _, ok := res[ticket]; ok {
+			continue
+		}
+		d, err := t.callDegrees(ctx, ticket)
+		if err == table.ErrNoSuchKey {
+			continue
+		} else if err != nil {
+			return nil, canonicalError(err, "call degrees", ticket)
+		}
+		res[ticket] = d
+	}
+	tracePrintf(ctx, "Found call degrees: %d/%d", len(res), len(tickets))
+	return res, nil
+}
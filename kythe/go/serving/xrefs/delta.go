@@ -0,0 +1,164 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/span"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// A DecorationsDelta is the set of changes between the references of two
+// DecorationsReplys for the same file.
+type DecorationsDelta struct {
+	// Fingerprint is the DecorationsFingerprint of the current references.
+	Fingerprint string
+
+	// Added are the references that exist only in the current buffer.
+	Added []*xpb.DecorationsReply_Reference
+
+	// Removed are the previous references that no longer exist.
+	Removed []*xpb.DecorationsReply_Reference
+
+	// Moved are the references that exist in both buffers at different spans.
+	Moved []*MovedReference
+}
+
+// A MovedReference is a reference whose span changed between two buffers.
+type MovedReference struct {
+	Previous, Current *xpb.DecorationsReply_Reference
+}
+
+// DecorationsFingerprint returns a stable fingerprint of the given references
+// suitable for passing to DecorationsDelta.
+func DecorationsFingerprint(refs []*xpb.DecorationsReply_Reference) string {
+	h := sha256.New()
+	for _, r := range refs {
+		start, end := span.ByteOffsets(r.Span)
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00%d\x00%d\n", r.TargetTicket, r.Kind, r.BuildConfig, start, end)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// DecorationsDelta returns the references added, removed, and moved between
+// the decorations of previousBuffer and those of req.DirtyBuffer.  An empty
+// buffer denotes the file's indexed text.  The fingerprint must be the
+// DecorationsFingerprint of the previous reply's references; if it is empty,
+// every current reference is reported as added.  A mismatched fingerprint
+// results in a FailedPrecondition error, after which the client should request
+// full decorations.
+func (t *Table) DecorationsDelta(ctx context.Context, req *xpb.DecorationsRequest, previousBuffer []byte, fingerprint string) (*DecorationsDelta, error) {
+	curReq := proto.Clone(req).(*xpb.DecorationsRequest)
+	curReq.References = true
+	curReq.Diagnostics = false
+	curReq.SourceText = false
+	// References are compared by their target, kind, build config, and span.
+	curReq.ReferenceMask = nil
+	cur, err := t.authorizedDecorations(ctx, curReq)
+	if err != nil {
+		return nil, err
+	}
+	delta := &DecorationsDelta{Fingerprint: DecorationsFingerprint(cur.Reference)}
+	if fingerprint == "" {
+		delta.Added = cur.Reference
+		return delta, nil
+	}
+
+	prevReq := proto.Clone(curReq).(*xpb.DecorationsRequest)
+	prevReq.DirtyBuffer = previousBuffer
+	prevReq.DisplayNames = false
+	prev, err := t.authorizedDecorations(ctx, prevReq)
+	if err != nil {
+		return nil, err
+	} else if DecorationsFingerprint(prev.Reference) != fingerprint {
+		return nil, status.Error(codes.FailedPrecondition, "decorations fingerprint mismatch")
+	}
+
+	prevText, curText := previousBuffer, req.DirtyBuffer
+	if len(prevText) == 0 || len(curText) == 0 {
+		ticket, err := kytheuri.Fix(req.GetLocation().GetTicket())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ticket %q: %v", req.GetLocation().GetTicket(), err)
+		}
+		decor, err := t.decorationsWithOverride(ctx, ticket)
+		if err == table.ErrNoSuchKey {
+			return nil, xrefs.ErrDecorationsNotFound
+		} else if err != nil {
+			return nil, canonicalError(err, "file decorations", ticket)
+		}
+		if len(prevText) == 0 {
+			prevText = decor.GetFile().GetText()
+		}
+		if len(curText) == 0 {
+			curText = decor.GetFile().GetText()
+		}
+	}
+	patcher, err := span.NewPatcher(prevText, curText)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error patching decorations for %s: %v", req.GetLocation().GetTicket(), err)
+	}
+
+	type refKey struct {
+		target, kind, buildConfig string
+		start, end                int32
+	}
+	current := make(map[refKey][]*xpb.DecorationsReply_Reference)
+	for _, r := range cur.Reference {
+		start, end := span.ByteOffsets(r.Span)
+		k := refKey{r.TargetTicket, r.Kind, r.BuildConfig, start, end}
+		current[k] = append(current[k], r)
+	}
+
+	for _, r := range prev.Reference {
+		oldStart, oldEnd := span.ByteOffsets(r.Span)
+		start, end, exists := patcher.Patch(oldStart, oldEnd)
+		k := refKey{r.TargetTicket, r.Kind, r.BuildConfig, start, end}
+		if !exists || len(current[k]) == 0 {
+			delta.Removed = append(delta.Removed, r)
+			continue
+		}
+		match := current[k][0]
+		current[k] = current[k][1:]
+		if start != oldStart || end != oldEnd {
+			delta.Moved = append(delta.Moved, &MovedReference{Previous: r, Current: match})
+		}
+	}
+
+	// Any remaining current references were not matched by a previous reference.
+	for _, r := range cur.Reference {
+		start, end := span.ByteOffsets(r.Span)
+		k := refKey{r.TargetTicket, r.Kind, r.BuildConfig, start, end}
+		if rs := current[k]; len(rs) > 0 && rs[0] == r {
+			delta.Added = append(delta.Added, r)
+			current[k] = rs[1:]
+		}
+	}
+	tracePrintf(ctx, "DecorationsDelta: +%d -%d ~%d", len(delta.Added), len(delta.Removed), len(delta.Moved))
+	return delta, nil
+}
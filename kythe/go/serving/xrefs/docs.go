@@ -0,0 +1,109 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/util/log"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// A documentLookup returns the Document stored for a ticket.
+type documentLookup func(ctx context.Context, ticket string) (*srvpb.Document, error)
+
+// BatchDocumentation serves the documentation of each of the request's
+// tickets as a separate DocumentationReply, e.g. to prefetch the hover text of
+// every symbol in a viewport.  Each reply holds the ticket's document along
+// with only the nodes and definition locations it references.  Duplicate
+// tickets are served once and each stored Document, including those shared
+// through DocumentedBy or as children, is read at most once per call.  Tickets
+// without documentation are omitted from the result, which is keyed by the
+// fixed form of each ticket.  The Table's ReadQuota applies to the batch as a
+// whole.
+func (t *Table) BatchDocumentation(ctx context.Context, req *xpb.DocumentationRequest) (map[string]*xpb.DocumentationReply, error) {
+	ctx = log.EnsureRequestID(ctx)
+	var v validate.Validator
+	tickets := v.Tickets("ticket", req.Ticket)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	ctx, budget := t.withReadBudget(ctx)
+	docs := &documentCache{documentation: t.documentation}
+	res := make(map[string]*xpb.DocumentationReply, len(tickets))
+	served := make(map[string]bool, len(tickets))
+	for _, ticket := range tickets {
+		if served[ticket] {
+			continue
+		}
+		served[ticket] = true
+
+		r := proto.Clone(req).(*xpb.DocumentationRequest)
+		r.Ticket = []string{ticket}
+		reply, err := t.authorizedDocumentation(ctx, r, docs.lookup)
+		if err != nil {
+			_, err = quotaReply(budget, reply, err)
+			return nil, err
+		} else if len(reply.Document) > 0 {
+			res[ticket] = reply
+		}
+	}
+	tracePrintf(ctx, "Batch documents: %d/%d (reads: %d)", len(res), len(served), len(docs.reads))
+	return res, nil
+}
+
+// A documentCache memoizes the Documents read for a single request.
+type documentCache struct {
+	documentation documentLookup
+	reads         map[string]*documentRead
+}
+
+type documentRead struct {
+	doc *srvpb.Document
+	err error
+}
+
+// lookup returns the Document for ticket, resolving any DocumentedBy
+// indirection through the cache.
+func (c *documentCache) lookup(ctx context.Context, ticket string) (*srvpb.Document, error) {
+	return resolveDocument(ctx, ticket, c.read)
+}
+
+// read returns a copy of the stored Document for ticket, reading it only if it
+// has not already been read.  A copy is returned since resolveDocument
+// modifies subsuming Documents.
+func (c *documentCache) read(ctx context.Context, ticket string) (*srvpb.Document, error) {
+	r, ok := c.reads[ticket]
+	if !ok {
+		d, err := c.documentation(ctx, ticket)
+		r = &documentRead{doc: d, err: err}
+		if c.reads == nil {
+			c.reads = make(map[string]*documentRead)
+		}
+		c.reads[ticket] = r
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return proto.Clone(r.doc).(*srvpb.Document), nil
+}
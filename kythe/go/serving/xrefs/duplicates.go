@@ -0,0 +1,70 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/storage/table"
+
+	"bitbucket.org/creachadair/stringset"
+)
+
+// DuplicateFiles returns the tickets of other files whose contents are
+// identical to those of the given file (e.g. vendored copies), as recorded in
+// the serving table's file digest index.  The returned tickets are sorted and
+// exclude the given file and those that are not authorized.  If the file has
+// no duplicates, nil is returned.
+func (t *Table) DuplicateFiles(ctx context.Context, ticket string) ([]string, error) {
+	var v validate.Validator
+	ticket = v.Ticket("ticket", ticket)
+	if err := v.Err(); err != nil {
+		return nil, err
+	} else if err := t.authorizeRequest(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	decor, err := t.fileDecorations(ctx, ticket)
+	if err == table.ErrNoSuchKey || (err == nil && decor.File == nil) {
+		return nil, xrefs.ErrDecorationsNotFound
+	} else if err != nil {
+		return nil, canonicalError(err, "file decorations", ticket)
+	}
+
+	digest := xrefs.FileDigest(decor.File.Text)
+	d, err := t.fileDigest(ctx, digest)
+	if err == table.ErrNoSuchKey {
+		return nil, nil
+	} else if err != nil {
+		return nil, canonicalError(err, "file digest", digest)
+	}
+
+	denied, err := t.authorize(ctx, stringset.New(d.Ticket...))
+	if err != nil {
+		return nil, err
+	}
+	var dups []string
+	for _, f := range d.Ticket {
+		if f != ticket && !denied.Contains(f) {
+			dups = append(dups, f)
+		}
+	}
+	tracePrintf(ctx, "Found %d duplicates of %s", len(dups), ticket)
+	return dups, nil
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"kythe.io/kythe/go/util/log"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	kinds "kythe.io/kythe/go/util/schema/nodes"
+	"kythe.io/kythe/go/util/schema/tickets"
+	"kythe.io/kythe/go/util/span"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// maxFallbackEdges is the maximum number of edges read to reconstruct the
+// cross-references of a single ticket from its edges.
+const maxFallbackEdges = 10000
+
+// crossReferencesFromEdges reconstructs the anchor groups of the given ticket
+// from its reverse anchor edges in t.EdgeFallback, for a ticket missing from
+// the cross-references table.  It returns nil if the request does not ask for
+// the fallback or no anchors are found.  Failures are logged rather than
+// returned; the fallback is best-effort.
+func (t *Table) crossReferencesFromEdges(ctx context.Context, req *xpb.CrossReferencesRequest, ticket string) *srvpb.PagedCrossReferences {
+	if !req.GetEdgeFallback() || t.EdgeFallback == nil {
+		return nil
+	}
+	reply, err := t.EdgeFallback.Edges(ctx, &gpb.EdgesRequest{
+		Ticket:   []string{ticket},
+		Filter:   []string{facts.NodeKind, facts.AnchorStart, facts.AnchorEnd},
+		PageSize: maxFallbackEdges,
+	})
+	if err != nil {
+		log.Warningf(ctx, "reading edges of %q for cross-references fallback: %v", ticket, err)
+		return nil
+	}
+
+	type fileSpans struct {
+		text []byte
+		norm *span.Normalizer
+	}
+	files := make(map[string]*fileSpans)
+	fileText := func(file string) ([]byte, *span.Normalizer) {
+		f, ok := files[file]
+		if !ok {
+			f = &fileSpans{}
+			decor, err := t.decorationsWithOverride(ctx, file)
+			if err == nil {
+				err = t.resolveFileContent(ctx, file, decor)
+			}
+			if err != nil {
+				log.Warningf(ctx, "reading text of %q for cross-references fallback: %v", file, err)
+			} else if f.text = decor.GetFile().GetText(); f.text != nil {
+				f.norm = span.NewNormalizer(f.text)
+			}
+			files[file] = f
+		}
+		return f.text, f.norm
+	}
+
+	var groups []*srvpb.PagedCrossReferences_Group
+	for kind, grp := range reply.GetEdgeSets()[ticket].GetGroups() {
+		if !edges.IsReverse(kind) {
+			continue
+		}
+		g := &srvpb.PagedCrossReferences_Group{Kind: edges.Mirror(kind)}
+		for _, e := range grp.GetEdge() {
+			info := reply.GetNodes()[e.GetTargetTicket()]
+			if string(info.GetFacts()[facts.NodeKind]) != kinds.Anchor {
+				continue
+			}
+			a, ok := fallbackAnchor(e.GetTargetTicket(), g.Kind, info, fileText)
+			if ok {
+				g.Anchor = append(g.Anchor, a)
+			}
+		}
+		if len(g.Anchor) == 0 {
+			continue
+		}
+		sort.Slice(g.Anchor, func(i, j int) bool { return g.Anchor[i].Ticket < g.Anchor[j].Ticket })
+		groups = append(groups, g)
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Kind < groups[j].Kind })
+	return &srvpb.PagedCrossReferences{SourceTicket: ticket, Group: groups}
+}
+
+// fallbackAnchor returns the anchor with the given ticket, edge kind, and
+// offset facts.  Its span and text are resolved against the text of its file,
+// if known.
+func fallbackAnchor(ticket, kind string, info *cpb.NodeInfo, fileText func(string) ([]byte, *span.Normalizer)) (*srvpb.ExpandedAnchor, bool) {
+	start, err := strconv.Atoi(string(info.GetFacts()[facts.AnchorStart]))
+	if err != nil {
+		return nil, false
+	}
+	end, err := strconv.Atoi(string(info.GetFacts()[facts.AnchorEnd]))
+	if err != nil || end < start {
+		return nil, false
+	}
+	a := &srvpb.ExpandedAnchor{
+		Ticket: ticket,
+		Kind:   kind,
+		Span: &cpb.Span{
+			Start: &cpb.Point{ByteOffset: int32(start)},
+			End:   &cpb.Point{ByteOffset: int32(end)},
+		},
+	}
+	file, err := tickets.AnchorFile(ticket)
+	if err != nil {
+		return a, true
+	}
+	if text, norm := fileText(file); norm != nil && end <= len(text) {
+		a.Span = norm.SpanOffsets(int32(start), int32(end))
+		a.Text = string(text[start:end])
+	}
+	return a, true
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// A fieldMask is the set of top-level reply message fields a client requested
+// be populated.  A nil fieldMask includes every field.
+type fieldMask map[string]bool
+
+// newFieldMask returns the fieldMask for m, which must name only top-level
+// fields of msg.  A nil or empty m returns a nil fieldMask.
+func newFieldMask(m *fieldmaskpb.FieldMask, msg proto.Message) (fieldMask, error) {
+	if len(m.GetPaths()) == 0 {
+		return nil, nil
+	} else if !m.IsValid(msg) {
+		return nil, fmt.Errorf("paths must name fields of %s", msg.ProtoReflect().Descriptor().Name())
+	}
+	mask := make(fieldMask, len(m.GetPaths()))
+	for _, p := range m.GetPaths() {
+		if strings.Contains(p, ".") {
+			return nil, fmt.Errorf("unsupported nested path: %q", p)
+		}
+		mask[p] = true
+	}
+	return mask, nil
+}
+
+// includes reports whether the named field should be populated.
+func (m fieldMask) includes(field string) bool { return m == nil || m[field] }
+
+// names reports whether the named field is listed by the mask.  Fields that
+// are costly or change every reply, such as an Anchor's classification, are
+// populated only if named.
+func (m fieldMask) names(field string) bool { return m[field] }
+
+// with returns a fieldMask also including the named field.
+func (m fieldMask) with(field string) fieldMask {
+	if m.includes(field) {
+		return m
+	}
+	res := make(fieldMask, len(m)+1)
+	for f := range m {
+		res[f] = true
+	}
+	res[field] = true
+	return res
+}
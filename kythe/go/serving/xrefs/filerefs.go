@@ -0,0 +1,119 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/storage/table"
+
+	"bitbucket.org/creachadair/stringset"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// FileReferencedNodes returns the nodes referenced from within the given file
+// along with the number of anchors in the file referring to each, as recorded
+// in the serving table's file references index.  The nodes are sorted by
+// ticket; unauthorized nodes are omitted.  If the index has no entry for the
+// file, nil is returned.
+func (t *Table) FileReferencedNodes(ctx context.Context, ticket string) ([]*srvpb.FileReferences_Node, error) {
+	var v validate.Validator
+	ticket = v.Ticket("ticket", ticket)
+	if err := v.Err(); err != nil {
+		return nil, err
+	} else if err := t.authorizeRequest(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	r, err := t.fileReferences(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return nil, nil
+	} else if err != nil {
+		return nil, canonicalError(err, "file references", ticket)
+	}
+
+	var tickets stringset.Set
+	for _, n := range r.Node {
+		tickets.Add(n.Ticket)
+	}
+	denied, err := t.authorize(ctx, tickets)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*srvpb.FileReferences_Node, 0, len(r.Node))
+	for _, n := range r.Node {
+		if !denied.Contains(n.Ticket) {
+			nodes = append(nodes, n)
+		}
+	}
+	tracePrintf(ctx, "Found %d nodes referenced from %s", len(nodes), ticket)
+	return nodes, nil
+}
+
+// FileRelations returns the files included or imported by the given file and
+// those including or importing it, as recorded in the serving table's file
+// relations index.  Unauthorized related files are omitted.  If the index has
+// no entry for the file, nil is returned.
+func (t *Table) FileRelations(ctx context.Context, ticket string) (*srvpb.FileRelations, error) {
+	var v validate.Validator
+	ticket = v.Ticket("ticket", ticket)
+	if err := v.Err(); err != nil {
+		return nil, err
+	} else if err := t.authorizeRequest(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	r, err := t.fileRelations(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return nil, nil
+	} else if err != nil {
+		return nil, canonicalError(err, "file relations", ticket)
+	}
+
+	var tickets stringset.Set
+	for _, rel := range r.Outgoing {
+		tickets.Add(rel.Ticket)
+	}
+	for _, rel := range r.Incoming {
+		tickets.Add(rel.Ticket)
+	}
+	denied, err := t.authorize(ctx, tickets)
+	if err != nil {
+		return nil, err
+	} else if !denied.Empty() {
+		r = &srvpb.FileRelations{
+			File:     r.File,
+			Outgoing: allowedRelations(r.Outgoing, denied),
+			Incoming: allowedRelations(r.Incoming, denied),
+		}
+	}
+	tracePrintf(ctx, "Found %d/%d files related to %s", len(r.Outgoing), len(r.Incoming), ticket)
+	return r, nil
+}
+
+// allowedRelations returns the relations whose files are not denied.
+func allowedRelations(rels []*srvpb.FileRelations_Relation, denied stringset.Set) []*srvpb.FileRelations_Relation {
+	var allowed []*srvpb.FileRelations_Relation
+	for _, rel := range rels {
+		if !denied.Contains(rel.Ticket) {
+			allowed = append(allowed, rel)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"strconv"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// GetFileOptions restrict the content returned by GetFile.
+type GetFileOptions struct {
+	// Start and End select the half-open byte range [Start, End) of the file's
+	// text to return.  An End <= 0 extends the range to the end of the file.
+	Start, End int
+
+	// Digest, if set, is the expected digest of the file (see
+	// xrefs.FileDigest).  GetFile fails with FAILED_PRECONDITION if the file's
+	// digest differs, e.g. because the file changed between range requests.
+	Digest string
+}
+
+// A FileContent is a range of the raw content of a file.
+type FileContent struct {
+	// Text is the requested range of the file's text.
+	Text []byte
+
+	// Start and End are the byte offsets of Text within the file.
+	Start, End int
+
+	// Size is the length of the file's text in bytes.
+	Size int
+
+	// Encoding is the file's text encoding, if known.
+	Encoding string
+
+	// Digest is the digest of the file's full text (see xrefs.FileDigest).  It
+	// is computed only for ranges starting at the beginning of the file and
+	// for requests checking GetFileOptions.Digest; otherwise it is empty.
+	Digest string
+}
+
+// GetFile returns the text of the file with the given ticket, or the range of
+// it selected by opts, without serving its decorations.  The file is read
+// with any override in t.DecorationOverrides and its content resolved by
+// t.ContentResolver if not stored.  A range beyond the end of the file fails
+// with OUT_OF_RANGE.
+func (t *Table) GetFile(ctx context.Context, ticket string, opts *GetFileOptions) (*FileContent, error) {
+	if opts == nil {
+		opts = &GetFileOptions{}
+	}
+	var v validate.Validator
+	ticket = v.Ticket("ticket", ticket)
+	if opts.Start < 0 {
+		v.Addf("start", strconv.Itoa(opts.Start), "must be non-negative")
+	}
+	if opts.End > 0 && opts.End < opts.Start {
+		v.Addf("end", strconv.Itoa(opts.End), "must not precede start %d", opts.Start)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	} else if err := t.authorizeRequest(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	file, err := t.fileWithOverride(ctx, ticket)
+	if err == table.ErrNoSuchKey || (err == nil && file == nil) {
+		return nil, xrefs.ErrDecorationsNotFound
+	} else if err != nil {
+		return nil, canonicalError(err, "file decorations", ticket)
+	}
+	decor := &srvpb.FileDecorations{File: file}
+	if err := t.resolveFileContent(ctx, ticket, decor); err != nil {
+		return nil, err
+	}
+
+	text := decor.File.Text
+	var digest string
+	if opts.Digest != "" || opts.Start == 0 {
+		digest = xrefs.FileDigest(text)
+	}
+	if opts.Digest != "" && opts.Digest != digest {
+		return nil, status.Errorf(codes.FailedPrecondition, "file %q has digest %s; expected %s", ticket, digest, opts.Digest)
+	}
+	end := opts.End
+	if end <= 0 {
+		end = len(text)
+	}
+	if opts.Start > len(text) || end > len(text) {
+		return nil, status.Errorf(codes.OutOfRange, "range [%d, %d) exceeds size of file %q (%d bytes)", opts.Start, end, ticket, len(text))
+	}
+	tracePrintf(ctx, "Serving file content: %s [%d, %d)", ticket, opts.Start, end)
+	return &FileContent{
+		// The text may be shared with cached or overriding tables.
+		Text:     append([]byte(nil), text[opts.Start:end]...),
+		Start:    opts.Start,
+		End:      end,
+		Size:     len(text),
+		Encoding: decor.File.Encoding,
+		Digest:   digest,
+	}, nil
+}
+
+// fileWithOverride returns the File of the FileDecorations for the given file
+// ticket, or that of its override in t.DecorationOverrides, if any.  Unlike
+// decorationsWithOverride, it reads none of the file's decorations.
+func (t *Table) fileWithOverride(ctx context.Context, ticket string) (*srvpb.File, error) {
+	if t.DecorationOverrides != nil {
+		if f := t.DecorationOverrides.get(ticket).GetFile(); f != nil {
+			return f, nil
+		}
+	}
+	return t.file(ctx, ticket)
+}
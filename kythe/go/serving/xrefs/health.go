@@ -0,0 +1,239 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"kythe.io/kythe/go/util/kytheuri"
+
+	"bitbucket.org/creachadair/stringset"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultHealthWindow is the default length of time over which a
+// HealthMonitor aggregates requests.
+const DefaultHealthWindow = 5 * time.Minute
+
+const (
+	// healthBuckets is the number of intervals into which a HealthMonitor's
+	// window is divided.  Requests expire an interval at a time.
+	healthBuckets = 30
+
+	// latencyBuckets is the number of latency histogram buckets of each
+	// interval.  Bucket i holds latencies of at most 1µs * √2^i, and the last
+	// bucket holds all larger latencies.
+	latencyBuckets = 64
+)
+
+// latencyBounds are the upper bounds of each latency histogram bucket.
+var latencyBounds = func() (bs [latencyBuckets]time.Duration) {
+	for i := range bs {
+		bs[i] = time.Duration(float64(time.Microsecond) * math.Pow(math.Sqrt2, float64(i)))
+	}
+	return
+}()
+
+// A HealthMonitor aggregates the requests served by a Table by the corpus of
+// their tickets, e.g. so that load balancers and dashboards of a deployment
+// sharded by corpus can monitor each backend.  Each corpus's requests are
+// counted in a fixed number of intervals, each with a latency histogram, so
+// its memory use does not grow with the request rate.  It is safe for
+// concurrent use and, as an http.Handler, serves its Health report as JSON.
+type HealthMonitor struct {
+	// Window is the length of time over which requests are aggregated.  If
+	// <= 0, DefaultHealthWindow is used.
+	Window time.Duration
+
+	corpora sync.Map // corpus -> *corpusHealth
+
+	// now returns the current time; replaced in tests.
+	now func() time.Time
+}
+
+// corpusHealth holds the recent requests of a single corpus in a ring of
+// intervals.
+type corpusHealth struct {
+	mu        sync.Mutex
+	intervals [healthBuckets]healthInterval
+
+	last       int64 // interval of the most recent request
+	lastFailed bool  // whether the most recent request failed
+}
+
+type healthInterval struct {
+	index      int64 // the interval's number since the epoch
+	requests   int
+	errors     int
+	maxLatency time.Duration
+	latencies  [latencyBuckets]int32
+}
+
+// A CorpusHealth summarizes the recent requests for a single corpus.
+type CorpusHealth struct {
+	Corpus string `json:"corpus"`
+
+	// Requests is the number of requests within the monitor's window and
+	// Errors is the number of those that failed with a server error.
+	// Requests failing due to the request itself (e.g. an invalid or
+	// unauthorized ticket) are not counted as errors.
+	Requests  int     `json:"requests"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"error_rate"`
+
+	// MedianLatency is the median latency of the requests, rounded up to the
+	// bound of its histogram bucket (within a factor of √2) but no greater
+	// than the largest latency.
+	MedianLatency time.Duration `json:"median_latency_ns"`
+
+	// Reachable reports whether the most recent request succeeded or failed
+	// only due to the request itself.
+	Reachable bool `json:"reachable"`
+}
+
+// NewHealthMonitor returns an empty HealthMonitor.
+func NewHealthMonitor() *HealthMonitor { return &HealthMonitor{now: time.Now} }
+
+// RecordRequest adds a request for the given tickets, completed now with the
+// given latency and error, to each of their corpora.
+func (m *HealthMonitor) RecordRequest(tickets []string, latency time.Duration, err error) {
+	corpora := stringset.New()
+	for _, ticket := range tickets {
+		if uri, err := kytheuri.Parse(ticket); err == nil {
+			corpora.Add(uri.Corpus)
+		}
+	}
+	index := m.interval()
+	failed := isServerError(err)
+	bucket := sort.Search(latencyBuckets-1, func(i int) bool { return latency <= latencyBounds[i] })
+	for corpus := range corpora {
+		c, ok := m.corpora.Load(corpus)
+		if !ok {
+			c, _ = m.corpora.LoadOrStore(corpus, &corpusHealth{})
+		}
+		h := c.(*corpusHealth)
+		h.mu.Lock()
+		in := &h.intervals[index%healthBuckets]
+		if in.index != index {
+			*in = healthInterval{index: index}
+		}
+		in.requests++
+		if failed {
+			in.errors++
+		}
+		if latency > in.maxLatency {
+			in.maxLatency = latency
+		}
+		in.latencies[bucket]++
+		h.last, h.lastFailed = index, failed
+		h.mu.Unlock()
+	}
+}
+
+// interval returns the number of the monitor's current interval.
+func (m *HealthMonitor) interval() int64 {
+	window := m.Window
+	if window <= 0 {
+		window = DefaultHealthWindow
+	}
+	width := window / healthBuckets
+	if width <= 0 {
+		width = 1
+	}
+	return m.now().UnixNano() / int64(width)
+}
+
+// Health returns the health of each corpus requested within the monitor's
+// window, ordered by corpus.
+func (m *HealthMonitor) Health() []*CorpusHealth {
+	oldest := m.interval() - healthBuckets + 1
+	var res []*CorpusHealth
+	m.corpora.Range(func(k, v any) bool {
+		if h := v.(*corpusHealth).health(k.(string), oldest); h != nil {
+			res = append(res, h)
+		}
+		return true
+	})
+	sort.Slice(res, func(i, j int) bool { return res[i].Corpus < res[j].Corpus })
+	return res
+}
+
+// health summarizes the requests of the intervals since oldest, returning nil
+// if there are none.
+func (c *corpusHealth) health(corpus string, oldest int64) *CorpusHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.last < oldest {
+		return nil
+	}
+	h := &CorpusHealth{Corpus: corpus, Reachable: !c.lastFailed}
+	var latencies [latencyBuckets]int
+	var maxLatency time.Duration
+	for i := range c.intervals {
+		in := &c.intervals[i]
+		if in.index < oldest || in.requests == 0 {
+			continue
+		}
+		h.Requests += in.requests
+		h.Errors += in.errors
+		if in.maxLatency > maxLatency {
+			maxLatency = in.maxLatency
+		}
+		for j, n := range in.latencies {
+			latencies[j] += int(n)
+		}
+	}
+	if h.Requests == 0 {
+		return nil
+	}
+	h.ErrorRate = float64(h.Errors) / float64(h.Requests)
+	h.MedianLatency = maxLatency
+	median := h.Requests / 2
+	for i, n := range latencies[:latencyBuckets-1] {
+		if median -= n; median < 0 {
+			if latencyBounds[i] < maxLatency {
+				h.MedianLatency = latencyBounds[i]
+			}
+			break
+		}
+	}
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (m *HealthMonitor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m.Health()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// isServerError reports whether err is a failure of the serving layer rather
+// than of the request itself.
+func isServerError(err error) bool {
+	switch status.Code(err) {
+	case codes.OK, codes.InvalidArgument, codes.NotFound, codes.PermissionDenied, codes.Unauthenticated, codes.Canceled, codes.FailedPrecondition, codes.OutOfRange:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"kythe.io/kythe/go/services/web"
+	"kythe.io/kythe/go/util/log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// RegisterHTTPHandlers registers JSON HTTP handlers with mux for the Table
+// methods beyond those of the xrefs Service (see xrefs.RegisterHTTPHandlers).
+// The following methods will be exposed:
+//
+//	GET /documentation/batch
+//	  Request: JSON encoded xrefs.DocumentationRequest
+//	  Response: JSON encoded xrefs.BatchDocumentationReply
+//	GET /anchors/fingerprint
+//	  Request: JSON encoded xrefs.ResolveFingerprintRequest
+//	  Response: JSON encoded xrefs.ResolveFingerprintReply
+//	GET /members
+//	  Request: JSON encoded xrefs.MembersRequest
+//	  Response: JSON encoded xrefs.MembersReply
+//	GET /outline
+//	  Request: JSON encoded xrefs.OutlineRequest
+//	  Response: JSON encoded xrefs.OutlineReply
+//
+// Note: each response is returned as a serialized protobuf if the "proto"
+// query parameter is set.
+func RegisterHTTPHandlers(ctx context.Context, t *Table, mux *http.ServeMux) {
+	mux.HandleFunc("/documentation/batch", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Infof(ctx, "xrefs.BatchDocumentation:\t%s", time.Since(start))
+		}()
+		var req xpb.DocumentationRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		replies, err := t.BatchDocumentation(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := web.WriteResponse(w, r, &xpb.BatchDocumentationReply{Replies: replies}); err != nil {
+			log.Errorf(ctx, "writing BatchDocumentation response: %v", err)
+		}
+	})
+
+	mux.HandleFunc("/anchors/fingerprint", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Infof(ctx, "xrefs.ResolveFingerprint:\t%s", time.Since(start))
+		}()
+		var req xpb.ResolveFingerprintRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, err := t.ResolveFingerprint(ctx, req.Ticket, req.Fingerprint)
+		if err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+
+		if err := web.WriteResponse(w, r, &xpb.ResolveFingerprintReply{
+			Anchor:       res.Anchor,
+			TargetTicket: res.TargetTicket,
+		}); err != nil {
+			log.Errorf(ctx, "writing ResolveFingerprint response: %v", err)
+		}
+	})
+
+	mux.HandleFunc("/members", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Infof(ctx, "xrefs.Members:\t%s", time.Since(start))
+		}()
+		var req xpb.MembersRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := t.Members(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Errorf(ctx, "writing Members response: %v", err)
+		}
+	})
+
+	mux.HandleFunc("/outline", func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			log.Infof(ctx, "xrefs.Outline:\t%s", time.Since(start))
+		}()
+		var req xpb.OutlineRequest
+		if err := web.ReadJSONBody(r, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply, err := t.Outline(ctx, &req)
+		if err != nil {
+			http.Error(w, err.Error(), httpStatus(err))
+			return
+		}
+
+		if err := web.WriteResponse(w, r, reply); err != nil {
+			log.Errorf(ctx, "writing Outline response: %v", err)
+		}
+	})
+}
+
+// httpStatus returns the HTTP status code corresponding to the gRPC status of
+// the given non-nil error.
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
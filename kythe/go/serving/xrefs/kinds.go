@@ -0,0 +1,126 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"sort"
+
+	"kythe.io/kythe/go/util/schema/edges"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// canonicalKind returns kind without any legacy ordinal suffix.
+func canonicalKind(kind string) string {
+	base, _, _ := edges.ParseOrdinal(kind)
+	return base
+}
+
+func canonicalizeAnchorKind(a *xpb.Anchor) {
+	if a != nil {
+		a.Kind = canonicalKind(a.Kind)
+	}
+}
+
+func canonicalizeRelatedAnchorKinds(ras []*xpb.CrossReferencesReply_RelatedAnchor) {
+	for _, ra := range ras {
+		canonicalizeAnchorKind(ra.Anchor)
+		for _, site := range ra.Site {
+			canonicalizeAnchorKind(site)
+		}
+	}
+}
+
+func canonicalizeKindCounts(counts map[string]int64) map[string]int64 {
+	if counts == nil {
+		return nil
+	}
+	res := make(map[string]int64, len(counts))
+	for kind, n := range counts {
+		res[canonicalKind(kind)] += n
+	}
+	return res
+}
+
+// canonicalizeDecorationKinds replaces each legacy edge kind with an ordinal
+// suffix in the given reply by its base kind.
+func canonicalizeDecorationKinds(reply *xpb.DecorationsReply) {
+	for _, ref := range reply.Reference {
+		ref.Kind = canonicalKind(ref.Kind)
+	}
+	for _, def := range reply.DefinitionLocations {
+		canonicalizeAnchorKind(def)
+	}
+}
+
+// canonicalizeCrossReferenceKinds replaces each legacy edge kind with an
+// ordinal suffix in the given reply by its base kind.  The suffix of each
+// related node's relation kind is recorded as its ordinal.
+func canonicalizeCrossReferenceKinds(reply *xpb.CrossReferencesReply) {
+	for _, crs := range reply.CrossReferences {
+		canonicalizeRelatedAnchorKinds(crs.Definition)
+		canonicalizeRelatedAnchorKinds(crs.Declaration)
+		canonicalizeRelatedAnchorKinds(crs.Reference)
+		canonicalizeRelatedAnchorKinds(crs.Caller)
+		for _, rn := range crs.RelatedNode {
+			base, ordinal, ok := edges.ParseOrdinal(rn.RelationKind)
+			if !ok {
+				continue
+			}
+			rn.RelationKind = base
+			if rn.Ordinal == 0 {
+				rn.Ordinal = int32(ordinal)
+			}
+		}
+	}
+	for _, def := range reply.DefinitionLocations {
+		canonicalizeAnchorKind(def)
+	}
+	if reply.Total != nil {
+		reply.Total.RelatedNodesByRelation = canonicalizeKindCounts(reply.Total.RelatedNodesByRelation)
+	}
+	if reply.Filtered != nil {
+		reply.Filtered.RelatedNodesByRelation = canonicalizeKindCounts(reply.Filtered.RelatedNodesByRelation)
+	}
+}
+
+// groupReferencesByKind moves the references of each CrossReferenceSet in the
+// given reply into ReferenceGroups by anchor kind, ordered by kind.  Each
+// group keeps the relative order of its references.  Anchor kinds are cleared
+// afterwards if they are excluded by mask.
+func groupReferencesByKind(reply *xpb.CrossReferencesReply, mask fieldMask) {
+	for _, crs := range reply.CrossReferences {
+		var groups []*xpb.CrossReferencesReply_ReferenceGroup
+		byKind := make(map[string]*xpb.CrossReferencesReply_ReferenceGroup)
+		for _, ra := range crs.Reference {
+			kind := ra.GetAnchor().GetKind()
+			g, ok := byKind[kind]
+			if !ok {
+				g = &xpb.CrossReferencesReply_ReferenceGroup{Kind: kind}
+				byKind[kind] = g
+				groups = append(groups, g)
+			}
+			g.Reference = append(g.Reference, ra)
+			if !mask.includes("kind") && ra.Anchor != nil {
+				ra.Anchor.Kind = ""
+			}
+		}
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Kind < groups[j].Kind })
+		crs.ReferenceGroup = groups
+		crs.Reference = nil
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/log"
+
+	"google.golang.org/protobuf/proto"
+
+	ipb "kythe.io/kythe/proto/internal_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// Members returns a page of the nodes of the requested kind contained by the
+// requested node or file (e.g. all classes in a package or all functions in a
+// file), as precomputed in the serving table's container members index.  A
+// container without members of the kind has an empty reply.
+func (t *Table) Members(ctx context.Context, req *xpb.MembersRequest) (*xpb.MembersReply, error) {
+	ctx = log.EnsureRequestID(ctx)
+	var v validate.Validator
+	container := v.Ticket("container", req.Container)
+	if req.Kind == "" {
+		v.Addf("kind", "", "missing node kind")
+	}
+	v.PageSize("page_size", req.PageSize)
+	skip, err := parseIndexPageToken(req.PageToken)
+	v.PageToken("page_token", req.PageToken, err)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := t.authorizeRequest(ctx, container); err != nil {
+		return nil, err
+	}
+
+	cm, err := t.containerMembers(ctx, container, req.Kind)
+	if err == table.ErrNoSuchKey {
+		return &xpb.MembersReply{}, nil
+	} else if err != nil {
+		return nil, canonicalError(err, "container members", container)
+	}
+
+	members, err := t.authorizedMembers(ctx, cm.Member)
+	if err != nil {
+		return nil, err
+	}
+	reply := &xpb.MembersReply{Total: int64(len(members))}
+	members, reply.NextPageToken, err = indexPage(members, skip, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	converter := &anchorConverter{anchorText: true}
+	for _, m := range members {
+		member := &xpb.MembersReply_Member{
+			Ticket:      m.Ticket,
+			Subkind:     m.Subkind,
+			DisplayName: m.DisplayName,
+		}
+		if m.Definition != nil {
+			member.Definition = converter.Convert(m.Definition).Anchor
+		}
+		reply.Member = append(reply.Member, member)
+	}
+	tracePrintf(ctx, "Members: %d/%d", len(reply.Member), reply.Total)
+	return reply, nil
+}
+
+// indexPage returns the page of the given elements following the first skip
+// elements along with the page token of the next page, if any.  A pageSize of
+// 0 selects the default page size.
+func indexPage[T any](elems []T, skip int, pageSize int32) ([]T, string, error) {
+	if skip > len(elems) {
+		skip = len(elems)
+	}
+	elems = elems[skip:]
+	size := int(pageSize)
+	if size == 0 {
+		size = defaultPageSize
+	} else if size > maxPageSize {
+		size = maxPageSize
+	}
+	if len(elems) <= size {
+		return elems, "", nil
+	}
+	rec, err := proto.Marshal(&ipb.PageToken{Index: int32(skip + size)})
+	if err != nil {
+		return nil, "", fmt.Errorf("internal error: error marshalling page token: %v", err)
+	}
+	return elems[:size], base64.StdEncoding.EncodeToString(rec), nil
+}
+
+// parseIndexPageToken returns the number of elements to skip encoded in the
+// given MembersRequest or RollupRequest page token.  An empty token skips no
+// elements.
+func parseIndexPageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	rec, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	var t ipb.PageToken
+	if err := proto.Unmarshal(rec, &t); err != nil {
+		return 0, err
+	} else if t.Index < 0 {
+		return 0, fmt.Errorf("negative index: %d", t.Index)
+	}
+	return int(t.Index), nil
+}
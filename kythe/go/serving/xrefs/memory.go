@@ -0,0 +1,313 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"sync"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// MemoryTables is an in-memory set of xrefs serving tables.  It is meant for
+// small tools and tests that want to serve xrefs without an on-disk table.
+// Tickets are canonicalized when values are added.  The zero value is not
+// ready for use; see NewMemoryTables.  MemoryTables is safe for concurrent
+// use.
+type MemoryTables struct {
+	mu            sync.RWMutex
+	decorations   map[string]*srvpb.FileDecorations
+	decorPages    map[string]*srvpb.FileDecorationsPage
+	crossRefs     map[string]*srvpb.PagedCrossReferences
+	crossRefPages map[string]*srvpb.PagedCrossReferences_Page
+	documents     map[string]*srvpb.Document
+	names         map[string]*srvpb.DisplayName
+	digests       map[string]*srvpb.FileDigest
+	fileRefs      map[string]*srvpb.FileReferences
+	relations     map[string]*srvpb.FileRelations
+	degrees       map[string]*srvpb.CallDegrees
+	members       map[string]*srvpb.ContainerMembers
+	rollups       map[string]*srvpb.ContainerRollup
+	outlines      map[string]*srvpb.FileOutline
+	aliases       map[string]*srvpb.TicketAlias
+}
+
+// NewMemoryTables returns an empty MemoryTables.
+func NewMemoryTables() *MemoryTables {
+	return &MemoryTables{
+		decorations:   make(map[string]*srvpb.FileDecorations),
+		decorPages:    make(map[string]*srvpb.FileDecorationsPage),
+		crossRefs:     make(map[string]*srvpb.PagedCrossReferences),
+		crossRefPages: make(map[string]*srvpb.PagedCrossReferences_Page),
+		documents:     make(map[string]*srvpb.Document),
+		names:         make(map[string]*srvpb.DisplayName),
+		digests:       make(map[string]*srvpb.FileDigest),
+		fileRefs:      make(map[string]*srvpb.FileReferences),
+		relations:     make(map[string]*srvpb.FileRelations),
+		degrees:       make(map[string]*srvpb.CallDegrees),
+		members:       make(map[string]*srvpb.ContainerMembers),
+		rollups:       make(map[string]*srvpb.ContainerRollup),
+		outlines:      make(map[string]*srvpb.FileOutline),
+		aliases:       make(map[string]*srvpb.TicketAlias),
+	}
+}
+
+// NewMemoryTable returns a table based on the given in-memory tables.  Values
+// added to m after the table is constructed are visible to the table.
+func NewMemoryTable(m *MemoryTables) *Table { return &Table{staticLookupTables: m} }
+
+// PutDecorations adds the given FileDecorations keyed by its file ticket.
+func (m *MemoryTables) PutDecorations(fd *srvpb.FileDecorations) error {
+	ticket, err := fixTicket(fd.GetFile().GetTicket())
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.decorations[ticket] = proto.Clone(fd).(*srvpb.FileDecorations)
+	return nil
+}
+
+// PutCrossReferences adds the given PagedCrossReferences keyed by its source
+// ticket.
+func (m *MemoryTables) PutCrossReferences(cr *srvpb.PagedCrossReferences) error {
+	ticket, err := fixTicket(cr.SourceTicket)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.crossRefs[ticket] = proto.Clone(cr).(*srvpb.PagedCrossReferences)
+	return nil
+}
+
+// PutDecorationsPage adds the given FileDecorationsPage keyed by its page key.
+func (m *MemoryTables) PutDecorationsPage(p *srvpb.FileDecorationsPage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.decorPages[p.PageKey] = proto.Clone(p).(*srvpb.FileDecorationsPage)
+}
+
+// PutCrossReferencesPage adds the given PagedCrossReferences_Page keyed by its
+// page key.
+func (m *MemoryTables) PutCrossReferencesPage(p *srvpb.PagedCrossReferences_Page) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.crossRefPages[p.PageKey] = proto.Clone(p).(*srvpb.PagedCrossReferences_Page)
+}
+
+// PutDocument adds the given Document keyed by its ticket.
+func (m *MemoryTables) PutDocument(d *srvpb.Document) error {
+	ticket, err := fixTicket(d.Ticket)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.documents[ticket] = proto.Clone(d).(*srvpb.Document)
+	return nil
+}
+
+// PutDisplayName adds the given DisplayName keyed by its ticket.
+func (m *MemoryTables) PutDisplayName(n *srvpb.DisplayName) error {
+	ticket, err := fixTicket(n.Ticket)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.names[ticket] = proto.Clone(n).(*srvpb.DisplayName)
+	return nil
+}
+
+// PutFileDigest adds the given FileDigest keyed by its digest.
+func (m *MemoryTables) PutFileDigest(d *srvpb.FileDigest) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.digests[d.Digest] = proto.Clone(d).(*srvpb.FileDigest)
+}
+
+// PutFileReferences adds the given FileReferences keyed by its file ticket.
+func (m *MemoryTables) PutFileReferences(r *srvpb.FileReferences) error {
+	ticket, err := fixTicket(r.File)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fileRefs[ticket] = proto.Clone(r).(*srvpb.FileReferences)
+	return nil
+}
+
+// PutFileRelations adds the given FileRelations keyed by its file ticket.
+func (m *MemoryTables) PutFileRelations(r *srvpb.FileRelations) error {
+	ticket, err := fixTicket(r.File)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.relations[ticket] = proto.Clone(r).(*srvpb.FileRelations)
+	return nil
+}
+
+// PutCallDegrees adds the given CallDegrees keyed by its function ticket.
+func (m *MemoryTables) PutCallDegrees(d *srvpb.CallDegrees) error {
+	ticket, err := fixTicket(d.Ticket)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.degrees[ticket] = proto.Clone(d).(*srvpb.CallDegrees)
+	return nil
+}
+
+// PutContainerMembers adds the given ContainerMembers keyed by its member kind
+// and container ticket.
+func (m *MemoryTables) PutContainerMembers(cm *srvpb.ContainerMembers) error {
+	ticket, err := fixTicket(cm.Container)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.members[membersKey(ticket, cm.Kind)] = proto.Clone(cm).(*srvpb.ContainerMembers)
+	return nil
+}
+
+// PutContainerRollup adds the given ContainerRollup keyed by its container
+// ticket.
+func (m *MemoryTables) PutContainerRollup(r *srvpb.ContainerRollup) error {
+	ticket, err := fixTicket(r.Container)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rollups[ticket] = proto.Clone(r).(*srvpb.ContainerRollup)
+	return nil
+}
+
+// PutFileOutline adds the given FileOutline keyed by its file ticket.
+func (m *MemoryTables) PutFileOutline(o *srvpb.FileOutline) error {
+	ticket, err := fixTicket(o.File)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.outlines[ticket] = proto.Clone(o).(*srvpb.FileOutline)
+	return nil
+}
+
+// PutTicketAlias adds the given TicketAlias keyed by its aliased ticket.
+func (m *MemoryTables) PutTicketAlias(a *srvpb.TicketAlias) error {
+	ticket, err := fixTicket(a.Alias)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aliases[ticket] = proto.Clone(a).(*srvpb.TicketAlias)
+	return nil
+}
+
+// lookupMemory returns a copy of the value for key in vals.  Copies are
+// returned since the Table may modify the values it reads.
+func lookupMemory[T proto.Message](mu *sync.RWMutex, vals map[string]T, key string) (T, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	v, ok := vals[key]
+	if !ok {
+		var zero T
+		return zero, table.ErrNoSuchKey
+	}
+	return proto.Clone(v).(T), nil
+}
+
+func (m *MemoryTables) fileDecorations(ctx context.Context, ticket string) (*srvpb.FileDecorations, error) {
+	tracePrintf(ctx, "Reading FileDecorations: %s", ticket)
+	return lookupMemory(&m.mu, m.decorations, ticket)
+}
+func (m *MemoryTables) fileDecorationsPage(ctx context.Context, key string) (*srvpb.FileDecorationsPage, error) {
+	tracePrintf(ctx, "Reading FileDecorationsPage: %s", key)
+	return lookupMemory(&m.mu, m.decorPages, key)
+}
+func (m *MemoryTables) crossReferences(ctx context.Context, ticket string) (*srvpb.PagedCrossReferences, error) {
+	tracePrintf(ctx, "Reading PagedCrossReferences: %s", ticket)
+	return lookupMemory(&m.mu, m.crossRefs, ticket)
+}
+func (m *MemoryTables) crossReferencesPage(ctx context.Context, key string) (*srvpb.PagedCrossReferences_Page, error) {
+	tracePrintf(ctx, "Reading PagedCrossReferences.Page: %s", key)
+	return lookupMemory(&m.mu, m.crossRefPages, key)
+}
+func (m *MemoryTables) documentation(ctx context.Context, ticket string) (*srvpb.Document, error) {
+	tracePrintf(ctx, "Reading Document: %s", ticket)
+	return lookupMemory(&m.mu, m.documents, ticket)
+}
+func (m *MemoryTables) displayName(ctx context.Context, ticket string) (*srvpb.DisplayName, error) {
+	tracePrintf(ctx, "Reading DisplayName: %s", ticket)
+	return lookupMemory(&m.mu, m.names, ticket)
+}
+func (m *MemoryTables) fileDigest(ctx context.Context, digest string) (*srvpb.FileDigest, error) {
+	tracePrintf(ctx, "Reading FileDigest: %s", digest)
+	return lookupMemory(&m.mu, m.digests, digest)
+}
+func (m *MemoryTables) fileReferences(ctx context.Context, ticket string) (*srvpb.FileReferences, error) {
+	tracePrintf(ctx, "Reading FileReferences: %s", ticket)
+	return lookupMemory(&m.mu, m.fileRefs, ticket)
+}
+func (m *MemoryTables) fileRelations(ctx context.Context, ticket string) (*srvpb.FileRelations, error) {
+	tracePrintf(ctx, "Reading FileRelations: %s", ticket)
+	return lookupMemory(&m.mu, m.relations, ticket)
+}
+func (m *MemoryTables) callDegrees(ctx context.Context, ticket string) (*srvpb.CallDegrees, error) {
+	tracePrintf(ctx, "Reading CallDegrees: %s", ticket)
+	return lookupMemory(&m.mu, m.degrees, ticket)
+}
+func (m *MemoryTables) containerMembers(ctx context.Context, container, kind string) (*srvpb.ContainerMembers, error) {
+	tracePrintf(ctx, "Reading ContainerMembers: %s %s", kind, container)
+	return lookupMemory(&m.mu, m.members, membersKey(container, kind))
+}
+func (m *MemoryTables) containerRollup(ctx context.Context, ticket string) (*srvpb.ContainerRollup, error) {
+	tracePrintf(ctx, "Reading ContainerRollup: %s", ticket)
+	return lookupMemory(&m.mu, m.rollups, ticket)
+}
+func (m *MemoryTables) fileOutline(ctx context.Context, ticket string) (*srvpb.FileOutline, error) {
+	tracePrintf(ctx, "Reading FileOutline: %s", ticket)
+	return lookupMemory(&m.mu, m.outlines, ticket)
+}
+func (m *MemoryTables) ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error) {
+	tracePrintf(ctx, "Reading TicketAlias: %s", ticket)
+	return lookupMemory(&m.mu, m.aliases, ticket)
+}
+
+// decorationsFilter reports exactly which files have decorations, including
+// those added after it is returned.
+func (m *MemoryTables) decorationsFilter(ctx context.Context) (fileFilter, error) {
+	return func(file string) bool {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		_, ok := m.decorations[file]
+		return ok
+	}, nil
+}
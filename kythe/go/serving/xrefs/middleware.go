@@ -0,0 +1,42 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import "kythe.io/kythe/go/services/xrefs"
+
+// A Middleware wraps an xrefs.Service with additional behavior, e.g. logging,
+// authorization, caching, or metrics.  The returned Service should delegate
+// each request it does not itself serve to the wrapped Service.
+type Middleware func(xrefs.Service) xrefs.Service
+
+// Chain returns s wrapped by each of the given middlewares.  The first
+// middleware is outermost: it receives each request first and each reply
+// last.  Nil middlewares are skipped.
+func Chain(s xrefs.Service, mw ...Middleware) xrefs.Service {
+	for i := len(mw) - 1; i >= 0; i-- {
+		if mw[i] != nil {
+			s = mw[i](s)
+		}
+	}
+	return s
+}
+
+// NewSplitTableWithMiddleware returns a table based on the given serving
+// tables (as NewSplitTable) wrapped by the given middlewares (as Chain).
+func NewSplitTableWithMiddleware(c *SplitTable, mw ...Middleware) xrefs.Service {
+	return Chain(NewSplitTable(c), mw...)
+}
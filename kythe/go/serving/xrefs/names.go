@@ -0,0 +1,95 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/storage/table"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// displayNames is a per-request cache of node display names.
+type displayNames struct {
+	t     *Table
+	names map[string]string
+}
+
+func (t *Table) newDisplayNames() *displayNames {
+	return &displayNames{t: t, names: make(map[string]string)}
+}
+
+// Get returns the display name for the given ticket or "" if it is unknown.
+func (d *displayNames) Get(ctx context.Context, ticket string) (string, error) {
+	if name, ok := d.names[ticket]; ok {
+		return name, nil
+	}
+	n, err := d.t.displayName(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		d.names[ticket] = ""
+		return "", nil
+	} else if err != nil {
+		return "", canonicalError(err, "display name", ticket)
+	}
+	d.names[ticket] = n.GetName()
+	return n.GetName(), nil
+}
+
+// addCrossReferencesDisplayNames populates the display names of each NodeInfo
+// and RelatedNode in the given reply.
+func (t *Table) addCrossReferencesDisplayNames(ctx context.Context, reply *xpb.CrossReferencesReply) error {
+	names := t.newDisplayNames()
+	for ticket, info := range reply.Nodes {
+		name, err := names.Get(ctx, ticket)
+		if err != nil {
+			return err
+		}
+		info.DisplayName = name
+	}
+	for _, crs := range reply.CrossReferences {
+		for _, rn := range crs.RelatedNode {
+			name, err := names.Get(ctx, rn.Ticket)
+			if err != nil {
+				return err
+			}
+			rn.DisplayName = name
+		}
+	}
+	return nil
+}
+
+// addDecorationsDisplayNames populates the display names of each NodeInfo and
+// Reference target in the given reply.
+func (t *Table) addDecorationsDisplayNames(ctx context.Context, reply *xpb.DecorationsReply) error {
+	names := t.newDisplayNames()
+	for ticket, info := range reply.Nodes {
+		name, err := names.Get(ctx, ticket)
+		if err != nil {
+			return err
+		}
+		info.DisplayName = name
+	}
+	for _, r := range reply.Reference {
+		name, err := names.Get(ctx, r.TargetTicket)
+		if err != nil {
+			return err
+		}
+		r.TargetDisplayName = name
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"container/list"
+	"context"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kythe.io/kythe/go/storage/table"
+)
+
+// The ticket-keyed lookups of staticLookupTables remembered by a NegativeCache.
+const (
+	negDecorations     = "decorations"
+	negCrossReferences = "crossReferences"
+	negDocumentation   = "documentation"
+	negDisplayName     = "displayName"
+	negFileReferences  = "fileReferences"
+	negFileRelations   = "fileRelations"
+	negCallDegrees     = "callDegrees"
+	negContainerRollup = "containerRollup"
+	negFileOutline     = "fileOutline"
+	negTicketAlias     = "ticketAlias"
+)
+
+var negativeMethods = []string{
+	negDecorations, negCrossReferences, negDocumentation, negDisplayName,
+	negFileReferences, negFileRelations, negCallDegrees, negContainerRollup,
+	negFileOutline, negTicketAlias,
+}
+
+// NegativeCacheOptions configures a NegativeCache.
+type NegativeCacheOptions struct {
+	// Size is the maximum number of missing lookups retained.  If <= 0, 4096
+	// is used.
+	Size int
+
+	// TTL is how long a missing lookup is retained before expiring.  If <= 0,
+	// 1 minute is used.
+	TTL time.Duration
+}
+
+func (o *NegativeCacheOptions) size() int {
+	if o == nil || o.Size <= 0 {
+		return 4096
+	}
+	return o.Size
+}
+
+func (o *NegativeCacheOptions) ttl() time.Duration {
+	if o == nil || o.TTL <= 0 {
+		return time.Minute
+	}
+	return o.TTL
+}
+
+// A NegativeCache is a size- and time-bounded LRU cache of the table lookups
+// of a Table found to have no entry, keyed by lookup method and ticket, so
+// that repeated requests for missing tickets (e.g. from stale client links)
+// do not read the table until their entries expire.  A NegativeCache must
+// only be shared by Tables serving the same data and must be flushed when
+// that data is replaced, as by AdminSwap.  It is safe for concurrent use; its
+// entries are split by ticket among independently locked shards, each an LRU
+// cache of its share of the entries.
+type NegativeCache struct {
+	shards []negativeShard
+	counts map[string]*negativeCounts // by lookup method; never modified
+
+	// now returns the current time; replaced in tests.
+	now func() time.Time
+}
+
+// negativeShardSize is the minimum number of entries of each NegativeCache
+// shard; smaller caches have fewer shards.
+const negativeShardSize = 256
+
+// maxNegativeShards is the maximum number of NegativeCache shards.
+const maxNegativeShards = 16
+
+// A negativeShard holds the entries of a NegativeCache for a subset of
+// tickets.
+type negativeShard struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[negativeKey]*list.Element
+	lru     *list.List
+
+	// epoch is advanced whenever entries of the shard are invalidated, so
+	// that lookups begun before then are not recorded as missing.
+	epoch uint64
+}
+
+type negativeCounts struct{ lookups, hits int64 }
+
+type negativeKey struct{ method, ticket string }
+
+type negativeEntry struct {
+	key     negativeKey
+	expires time.Time
+}
+
+// NewNegativeCache returns an empty NegativeCache.
+func NewNegativeCache(opts *NegativeCacheOptions) *NegativeCache {
+	size := opts.size()
+	n := size / negativeShardSize
+	if n < 1 {
+		n = 1
+	} else if n > maxNegativeShards {
+		n = maxNegativeShards
+	}
+	c := &NegativeCache{
+		shards: make([]negativeShard, n),
+		counts: make(map[string]*negativeCounts, len(negativeMethods)),
+		now:    time.Now,
+	}
+	for i := range c.shards {
+		c.shards[i] = negativeShard{
+			size:    (size + n - 1) / n,
+			ttl:     opts.ttl(),
+			entries: make(map[negativeKey]*list.Element),
+			lru:     list.New(),
+		}
+	}
+	for _, method := range negativeMethods {
+		c.counts[method] = new(negativeCounts)
+	}
+	return c
+}
+
+// NegativeCacheStats reports the state of a NegativeCache.
+type NegativeCacheStats struct {
+	// Entries is the number of missing lookups retained, including any
+	// expired but not yet evicted.
+	Entries int `json:"entries"`
+
+	// Lookups and Hits count the lookups checked against the cache, and those
+	// found to be missing without reading the table, by lookup method.
+	Lookups map[string]int64 `json:"lookups,omitempty"`
+	Hits    map[string]int64 `json:"hits,omitempty"`
+}
+
+// Stats returns the cache's current statistics.
+func (c *NegativeCache) Stats() *NegativeCacheStats {
+	s := &NegativeCacheStats{
+		Lookups: make(map[string]int64),
+		Hits:    make(map[string]int64),
+	}
+	for i := range c.shards {
+		sh := &c.shards[i]
+		sh.mu.Lock()
+		s.Entries += len(sh.entries)
+		sh.mu.Unlock()
+	}
+	for method, n := range c.counts {
+		if lookups := atomic.LoadInt64(&n.lookups); lookups > 0 {
+			s.Lookups[method] = lookups
+		}
+		if hits := atomic.LoadInt64(&n.hits); hits > 0 {
+			s.Hits[method] = hits
+		}
+	}
+	return s
+}
+
+// Flush drops every entry of the cache.  Its statistics are retained.
+func (c *NegativeCache) Flush() {
+	for i := range c.shards {
+		sh := &c.shards[i]
+		sh.mu.Lock()
+		sh.entries = make(map[negativeKey]*list.Element)
+		sh.lru.Init()
+		sh.epoch++
+		sh.mu.Unlock()
+	}
+}
+
+// Invalidate drops the entries of every lookup method for the given tickets,
+// e.g. once entries are written for them.  Lookups of the tickets in flight
+// when Invalidate is called are not recorded as missing once they complete.
+func (c *NegativeCache) Invalidate(tickets ...string) {
+	for _, ticket := range tickets {
+		sh := c.shard(ticket)
+		sh.mu.Lock()
+		for _, method := range negativeMethods {
+			if e, ok := sh.entries[negativeKey{method, ticket}]; ok {
+				sh.remove(e)
+			}
+		}
+		sh.epoch++
+		sh.mu.Unlock()
+	}
+}
+
+// shard returns the shard holding the entries of the given ticket.
+func (c *NegativeCache) shard(ticket string) *negativeShard {
+	if len(c.shards) == 1 {
+		return &c.shards[0]
+	}
+	h := fnv.New32a()
+	h.Write([]byte(ticket))
+	return &c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// contains reports whether the given lookup is known to be missing.  If not,
+// it also returns the epoch to pass to put should the lookup be found
+// missing.
+func (c *NegativeCache) contains(method, ticket string) (bool, uint64) {
+	n := c.counts[method]
+	atomic.AddInt64(&n.lookups, 1)
+	sh := c.shard(ticket)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	e, ok := sh.entries[negativeKey{method, ticket}]
+	if !ok {
+		return false, sh.epoch
+	} else if !c.now().Before(e.Value.(*negativeEntry).expires) {
+		sh.remove(e)
+		return false, sh.epoch
+	}
+	sh.lru.MoveToFront(e)
+	atomic.AddInt64(&n.hits, 1)
+	return true, sh.epoch
+}
+
+// put records the given lookup, begun in the given epoch (see contains), as
+// missing, evicting expired entries and then the least recently used entries
+// to make room.  The lookup is not recorded if the ticket's shard has been
+// invalidated since, as the ticket may since have been written.
+func (c *NegativeCache) put(method, ticket string, epoch uint64) {
+	sh := c.shard(ticket)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if sh.epoch != epoch {
+		return
+	}
+	now := c.now()
+	key := negativeKey{method, ticket}
+	if e, ok := sh.entries[key]; ok {
+		sh.remove(e)
+	}
+	for e := sh.lru.Back(); e != nil; e = sh.lru.Back() {
+		if len(sh.entries) < sh.size && now.Before(e.Value.(*negativeEntry).expires) {
+			break
+		}
+		sh.remove(e)
+	}
+	sh.entries[key] = sh.lru.PushFront(&negativeEntry{key: key, expires: now.Add(sh.ttl)})
+}
+
+func (sh *negativeShard) remove(e *list.Element) {
+	sh.lru.Remove(e)
+	delete(sh.entries, e.Value.(*negativeEntry).key)
+}
+
+// knownMissing reports whether t.NegativeCache, if set, holds the given
+// lookup.  If not, it also returns the epoch to pass to noteMissing.
+func (t *Table) knownMissing(ctx context.Context, method, ticket string) (bool, uint64) {
+	if t.NegativeCache == nil {
+		return false, 0
+	}
+	missing, epoch := t.NegativeCache.contains(method, ticket)
+	if missing {
+		tracePrintf(ctx, "Negative cache hit: %s %s", method, ticket)
+	}
+	return missing, epoch
+}
+
+// noteMissing records the given lookup, begun in the given epoch (see
+// knownMissing), in t.NegativeCache, if set, if it failed with
+// table.ErrNoSuchKey.
+func (t *Table) noteMissing(method, ticket string, epoch uint64, err error) {
+	if t.NegativeCache != nil && err == table.ErrNoSuchKey {
+		t.NegativeCache.put(method, ticket, epoch)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"sort"
+
+	"kythe.io/kythe/go/util/compare"
+	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/tickets"
+
+	cpb "kythe.io/kythe/proto/common_go_proto"
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// anchorOrder orders anchors by their file's corpus, root, and path and then by
+// their offsets within the file.  The file is derived from each anchor's ticket
+// and the offsets are those of its stored span, as recorded by record before
+// the request's anchor mask is applied, so the order does not depend on the
+// mask.  Anchors without a recorded span are ordered by their reply span.
+type anchorOrder struct {
+	files map[string]*kytheuri.URI
+	spans map[string]*cpb.Span
+}
+
+func newAnchorOrder() *anchorOrder {
+	return &anchorOrder{
+		files: make(map[string]*kytheuri.URI),
+		spans: make(map[string]*cpb.Span),
+	}
+}
+
+// record remembers the stored span of the given anchor.
+func (o *anchorOrder) record(a *srvpb.ExpandedAnchor) {
+	if a.GetSpan() != nil {
+		o.spans[a.GetTicket()] = a.GetSpan()
+	}
+}
+
+// file returns the parsed file ticket of the anchor with the given ticket, or
+// of parent if the ticket is not an anchor ticket.  Parsed tickets are cached.
+func (o *anchorOrder) file(ticket, parent string) *kytheuri.URI {
+	if u, ok := o.files[ticket]; ok {
+		return u
+	}
+	file, err := tickets.AnchorFile(ticket)
+	if err != nil {
+		file = parent
+	}
+	u, err := kytheuri.Parse(file)
+	if err != nil {
+		u = &kytheuri.URI{Path: file}
+	}
+	o.files[ticket] = u
+	return u
+}
+
+func (o *anchorOrder) span(a *xpb.Anchor) *cpb.Span {
+	if s, ok := o.spans[a.GetTicket()]; ok {
+		return s
+	}
+	return a.GetSpan()
+}
+
+// Compare returns the relative order of the two anchors.
+func (o *anchorOrder) Compare(a, b *xpb.Anchor) compare.Order {
+	return compareLocations(o.file(a.GetTicket(), a.GetParent()), o.file(b.GetTicket(), b.GetParent()),
+		o.span(a), o.span(b), a.GetTicket(), b.GetTicket())
+}
+
+// CompareStored returns the relative order of the two stored anchors.  It
+// orders them as Compare orders them once converted.
+func (o *anchorOrder) CompareStored(a, b *srvpb.ExpandedAnchor) compare.Order {
+	return compareLocations(o.file(a.GetTicket(), ""), o.file(b.GetTicket(), ""),
+		a.GetSpan(), b.GetSpan(), a.GetTicket(), b.GetTicket())
+}
+
+func compareLocations(fa, fb *kytheuri.URI, sa, sb *cpb.Span, ta, tb string) compare.Order {
+	return compare.Strings(fa.Corpus, fb.Corpus).
+		AndThen(fa.Root, fb.Root).
+		AndThen(fa.Path, fb.Path).
+		AndThen(sa.GetStart().GetByteOffset(), sb.GetStart().GetByteOffset()).
+		AndThen(sa.GetEnd().GetByteOffset(), sb.GetEnd().GetByteOffset()).
+		AndThen(ta, tb)
+}
+
+// sortRelatedAnchors sorts the given anchors (and each anchor's sites) by file
+// and then by span within each file.
+func (o *anchorOrder) sortRelatedAnchors(as []*xpb.CrossReferencesReply_RelatedAnchor) {
+	for _, ra := range as {
+		sort.SliceStable(ra.Site, func(i, j int) bool { return o.Compare(ra.Site[i], ra.Site[j]) == compare.LT })
+	}
+	sort.SliceStable(as, func(i, j int) bool { return o.Compare(as[i].Anchor, as[j].Anchor) == compare.LT })
+}
+
+// sortCrossReferences sorts each set of anchors in the reply so that anchors
+// are grouped by file (ordered by corpus, root, and path) and sorted by their
+// offsets within each file.  The anchors of a reply are merged across groups
+// and pages in the same order (see anchorStream), so the concatenation of
+// successive pages is sorted as well.
+func sortCrossReferences(reply *xpb.CrossReferencesReply, o *anchorOrder) {
+	for _, crs := range reply.CrossReferences {
+		o.sortRelatedAnchors(crs.Definition)
+		o.sortRelatedAnchors(crs.Declaration)
+		o.sortRelatedAnchors(crs.Reference)
+		o.sortRelatedAnchors(crs.Caller)
+	}
+}
+
+// sortRelatedNodes sorts the related nodes of each set in the reply by their
+// relation kind, then by their ordinal, and then by their ticket so that
+// identical requests receive identically ordered replies.
+func sortRelatedNodes(reply *xpb.CrossReferencesReply) {
+	for _, crs := range reply.CrossReferences {
+		rns := crs.RelatedNode
+		sort.Slice(rns, func(i, j int) bool {
+			return compare.Strings(rns[i].RelationKind, rns[j].RelationKind).
+				AndThen(rns[i].Ordinal, rns[j].Ordinal).
+				AndThen(rns[i].Ticket, rns[j].Ticket) == compare.LT
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"sort"
+
+	"kythe.io/kythe/go/util/schema/edges"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// maxRelatedNodeListOrdinal is the largest ordinal grouped into a
+// RelatedNodeList.  Relation kinds with larger ordinals are left ungrouped to
+// bound the size of the sparse ticket list.
+const maxRelatedNodeListOrdinal = 1024
+
+// groupRelatedNodeOrdinals populates the RelatedNodeLists of each
+// CrossReferenceSet in the given reply from its related nodes of forward
+// ordinal-bearing relation kinds.
+func groupRelatedNodeOrdinals(reply *xpb.CrossReferencesReply) {
+	for _, crs := range reply.CrossReferences {
+		var kinds []string
+		byKind := make(map[string][]*xpb.CrossReferencesReply_RelatedNode)
+		for _, rn := range crs.RelatedNode {
+			if !edges.IsForward(rn.RelationKind) || !edges.OrdinalKind(rn.RelationKind) {
+				continue
+			}
+			if _, ok := byKind[rn.RelationKind]; !ok {
+				kinds = append(kinds, rn.RelationKind)
+			}
+			byKind[rn.RelationKind] = append(byKind[rn.RelationKind], rn)
+		}
+		sort.Strings(kinds)
+
+		crs.RelatedNodeList = nil
+		for _, kind := range kinds {
+			if l := relatedNodeList(kind, byKind[kind]); l != nil {
+				crs.RelatedNodeList = append(crs.RelatedNodeList, l)
+			}
+		}
+	}
+}
+
+// relatedNodeList returns the given related nodes ordered by their ordinals or
+// nil if any ordinal is out of range.
+func relatedNodeList(kind string, rns []*xpb.CrossReferencesReply_RelatedNode) *xpb.CrossReferencesReply_RelatedNodeList {
+	var max int32 = -1
+	for _, rn := range rns {
+		if rn.Ordinal < 0 || rn.Ordinal > maxRelatedNodeListOrdinal {
+			return nil
+		} else if rn.Ordinal > max {
+			max = rn.Ordinal
+		}
+	}
+	tickets := make([]string, max+1)
+	for _, rn := range rns {
+		if tickets[rn.Ordinal] == "" {
+			tickets[rn.Ordinal] = rn.Ticket
+		}
+	}
+	return &xpb.CrossReferencesReply_RelatedNodeList{
+		RelationKind: kind,
+		Ticket:       tickets,
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"sort"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/log"
+	"kythe.io/kythe/go/util/schema/edges"
+	"kythe.io/kythe/go/util/schema/facts"
+	"kythe.io/kythe/go/util/span"
+
+	"bitbucket.org/creachadair/stringset"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// Outline returns the hierarchy of symbols defined in the requested file, as
+// for an editor's document outline.  Each symbol is nested under the first of
+// its /kythe/edge/childof parents also defined in the file; the others are
+// top-level symbols.  Outlines are precomputed in the serving table's file
+// outline index.  For files without one, the outline is derived from the
+// file's definition decorations, which carry no childof relations, and so is
+// flat.  For files with a decoration override in t.DecorationOverrides, whose
+// precomputed outline may be stale, the outline is derived from the patched
+// decorations, keeping the parents and display names of the symbols still
+// defined in the file.
+func (t *Table) Outline(ctx context.Context, req *xpb.OutlineRequest) (*xpb.OutlineReply, error) {
+	ctx = log.EnsureRequestID(ctx)
+	var v validate.Validator
+	file := v.Ticket("file", req.File)
+	if err := v.Err(); err != nil {
+		return nil, err
+	} else if err := t.authorizeRequest(ctx, file); err != nil {
+		return nil, err
+	}
+
+	o, err := t.precomputedOutline(ctx, file)
+	if err == table.ErrNoSuchKey {
+		o, err = t.decorationsOutline(ctx, file, nil)
+	} else if err != nil {
+		return nil, canonicalError(err, "file outline", file)
+	} else if t.hasDecorationOverride(file) {
+		tracePrintf(ctx, "Outline of overridden file: %s", file)
+		o, err = t.decorationsOutline(ctx, file, o)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets stringset.Set
+	for _, sym := range o.symbols {
+		tickets.Add(sym.Ticket)
+	}
+	denied, err := t.authorize(ctx, tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &xpb.OutlineReply{}
+	defined := make(map[string]*xpb.OutlineReply_Symbol, len(o.symbols))
+	for i, sym := range o.symbols {
+		if denied.Contains(sym.Ticket) {
+			continue
+		}
+		// Only parents preceding a symbol are considered, which also rules out
+		// childof cycles.
+		var parent *xpb.OutlineReply_Symbol
+		for _, p := range o.parents[i] {
+			if parent = defined[p]; parent != nil {
+				break
+			}
+		}
+		if parent != nil {
+			parent.Child = append(parent.Child, sym)
+		} else {
+			reply.Symbol = append(reply.Symbol, sym)
+		}
+		defined[sym.Ticket] = sym
+	}
+	tracePrintf(ctx, "Outline symbols: %d", len(defined))
+	return reply, nil
+}
+
+// An outline is a file's symbols, ordered by definition span, along with the
+// childof parents of each.
+type outline struct {
+	symbols []*xpb.OutlineReply_Symbol
+	parents [][]string
+}
+
+// precomputedOutline returns the outline of the given file read from the file
+// outline index.
+func (t *Table) precomputedOutline(ctx context.Context, file string) (*outline, error) {
+	o, err := t.fileOutline(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	ac := &anchorConverter{anchorText: true}
+	res := &outline{}
+	for _, sym := range o.Symbol {
+		s := &xpb.OutlineReply_Symbol{
+			Ticket:      sym.Ticket,
+			Kind:        sym.Kind,
+			Subkind:     sym.Subkind,
+			DisplayName: sym.DisplayName,
+		}
+		if sym.Definition != nil {
+			s.Definition = ac.Convert(sym.Definition).Anchor
+		}
+		res.symbols = append(res.symbols, s)
+		res.parents = append(res.parents, sym.Parent)
+	}
+	return res, nil
+}
+
+// decorationsOutline returns the outline of the given file derived from its
+// defines/binding decorations.  The parents and display names of its symbols
+// are taken from prev, if given; otherwise, the outline is flat.
+func (t *Table) decorationsOutline(ctx context.Context, file string, prev *outline) (*outline, error) {
+	decor, err := t.decorationsWithOverride(ctx, file)
+	if err == table.ErrNoSuchKey {
+		return nil, xrefs.ErrDecorationsNotFound
+	} else if err != nil {
+		return nil, canonicalError(err, "file decorations", file)
+	} else if decor.File == nil {
+		return nil, xrefs.ErrDecorationsNotFound
+	} else if err := t.resolveFileContent(ctx, file, decor); err != nil {
+		return nil, err
+	} else if err := t.readDecorationPages(ctx, decor, nil); err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]*srvpb.Node, len(decor.Target))
+	for _, n := range decor.Target {
+		targets[n.Ticket] = n
+	}
+	text := decor.File.Text
+	norm := span.NewNormalizer(text)
+	revision := makeFileInfoMap(decor.FileInfo)[file].GetRevision()
+	seen := stringset.New()
+	var symbols []*xpb.OutlineReply_Symbol
+	for _, d := range decor.Decoration {
+		if d.Kind != edges.DefinesBinding || !seen.Add(d.Target) {
+			continue
+		}
+		n := targets[d.Target]
+		symbols = append(symbols, &xpb.OutlineReply_Symbol{
+			Ticket:     d.Target,
+			Kind:       nodeKind(n),
+			Subkind:    nodeFact(n, facts.Subkind),
+			Definition: resolveRawAnchor(norm, text, file, revision, d.Kind, d.Anchor),
+		})
+	}
+	start := func(i int) int32 { return symbols[i].Definition.GetSpan().GetStart().GetByteOffset() }
+	end := func(i int) int32 { return symbols[i].Definition.GetSpan().GetEnd().GetByteOffset() }
+	sort.SliceStable(symbols, func(i, j int) bool {
+		if start(i) != start(j) {
+			return start(i) < start(j)
+		}
+		return end(i) > end(j)
+	})
+	res := &outline{symbols: symbols, parents: make([][]string, len(symbols))}
+	if prev != nil {
+		known := make(map[string]int, len(prev.symbols))
+		for i, sym := range prev.symbols {
+			known[sym.Ticket] = i
+		}
+		for i, sym := range symbols {
+			j, ok := known[sym.Ticket]
+			if !ok {
+				continue
+			}
+			if sym.Kind == "" {
+				sym.Kind, sym.Subkind = prev.symbols[j].Kind, prev.symbols[j].Subkind
+			}
+			sym.DisplayName = prev.symbols[j].DisplayName
+			res.parents[i] = prev.parents[j]
+		}
+	}
+	return res, nil
+}
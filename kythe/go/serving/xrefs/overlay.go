@@ -0,0 +1,655 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/serving/xrefs/assemble"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/schema/tickets"
+
+	"bitbucket.org/creachadair/stringset"
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// OverlayTables is a set of static lookup tables consisting of a small overlay
+// of freshly indexed entries layered over a base serving table.  Lookups
+// consult the overlay first and fall back to the base.  FileDecorations and
+// PagedCrossReferences present in both tables are merged.  A file with
+// FileDecorations in the overlay is taken to be reindexed: base anchors within
+// it are stale and dropped from merged entries.
+type OverlayTables struct {
+	overlay, base staticLookupTables
+}
+
+// NewOverlayTable returns a table serving the entries of the combined overlay
+// table layered over the combined base table.  Both tables are expected to be
+// keyed using only the *Key functions.
+func NewOverlayTable(overlay, base table.Proto) *Table {
+	return &Table{staticLookupTables: &OverlayTables{
+		overlay: &combinedTable{overlay},
+		base:    &combinedTable{base},
+	}}
+}
+
+// lookupBoth reads the given key from both the overlay and base tables.  A
+// missing key in either table is returned as a nil value.
+func lookupBoth[T proto.Message](ctx context.Context, key string, overlay, base func(context.Context, string) (T, error)) (o, b T, found bool, err error) {
+	var zero T
+	o, err = overlay(ctx, key)
+	if err == table.ErrNoSuchKey {
+		o = zero
+	} else if err != nil {
+		return zero, zero, false, err
+	} else {
+		found = true
+	}
+	b, err = base(ctx, key)
+	if err == table.ErrNoSuchKey {
+		b = zero
+	} else if err != nil {
+		return zero, zero, false, err
+	} else {
+		found = true
+	}
+	return o, b, found, nil
+}
+
+func (o *OverlayTables) fileDecorations(ctx context.Context, ticket string) (*srvpb.FileDecorations, error) {
+	over, base, found, err := lookupBoth(ctx, ticket, o.overlay.fileDecorations, o.base.fileDecorations)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, table.ErrNoSuchKey
+	} else if over == nil {
+		return base, nil
+	} else if base == nil {
+		return over, nil
+	}
+	files := stringset.New()
+	for _, d := range base.TargetDefinitions {
+		files.Add(anchorFile(d.GetTicket()))
+	}
+	stale, err := o.overlayFiles(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	tracePrintf(ctx, "Merging overlay FileDecorations: %s", ticket)
+	return mergeFileDecorations(over, base, stale), nil
+}
+
+func (o *OverlayTables) fileDecorationsPage(ctx context.Context, key string) (*srvpb.FileDecorationsPage, error) {
+	p, err := o.overlay.fileDecorationsPage(ctx, key)
+	if err == table.ErrNoSuchKey {
+		return o.base.fileDecorationsPage(ctx, key)
+	}
+	return p, err
+}
+
+func (o *OverlayTables) crossReferences(ctx context.Context, ticket string) (*srvpb.PagedCrossReferences, error) {
+	over, base, found, err := lookupBoth(ctx, ticket, o.overlay.crossReferences, o.base.crossReferences)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, table.ErrNoSuchKey
+	} else if base == nil {
+		return over, nil
+	}
+	files := stringset.New()
+	for _, g := range base.Group {
+		addGroupFiles(files, g)
+	}
+	stale, err := o.overlayFiles(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	var res *srvpb.PagedCrossReferences
+	if over == nil && len(stale) == 0 {
+		res = base
+	} else if over == nil {
+		res = proto.Clone(base).(*srvpb.PagedCrossReferences)
+		res.Group = nil
+		for _, g := range base.Group {
+			if g = dropStaleAnchors(g, stale); !emptyGroup(g) {
+				res.Group = append(res.Group, g)
+			}
+		}
+	} else {
+		tracePrintf(ctx, "Merging overlay PagedCrossReferences: %s", ticket)
+		res = mergeCrossReferenceSets(over, base, stale)
+	}
+	if err := o.recountPages(ctx, res, over); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// recountPages sets the count of each base page of the given set to the
+// number of its anchors, callers, and related nodes left once its stale
+// anchors are dropped, as served by crossReferencesPage, so that the set's
+// totals and page tokens match the anchors served.  Pages held by the overlay
+// set are current and not read.  Pages left empty are kept, with a zero
+// count, so that the set's page search index remains valid.
+func (o *OverlayTables) recountPages(ctx context.Context, set, over *srvpb.PagedCrossReferences) error {
+	current := stringset.New()
+	for _, idx := range over.GetPageIndex() {
+		current.Add(idx.PageKey)
+	}
+	for i, idx := range set.PageIndex {
+		if current.Contains(idx.PageKey) {
+			continue
+		}
+		p, err := o.crossReferencesPage(ctx, idx.PageKey)
+		if err == table.ErrNoSuchKey {
+			// Missing pages are reported when they are served.
+			continue
+		} else if err != nil {
+			return err
+		}
+		if n := int32(groupCount(p.Group)); n != idx.Count {
+			idx = proto.Clone(idx).(*srvpb.PagedCrossReferences_PageIndex)
+			idx.Count = n
+			set.PageIndex[i] = idx
+		}
+	}
+	return nil
+}
+
+func (o *OverlayTables) crossReferencesPage(ctx context.Context, key string) (*srvpb.PagedCrossReferences_Page, error) {
+	p, err := o.overlay.crossReferencesPage(ctx, key)
+	if err != table.ErrNoSuchKey {
+		return p, err
+	}
+	p, err = o.base.crossReferencesPage(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	files := stringset.New()
+	addGroupFiles(files, p.Group)
+	stale, err := o.overlayFiles(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	p.Group = dropStaleAnchors(p.Group, stale)
+	return p, nil
+}
+
+// overlayFiles returns those of the given files with FileDecorations in the
+// overlay, whose base anchors are stale.
+func (o *OverlayTables) overlayFiles(ctx context.Context, files stringset.Set) (stringset.Set, error) {
+	files.Discard("")
+	held := stringset.New()
+	if len(files) == 0 {
+		return held, nil
+	}
+	candidates := files.Elements()
+	if may, err := o.overlay.decorationsFilter(ctx); err == nil {
+		candidates = candidates[:0]
+		for _, file := range files.Elements() {
+			if may(file) {
+				candidates = append(candidates, file)
+			}
+		}
+	} else if err != table.ErrNoSuchKey {
+		return nil, err
+	}
+	has := func(ctx context.Context, file string) (bool, error) {
+		_, err := o.overlay.fileDecorations(ctx, file)
+		if err == table.ErrNoSuchKey {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	if c, ok := o.overlay.(decorationsChecker); ok {
+		has = c.hasDecorations
+	}
+	for _, file := range candidates {
+		if ok, err := has(ctx, file); err != nil {
+			return nil, err
+		} else if ok {
+			held.Add(file)
+		}
+	}
+	return held, nil
+}
+
+// anchorFile returns the file ticket of the given anchor ticket, or "" if it
+// is invalid.
+func anchorFile(ticket string) string {
+	file, err := tickets.AnchorFile(ticket)
+	if err != nil {
+		return ""
+	}
+	return file
+}
+
+// addGroupFiles adds to files the file of each anchor of the given group.
+func addGroupFiles(files stringset.Set, g *srvpb.PagedCrossReferences_Group) {
+	for _, a := range g.GetAnchor() {
+		files.Add(anchorFile(a.GetTicket()))
+	}
+	for _, c := range g.GetCaller() {
+		files.Add(anchorFile(c.GetCaller().GetTicket()))
+		for _, site := range c.GetCallsite() {
+			files.Add(anchorFile(site.GetTicket()))
+		}
+	}
+	for _, r := range g.GetScopedReference() {
+		for _, a := range r.GetReference() {
+			files.Add(anchorFile(a.GetTicket()))
+		}
+	}
+}
+
+// groupCount returns the number of anchors, callers, and related nodes of g.
+func groupCount(g *srvpb.PagedCrossReferences_Group) int {
+	return len(g.GetAnchor()) + countRefs(g.GetScopedReference()) + len(g.GetCaller()) + len(g.GetRelatedNode())
+}
+
+// emptyGroup reports whether g has no anchors, callers, or related nodes.
+func emptyGroup(g *srvpb.PagedCrossReferences_Group) bool {
+	return len(g.Anchor) == 0 && len(g.RelatedNode) == 0 && len(g.Caller) == 0 && len(g.ScopedReference) == 0
+}
+
+// dropStaleAnchors returns a copy of g without its anchors, callers, and call
+// sites in the given stale files.
+func dropStaleAnchors(g *srvpb.PagedCrossReferences_Group, stale stringset.Set) *srvpb.PagedCrossReferences_Group {
+	if g == nil || len(stale) == 0 {
+		return g
+	}
+	fresh := func(as []*srvpb.ExpandedAnchor) []*srvpb.ExpandedAnchor {
+		var res []*srvpb.ExpandedAnchor
+		for _, a := range as {
+			if !stale.Contains(anchorFile(a.GetTicket())) {
+				res = append(res, a)
+			}
+		}
+		return res
+	}
+	res := proto.Clone(g).(*srvpb.PagedCrossReferences_Group)
+	res.Anchor = fresh(g.Anchor)
+	res.Caller = nil
+	for _, c := range g.Caller {
+		if !stale.Contains(anchorFile(c.GetCaller().GetTicket())) {
+			c = proto.Clone(c).(*srvpb.PagedCrossReferences_Caller)
+			c.Callsite = fresh(c.Callsite)
+			res.Caller = append(res.Caller, c)
+		}
+	}
+	res.ScopedReference = nil
+	for _, r := range g.ScopedReference {
+		if refs := fresh(r.Reference); len(refs) > 0 {
+			r = proto.Clone(r).(*srvpb.PagedCrossReferences_ScopedReference)
+			r.Reference = refs
+			res.ScopedReference = append(res.ScopedReference, r)
+		}
+	}
+	return res
+}
+
+func (o *OverlayTables) documentation(ctx context.Context, ticket string) (*srvpb.Document, error) {
+	d, err := o.overlay.documentation(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return o.base.documentation(ctx, ticket)
+	}
+	return d, err
+}
+
+func (o *OverlayTables) displayName(ctx context.Context, ticket string) (*srvpb.DisplayName, error) {
+	n, err := o.overlay.displayName(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return o.base.displayName(ctx, ticket)
+	}
+	return n, err
+}
+
+func (o *OverlayTables) fileDigest(ctx context.Context, digest string) (*srvpb.FileDigest, error) {
+	over, base, found, err := lookupBoth(ctx, digest, o.overlay.fileDigest, o.base.fileDigest)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, table.ErrNoSuchKey
+	} else if over == nil {
+		return base, nil
+	} else if base == nil {
+		return over, nil
+	}
+	tickets := stringset.New(over.Ticket...)
+	tickets.Add(base.Ticket...)
+	return &srvpb.FileDigest{Digest: digest, Ticket: tickets.Elements()}, nil
+}
+
+// fileReferences returns the overlay's FileReferences, if any, since, like its
+// FileDecorations, they describe the latest version of the file.
+func (o *OverlayTables) fileReferences(ctx context.Context, ticket string) (*srvpb.FileReferences, error) {
+	r, err := o.overlay.fileReferences(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return o.base.fileReferences(ctx, ticket)
+	}
+	return r, err
+}
+
+// fileRelations returns the overlay's FileRelations, if any, since it reflects
+// the latest indexing of the file's includes and imports.
+func (o *OverlayTables) fileRelations(ctx context.Context, ticket string) (*srvpb.FileRelations, error) {
+	r, err := o.overlay.fileRelations(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return o.base.fileRelations(ctx, ticket)
+	}
+	return r, err
+}
+
+// fileOutline returns the overlay's FileOutline, if any, since it reflects the
+// latest indexing of the file's definitions.  The base's FileOutline of a file
+// reindexed by the overlay is stale, so none is returned for such a file and
+// its outline is derived from its merged decorations instead.
+func (o *OverlayTables) fileOutline(ctx context.Context, ticket string) (*srvpb.FileOutline, error) {
+	f, err := o.overlay.fileOutline(ctx, ticket)
+	if err != table.ErrNoSuchKey {
+		return f, err
+	}
+	if held, err := o.overlayFiles(ctx, stringset.New(ticket)); err != nil {
+		return nil, err
+	} else if held.Contains(ticket) {
+		return nil, table.ErrNoSuchKey
+	}
+	return o.base.fileOutline(ctx, ticket)
+}
+
+// callDegrees returns the overlay's CallDegrees, if any.  Degrees are not
+// summed since the overlay's call graph supersedes the base's for the same
+// function.
+func (o *OverlayTables) callDegrees(ctx context.Context, ticket string) (*srvpb.CallDegrees, error) {
+	d, err := o.overlay.callDegrees(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return o.base.callDegrees(ctx, ticket)
+	}
+	return d, err
+}
+
+// containerMembers returns the union of the overlay's and base's
+// ContainerMembers.  A member in both is taken from the overlay since it
+// reflects the latest indexing of the member's definition.
+func (o *OverlayTables) containerMembers(ctx context.Context, container, kind string) (*srvpb.ContainerMembers, error) {
+	over, err := o.overlay.containerMembers(ctx, container, kind)
+	if err == table.ErrNoSuchKey {
+		return o.base.containerMembers(ctx, container, kind)
+	} else if err != nil {
+		return nil, err
+	}
+	base, err := o.base.containerMembers(ctx, container, kind)
+	if err == table.ErrNoSuchKey {
+		return over, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	merged := proto.Clone(over).(*srvpb.ContainerMembers)
+	seen := stringset.New()
+	for _, m := range merged.Member {
+		seen.Add(m.Ticket)
+	}
+	for _, m := range base.Member {
+		if seen.Add(m.Ticket) {
+			merged.Member = append(merged.Member, m)
+		}
+	}
+	assemble.SortMembers(merged.Member)
+	return merged, nil
+}
+
+// containerRollup returns the union of the overlay's and base's
+// ContainerRollup targets.  The reference counts of a target in both are
+// summed, as are the containers' total reference counts.
+func (o *OverlayTables) containerRollup(ctx context.Context, ticket string) (*srvpb.ContainerRollup, error) {
+	over, err := o.overlay.containerRollup(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return o.base.containerRollup(ctx, ticket)
+	} else if err != nil {
+		return nil, err
+	}
+	base, err := o.base.containerRollup(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return over, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	merged := proto.Clone(over).(*srvpb.ContainerRollup)
+	targets := make(map[string]*srvpb.ContainerRollup_Target, len(merged.Target))
+	for _, t := range merged.Target {
+		targets[t.Ticket] = t
+	}
+	for _, t := range base.Target {
+		if m, ok := targets[t.Ticket]; ok {
+			m.ReferenceCount += t.ReferenceCount
+		} else {
+			merged.Target = append(merged.Target, t)
+		}
+	}
+	merged.ReferenceCount += base.ReferenceCount
+	assemble.SortRollupTargets(merged.Target)
+	return merged, nil
+}
+
+// ticketAlias returns the overlay's TicketAlias, if any, since it reflects the
+// latest move or rename of the aliased node.
+func (o *OverlayTables) ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error) {
+	a, err := o.overlay.ticketAlias(ctx, ticket)
+	if err == table.ErrNoSuchKey {
+		return o.base.ticketAlias(ctx, ticket)
+	}
+	return a, err
+}
+
+// decorationsFilter reports that a file may be decorated if it may be
+// decorated in either table.  If either table cannot tell, neither can the
+// overlay.
+func (o *OverlayTables) decorationsFilter(ctx context.Context) (fileFilter, error) {
+	over, err := o.overlay.decorationsFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base, err := o.base.decorationsFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return func(file string) bool { return over(file) || base(file) }, nil
+}
+
+// mergeFileDecorations merges the base FileDecorations into the overlay.  The
+// overlay's file text, decorations, and diagnostics replace the base's since
+// the base offsets are only valid for the base text.  Decoration targets,
+// definitions, overrides, reference counts, and file infos are unioned with
+// the overlay's taking precedence.  Base definitions in the given stale files
+// are dropped.
+func mergeFileDecorations(over, base *srvpb.FileDecorations, stale stringset.Set) *srvpb.FileDecorations {
+	res := over
+	if res.File == nil {
+		res.File = base.File
+		res.Decoration = base.Decoration
+		res.DecorationPage = base.DecorationPage
+		res.Diagnostic = base.Diagnostic
+	}
+
+	targets := stringset.New()
+	for _, n := range res.Target {
+		targets.Add(n.GetTicket())
+	}
+	for _, n := range base.Target {
+		if targets.Add(n.GetTicket()) {
+			res.Target = append(res.Target, n)
+		}
+	}
+
+	defs := stringset.New()
+	for _, d := range res.TargetDefinitions {
+		defs.Add(d.GetTicket())
+	}
+	for _, d := range base.TargetDefinitions {
+		if !stale.Contains(anchorFile(d.GetTicket())) && defs.Add(d.GetTicket()) {
+			res.TargetDefinitions = append(res.TargetDefinitions, d)
+		}
+	}
+
+	res.TargetOverride = mergeOverrides(res.TargetOverride, base.TargetOverride)
+	res.TargetOverriddenBy = mergeOverrides(res.TargetOverriddenBy, base.TargetOverriddenBy)
+
+	for ticket, count := range base.TargetReferenceCount {
+		if _, ok := res.TargetReferenceCount[ticket]; !ok {
+			if res.TargetReferenceCount == nil {
+				res.TargetReferenceCount = make(map[string]int64)
+			}
+			res.TargetReferenceCount[ticket] = count
+		}
+	}
+
+	res.FileInfo = mergeFileInfos(res.FileInfo, base.FileInfo)
+	generatedBy := stringset.New(res.GeneratedBy...)
+	for _, g := range base.GeneratedBy {
+		if generatedBy.Add(g) {
+			res.GeneratedBy = append(res.GeneratedBy, g)
+		}
+	}
+	return res
+}
+
+// mergeOverrides appends to over any base overrides not already present.
+func mergeOverrides(over, base []*srvpb.FileDecorations_Override) []*srvpb.FileDecorations_Override {
+	overrides := stringset.New()
+	for _, o := range over {
+		overrides.Add(o.GetOverriding() + "\x00" + o.GetOverridden())
+	}
+	for _, o := range base {
+		if overrides.Add(o.GetOverriding() + "\x00" + o.GetOverridden()) {
+			over = append(over, o)
+		}
+	}
+	return over
+}
+
+// mergeCrossReferenceSets merges the base PagedCrossReferences into the
+// overlay.  Groups with the same kind and build configuration are merged,
+// dropping any base anchors, callers, and related nodes already present in
+// the overlay or within the given stale files.  Page indices are concatenated
+// and, since page search indices cannot be combined, the merged set's search
+// index is dropped.
+func mergeCrossReferenceSets(over, base *srvpb.PagedCrossReferences, stale stringset.Set) *srvpb.PagedCrossReferences {
+	res := over
+	if res.SourceNode == nil {
+		res.SourceNode = base.SourceNode
+	}
+	if res.MarkedSource == nil {
+		res.MarkedSource = base.MarkedSource
+	}
+	res.Incomplete = res.Incomplete && base.Incomplete
+
+	mergeWith := stringset.New(res.MergeWith...)
+	for _, m := range base.MergeWith {
+		if mergeWith.Add(m) {
+			res.MergeWith = append(res.MergeWith, m)
+		}
+	}
+
+	type groupKey struct{ kind, buildConfig string }
+	groups := make(map[groupKey]*srvpb.PagedCrossReferences_Group, len(res.Group))
+	for _, g := range res.Group {
+		groups[groupKey{g.Kind, g.BuildConfig}] = g
+	}
+	for _, g := range base.Group {
+		g = dropStaleAnchors(g, stale)
+		if existing := groups[groupKey{g.Kind, g.BuildConfig}]; existing != nil {
+			mergeCrossReferencesGroup(existing, g)
+		} else if !emptyGroup(g) {
+			res.Group = append(res.Group, g)
+		}
+	}
+
+	pages := stringset.New()
+	for _, idx := range res.PageIndex {
+		pages.Add(idx.PageKey)
+	}
+	for _, idx := range base.PageIndex {
+		if pages.Add(idx.PageKey) {
+			res.PageIndex = append(res.PageIndex, idx)
+		}
+	}
+	res.PageSearchIndex = nil
+	return res
+}
+
+func mergeCrossReferencesGroup(into, from *srvpb.PagedCrossReferences_Group) {
+	anchors := stringset.New()
+	for _, a := range into.Anchor {
+		anchors.Add(a.GetTicket())
+	}
+	for _, a := range from.Anchor {
+		if anchors.Add(a.GetTicket()) {
+			into.Anchor = append(into.Anchor, a)
+		}
+	}
+
+	related := stringset.New()
+	for _, rn := range into.RelatedNode {
+		related.Add(rn.GetNode().GetTicket())
+	}
+	for _, rn := range from.RelatedNode {
+		if related.Add(rn.GetNode().GetTicket()) {
+			into.RelatedNode = append(into.RelatedNode, rn)
+		}
+	}
+
+	callers := stringset.New()
+	for _, c := range into.Caller {
+		callers.Add(c.GetCaller().GetTicket())
+	}
+	for _, c := range from.Caller {
+		if callers.Add(c.GetCaller().GetTicket()) {
+			into.Caller = append(into.Caller, c)
+		}
+	}
+
+	scopes := stringset.New()
+	for _, r := range into.ScopedReference {
+		scopes.Add(r.GetScope().GetTicket())
+	}
+	for _, r := range from.ScopedReference {
+		if scopes.Add(r.GetScope().GetTicket()) {
+			into.ScopedReference = append(into.ScopedReference, r)
+		}
+	}
+
+	into.FileInfo = mergeFileInfos(into.FileInfo, from.FileInfo)
+}
+
+// mergeFileInfos appends each FileInfo in base whose file is not already
+// described in over.
+func mergeFileInfos(over, base []*srvpb.FileInfo) []*srvpb.FileInfo {
+	files := stringset.New()
+	for _, fi := range over {
+		files.Add(corpusPathTicket(fi.GetCorpusPath()))
+	}
+	for _, fi := range base {
+		if files.Add(corpusPathTicket(fi.GetCorpusPath())) {
+			over = append(over, fi)
+		}
+	}
+	return over
+}
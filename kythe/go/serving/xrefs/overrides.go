@@ -0,0 +1,136 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// DecorationOverrides is a set of in-memory FileDecorations, e.g. for files
+// freshly indexed from an IDE's modified buffers, that take precedence over a
+// Table's static FileDecorations until they expire or are invalidated.  It is
+// safe for concurrent use.
+type DecorationOverrides struct {
+	mu      sync.Mutex
+	entries map[string]*decorationOverride
+	hooks   []func(ticket string)
+
+	// now returns the current time; replaced in tests.
+	now func() time.Time
+}
+
+type decorationOverride struct {
+	decor   *srvpb.FileDecorations
+	expires time.Time // zero if the override never expires
+}
+
+// NewDecorationOverrides returns an empty set of DecorationOverrides.
+func NewDecorationOverrides() *DecorationOverrides {
+	return &DecorationOverrides{
+		entries: make(map[string]*decorationOverride),
+		now:     time.Now,
+	}
+}
+
+// Put registers the given FileDecorations as the override for its file ticket
+// for the given duration, replacing any existing override.  If ttl <= 0, the
+// override does not expire.  The FileDecorations must not be modified
+// afterwards.
+func (o *DecorationOverrides) Put(decor *srvpb.FileDecorations, ttl time.Duration) {
+	e := &decorationOverride{decor: decor}
+	if ttl > 0 {
+		e.expires = o.now().Add(ttl)
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entries[decor.GetFile().GetTicket()] = e
+}
+
+// Invalidate removes the override for the given file ticket, if any, and calls
+// the OnInvalidate hooks.
+func (o *DecorationOverrides) Invalidate(ticket string) {
+	o.mu.Lock()
+	_, ok := o.entries[ticket]
+	delete(o.entries, ticket)
+	o.mu.Unlock()
+	if ok {
+		o.invalidated(ticket)
+	}
+}
+
+// OnInvalidate registers a hook called with the file ticket of each override
+// that is invalidated or found to be expired.  Hooks must not call back into
+// the DecorationOverrides.
+func (o *DecorationOverrides) OnInvalidate(hook func(ticket string)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.hooks = append(o.hooks, hook)
+}
+
+// get returns the unexpired override for the given file ticket, if any.
+func (o *DecorationOverrides) get(ticket string) *srvpb.FileDecorations {
+	o.mu.Lock()
+	e, ok := o.entries[ticket]
+	expired := ok && !e.expires.IsZero() && !o.now().Before(e.expires)
+	if expired {
+		delete(o.entries, ticket)
+	}
+	o.mu.Unlock()
+	if expired {
+		o.invalidated(ticket)
+		return nil
+	} else if !ok {
+		return nil
+	}
+	return e.decor
+}
+
+func (o *DecorationOverrides) invalidated(ticket string) {
+	o.mu.Lock()
+	hooks := o.hooks
+	o.mu.Unlock()
+	for _, hook := range hooks {
+		hook(ticket)
+	}
+}
+
+// decorationsWithOverride returns the FileDecorations for the given file
+// ticket, merged over by its override in t.DecorationOverrides, if any.
+func (t *Table) decorationsWithOverride(ctx context.Context, ticket string) (*srvpb.FileDecorations, error) {
+	var over *srvpb.FileDecorations
+	if t.DecorationOverrides != nil {
+		over = t.DecorationOverrides.get(ticket)
+	}
+	decor, err := t.fileDecorations(ctx, ticket)
+	if over == nil {
+		return decor, err
+	} else if err == table.ErrNoSuchKey {
+		return proto.Clone(over).(*srvpb.FileDecorations), nil
+	} else if err != nil {
+		return nil, err
+	}
+	tracePrintf(ctx, "Merging FileDecorations override: %s", ticket)
+	return mergeFileDecorations(proto.Clone(over).(*srvpb.FileDecorations), decor, nil), nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// Phases of serving a request timed by a Table.
+const (
+	// PhaseRead is time spent reading serving table entries, including
+	// decoding and (if Table.Paranoid is set) validating them.
+	PhaseRead = "read"
+
+	// PhaseFilter is time spent applying a request's filters (e.g. corpus path
+	// filters and anchor text patterns) to cross-reference pages.
+	PhaseFilter = "filter"
+
+	// PhaseAuthorize is time spent waiting on the Table's Authorizer.
+	PhaseAuthorize = "authorize"
+)
+
+// phaseTimings accumulates the time spent in each phase of a request.  It is
+// safe for concurrent use.
+type phaseTimings struct {
+	mu sync.Mutex
+	d  map[string]time.Duration
+}
+
+type phaseTimingsKey struct{}
+
+// withPhaseTimings returns a context in which startPhase accumulates timings
+// into the returned phaseTimings.
+func withPhaseTimings(ctx context.Context) (context.Context, *phaseTimings) {
+	p := &phaseTimings{d: make(map[string]time.Duration)}
+	return context.WithValue(ctx, phaseTimingsKey{}, p), p
+}
+
+// phaseTimingsFromContext returns the phaseTimings added to ctx by
+// withPhaseTimings, or nil if there is none.
+func phaseTimingsFromContext(ctx context.Context) *phaseTimings {
+	p, _ := ctx.Value(phaseTimingsKey{}).(*phaseTimings)
+	return p
+}
+
+// startPhase starts timing the given phase, returning a func to stop it.  If
+// ctx was not returned by withPhaseTimings, the phase is not timed.
+func startPhase(ctx context.Context, phase string) (stop func()) {
+	p := phaseTimingsFromContext(ctx)
+	if p == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { p.add(phase, time.Since(start)) }
+}
+
+func (p *phaseTimings) add(phase string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.d[phase] += d
+}
+
+// Durations returns a copy of the accumulated timings, keyed by phase.  A nil
+// phaseTimings has no timings.
+func (p *phaseTimings) Durations() map[string]time.Duration {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	d := make(map[string]time.Duration, len(p.d))
+	for phase, t := range p.d {
+		d[phase] = t
+	}
+	return d
+}
+
+// DebugInfo returns the accumulated timings as a DebugInfo.
+func (p *phaseTimings) DebugInfo() *xpb.DebugInfo {
+	info := &xpb.DebugInfo{}
+	for phase, d := range p.Durations() {
+		info.PhaseTiming = append(info.PhaseTiming, &xpb.DebugInfo_PhaseTiming{
+			Phase:    phase,
+			Duration: durationpb.New(d),
+		})
+	}
+	sort.Slice(info.PhaseTiming, func(i, j int) bool {
+		return info.PhaseTiming[i].Phase < info.PhaseTiming[j].Phase
+	})
+	return info
+}
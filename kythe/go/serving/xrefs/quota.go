@@ -0,0 +1,273 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/serving/quota"
+	"kythe.io/kythe/go/storage/table"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// A ReadQuota bounds the table reads made to serve a single Decorations,
+// CrossReferences, Documentation, or BatchDocumentation request.  A limit <= 0
+// is unlimited.  MaxPages bounds the decorations and cross-references page
+// lookups.
+type ReadQuota = quota.ReadQuota
+
+// A ReadLimiter throttles the table reads made by a Table across all of its
+// requests.
+type ReadLimiter = quota.ReadLimiter
+
+// A QuotaExceededError is returned by a request whose table reads exceed the
+// Table's ReadQuota.  Its gRPC status code is RESOURCE_EXHAUSTED and its
+// partial reply, if any, is attached to the status as a detail.
+// CrossReferences requests skip the pages they cannot read, reporting their
+// edge kinds in each CrossReferenceSet's truncated_kind.
+type QuotaExceededError = quota.ExceededError
+
+func isQuotaExceeded(err error) bool { return quota.IsExceeded(err) }
+
+// withReadBudget returns a context carrying a new read budget for t.ReadQuota,
+// or ctx and a nil budget if the quota is unlimited.
+func (t *Table) withReadBudget(ctx context.Context) (context.Context, *quota.Budget) {
+	return quota.NewContext(ctx, t.ReadQuota)
+}
+
+// quotaReply returns the given result of a request served within b, replacing
+// it with a QuotaExceededError holding any partial reply if b was exceeded.
+func quotaReply[R proto.Message](b *quota.Budget, reply R, err error) (R, error) {
+	if err != nil && !isNonContextError(err) {
+		return reply, err
+	}
+	e := b.Exceeded()
+	if e == nil {
+		return reply, err
+	}
+	if err == nil {
+		e.Reply = reply
+	}
+	var none R
+	return none, e
+}
+
+// chargeRead charges a single table read to the request's read budget, if
+// any, and then waits for t.ReadLimiter, if set.
+func (t *Table) chargeRead(ctx context.Context, page bool) error {
+	return quota.Charge(ctx, t.ReadLimiter, page)
+}
+
+// The non-page lookups of staticLookupTables are charged to each request's
+// read budget.  Page lookups are charged by fileDecorationsPage and
+// crossReferencesPage.  FileDecorations and PagedCrossReferences are passed
+// through checkEntry.  Ticket lookups known by t.NegativeCache to be missing
+// fail with table.ErrNoSuchKey without being read or charged.
+
+func (t *Table) fileDecorations(ctx context.Context, ticket string) (*srvpb.FileDecorations, error) {
+	missing, epoch := t.knownMissing(ctx, negDecorations, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	fd, err := t.staticLookupTables.fileDecorations(ctx, ticket)
+	t.noteMissing(negDecorations, ticket, epoch, err)
+	if err == nil {
+		err = t.checkEntry(ctx, ticket, "", fd)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fd, nil
+}
+
+// file is like fileDecorations, but returns only the File of the
+// FileDecorations, decoding nothing else when t's tables are a fileReader.
+func (t *Table) file(ctx context.Context, ticket string) (*srvpb.File, error) {
+	r, ok := t.staticLookupTables.(fileReader)
+	if !ok {
+		fd, err := t.fileDecorations(ctx, ticket)
+		return fd.GetFile(), err
+	}
+	missing, epoch := t.knownMissing(ctx, negDecorations, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	f, err := r.file(ctx, ticket)
+	t.noteMissing(negDecorations, ticket, epoch, err)
+	return f, err
+}
+
+func (t *Table) crossReferences(ctx context.Context, ticket string) (*srvpb.PagedCrossReferences, error) {
+	return t.partialCrossReferences(ctx, ticket, nil)
+}
+
+// partialCrossReferences is like crossReferences, but omits the groups
+// disallowed by want, if non-nil, when t's tables are a
+// partialCrossReferencesReader.
+func (t *Table) partialCrossReferences(ctx context.Context, ticket string, want groupFilter) (*srvpb.PagedCrossReferences, error) {
+	missing, epoch := t.knownMissing(ctx, negCrossReferences, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	var cr *srvpb.PagedCrossReferences
+	var err error
+	if r, ok := t.staticLookupTables.(partialCrossReferencesReader); ok && want != nil {
+		cr, err = r.partialCrossReferences(ctx, ticket, want)
+	} else {
+		cr, err = t.staticLookupTables.crossReferences(ctx, ticket)
+	}
+	t.noteMissing(negCrossReferences, ticket, epoch, err)
+	if err == nil {
+		err = t.checkEntry(ctx, ticket, "", cr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+func (t *Table) documentation(ctx context.Context, ticket string) (*srvpb.Document, error) {
+	missing, epoch := t.knownMissing(ctx, negDocumentation, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	res, err := t.staticLookupTables.documentation(ctx, ticket)
+	t.noteMissing(negDocumentation, ticket, epoch, err)
+	return res, err
+}
+
+func (t *Table) displayName(ctx context.Context, ticket string) (*srvpb.DisplayName, error) {
+	missing, epoch := t.knownMissing(ctx, negDisplayName, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	res, err := t.staticLookupTables.displayName(ctx, ticket)
+	t.noteMissing(negDisplayName, ticket, epoch, err)
+	return res, err
+}
+
+func (t *Table) fileDigest(ctx context.Context, digest string) (*srvpb.FileDigest, error) {
+	if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	return t.staticLookupTables.fileDigest(ctx, digest)
+}
+
+func (t *Table) fileReferences(ctx context.Context, ticket string) (*srvpb.FileReferences, error) {
+	missing, epoch := t.knownMissing(ctx, negFileReferences, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	res, err := t.staticLookupTables.fileReferences(ctx, ticket)
+	t.noteMissing(negFileReferences, ticket, epoch, err)
+	return res, err
+}
+
+func (t *Table) fileRelations(ctx context.Context, ticket string) (*srvpb.FileRelations, error) {
+	missing, epoch := t.knownMissing(ctx, negFileRelations, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	res, err := t.staticLookupTables.fileRelations(ctx, ticket)
+	t.noteMissing(negFileRelations, ticket, epoch, err)
+	return res, err
+}
+
+func (t *Table) callDegrees(ctx context.Context, ticket string) (*srvpb.CallDegrees, error) {
+	missing, epoch := t.knownMissing(ctx, negCallDegrees, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	res, err := t.staticLookupTables.callDegrees(ctx, ticket)
+	t.noteMissing(negCallDegrees, ticket, epoch, err)
+	return res, err
+}
+
+func (t *Table) containerMembers(ctx context.Context, container, kind string) (*srvpb.ContainerMembers, error) {
+	if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	return t.staticLookupTables.containerMembers(ctx, container, kind)
+}
+
+func (t *Table) containerRollup(ctx context.Context, ticket string) (*srvpb.ContainerRollup, error) {
+	missing, epoch := t.knownMissing(ctx, negContainerRollup, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	res, err := t.staticLookupTables.containerRollup(ctx, ticket)
+	t.noteMissing(negContainerRollup, ticket, epoch, err)
+	return res, err
+}
+
+func (t *Table) fileOutline(ctx context.Context, ticket string) (*srvpb.FileOutline, error) {
+	missing, epoch := t.knownMissing(ctx, negFileOutline, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	res, err := t.staticLookupTables.fileOutline(ctx, ticket)
+	t.noteMissing(negFileOutline, ticket, epoch, err)
+	return res, err
+}
+
+func (t *Table) ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error) {
+	missing, epoch := t.knownMissing(ctx, negTicketAlias, ticket)
+	if missing {
+		return nil, table.ErrNoSuchKey
+	} else if err := t.chargeRead(ctx, false); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	res, err := t.staticLookupTables.ticketAlias(ctx, ticket)
+	t.noteMissing(negTicketAlias, ticket, epoch, err)
+	return res, err
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"sort"
+
+	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/storage/table"
+
+	"bitbucket.org/creachadair/stringset"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// maxRelatedNodeExpansionDepth is the maximum number of additional hops taken
+// when expanding the related nodes of a CrossReferencesReply.
+const maxRelatedNodeExpansionDepth = 3
+
+// expandRelatedNodes adds the related nodes of each RelatedNode already in the
+// reply as their own CrossReferenceSets, repeating for up to depth hops.  Only
+// relations matching relatedKinds are followed and NodeInfos are populated
+// using the given converter.
+func (t *Table) expandRelatedNodes(ctx context.Context, reply *xpb.CrossReferencesReply, depth int, relatedKinds stringset.Set, nc nodeConverter) error {
+	if depth > maxRelatedNodeExpansionDepth {
+		depth = maxRelatedNodeExpansionDepth
+	}
+
+	seen := stringset.New()
+	for ticket := range reply.CrossReferences {
+		seen.Add(ticket)
+	}
+	var frontier []string
+	for _, crs := range reply.CrossReferences {
+		for _, rn := range crs.RelatedNode {
+			if !seen.Contains(rn.Ticket) {
+				seen.Add(rn.Ticket)
+				frontier = append(frontier, rn.Ticket)
+			}
+		}
+	}
+
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		sort.Strings(frontier)
+		var next []string
+		for _, ticket := range frontier {
+			cr, err := t.crossReferences(ctx, ticket)
+			if err == table.ErrNoSuchKey {
+				continue
+			} else if err != nil {
+				return canonicalError(err, "related node expansion", ticket)
+			}
+
+			crs := &xpb.CrossReferencesReply_CrossReferenceSet{
+				Ticket:       ticket,
+				MarkedSource: cr.MarkedSource,
+			}
+			addGroup := func(grp *srvpb.PagedCrossReferences_Group) {
+				for _, rn := range grp.RelatedNode {
+					target := rn.Node.GetTicket()
+					if _, ok := reply.Nodes[target]; !ok {
+						if info := nc.ToInfo(rn.Node); info != nil {
+							reply.Nodes[target] = info
+						}
+					}
+					crs.RelatedNode = append(crs.RelatedNode, newRelatedNode(grp.Kind, rn))
+					if !seen.Contains(target) {
+						seen.Add(target)
+						next = append(next, target)
+					}
+				}
+			}
+
+			for _, grp := range cr.Group {
+				if len(grp.RelatedNode) > 0 && xrefs.IsRelatedNodeKind(relatedKinds, grp.Kind) {
+					addGroup(grp)
+				}
+			}
+			for _, idx := range cr.PageIndex {
+				if !xrefs.IsRelatedNodeKind(relatedKinds, idx.Kind) {
+					continue
+				}
+				p, err := t.crossReferencesPage(ctx, cr.GetSourceTicket(), idx)
+				if err != nil {
+					return crossReferencesPageError(idx.PageKey, err)
+				}
+				addGroup(p.Group)
+			}
+
+			if len(crs.RelatedNode) > 0 {
+				reply.CrossReferences[ticket] = crs
+			}
+		}
+		tracePrintf(ctx, "Expanded related nodes (hop %d): %d", hop+1, len(frontier))
+		frontier = next
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"flag"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/util/log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+var (
+	referenceReportPageSize  = flag.Int("reference_report_page_size", 1024, "Number of references read per CrossReferences page by a reference report")
+	referenceReportRetention = flag.Duration("reference_report_retention", time.Hour, "How long the status of a finished reference report is retained")
+)
+
+// A ReferenceSink receives the references found by a reference report.
+type ReferenceSink interface {
+	// WriteReference writes a single reference to the node with the given
+	// ticket.  Returning an error aborts the report.
+	WriteReference(ctx context.Context, ticket string, ref *xpb.CrossReferencesReply_RelatedAnchor) error
+}
+
+// ReferenceReportStatus is a snapshot of the progress of a reference report.
+type ReferenceReportStatus struct {
+	// ID is the report's unique identifier.
+	ID string
+
+	// Written is the number of references written to the report's sink.
+	Written int64
+
+	// Total is the number of references expected to be written, or 0 if not
+	// yet known.
+	Total int64
+
+	// Done is true if the report has finished, successfully or not.
+	Done bool
+
+	// Err is the reason the report failed or was cancelled, if any.
+	Err error
+}
+
+// ReferenceReports runs reports of all references to a set of nodes in the
+// background.  Unlike a CrossReferences request, a report is not bound by the
+// deadline of the request starting it; its results are written to a
+// caller-provided ReferenceSink as they are read from the serving table.
+type ReferenceReports struct {
+	t *Table
+
+	mu     sync.Mutex
+	nextID int
+	jobs   map[string]*referenceReport
+}
+
+type referenceReport struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	status ReferenceReportStatus
+}
+
+// NewReferenceReports returns a ReferenceReports reading from the given Table.
+func NewReferenceReports(t *Table) *ReferenceReports {
+	return &ReferenceReports{t: t, jobs: make(map[string]*referenceReport)}
+}
+
+// StartReferenceReport begins writing all references to the nodes named by
+// the given tickets to sink and returns the new report's ID.  The report runs
+// until it is finished or cancelled; ctx only applies to starting the report.
+func (r *ReferenceReports) StartReferenceReport(ctx context.Context, tickets []string, sink ReferenceSink) (string, error) {
+	var v validate.Validator
+	tickets = v.Tickets("ticket", tickets)
+	if sink == nil {
+		v.Addf("sink", "", "missing reference sink")
+	}
+	if err := v.Err(); err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	id := strconv.Itoa(r.nextID)
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job := &referenceReport{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		status: ReferenceReportStatus{ID: id},
+	}
+	r.jobs[id] = job
+
+	go func() {
+		err := r.run(jobCtx, job, tickets, sink)
+		if err != nil {
+			log.Warningf(jobCtx, "reference report %s failed: %v", id, err)
+		}
+		job.mu.Lock()
+		job.status.Done, job.status.Err = true, err
+		job.mu.Unlock()
+		cancel()
+		close(job.done)
+
+		time.AfterFunc(*referenceReportRetention, func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			delete(r.jobs, id)
+		})
+	}()
+	tracePrintf(ctx, "Started reference report %s for %d tickets", id, len(tickets))
+	return id, nil
+}
+
+// GetReferenceReport returns the current status of the given report.
+func (r *ReferenceReports) GetReferenceReport(ctx context.Context, id string) (*ReferenceReportStatus, error) {
+	job, err := r.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	status := job.status
+	return &status, nil
+}
+
+// CancelReferenceReport cancels the given report.  Cancelling a finished
+// report has no effect.
+func (r *ReferenceReports) CancelReferenceReport(ctx context.Context, id string) error {
+	job, err := r.lookup(id)
+	if err != nil {
+		return err
+	}
+	job.cancel()
+	return nil
+}
+
+func (r *ReferenceReports) lookup(id string) (*referenceReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "reference report not found: %q", id)
+	}
+	return job, nil
+}
+
+// run pages through the cross-references of tickets, writing each reference
+// to sink.
+func (r *ReferenceReports) run(ctx context.Context, job *referenceReport, tickets []string, sink ReferenceSink) error {
+	req := &xpb.CrossReferencesRequest{
+		Ticket:        tickets,
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		TotalsQuality: xpb.CrossReferencesRequest_PRECISE_TOTALS,
+		PageSize:      int32(*referenceReportPageSize),
+	}
+	for first := true; ; first = false {
+		reply, err := r.t.CrossReferences(ctx, req)
+		if err != nil {
+			return err
+		}
+		if first {
+			job.mu.Lock()
+			job.status.Total = reply.GetTotal().GetReferences()
+			job.mu.Unlock()
+		}
+
+		sets := make([]*xpb.CrossReferencesReply_CrossReferenceSet, 0, len(reply.CrossReferences))
+		for _, set := range reply.CrossReferences {
+			sets = append(sets, set)
+		}
+		sort.Slice(sets, func(i, j int) bool { return sets[i].Ticket < sets[j].Ticket })
+		for _, set := range sets {
+			for _, ref := range set.Reference {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if err := sink.WriteReference(ctx, set.Ticket, ref); err != nil {
+					return err
+				}
+				job.mu.Lock()
+				job.status.Written++
+				job.mu.Unlock()
+			}
+		}
+
+		if reply.NextPageToken == "" {
+			return nil
+		}
+		req.PageToken = reply.NextPageToken
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// A RequestLogSink receives a record of requests served by a Table, e.g. to
+// feed an analytics pipeline tracking the most requested tickets and files.
+// LogRequest is called synchronously once each request is served, so
+// implementations should not block.
+type RequestLogSink interface {
+	LogRequest(ctx context.Context, e *RequestLogEntry)
+}
+
+// MultiRequestLog returns a RequestLogSink sending each RequestLogEntry to
+// every non-nil sink, in order.  It returns nil if there are none.
+func MultiRequestLog(sinks ...RequestLogSink) RequestLogSink {
+	var m multiRequestLog
+	for _, s := range sinks {
+		if s != nil {
+			m = append(m, s)
+		}
+	}
+	switch len(m) {
+	case 0:
+		return nil
+	case 1:
+		return m[0]
+	default:
+		return m
+	}
+}
+
+type multiRequestLog []RequestLogSink
+
+// LogRequest implements the RequestLogSink interface.
+func (m multiRequestLog) LogRequest(ctx context.Context, e *RequestLogEntry) {
+	for _, s := range m {
+		s.LogRequest(ctx, e)
+	}
+}
+
+// A RequestLogEntry describes a single request served by a Table.
+type RequestLogEntry struct {
+	// Method is the name of the Table method serving the request, e.g.
+	// "CrossReferences".
+	Method string
+
+	// Tickets are the requested tickets, or the requested file ticket for a
+	// Decorations request.
+	Tickets []string
+
+	// Start is the time the request was received and Latency is the time taken
+	// to serve it.
+	Start   time.Time
+	Latency time.Duration
+
+	// ReplySize is the encoded size of the reply in bytes, or 0 if the request
+	// failed.
+	ReplySize int
+
+	// Err is the error returned for the request, if any.
+	Err error
+
+	// Phases is the cumulative time spent in each phase of serving the
+	// request (e.g. PhaseRead), for methods whose phases are timed.
+	Phases map[string]time.Duration
+}
+
+// logRequest records the given request in t.Health and sends a
+// RequestLogEntry for it to t.RequestLog, subject to t.RequestLogSampleRate.
+func (t *Table) logRequest(ctx context.Context, method string, tickets []string, start time.Time, reply proto.Message, err error) {
+	if t.Health != nil {
+		t.Health.RecordRequest(tickets, time.Since(start), err)
+	}
+	if t.RequestLog == nil {
+		return
+	} else if rate := t.RequestLogSampleRate; rate > 0 && rate < 1 && rand.Float64() >= rate {
+		return
+	}
+	e := &RequestLogEntry{
+		Method:  method,
+		Tickets: tickets,
+		Start:   start,
+		Latency: time.Since(start),
+		Err:     err,
+		Phases:  phaseTimingsFromContext(ctx).Durations(),
+	}
+	if err == nil {
+		e.ReplySize = proto.Size(reply)
+	}
+	t.RequestLog.LogRequest(ctx, e)
+}
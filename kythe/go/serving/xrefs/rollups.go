@@ -0,0 +1,78 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+
+	"kythe.io/kythe/go/services/validate"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/log"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// Rollup returns the number of references to the nodes contained by the
+// requested node or file (e.g. all references to the members of a package)
+// along with a page of its referenced members, as precomputed in the serving
+// table's container rollups index.  A container without referenced members
+// has an empty reply.
+func (t *Table) Rollup(ctx context.Context, req *xpb.RollupRequest) (*xpb.RollupReply, error) {
+	ctx = log.EnsureRequestID(ctx)
+	var v validate.Validator
+	container := v.Ticket("container", req.Container)
+	v.PageSize("page_size", req.PageSize)
+	skip, err := parseIndexPageToken(req.PageToken)
+	v.PageToken("page_token", req.PageToken, err)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := t.authorizeRequest(ctx, container); err != nil {
+		return nil, err
+	}
+
+	r, err := t.containerRollup(ctx, container)
+	if err == table.ErrNoSuchKey {
+		return &xpb.RollupReply{}, nil
+	} else if err != nil {
+		return nil, canonicalError(err, "container rollup", container)
+	}
+	if r, err = t.authorizedRollup(ctx, r); err != nil {
+		return nil, err
+	}
+
+	reply := &xpb.RollupReply{
+		ReferenceCount: r.ReferenceCount,
+		TotalTargets:   int64(len(r.Target)),
+	}
+	targets, next, err := indexPage(r.Target, skip, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	reply.NextPageToken = next
+
+	for _, target := range targets {
+		reply.Target = append(reply.Target, &xpb.RollupReply_Target{
+			Ticket:         target.Ticket,
+			Kind:           target.Kind,
+			ReferenceCount: target.ReferenceCount,
+		})
+	}
+	tracePrintf(ctx, "Rollup targets: %d/%d", len(reply.Target), reply.TotalTargets)
+	return reply, nil
+}
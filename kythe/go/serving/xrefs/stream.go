@@ -0,0 +1,339 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+
+	"kythe.io/kythe/go/util/compare"
+
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// A streamItem is an anchor of a cross-references group or one of its
+// callers.
+type streamItem struct {
+	anchor *srvpb.ExpandedAnchor // the anchor, or the caller's anchor
+	caller *srvpb.PagedCrossReferences_Caller
+
+	// kept reports whether the request's filters keep the item.
+	kept bool
+}
+
+// key returns the stored message of the item, which the request's filters
+// keep or remove in place.
+func (it streamItem) key() proto.Message {
+	if it.caller != nil {
+		return it.caller
+	}
+	return it.anchor
+}
+
+// groupItems returns the anchors (or callers) of grp.
+func groupItems(grp *srvpb.PagedCrossReferences_Group, c xrefCategory) []streamItem {
+	if c == xrefCategoryCall {
+		items := make([]streamItem, len(grp.Caller))
+		for i, caller := range grp.Caller {
+			items[i] = streamItem{anchor: caller.Caller, caller: caller}
+		}
+		return items
+	}
+	items := make([]streamItem, 0, groupSize(grp, c))
+	for _, a := range grp.Anchor {
+		items = append(items, streamItem{anchor: a})
+	}
+	for _, refs := range grp.GetScopedReference() {
+		// TODO(schroederc): make scopes available in API
+		for _, a := range refs.GetReference() {
+			items = append(items, streamItem{anchor: a})
+		}
+	}
+	return items
+}
+
+// groupSize returns the number of anchors (or callers) of grp.
+func groupSize(grp *srvpb.PagedCrossReferences_Group, c xrefCategory) int {
+	if c == xrefCategoryCall {
+		return len(grp.Caller)
+	}
+	return len(grp.Anchor) + countRefs(grp.GetScopedReference())
+}
+
+// streamOptions holds the request's options shared by its anchorStreams.
+type streamOptions struct {
+	order *anchorOrder
+
+	// read reads the given page of a stream.  It returns a nil group if the
+	// page is skipped.
+	read func(ctx context.Context, s *anchorStream, idx *srvpb.PagedCrossReferences_PageIndex) (*srvpb.PagedCrossReferences_Group, error)
+
+	// filter applies the request's filters to a group, returning the number of
+	// cross-references it removed.
+	filter func(*srvpb.PagedCrossReferences_Group) int
+
+	// stop reports whether to stop reading pages, returning the items read so
+	// far.
+	stop func() bool
+}
+
+// An anchorStream is the sequence of anchors (or callers) of a single
+// cross-references group of a set: either a group stored inline in the set or
+// a run of consecutive pages of the same edge kind and build configuration.
+// The items of each group or page are served in location order, as the
+// serving pipeline sorts them, so that the anchors of every stream of a
+// request can be merged into a single sequence sorted by location.
+//
+// The stream's position is the number of its stored items consumed, including
+// those removed by the request's filters.  Page tokens record the position of
+// each stream so that the following page continues where the previous page
+// stopped; whole pages before the position are skipped without being read.
+type anchorStream struct {
+	*streamOptions
+
+	// key identifies the stream in page tokens.
+	key string
+	// ticket is the ticket of the set from which the stream's pages are read.
+	ticket string
+
+	crs        *xpb.CrossReferencesReply_CrossReferenceSet
+	category   xrefCategory
+	incomplete bool
+
+	// pages holds the stream's unread pages.
+	pages []*srvpb.PagedCrossReferences_PageIndex
+
+	// group is the current group of the stream, or nil before its first page
+	// is read; page is the index of the page holding group, if any.
+	group *srvpb.PagedCrossReferences_Group
+	page  *srvpb.PagedCrossReferences_PageIndex
+	// conv converts the anchors of group; it is set when they are first added
+	// to the reply.
+	conv *anchorConverter
+
+	// items holds group's items in location order; next indexes the first
+	// unconsumed item.
+	items []streamItem
+	next  int
+
+	// base is the position of group's first item and size the number of items
+	// group contributes to the position.  start is the number of items of the
+	// stream's next page consumed by previous pages of the reply.
+	base, size, start int
+
+	// filtered is the number of cross-references removed from the stream's
+	// pages by filter.
+	filtered int
+}
+
+// newGroupStream returns a stream of the given inline group, starting at pos,
+// and the number of cross-references of grp removed by o.filter.
+func (o *streamOptions) newGroupStream(key string, crs *xpb.CrossReferencesReply_CrossReferenceSet, c xrefCategory, incomplete bool, grp *srvpb.PagedCrossReferences_Group, pos int) (*anchorStream, int) {
+	s := &anchorStream{streamOptions: o, key: key, crs: crs, category: c, incomplete: incomplete}
+	filtered := s.setGroup(grp)
+	s.size = len(s.items)
+	s.skip(pos)
+	return s, filtered
+}
+
+// newPagedStream returns a stream of pages of the set ticket, starting at pos.
+// Its pages are added by addPage.
+func (o *streamOptions) newPagedStream(key, ticket string, crs *xpb.CrossReferencesReply_CrossReferenceSet, c xrefCategory, incomplete bool, pos int) *anchorStream {
+	return &anchorStream{streamOptions: o, key: key, ticket: ticket, crs: crs, category: c, incomplete: incomplete, start: pos}
+}
+
+// addPage appends the given page to the stream.  Pages wholly before the
+// stream's starting position are skipped.
+func (s *anchorStream) addPage(idx *srvpb.PagedCrossReferences_PageIndex) {
+	if len(s.pages) == 0 && s.start >= int(idx.Count) {
+		s.start -= int(idx.Count)
+		s.base += int(idx.Count)
+		return
+	}
+	s.pages = append(s.pages, idx)
+}
+
+// setGroup makes grp the current group of the stream, filtering it and
+// sorting its items.  It returns the number of cross-references removed by
+// the filter.
+func (s *anchorStream) setGroup(grp *srvpb.PagedCrossReferences_Group) int {
+	// The filters remove items in place, so the stored items are listed first.
+	items := groupItems(grp, s.category)
+	filtered := s.filter(grp)
+	kept := make(map[proto.Message]bool, len(items))
+	for _, it := range groupItems(grp, s.category) {
+		kept[it.key()] = true
+	}
+	for i := range items {
+		items[i].kept = kept[items[i].key()]
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return s.order.CompareStored(items[i].anchor, items[j].anchor) == compare.LT
+	})
+	s.group, s.conv, s.items, s.next = grp, nil, items, 0
+	return filtered
+}
+
+// skip consumes the first n items of the stream's current group.
+func (s *anchorStream) skip(n int) {
+	if n > len(s.items) {
+		n = len(s.items)
+	}
+	s.next = n
+}
+
+// pos returns the stream's position.
+func (s *anchorStream) pos() int {
+	if s.next < len(s.items) {
+		return s.base + s.next
+	}
+	return s.base + s.size + s.start
+}
+
+// mayHaveMore reports whether the stream may have further items kept by the
+// request's filters.
+func (s *anchorStream) mayHaveMore() bool {
+	for _, it := range s.items[s.next:] {
+		if it.kept {
+			return true
+		}
+	}
+	return len(s.pages) > 0
+}
+
+// nextPage returns the page holding the stream's following items, if any.
+func (s *anchorStream) nextPage() *srvpb.PagedCrossReferences_PageIndex {
+	if s.next < len(s.items) {
+		return s.page
+	} else if len(s.pages) > 0 {
+		return s.pages[0]
+	}
+	return nil
+}
+
+// head returns the stream's next item kept by the request's filters, reading
+// its pages as needed, or false if the stream is exhausted.  Items removed by
+// the filters are consumed.
+func (s *anchorStream) head(ctx context.Context) (streamItem, bool, error) {
+	for {
+		for ; s.next < len(s.items); s.next++ {
+			if s.items[s.next].kept {
+				return s.items[s.next], true, nil
+			}
+		}
+		if len(s.pages) == 0 {
+			return streamItem{}, false, nil
+		}
+		idx := s.pages[0]
+		grp, err := s.read(ctx, s, idx)
+		if err != nil {
+			return streamItem{}, false, err
+		}
+		s.pages = s.pages[1:]
+		s.base += s.size
+		s.size = int(idx.Count)
+		start := s.start
+		s.page, s.start = idx, 0
+		if grp == nil {
+			// The page is skipped; its items count as consumed.
+			s.group, s.conv, s.items, s.next = nil, nil, nil, 0
+			continue
+		}
+		s.filtered += s.setGroup(grp)
+		s.skip(start)
+	}
+}
+
+// mergeStreams calls add for the items of the given streams in location
+// order, up to max items, and reports whether any stream may have further
+// items.  Streams are read no further than needed: once max items are added,
+// the last stream added from is not read to find its next item.
+func mergeStreams(ctx context.Context, streams []*anchorStream, max int, add func(*anchorStream, streamItem)) (bool, error) {
+	more := func() bool {
+		for _, s := range streams {
+			if s.mayHaveMore() {
+				return true
+			}
+		}
+		return false
+	}
+	if max <= 0 || len(streams) == 0 {
+		return more(), nil
+	}
+
+	h := &streamHeap{order: streams[0].order}
+	for _, s := range streams {
+		if s.stop() {
+			return true, nil
+		}
+		it, ok, err := s.head(ctx)
+		if err != nil {
+			return false, err
+		} else if ok {
+			h.entries = append(h.entries, streamHead{s, it})
+		}
+	}
+	heap.Init(h)
+	for n := 0; h.Len() > 0; {
+		top := &h.entries[0]
+		add(top.s, top.item)
+		top.s.next++
+		if n++; n == max {
+			break
+		}
+		if top.s.stop() {
+			return true, nil
+		}
+		it, ok, err := top.s.head(ctx)
+		if err != nil {
+			return false, err
+		} else if ok {
+			top.item = it
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return more(), nil
+}
+
+type streamHead struct {
+	s    *anchorStream
+	item streamItem
+}
+
+// streamHeap orders streams by the location of their next items.
+type streamHeap struct {
+	order   *anchorOrder
+	entries []streamHead
+}
+
+func (h *streamHeap) Len() int      { return len(h.entries) }
+func (h *streamHeap) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *streamHeap) Less(i, j int) bool {
+	return h.order.CompareStored(h.entries[i].item.anchor, h.entries[j].item.anchor) == compare.LT
+}
+func (h *streamHeap) Push(x any) { h.entries = append(h.entries, x.(streamHead)) }
+func (h *streamHeap) Pop() any {
+	e := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return e
+}
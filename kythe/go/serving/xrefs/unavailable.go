@@ -0,0 +1,139 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"sync"
+
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/schema/tickets"
+
+	"bitbucket.org/creachadair/stringset"
+
+	xpb "kythe.io/kythe/proto/xref_go_proto"
+)
+
+// markUnavailableFiles marks each anchor of the given reply whose parent file
+// has neither decorations nor a decorations override as file_unavailable.
+// Anchors are left unmarked if the table cannot tell which files have
+// decorations.
+func (t *Table) markUnavailableFiles(ctx context.Context, reply *xpb.CrossReferencesReply) error {
+	var anchors []*xpb.Anchor
+	for _, crs := range reply.GetCrossReferences() {
+		for _, ras := range [][]*xpb.CrossReferencesReply_RelatedAnchor{crs.Definition, crs.Declaration, crs.Reference, crs.Caller} {
+			for _, ra := range ras {
+				anchors = append(anchors, ra.Anchor)
+				anchors = append(anchors, ra.Site...)
+			}
+		}
+	}
+	for _, a := range reply.GetDefinitionLocations() {
+		anchors = append(anchors, a)
+	}
+
+	parents := make(map[*xpb.Anchor]string, len(anchors))
+	files := stringset.New()
+	for _, a := range anchors {
+		if parent := anchorParent(a); parent != "" {
+			parents[a] = parent
+			files.Add(parent)
+		}
+	}
+	if files.Empty() {
+		return nil
+	}
+
+	decorated, err := t.decorationsFilter(ctx)
+	if err == table.ErrNoSuchKey {
+		tracePrintf(ctx, "No decorations filter; not marking unavailable files")
+		return nil
+	} else if err != nil {
+		return canonicalError(err, "decorations filter", "")
+	}
+	unavailable := make(map[string]bool, len(files))
+	for file := range files {
+		unavailable[file] = !decorated(file) && !t.hasDecorationOverride(file)
+	}
+	for a, parent := range parents {
+		a.FileUnavailable = unavailable[parent]
+	}
+	return nil
+}
+
+// hasDecorationOverride reports whether t.DecorationOverrides holds an
+// unexpired override for the given file ticket.
+func (t *Table) hasDecorationOverride(file string) bool {
+	return t.DecorationOverrides != nil && t.DecorationOverrides.get(file) != nil
+}
+
+// A decorationsFilterCache holds the decorations filter of a Table once it
+// has been read.  Each Table is a single table generation, so its filter only
+// changes when files are added to it in place (see update.Updater).
+type decorationsFilterCache struct {
+	mu     sync.Mutex
+	loaded bool
+	filter fileFilter // nil if the table has no decorations filter
+}
+
+// decorationsFilter returns the table's decorations filter, reading it only
+// if it is not already cached.  A table without a filter is cached as such.
+func (t *Table) decorationsFilter(ctx context.Context) (fileFilter, error) {
+	c := &t.decorFilter
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.loaded {
+		if err := t.chargeRead(ctx, false); err != nil {
+			return nil, err
+		}
+		stop := startPhase(ctx, PhaseRead)
+		f, err := t.staticLookupTables.decorationsFilter(ctx)
+		stop()
+		if err != nil && err != table.ErrNoSuchKey {
+			return nil, err
+		}
+		c.filter, c.loaded = f, true
+	}
+	if c.filter == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	return c.filter, nil
+}
+
+// InvalidateDecorationsFilter drops the table's cached decorations filter so
+// that it is reread by the next request, e.g. after files are added to the
+// table in place.
+func (t *Table) InvalidateDecorationsFilter() {
+	t.decorFilter.mu.Lock()
+	defer t.decorFilter.mu.Unlock()
+	t.decorFilter.loaded, t.decorFilter.filter = false, nil
+}
+
+// anchorParent returns the ticket of the given anchor's parent file, or "" if
+// it is unknown (e.g. for a call site whose ticket was cleared).
+func anchorParent(a *xpb.Anchor) string {
+	if a.GetParent() != "" {
+		return a.GetParent()
+	} else if a.GetTicket() == "" {
+		return ""
+	}
+	parent, err := tickets.AnchorFile(a.GetTicket())
+	if err != nil {
+		return ""
+	}
+	return parent
+}
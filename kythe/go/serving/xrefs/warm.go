@@ -0,0 +1,256 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"kythe.io/kythe/go/storage/table"
+)
+
+// A WarmingManifest lists the hottest keys requested of each Table method, so
+// that a newly opened Table can be warmed with them by Warm before serving
+// requests.  It is encoded as JSON so that it can be persisted across
+// restarts.
+type WarmingManifest struct {
+	// Keys are the requested keys of each method, hottest first: the file
+	// tickets of "Decorations" and the node tickets of "CrossReferences" and
+	// "Documentation".
+	Keys map[string][]string `json:"keys"`
+}
+
+// ReadWarmingManifest decodes the JSON-encoded WarmingManifest read from r.
+func ReadWarmingManifest(r io.Reader) (*WarmingManifest, error) {
+	var m WarmingManifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("error decoding warming manifest: %v", err)
+	}
+	return &m, nil
+}
+
+// Write writes the JSON-encoded manifest to w.
+func (m *WarmingManifest) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(m)
+}
+
+// HotKeys is a RequestLogSink tracking how often each key is requested of each
+// Table method, from which a WarmingManifest of the hottest keys can be made.
+// It is safe for concurrent use.
+type HotKeys struct {
+	max int
+
+	mu   sync.Mutex
+	keys map[string]*hotKeyHeap
+}
+
+// NewHotKeys returns an empty HotKeys tracking at most max keys per method.
+// Once a method has max keys, each new key replaces its least requested key,
+// so counts are approximate.  If max <= 0, 100000 is used.
+func NewHotKeys(max int) *HotKeys {
+	if max <= 0 {
+		max = 100000
+	}
+	return &HotKeys{max: max, keys: make(map[string]*hotKeyHeap)}
+}
+
+// LogRequest implements the RequestLogSink interface.  Failed requests are not
+// counted.
+func (h *HotKeys) LogRequest(_ context.Context, e *RequestLogEntry) {
+	if e.Err != nil || warmers[e.Method] == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	keys := h.keys[e.Method]
+	if keys == nil {
+		keys = &hotKeyHeap{index: make(map[string]*hotKey)}
+		h.keys[e.Method] = keys
+	}
+	for _, key := range e.Tickets {
+		if k, ok := keys.index[key]; ok {
+			k.count++
+			heap.Fix(keys, k.pos)
+			continue
+		} else if len(keys.keys) >= h.max {
+			delete(keys.index, heap.Pop(keys).(*hotKey).key)
+		}
+		k := &hotKey{key: key, count: 1}
+		keys.index[key] = k
+		heap.Push(keys, k)
+	}
+}
+
+// Manifest returns a WarmingManifest of the n most requested keys of each
+// method.
+func (h *HotKeys) Manifest(n int) *WarmingManifest {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := &WarmingManifest{Keys: make(map[string][]string, len(h.keys))}
+	for method, keys := range h.keys {
+		if hot := keys.hottest(); len(hot) > n {
+			m.Keys[method] = hot[:n]
+		} else if len(hot) > 0 {
+			m.Keys[method] = hot
+		}
+	}
+	return m
+}
+
+// A hotKey is a key tracked by HotKeys along with its request count.
+type hotKey struct {
+	key   string
+	count int64
+	pos   int // index in hotKeyHeap.keys
+}
+
+// colder reports whether k is requested less than o, with ties ordered by
+// descending key.
+func (k *hotKey) colder(o *hotKey) bool {
+	if k.count != o.count {
+		return k.count < o.count
+	}
+	return k.key > o.key
+}
+
+// A hotKeyHeap is a min-heap of the keys of a method by their request counts,
+// so that its least requested key is evicted first.  It implements
+// heap.Interface.
+type hotKeyHeap struct {
+	keys  []*hotKey
+	index map[string]*hotKey
+}
+
+func (h *hotKeyHeap) Len() int           { return len(h.keys) }
+func (h *hotKeyHeap) Less(i, j int) bool { return h.keys[i].colder(h.keys[j]) }
+func (h *hotKeyHeap) Swap(i, j int) {
+	h.keys[i], h.keys[j] = h.keys[j], h.keys[i]
+	h.keys[i].pos, h.keys[j].pos = i, j
+}
+func (h *hotKeyHeap) Push(x interface{}) {
+	k := x.(*hotKey)
+	k.pos = len(h.keys)
+	h.keys = append(h.keys, k)
+}
+func (h *hotKeyHeap) Pop() interface{} {
+	k := h.keys[len(h.keys)-1]
+	h.keys = h.keys[:len(h.keys)-1]
+	return k
+}
+
+// hottest returns the keys of h from the most to the least requested, with
+// ties ordered by key.
+func (h *hotKeyHeap) hottest() []string {
+	sorted := append([]*hotKey(nil), h.keys...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[j].colder(sorted[i]) })
+	keys := make([]string, len(sorted))
+	for i, k := range sorted {
+		keys[i] = k.key
+	}
+	return keys
+}
+
+// warmers read the table entry of a key requested of each Table method.
+var warmers = map[string]func(ctx context.Context, t staticLookupTables, key string) error{
+	"Decorations": func(ctx context.Context, t staticLookupTables, key string) error {
+		_, err := t.fileDecorations(ctx, key)
+		return err
+	},
+	"CrossReferences": func(ctx context.Context, t staticLookupTables, key string) error {
+		_, err := t.crossReferences(ctx, key)
+		return err
+	},
+	"Documentation": func(ctx context.Context, t staticLookupTables, key string) error {
+		_, err := t.documentation(ctx, key)
+		return err
+	},
+}
+
+// A WarmingBudget bounds the work done by Warm.
+type WarmingBudget struct {
+	// MaxKeys is the maximum number of keys read.  If <= 0, every key of the
+	// manifest is read.
+	MaxKeys int
+
+	// Timeout is the maximum time spent reading keys.  If <= 0, there is no
+	// limit other than the context's.
+	Timeout time.Duration
+}
+
+// WarmingStats reports the work done by Warm.
+type WarmingStats struct {
+	// Keys is the number of keys read, of which Missing had no table entry and
+	// Errors failed to be read.
+	Keys    int `json:"keys"`
+	Missing int `json:"missing,omitempty"`
+	Errors  int `json:"errors,omitempty"`
+
+	// Duration is the time spent reading keys.
+	Duration time.Duration `json:"duration"`
+}
+
+// Warm reads the table entries of the keys of m, within the given budget, so
+// that the caches underlying t (e.g. its LevelDB block cache) hold them before
+// t serves requests.  The methods' keys are read in turn, hottest first, so
+// that a budget cutting warming short still covers the hottest keys of each.
+// Only the keys' top-level entries are read, not their pages, and reads are
+// neither charged to t.ReadQuota nor recorded in t.NegativeCache.
+func (t *Table) Warm(ctx context.Context, m *WarmingManifest, budget *WarmingBudget) *WarmingStats {
+	start := time.Now()
+	if budget != nil && budget.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, budget.Timeout)
+		defer cancel()
+	}
+	var methods []string
+	for method := range m.Keys {
+		if warmers[method] != nil {
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+
+	stats := &WarmingStats{}
+	defer func() { stats.Duration = time.Since(start) }()
+	for i := 0; ; i++ {
+		var read bool
+		for _, method := range methods {
+			keys := m.Keys[method]
+			if i >= len(keys) {
+				continue
+			} else if ctx.Err() != nil || (budget != nil && budget.MaxKeys > 0 && stats.Keys >= budget.MaxKeys) {
+				return stats
+			}
+			read = true
+			stats.Keys++
+			if err := warmers[method](ctx, t.staticLookupTables, keys[i]); err == table.ErrNoSuchKey {
+				stats.Missing++
+			} else if err != nil && ctx.Err() == nil {
+				stats.Errors++
+			}
+		}
+		if !read {
+			return stats
+		}
+	}
+}
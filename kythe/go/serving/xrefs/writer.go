@@ -0,0 +1,439 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package xrefs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"kythe.io/kythe/go/serving/pagekey"
+	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/bloom"
+	"kythe.io/kythe/go/util/compare"
+	"kythe.io/kythe/go/util/kytheuri"
+
+	"bitbucket.org/creachadair/stringset"
+	"google.golang.org/protobuf/proto"
+
+	srvpb "kythe.io/kythe/proto/serving_go_proto"
+)
+
+// defaultWriterPageSize is the default maximum number of cross-references kept
+// in any single PagedCrossReferences_Group written by a Writer.
+const defaultWriterPageSize = 4096
+
+// A Writer writes serving data in the format expected by a Table.  Tickets are
+// canonicalized before being used as keys.
+type Writer struct {
+	// PageSize is the maximum number of anchors, related nodes, or callers
+	// kept in any single PagedCrossReferences_Group and the maximum number of
+	// decorations kept in any single FileDecorations; larger groups and
+	// decoration sets are split into pages.  If <= 0, a default of 4096 is
+	// used.
+	PageSize int
+
+	decorations, decorPages       table.Proto
+	crossRefs, crossRefPages      table.Proto
+	documentation, names, digests table.Proto
+	fileRefs, fileRelations       table.Proto
+	callDegrees, members          table.Proto
+	rollups, aliases, outlines    table.Proto
+	decorFilter                   table.Proto
+	combined                      bool
+}
+
+// NewCombinedWriter returns a Writer for a table read by NewCombinedTable.
+func NewCombinedWriter(t table.Proto) *Writer {
+	return &Writer{
+		decorations:   t,
+		decorPages:    t,
+		crossRefs:     t,
+		crossRefPages: t,
+		documentation: t,
+		names:         t,
+		digests:       t,
+		fileRefs:      t,
+		fileRelations: t,
+		callDegrees:   t,
+		members:       t,
+		rollups:       t,
+		aliases:       t,
+		outlines:      t,
+		decorFilter:   t,
+		combined:      true,
+	}
+}
+
+// NewSplitWriter returns a Writer for the tables of a SplitTable.  Writing to
+// an optional table that is unset in s is an error.
+func NewSplitWriter(s *SplitTable) *Writer {
+	return &Writer{
+		decorations:   s.Decorations,
+		decorPages:    s.DecorationPages,
+		crossRefs:     s.CrossReferences,
+		crossRefPages: s.CrossReferencePages,
+		documentation: s.Documentation,
+		names:         s.DisplayNames,
+		digests:       s.FileDigests,
+		fileRefs:      s.FileReferences,
+		fileRelations: s.FileRelations,
+		callDegrees:   s.CallDegrees,
+		members:       s.ContainerMembers,
+		rollups:       s.ContainerRollups,
+		aliases:       s.TicketAliases,
+		outlines:      s.FileOutlines,
+		decorFilter:   s.DecorationsFilter,
+	}
+}
+
+func (w *Writer) put(ctx context.Context, t table.Proto, key []byte, combinedKey func(string) []byte, msg proto.Message) error {
+	if t == nil {
+		return fmt.Errorf("no table for %T", msg)
+	}
+	if w.combined {
+		key = combinedKey(string(key))
+	}
+	if err := t.Put(ctx, key, msg); err != nil {
+		return fmt.Errorf("error writing %T %q: %v", msg, key, err)
+	}
+	return nil
+}
+
+func fixTicket(ticket string) (string, error) {
+	fixed, err := kytheuri.Fix(ticket)
+	if err != nil {
+		return "", fmt.Errorf("invalid ticket %q: %v", ticket, err)
+	}
+	return fixed, nil
+}
+
+// WriteDecorations writes the given FileDecorations keyed by its file ticket.
+// Decorations beyond the Writer's PageSize are split into
+// FileDecorationsPages, which are written along with the set.  The given set
+// is not modified.
+func (w *Writer) WriteDecorations(ctx context.Context, fd *srvpb.FileDecorations) error {
+	ticket, err := fixTicket(fd.GetFile().GetTicket())
+	if err != nil {
+		return err
+	}
+	if len(fd.Decoration) > w.pageSize() {
+		fd = proto.Clone(fd).(*srvpb.FileDecorations)
+		for _, p := range pageFileDecorations(ticket, fd, w.pageSize()) {
+			if err := w.WriteDecorationsPage(ctx, p); err != nil {
+				return err
+			}
+		}
+	}
+	return w.put(ctx, w.decorations, []byte(ticket), DecorationsKey, fd)
+}
+
+// WriteDecorationsPage writes the given FileDecorationsPage keyed by its page
+// key.
+func (w *Writer) WriteDecorationsPage(ctx context.Context, p *srvpb.FileDecorationsPage) error {
+	if err := pagekey.Validate(p.PageKey, pagekey.DecorationsPage, "", ""); err != nil {
+		return fmt.Errorf("invalid FileDecorationsPage: %v", err)
+	}
+	return w.put(ctx, w.decorPages, []byte(p.PageKey), DecorationsPageKey, p)
+}
+
+// AddDecoratedFiles adds the given file tickets to the table's decorations
+// filter so that files whose FileDecorations were written after the table was
+// built are not reported as unavailable.  It does nothing if the table has no
+// decorations filter.
+func (w *Writer) AddDecoratedFiles(ctx context.Context, files ...string) error {
+	if w.decorFilter == nil {
+		return nil
+	}
+	var f srvpb.ExistenceFilter
+	if err := w.decorFilter.Lookup(ctx, DecorationsFilterKey, &f); err == table.ErrNoSuchKey {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading decorations filter: %v", err)
+	} else if len(f.Bits) == 0 || f.HashCount < 1 {
+		return fmt.Errorf("invalid decorations filter: %d bits, %d hashes", len(f.Bits)*8, f.HashCount)
+	}
+	filter := bloom.FromBits(f.Bits, int(f.HashCount))
+	for _, file := range files {
+		ticket, err := fixTicket(file)
+		if err != nil {
+			return err
+		}
+		filter.Add(ticket)
+	}
+	f.Bits = filter.Bits()
+	if err := w.decorFilter.Put(ctx, DecorationsFilterKey, &f); err != nil {
+		return fmt.Errorf("error writing decorations filter: %v", err)
+	}
+	return nil
+}
+
+func (w *Writer) pageSize() int {
+	if w.PageSize <= 0 {
+		return defaultWriterPageSize
+	}
+	return w.PageSize
+}
+
+// WriteCrossReferences writes the given PagedCrossReferences keyed by its
+// source ticket.  Any group larger than the Writer's PageSize is split into
+// PagedCrossReferences_Pages, which are written along with the set.  The given
+// set is not modified.
+func (w *Writer) WriteCrossReferences(ctx context.Context, cr *srvpb.PagedCrossReferences) error {
+	ticket, err := fixTicket(cr.SourceTicket)
+	if err != nil {
+		return err
+	}
+	cr = proto.Clone(cr).(*srvpb.PagedCrossReferences)
+	for _, p := range pageCrossReferences(cr, w.pageSize()) {
+		if err := w.WriteCrossReferencesPage(ctx, p); err != nil {
+			return err
+		}
+	}
+	return w.put(ctx, w.crossRefs, []byte(ticket), CrossReferencesKey, cr)
+}
+
+// WriteCrossReferencesPage writes the given PagedCrossReferences_Page keyed by
+// its page key.
+func (w *Writer) WriteCrossReferencesPage(ctx context.Context, p *srvpb.PagedCrossReferences_Page) error {
+	if err := pagekey.Validate(p.PageKey, pagekey.CrossReferencesPage, p.SourceTicket, p.GetGroup().GetKind()); err != nil {
+		return fmt.Errorf("invalid PagedCrossReferences_Page: %v", err)
+	}
+	return w.put(ctx, w.crossRefPages, []byte(p.PageKey), CrossReferencesPageKey, p)
+}
+
+// WriteDocument writes the given Document keyed by its ticket.
+func (w *Writer) WriteDocument(ctx context.Context, d *srvpb.Document) error {
+	ticket, err := fixTicket(d.Ticket)
+	if err != nil {
+		return err
+	}
+	return w.put(ctx, w.documentation, []byte(ticket), DocumentationKey, d)
+}
+
+// WriteDisplayName writes the given DisplayName keyed by its ticket.
+func (w *Writer) WriteDisplayName(ctx context.Context, n *srvpb.DisplayName) error {
+	ticket, err := fixTicket(n.Ticket)
+	if err != nil {
+		return err
+	}
+	return w.put(ctx, w.names, []byte(ticket), DisplayNameKey, n)
+}
+
+// WriteFileDigest writes the given FileDigest keyed by its digest.
+func (w *Writer) WriteFileDigest(ctx context.Context, d *srvpb.FileDigest) error {
+	return w.put(ctx, w.digests, []byte(d.Digest), FileDigestKey, d)
+}
+
+// WriteFileReferences writes the given FileReferences keyed by its file ticket.
+func (w *Writer) WriteFileReferences(ctx context.Context, r *srvpb.FileReferences) error {
+	ticket, err := fixTicket(r.File)
+	if err != nil {
+		return err
+	}
+	return w.put(ctx, w.fileRefs, []byte(ticket), FileReferencesKey, r)
+}
+
+// WriteFileRelations writes the given FileRelations keyed by its file ticket.
+func (w *Writer) WriteFileRelations(ctx context.Context, r *srvpb.FileRelations) error {
+	ticket, err := fixTicket(r.File)
+	if err != nil {
+		return err
+	}
+	return w.put(ctx, w.fileRelations, []byte(ticket), FileRelationsKey, r)
+}
+
+// WriteCallDegrees writes the given CallDegrees keyed by its function ticket.
+func (w *Writer) WriteCallDegrees(ctx context.Context, d *srvpb.CallDegrees) error {
+	ticket, err := fixTicket(d.Ticket)
+	if err != nil {
+		return err
+	}
+	return w.put(ctx, w.callDegrees, []byte(ticket), CallDegreesKey, d)
+}
+
+// WriteContainerMembers writes the given ContainerMembers keyed by its member
+// kind and container ticket.
+func (w *Writer) WriteContainerMembers(ctx context.Context, m *srvpb.ContainerMembers) error {
+	ticket, err := fixTicket(m.Container)
+	if err != nil {
+		return err
+	} else if m.Kind == "" {
+		return fmt.Errorf("missing member kind for container %q", ticket)
+	}
+	return w.put(ctx, w.members, []byte(membersKey(ticket, m.Kind)), func(key string) []byte {
+		return []byte(membersTablePrefix + key)
+	}, m)
+}
+
+// WriteContainerRollup writes the given ContainerRollup keyed by its container
+// ticket.
+func (w *Writer) WriteContainerRollup(ctx context.Context, r *srvpb.ContainerRollup) error {
+	ticket, err := fixTicket(r.Container)
+	if err != nil {
+		return err
+	}
+	return w.put(ctx, w.rollups, []byte(ticket), RollupsKey, r)
+}
+
+// WriteFileOutline writes the given FileOutline keyed by its file ticket.
+func (w *Writer) WriteFileOutline(ctx context.Context, o *srvpb.FileOutline) error {
+	ticket, err := fixTicket(o.File)
+	if err != nil {
+		return err
+	}
+	return w.put(ctx, w.outlines, []byte(ticket), OutlineKey, o)
+}
+
+// WriteTicketAlias writes the given TicketAlias keyed by its aliased ticket.
+func (w *Writer) WriteTicketAlias(ctx context.Context, a *srvpb.TicketAlias) error {
+	ticket, err := fixTicket(a.Alias)
+	if err != nil {
+		return err
+	}
+	return w.put(ctx, w.aliases, []byte(ticket), TicketAliasKey, a)
+}
+
+// pageCrossReferences splits each group of cr with more than pageSize anchors,
+// related nodes, or callers, keeping the first pageSize of each in the set and
+// moving the remainder, in order, into new pages.  The new pages are returned
+// and indexed in cr.  Since the new pages are not searchable, cr's page search
+// index is dropped if any pages are added.
+func pageCrossReferences(cr *srvpb.PagedCrossReferences, pageSize int) []*srvpb.PagedCrossReferences_Page {
+	keys := stringset.New()
+	for _, idx := range cr.PageIndex {
+		keys.Add(idx.PageKey)
+	}
+	nextPage := len(cr.PageIndex)
+	nextKey := func(group string) string {
+		for {
+			key := pagekey.New(pagekey.CrossReferencesPage, cr.SourceTicket, group, nextPage)
+			nextPage++
+			if keys.Add(key) {
+				return key
+			}
+		}
+	}
+
+	var pages []*srvpb.PagedCrossReferences_Page
+	addPage := func(grp *srvpb.PagedCrossReferences_Group, count int) {
+		p := &srvpb.PagedCrossReferences_Page{
+			PageKey:      nextKey(grp.Kind),
+			SourceTicket: cr.SourceTicket,
+			Group:        grp,
+		}
+		cr.PageIndex = append(cr.PageIndex, &srvpb.PagedCrossReferences_PageIndex{
+			Kind:             grp.Kind,
+			BuildConfig:      grp.BuildConfig,
+			Count:            int32(count),
+			PageKey:          p.PageKey,
+			UncompressedSize: int64(proto.Size(p)),
+		})
+		pages = append(pages, p)
+	}
+	newGroup := func(g *srvpb.PagedCrossReferences_Group) *srvpb.PagedCrossReferences_Group {
+		return &srvpb.PagedCrossReferences_Group{
+			Kind:        g.Kind,
+			BuildConfig: g.BuildConfig,
+			FileInfo:    g.FileInfo,
+		}
+	}
+
+	for _, g := range cr.Group {
+		if len(g.Anchor) > pageSize {
+			rest := g.Anchor[pageSize:]
+			g.Anchor = g.Anchor[:pageSize]
+			for len(rest) > 0 {
+				n := pageLength(len(rest), pageSize)
+				pg := newGroup(g)
+				pg.Anchor, rest = rest[:n], rest[n:]
+				addPage(pg, n)
+			}
+		}
+		if len(g.RelatedNode) > pageSize {
+			rest := g.RelatedNode[pageSize:]
+			g.RelatedNode = g.RelatedNode[:pageSize]
+			for len(rest) > 0 {
+				n := pageLength(len(rest), pageSize)
+				pg := newGroup(g)
+				pg.RelatedNode, rest = rest[:n], rest[n:]
+				addPage(pg, n)
+			}
+		}
+		if len(g.Caller) > pageSize {
+			rest := g.Caller[pageSize:]
+			g.Caller = g.Caller[:pageSize]
+			for len(rest) > 0 {
+				n := pageLength(len(rest), pageSize)
+				pg := newGroup(g)
+				pg.Caller, rest = rest[:n], rest[n:]
+				addPage(pg, n)
+			}
+		}
+	}
+	if len(pages) > 0 {
+		cr.PageSearchIndex = nil
+	}
+	return pages
+}
+
+// pageFileDecorations splits the decorations of fd beyond the first pageSize
+// into new pages, each holding at most pageSize decorations.  Decorations are
+// first sorted by their anchor offsets so that each page spans a contiguous
+// range of the file.  The new pages are returned and indexed in fd.
+func pageFileDecorations(ticket string, fd *srvpb.FileDecorations, pageSize int) []*srvpb.FileDecorationsPage {
+	if len(fd.Decoration) <= pageSize {
+		return nil
+	}
+	sort.SliceStable(fd.Decoration, func(i, j int) bool {
+		a, b := fd.Decoration[i].GetAnchor(), fd.Decoration[j].GetAnchor()
+		return compare.Compare(a.GetStartOffset(), b.GetStartOffset()).
+			AndThen(a.GetEndOffset(), b.GetEndOffset()) == compare.LT
+	})
+
+	var pages []*srvpb.FileDecorationsPage
+	rest := fd.Decoration[pageSize:]
+	fd.Decoration = fd.Decoration[:pageSize]
+	for len(rest) > 0 {
+		n := pageLength(len(rest), pageSize)
+		p := &srvpb.FileDecorationsPage{
+			PageKey:    pagekey.New(pagekey.DecorationsPage, ticket, "", len(fd.DecorationPage)),
+			Decoration: rest[:n],
+		}
+		rest = rest[n:]
+
+		idx := &srvpb.FileDecorations_DecorationPage{
+			PageKey:     p.PageKey,
+			StartOffset: p.Decoration[0].GetAnchor().GetStartOffset(),
+		}
+		for _, d := range p.Decoration {
+			if end := d.GetAnchor().GetEndOffset(); end > idx.EndOffset {
+				idx.EndOffset = end
+			}
+		}
+		fd.DecorationPage = append(fd.DecorationPage, idx)
+		pages = append(pages, p)
+	}
+	return pages
+}
+
+func pageLength(remaining, pageSize int) int {
+	if remaining < pageSize {
+		return remaining
+	}
+	return pageSize
+}
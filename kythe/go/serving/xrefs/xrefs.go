@@ -19,28 +19,47 @@
 //
 // Table format:
 //
-//	decor:<ticket>         -> srvpb.FileDecorations
-//	docs:<ticket>          -> srvpb.Document
-//	xrefs:<ticket>         -> srvpb.PagedCrossReferences
-//	xrefPages:<page_key>   -> srvpb.PagedCrossReferences_Page
+//	decor:<ticket>          -> srvpb.FileDecorations
+//	decorPages:<page_key>   -> srvpb.FileDecorationsPage
+//	decorFilter             -> srvpb.ExistenceFilter
+//	docs:<ticket>           -> srvpb.Document
+//	xrefs:<ticket>          -> srvpb.PagedCrossReferences
+//	xrefPages:<page_key>    -> srvpb.PagedCrossReferences_Page
+//	names:<ticket>          -> srvpb.DisplayName
+//	digests:<digest>        -> srvpb.FileDigest
+//	fileRefs:<ticket>       -> srvpb.FileReferences
+//	fileRelations:<ticket>  -> srvpb.FileRelations
+//	callDegrees:<ticket>    -> srvpb.CallDegrees
+//	members:<kind>:<ticket> -> srvpb.ContainerMembers
+//	rollups:<ticket>        -> srvpb.ContainerRollup
+//	outline:<ticket>        -> srvpb.FileOutline
+//	aliases:<ticket>        -> srvpb.TicketAlias
 package xrefs // import "kythe.io/kythe/go/serving/xrefs"
 
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"flag"
 	"fmt"
-	"log"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/validate"
 	"kythe.io/kythe/go/services/xrefs"
+	"kythe.io/kythe/go/serving/pagekey"
+	"kythe.io/kythe/go/serving/readahead"
+	"kythe.io/kythe/go/serving/tablecheck"
 	"kythe.io/kythe/go/storage/table"
+	"kythe.io/kythe/go/util/bloom"
+	enctext "kythe.io/kythe/go/util/encoding/text"
 	"kythe.io/kythe/go/util/flagutil"
 	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/log"
 	"kythe.io/kythe/go/util/schema/edges"
 	"kythe.io/kythe/go/util/schema/facts"
 	"kythe.io/kythe/go/util/schema/tickets"
@@ -65,8 +84,9 @@ var (
 
 	experimentalCrossReferenceIndirectionKinds flagutil.StringMultimap
 
-	// TODO(schroederc): remove once relevant clients specify their required quality
-	defaultTotalsQuality = flag.String("experimental_default_totals_quality", "APPROXIMATE_TOTALS", "Default TotalsQuality when unspecified in CrossReferencesRequest")
+	// Anchors are paged in location order, which reads every requested set, so
+	// totals are always precise.  The flag is kept for existing command lines.
+	_ = flag.String("experimental_default_totals_quality", "PRECISE_TOTALS", "Deprecated: has no effect; CrossReferences totals are always precise")
 
 	pageReadAhead = flag.Uint("page_read_ahead", 0, "How many xref pages to read ahead concurrently (0 disables readahead)")
 
@@ -80,11 +100,29 @@ func init() {
 
 type staticLookupTables interface {
 	fileDecorations(ctx context.Context, ticket string) (*srvpb.FileDecorations, error)
+	fileDecorationsPage(ctx context.Context, key string) (*srvpb.FileDecorationsPage, error)
 	crossReferences(ctx context.Context, ticket string) (*srvpb.PagedCrossReferences, error)
 	crossReferencesPage(ctx context.Context, key string) (*srvpb.PagedCrossReferences_Page, error)
 	documentation(ctx context.Context, ticket string) (*srvpb.Document, error)
+	displayName(ctx context.Context, ticket string) (*srvpb.DisplayName, error)
+	fileDigest(ctx context.Context, digest string) (*srvpb.FileDigest, error)
+	fileReferences(ctx context.Context, ticket string) (*srvpb.FileReferences, error)
+	fileRelations(ctx context.Context, ticket string) (*srvpb.FileRelations, error)
+	callDegrees(ctx context.Context, ticket string) (*srvpb.CallDegrees, error)
+	containerMembers(ctx context.Context, container, kind string) (*srvpb.ContainerMembers, error)
+	containerRollup(ctx context.Context, ticket string) (*srvpb.ContainerRollup, error)
+	fileOutline(ctx context.Context, ticket string) (*srvpb.FileOutline, error)
+	ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error)
+
+	// decorationsFilter returns a filter reporting whether a file ticket may
+	// have a FileDecorations entry; false results are definite.  It returns
+	// table.ErrNoSuchKey if the table cannot tell.
+	decorationsFilter(ctx context.Context) (fileFilter, error)
 }
 
+// A fileFilter reports whether the given file ticket may be in a set of files.
+type fileFilter func(file string) bool
+
 // SplitTable implements the xrefs Service interface using separate static
 // lookup tables for each API component.
 type SplitTable struct {
@@ -92,6 +130,14 @@ type SplitTable struct {
 	// location tickets.
 	Decorations table.Proto
 
+	// DecorationPages is an optional table of srvpb.FileDecorationsPages keyed
+	// by their page keys.
+	DecorationPages table.Proto
+
+	// DecorationsFilter is an optional table holding the srvpb.ExistenceFilter
+	// of the file tickets keying Decorations under DecorationsFilterKey.
+	DecorationsFilter table.Proto
+
 	// CrossReferences is a table of srvpb.PagedCrossReferences keyed by their
 	// source node tickets.
 	CrossReferences table.Proto
@@ -103,24 +149,64 @@ type SplitTable struct {
 	// Documentation is a table of srvpb.Documents keyed by their node ticket.
 	Documentation table.Proto
 
+	// DisplayNames is an optional table of srvpb.DisplayNames keyed by their
+	// node ticket.
+	DisplayNames table.Proto
+
+	// FileDigests is an optional table of srvpb.FileDigests keyed by the digest
+	// of their files' contents.
+	FileDigests table.Proto
+
+	// FileReferences is an optional table of srvpb.FileReferences keyed by
+	// their file tickets.
+	FileReferences table.Proto
+
+	// FileRelations is an optional table of srvpb.FileRelations keyed by their
+	// file tickets.
+	FileRelations table.Proto
+
+	// CallDegrees is an optional table of srvpb.CallDegrees keyed by their
+	// function tickets.
+	CallDegrees table.Proto
+
+	// ContainerMembers is an optional table of srvpb.ContainerMembers keyed by
+	// their member kinds and container tickets (see MembersKey).
+	ContainerMembers table.Proto
+
+	// ContainerRollups is an optional table of srvpb.ContainerRollups keyed by
+	// their container tickets.
+	ContainerRollups table.Proto
+
+	// FileOutlines is an optional table of srvpb.FileOutlines keyed by their
+	// file tickets.
+	FileOutlines table.Proto
+
+	// TicketAliases is an optional table of srvpb.TicketAliases keyed by their
+	// aliased tickets.
+	TicketAliases table.Proto
+
 	// RewriteEdgeLabel is an optional callback to rewrite edge labels.
 	// It will be called once per request; the function it returns will then be
 	// called once per edge.
 	RewriteEdgeLabel func(context.Context) func(string) string
 }
 
-func (s *SplitTable) rewriteFileDecorations(ctx context.Context, fd *srvpb.FileDecorations, err error) (*srvpb.FileDecorations, error) {
-	if fd == nil || err != nil || s.RewriteEdgeLabel == nil || fd.Decoration == nil {
-		return fd, err
+// edgeLabelRewriter returns the function rewriting the edge labels of the
+// entries read for a request, or nil if they are served as stored.
+func (s *SplitTable) edgeLabelRewriter(ctx context.Context) func(string) string {
+	if s.RewriteEdgeLabel == nil {
+		return nil
 	}
-	f := s.RewriteEdgeLabel(ctx)
+	return s.RewriteEdgeLabel(ctx)
+}
+
+func rewriteDecorations(ds []*srvpb.FileDecorations_Decoration, f func(string) string) {
 	if f == nil {
-		return fd, err
+		return
 	}
-	for _, d := range fd.Decoration {
+	for _, d := range ds {
 		d.Kind = f(d.Kind)
 	}
-	return fd, err
 }
 
 func rewriteCrossReferencesGroup(g *srvpb.PagedCrossReferences_Group, f func(string) string) {
@@ -129,53 +215,134 @@ func rewriteCrossReferencesGroup(g *srvpb.PagedCrossReferences_Group, f func(str
 	}
 }
 
-func (s *SplitTable) rewriteCrossReferences(ctx context.Context, cr *srvpb.PagedCrossReferences, err error) (*srvpb.PagedCrossReferences, error) {
-	if cr == nil || err != nil || s.RewriteEdgeLabel == nil || cr.Group == nil {
-		return cr, err
-	}
-	f := s.RewriteEdgeLabel(ctx)
-	for _, g := range cr.Group {
-		rewriteCrossReferencesGroup(g, f)
-	}
-	return cr, err
-}
-
-func (s *SplitTable) rewriteCrossReferencesPage(ctx context.Context, cr *srvpb.PagedCrossReferences_Page, err error) (*srvpb.PagedCrossReferences_Page, error) {
-	if cr == nil || err != nil || s.RewriteEdgeLabel == nil || cr.Group == nil {
-		return cr, err
-	}
-	f := s.RewriteEdgeLabel(ctx)
-	rewriteCrossReferencesGroup(cr.Group, f)
-	return cr, err
-}
-
 func (s *SplitTable) fileDecorations(ctx context.Context, ticket string) (*srvpb.FileDecorations, error) {
 	tracePrintf(ctx, "Reading FileDecorations: %s", ticket)
 	var fd srvpb.FileDecorations
-	return s.rewriteFileDecorations(ctx, &fd, s.Decorations.Lookup(ctx, []byte(ticket), &fd))
+	return &fd, s.Decorations.Lookup(ctx, []byte(ticket), &fd)
+}
+func (s *SplitTable) fileDecorationsPage(ctx context.Context, key string) (*srvpb.FileDecorationsPage, error) {
+	if s.DecorationPages == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading FileDecorationsPage: %s", key)
+	var p srvpb.FileDecorationsPage
+	return &p, s.DecorationPages.Lookup(ctx, []byte(key), &p)
 }
 func (s *SplitTable) crossReferences(ctx context.Context, ticket string) (*srvpb.PagedCrossReferences, error) {
 	tracePrintf(ctx, "Reading PagedCrossReferences: %s", ticket)
 	var cr srvpb.PagedCrossReferences
-	return s.rewriteCrossReferences(ctx, &cr, s.CrossReferences.Lookup(ctx, []byte(ticket), &cr))
+	return &cr, s.CrossReferences.Lookup(ctx, []byte(ticket), &cr)
 }
 func (s *SplitTable) crossReferencesPage(ctx context.Context, key string) (*srvpb.PagedCrossReferences_Page, error) {
 	tracePrintf(ctx, "Reading PagedCrossReferences.Page: %s", key)
 	var p srvpb.PagedCrossReferences_Page
-	return s.rewriteCrossReferencesPage(ctx, &p, s.CrossReferencePages.Lookup(ctx, []byte(key), &p))
+	return &p, s.CrossReferencePages.Lookup(ctx, []byte(key), &p)
 }
 func (s *SplitTable) documentation(ctx context.Context, ticket string) (*srvpb.Document, error) {
 	tracePrintf(ctx, "Reading Document: %s", ticket)
 	var d srvpb.Document
 	return &d, s.Documentation.Lookup(ctx, []byte(ticket), &d)
 }
+func (s *SplitTable) displayName(ctx context.Context, ticket string) (*srvpb.DisplayName, error) {
+	if s.DisplayNames == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading DisplayName: %s", ticket)
+	var n srvpb.DisplayName
+	return &n, s.DisplayNames.Lookup(ctx, []byte(ticket), &n)
+}
+func (s *SplitTable) fileDigest(ctx context.Context, digest string) (*srvpb.FileDigest, error) {
+	if s.FileDigests == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading FileDigest: %s", digest)
+	var d srvpb.FileDigest
+	return &d, s.FileDigests.Lookup(ctx, []byte(digest), &d)
+}
+func (s *SplitTable) fileReferences(ctx context.Context, ticket string) (*srvpb.FileReferences, error) {
+	if s.FileReferences == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading FileReferences: %s", ticket)
+	var r srvpb.FileReferences
+	return &r, s.FileReferences.Lookup(ctx, []byte(ticket), &r)
+}
+func (s *SplitTable) fileRelations(ctx context.Context, ticket string) (*srvpb.FileRelations, error) {
+	if s.FileRelations == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading FileRelations: %s", ticket)
+	var r srvpb.FileRelations
+	return &r, s.FileRelations.Lookup(ctx, []byte(ticket), &r)
+}
+func (s *SplitTable) callDegrees(ctx context.Context, ticket string) (*srvpb.CallDegrees, error) {
+	if s.CallDegrees == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading CallDegrees: %s", ticket)
+	var d srvpb.CallDegrees
+	return &d, s.CallDegrees.Lookup(ctx, []byte(ticket), &d)
+}
+func (s *SplitTable) containerMembers(ctx context.Context, container, kind string) (*srvpb.ContainerMembers, error) {
+	if s.ContainerMembers == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading ContainerMembers: %s %s", kind, container)
+	var m srvpb.ContainerMembers
+	return &m, s.ContainerMembers.Lookup(ctx, []byte(membersKey(container, kind)), &m)
+}
+func (s *SplitTable) containerRollup(ctx context.Context, ticket string) (*srvpb.ContainerRollup, error) {
+	if s.ContainerRollups == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading ContainerRollup: %s", ticket)
+	var r srvpb.ContainerRollup
+	return &r, s.ContainerRollups.Lookup(ctx, []byte(ticket), &r)
+}
+func (s *SplitTable) fileOutline(ctx context.Context, ticket string) (*srvpb.FileOutline, error) {
+	if s.FileOutlines == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading FileOutline: %s", ticket)
+	var o srvpb.FileOutline
+	return &o, s.FileOutlines.Lookup(ctx, []byte(ticket), &o)
+}
+func (s *SplitTable) ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error) {
+	if s.TicketAliases == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading TicketAlias: %s", ticket)
+	var a srvpb.TicketAlias
+	return &a, s.TicketAliases.Lookup(ctx, []byte(ticket), &a)
+}
+func (s *SplitTable) decorationsFilter(ctx context.Context) (fileFilter, error) {
+	if s.DecorationsFilter == nil {
+		return nil, table.ErrNoSuchKey
+	}
+	tracePrintf(ctx, "Reading DecorationsFilter")
+	var f srvpb.ExistenceFilter
+	if err := s.DecorationsFilter.Lookup(ctx, DecorationsFilterKey, &f); err != nil {
+		return nil, err
+	}
+	return existenceFilter(&f), nil
+}
 
 // Key prefixes for the combinedTable implementation.
 const (
 	crossRefTablePrefix      = "xrefs:"
 	crossRefPageTablePrefix  = "xrefPages:"
 	decorTablePrefix         = "decor:"
+	decorPageTablePrefix     = "decorPages:"
 	documentationTablePrefix = "docs:"
+	displayNameTablePrefix   = "names:"
+	fileDigestTablePrefix    = "digests:"
+	fileRefsTablePrefix      = "fileRefs:"
+	fileRelationsTablePrefix = "fileRelations:"
+	callDegreesTablePrefix   = "callDegrees:"
+	membersTablePrefix       = "members:"
+	rollupsTablePrefix       = "rollups:"
+	outlineTablePrefix       = "outline:"
+	ticketAliasTablePrefix   = "aliases:"
 )
 
 type combinedTable struct{ table.Proto }
@@ -184,6 +351,10 @@ func (c *combinedTable) fileDecorations(ctx context.Context, ticket string) (*sr
 	var fd srvpb.FileDecorations
 	return &fd, c.Lookup(ctx, DecorationsKey(ticket), &fd)
 }
+func (c *combinedTable) fileDecorationsPage(ctx context.Context, key string) (*srvpb.FileDecorationsPage, error) {
+	var p srvpb.FileDecorationsPage
+	return &p, c.Lookup(ctx, DecorationsPageKey(key), &p)
+}
 func (c *combinedTable) crossReferences(ctx context.Context, ticket string) (*srvpb.PagedCrossReferences, error) {
 	var cr srvpb.PagedCrossReferences
 	return &cr, c.Lookup(ctx, CrossReferencesKey(ticket), &cr)
@@ -196,6 +367,54 @@ func (c *combinedTable) documentation(ctx context.Context, ticket string) (*srvp
 	var d srvpb.Document
 	return &d, c.Lookup(ctx, DocumentationKey(ticket), &d)
 }
+func (c *combinedTable) displayName(ctx context.Context, ticket string) (*srvpb.DisplayName, error) {
+	var n srvpb.DisplayName
+	return &n, c.Lookup(ctx, DisplayNameKey(ticket), &n)
+}
+func (c *combinedTable) fileDigest(ctx context.Context, digest string) (*srvpb.FileDigest, error) {
+	var d srvpb.FileDigest
+	return &d, c.Lookup(ctx, FileDigestKey(digest), &d)
+}
+func (c *combinedTable) fileReferences(ctx context.Context, ticket string) (*srvpb.FileReferences, error) {
+	var r srvpb.FileReferences
+	return &r, c.Lookup(ctx, FileReferencesKey(ticket), &r)
+}
+func (c *combinedTable) fileRelations(ctx context.Context, ticket string) (*srvpb.FileRelations, error) {
+	var r srvpb.FileRelations
+	return &r, c.Lookup(ctx, FileRelationsKey(ticket), &r)
+}
+func (c *combinedTable) callDegrees(ctx context.Context, ticket string) (*srvpb.CallDegrees, error) {
+	var d srvpb.CallDegrees
+	return &d, c.Lookup(ctx, CallDegreesKey(ticket), &d)
+}
+func (c *combinedTable) containerMembers(ctx context.Context, container, kind string) (*srvpb.ContainerMembers, error) {
+	var m srvpb.ContainerMembers
+	return &m, c.Lookup(ctx, MembersKey(container, kind), &m)
+}
+func (c *combinedTable) containerRollup(ctx context.Context, ticket string) (*srvpb.ContainerRollup, error) {
+	var r srvpb.ContainerRollup
+	return &r, c.Lookup(ctx, RollupsKey(ticket), &r)
+}
+func (c *combinedTable) fileOutline(ctx context.Context, ticket string) (*srvpb.FileOutline, error) {
+	var o srvpb.FileOutline
+	return &o, c.Lookup(ctx, OutlineKey(ticket), &o)
+}
+func (c *combinedTable) ticketAlias(ctx context.Context, ticket string) (*srvpb.TicketAlias, error) {
+	var a srvpb.TicketAlias
+	return &a, c.Lookup(ctx, TicketAliasKey(ticket), &a)
+}
+func (c *combinedTable) decorationsFilter(ctx context.Context) (fileFilter, error) {
+	var f srvpb.ExistenceFilter
+	if err := c.Lookup(ctx, DecorationsFilterKey, &f); err != nil {
+		return nil, err
+	}
+	return existenceFilter(&f), nil
+}
+
+// existenceFilter returns the fileFilter of the given ExistenceFilter.
+func existenceFilter(f *srvpb.ExistenceFilter) fileFilter {
+	return bloom.FromBits(f.Bits, int(f.HashCount)).MayContain
+}
 
 // NewSplitTable returns a table based on the given serving tables for each API
 // component.
@@ -211,6 +430,17 @@ func DecorationsKey(ticket string) []byte {
 	return []byte(decorTablePrefix + ticket)
 }
 
+// DecorationsFilterKey is the key of the srvpb.ExistenceFilter of the file
+// tickets with decorations in both a CombinedTable and a
+// SplitTable.DecorationsFilter table.
+var DecorationsFilterKey = []byte("decorFilter")
+
+// DecorationsPageKey returns the decorations page CombinedTable key for the
+// given key.
+func DecorationsPageKey(key string) []byte {
+	return []byte(decorPageTablePrefix + key)
+}
+
 // CrossReferencesKey returns the cross-references CombinedTable key for the
 // given node ticket.
 func CrossReferencesKey(ticket string) []byte {
@@ -229,6 +459,65 @@ func DocumentationKey(ticket string) []byte {
 	return []byte(documentationTablePrefix + ticket)
 }
 
+// DisplayNameKey returns the display name CombinedTable key for the given
+// ticket.
+func DisplayNameKey(ticket string) []byte {
+	return []byte(displayNameTablePrefix + ticket)
+}
+
+// FileDigestKey returns the file digest CombinedTable key for the given
+// content digest.
+func FileDigestKey(digest string) []byte {
+	return []byte(fileDigestTablePrefix + digest)
+}
+
+// FileReferencesKey returns the file references CombinedTable key for the
+// given file ticket.
+func FileReferencesKey(ticket string) []byte {
+	return []byte(fileRefsTablePrefix + ticket)
+}
+
+// FileRelationsKey returns the file relations CombinedTable key for the given
+// file ticket.
+func FileRelationsKey(ticket string) []byte {
+	return []byte(fileRelationsTablePrefix + ticket)
+}
+
+// CallDegreesKey returns the call degrees CombinedTable key for the given
+// function ticket.
+func CallDegreesKey(ticket string) []byte {
+	return []byte(callDegreesTablePrefix + ticket)
+}
+
+// MembersKey returns the container members CombinedTable key for the given
+// container ticket and member node kind.
+func MembersKey(container, kind string) []byte {
+	return []byte(membersTablePrefix + membersKey(container, kind))
+}
+
+// membersKey returns the SplitTable key for the given container ticket and
+// member node kind.  Node kinds do not contain colons, so the key is
+// unambiguous.
+func membersKey(container, kind string) string { return kind + ":" + container }
+
+// RollupsKey returns the container rollups CombinedTable key for the given
+// container ticket.
+func RollupsKey(ticket string) []byte {
+	return []byte(rollupsTablePrefix + ticket)
+}
+
+// OutlineKey returns the file outline CombinedTable key for the given file
+// ticket.
+func OutlineKey(ticket string) []byte {
+	return []byte(outlineTablePrefix + ticket)
+}
+
+// TicketAliasKey returns the ticket alias CombinedTable key for the given
+// aliased ticket.
+func TicketAliasKey(ticket string) []byte {
+	return []byte(ticketAliasTablePrefix + ticket)
+}
+
 // Table implements the xrefs Service interface using static lookup tables.
 type Table struct {
 	staticLookupTables
@@ -239,6 +528,122 @@ type Table struct {
 	// ResolvePath is used to resolve CorpusPaths for filtering.  If unset,
 	// DefaultResolvePath will be used.
 	ResolvePath PathResolver
+
+	// RequestLog, if set, is sent a RequestLogEntry for each sampled
+	// Decorations, CrossReferences, and Documentation request.
+	RequestLog RequestLogSink
+
+	// RequestLogSampleRate is the fraction of requests sent to RequestLog.  If
+	// <= 0 or >= 1, every request is sent.
+	RequestLogSampleRate float64
+
+	// Health, if set, records every Decorations, CrossReferences, and
+	// Documentation request regardless of RequestLogSampleRate.
+	Health *HealthMonitor
+
+	// Authorizer, if set, is consulted before serving Decorations,
+	// CrossReferences, and Documentation requests, which fail with
+	// xrefs.ErrPermissionDenied if any requested ticket is denied.  Replies are
+	// stripped of unauthorized nodes and of anchors in unauthorized files.
+	Authorizer Authorizer
+
+	// DecorationOverrides, if set, holds in-memory FileDecorations that are
+	// merged over the static FileDecorations served by Decorations.
+	DecorationOverrides *DecorationOverrides
+
+	// Readahead, if set, caches the cross-reference pages prefetched after
+	// each CrossReferences reply with a next page token, i.e. those needed by
+	// the following page.
+	Readahead *readahead.Cache[*srvpb.PagedCrossReferences_Page]
+
+	// ContentResolver, if set, supplies the text of files whose text is not
+	// stored in their FileDecorations so that Decorations can still serve
+	// their source text and anchor spans.
+	ContentResolver FileContentResolver
+
+	// ReadQuota bounds the table reads made to serve each Decorations,
+	// CrossReferences, Documentation, and BatchDocumentation request, which
+	// fail with a *QuotaExceededError if it is exceeded.
+	ReadQuota ReadQuota
+
+	// ReadLimiter, if set, is waited on before every table read, e.g. to
+	// enforce a global read rate shared across requests.
+	ReadLimiter ReadLimiter
+
+	// Paranoid, if true, validates each FileDecorations and
+	// PagedCrossReferences, and each of their pages, as it is read, failing
+	// requests that read a corrupt entry with a *tablecheck.CorruptionError.
+	Paranoid bool
+
+	decorFilter decorationsFilterCache
+
+	// EdgeFallback, if set, serves the edges of tickets missing from the
+	// cross-references table so that CrossReferences requests with
+	// edge_fallback set can reconstruct their anchors.
+	EdgeFallback graph.Service
+
+	// NegativeCache, if set, remembers the ticket lookups found to have no
+	// entry so that repeated requests for missing tickets are served without
+	// reading the table until the lookups expire.
+	NegativeCache *NegativeCache
+}
+
+// fileDecorationsPage returns the FileDecorationsPage with the given key of
+// the file with the given ticket, checking that the key belongs to the file.
+// The page is passed through checkEntry.
+func (t *Table) fileDecorationsPage(ctx context.Context, ticket, key string) (*srvpb.FileDecorationsPage, error) {
+	if err := pagekey.Validate(key, pagekey.DecorationsPage, ticket, ""); err != nil {
+		return nil, fmt.Errorf("internal error: %v", err)
+	} else if err := t.chargeRead(ctx, true); err != nil {
+		return nil, err
+	}
+	defer startPhase(ctx, PhaseRead)()
+	p, err := t.staticLookupTables.fileDecorationsPage(ctx, key)
+	if err == nil {
+		err = t.checkEntry(ctx, ticket, key, p)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// crossReferencesPage returns the cross-references page with the given index
+// in the PagedCrossReferences of the given ticket, checking that its key
+// belongs to the set and to a group of the index's kind.  The page is passed
+// through checkEntry.
+func (t *Table) crossReferencesPage(ctx context.Context, ticket string, idx *srvpb.PagedCrossReferences_PageIndex) (*srvpb.PagedCrossReferences_Page, error) {
+	key := idx.GetPageKey()
+	if err := pagekey.Validate(key, pagekey.CrossReferencesPage, ticket, idx.GetKind()); err != nil {
+		return nil, fmt.Errorf("internal error: %v", err)
+	}
+	var p *srvpb.PagedCrossReferences_Page
+	if t.Readahead != nil {
+		p, _ = t.Readahead.Get(key)
+	}
+	if p != nil {
+		tracePrintf(ctx, "Prefetched PagedCrossReferences_Page: %s", key)
+	} else if err := t.chargeRead(ctx, true); err != nil {
+		return nil, err
+	} else {
+		stopRead := startPhase(ctx, PhaseRead)
+		p, err = t.staticLookupTables.crossReferencesPage(ctx, key)
+		stopRead()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := t.checkEntry(ctx, ticket, key, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// A pageRef refers to a cross-references page by the ticket of its
+// PagedCrossReferences and its index within the set.
+type pageRef struct {
+	ticket string
+	idx    *srvpb.PagedCrossReferences_PageIndex
 }
 
 // A PathResolver resolves a CorpusPath into a single filepath.
@@ -293,43 +698,91 @@ func (c *nodeConverter) ToInfo(n *srvpb.Node) *cpb.NodeInfo {
 			ni.Facts[f.Name] = f.Value
 		}
 	}
-	if len(ni.Facts) == 0 {
+	if n.Deprecated {
+		ni.Deprecated = true
+		ni.DeprecationMessage = n.DeprecationMessage
+	} else if len(ni.Facts) == 0 {
 		return nil
 	}
 	return ni
 }
 
+// newRelatedNode returns the RelatedNode for rn in a group of the given kind.
+func newRelatedNode(kind string, rn *srvpb.PagedCrossReferences_RelatedNode) *xpb.CrossReferencesReply_RelatedNode {
+	return &xpb.CrossReferencesReply_RelatedNode{
+		RelationKind:       kind,
+		Ticket:             rn.Node.GetTicket(),
+		Ordinal:            rn.Ordinal,
+		Deprecated:         rn.Node.GetDeprecated(),
+		DeprecationMessage: rn.Node.GetDeprecationMessage(),
+	}
+}
+
 func corpusPathTicket(cp *cpb.CorpusPath) string { return kytheuri.FromCorpusPath(cp).String() }
 
 // Decorations implements part of the xrefs Service interface.
 func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
-	if req.GetLocation() == nil || req.GetLocation().Ticket == "" {
-		return nil, status.Error(codes.InvalidArgument, "missing location")
-	}
+	ctx = log.EnsureRequestID(ctx)
+	start := time.Now()
+	ctx, budget := t.withReadBudget(ctx)
+	reply, err := t.authorizedDecorations(ctx, req)
+	if err == nil && req.GetCanonicalNodes() {
+		canonicalizeDecorationsNodes(reply)
+	}
+	if err == nil && req.GetGroupByBuildConfig() {
+		// The mask was validated when serving the request.
+		mask, _ := newFieldMask(req.GetReferenceMask(), &xpb.DecorationsReply_Reference{})
+		groupDecorationsByBuildConfig(reply, mask)
+	}
+	reply, err = quotaReply(budget, reply, err)
+	t.logRequest(ctx, "Decorations", []string{req.GetLocation().GetTicket()}, start, reply, err)
+	return reply, err
+}
 
-	ticket, err := kytheuri.Fix(req.GetLocation().Ticket)
+func (t *Table) serveDecorations(ctx context.Context, req *xpb.DecorationsRequest) (*xpb.DecorationsReply, error) {
+	ctx = log.EnsureRequestID(ctx)
+	var v validate.Validator
+	var ticket string
+	if req.GetLocation() == nil {
+		v.Addf("location", "", "missing location")
+	} else {
+		ticket = v.Ticket("location.ticket", req.GetLocation().Ticket)
+	}
+	refMask, err := newFieldMask(req.GetReferenceMask(), &xpb.DecorationsReply_Reference{})
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid ticket %q: %v", req.GetLocation().Ticket, err)
+		v.Addf("reference_mask", strings.Join(req.GetReferenceMask().GetPaths(), ","), "%v", err)
+	}
+	var targets stringset.Set
+	if len(req.GetTargetTicket()) > 0 {
+		targets = stringset.New(v.Tickets("target_ticket", req.GetTargetTicket())...)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	// convMask is refMask including the build configs needed for grouping.
+	convMask := refMask
+	if req.GetGroupByBuildConfig() {
+		convMask = refMask.with("build_config")
 	}
 
 	var multiPatcher MultiFilePatcher
 	if t.MakePatcher != nil && req.GetWorkspace() != nil && req.GetPatchAgainstWorkspace() {
 		multiPatcher, err = t.MakePatcher(ctx, req.GetWorkspace())
 		if isNonContextError(err) {
-			log.Printf("ERROR: creating patcher: %v", err)
+			log.Errorf(ctx, "creating patcher: %v", err)
 		}
 
 		if multiPatcher != nil {
 			defer func() {
 				if err := multiPatcher.Close(); isNonContextError(err) {
 					// No need to fail the request; just log the error.
-					log.Printf("ERROR: closing patcher: %v", err)
+					log.Errorf(ctx, "closing patcher: %v", err)
 				}
 			}()
 		}
 	}
 
-	decor, err := t.fileDecorations(ctx, ticket)
+	decor, err := t.decorationsWithOverride(ctx, ticket)
 	if err == table.ErrNoSuchKey {
 		return nil, xrefs.ErrDecorationsNotFound
 	} else if err != nil {
@@ -338,7 +791,7 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 
 	if decor.File == nil {
 		if len(decor.Diagnostic) == 0 {
-			log.Printf("Error: FileDecorations.file is missing without related diagnostics: %q", req.Location.Ticket)
+			log.Errorf(ctx, "FileDecorations.file is missing without related diagnostics: %q", req.Location.Ticket)
 			return nil, xrefs.ErrDecorationsNotFound
 		}
 
@@ -354,8 +807,21 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 		}
 		return nil, xrefs.ErrDecorationsNotFound
 	}
+	if err := t.resolveFileContent(ctx, ticket, decor); err != nil {
+		return nil, err
+	}
+
+	stored, encoding := decor.File.Text, decor.File.Encoding
+	var transcoding *enctext.OffsetMap
+	if req.TranscodeToUtf8 && !enctext.IsUTF8(encoding) {
+		stored, transcoding, err = enctext.ToUTF8WithOffsets(encoding, stored)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "cannot transcode %s text of %q to UTF-8: %v", encoding, ticket, err)
+		}
+		encoding = "UTF-8"
+	}
 
-	text := decor.File.Text
+	text := stored
 	if len(req.DirtyBuffer) > 0 {
 		text = req.DirtyBuffer
 	}
@@ -389,7 +855,7 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 	}
 
 	if req.SourceText {
-		reply.Encoding = decor.File.Encoding
+		reply.Encoding = encoding
 		if loc.Kind == xpb.Location_FILE {
 			reply.SourceText = text
 		} else {
@@ -397,12 +863,16 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 		}
 	}
 
+	if req.LineTable {
+		reply.LineStart, reply.LineLength = norm.LineTable()
+	}
+
 	var patcher *span.Patcher
 	if len(req.DirtyBuffer) > 0 {
 		if multiPatcher != nil {
 			return nil, status.Errorf(codes.Unimplemented, "cannot patch decorations against Workspace with a dirty_buffer")
 		}
-		patcher, err = span.NewPatcher(decor.File.Text, req.DirtyBuffer)
+		patcher, err = span.NewPatcher(stored, req.DirtyBuffer)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "error patching decorations for %s: %v", req.Location.Ticket, err)
 		}
@@ -421,6 +891,21 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 	}
 
 	if req.References {
+		if len(decor.DecorationPage) > 0 {
+			// Only read the pages that may hold decorations within the requested
+			// span.  Patched or transcoded offsets may differ from those stored,
+			// so all pages are read when patching or transcoding.
+			var include func(*srvpb.FileDecorations_DecorationPage) bool
+			if patcher == nil && multiPatcher == nil && transcoding == nil {
+				include = func(p *srvpb.FileDecorations_DecorationPage) bool {
+					return p.StartOffset <= endBoundary && p.EndOffset >= startBoundary
+				}
+			}
+			if err := t.readDecorationPages(ctx, decor, include); err != nil {
+				return nil, err
+			}
+		}
+
 		patterns := xrefs.ConvertFilters(req.Filter)
 		buildConfigs := stringset.New(req.BuildConfig...)
 
@@ -430,13 +915,12 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 		reply.Reference = make([]*xpb.DecorationsReply_Reference, 0, len(decor.Decoration))
 		reply.Nodes = make(map[string]*cpb.NodeInfo, len(decor.Target))
 
-		// Reference.TargetTicket -> NodeInfo (superset of reply.Nodes)
+		// Reference.TargetTicket -> NodeInfo (superset of reply.Nodes).  Without
+		// fact filters, only deprecated targets have a NodeInfo.
 		nodes := make(map[string]*cpb.NodeInfo, len(decor.Target))
-		if len(patterns) > 0 {
-			for _, n := range decor.Target {
-				if info := nc.ToInfo(n); info != nil {
-					nodes[n.Ticket] = info
-				}
+		for _, n := range decor.Target {
+			if info := nc.ToInfo(n); info != nil {
+				nodes[n.Ticket] = info
 			}
 		}
 		tracePrintf(ctx, "Potential target nodes: %d", len(nodes))
@@ -454,7 +938,7 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 				if fileInfo != nil {
 					if err := multiPatcher.AddFile(ctx, fileInfo); isNonContextError(err) {
 						// Attempt to continue with the request, just log the error.
-						log.Printf("ERROR: adding file: %v", err)
+						log.Errorf(ctx, "adding file: %v", err)
 					}
 				}
 			}
@@ -465,14 +949,38 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 		tracePrintf(ctx, "Potential target defs: %d", len(defs))
 
 		bindings := stringset.New()
+		var overrides, overriddenBy map[string][]string
+		if req.OverrideMarkers {
+			overrides, overriddenBy = overrideMarkers(decor)
+		}
+		defSelector := t.newDefinitionSelector(req.DefinitionPreference, int(req.MaxDefinitionJumps), decor.Target, ac)
+		var fileCorpus string
+		if uri, err := kytheuri.Parse(ticket); err == nil {
+			fileCorpus = uri.Corpus
+		}
 
+		// Targets whose definitions are classified as declarations.
+		var incompleteTargets stringset.Set
+		if refMask.names("classification") {
+			for _, n := range decor.Target {
+				if isIncomplete(n) {
+					incompleteTargets.Add(n.Ticket)
+				}
+			}
+		}
+
+		decorations := make([]*srvpb.FileDecorations_Decoration, 0, len(decor.Decoration))
 		for _, d := range decor.Decoration {
 			// Filter decorations by requested build configs.
 			if len(buildConfigs) != 0 && !buildConfigs.Contains(d.Anchor.BuildConfiguration) {
 				continue
 			}
+			// Filter decorations by requested targets.
+			if targets != nil && !targets.Contains(d.Target) {
+				continue
+			}
 
-			start, end, exists := patcher.Patch(d.Anchor.StartOffset, d.Anchor.EndOffset)
+			start, end, exists := patcher.Patch(transcoding.ToUTF8(d.Anchor.StartOffset), transcoding.ToUTF8(d.Anchor.EndOffset))
 			// Filter non-existent anchor.  Anchors can no longer exist if we were
 			// given a dirty buffer and the anchor was inside a changed region.
 			if !exists || !span.InBounds(spanKind, start, end, startBoundary, endBoundary) {
@@ -481,11 +989,57 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 
 			d.Anchor.StartOffset = start
 			d.Anchor.EndOffset = end
+			decorations = append(decorations, d)
+		}
 
-			r := decorationToReference(norm, d)
-			if req.TargetDefinitions {
-				if def, ok := defs[d.TargetDefinition]; ok {
-					reply.DefinitionLocations[d.TargetDefinition] = def
+		if req.TargetDefinitions && refMask.includes("target_definition") {
+			// Read the definitions of every returned target at once, rather than
+			// as each is selected.
+			stored := make(map[string]*xpb.Anchor)
+			for _, d := range decorations {
+				if _, ok := stored[d.Target]; !ok {
+					stored[d.Target] = defs[d.TargetDefinition]
+				}
+			}
+			all := req.DefinitionSelection == xpb.DecorationsRequest_ALL_DEFINITIONS
+			if err := defSelector.Prefetch(ctx, stored, all); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, d := range decorations {
+			r := decorationToReference(norm, d, convMask)
+			if req.TargetDefinitions && refMask.includes("target_definition") {
+				def, kind, err := defSelector.Select(ctx, r.TargetTicket, defs[d.TargetDefinition])
+				if err != nil {
+					return nil, err
+				}
+				if req.DefinitionSelection == xpb.DecorationsRequest_ALL_DEFINITIONS {
+					candidates, err := defSelector.Candidates(ctx, r.TargetTicket, fileCorpus, defs[d.TargetDefinition])
+					if err != nil {
+						return nil, err
+					}
+					for _, c := range candidates {
+						r.TargetDefinitionCandidate = append(r.TargetDefinitionCandidate, &xpb.DecorationsReply_DefinitionCandidate{
+							Ticket: c.anchor.Ticket,
+							Kind:   c.kind,
+						})
+						reply.DefinitionLocations[c.anchor.Ticket] = c.anchor
+					}
+					if len(candidates) > 0 {
+						def, kind = candidates[0].anchor, candidates[0].kind
+					}
+				}
+				if def != nil {
+					r.TargetDefinition = def.Ticket
+					r.TargetDefinitionKind = kind
+					reply.DefinitionLocations[def.Ticket] = def
+					if jumps := defSelector.Jumps(r.TargetTicket); jumps > 0 {
+						if reply.DefinitionJumps == nil {
+							reply.DefinitionJumps = make(map[string]int32)
+						}
+						reply.DefinitionJumps[r.TargetTicket] = int32(jumps)
+					}
 				}
 			} else {
 				r.TargetDefinition = ""
@@ -495,10 +1049,35 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 				r.SemanticScope = ""
 			}
 
+			if req.OverrideMarkers {
+				if refMask.includes("overrides") {
+					r.Overrides = overrides[r.TargetTicket]
+				}
+				if refMask.includes("overridden_by") {
+					r.OverriddenBy = overriddenBy[r.TargetTicket]
+				}
+			}
+			if req.ReferenceCounts && refMask.includes("reference_count") {
+				r.ReferenceCount = decor.TargetReferenceCount[r.TargetTicket]
+			}
+			if refMask.names("classification") {
+				r.Classification = xrefs.ClassifyAnchor(d.Kind, incompleteTargets.Contains(r.TargetTicket))
+			}
+			if n := nodes[r.TargetTicket]; n.GetDeprecated() {
+				if refMask.includes("target_deprecated") {
+					r.TargetDeprecated = true
+				}
+				if refMask.includes("target_deprecation_message") {
+					r.TargetDeprecationMessage = n.DeprecationMessage
+				}
+			}
+
 			// Populate any target revision, if known
-			r.TargetRevision = fileInfos[r.TargetTicket].GetRevision()
+			if refMask.includes("target_revision") {
+				r.TargetRevision = fileInfos[r.TargetTicket].GetRevision()
+			}
 
-			if req.ExtendsOverrides && (r.Kind == edges.Defines || r.Kind == edges.DefinesBinding) {
+			if req.ExtendsOverrides && (d.Kind == edges.Defines || d.Kind == edges.DefinesBinding) {
 				bindings.Add(r.TargetTicket)
 			}
 
@@ -554,7 +1133,8 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 			if diag.Span == nil {
 				reply.Diagnostic = append(reply.Diagnostic, diag)
 			} else {
-				start, end, exists := patcher.Patch(span.ByteOffsets(diag.Span))
+				start, end := span.ByteOffsets(diag.Span)
+				start, end, exists := patcher.Patch(transcoding.ToUTF8(start), transcoding.ToUTF8(end))
 				// Filter non-existent (or out-of-bounds) diagnostic.  Diagnostics can
 				// no longer exist if we were given a dirty buffer and the diagnostic
 				// was inside a changed region.
@@ -575,15 +1155,25 @@ func (t *Table) Decorations(ctx context.Context, req *xpb.DecorationsRequest) (*
 		}
 	}
 
+	if req.DisplayNames {
+		if err := t.addDecorationsDisplayNames(ctx, reply); err != nil {
+			return nil, err
+		}
+	}
+
 	if multiPatcher != nil {
 		defs, err := patchDefLocations(ctx, multiPatcher, reply.GetDefinitionLocations())
 		if err != nil {
-			log.Printf("ERROR: patching definition locations: %v", err)
+			log.Errorf(ctx, "patching definition locations: %v", err)
 		} else {
 			reply.DefinitionLocations = defs
 		}
 	}
 
+	if req.CanonicalKinds {
+		canonicalizeDecorationKinds(reply)
+	}
+
 	return reply, nil
 }
 
@@ -601,6 +1191,7 @@ func patchDefLocations(ctx context.Context, patcher MultiFilePatcher, defLocs ma
 	}
 	res := make(map[string]*xpb.Anchor, len(defs))
 	for _, def := range defs {
+		refreshSnippetHighlight(def)
 		res[def.GetTicket()] = def
 	}
 	tracePrintf(ctx, "Patched DefinitionLocations: %d", len(defs))
@@ -615,16 +1206,69 @@ func makeFileInfoMap(infos []*srvpb.FileInfo) map[string]*srvpb.FileInfo {
 	return fileInfos
 }
 
-func decorationToReference(norm *span.Normalizer, d *srvpb.FileDecorations_Decoration) *xpb.DecorationsReply_Reference {
-	span := norm.SpanOffsets(d.Anchor.StartOffset, d.Anchor.EndOffset)
-	return &xpb.DecorationsReply_Reference{
-		TargetTicket:     d.Target,
-		Kind:             d.Kind,
-		Span:             span,
-		TargetDefinition: d.TargetDefinition,
-		BuildConfig:      d.Anchor.BuildConfiguration,
-		SemanticScope:    d.SemanticScope,
+// readDecorationPages appends to decor the decorations of each of its pages
+// for which include returns true (or of every page, if include is nil).
+func (t *Table) readDecorationPages(ctx context.Context, decor *srvpb.FileDecorations, include func(*srvpb.FileDecorations_DecorationPage) bool) error {
+	for _, idx := range decor.DecorationPage {
+		if include != nil && !include(idx) {
+			continue
+		}
+		p, err := t.fileDecorationsPage(ctx, decor.GetFile().GetTicket(), idx.PageKey)
+		if err == table.ErrNoSuchKey {
+			return fmt.Errorf("internal error: missing decorations page: %q", idx.PageKey)
+		} else if err != nil {
+			return canonicalError(err, "file decorations page", idx.PageKey)
+		}
+		decor.Decoration = append(decor.Decoration, p.Decoration...)
+	}
+	tracePrintf(ctx, "Decorations after reading pages: %d", len(decor.Decoration))
+	return nil
+}
+
+// overrideMarkers returns the sorted tickets of the nodes overridden by and
+// overriding each of the given file's decoration targets.
+func overrideMarkers(decor *srvpb.FileDecorations) (overrides, overriddenBy map[string][]string) {
+	collect := func(os []*srvpb.FileDecorations_Override, key, val func(*srvpb.FileDecorations_Override) string) map[string][]string {
+		sets := make(map[string]stringset.Set)
+		for _, o := range os {
+			set := sets[key(o)]
+			set.Add(val(o))
+			sets[key(o)] = set
+		}
+		m := make(map[string][]string, len(sets))
+		for k, set := range sets {
+			m[k] = set.Elements()
+		}
+		return m
+	}
+	overriding := func(o *srvpb.FileDecorations_Override) string { return o.Overriding }
+	overridden := func(o *srvpb.FileDecorations_Override) string { return o.Overridden }
+	return collect(decor.TargetOverride, overriding, overridden), collect(decor.TargetOverriddenBy, overridden, overriding)
+}
+
+// decorationToReference converts d to a Reference populated with only the
+// fields included in mask.
+func decorationToReference(norm *span.Normalizer, d *srvpb.FileDecorations_Decoration, mask fieldMask) *xpb.DecorationsReply_Reference {
+	r := &xpb.DecorationsReply_Reference{TargetTicket: d.Target}
+	if mask.includes("kind") {
+		r.Kind = d.Kind
+	}
+	if mask.includes("span") {
+		r.Span = norm.SpanOffsets(d.Anchor.StartOffset, d.Anchor.EndOffset)
 	}
+	if mask.includes("target_definition") {
+		r.TargetDefinition = d.TargetDefinition
+	}
+	if mask.includes("build_config") {
+		r.BuildConfig = d.Anchor.BuildConfiguration
+	}
+	if mask.includes("semantic_scope") {
+		r.SemanticScope = d.SemanticScope
+	}
+	if mask.includes("fingerprint") {
+		r.Fingerprint = d.Anchor.Fingerprint
+	}
+	return r
 }
 
 type xrefCategory int
@@ -644,40 +1288,135 @@ func (c xrefCategory) AddCount(reply *xpb.CrossReferencesReply, idx *srvpb.Paged
 	case xrefCategoryDef:
 		if pageSet.Contains(idx) {
 			reply.Total.Definitions += int64(idx.Count)
+			reply.Total.UncompressedPageBytes += idx.UncompressedSize
 		} else {
 			reply.Filtered.Definitions += int64(idx.Count)
+			reply.Filtered.UncompressedPageBytes += idx.UncompressedSize
 		}
 	case xrefCategoryDecl:
 		if pageSet.Contains(idx) {
 			reply.Total.Declarations += int64(idx.Count)
+			reply.Total.UncompressedPageBytes += idx.UncompressedSize
 		} else {
 			reply.Filtered.Declarations += int64(idx.Count)
+			reply.Filtered.UncompressedPageBytes += idx.UncompressedSize
 		}
 	case xrefCategoryRef:
 		if pageSet.Contains(idx) {
 			reply.Total.References += int64(idx.Count)
+			reply.Total.UncompressedPageBytes += idx.UncompressedSize
 		} else {
 			reply.Filtered.References += int64(idx.Count)
+			reply.Filtered.UncompressedPageBytes += idx.UncompressedSize
 		}
 	case xrefCategoryRelated:
 		if pageSet.Contains(idx) {
 			reply.Total.RelatedNodesByRelation[idx.Kind] += int64(idx.Count)
+			reply.Total.UncompressedPageBytes += idx.UncompressedSize
 		} else {
 			reply.Filtered.RelatedNodesByRelation[idx.Kind] += int64(idx.Count)
+			reply.Filtered.UncompressedPageBytes += idx.UncompressedSize
 		}
 	case xrefCategoryCall:
 		if pageSet.Contains(idx) {
 			reply.Total.Callers += int64(idx.Count)
+			reply.Total.UncompressedPageBytes += idx.UncompressedSize
 		} else {
 			reply.Filtered.Callers += int64(idx.Count)
+			reply.Filtered.UncompressedPageBytes += idx.UncompressedSize
 		}
 	}
 }
 
+// AddGroupCount adds to reply the counts of a group of the category, given the
+// numbers of its cross-references kept and removed by the request's filters.
+func (c xrefCategory) AddGroupCount(reply *xpb.CrossReferencesReply, kept, filtered int) {
+	switch c {
+	case xrefCategoryDef:
+		reply.Total.Definitions += int64(kept)
+		reply.Filtered.Definitions += int64(filtered)
+	case xrefCategoryDecl:
+		reply.Total.Declarations += int64(kept)
+		reply.Filtered.Declarations += int64(filtered)
+	case xrefCategoryRef:
+		reply.Total.References += int64(kept)
+		reply.Filtered.References += int64(filtered)
+	case xrefCategoryCall:
+		reply.Total.Callers += int64(kept)
+		reply.Filtered.Callers += int64(filtered)
+	}
+}
+
+// indirectionKinds returns the edge kinds of the related nodes of cr that are
+// read as merge nodes, given the kind of cr's source node.
+func indirectionKinds(cr *srvpb.PagedCrossReferences) stringset.Set {
+	return experimentalCrossReferenceIndirectionKinds[nodeKind(cr.SourceNode)].
+		Union(experimentalCrossReferenceIndirectionKinds["*"])
+}
+
 // CrossReferences implements part of the xrefs.Service interface.
 func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
-	tickets, err := xrefs.FixTickets(req.Ticket)
+	ctx = log.EnsureRequestID(ctx)
+	start := time.Now()
+	ctx, budget := t.withReadBudget(ctx)
+	var timings *phaseTimings
+	if req.GetPhaseTimings() || t.RequestLog != nil {
+		ctx, timings = withPhaseTimings(ctx)
+	}
+	reply, err := t.authorizedCrossReferences(ctx, req)
+	if err == nil && req.GetCanonicalNodes() {
+		canonicalizeCrossReferencesNodes(reply)
+	}
+	if err == nil && req.GetGroupByBuildConfig() {
+		// The mask was validated when serving the request.
+		mask, _ := newFieldMask(req.GetAnchorMask(), &xpb.Anchor{})
+		groupCrossReferencesByBuildConfig(reply, mask)
+	}
+	if err == nil && req.GetPhaseTimings() {
+		reply.DebugInfo = timings.DebugInfo()
+	}
+	reply, err = quotaReply(budget, reply, err)
+	t.logRequest(ctx, "CrossReferences", req.GetTicket(), start, reply, err)
+	return reply, err
+}
+
+func (t *Table) serveCrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	ctx = log.EnsureRequestID(ctx)
+	var v validate.Validator
+	var (
+		tickets        []string
+		invalidTickets []*validate.Violation
+	)
+	if req.AcceptPartialTickets {
+		tickets, invalidTickets = v.ValidTickets("ticket", req.Ticket)
+	} else {
+		tickets = v.Tickets("ticket", req.Ticket)
+	}
+	v.PageSize("page_size", req.PageSize)
+	v.PageSize("related_node_page_size", req.RelatedNodePageSize)
+	pageToken, err := parseCrossReferencesPageToken(req.PageToken)
+	v.PageToken("page_token", req.PageToken, err)
+	if req.RelatedNodeExpansionDepth < 0 {
+		v.Addf("related_node_expansion_depth", fmt.Sprint(req.RelatedNodeExpansionDepth), "must be non-negative")
+	}
+	filter, err := compileCorpusPathFilters(req.GetCorpusPathFilters(), t.ResolvePath)
 	if err != nil {
+		v.Addf("corpus_path_filters", strings.ReplaceAll(req.GetCorpusPathFilters().String(), "\n", " "), "%v", err)
+	}
+	anchorMask, err := newFieldMask(req.GetAnchorMask(), &xpb.Anchor{})
+	if err != nil {
+		v.Addf("anchor_mask", strings.Join(req.GetAnchorMask().GetPaths(), ","), "%v", err)
+	}
+	if req.GroupByBuildConfig && req.GroupReferencesByKind {
+		v.Addf("group_by_build_config", "true", "may not be combined with group_references_by_kind")
+	}
+	textFilter, err := t.newAnchorTextFilter(ctx, req.GetAnchorTextPattern())
+	if err != nil {
+		v.Addf("anchor_text_pattern", req.GetAnchorTextPattern(), "%v", err)
+	}
+	generatedFilter := newGeneratedFilter(req.GetExcludeGenerated())
+	commitFilter := newCommitTimeFilter(&v, req.GetCommittedAfter(), req.GetCommittedBefore())
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
 
@@ -690,69 +1429,73 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 		}
 	}
 
-	filter, err := compileCorpusPathFilters(req.GetCorpusPathFilters(), t.ResolvePath)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid corpus_path_filters %s: %v", strings.ReplaceAll(req.GetCorpusPathFilters().String(), "\n", " "), err)
-	}
-
 	pageReadGroupCtx, stopReadingPages := context.WithCancel(ctx)
 	defer stopReadingPages()
 	pageReadGroup, pageReadGroupCtx := errgroup.WithContext(pageReadGroupCtx)
 	pageReadGroup.SetLimit(int(*pageReadAhead) + 1)
 	single := new(syncCache[*srvpb.PagedCrossReferences_Page])
 
-	getCachedPage := func(ctx context.Context, pageKey string) (*srvpb.PagedCrossReferences_Page, error) {
-		return single.Get(pageKey, func() (*srvpb.PagedCrossReferences_Page, error) {
-			return t.crossReferencesPage(ctx, pageKey)
+	getCachedPage := func(ctx context.Context, ticket string, idx *srvpb.PagedCrossReferences_PageIndex) (*srvpb.PagedCrossReferences_Page, error) {
+		return single.Get(idx.PageKey, func() (*srvpb.PagedCrossReferences_Page, error) {
+			return t.crossReferencesPage(ctx, ticket, idx)
 		})
 	}
-	getFilteredPage := func(ctx context.Context, pageKey string) (*srvpb.PagedCrossReferences_Page, int, error) {
-		p, err := getCachedPage(ctx, pageKey)
+	// filterGroup applies the request's filters to grp, returning the number of
+	// cross-references removed.
+	filterGroup := func(grp *srvpb.PagedCrossReferences_Group) int {
+		defer startPhase(ctx, PhaseFilter)()
+		return filter.FilterGroup(grp) + textFilter.FilterGroup(grp) + generatedFilter.FilterGroup(grp) + commitFilter.FilterGroup(grp)
+	}
+	getFilteredPage := func(ctx context.Context, ticket string, idx *srvpb.PagedCrossReferences_PageIndex) (*srvpb.PagedCrossReferences_Page, int, error) {
+		p, err := getCachedPage(ctx, ticket, idx)
 		if err != nil {
 			return nil, 0, err
 		}
 		// Clear page from cache; it should only be used once.
-		single.Delete(pageKey)
-		return p, filter.FilterGroup(p.GetGroup()), nil
+		single.Delete(idx.PageKey)
+		return p, filterGroup(p.GetGroup()), nil
+	}
+	// skipBadPage reports whether the page read error should be ignored
+	// because the request is lenient about missing pages or the read quota is
+	// exceeded.  If so, the page's edge kind is marked as truncated in crs.
+	skipBadPage := func(crs *xpb.CrossReferencesReply_CrossReferenceSet, idx *srvpb.PagedCrossReferences_PageIndex, err error) bool {
+		if !isQuotaExceeded(err) && (!req.SkipMissingPages || !isNonContextError(err)) {
+			return false
+		}
+		log.Warningf(ctx, "skipping cross-references page %q for %q: %v", idx.PageKey, crs.Ticket, err)
+		for _, k := range crs.TruncatedKind {
+			if k == idx.Kind {
+				return true
+			}
+		}
+		crs.TruncatedKind = append(crs.TruncatedKind, idx.Kind)
+		return true
 	}
 
 	stats := refStats{
 		max: int(req.PageSize),
 
 		refOptions: refOptions{
-			anchorText: req.AnchorText,
+			anchorText:   req.AnchorText,
+			anchorMask:   anchorMask,
+			keepKinds:    req.GroupReferencesByKind,
+			resolvePaths: req.ResolveAnchorPaths,
+			order:        newAnchorOrder(),
 		},
 	}
-	if stats.max < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid page_size: %d", req.PageSize)
+	if req.GroupByBuildConfig {
+		// Build configs are needed for grouping; they are cleared afterwards if
+		// excluded by anchorMask.
+		stats.anchorMask = anchorMask.with("build_config")
+	}
+	if req.CountOnly {
+		stats.max = 0
 	} else if stats.max == 0 {
 		stats.max = defaultPageSize
 	} else if stats.max > maxPageSize {
 		stats.max = maxPageSize
 	}
 
-	var pageToken ipb.PageToken
-	if req.PageToken != "" {
-		rec, err := base64.StdEncoding.DecodeString(req.PageToken)
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", req.PageToken)
-		}
-		rec, err = snappy.Decode(nil, rec)
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", req.PageToken)
-		}
-		if err := proto.Unmarshal(rec, &pageToken); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", req.PageToken)
-		}
-		for _, index := range pageToken.Indices {
-			if index < 0 {
-				return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", req.PageToken)
-			}
-		}
-	}
-	initialSkip := int(pageToken.Indices["skip"])
-	stats.skip = initialSkip
-
 	reply := &xpb.CrossReferencesReply{
 		CrossReferences: make(map[string]*xpb.CrossReferencesReply_CrossReferenceSet, len(req.Ticket)),
 		Nodes:           make(map[string]*cpb.NodeInfo, len(req.Ticket)),
@@ -768,6 +1511,12 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 	if req.NodeDefinitions {
 		reply.DefinitionLocations = make(map[string]*xpb.Anchor)
 	}
+	if len(invalidTickets) > 0 {
+		reply.InvalidTicket = make(map[string]string, len(invalidTickets))
+		for _, i := range invalidTickets {
+			reply.InvalidTicket[i.Field] = i.Reason
+		}
+	}
 	stats.reply = reply
 
 	buildConfigs := stringset.New(req.BuildConfig...)
@@ -792,59 +1541,213 @@ func (t *Table) CrossReferences(ctx context.Context, req *xpb.CrossReferencesReq
 		req.CallerKind != xpb.CrossReferencesRequest_NO_CALLERS ||
 		len(req.Filter) > 0)
 
-	totalsQuality := req.TotalsQuality
-	if totalsQuality == xpb.CrossReferencesRequest_UNSPECIFIED_TOTALS {
-		totalsQuality = xpb.CrossReferencesRequest_TotalsQuality(xpb.CrossReferencesRequest_TotalsQuality_value[strings.ToUpper(*defaultTotalsQuality)])
-	}
-
 	var patcher MultiFilePatcher
 	if t.MakePatcher != nil && req.GetWorkspace() != nil && req.GetPatchAgainstWorkspace() {
 		patcher, err = t.MakePatcher(ctx, req.GetWorkspace())
 		if isNonContextError(err) {
-			log.Printf("ERROR: creating patcher: %v", err)
+			log.Errorf(ctx, "creating patcher: %v", err)
 		}
 
 		if patcher != nil {
 			defer func() {
 				if err := patcher.Close(); isNonContextError(err) {
 					// No need to fail the request; just log the error.
-					log.Printf("ERROR: closing patcher: %v", err)
+					log.Errorf(ctx, "closing patcher: %v", err)
 				}
 			}()
 
 			stats.refOptions.patcherFunc = func(f *srvpb.FileInfo) {
 				if err := patcher.AddFile(ctx, f); isNonContextError(err) {
 					// Attempt to continue with the request, just log the error.
-					log.Printf("ERROR: adding file: %v", err)
+					log.Errorf(ctx, "adding file: %v", err)
 				}
 			}
 		}
 	}
 
-	// Set of xref page keys to read for further indirection nodes.
-	var indirectionPages []string
+	// Related nodes are added after the anchors.  They share the page_size
+	// budget with anchors unless they are paginated independently.
+	relatedStats := &refStats{
+		skip: int(pageToken.GetIndices()["related_skip"]),
+		max:  int(req.RelatedNodePageSize),
 
-	var foundCrossRefs bool
-readLoop:
-	for i := 0; i < len(tickets); i++ {
-		if totalsQuality == xpb.CrossReferencesRequest_APPROXIMATE_TOTALS && stats.done() {
-			break
+		reply:         reply,
+		refOptions:    stats.refOptions,
+		nodeConverter: stats.nodeConverter,
+	}
+	if req.CountOnly {
+		relatedStats.max = 0
+	} else if relatedStats.max > maxPageSize {
+		relatedStats.max = maxPageSize
+	}
+	// Related nodes added once the anchors are added.
+	var relatedWork []func() error
+	relatedPage := func(crs *xpb.CrossReferencesReply_CrossReferenceSet, ticket string, idx *srvpb.PagedCrossReferences_PageIndex) func() error {
+		return func() error {
+			if relatedStats.skipPage(idx) {
+				return nil
+			}
+			p, filtered, err := getFilteredPage(ctx, ticket, idx)
+			if skipBadPage(crs, idx, err) {
+				// Count the page's related nodes as served so that the next page
+				// token starts after them.
+				relatedStats.skipUnreadPage(idx)
+				return nil
+			} else if err != nil {
+				return crossReferencesPageError(idx.PageKey, err)
+			}
+			reply.Total.RelatedNodesByRelation[idx.Kind] -= int64(filtered) // update counts to reflect filtering
+			reply.Filtered.RelatedNodesByRelation[idx.Kind] += int64(filtered)
+			relatedStats.addRelatedNodes(crs, p.Group)
+			return nil
 		}
+	}
+
+	// Set of xref pages to read for further indirection nodes.
+	var indirectionPages []pageRef
 
+	// The anchors (and callers) of each group and run of pages of every set
+	// are merged by location once every set is read.
+	var streams []*anchorStream
+	streamOpts := &streamOptions{
+		order:  stats.order,
+		filter: filterGroup,
+		stop: func() bool {
+			if !leewayTime.IsZero() && time.Now().After(leewayTime) {
+				log.Warningf(ctx, "hit soft deadline; trying to return already read xrefs: %s", time.Now().Sub(leewayTime))
+				return true
+			}
+			return false
+		},
+	}
+	// prefetch starts reading the given page concurrently.
+	prefetch := func(ticket string, idx *srvpb.PagedCrossReferences_PageIndex) {
+		pageReadGroup.TryGo(func() error {
+			_, err := getCachedPage(pageReadGroupCtx, ticket, idx)
+			if isQuotaExceeded(err) || (req.SkipMissingPages && isNonContextError(err)) {
+				// Leave the error to be handled when the page is used.
+				return nil
+			}
+			return err
+		})
+	}
+	streamOpts.read = func(ctx context.Context, s *anchorStream, idx *srvpb.PagedCrossReferences_PageIndex) (*srvpb.PagedCrossReferences_Group, error) {
+		if *pageReadAhead > 0 && len(s.pages) > 1 {
+			prefetch(s.ticket, s.pages[1])
+		}
+		p, err := getCachedPage(ctx, s.ticket, idx)
+		if skipBadPage(s.crs, idx, err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, crossReferencesPageError(idx.PageKey, err)
+		}
+		// Clear page from cache; it should only be used once.
+		single.Delete(idx.PageKey)
+		return p.Group, nil
+	}
+
+	// Merge nodes may be appended to tickets; only the requested tickets are
+	// reported as missing.
+	requested := len(tickets)
+
+	// Requested tickets replaced by their current tickets, keyed by the latter.
+	aliasOf := make(map[string]string)
+
+	// categorize classifies the groups and page indices of a cross-references
+	// set with the given kind and build config, given whether the set is
+	// incomplete and the edge kinds it reads as merge nodes.
+	categorize := func(incomplete bool, indirections stringset.Set, kind, buildConfig string) xrefCategory {
+		// Filter anchor groups and pages based on requested build configs
+		if len(buildConfigs) != 0 && !buildConfigs.Contains(buildConfig) && !xrefs.IsRelatedNodeKind(relatedKinds, kind) {
+			return xrefCategoryNone
+		}
+
+		switch {
+		case xrefs.IsDefKind(req.DefinitionKind, kind, incomplete):
+			return xrefCategoryDef
+		case xrefs.IsDeclKind(req.DeclarationKind, kind, incomplete):
+			return xrefCategoryDecl
+		case xrefs.IsRefKind(req.ReferenceKind, kind):
+			return xrefCategoryRef
+		case len(req.Filter) > 0 && xrefs.IsRelatedNodeKind(relatedKinds, kind):
+			return xrefCategoryRelated
+		case indirections.Contains(kind):
+			return xrefCategoryIndirection
+		case xrefs.IsCallerKind(req.CallerKind, kind):
+			return xrefCategoryCall
+		default:
+			return xrefCategoryNone
+		}
+	}
+
+	// wantGroup reports whether a group is used by the loop below; the anchors
+	// of the others are left undecoded.  The indirection kinds of each set are
+	// computed once, on its first group.
+	var (
+		wantSet          *srvpb.PagedCrossReferences
+		wantIndirections stringset.Set
+	)
+	wantGroup := func(cr *srvpb.PagedCrossReferences, kind, buildConfig string) bool {
+		if cr != wantSet {
+			wantSet, wantIndirections = cr, indirectionKinds(cr)
+		}
+		return categorize(cr.Incomplete, wantIndirections, kind, buildConfig) != xrefCategoryNone
+	}
+
+	// truncated reports whether sets were left unread at the soft deadline.
+	var foundCrossRefs, truncated bool
+	for i := 0; i < len(tickets); i++ {
 		if !leewayTime.IsZero() && time.Now().After(leewayTime) {
-			log.Println("WARNING: hit soft deadline; trying to return already read xrefs")
+			log.Warningf(ctx, "hit soft deadline; trying to return already read xrefs")
+			truncated = true
 			break
 		}
 
 		ticket := tickets[i]
-		cr, err := t.crossReferences(ctx, ticket)
+		cr, err := t.partialCrossReferences(ctx, ticket, wantGroup)
 		if err == table.ErrNoSuchKey {
-			continue
+			if alias, ok := aliasOf[ticket]; ok {
+				delete(reply.RedirectedTicket, alias)
+				reply.MissingTicket = append(reply.MissingTicket, alias)
+			} else if i < requested {
+				current, err := t.resolveTicketAlias(ctx, ticket)
+				if err != nil {
+					return nil, err
+				} else if current == "" {
+					if cr = t.crossReferencesFromEdges(ctx, req, ticket); cr == nil {
+						reply.MissingTicket = append(reply.MissingTicket, ticket)
+						continue
+					}
+					reply.SlowPathTicket = append(reply.SlowPathTicket, ticket)
+				} else {
+					if reply.RedirectedTicket == nil {
+						reply.RedirectedTicket = make(map[string]string)
+					}
+					reply.RedirectedTicket[ticket] = current
+					if _, ok := mergeInto[current]; !ok {
+						// Read the current ticket in place of the requested ticket.
+						aliasOf[current] = ticket
+						mergeInto[current] = current
+						tickets[i] = current
+						i--
+					}
+				}
+			}
+			if cr == nil {
+				continue
+			}
 		} else if err != nil {
 			return nil, canonicalError(err, "cross-references", ticket)
 		}
 		foundCrossRefs = true
 
+		// The streams of each set are keyed in page tokens by the ticket read
+		// and their index within the set.
+		setTicket, setStreams := ticket, len(streams)
+		streamKey := func() string {
+			return fmt.Sprintf("pos:%s:%d", setTicket, len(streams)-setStreams)
+		}
+
 		// If this node is to be merged into another, we will use that node's ticket
 		// for all further book-keeping purposes.
 		ticket = mergeInto[ticket]
@@ -877,43 +1780,19 @@ readLoop:
 			}
 		}
 
-		// Read the set of indirection edge kinds for the given node kind.
-		nodeKind := nodeKind(cr.SourceNode)
-		indirections := experimentalCrossReferenceIndirectionKinds[nodeKind].
-			Union(experimentalCrossReferenceIndirectionKinds["*"])
+		indirections := wantIndirections
+		if cr != wantSet {
+			indirections = indirectionKinds(cr)
+		}
 
 		for _, grp := range cr.Group {
-			// Filter anchor groups based on requested build configs
-			if len(buildConfigs) != 0 && !buildConfigs.Contains(grp.BuildConfig) && !xrefs.IsRelatedNodeKind(relatedKinds, grp.Kind) {
-				continue
-			}
-
-			switch {
-			case xrefs.IsDefKind(req.DefinitionKind, grp.Kind, cr.Incomplete):
-				filtered := filter.FilterGroup(grp)
-				reply.Total.Definitions += int64(len(grp.Anchor))
-				reply.Total.Definitions += int64(countRefs(grp.GetScopedReference()))
-				reply.Filtered.Definitions += int64(filtered)
-				if wantMoreCrossRefs {
-					stats.addAnchors(&crs.Definition, grp)
-				}
-			case xrefs.IsDeclKind(req.DeclarationKind, grp.Kind, cr.Incomplete):
-				filtered := filter.FilterGroup(grp)
-				reply.Total.Declarations += int64(len(grp.Anchor))
-				reply.Total.Declarations += int64(countRefs(grp.GetScopedReference()))
-				reply.Filtered.Declarations += int64(filtered)
-				if wantMoreCrossRefs {
-					stats.addAnchors(&crs.Declaration, grp)
-				}
-			case xrefs.IsRefKind(req.ReferenceKind, grp.Kind):
-				filtered := filter.FilterGroup(grp)
-				reply.Total.References += int64(len(grp.Anchor))
-				reply.Total.References += int64(countRefs(grp.GetScopedReference()))
-				reply.Filtered.References += int64(filtered)
-				if wantMoreCrossRefs {
-					stats.addAnchors(&crs.Reference, grp)
-				}
-			case len(grp.RelatedNode) > 0:
+			switch c := categorize(cr.Incomplete, indirections, grp.Kind, grp.BuildConfig); c {
+			case xrefCategoryDef, xrefCategoryDecl, xrefCategoryRef, xrefCategoryCall:
+				key := streamKey()
+				s, filtered := streamOpts.newGroupStream(key, crs, c, cr.Incomplete, grp, int(pageToken.GetIndices()[key]))
+				c.AddGroupCount(reply, groupSize(grp, c), filtered)
+				streams = append(streams, s)
+			case xrefCategoryRelated, xrefCategoryIndirection:
 				// If requested, add related nodes to merge node set.
 				if indirections.Contains(grp.Kind) {
 					for _, rn := range grp.RelatedNode {
@@ -921,178 +1800,56 @@ readLoop:
 					}
 				}
 
-				if len(req.Filter) > 0 && xrefs.IsRelatedNodeKind(relatedKinds, grp.Kind) {
+				if c == xrefCategoryRelated {
+					stopFilter := startPhase(ctx, PhaseFilter)
 					filtered := filter.FilterGroup(grp)
+					stopFilter()
 					reply.Total.RelatedNodesByRelation[grp.Kind] += int64(len(grp.RelatedNode))
 					reply.Filtered.RelatedNodesByRelation[grp.Kind] += int64(filtered)
 					if wantMoreCrossRefs {
-						stats.addRelatedNodes(crs, grp)
+						crs, grp := crs, grp
+						relatedWork = append(relatedWork, func() error {
+							relatedStats.addRelatedNodes(crs, grp)
+							return nil
+						})
 					}
 				}
-			case xrefs.IsCallerKind(req.CallerKind, grp.Kind):
-				filtered := filter.FilterGroup(grp)
-				reply.Total.Callers += int64(len(grp.Caller))
-				reply.Filtered.Callers += int64(filtered)
-				if wantMoreCrossRefs {
-					stats.addCallers(crs, grp)
-				}
 			}
 		}
 
-		pageSet := filter.PageSet(cr)
-
-		pageCategory := func(idx *srvpb.PagedCrossReferences_PageIndex) xrefCategory {
-			// Filter anchor pages based on requested build configs
-			if len(buildConfigs) != 0 && !buildConfigs.Contains(idx.BuildConfig) && !xrefs.IsRelatedNodeKind(relatedKinds, idx.Kind) {
-				return xrefCategoryNone
-			}
-
-			switch {
-			case xrefs.IsDefKind(req.DefinitionKind, idx.Kind, cr.Incomplete):
-				return xrefCategoryDef
-			case xrefs.IsDeclKind(req.DeclarationKind, idx.Kind, cr.Incomplete):
-				return xrefCategoryDecl
-			case xrefs.IsRefKind(req.ReferenceKind, idx.Kind):
-				return xrefCategoryRef
-			case len(req.Filter) > 0 && xrefs.IsRelatedNodeKind(relatedKinds, idx.Kind):
-				return xrefCategoryRelated
-			case indirections.Contains(idx.Kind):
-				return xrefCategoryIndirection
-			case xrefs.IsCallerKind(req.CallerKind, idx.Kind):
-				return xrefCategoryCall
-			default:
-				return xrefCategoryNone
-			}
-		}
+		pageSet := filter.PageSet(ctx, cr)
 
-		// Find the first unskipped page index so proper read ahead.
-		firstUnskippedPage := len(cr.GetPageIndex())
-		for i, idx := range cr.GetPageIndex() {
-			c := pageCategory(idx)
-			if c == xrefCategoryNone {
-				continue
-			}
-
-			if !stats.skipPage(idx) {
-				firstUnskippedPage = i
-				break
-			}
+		// Consecutive pages of the same category, edge kind, and build config
+		// form a single stream.
+		var run *anchorStream
+		var runKind, runConfig string
+		for _, idx := range cr.GetPageIndex() {
+			c := categorize(cr.Incomplete, indirections, idx.Kind, idx.BuildConfig)
 			c.AddCount(reply, idx, pageSet)
-		}
-
-		// If enabled, start reading pages concurrently starting from the first
-		// unskipped page.
-		if *pageReadAhead > 0 {
-			pageReadGroup.Go(func() error {
-				ctx := pageReadGroupCtx
-				for _, idx := range cr.GetPageIndex()[firstUnskippedPage:] {
-					if err := ctx.Err(); err != nil {
-						return err
-					}
-					if pageCategory(idx) == xrefCategoryNone || !pageSet.Contains(idx) {
-						continue
-					}
-
-					idx := idx
-					pageReadGroup.Go(func() error {
-						_, err := getCachedPage(ctx, idx.PageKey)
-						return err
-					})
-				}
-				return nil
-			})
-		}
-
-		for _, idx := range cr.GetPageIndex()[firstUnskippedPage:] {
-			if !leewayTime.IsZero() && time.Now().After(leewayTime) {
-				log.Printf("WARNING: hit soft deadline; trying to return already read xrefs: %s", time.Now().Sub(leewayTime))
-				break readLoop
-			}
-
-			c := pageCategory(idx)
-			if c == xrefCategoryNone {
-				continue
-			}
-			if wantMoreCrossRefs && !stats.skipPage(idx) {
-				c.AddCount(reply, idx, pageSet)
-			}
-			if c != xrefCategoryIndirection && c != xrefCategoryRelated && !pageSet.Contains(idx) {
-				continue
-			}
 
 			switch c {
-			case xrefCategoryDef:
-				if wantMoreCrossRefs && !stats.skipPage(idx) {
-					p, filtered, err := getFilteredPage(ctx, idx.PageKey)
-					if err != nil {
-						return nil, fmt.Errorf("internal error: error retrieving cross-references page %v: %v", idx.PageKey, err)
-					}
-					reply.Total.Definitions -= int64(filtered) // update counts to reflect filtering
-					reply.Filtered.Definitions += int64(filtered)
-					stats.addAnchors(&crs.Definition, p.Group)
+			case xrefCategoryDef, xrefCategoryDecl, xrefCategoryRef, xrefCategoryCall:
+				if run == nil || run.category != c || runKind != idx.Kind || runConfig != idx.BuildConfig {
+					key := streamKey()
+					run = streamOpts.newPagedStream(key, cr.GetSourceTicket(), crs, c, cr.Incomplete, int(pageToken.GetIndices()[key]))
+					runKind, runConfig = idx.Kind, idx.BuildConfig
+					streams = append(streams, run)
 				}
-			case xrefCategoryDecl:
-				if wantMoreCrossRefs && !stats.skipPage(idx) {
-					p, filtered, err := getFilteredPage(ctx, idx.PageKey)
-					if err != nil {
-						return nil, fmt.Errorf("internal error: error retrieving cross-references page %v: %v", idx.PageKey, err)
-					}
-					reply.Total.Declarations -= int64(filtered) // update counts to reflect filtering
-					reply.Filtered.Declarations += int64(filtered)
-					stats.addAnchors(&crs.Declaration, p.Group)
-				}
-			case xrefCategoryRef:
-				if wantMoreCrossRefs && !stats.skipPage(idx) {
-					p, filtered, err := getFilteredPage(ctx, idx.PageKey)
-					if err != nil {
-						return nil, fmt.Errorf("internal error: error retrieving cross-references page %v: %v", idx.PageKey, err)
-					}
-					reply.Total.References -= int64(filtered) // update counts to reflect filtering
-					reply.Filtered.References += int64(filtered)
-					stats.addAnchors(&crs.Reference, p.Group)
+				if pageSet.Contains(idx) {
+					run.addPage(idx)
 				}
 			case xrefCategoryRelated, xrefCategoryIndirection:
-				var p *srvpb.PagedCrossReferences_Page
-
-				if len(req.Filter) > 0 && xrefs.IsRelatedNodeKind(relatedKinds, idx.Kind) {
-					if pageSet.Contains(idx) {
-						if wantMoreCrossRefs && !stats.skipPage(idx) {
-							var filtered int
-							p, filtered, err = getFilteredPage(ctx, idx.PageKey)
-							if err != nil {
-								return nil, fmt.Errorf("internal error: error retrieving cross-references page: %v", idx.PageKey)
-							}
-							reply.Total.RelatedNodesByRelation[idx.Kind] -= int64(filtered) // update counts to reflect filtering
-							reply.Filtered.RelatedNodesByRelation[idx.Kind] += int64(filtered)
-							stats.addRelatedNodes(crs, p.Group)
-						}
-					}
+				run = nil
+				if c == xrefCategoryRelated && wantMoreCrossRefs && pageSet.Contains(idx) {
+					relatedWork = append(relatedWork, relatedPage(crs, cr.GetSourceTicket(), idx))
 				}
-
-				// If requested, add related nodes to merge node set.
+				// If requested, add related nodes to merge node set.  The page is
+				// saved until we need more tickets.
 				if indirections.Contains(idx.Kind) {
-					if p == nil {
-						// We haven't needed to read the page yet; save it until we need
-						// more tickets.
-						indirectionPages = append(indirectionPages, idx.PageKey)
-					} else {
-						// We've already read the page, immediately populate the indirect
-						// nodes.
-						for _, rn := range p.Group.RelatedNode {
-							tickets = addMergeNode(mergeInto, tickets, ticket, rn.Node.GetTicket())
-						}
-					}
-				}
-			case xrefCategoryCall:
-				if wantMoreCrossRefs && !stats.skipPage(idx) {
-					p, filtered, err := getFilteredPage(ctx, idx.PageKey)
-					if err != nil {
-						return nil, fmt.Errorf("internal error: error retrieving cross-references page: %v", idx.PageKey)
-					}
-					reply.Total.Callers -= int64(filtered) // update counts to reflect filtering
-					reply.Filtered.Callers += int64(filtered)
-					stats.addCallers(crs, p.Group)
+					indirectionPages = append(indirectionPages, pageRef{cr.GetSourceTicket(), idx})
 				}
+			default:
+				run = nil
 			}
 		}
 
@@ -1100,11 +1857,15 @@ readLoop:
 			// We've hit the end of known tickets to pull for xrefs; read an
 			// indirection page until we've found another ticket or we've exhausted
 			// all indirection pages.
-			pageKey := indirectionPages[len(indirectionPages)-1]
+			page := indirectionPages[len(indirectionPages)-1]
 			indirectionPages = indirectionPages[:len(indirectionPages)-1]
-			p, err := t.crossReferencesPage(ctx, pageKey)
-			if err != nil {
-				return nil, fmt.Errorf("internal error: error retrieving cross-references page: %v", pageKey)
+			pageKey := page.idx.PageKey
+			p, err := t.crossReferencesPage(ctx, page.ticket, page.idx)
+			if isQuotaExceeded(err) || (req.SkipMissingPages && isNonContextError(err)) {
+				log.Warningf(ctx, "skipping indirection page %q: %v", pageKey, err)
+				continue
+			} else if err != nil {
+				return nil, crossReferencesPageError(pageKey, err)
 			}
 			for _, rn := range p.Group.RelatedNode {
 				tickets = addMergeNode(mergeInto, tickets, ticket, rn.Node.GetTicket())
@@ -1114,21 +1875,53 @@ readLoop:
 		tracePrintf(ctx, "CrossReferenceSet: %s", crs.Ticket)
 	}
 
+	if *pageReadAhead > 0 {
+		// Start reading the first page of each stream concurrently.
+		for _, s := range streams {
+			if len(s.pages) > 0 {
+				prefetch(s.ticket, s.pages[0])
+			}
+		}
+	}
+	moreAnchors, err := mergeStreams(ctx, streams, stats.max, stats.addItem)
+	if err != nil {
+		return nil, err
+	}
+	moreAnchors = moreAnchors || truncated
+	for _, s := range streams {
+		// Update counts to reflect filtering of the pages read.
+		s.category.AddGroupCount(reply, -s.filtered, s.filtered)
+	}
+
+	if req.RelatedNodePageSize == 0 && !req.CountOnly {
+		relatedStats.max = stats.max - stats.total
+	}
+	for _, add := range relatedWork {
+		if err := add(); err != nil {
+			return nil, err
+		}
+	}
+
 	stopReadingPages()
 	go func() {
 		if err := pageReadGroup.Wait(); isNonContextError(err) {
-			log.Printf("ERROR: page read ahead error: %v", err)
+			log.Errorf(ctx, "page read ahead error: %v", err)
 		}
 	}()
 
-	if !foundCrossRefs {
+	if req.Strict && len(reply.MissingTicket) > 0 {
+		return nil, status.Errorf(codes.NotFound, "cross-references not found: %q", reply.MissingTicket)
+	} else if !foundCrossRefs {
 		// Short-circuit return; skip any slow requests.
-		return &xpb.CrossReferencesReply{}, nil
+		return &xpb.CrossReferencesReply{
+			MissingTicket: reply.MissingTicket,
+			InvalidTicket: reply.InvalidTicket,
+		}, nil
 	}
 
 	var emptySets []string
 	for key, crs := range reply.CrossReferences {
-		if len(crs.Declaration)+len(crs.Definition)+len(crs.Reference)+len(crs.Caller)+len(crs.RelatedNode) == 0 {
+		if len(crs.Declaration)+len(crs.Definition)+len(crs.Reference)+len(crs.Caller)+len(crs.RelatedNode)+len(crs.TruncatedKind) == 0 {
 			emptySets = append(emptySets, key)
 		}
 	}
@@ -1136,16 +1929,71 @@ readLoop:
 		delete(reply.CrossReferences, k)
 	}
 
-	if initialSkip+stats.total != sumTotalCrossRefs(reply.Total) && stats.total != 0 {
-		nextPageToken.Indices["skip"] = int32(initialSkip + stats.total)
+	if req.CanonicalKinds {
+		canonicalizeCrossReferenceKinds(reply)
+	}
+
+	if req.CountOnly {
+		return &xpb.CrossReferencesReply{
+			Total:         reply.Total,
+			Filtered:      reply.Filtered,
+			MissingTicket: reply.MissingTicket,
+			InvalidTicket: reply.InvalidTicket,
+		}, nil
+	}
+
+	if req.RelatedNodeExpansionDepth > 0 && len(req.Filter) > 0 {
+		if err := t.expandRelatedNodes(ctx, reply, int(req.RelatedNodeExpansionDepth), relatedKinds, stats.nodeConverter); err != nil {
+			return nil, err
+		}
+	}
+	sortRelatedNodes(reply)
+
+	if req.DisplayNames {
+		if err := t.addCrossReferencesDisplayNames(ctx, reply); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.GroupRelatedNodeOrdinals {
+		groupRelatedNodeOrdinals(reply)
 	}
 
-	if _, skip := nextPageToken.Indices["skip"]; skip {
+	if req.Documentation {
+		if err := t.addCrossReferencesDocumentation(ctx, reply); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range streams {
+		if pos := s.pos(); pos > 0 {
+			nextPageToken.Indices[s.key] = int32(pos)
+		}
+	}
+	relatedSkip := int(pageToken.GetIndices()["related_skip"]) + relatedStats.total + relatedStats.unread
+	if relatedSkip > 0 {
+		nextPageToken.Indices["related_skip"] = int32(relatedSkip)
+	}
+
+	if moreAnchors || relatedSkip < sumRelatedNodes(reply.Total) {
 		rec, err := proto.Marshal(nextPageToken)
 		if err != nil {
 			return nil, fmt.Errorf("internal error: error marshalling page token: %v", err)
 		}
 		reply.NextPageToken = base64.StdEncoding.EncodeToString(snappy.Encode(nil, rec))
+		if t.Readahead != nil {
+			// Prefetch the page of each stream from which the following page of
+			// the reply continues.
+			var upcomingPages []string
+			for _, s := range streams {
+				if len(upcomingPages) == t.Readahead.Depth() {
+					break
+				} else if idx := s.nextPage(); idx != nil && s.mayHaveMore() {
+					upcomingPages = append(upcomingPages, idx.PageKey)
+				}
+			}
+			t.Readahead.Prefetch(ctx, upcomingPages, t.staticLookupTables.crossReferencesPage)
+		}
 	}
 
 	if req.Snippets == xpb.SnippetsKind_NONE {
@@ -1225,11 +2073,66 @@ readLoop:
 		if err := g.Wait(); err != nil {
 			return nil, err
 		}
+		for _, set := range reply.GetCrossReferences() {
+			for _, as := range [][]*xpb.CrossReferencesReply_RelatedAnchor{set.Definition, set.Declaration, set.Reference, set.Caller} {
+				for _, a := range as {
+					refreshSnippetHighlight(a.Anchor)
+					for _, site := range a.Site {
+						refreshSnippetHighlight(site)
+					}
+				}
+			}
+		}
+	}
+
+	sortCrossReferences(reply, stats.order)
+
+	if req.AnchorFingerprints && anchorMask.includes("fingerprint") {
+		if err := t.addAnchorFingerprints(ctx, reply); err != nil {
+			return nil, err
+		}
+	}
+	if req.MarkUnavailableFiles {
+		if err := t.markUnavailableFiles(ctx, reply); err != nil {
+			return nil, err
+		}
+	}
+	if req.GroupReferencesByKind {
+		groupReferencesByKind(reply, anchorMask)
+	}
+	if req.CallSitesByCaller {
+		groupCallSitesByCaller(reply, stats.order)
 	}
 
 	return reply, nil
 }
 
+// parseCrossReferencesPageToken decodes the given CrossReferencesRequest page
+// token.  An empty token decodes to an empty PageToken.
+func parseCrossReferencesPageToken(token string) (*ipb.PageToken, error) {
+	var pageToken ipb.PageToken
+	if token == "" {
+		return &pageToken, nil
+	}
+	rec, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	rec, err = snappy.Decode(nil, rec)
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(rec, &pageToken); err != nil {
+		return nil, err
+	}
+	for key, index := range pageToken.Indices {
+		if index < 0 {
+			return nil, fmt.Errorf("negative %q index: %d", key, index)
+		}
+	}
+	return &pageToken, nil
+}
+
 func addMergeNode(mergeMap map[string]string, allTickets []string, rootNode, mergeNode string) []string {
 	if _, ok := mergeMap[mergeNode]; ok {
 		return allTickets
@@ -1247,12 +2150,12 @@ func countRefs(rs []*srvpb.PagedCrossReferences_ScopedReference) int {
 	return n
 }
 
-func nodeKind(n *srvpb.Node) string {
-	if n == nil {
-		return ""
-	}
-	for _, f := range n.Fact {
-		if f.Name == facts.NodeKind {
+func nodeKind(n *srvpb.Node) string { return nodeFact(n, facts.NodeKind) }
+
+// nodeFact returns the value of the named fact of n, if any.
+func nodeFact(n *srvpb.Node, name string) string {
+	for _, f := range n.GetFact() {
+		if f.Name == name {
 			return string(f.Value)
 		}
 	}
@@ -1260,16 +2163,30 @@ func nodeKind(n *srvpb.Node) string {
 }
 
 func sumTotalCrossRefs(ts *xpb.CrossReferencesReply_Total) int {
+	return int(ts.Callers) + int(ts.Definitions) + int(ts.Declarations) + int(ts.References) + int(ts.Documentation) + sumRelatedNodes(ts)
+}
+
+func sumRelatedNodes(ts *xpb.CrossReferencesReply_Total) int {
 	var relatedNodes int
 	for _, cnt := range ts.RelatedNodesByRelation {
 		relatedNodes += int(cnt)
 	}
-	return int(ts.Callers) + int(ts.Definitions) + int(ts.Declarations) + int(ts.References) + int(ts.Documentation) + relatedNodes
+	return relatedNodes
 }
 
 type refOptions struct {
 	patcherFunc patcherFunc
 	anchorText  bool
+	anchorMask  fieldMask
+
+	// keepKinds populates anchor kinds even if excluded by anchorMask.
+	keepKinds bool
+
+	// resolvePaths populates anchor parent paths.
+	resolvePaths bool
+
+	// order, if set, records the stored spans of converted anchors.
+	order *anchorOrder
 }
 
 type refStats struct {
@@ -1279,12 +2196,26 @@ type refStats struct {
 	//   total (count of refs so far read for current page)
 	skip, total, max int
 
+	// unread is the number of refs past the skip offset on pages that were
+	// skipped because they could not be read.  They are counted towards the
+	// next page token's offset so that later pages do not repeat refs.
+	unread int
+
 	reply *xpb.CrossReferencesReply
 	refOptions
 	nodeConverter
 }
 
-func (s *refStats) done() bool { return s.total == s.max }
+// skipUnreadPage accounts for the refs of a page that could not be read.
+func (s *refStats) skipUnreadPage(idx *srvpb.PagedCrossReferences_PageIndex) {
+	n := int(idx.Count)
+	if s.skip >= n {
+		s.skip -= n
+		return
+	}
+	s.unread += n - s.skip
+	s.skip = 0
+}
 
 func (s *refStats) skipPage(idx *srvpb.PagedCrossReferences_PageIndex) bool {
 	if s.skip > int(idx.Count) {
@@ -1294,52 +2225,16 @@ func (s *refStats) skipPage(idx *srvpb.PagedCrossReferences_PageIndex) bool {
 	return s.total >= s.max
 }
 
-func (s *refStats) addCallers(crs *xpb.CrossReferencesReply_CrossReferenceSet, grp *srvpb.PagedCrossReferences_Group) bool {
-	cs := grp.Caller
-	converter := &anchorConverter{
-		fileInfos:   makeFileInfoMap(grp.FileInfo),
-		patcherFunc: s.patcherFunc,
-	}
-
-	if s.done() {
-		// We've already hit our cap; return true that we're done.
-		return true
-	} else if s.skip > len(cs) {
-		// We can skip this entire group.
-		s.skip -= len(cs)
-		return false
-	} else if s.skip > 0 {
-		// Skip part of the group, and put the rest in the reply.
-		cs = cs[s.skip:]
-		s.skip = 0
-	}
-
-	if s.total+len(cs) > s.max {
-		cs = cs[:(s.max - s.total)]
-	}
-	s.total += len(cs)
-	for _, c := range cs {
-		ra := &xpb.CrossReferencesReply_RelatedAnchor{
-			Anchor: converter.Convert(c.Caller).Anchor,
-			Ticket: c.SemanticCaller,
-			Site:   make([]*xpb.Anchor, 0, len(c.Callsite)),
-		}
-		ra.MarkedSource = c.MarkedSource
-		for _, site := range c.Callsite {
-			ra.Site = append(ra.Site, converter.Convert(site).Anchor)
-		}
-		crs.Caller = append(crs.Caller, ra)
-	}
-	return s.done() // return whether we've hit our cap
-}
-
 func (s *refStats) addRelatedNodes(crs *xpb.CrossReferencesReply_CrossReferenceSet, grp *srvpb.PagedCrossReferences_Group) bool {
 	ns := grp.RelatedNode
 	nodes := s.reply.Nodes
 	defs := s.reply.DefinitionLocations
 	ac := &anchorConverter{
-		fileInfos:   makeFileInfoMap(grp.FileInfo),
-		patcherFunc: s.patcherFunc,
+		fileInfos:    makeFileInfoMap(grp.FileInfo),
+		patcherFunc:  s.patcherFunc,
+		mask:         s.anchorMask,
+		resolvePaths: s.resolvePaths,
+		order:        s.order,
 	}
 
 	if s.total == s.max {
@@ -1365,49 +2260,64 @@ func (s *refStats) addRelatedNodes(crs *xpb.CrossReferencesReply_CrossReferenceS
 				nodes[rn.Node.Ticket] = info
 				if defs != nil && rn.Node.DefinitionLocation != nil {
 					nodes[rn.Node.Ticket].Definition = rn.Node.DefinitionLocation.Ticket
+					ac.incomplete = isIncomplete(rn.Node)
 					defs[rn.Node.DefinitionLocation.Ticket] = ac.Convert(rn.Node.DefinitionLocation).Anchor
 				}
 			}
 		}
-		crs.RelatedNode = append(crs.RelatedNode, &xpb.CrossReferencesReply_RelatedNode{
-			RelationKind: grp.Kind,
-			Ticket:       rn.Node.Ticket,
-			Ordinal:      rn.Ordinal,
-		})
+		crs.RelatedNode = append(crs.RelatedNode, newRelatedNode(grp.Kind, rn))
 	}
 	return s.total == s.max // return whether we've hit our cap
 }
 
-func (s *refStats) addAnchors(to *[]*xpb.CrossReferencesReply_RelatedAnchor, grp *srvpb.PagedCrossReferences_Group) bool {
-	kind := edges.Canonical(grp.Kind)
-	as := grp.Anchor
-	for _, refs := range grp.GetScopedReference() {
-		// TODO(schroederc): make scopes available in API
-		as = append(as, refs.GetReference()...)
+// addItem adds the given item of st to the reply.  The anchors of st's
+// definitions are classified as declarations if st's node is incomplete.
+func (s *refStats) addItem(st *anchorStream, it streamItem) {
+	if st.conv == nil {
+		st.conv = &anchorConverter{
+			fileInfos:    makeFileInfoMap(st.group.FileInfo),
+			patcherFunc:  s.patcherFunc,
+			mask:         s.anchorMask,
+			resolvePaths: s.resolvePaths,
+			order:        s.order,
+		}
+		if st.category != xrefCategoryCall {
+			st.conv.anchorText = s.anchorText
+			st.conv.incomplete = st.incomplete
+		}
 	}
-	fileInfos := makeFileInfoMap(grp.FileInfo)
+	s.total++
 
-	if s.total == s.max {
-		return true
-	} else if s.skip > len(as) {
-		s.skip -= len(as)
-		return false
-	} else if s.skip > 0 {
-		as = as[s.skip:]
-		s.skip = 0
+	if c := it.caller; c != nil {
+		ra := &xpb.CrossReferencesReply_RelatedAnchor{
+			Anchor: st.conv.Convert(c.Caller).Anchor,
+			Ticket: c.SemanticCaller,
+			Site:   make([]*xpb.Anchor, 0, len(c.Callsite)),
+		}
+		ra.MarkedSource = c.MarkedSource
+		for _, site := range c.Callsite {
+			ra.Site = append(ra.Site, st.conv.Convert(site).Anchor)
+		}
+		st.crs.Caller = append(st.crs.Caller, ra)
+		return
 	}
 
-	if s.total+len(as) > s.max {
-		as = as[:(s.max - s.total)]
-	}
-	s.total += len(as)
-	c := &anchorConverter{fileInfos: fileInfos, anchorText: s.anchorText, patcherFunc: s.patcherFunc}
-	for _, a := range as {
-		ra := c.Convert(a)
+	kind := edges.Canonical(st.group.Kind)
+	ra := st.conv.Convert(it.anchor)
+	if s.keepKinds || s.anchorMask.includes("kind") {
 		ra.Anchor.Kind = kind
-		*to = append(*to, ra)
 	}
-	return s.total == s.max
+	if s.anchorMask.names("classification") {
+		ra.Anchor.Classification = xrefs.ClassifyAnchor(kind, st.incomplete)
+	}
+	switch st.category {
+	case xrefCategoryDef:
+		st.crs.Definition = append(st.crs.Definition, ra)
+	case xrefCategoryDecl:
+		st.crs.Declaration = append(st.crs.Declaration, ra)
+	case xrefCategoryRef:
+		st.crs.Reference = append(st.crs.Reference, ra)
+	}
 }
 
 type patcherFunc func(f *srvpb.FileInfo)
@@ -1416,35 +2326,93 @@ type anchorConverter struct {
 	fileInfos   map[string]*srvpb.FileInfo
 	anchorText  bool
 	patcherFunc patcherFunc
+
+	// resolvePaths populates each anchor's parent_path.
+	resolvePaths bool
+
+	// mask restricts the Anchor fields populated by Convert.
+	mask fieldMask
+
+	// order, if set, records the stored span of each converted anchor.
+	order *anchorOrder
+
+	// incomplete reports whether the node related to the converted anchors is
+	// incomplete, classifying its definitions as declarations.
+	incomplete bool
 }
 
 func (c *anchorConverter) Convert(a *srvpb.ExpandedAnchor) *xpb.CrossReferencesReply_RelatedAnchor {
-	var text string
-	if c.anchorText {
-		text = a.Text
+	m := c.mask
+	if c.order != nil {
+		c.order.record(a)
+	}
+	res := &xpb.Anchor{Ticket: a.Ticket}
+	if m.includes("kind") {
+		res.Kind = edges.Canonical(a.Kind)
+	}
+	if m.names("classification") {
+		res.Classification = xrefs.ClassifyAnchor(a.Kind, c.incomplete)
+	}
+	// Patching requires each anchor's file, so its parent and revision are
+	// always populated when patching.
+	resolvePath := c.resolvePaths && m.includes("parent_path")
+	if c.patcherFunc != nil || resolvePath || m.includes("parent") || m.includes("revision") {
+		parent, err := tickets.AnchorFile(a.Ticket)
+		if err != nil {
+			log.Errorf(context.Background(), "parsing anchor ticket: %v", err)
+		}
+		fileInfo := a.GetFileInfo()
+		if fileInfo == nil {
+			fileInfo = c.fileInfos[parent]
+		}
+		if c.patcherFunc != nil {
+			c.patcherFunc(fileInfo)
+		}
+		if c.patcherFunc != nil || m.includes("parent") {
+			res.Parent = parent
+		}
+		if c.patcherFunc != nil || m.includes("revision") {
+			res.Revision = fileInfo.GetRevision()
+		}
+		if resolvePath {
+			res.ParentPath = fileInfo.GetCorpusPath()
+			if res.ParentPath == nil {
+				res.ParentPath = parentPath(parent)
+			}
+		}
 	}
-	parent, err := tickets.AnchorFile(a.Ticket)
-	if err != nil {
-		log.Printf("Error parsing anchor ticket: %v", err)
+	if c.anchorText && m.includes("text") {
+		res.Text = a.Text
+	}
+	if m.includes("span") {
+		res.Span = a.Span
+	}
+	if m.includes("snippet") {
+		res.Snippet = a.Snippet
+	}
+	if m.includes("snippet_span") {
+		res.SnippetSpan = a.SnippetSpan
 	}
-	fileInfo := a.GetFileInfo()
-	if fileInfo == nil {
-		fileInfo = c.fileInfos[parent]
+	if m.includes("snippet_highlight") && a.Snippet != "" {
+		res.SnippetHighlight = snippetHighlight(a.Span, a.SnippetSpan)
 	}
-	if c.patcherFunc != nil {
-		c.patcherFunc(fileInfo)
+	if m.includes("build_config") {
+		res.BuildConfig = a.BuildConfiguration
 	}
-	return &xpb.CrossReferencesReply_RelatedAnchor{Anchor: &xpb.Anchor{
-		Ticket:      a.Ticket,
-		Kind:        edges.Canonical(a.Kind),
-		Parent:      parent,
-		Text:        text,
-		Span:        a.Span,
-		Snippet:     a.Snippet,
-		SnippetSpan: a.SnippetSpan,
-		BuildConfig: a.BuildConfiguration,
-		Revision:    fileInfo.GetRevision(),
-	}}
+	if m.includes("commit_time") {
+		res.CommitTime = a.CommitTime
+	}
+	return &xpb.CrossReferencesReply_RelatedAnchor{Anchor: res}
+}
+
+// parentPath returns the CorpusPath of the given file ticket, or nil if it is
+// invalid.
+func parentPath(ticket string) *cpb.CorpusPath {
+	uri, err := kytheuri.Parse(ticket)
+	if err != nil {
+		return nil
+	}
+	return &cpb.CorpusPath{Corpus: uri.Corpus, Root: uri.Root, Path: uri.Path}
 }
 
 type documentConverter struct {
@@ -1491,7 +2459,14 @@ func (c *documentConverter) Convert(d *srvpb.Document) *xpb.DocumentationReply_D
 }
 
 func (t *Table) lookupDocument(ctx context.Context, ticket string) (*srvpb.Document, error) {
-	d, err := t.documentation(ctx, ticket)
+	return resolveDocument(ctx, ticket, t.documentation)
+}
+
+// resolveDocument returns the Document for ticket read by documentation,
+// replacing it with the subsuming Document named by its DocumentedBy field, if
+// any.
+func resolveDocument(ctx context.Context, ticket string, documentation documentLookup) (*srvpb.Document, error) {
+	d, err := documentation(ctx, ticket)
 	if err != nil {
 		return nil, err
 	}
@@ -1499,9 +2474,9 @@ func (t *Table) lookupDocument(ctx context.Context, ticket string) (*srvpb.Docum
 
 	// If DocumentedBy is provided, replace document with another lookup.
 	if d.DocumentedBy != "" {
-		doc, err := t.documentation(ctx, d.DocumentedBy)
+		doc, err := documentation(ctx, d.DocumentedBy)
 		if err != nil {
-			log.Printf("Error looking up subsuming documentation for {%+v}: %v", d, err)
+			log.Errorf(ctx, "looking up subsuming documentation for {%+v}: %v", d, err)
 			return nil, err
 		}
 
@@ -1520,10 +2495,40 @@ func (t *Table) lookupDocument(ctx context.Context, ticket string) (*srvpb.Docum
 	return d, nil
 }
 
+// addCrossReferencesDocumentation populates the documentation of each
+// CrossReferenceSet in the given reply.
+func (t *Table) addCrossReferencesDocumentation(ctx context.Context, reply *xpb.CrossReferencesReply) error {
+	for ticket, crs := range reply.CrossReferences {
+		d, err := t.lookupDocument(ctx, ticket)
+		if err == table.ErrNoSuchKey {
+			continue
+		} else if err != nil {
+			return canonicalError(err, "documentation", ticket)
+		}
+		crs.Documentation = &xpb.Printable{
+			RawText: d.RawText,
+			Link:    d.Link,
+		}
+	}
+	return nil
+}
+
 // Documentation implements part of the xrefs Service interface.
 func (t *Table) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
-	tickets, err := xrefs.FixTickets(req.Ticket)
-	if err != nil {
+	ctx = log.EnsureRequestID(ctx)
+	start := time.Now()
+	ctx, budget := t.withReadBudget(ctx)
+	reply, err := t.authorizedDocumentation(ctx, req, t.lookupDocument)
+	reply, err = quotaReply(budget, reply, err)
+	t.logRequest(ctx, "Documentation", req.GetTicket(), start, reply, err)
+	return reply, err
+}
+
+func (t *Table) serveDocumentation(ctx context.Context, req *xpb.DocumentationRequest, lookupDocument documentLookup) (*xpb.DocumentationReply, error) {
+	ctx = log.EnsureRequestID(ctx)
+	var v validate.Validator
+	tickets := v.Tickets("ticket", req.Ticket)
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
 
@@ -1547,30 +2552,31 @@ func (t *Table) Documentation(ctx context.Context, req *xpb.DocumentationRequest
 
 	var patcher MultiFilePatcher
 	if t.MakePatcher != nil && req.GetWorkspace() != nil && req.GetPatchAgainstWorkspace() {
+		var err error
 		patcher, err = t.MakePatcher(ctx, req.GetWorkspace())
 		if isNonContextError(err) {
-			log.Printf("ERROR: creating patcher: %v", err)
+			log.Errorf(ctx, "creating patcher: %v", err)
 		}
 
 		if patcher != nil {
 			defer func() {
 				if err := patcher.Close(); isNonContextError(err) {
 					// No need to fail the request; just log the error.
-					log.Printf("ERROR: closing patcher: %v", err)
+					log.Errorf(ctx, "closing patcher: %v", err)
 				}
 			}()
 
 			dc.anchorConverter.patcherFunc = func(f *srvpb.FileInfo) {
 				if err := patcher.AddFile(ctx, f); isNonContextError(err) {
 					// Attempt to continue with the request, just log the error.
-					log.Printf("ERROR: adding file: %v", err)
+					log.Errorf(ctx, "adding file: %v", err)
 				}
 			}
 		}
 	}
 
 	for _, ticket := range tickets {
-		d, err := t.lookupDocument(ctx, ticket)
+		d, err := lookupDocument(ctx, ticket)
 		if err == table.ErrNoSuchKey {
 			continue
 		} else if err != nil {
@@ -1581,7 +2587,7 @@ func (t *Table) Documentation(ctx context.Context, req *xpb.DocumentationRequest
 		if req.IncludeChildren {
 			for _, child := range d.ChildTicket {
 				// TODO(schroederc): store children with root of documentation tree
-				cd, err := t.lookupDocument(ctx, child)
+				cd, err := lookupDocument(ctx, child)
 				if err == table.ErrNoSuchKey {
 					continue
 				} else if err != nil {
@@ -1600,7 +2606,7 @@ func (t *Table) Documentation(ctx context.Context, req *xpb.DocumentationRequest
 	if patcher != nil {
 		defs, err := patchDefLocations(ctx, patcher, reply.GetDefinitionLocations())
 		if err != nil {
-			log.Printf("ERROR: patching definition locations: %v", err)
+			log.Errorf(ctx, "patching definition locations: %v", err)
 		} else {
 			reply.DefinitionLocations = defs
 		}
@@ -1619,6 +2625,7 @@ func clearRelatedSnippets(ra *xpb.CrossReferencesReply_RelatedAnchor) {
 func clearSnippet(anchor *xpb.Anchor) {
 	anchor.Snippet = ""
 	anchor.SnippetSpan = nil
+	anchor.SnippetHighlight = nil
 }
 
 func tracePrintf(ctx context.Context, msg string, args ...interface{}) {
@@ -1652,6 +2659,17 @@ func canonicalError(err error, caller string, ticket string) error {
 	}
 }
 
+// crossReferencesPageError returns the error of a failed read of the
+// cross-references page with the given key.  Corrupt pages keep their
+// *tablecheck.CorruptionError so that requests fail with DATA_LOSS.
+func crossReferencesPageError(key string, err error) error {
+	var corrupt *tablecheck.CorruptionError
+	if errors.As(err, &corrupt) {
+		return err
+	}
+	return fmt.Errorf("internal error: error retrieving cross-references page %v: %v", key, err)
+}
+
 func isNonContextError(err error) bool {
 	err = canonicalError(err, "", "")
 	return err != nil && err != xrefs.ErrCanceled && err != xrefs.ErrDeadlineExceeded
@@ -17,15 +17,20 @@
 package xrefs
 
 import (
-	"log"
+	"context"
 	"math"
 	"regexp"
 	"regexp/syntax"
+	"time"
 
 	"bitbucket.org/creachadair/stringset"
+	"kythe.io/kythe/go/services/validate"
 	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/log"
+	"kythe.io/kythe/go/util/schema/tickets"
 
 	"github.com/google/codesearch/index"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
@@ -86,14 +91,14 @@ func (p *pageSet) Contains(i *srvpb.PagedCrossReferences_PageIndex) bool {
 	return p == nil || p.KeySet.Contains(i.GetPageKey())
 }
 
-func (f *corpusPathFilter) PageSet(set *srvpb.PagedCrossReferences) *pageSet {
+func (f *corpusPathFilter) PageSet(ctx context.Context, set *srvpb.PagedCrossReferences) *pageSet {
 	idx := set.GetPageSearchIndex()
 	if idx == nil || f == nil || len(f.corpusQuery)+len(f.rootQuery)+len(f.pathQuery)+len(f.resolvedPathQuery) == 0 {
 		return nil
 	}
 
 	if len(set.GetPageIndex()) >= math.MaxUint32 {
-		log.Printf("WARNING: too many pages to perform index search: %d", len(set.GetPageIndex()))
+		log.Warningf(ctx, "too many pages to perform index search: %d", len(set.GetPageIndex()))
 		return nil
 	}
 
@@ -465,3 +470,150 @@ func (f *corpusPathFilter) filterRelatedNodes(rs []*srvpb.PagedCrossReferences_R
 	}
 	return rs[:j], len(rs) - j
 }
+
+// An anchorFilter restricts cross-references to the anchors it allows.  A nil
+// anchorFilter allows every anchor.
+type anchorFilter func(*srvpb.ExpandedAnchor) bool
+
+// FilterGroup removes the anchors, references, and caller call sites of grp
+// that are not allowed by the filter, along with callers left without call
+// sites.  It returns the number of anchors, references, and callers removed.
+func (allow anchorFilter) FilterGroup(grp *srvpb.PagedCrossReferences_Group) (filtered int) {
+	if allow == nil {
+		return 0
+	}
+
+	var n int
+	grp.Anchor, n = allow.filterAnchors(grp.GetAnchor())
+	filtered += n
+	grp.ScopedReference, n = allow.filterReferences(grp.GetScopedReference())
+	filtered += n
+	grp.Caller, n = allow.filterCallers(grp.GetCaller())
+	filtered += n
+	return
+}
+
+func (allow anchorFilter) filterAnchors(as []*srvpb.ExpandedAnchor) ([]*srvpb.ExpandedAnchor, int) {
+	var j int
+	for i, a := range as {
+		if !allow(a) {
+			continue
+		}
+		as[j] = as[i]
+		j++
+	}
+	return as[:j], len(as) - j
+}
+
+func (allow anchorFilter) filterReferences(rs []*srvpb.PagedCrossReferences_ScopedReference) ([]*srvpb.PagedCrossReferences_ScopedReference, int) {
+	var j, filtered int
+	for i, r := range rs {
+		var n int
+		r.Reference, n = allow.filterAnchors(r.GetReference())
+		filtered += n
+		if len(r.GetReference()) == 0 {
+			continue
+		}
+		rs[j] = rs[i]
+		j++
+	}
+	return rs[:j], filtered
+}
+
+func (allow anchorFilter) filterCallers(cs []*srvpb.PagedCrossReferences_Caller) ([]*srvpb.PagedCrossReferences_Caller, int) {
+	var j int
+	for i, c := range cs {
+		c.Callsite, _ = allow.filterAnchors(c.GetCallsite())
+		if len(c.GetCallsite()) == 0 {
+			continue
+		}
+		cs[j] = cs[i]
+		j++
+	}
+	return cs[:j], len(cs) - j
+}
+
+// newAnchorTextFilter returns a filter for anchors whose source text matches
+// pattern, reading the text of files from t as necessary.  Anchors whose text
+// cannot be determined are not allowed.  It returns nil if pattern is empty.
+func (t *Table) newAnchorTextFilter(ctx context.Context, pattern string) (anchorFilter, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte)
+	fileText := func(ticket string) []byte {
+		if text, ok := files[ticket]; ok {
+			return text
+		}
+		decor, err := t.decorationsWithOverride(ctx, ticket)
+		if err == nil {
+			err = t.resolveFileContent(ctx, ticket, decor)
+		}
+		if err != nil {
+			log.Warningf(ctx, "reading text of %q for anchor text filter: %v", ticket, err)
+		}
+		text := decor.GetFile().GetText()
+		files[ticket] = text
+		return text
+	}
+	return func(a *srvpb.ExpandedAnchor) bool {
+		if a.GetText() != "" {
+			return re.MatchString(a.GetText())
+		}
+		file, err := tickets.AnchorFile(a.GetTicket())
+		if err != nil {
+			return false
+		}
+		text := fileText(file)
+		start, end := a.GetSpan().GetStart().GetByteOffset(), a.GetSpan().GetEnd().GetByteOffset()
+		if start < 0 || end < start || int(end) > len(text) {
+			return false
+		}
+		return re.Match(text[start:end])
+	}, nil
+}
+
+// newGeneratedFilter returns a filter excluding anchors in files generated by
+// the build, as marked by their FileInfo, or nil if exclude is false.
+func newGeneratedFilter(exclude bool) anchorFilter {
+	if !exclude {
+		return nil
+	}
+	return func(a *srvpb.ExpandedAnchor) bool { return !a.GetFileInfo().GetGenerated() }
+}
+
+// newCommitTimeFilter returns a filter for anchors last modified within the
+// half-open range [after, before), as recorded by their commit times, or nil
+// if both bounds are unset.  Anchors without a known commit time are not
+// allowed.  Invalid bounds and empty ranges are reported to v under the
+// committed_after and committed_before fields.
+func newCommitTimeFilter(v *validate.Validator, after, before *timestamppb.Timestamp) anchorFilter {
+	if after == nil && before == nil {
+		return nil
+	}
+	bound := func(field string, ts *timestamppb.Timestamp) *time.Time {
+		if ts == nil {
+			return nil
+		} else if err := ts.CheckValid(); err != nil {
+			v.Addf(field, ts.String(), "invalid timestamp: %v", err)
+			return nil
+		}
+		t := ts.AsTime()
+		return &t
+	}
+	from, to := bound("committed_after", after), bound("committed_before", before)
+	if from != nil && to != nil && !from.Before(*to) {
+		v.Addf("committed_before", to.Format(time.RFC3339), "must be after committed_after %s", from.Format(time.RFC3339))
+	}
+	return func(a *srvpb.ExpandedAnchor) bool {
+		if a.GetCommitTime() == nil {
+			return false
+		}
+		t := a.CommitTime.AsTime()
+		return (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to))
+	}
+}
@@ -19,27 +19,52 @@ package xrefs
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"math"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"bitbucket.org/creachadair/stringset"
+	"kythe.io/kythe/go/services/graph"
+	"kythe.io/kythe/go/services/validate"
 	"kythe.io/kythe/go/services/xrefs"
+	gsrv "kythe.io/kythe/go/serving/graph"
+	"kythe.io/kythe/go/serving/pagekey"
+	"kythe.io/kythe/go/serving/readahead"
+	"kythe.io/kythe/go/serving/xrefs/assemble"
+	"kythe.io/kythe/go/storage/inmemory"
+	"kythe.io/kythe/go/storage/keyvalue"
 	"kythe.io/kythe/go/storage/table"
 	"kythe.io/kythe/go/test/testutil"
+	"kythe.io/kythe/go/util/bloom"
 	"kythe.io/kythe/go/util/compare"
 	"kythe.io/kythe/go/util/kytheuri"
+	"kythe.io/kythe/go/util/schema/facts"
 	"kythe.io/kythe/go/util/span"
 
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/unicode"
 	"golang.org/x/text/transform"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	cpb "kythe.io/kythe/proto/common_go_proto"
+	gpb "kythe.io/kythe/proto/graph_go_proto"
+	ipb "kythe.io/kythe/proto/internal_go_proto"
 	srvpb "kythe.io/kythe/proto/serving_go_proto"
 	xpb "kythe.io/kythe/proto/xref_go_proto"
 )
@@ -706,6 +731,663 @@ func TestDecorationsRefs(t *testing.T) {
 	}
 }
 
+func TestDecorationsDisplayNames(t *testing.T) {
+	d := tbl.Decorations[1]
+	names := &testTable{
+		Decorations: tbl.Decorations,
+		Names: []*srvpb.DisplayName{
+			{Ticket: "kythe://core?lang=otpl#cons", Name: "cons(x, seq)"},
+		},
+	}
+
+	st := names.Construct(t)
+	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:     &xpb.Location{Ticket: d.File.Ticket},
+		References:   true,
+		DisplayNames: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+	for _, r := range reply.Reference {
+		var expected string
+		if r.TargetTicket == "kythe://core?lang=otpl#cons" {
+			expected = "cons(x, seq)"
+		}
+		if r.TargetDisplayName != expected {
+			t.Errorf("Expected display name %q for %q; found %q", expected, r.TargetTicket, r.TargetDisplayName)
+		}
+	}
+}
+
+func TestDecorationsDefinitionPreference(t *testing.T) {
+	const (
+		file   = "kythe://c?path=file"
+		target = "kythe://c?lang=otpl#fwd"
+		decl   = "kythe://c?lang=otpl?path=file#decl"
+		def    = "kythe://c?lang=otpl?path=other#def"
+	)
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("class fwd;")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor:           &srvpb.RawAnchor{Ticket: decl, StartOffset: 6, EndOffset: 9},
+				Kind:             "/kythe/edge/defines/binding",
+				Target:           target,
+				TargetDefinition: decl,
+			}},
+			Target: []*srvpb.Node{{
+				Ticket: target,
+				Fact:   []*cpb.Fact{{Name: facts.Complete, Value: []byte("incomplete")}},
+			}},
+			TargetDefinitions: []*srvpb.ExpandedAnchor{{
+				Ticket: decl,
+				Kind:   "/kythe/edge/defines/binding",
+				Span:   &cpb.Span{Start: &cpb.Point{ByteOffset: 6}, End: &cpb.Point{ByteOffset: 9}},
+			}},
+		}},
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: target,
+			Incomplete:   true,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind: "%/kythe/edge/defines/binding",
+				Anchor: []*srvpb.ExpandedAnchor{{
+					Ticket: decl,
+					Kind:   "/kythe/edge/defines/binding",
+				}},
+			}, {
+				Kind: "%/kythe/edge/completes/uniquely",
+				Anchor: []*srvpb.ExpandedAnchor{{
+					Ticket: def,
+					Kind:   "/kythe/edge/completes/uniquely",
+				}},
+			}},
+		}},
+	}).Construct(t)
+
+	tests := []struct {
+		preference []xpb.TargetDefinitionKind
+		definition string
+		kind       xpb.TargetDefinitionKind
+	}{
+		{nil, def, xpb.TargetDefinitionKind_COMPLETION},
+		{[]xpb.TargetDefinitionKind{xpb.TargetDefinitionKind_DECLARATION}, decl, xpb.TargetDefinitionKind_DECLARATION},
+		{[]xpb.TargetDefinitionKind{xpb.TargetDefinitionKind_FULL_DEFINITION, xpb.TargetDefinitionKind_COMPLETION}, def, xpb.TargetDefinitionKind_COMPLETION},
+	}
+	for _, test := range tests {
+		reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+			Location:             &xpb.Location{Ticket: file},
+			References:           true,
+			TargetDefinitions:    true,
+			DefinitionPreference: test.preference,
+		})
+		testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+		if len(reply.Reference) != 1 {
+			t.Fatalf("Expected 1 reference; found %v", reply.Reference)
+		}
+		ref := reply.Reference[0]
+		if ref.TargetDefinition != test.definition || ref.TargetDefinitionKind != test.kind {
+			t.Errorf("With preference %v: expected %s definition %q; found %s %q", test.preference, test.kind, test.definition, ref.TargetDefinitionKind, ref.TargetDefinition)
+		}
+		if _, ok := reply.DefinitionLocations[test.definition]; !ok {
+			t.Errorf("With preference %v: missing definition location for %q: %v", test.preference, test.definition, reply.DefinitionLocations)
+		}
+	}
+}
+
+func TestDecorationsPrefetchDefinitions(t *testing.T) {
+	const (
+		file = "kythe://c?path=file"
+		text = "class a; class b; class c;"
+	)
+	target := func(name string) string { return "kythe://c?lang=otpl#" + name }
+	decl := func(name string) string { return "kythe://c?lang=otpl?path=file#" + name }
+	def := func(name string) string { return "kythe://c?lang=otpl?path=other#" + name }
+
+	tbl := &testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte(text)},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey: "cDefs",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   "%/kythe/edge/completes/uniquely",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: def("c"), Kind: "/kythe/edge/completes/uniquely"}},
+			},
+		}, {
+			PageKey: "cRefs",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe://c?lang=otpl?path=other#ref"}},
+			},
+		}, {
+			PageKey: "bDecls",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   "%/kythe/edge/defines/binding",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: def("bDecl"), Kind: "/kythe/edge/defines/binding"}},
+			},
+		}},
+	}
+	d := tbl.Decorations[0]
+	for i, name := range []string{"a", "b", "c"} {
+		start := int32(i*9 + 6)
+		d.Decoration = append(d.Decoration, &srvpb.FileDecorations_Decoration{
+			Anchor:           &srvpb.RawAnchor{Ticket: decl(name), StartOffset: start, EndOffset: start + 1},
+			Kind:             "/kythe/edge/defines/binding",
+			Target:           target(name),
+			TargetDefinition: decl(name),
+		})
+		d.Target = append(d.Target, &srvpb.Node{
+			Ticket: target(name),
+			Fact:   []*cpb.Fact{{Name: facts.Complete, Value: []byte("incomplete")}},
+		})
+		d.TargetDefinitions = append(d.TargetDefinitions, &srvpb.ExpandedAnchor{
+			Ticket: decl(name),
+			Kind:   "/kythe/edge/defines/binding",
+		})
+		cr := &srvpb.PagedCrossReferences{SourceTicket: target(name), Incomplete: true}
+		if name == "c" {
+			cr.PageIndex = []*srvpb.PagedCrossReferences_PageIndex{
+				{PageKey: "cDefs", Kind: "%/kythe/edge/completes/uniquely", Count: 1},
+				{PageKey: "cRefs", Kind: "%/kythe/edge/ref", Count: 1},
+			}
+		} else {
+			cr.Group = []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "%/kythe/edge/completes/uniquely",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: def(name), Kind: "/kythe/edge/completes/uniquely"}},
+			}, {
+				Kind:   "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe://c?lang=otpl?path=other#ref"}},
+			}}
+		}
+		if name == "b" {
+			// b's declarations cannot beat its inline definition.
+			cr.PageIndex = []*srvpb.PagedCrossReferences_PageIndex{
+				{PageKey: "bDecls", Kind: "%/kythe/edge/defines/binding", Count: 1},
+			}
+		}
+		tbl.RefSets = append(tbl.RefSets, cr)
+	}
+
+	// A non-positive concurrency reads definitions one at a time.
+	defer func(n int) { *definitionReadConcurrency = n }(*definitionReadConcurrency)
+	for _, concurrency := range []int{*definitionReadConcurrency, 0} {
+		*definitionReadConcurrency = concurrency
+		testDecorationsPrefetchDefinitions(t, tbl, concurrency)
+	}
+}
+
+func testDecorationsPrefetchDefinitions(t *testing.T, tbl *testTable, concurrency int) {
+	const file = "kythe://c?path=file"
+	target := func(name string) string { return "kythe://c?lang=otpl#" + name }
+	def := func(name string) string { return "kythe://c?lang=otpl?path=other#" + name }
+
+	for _, selection := range []xpb.DecorationsRequest_DefinitionSelection{
+		xpb.DecorationsRequest_BEST_DEFINITION,
+		xpb.DecorationsRequest_ALL_DEFINITIONS,
+	} {
+		counter := &countingProtoTable{testProtoTable: tbl.protoTable(t), lookups: make(map[string]int)}
+		reply, err := NewCombinedTable(counter).Decorations(ctx, &xpb.DecorationsRequest{
+			Location:            &xpb.Location{Ticket: file},
+			References:          true,
+			TargetDefinitions:   true,
+			DefinitionSelection: selection,
+		})
+		testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+		found := make(map[string]string)
+		for _, ref := range reply.Reference {
+			found[ref.TargetTicket] = ref.TargetDefinition
+		}
+		if err := testutil.DeepEqual(map[string]string{
+			target("a"): def("a"),
+			target("b"): def("b"),
+			target("c"): def("c"),
+		}, found); err != nil {
+			t.Errorf("%v (concurrency %d): unexpected definitions: %v", selection, concurrency, err)
+		}
+
+		// Each target's cross-references and needed definition pages are read
+		// once; pages of other groups, and those of definitions that cannot beat
+		// the best found, are skipped unless every definition is requested.
+		lookups := make(map[string]int)
+		for key, n := range counter.lookups {
+			if strings.HasPrefix(key, crossRefTablePrefix) || strings.HasPrefix(key, crossRefPageTablePrefix) {
+				lookups[key] = n
+			}
+		}
+		expected := map[string]int{
+			string(CrossReferencesKey(target("a"))): 1,
+			string(CrossReferencesKey(target("b"))): 1,
+			string(CrossReferencesKey(target("c"))): 1,
+			string(CrossReferencesPageKey("cDefs")): 1,
+		}
+		if selection == xpb.DecorationsRequest_ALL_DEFINITIONS {
+			expected[string(CrossReferencesPageKey("bDecls"))] = 1
+		}
+		if err := testutil.DeepEqual(expected, lookups); err != nil {
+			t.Errorf("%v (concurrency %d): unexpected lookups: %v", selection, concurrency, err)
+		}
+	}
+}
+
+func TestDecorationsDefinitionJumps(t *testing.T) {
+	const (
+		file   = "kythe://c?path=file"
+		thunk  = "kythe://c?lang=otpl#thunk"
+		wrap   = "kythe://c?lang=otpl#wrap"
+		target = "kythe://c?lang=otpl#callable"
+		ref    = "kythe://c?lang=otpl?path=file#ref"
+		def    = "kythe://c?lang=otpl?path=other#def"
+	)
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("thunk();")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: ref, StartOffset: 0, EndOffset: 5},
+				Kind:   "/kythe/edge/ref",
+				Target: thunk,
+			}},
+		}},
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: thunk,
+			MergeWith:    []string{wrap},
+		}, {
+			SourceTicket: wrap,
+			MergeWith:    []string{target},
+		}, {
+			SourceTicket: target,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind: "/kythe/edge/defines/binding",
+				Anchor: []*srvpb.ExpandedAnchor{{
+					Ticket: def,
+					Kind:   "/kythe/edge/defines/binding",
+				}},
+			}},
+		}},
+	}).Construct(t)
+
+	tests := []struct {
+		maxJumps   int32
+		definition string
+		jumps      map[string]int32
+	}{
+		{0, "", nil},
+		{-1, "", nil},
+		{1, "", nil},
+		{2, def, map[string]int32{thunk: 2}},
+		{3, def, map[string]int32{thunk: 2}},
+	}
+	for _, test := range tests {
+		reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+			Location:           &xpb.Location{Ticket: file},
+			References:         true,
+			TargetDefinitions:  true,
+			MaxDefinitionJumps: test.maxJumps,
+		})
+		testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+		if len(reply.Reference) != 1 {
+			t.Fatalf("Expected 1 reference; found %v", reply.Reference)
+		}
+		if found := reply.Reference[0].TargetDefinition; found != test.definition {
+			t.Errorf("With max jumps %d: expected definition %q; found %q", test.maxJumps, test.definition, found)
+		}
+		if err := testutil.DeepEqual(test.jumps, reply.DefinitionJumps); err != nil {
+			t.Errorf("With max jumps %d: %v", test.maxJumps, err)
+		}
+	}
+}
+
+func TestDecorationsAllDefinitions(t *testing.T) {
+	const (
+		file    = "kythe://c?path=file"
+		target  = "kythe://c?lang=otpl#f"
+		ref     = "kythe://c?lang=otpl?path=file#ref"
+		remote  = "kythe://other?lang=otpl?path=a#def"
+		binding = "kythe://c?lang=otpl?path=b#def"
+		plain   = "kythe://c?lang=otpl?path=a#def"
+	)
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("f();")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: ref, StartOffset: 0, EndOffset: 1},
+				Kind:   "/kythe/edge/ref",
+				Target: target,
+			}},
+		}},
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: target,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "%/kythe/edge/defines/binding",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: remote}, {Ticket: binding}},
+			}, {
+				Kind:   "%/kythe/edge/defines",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: plain}},
+			}},
+		}},
+	}).Construct(t)
+
+	tests := []struct {
+		selection  xpb.DecorationsRequest_DefinitionSelection
+		definition string
+		candidates []string
+	}{
+		{xpb.DecorationsRequest_BEST_DEFINITION, "", nil},
+		{xpb.DecorationsRequest_ALL_DEFINITIONS, binding, []string{binding, plain, remote}},
+	}
+	for _, test := range tests {
+		reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+			Location:            &xpb.Location{Ticket: file},
+			References:          true,
+			TargetDefinitions:   true,
+			DefinitionSelection: test.selection,
+		})
+		testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+		if len(reply.Reference) != 1 {
+			t.Fatalf("Expected 1 reference; found %v", reply.Reference)
+		}
+		ref := reply.Reference[0]
+		if ref.TargetDefinition != test.definition {
+			t.Errorf("With selection %v: expected definition %q; found %q", test.selection, test.definition, ref.TargetDefinition)
+		}
+		var found []string
+		for _, c := range ref.TargetDefinitionCandidate {
+			found = append(found, c.Ticket)
+			if _, ok := reply.DefinitionLocations[c.Ticket]; !ok {
+				t.Errorf("With selection %v: missing definition location for %q", test.selection, c.Ticket)
+			}
+		}
+		if err := testutil.DeepEqual(test.candidates, found); err != nil {
+			t.Errorf("With selection %v: %v", test.selection, err)
+		}
+	}
+}
+
+func TestDecorationsOverrideMarkers(t *testing.T) {
+	const (
+		file    = "kythe://c?path=file"
+		base    = "kythe://c?lang=otpl#base"
+		method  = "kythe://c?lang=otpl#method"
+		derived = "kythe://c?lang=otpl#derived"
+		other   = "kythe://c?lang=otpl#other"
+	)
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("base method")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a0", StartOffset: 0, EndOffset: 4},
+				Kind:   "/kythe/edge/ref",
+				Target: base,
+			}, {
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a1", StartOffset: 5, EndOffset: 11},
+				Kind:   "/kythe/edge/defines/binding",
+				Target: method,
+			}},
+			TargetOverride: []*srvpb.FileDecorations_Override{{
+				Overriding: method,
+				Overridden: base,
+			}, {
+				Overriding: method,
+				Overridden: base,
+				Kind:       srvpb.FileDecorations_Override_EXTENDS,
+			}},
+			TargetOverriddenBy: []*srvpb.FileDecorations_Override{{
+				Overriding: method,
+				Overridden: base,
+			}, {
+				Overriding: other,
+				Overridden: base,
+			}, {
+				Overriding: derived,
+				Overridden: method,
+			}},
+		}},
+	}).Construct(t)
+
+	for _, markers := range []bool{false, true} {
+		reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+			Location:        &xpb.Location{Ticket: file},
+			References:      true,
+			OverrideMarkers: markers,
+		})
+		testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+		if len(reply.Reference) != 2 {
+			t.Fatalf("Expected 2 references; found %v", reply.Reference)
+		}
+		var expected [][2][]string
+		if markers {
+			expected = [][2][]string{
+				{nil, {method, other}},
+				{{base}, {derived}},
+			}
+		} else {
+			expected = [][2][]string{{}, {}}
+		}
+		for i, r := range reply.Reference {
+			if err := testutil.DeepEqual(expected[i], [2][]string{r.Overrides, r.OverriddenBy}); err != nil {
+				t.Errorf("With override_markers=%v, reference %s: %v", markers, r.TargetTicket, err)
+			}
+		}
+	}
+}
+
+func TestDecorationsReferenceCounts(t *testing.T) {
+	const (
+		file    = "kythe://c?path=file"
+		counted = "kythe://c?lang=otpl#counted"
+		unknown = "kythe://c?lang=otpl#unknown"
+	)
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("counted unknown")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a0", StartOffset: 0, EndOffset: 7},
+				Kind:   "/kythe/edge/ref",
+				Target: counted,
+			}, {
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a1", StartOffset: 8, EndOffset: 15},
+				Kind:   "/kythe/edge/ref",
+				Target: unknown,
+			}},
+			TargetReferenceCount: map[string]int64{counted: 42},
+		}},
+	}).Construct(t)
+
+	for _, counts := range []bool{false, true} {
+		reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+			Location:        &xpb.Location{Ticket: file},
+			References:      true,
+			ReferenceCounts: counts,
+		})
+		testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+		expected := []int64{0, 0}
+		if counts {
+			expected[0] = 42
+		}
+		var found []int64
+		for _, r := range reply.Reference {
+			found = append(found, r.ReferenceCount)
+		}
+		if err := testutil.DeepEqual(expected, found); err != nil {
+			t.Errorf("With reference_counts=%v: %v", counts, err)
+		}
+	}
+}
+
+func TestDecorationsDeprecatedTargets(t *testing.T) {
+	const (
+		file       = "kythe://c?path=file"
+		deprecated = "kythe://c?lang=otpl#old"
+		current    = "kythe://c?lang=otpl#new"
+	)
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("old new")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a0", StartOffset: 0, EndOffset: 3},
+				Kind:   "/kythe/edge/ref",
+				Target: deprecated,
+			}, {
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a1", StartOffset: 4, EndOffset: 7},
+				Kind:   "/kythe/edge/ref",
+				Target: current,
+			}},
+			Target: []*srvpb.Node{
+				assemble.Node(&ipb.Source{
+					Ticket: deprecated,
+					Facts: map[string][]byte{
+						facts.NodeKind:   []byte("function"),
+						facts.Deprecated: []byte("use new"),
+					},
+				}),
+				assemble.Node(&ipb.Source{
+					Ticket: current,
+					Facts:  map[string][]byte{facts.NodeKind: []byte("function")},
+				}),
+			},
+		}},
+	}).Construct(t)
+
+	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		References: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+	type marking struct {
+		Deprecated bool
+		Message    string
+	}
+	var found []marking
+	for _, r := range reply.Reference {
+		found = append(found, marking{r.TargetDeprecated, r.TargetDeprecationMessage})
+	}
+	if err := testutil.DeepEqual([]marking{{true, "use new"}, {}}, found); err != nil {
+		t.Error(err)
+	}
+
+	// Deprecated targets have a NodeInfo even without a fact filter.
+	expectedNodes := map[string]*cpb.NodeInfo{
+		deprecated: {Deprecated: true, DeprecationMessage: "use new"},
+	}
+	if err := testutil.DeepEqual(expectedNodes, reply.Nodes); err != nil {
+		t.Error(err)
+	}
+
+	reply, err = st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:      &xpb.Location{Ticket: file},
+		References:    true,
+		ReferenceMask: &fieldmaskpb.FieldMask{Paths: []string{"target_deprecated"}},
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if r := reply.Reference[0]; !r.TargetDeprecated || r.TargetDeprecationMessage != "" {
+		t.Errorf("Expected only target_deprecated with mask; found %v", r)
+	}
+}
+
+func TestDecorationsCanonicalNodes(t *testing.T) {
+	const (
+		file       = "kythe://c?path=file"
+		deprecated = "kythe://c?lang=otpl#old"
+		current    = "kythe://c?lang=otpl#new"
+	)
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("old new new")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a0", StartOffset: 0, EndOffset: 3},
+				Kind:   "/kythe/edge/ref",
+				Target: deprecated,
+			}, {
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a1", StartOffset: 4, EndOffset: 7},
+				Kind:   "/kythe/edge/ref",
+				Target: current,
+			}, {
+				Anchor: &srvpb.RawAnchor{Ticket: "kythe://c?path=file#a2", StartOffset: 8, EndOffset: 11},
+				Kind:   "/kythe/edge/ref",
+				Target: current,
+			}},
+			Target: []*srvpb.Node{
+				assemble.Node(&ipb.Source{
+					Ticket: deprecated,
+					Facts: map[string][]byte{
+						facts.NodeKind:   []byte("function"),
+						facts.Deprecated: []byte("use new"),
+					},
+				}),
+				assemble.Node(&ipb.Source{
+					Ticket: current,
+					Facts:  map[string][]byte{facts.NodeKind: []byte("function")},
+				}),
+			},
+		}},
+		Names: []*srvpb.DisplayName{
+			{Ticket: deprecated, Name: "old()"},
+			{Ticket: current, Name: "new()"},
+		},
+	}).Construct(t)
+
+	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:       &xpb.Location{Ticket: file},
+		References:     true,
+		DisplayNames:   true,
+		CanonicalNodes: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+	for _, r := range reply.Reference {
+		if r.TargetDisplayName != "" || r.TargetDeprecated || r.TargetDeprecationMessage != "" {
+			t.Errorf("Reference to %q has non-canonical node info: %v", r.TargetTicket, r)
+		}
+	}
+	expectedNodes := map[string]*cpb.NodeInfo{
+		deprecated: {DisplayName: "old()", Deprecated: true, DeprecationMessage: "use new"},
+		current:    {DisplayName: "new()"},
+	}
+	if err := testutil.DeepEqual(expectedNodes, reply.Nodes); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecorationsReferenceMask(t *testing.T) {
+	d := tbl.Decorations[1]
+
+	st := tbl.Construct(t)
+	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:       &xpb.Location{Ticket: d.File.Ticket},
+		References:     true,
+		SemanticScopes: true,
+		ReferenceMask:  &fieldmaskpb.FieldMask{Paths: []string{"span", "semantic_scope"}},
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+	var expected []*xpb.DecorationsReply_Reference
+	for _, r := range refs(span.NewNormalizer(d.File.Text), d.Decoration, d.FileInfo) {
+		expected = append(expected, &xpb.DecorationsReply_Reference{
+			TargetTicket:  r.TargetTicket,
+			Span:          r.Span,
+			SemanticScope: r.SemanticScope,
+		})
+	}
+	if err := testutil.DeepEqual(expected, reply.Reference); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:      &xpb.Location{Ticket: d.File.Ticket},
+		References:    true,
+		ReferenceMask: &fieldmaskpb.FieldMask{Paths: []string{"snippet"}},
+	})
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error for invalid mask; found %v", err)
+	}
+}
+
 func TestDecorationsRefScopes(t *testing.T) {
 	d := tbl.Decorations[1]
 
@@ -854,28 +1536,156 @@ func TestDecorationsBuildConfig(t *testing.T) {
 	})
 }
 
-func TestDecorationsDirtyBuffer(t *testing.T) {
+func TestDecorationsTargetTickets(t *testing.T) {
 	d := tbl.Decorations[1]
-
 	st := tbl.Construct(t)
-	// s/empty?/seq/
-	dirty := []byte(`(defn map [f coll]
-  (if (seq coll)
-    []
-    (cons (f (first coll)) (map f (rest coll)))))
-`)
+
+	const target = "kythe://core?lang=otpl#cons"
 	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
-		Location:    &xpb.Location{Ticket: d.File.Ticket},
-		DirtyBuffer: dirty,
-		References:  true,
-		Filter:      []string{"**"},
+		Location:         &xpb.Location{Ticket: d.File.Ticket},
+		References:       true,
+		Filter:           []string{"**"},
+		ExtendsOverrides: true,
+		TargetTicket:     []string{target},
 	})
 	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
 
-	if len(reply.SourceText) != 0 {
-		t.Errorf("Unexpected source text: %q", string(reply.SourceText))
+	expected := refs(span.NewNormalizer(d.File.Text), d.Decoration[2:], d.FileInfo)
+	for _, ref := range expected {
+		ref.SemanticScope = "" // not requested
 	}
-	if reply.Encoding != "" {
+	if err := testutil.DeepEqual(expected, reply.Reference); err != nil {
+		t.Fatal(err)
+	}
+	for ticket := range reply.Nodes {
+		if ticket != target {
+			t.Errorf("Unexpected node for unrequested target: %q", ticket)
+		}
+	}
+
+	if _, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:     &xpb.Location{Ticket: d.File.Ticket},
+		References:   true,
+		TargetTicket: []string{"kythe:#invalid?lang=%"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error for invalid target ticket; found %v", err)
+	}
+
+	// Only the overrides of requested targets are returned, along with the
+	// nodes of the targets they override.
+	const file = "kythe:?path=overrides"
+	binding := func(start, end int32, target string) *srvpb.FileDecorations_Decoration {
+		return &srvpb.FileDecorations_Decoration{
+			Anchor: &srvpb.RawAnchor{StartOffset: start, EndOffset: end},
+			Kind:   "/kythe/edge/defines/binding",
+			Target: target,
+		}
+	}
+	node := func(ticket string) *srvpb.Node {
+		return &srvpb.Node{Ticket: ticket, Fact: []*cpb.Fact{{Name: facts.NodeKind, Value: []byte("function")}}}
+	}
+	st = (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File:       &srvpb.File{Ticket: file, Text: []byte("sub other")},
+			Decoration: []*srvpb.FileDecorations_Decoration{binding(0, 3, "kythe:#sub"), binding(4, 9, "kythe:#other")},
+			Target:     []*srvpb.Node{node("kythe:#sub"), node("kythe:#base"), node("kythe:#other"), node("kythe:#otherBase")},
+			TargetOverride: []*srvpb.FileDecorations_Override{
+				{Overriding: "kythe:#sub", Overridden: "kythe:#base"},
+				{Overriding: "kythe:#other", Overridden: "kythe:#otherBase"},
+			},
+		}},
+	}).Construct(t)
+	reply, err = st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:         &xpb.Location{Ticket: file},
+		References:       true,
+		Filter:           []string{"**"},
+		ExtendsOverrides: true,
+		TargetTicket:     []string{"kythe:#sub"},
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if err := testutil.DeepEqual(map[string]*xpb.DecorationsReply_Overrides{
+		"kythe:#sub": {Override: []*xpb.DecorationsReply_Override{{Target: "kythe:#base"}}},
+	}, reply.ExtendsOverrides); err != nil {
+		t.Errorf("Unexpected overrides: %v", err)
+	}
+	if err := testutil.DeepEqual([]string{"kythe:#base", "kythe:#sub"}, stringset.FromKeys(reply.Nodes).Elements()); err != nil {
+		t.Errorf("Unexpected nodes: %v", err)
+	}
+}
+
+func TestDecorationsGroupByBuildConfig(t *testing.T) {
+	const file = "kythe://someCorpus?path=some/file"
+	decor := func(start, end int32, config, target string) *srvpb.FileDecorations_Decoration {
+		return &srvpb.FileDecorations_Decoration{
+			Anchor: &srvpb.RawAnchor{
+				Ticket:             fmt.Sprintf("kythe://someCorpus?lang=otpl?path=some/file#%d-%d", start, end),
+				StartOffset:        start,
+				EndOffset:          end,
+				BuildConfiguration: config,
+			},
+			Kind:   "/kythe/edge/ref",
+			Target: target,
+		}
+	}
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("foo bar baz")},
+			Decoration: []*srvpb.FileDecorations_Decoration{
+				decor(0, 3, "windows", "kythe:#foo"),
+				decor(4, 7, "linux", "kythe:#bar"),
+				decor(8, 11, "windows", "kythe:#baz"),
+			},
+		}},
+	}).Construct(t)
+
+	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:           &xpb.Location{Ticket: file},
+		References:         true,
+		GroupByBuildConfig: true,
+		ReferenceMask:      &fieldmaskpb.FieldMask{Paths: []string{"target_ticket"}},
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+	ref := func(target string) *xpb.DecorationsReply_Reference {
+		return &xpb.DecorationsReply_Reference{TargetTicket: target}
+	}
+	expected := []*xpb.DecorationsReply_BuildConfigGroup{{
+		BuildConfig: "linux",
+		Reference:   []*xpb.DecorationsReply_Reference{ref("kythe:#bar")},
+	}, {
+		BuildConfig: "windows",
+		Reference:   []*xpb.DecorationsReply_Reference{ref("kythe:#foo"), ref("kythe:#baz")},
+	}}
+	if err := testutil.DeepEqual(expected, reply.BuildConfigGroup); err != nil {
+		t.Error(err)
+	}
+	if len(reply.Reference) != 0 {
+		t.Errorf("Unexpected ungrouped references: %v", reply.Reference)
+	}
+}
+
+func TestDecorationsDirtyBuffer(t *testing.T) {
+	d := tbl.Decorations[1]
+
+	st := tbl.Construct(t)
+	// s/empty?/seq/
+	dirty := []byte(`(defn map [f coll]
+  (if (seq coll)
+    []
+    (cons (f (first coll)) (map f (rest coll)))))
+`)
+	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:    &xpb.Location{Ticket: d.File.Ticket},
+		DirtyBuffer: dirty,
+		References:  true,
+		Filter:      []string{"**"},
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+	if len(reply.SourceText) != 0 {
+		t.Errorf("Unexpected source text: %q", string(reply.SourceText))
+	}
+	if reply.Encoding != "" {
 		t.Errorf("Unexpected encoding: %q", reply.Encoding)
 	}
 
@@ -931,6 +1741,70 @@ func TestDecorationsDirtyBuffer(t *testing.T) {
 	}
 }
 
+func TestDecorationsDelta(t *testing.T) {
+	d := tbl.Decorations[1]
+	st := tbl.Construct(t)
+
+	req := &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: d.File.Ticket},
+		References: true,
+	}
+	full, err := st.Decorations(ctx, req)
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+	// An empty fingerprint returns every reference.
+	initial, err := st.DecorationsDelta(ctx, req, nil, "")
+	testutil.Fatalf(t, "DecorationsDelta error: %v", err)
+	if err := testutil.DeepEqual(full.Reference, initial.Added); err != nil {
+		t.Fatal(err)
+	}
+	if fp := DecorationsFingerprint(full.Reference); initial.Fingerprint != fp {
+		t.Errorf("Expected fingerprint %q; found %q", fp, initial.Fingerprint)
+	}
+
+	// s/empty?/seq/
+	dirty := []byte(`(defn map [f coll]
+  (if (seq coll)
+    []
+    (cons (f (first coll)) (map f (rest coll)))))
+`)
+	req.DirtyBuffer = dirty
+	delta, err := st.DecorationsDelta(ctx, req, nil, initial.Fingerprint)
+	testutil.Fatalf(t, "DecorationsDelta error: %v", err)
+
+	if len(delta.Added) != 0 {
+		t.Errorf("Unexpected added references: %v", delta.Added)
+	}
+	if len(delta.Removed) != 1 || delta.Removed[0].TargetTicket != "kythe://core?lang=otpl#empty?" {
+		t.Errorf("Expected removed reference to empty?; found %v", delta.Removed)
+	}
+	if len(delta.Moved) != 1 {
+		t.Fatalf("Expected 1 moved reference; found %v", delta.Moved)
+	}
+	moved := delta.Moved[0]
+	if moved.Current.TargetTicket != "kythe://core?lang=otpl#cons" {
+		t.Errorf("Unexpected moved reference: %v", moved.Current)
+	}
+	if prev, cur := moved.Previous.Span.Start.ByteOffset, moved.Current.Span.Start.ByteOffset; prev-cur != 3 {
+		t.Errorf("Expected reference to move backwards by 3 bytes; moved from %d to %d", prev, cur)
+	}
+
+	// Stale fingerprints must be rejected.
+	if _, err := st.DecorationsDelta(ctx, req, nil, "stale"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for stale fingerprint; found %v", err)
+	}
+}
+
+func TestDecorationsInvalidRequest(t *testing.T) {
+	st := tbl.Construct(t)
+	_, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location: &xpb.Location{Ticket: "bad:ticket"},
+	})
+	if found := validate.FieldViolations(err); len(found) != 1 || found[0].Field != "location.ticket" {
+		t.Errorf("Unexpected violations: %v", err)
+	}
+}
+
 func TestDecorationsNotFound(t *testing.T) {
 	st := tbl.Construct(t)
 	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
@@ -982,6 +1856,175 @@ func TestDecorationsSourceText(t *testing.T) {
 	}
 }
 
+type staticContentResolver map[string]string
+
+func (r staticContentResolver) ResolveFileContent(_ context.Context, ticket string) ([]byte, string, error) {
+	text, ok := r[ticket]
+	if !ok {
+		return nil, "", nil
+	}
+	return []byte(text), "UTF-8", nil
+}
+
+func TestDecorationsContentResolver(t *testing.T) {
+	const (
+		file = "kythe://corpus?path=gen/file.go?root=out"
+		text = "package gen\nvar x int\n"
+	)
+	st := (&testTable{Decorations: []*srvpb.FileDecorations{{
+		File: &srvpb.File{Ticket: file},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: &srvpb.RawAnchor{Ticket: "kythe://corpus?lang=go?path=gen/file.go?root=out#16-17", StartOffset: 16, EndOffset: 17},
+			Kind:   "/kythe/edge/defines/binding",
+			Target: "kythe://corpus?lang=go#x",
+		}},
+	}}}).Construct(t)
+	req := &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		SourceText: true,
+		References: true,
+	}
+
+	// Without a resolver, the file has no text to locate the anchor within.
+	reply, err := st.Decorations(ctx, req)
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if len(reply.SourceText) != 0 || len(reply.Reference) != 0 {
+		t.Errorf("Unexpected reply without resolver: %v", reply)
+	}
+
+	st.ContentResolver = staticContentResolver{file: text}
+	reply, err = st.Decorations(ctx, req)
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if string(reply.SourceText) != text || reply.Encoding != "UTF-8" {
+		t.Errorf("Expected resolved source text %q (UTF-8); found %q (%s)", text, reply.SourceText, reply.Encoding)
+	}
+	if len(reply.Reference) != 1 {
+		t.Fatalf("Expected 1 reference; found %v", reply.Reference)
+	}
+	expected := span.NewNormalizer([]byte(text)).SpanOffsets(16, 17)
+	if err := testutil.DeepEqual(expected, reply.Reference[0].Span); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDecorationsLineTable(t *testing.T) {
+	file := tbl.Decorations[1].File
+	st := tbl.Construct(t)
+	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:  &xpb.Location{Ticket: file.Ticket},
+		LineTable: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+
+	lines := bytes.Split(file.Text, []byte("\n"))
+	if len(reply.LineStart) != len(lines) || len(reply.LineLength) != len(lines) {
+		t.Fatalf("Expected %d lines; found %v %v", len(lines), reply.LineStart, reply.LineLength)
+	}
+	for i, line := range lines {
+		start, length := reply.LineStart[i], reply.LineLength[i]
+		if found := file.Text[start : start+length]; !bytes.Equal(found, line) {
+			t.Errorf("Line %d: expected %q; found %q", i+1, line, found)
+		}
+	}
+}
+
+func TestDecorationsTranscodeToUTF8(t *testing.T) {
+	const (
+		file     = "kythe://corpus?path=latin1.go"
+		encoded  = "var caf\xe9 = 1\nx := caf\xe9\n"
+		utf8Text = "var café = 1\nx := café\n"
+	)
+	anchor := func(start, end int32) *srvpb.RawAnchor {
+		return &srvpb.RawAnchor{
+			Ticket:      fmt.Sprintf("kythe://corpus?lang=go?path=latin1.go#%d-%d", start, end),
+			StartOffset: start,
+			EndOffset:   end,
+		}
+	}
+	st := (&testTable{Decorations: []*srvpb.FileDecorations{{
+		File: &srvpb.File{Ticket: file, Text: []byte(encoded), Encoding: "latin1"},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: anchor(4, 8),
+			Kind:   "/kythe/edge/defines/binding",
+			Target: "kythe://corpus?lang=go#café",
+		}, {
+			Anchor: anchor(18, 22),
+			Kind:   "/kythe/edge/ref",
+			Target: "kythe://corpus?lang=go#café",
+		}},
+		Diagnostic: []*cpb.Diagnostic{{
+			Message: "unused",
+			Span:    span.NewNormalizer([]byte(encoded)).SpanOffsets(18, 22),
+		}},
+	}}}).Construct(t)
+	norm := span.NewNormalizer([]byte(utf8Text))
+
+	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:        &xpb.Location{Ticket: file},
+		SourceText:      true,
+		References:      true,
+		Diagnostics:     true,
+		TranscodeToUtf8: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if string(reply.SourceText) != utf8Text || reply.Encoding != "UTF-8" {
+		t.Errorf("Expected transcoded source text %q (UTF-8); found %q (%s)", utf8Text, reply.SourceText, reply.Encoding)
+	}
+	var spans []*cpb.Span
+	for _, r := range reply.Reference {
+		spans = append(spans, r.Span)
+	}
+	if err := testutil.DeepEqual([]*cpb.Span{norm.SpanOffsets(4, 9), norm.SpanOffsets(19, 24)}, spans); err != nil {
+		t.Errorf("Reference spans: %v", err)
+	}
+	if len(reply.Diagnostic) != 1 {
+		t.Fatalf("Expected 1 diagnostic; found %v", reply.Diagnostic)
+	} else if err := testutil.DeepEqual(norm.SpanOffsets(19, 24), reply.Diagnostic[0].Span); err != nil {
+		t.Errorf("Diagnostic span: %v", err)
+	}
+
+	// Requested spans are in terms of the UTF-8 text.
+	reply, err = st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location: &xpb.Location{
+			Ticket: file,
+			Kind:   xpb.Location_SPAN,
+			Span:   norm.SpanOffsets(14, 24),
+		},
+		SourceText:      true,
+		References:      true,
+		TranscodeToUtf8: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if string(reply.SourceText) != "x := café" {
+		t.Errorf("Unexpected source text for span: %q", reply.SourceText)
+	}
+	if len(reply.Reference) != 1 {
+		t.Fatalf("Expected 1 reference within span; found %v", reply.Reference)
+	} else if err := testutil.DeepEqual(norm.SpanOffsets(19, 24), reply.Reference[0].Span); err != nil {
+		t.Errorf("Reference span: %v", err)
+	}
+
+	// Without transcoding, the text is served as stored.
+	reply, err = st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		SourceText: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if string(reply.SourceText) != encoded || reply.Encoding != "latin1" {
+		t.Errorf("Expected stored source text %q (latin1); found %q (%s)", encoded, reply.SourceText, reply.Encoding)
+	}
+
+	st = (&testTable{Decorations: []*srvpb.FileDecorations{{
+		File: &srvpb.File{Ticket: file, Text: []byte(encoded), Encoding: "unknown-encoding"},
+	}}}).Construct(t)
+	if _, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:        &xpb.Location{Ticket: file},
+		TranscodeToUtf8: true,
+	}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition error for unknown encoding; found %v", err)
+	}
+}
+
 func TestDecorationsGeneratedBy(t *testing.T) {
 	st := tbl.Construct(t)
 	reply, err := st.Decorations(ctx, &xpb.DecorationsRequest{
@@ -1186,6 +2229,97 @@ func TestCrossReferencesNone(t *testing.T) {
 	}
 }
 
+func TestCrossReferencesMissingTickets(t *testing.T) {
+	const (
+		found   = "kythe://someCorpus?lang=otpl#signature"
+		missing = "kythe://someCorpus?lang=otpl#sig2"
+	)
+	st := tbl.Construct(t)
+	req := &xpb.CrossReferencesRequest{
+		Ticket:        []string{found, missing},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	}
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if _, ok := reply.CrossReferences[found]; !ok {
+		t.Errorf("Missing expected CrossReferences; found: %v", reply)
+	}
+	if err := testutil.DeepEqual([]string{missing}, reply.MissingTicket); err != nil {
+		t.Error(err)
+	}
+
+	req.Strict = true
+	if _, err := st.CrossReferences(ctx, req); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error for strict request; found %v", err)
+	}
+	req.Ticket = []string{found}
+	_, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+}
+
+func TestCrossReferencesCountOnly(t *testing.T) {
+	st := tbl.Construct(t)
+	req := &xpb.CrossReferencesRequest{
+		Ticket: []string{
+			"kythe://someCorpus?lang=otpl#signature",
+			"kythe://someCorpus?lang=otpl#withCallers",
+			"kythe://someCorpus?lang=otpl#sig2",
+		},
+		DefinitionKind:  xpb.CrossReferencesRequest_ALL_DEFINITIONS,
+		DeclarationKind: xpb.CrossReferencesRequest_ALL_DECLARATIONS,
+		ReferenceKind:   xpb.CrossReferencesRequest_ALL_REFERENCES,
+		CallerKind:      xpb.CrossReferencesRequest_DIRECT_CALLERS,
+		TotalsQuality:   xpb.CrossReferencesRequest_PRECISE_TOTALS,
+	}
+	full, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	req.CountOnly = true
+	req.TotalsQuality = xpb.CrossReferencesRequest_APPROXIMATE_TOTALS
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	expected := &xpb.CrossReferencesReply{
+		Total:         full.Total,
+		Filtered:      full.Filtered,
+		MissingTicket: []string{"kythe://someCorpus?lang=otpl#sig2"},
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+	if full.Total.References == 0 || full.Total.Callers == 0 {
+		t.Errorf("Expected non-zero totals; found %v", full.Total)
+	}
+}
+
+func TestCrossReferencesCountOnlyPageBytes(t *testing.T) {
+	refSets := make([]*srvpb.PagedCrossReferences, len(tbl.RefSets))
+	for i, cr := range tbl.RefSets {
+		cr = proto.Clone(cr).(*srvpb.PagedCrossReferences)
+		for _, idx := range cr.PageIndex {
+			idx.UncompressedSize = 100
+		}
+		refSets[i] = cr
+	}
+	st := (&testTable{
+		Nodes:       tbl.Nodes,
+		Decorations: tbl.Decorations,
+		RefSets:     refSets,
+		RefPages:    tbl.RefPages,
+	}).Construct(t)
+
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{"kythe://someCorpus?lang=otpl#signature"},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		CountOnly:     true,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	// Only the single references page is counted; inline groups are not.
+	if n := reply.Total.GetUncompressedPageBytes(); n != 100 {
+		t.Errorf("Expected 100 uncompressed page bytes; found %d", n)
+	}
+}
+
 func TestCrossReferences(t *testing.T) {
 	ticket := "kythe://someCorpus?lang=otpl#signature"
 
@@ -1221,7 +2355,8 @@ func TestCrossReferences(t *testing.T) {
 					ColumnOffset: 28,
 				},
 			},
-			Snippet: "これはいくつかのテキストです",
+			Snippet:          "これはいくつかのテキストです",
+			SnippetHighlight: &xpb.Anchor_SnippetRange{End: 4},
 		}}, {Anchor: &xpb.Anchor{
 			Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
 			Kind:   "/kythe/edge/ref",
@@ -1251,7 +2386,8 @@ func TestCrossReferences(t *testing.T) {
 					ColumnOffset: 16,
 				},
 			},
-			Snippet: "some random text",
+			Snippet:          "some random text",
+			SnippetHighlight: &xpb.Anchor_SnippetRange{Start: 15, End: 16},
 		}}},
 
 		Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
@@ -1349,7 +2485,8 @@ func TestCrossReferencesReadAhead(t *testing.T) {
 					ColumnOffset: 28,
 				},
 			},
-			Snippet: "これはいくつかのテキストです",
+			Snippet:          "これはいくつかのテキストです",
+			SnippetHighlight: &xpb.Anchor_SnippetRange{End: 4},
 		}}, {Anchor: &xpb.Anchor{
 			Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
 			Kind:   "/kythe/edge/ref",
@@ -1379,7 +2516,8 @@ func TestCrossReferencesReadAhead(t *testing.T) {
 					ColumnOffset: 16,
 				},
 			},
-			Snippet: "some random text",
+			Snippet:          "some random text",
+			SnippetHighlight: &xpb.Anchor_SnippetRange{Start: 15, End: 16},
 		}}},
 
 		Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
@@ -1644,7 +2782,8 @@ func TestCrossReferencesPatching(t *testing.T) {
 					ColumnOffset: 29,
 				},
 			},
-			Snippet: "これはいくつかのテキストです",
+			Snippet:          "これはいくつかのテキストです",
+			SnippetHighlight: &xpb.Anchor_SnippetRange{End: 4},
 		}}, {Anchor: &xpb.Anchor{
 			Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
 			Kind:   "/kythe/edge/ref",
@@ -1675,7 +2814,8 @@ func TestCrossReferencesPatching(t *testing.T) {
 					ColumnOffset: 17,
 				},
 			},
-			Snippet: "some random text",
+			Snippet:          "some random text",
+			SnippetHighlight: &xpb.Anchor_SnippetRange{Start: 15, End: 16},
 		}}},
 
 		Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
@@ -1779,7 +2919,8 @@ filter: {
 					ColumnOffset: 16,
 				},
 			},
-			Snippet: "some random text",
+			Snippet:          "some random text",
+			SnippetHighlight: &xpb.Anchor_SnippetRange{Start: 15, End: 16},
 		}}},
 
 		Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
@@ -1839,42 +2980,427 @@ filter: {
 	}
 }
 
-func TestCrossReferences_BuildConfigRefs(t *testing.T) {
-	ticket := "kythe://someCorpus?lang=otpl#signature"
+func TestCrossReferencesAnchorTextPattern(t *testing.T) {
+	const (
+		ticket = "kythe://someCorpus?lang=otpl#spelled"
+		file   = "kythe://someCorpus?path=some/file"
+	)
+	anchor := func(start, end int32, text string) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{
+			Ticket: fmt.Sprintf("kythe://someCorpus?lang=otpl?path=some/file#%d-%d", start, end),
+			Kind:   "/kythe/edge/ref",
+			Text:   text,
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: start},
+				End:   &cpb.Point{ByteOffset: end},
+			},
+		}
+	}
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("foo(); bar(); foo();")},
+		}},
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor(0, 3, ""), anchor(7, 10, ""), anchor(14, 17, "")},
+			}},
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+				PageKey: "spelledPage",
+				Kind:    "/kythe/edge/ref",
+				Count:   2,
+			}},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey: "spelledPage",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor(7, 10, ""), anchor(20, 23, "foo")},
+			},
+		}},
+	}).Construct(t)
 
-	st := tbl.Construct(t)
-	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+	req := &xpb.CrossReferencesRequest{
+		Ticket:            []string{ticket},
+		ReferenceKind:     xpb.CrossReferencesRequest_ALL_REFERENCES,
+		AnchorTextPattern: "^fo+$",
+		PageSize:          2,
+	}
+	var pages [][]string
+	for {
+		reply, err := st.CrossReferences(ctx, req)
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+		var page []string
+		for _, ra := range reply.CrossReferences[ticket].GetReference() {
+			page = append(page, ra.Anchor.Ticket)
+		}
+		pages = append(pages, page)
+		if reply.NextPageToken == "" {
+			if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{References: 3}, reply.Total); err != nil {
+				t.Errorf("Unexpected totals: %v", err)
+			}
+			if err := testutil.DeepEqual(int64(2), reply.Filtered.GetReferences()); err != nil {
+				t.Errorf("Unexpected filtered references: %v", err)
+			}
+			break
+		} else if len(pages) > 2 {
+			t.Fatalf("Too many pages: %v", pages)
+		}
+		req.PageToken = reply.NextPageToken
+	}
+	if err := testutil.DeepEqual([][]string{{
+		"kythe://someCorpus?lang=otpl?path=some/file#0-3",
+		"kythe://someCorpus?lang=otpl?path=some/file#14-17",
+	}, {
+		"kythe://someCorpus?lang=otpl?path=some/file#20-23",
+	}}, pages); err != nil {
+		t.Errorf("Unexpected references per page: %v", err)
+	}
+
+	req.PageToken = ""
+	req.AnchorTextPattern = "(foo"
+	if _, err := st.CrossReferences(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for invalid anchor_text_pattern; found %v", err)
+	}
+}
+
+type staticEdges struct {
+	graph.Service
+	reply *gpb.EdgesReply
+}
+
+func (s staticEdges) Edges(context.Context, *gpb.EdgesRequest) (*gpb.EdgesReply, error) {
+	return s.reply, nil
+}
+
+func TestCrossReferencesEdgeFallback(t *testing.T) {
+	const (
+		ticket = "kythe://someCorpus?lang=otpl#missing"
+		file   = "kythe://someCorpus?path=some/file"
+		def    = "kythe://someCorpus?lang=otpl?path=some/file#def"
+		ref    = "kythe://someCorpus?lang=otpl?path=some/file#ref"
+	)
+	anchorInfo := func(start, end string) *cpb.NodeInfo {
+		return &cpb.NodeInfo{Facts: map[string][]byte{
+			facts.NodeKind:    []byte("anchor"),
+			facts.AnchorStart: []byte(start),
+			facts.AnchorEnd:   []byte(end),
+		}}
+	}
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("foo();\nbar();")},
+		}},
+	}).Construct(t)
+	st.EdgeFallback = staticEdges{reply: &gpb.EdgesReply{
+		EdgeSets: map[string]*gpb.EdgeSet{
+			ticket: {Groups: map[string]*gpb.EdgeSet_Group{
+				"%/kythe/edge/defines/binding": {Edge: []*gpb.EdgeSet_Group_Edge{{TargetTicket: def}}},
+				"%/kythe/edge/ref":             {Edge: []*gpb.EdgeSet_Group_Edge{{TargetTicket: ref}}},
+				"%/kythe/edge/childof":         {Edge: []*gpb.EdgeSet_Group_Edge{{TargetTicket: "kythe:#child"}}},
+				"/kythe/edge/childof":          {Edge: []*gpb.EdgeSet_Group_Edge{{TargetTicket: "kythe:#parent"}}},
+			}},
+		},
+		Nodes: map[string]*cpb.NodeInfo{
+			def:            anchorInfo("0", "3"),
+			ref:            anchorInfo("7", "10"),
+			"kythe:#child": {Facts: map[string][]byte{facts.NodeKind: []byte("record")}},
+		},
+	}}
+
+	req := &xpb.CrossReferencesRequest{
 		Ticket:         []string{ticket},
 		DefinitionKind: xpb.CrossReferencesRequest_ALL_DEFINITIONS,
 		ReferenceKind:  xpb.CrossReferencesRequest_ALL_REFERENCES,
-		Snippets:       xpb.SnippetsKind_DEFAULT,
-		BuildConfig:    []string{"testConfig"},
-	})
+		AnchorText:     true,
+	}
+	reply, err := st.CrossReferences(ctx, req)
 	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if err := testutil.DeepEqual([]string{ticket}, reply.MissingTicket); err != nil {
+		t.Errorf("Unexpected missing tickets without edge_fallback: %v", err)
+	}
 
+	req.EdgeFallback = true
+	reply, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
 	expected := &xpb.CrossReferencesReply_CrossReferenceSet{
 		Ticket: ticket,
-
 		Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
-			Ticket:      "kythe://c?lang=otpl?path=/a/path#27-33",
-			Kind:        "/kythe/edge/defines/binding",
-			Parent:      "kythe://c?path=/a/path",
-			BuildConfig: "testConfig",
-
+			Ticket: def,
+			Kind:   "/kythe/edge/defines/binding",
+			Parent: file,
+			Text:   "foo",
 			Span: &cpb.Span{
-				Start: &cpb.Point{
-					ByteOffset:   27,
-					LineNumber:   2,
-					ColumnOffset: 10,
-				},
-				End: &cpb.Point{
-					ByteOffset:   33,
-					LineNumber:   3,
-					ColumnOffset: 5,
-				},
+				Start: &cpb.Point{LineNumber: 1},
+				End:   &cpb.Point{ByteOffset: 3, LineNumber: 1, ColumnOffset: 3},
 			},
-
-			SnippetSpan: &cpb.Span{
+		}}},
+		Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
+			Ticket: ref,
+			Kind:   "/kythe/edge/ref",
+			Parent: file,
+			Text:   "bar",
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: 7, LineNumber: 2},
+				End:   &cpb.Point{ByteOffset: 10, LineNumber: 2, ColumnOffset: 3},
+			},
+		}}},
+	}
+	if err := testutil.DeepEqual(expected, reply.CrossReferences[ticket]); err != nil {
+		t.Errorf("Unexpected cross-references: %v", err)
+	}
+	if err := testutil.DeepEqual([]string{ticket}, reply.SlowPathTicket); err != nil {
+		t.Errorf("Unexpected slow path tickets: %v", err)
+	}
+	if len(reply.MissingTicket) != 0 {
+		t.Errorf("Unexpected missing tickets: %v", reply.MissingTicket)
+	}
+}
+
+func TestCrossReferencesExcludeGenerated(t *testing.T) {
+	const ticket = "kythe://someCorpus?lang=otpl#generated"
+	anchor := func(path string, generated bool) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{
+			Ticket:   "kythe://someCorpus?lang=otpl?path=" + path + "#0-3",
+			Kind:     "/kythe/edge/ref",
+			Text:     "foo",
+			FileInfo: &srvpb.FileInfo{Generated: generated},
+		}
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("a", false), anchor("gen/a", true)},
+			}},
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+				PageKey: "generatedPage",
+				Kind:    "/kythe/edge/ref",
+				Count:   2,
+			}},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey: "generatedPage",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("gen/c", true), anchor("b", false)},
+			},
+		}},
+	}).Construct(t)
+
+	req := &xpb.CrossReferencesRequest{
+		Ticket:           []string{ticket},
+		ReferenceKind:    xpb.CrossReferencesRequest_ALL_REFERENCES,
+		ExcludeGenerated: true,
+	}
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	var refs []string
+	for _, ra := range reply.CrossReferences[ticket].GetReference() {
+		refs = append(refs, ra.Anchor.Ticket)
+	}
+	if err := testutil.DeepEqual([]string{
+		"kythe://someCorpus?lang=otpl?path=a#0-3",
+		"kythe://someCorpus?lang=otpl?path=b#0-3",
+	}, refs); err != nil {
+		t.Errorf("Unexpected references: %v", err)
+	}
+	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{References: 2}, reply.Total); err != nil {
+		t.Errorf("Unexpected totals: %v", err)
+	}
+	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{References: 2}, reply.Filtered); err != nil {
+		t.Errorf("Unexpected filtered totals: %v", err)
+	}
+}
+
+func TestCrossReferencesCommitTime(t *testing.T) {
+	const ticket = "kythe://someCorpus?lang=otpl#blamed"
+	day := func(d int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2020, time.January, d, 0, 0, 0, 0, time.UTC))
+	}
+	anchor := func(path string, committed *timestamppb.Timestamp) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{
+			Ticket:     "kythe://someCorpus?lang=otpl?path=" + path + "#0-3",
+			Kind:       "/kythe/edge/ref",
+			CommitTime: committed,
+		}
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("old", day(1)), anchor("unknown", nil)},
+			}},
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+				PageKey: "blamedPage",
+				Kind:    "/kythe/edge/ref",
+				Count:   2,
+			}},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey: "blamedPage",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("mid", day(10)), anchor("new", day(20))},
+			},
+		}},
+	}).Construct(t)
+
+	tests := []struct {
+		after, before *timestamppb.Timestamp
+		expected      []string
+	}{
+		{nil, day(10), []string{"old"}},
+		{day(10), nil, []string{"mid", "new"}},
+		{day(5), day(15), []string{"mid"}},
+	}
+	for _, test := range tests {
+		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:          []string{ticket},
+			ReferenceKind:   xpb.CrossReferencesRequest_ALL_REFERENCES,
+			CommittedAfter:  test.after,
+			CommittedBefore: test.before,
+		})
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+		var refs []string
+		for _, ra := range reply.CrossReferences[ticket].GetReference() {
+			refs = append(refs, strings.TrimSuffix(strings.TrimPrefix(ra.Anchor.Ticket, "kythe://someCorpus?lang=otpl?path="), "#0-3"))
+			if ra.Anchor.CommitTime == nil {
+				t.Errorf("Missing commit time: %v", ra.Anchor)
+			}
+		}
+		if err := testutil.DeepEqual(test.expected, refs); err != nil {
+			t.Errorf("[%v, %v): unexpected references: %v", test.after, test.before, err)
+		}
+	}
+
+	invalid := &timestamppb.Timestamp{Nanos: -1}
+	for _, test := range []struct {
+		after, before *timestamppb.Timestamp
+		field         string
+	}{
+		{day(10), day(10), "committed_before"}, // empty range
+		{invalid, day(10), "committed_after"},
+		{day(10), invalid, "committed_before"},
+	} {
+		_, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:          []string{ticket},
+			CommittedAfter:  test.after,
+			CommittedBefore: test.before,
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("[%v, %v): expected InvalidArgument; found %v", test.after, test.before, err)
+		} else if vs := validate.FieldViolations(err); len(vs) != 1 || vs[0].GetField() != test.field {
+			t.Errorf("[%v, %v): expected a single %s violation; found %v", test.after, test.before, test.field, vs)
+		}
+	}
+}
+
+func TestCrossReferencesGroupByBuildConfig(t *testing.T) {
+	const ticket = "kythe://someCorpus?lang=otpl#configured"
+	anchor := func(name, kind, config string) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{
+			Ticket:             "kythe://someCorpus?lang=otpl?path=" + name + "#0-3",
+			Kind:               kind,
+			BuildConfiguration: config,
+		}
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:        "/kythe/edge/defines/binding",
+				BuildConfig: "windows",
+				Anchor:      []*srvpb.ExpandedAnchor{anchor("win", "/kythe/edge/defines/binding", "windows")},
+			}, {
+				Kind:        "/kythe/edge/ref",
+				BuildConfig: "windows",
+				Anchor:      []*srvpb.ExpandedAnchor{anchor("win", "/kythe/edge/ref", "windows")},
+			}, {
+				Kind:        "/kythe/edge/ref",
+				BuildConfig: "linux",
+				Anchor:      []*srvpb.ExpandedAnchor{anchor("linux", "/kythe/edge/ref", "linux")},
+			}},
+		}},
+	}).Construct(t)
+
+	req := &xpb.CrossReferencesRequest{
+		Ticket:             []string{ticket},
+		DefinitionKind:     xpb.CrossReferencesRequest_ALL_DEFINITIONS,
+		ReferenceKind:      xpb.CrossReferencesRequest_ALL_REFERENCES,
+		GroupByBuildConfig: true,
+		AnchorMask:         &fieldmaskpb.FieldMask{Paths: []string{"ticket"}},
+	}
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	ra := func(name string) []*xpb.CrossReferencesReply_RelatedAnchor {
+		return []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
+			Ticket: "kythe://someCorpus?lang=otpl?path=" + name + "#0-3",
+		}}}
+	}
+	expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+		Ticket: ticket,
+		BuildConfigGroup: []*xpb.CrossReferencesReply_BuildConfigGroup{{
+			BuildConfig: "linux",
+			Reference:   ra("linux"),
+		}, {
+			BuildConfig: "windows",
+			Definition:  ra("win"),
+			Reference:   ra("win"),
+		}},
+	}
+	if err := testutil.DeepEqual(expected, reply.CrossReferences[ticket]); err != nil {
+		t.Error(err)
+	}
+
+	req.GroupReferencesByKind = true
+	if _, err := st.CrossReferences(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument combining groupings; found %v", err)
+	}
+}
+
+func TestCrossReferences_BuildConfigRefs(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#signature"
+
+	st := tbl.Construct(t)
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:         []string{ticket},
+		DefinitionKind: xpb.CrossReferencesRequest_ALL_DEFINITIONS,
+		ReferenceKind:  xpb.CrossReferencesRequest_ALL_REFERENCES,
+		Snippets:       xpb.SnippetsKind_DEFAULT,
+		BuildConfig:    []string{"testConfig"},
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+		Ticket: ticket,
+
+		Definition: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
+			Ticket:      "kythe://c?lang=otpl?path=/a/path#27-33",
+			Kind:        "/kythe/edge/defines/binding",
+			Parent:      "kythe://c?path=/a/path",
+			BuildConfig: "testConfig",
+
+			Span: &cpb.Span{
+				Start: &cpb.Point{
+					ByteOffset:   27,
+					LineNumber:   2,
+					ColumnOffset: 10,
+				},
+				End: &cpb.Point{
+					ByteOffset:   33,
+					LineNumber:   3,
+					ColumnOffset: 5,
+				},
+			},
+
+			SnippetSpan: &cpb.Span{
 				Start: &cpb.Point{
 					ByteOffset: 17,
 					LineNumber: 2,
@@ -1964,429 +3490,3337 @@ func TestCrossReferencesRelatedNodes(t *testing.T) {
 	}
 }
 
-func TestCrossReferencesMarkedSource(t *testing.T) {
-	const ticket = "kythe://someCorpus?lang=otpl#withRelated"
+func TestCrossReferencesRelatedNodeOrder(t *testing.T) {
+	const ticket = "kythe://c?lang=l#fn"
+	related := func(ordinal int32, tickets ...string) []*srvpb.PagedCrossReferences_RelatedNode {
+		var rns []*srvpb.PagedCrossReferences_RelatedNode
+		for _, t := range tickets {
+			rns = append(rns, &srvpb.PagedCrossReferences_RelatedNode{Ordinal: ordinal, Node: &srvpb.Node{Ticket: t}})
+		}
+		return rns
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{
+				{Kind: "/kythe/edge/param", RelatedNode: related(1, "kythe:#p1")},
+				{Kind: "/kythe/edge/extends", RelatedNode: related(0, "kythe:#e2", "kythe:#e1")},
+				{Kind: "/kythe/edge/param", RelatedNode: related(0, "kythe:#p0b", "kythe:#p0a")},
+			},
+		}},
+	}).Construct(t)
 
-	st := tbl.Construct(t)
 	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
 		Ticket: []string{ticket},
 		Filter: []string{"**"},
 	})
 	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
 
-	expected := &xpb.CrossReferencesReply_CrossReferenceSet{
-		Ticket: ticket,
-		MarkedSource: &cpb.MarkedSource{
-			Kind:    cpb.MarkedSource_IDENTIFIER,
-			PreText: "id",
-		},
+	var found [][2]string
+	for _, rn := range reply.CrossReferences[ticket].GetRelatedNode() {
+		found = append(found, [2]string{rn.RelationKind, rn.Ticket})
+	}
+	expected := [][2]string{
+		{"/kythe/edge/extends", "kythe:#e1"},
+		{"/kythe/edge/extends", "kythe:#e2"},
+		{"/kythe/edge/param", "kythe:#p0a"},
+		{"/kythe/edge/param", "kythe:#p0b"},
+		{"/kythe/edge/param", "kythe:#p1"},
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Error(err)
+	}
+}
 
-		RelatedNode: []*xpb.CrossReferencesReply_RelatedNode{{
-			Ticket:       "kythe:#someRelatedNode",
-			RelationKind: "/kythe/edge/extends",
-		}, {
-			Ticket:       "kythe:#someParameter0",
-			RelationKind: "/kythe/edge/param",
-			Ordinal:      0,
-		}, {
-			Ticket:       "kythe:#someParameter1",
-			RelationKind: "/kythe/edge/param",
-			Ordinal:      1,
+func TestCrossReferencesRelatedNodePageSize(t *testing.T) {
+	const ticket = "kythe://someCorpus?lang=otpl#paged"
+	var anchors []*srvpb.ExpandedAnchor
+	var related []*srvpb.PagedCrossReferences_RelatedNode
+	for i := 0; i < 3; i++ {
+		anchors = append(anchors, &srvpb.ExpandedAnchor{
+			Ticket: fmt.Sprintf("kythe://someCorpus?lang=otpl?path=some/file#%d-%d", i, i+1),
+		})
+		related = append(related, &srvpb.PagedCrossReferences_RelatedNode{
+			Node: &srvpb.Node{Ticket: fmt.Sprintf("kythe:#related%d", i)},
+		})
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "%/kythe/edge/ref",
+				Anchor: anchors,
+			}, {
+				Kind:        "/kythe/edge/extends",
+				RelatedNode: related,
+			}},
 		}},
+	}).Construct(t)
+
+	req := &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		Filter:        []string{"**"},
+		PageSize:      2,
 	}
 
-	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
-		RelatedNodesByRelation: map[string]int64{
-			"/kythe/edge/extends": 1,
-			"/kythe/edge/param":   2,
-		},
-	}, reply.Total); err != nil {
-		t.Error(err)
+	// By default, anchors starve the related nodes of the first page.
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if crs := reply.CrossReferences[ticket]; len(crs.GetReference()) != 2 || len(crs.GetRelatedNode()) != 0 {
+		t.Errorf("Expected 2 references and 0 related nodes; found %v", crs)
 	}
 
-	xr := reply.CrossReferences[ticket]
-	if xr == nil {
-		t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
-	} else if err := testutil.DeepEqual(expected, xr); err != nil {
-		t.Fatal(err)
+	req.RelatedNodePageSize = 1
+	var pages [][2]int
+	for {
+		reply, err := st.CrossReferences(ctx, req)
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+		crs := reply.CrossReferences[ticket]
+		pages = append(pages, [2]int{len(crs.GetReference()), len(crs.GetRelatedNode())})
+		if reply.NextPageToken == "" {
+			break
+		} else if len(pages) > 3 {
+			t.Fatalf("Too many pages: %v", pages)
+		}
+		req.PageToken = reply.NextPageToken
+	}
+	if err := testutil.DeepEqual([][2]int{{2, 1}, {1, 1}, {0, 1}}, pages); err != nil {
+		t.Errorf("Unexpected (references, related nodes) per page: %v", err)
+	}
+
+	req.RelatedNodePageSize = -1
+	if _, err := st.CrossReferences(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for negative related_node_page_size; found %v", err)
 	}
 }
 
-func TestCrossReferencesMerge(t *testing.T) {
-	ticket := "kythe://someCorpus?lang=otpl#withMerge"
+func TestCrossReferencesGroupRelatedNodeOrdinals(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#withRelated"
 
 	st := tbl.Construct(t)
 	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
-		Ticket:     []string{ticket},
-		CallerKind: xpb.CrossReferencesRequest_DIRECT_CALLERS,
-		Filter:     []string{"**"},
+		Ticket: []string{ticket},
+		Filter: []string{"**"},
+
+		GroupRelatedNodeOrdinals: true,
 	})
 	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
 
-	expected := &xpb.CrossReferencesReply_CrossReferenceSet{
-		Ticket: ticket,
-		MarkedSource: &cpb.MarkedSource{
-			Kind:    cpb.MarkedSource_IDENTIFIER,
-			PreText: "id",
-		},
+	expected := []*xpb.CrossReferencesReply_RelatedNodeList{{
+		RelationKind: "/kythe/edge/param",
+		Ticket:       []string{"kythe:#someParameter0", "kythe:#someParameter1"},
+	}}
+	if err := testutil.DeepEqual(expected, reply.CrossReferences[ticket].GetRelatedNodeList()); err != nil {
+		t.Error(err)
+	}
+}
 
-		Caller: []*xpb.CrossReferencesReply_RelatedAnchor{{
-			Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=someFile#someCallerAnchor",
-				Parent: "kythe:?path=someFile",
-				Span:   arbitrarySpan,
-			},
-			Ticket: "kythe:#someCaller",
-			MarkedSource: &cpb.MarkedSource{
-				Kind:    cpb.MarkedSource_IDENTIFIER,
-				PreText: "id",
-			},
-			Site: []*xpb.Anchor{{
-				Ticket: "kythe:?path=someFile#someCallsiteAnchor",
-				Parent: "kythe:?path=someFile",
+func TestCrossReferencesCanonicalKinds(t *testing.T) {
+	const (
+		fn   = "kythe:#legacyFunc"
+		file = "kythe:?path=legacy"
+	)
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("f(x)\n")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: file + "#0-1", StartOffset: 0, EndOffset: 1},
+				Kind:   "/kythe/edge/param.0",
+				Target: fn,
 			}},
 		}},
-		RelatedNode: []*xpb.CrossReferencesReply_RelatedNode{{
-			Ticket:       "kythe:#someRelatedNode",
-			RelationKind: "/kythe/edge/extends",
-		}, {
-			Ticket:       "kythe:#someParameter0",
-			RelationKind: "/kythe/edge/param",
-			Ordinal:      0,
-		}, {
-			Ticket:       "kythe:#someParameter1",
-			RelationKind: "/kythe/edge/param",
-			Ordinal:      1,
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: fn,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind: "/kythe/edge/param.1",
+				RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{
+					Node: &srvpb.Node{Ticket: "kythe:#legacyParam1"},
+				}},
+			}, {
+				Kind: "/kythe/edge/param.0",
+				RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{
+					Node: &srvpb.Node{Ticket: "kythe:#legacyParam0"},
+				}},
+			}},
 		}},
-	}
+	}).Construct(t)
 
-	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
-		Callers: 1,
-		RelatedNodesByRelation: map[string]int64{
-			"/kythe/edge/extends": 1,
-			"/kythe/edge/param":   2,
-		},
-	}, reply.Total); err != nil {
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:                   []string{fn},
+		Filter:                   []string{"**"},
+		CanonicalKinds:           true,
+		GroupRelatedNodeOrdinals: true,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	expected := []*xpb.CrossReferencesReply_RelatedNodeList{{
+		RelationKind: "/kythe/edge/param",
+		Ticket:       []string{"kythe:#legacyParam0", "kythe:#legacyParam1"},
+	}}
+	if err := testutil.DeepEqual(expected, reply.CrossReferences[fn].GetRelatedNodeList()); err != nil {
 		t.Error(err)
 	}
-
-	xr := reply.CrossReferences[ticket]
-	if xr == nil {
-		t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
-	} else if err := testutil.DeepEqual(expected, xr); err != nil {
-		t.Fatal(err)
+	if err := testutil.DeepEqual(map[string]int64{"/kythe/edge/param": 2}, reply.Total.RelatedNodesByRelation); err != nil {
+		t.Error(err)
+	}
+
+	decor, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:       &xpb.Location{Ticket: file},
+		References:     true,
+		CanonicalKinds: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if len(decor.Reference) != 1 || decor.Reference[0].Kind != "/kythe/edge/param" {
+		t.Errorf("Unexpected references: %v", decor.Reference)
+	}
+}
+
+func TestCrossReferencesDocumentation(t *testing.T) {
+	const (
+		documented = "kythe:#xrefDocumented"
+		docBy      = "kythe:#xrefDocumentedBy"
+		plain      = "kythe:#xrefPlain"
+	)
+	link := &cpb.Link{Definition: []string{plain}}
+	refSet := func(ticket string) *srvpb.PagedCrossReferences {
+		return &srvpb.PagedCrossReferences{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe:?path=file#ref", Kind: "/kythe/edge/ref"}},
+			}},
+		}
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{refSet(documented), refSet(docBy), refSet(plain)},
+		Documents: []*srvpb.Document{{
+			Ticket:  documented,
+			RawText: "see [plain]",
+			Link:    []*cpb.Link{link},
+		}, {
+			Ticket:       docBy,
+			DocumentedBy: documented,
+		}},
+	}).Construct(t)
+
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{documented, docBy, plain},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		Documentation: true,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	expected := &xpb.Printable{RawText: "see [plain]", Link: []*cpb.Link{link}}
+	for _, ticket := range []string{documented, docBy} {
+		if err := testutil.DeepEqual(expected, reply.CrossReferences[ticket].GetDocumentation()); err != nil {
+			t.Errorf("%s: %v", ticket, err)
+		}
+	}
+	if doc := reply.CrossReferences[plain].GetDocumentation(); doc != nil {
+		t.Errorf("Unexpected documentation for %s: %v", plain, doc)
+	}
+}
+
+func TestCrossReferencesTicketAlias(t *testing.T) {
+	const (
+		current  = "kythe://someCorpus?lang=otpl#signature"
+		old      = "kythe://someCorpus?lang=otpl#old"
+		dangling = "kythe://someCorpus?lang=otpl#dangling"
+		unknown  = "kythe://someCorpus?lang=otpl#unknown"
+	)
+	aliased := *tbl
+	aliased.Aliases = []*srvpb.TicketAlias{
+		{Alias: old, Ticket: current},
+		{Alias: dangling, Ticket: "kythe://someCorpus?lang=otpl#gone"},
+	}
+	st := aliased.Construct(t)
+
+	req := &xpb.CrossReferencesRequest{
+		Ticket:         []string{current},
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		ReferenceKind:  xpb.CrossReferencesRequest_ALL_REFERENCES,
+	}
+	expected, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	expected.RedirectedTicket = map[string]string{old: current}
+
+	tests := []struct {
+		tickets []string
+		missing []string
+	}{
+		{[]string{old}, nil},
+		{[]string{old, current}, nil},
+		{[]string{old, dangling, unknown}, []string{dangling, unknown}},
+	}
+	for _, test := range tests {
+		req.Ticket = test.tickets
+		reply, err := st.CrossReferences(ctx, req)
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+		expected.MissingTicket = test.missing
+		if err := testutil.DeepEqual(expected, reply); err != nil {
+			t.Errorf("CrossReferences(%v): %v", test.tickets, err)
+		}
+	}
+}
+
+func TestCrossReferencesAnchorMask(t *testing.T) {
+	st := tbl.Construct(t)
+	req := &xpb.CrossReferencesRequest{
+		Ticket:         []string{"kythe://someCorpus?lang=otpl#signature"},
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		ReferenceKind:  xpb.CrossReferencesRequest_ALL_REFERENCES,
+		Snippets:       xpb.SnippetsKind_DEFAULT,
+	}
+	expected, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	for _, set := range expected.CrossReferences {
+		for _, as := range [][]*xpb.CrossReferencesReply_RelatedAnchor{set.Definition, set.Reference} {
+			for _, a := range as {
+				a.Anchor = &xpb.Anchor{Ticket: a.Anchor.Ticket, Span: a.Anchor.Span}
+			}
+		}
+	}
+
+	req.AnchorMask = &fieldmaskpb.FieldMask{Paths: []string{"span"}}
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+
+	req.AnchorMask = &fieldmaskpb.FieldMask{Paths: []string{"target_ticket"}}
+	if _, err := st.CrossReferences(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error for invalid mask; found %v", err)
+	}
+}
+
+func TestCrossReferencesClassification(t *testing.T) {
+	const (
+		complete   = "kythe:#complete"
+		incomplete = "kythe:#incomplete"
+	)
+	group := func(kind, anchor string) *srvpb.PagedCrossReferences_Group {
+		return &srvpb.PagedCrossReferences_Group{
+			Kind:   kind,
+			Anchor: []*srvpb.ExpandedAnchor{{Ticket: anchor, Kind: kind}},
+		}
+	}
+	tbl := &testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: complete,
+			Group: []*srvpb.PagedCrossReferences_Group{
+				group("%/kythe/edge/defines", "kythe:?path=a#def"),
+				group("%/kythe/edge/defines/binding", "kythe:?path=a#binding"),
+				group("%/kythe/edge/completes/uniquely", "kythe:?path=a#completes"),
+				group("%/kythe/edge/ref", "kythe:?path=a#ref"),
+				group("%/kythe/edge/ref/call", "kythe:?path=a#call"),
+			},
+		}, {
+			SourceTicket: incomplete,
+			Incomplete:   true,
+			Group: []*srvpb.PagedCrossReferences_Group{
+				group("%/kythe/edge/defines/binding", "kythe:?path=b#decl"),
+			},
+		}},
+	}
+	req := &xpb.CrossReferencesRequest{
+		Ticket:          []string{complete, incomplete},
+		DefinitionKind:  xpb.CrossReferencesRequest_ALL_DEFINITIONS,
+		DeclarationKind: xpb.CrossReferencesRequest_ALL_DECLARATIONS,
+		ReferenceKind:   xpb.CrossReferencesRequest_ALL_REFERENCES,
+		AnchorMask:      &fieldmaskpb.FieldMask{Paths: []string{"classification"}},
+	}
+	classes := func(reply *xpb.CrossReferencesReply) map[string]xpb.Anchor_Classification {
+		res := make(map[string]xpb.Anchor_Classification)
+		for _, crs := range reply.CrossReferences {
+			for _, as := range [][]*xpb.CrossReferencesReply_RelatedAnchor{crs.Definition, crs.Declaration, crs.Reference} {
+				for _, ra := range as {
+					res[ra.Anchor.Ticket] = ra.Anchor.Classification
+				}
+			}
+		}
+		return res
+	}
+
+	st := tbl.Construct(t)
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if err := testutil.DeepEqual(map[string]xpb.Anchor_Classification{
+		"kythe:?path=a#def":       xpb.Anchor_DEFINITION,
+		"kythe:?path=a#binding":   xpb.Anchor_BINDING,
+		"kythe:?path=a#completes": xpb.Anchor_COMPLETION,
+		"kythe:?path=a#ref":       xpb.Anchor_REFERENCE,
+		"kythe:?path=a#call":      xpb.Anchor_CALL,
+		"kythe:?path=b#decl":      xpb.Anchor_DECLARATION,
+	}, classes(reply)); err != nil {
+		t.Error(err)
+	}
+
+	// Classifications are omitted unless named by the anchor mask.
+	for _, mask := range []*fieldmaskpb.FieldMask{nil, {Paths: []string{"kind"}}} {
+		req.AnchorMask = mask
+		reply, err = st.CrossReferences(ctx, req)
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+		for ticket, c := range classes(reply) {
+			if c != xpb.Anchor_UNCLASSIFIED {
+				t.Errorf("Anchor %q: found classification %v not named by mask %v", ticket, c, mask)
+			}
+		}
+	}
+}
+
+func TestDecorationsClassification(t *testing.T) {
+	const file = "kythe:?path=classified"
+	decoration := func(start int32, kind, target string) *srvpb.FileDecorations_Decoration {
+		return &srvpb.FileDecorations_Decoration{
+			Anchor: &srvpb.RawAnchor{StartOffset: start, EndOffset: start + 1},
+			Kind:   kind,
+			Target: target,
+		}
+	}
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("abcd")},
+			Decoration: []*srvpb.FileDecorations_Decoration{
+				decoration(0, "/kythe/edge/defines/binding", "kythe:#complete"),
+				decoration(1, "/kythe/edge/defines/binding", "kythe:#incomplete"),
+				decoration(2, "/kythe/edge/ref", "kythe:#complete"),
+				decoration(3, "/kythe/edge/ref/call", "kythe:#complete"),
+			},
+			Target: []*srvpb.Node{{
+				Ticket: "kythe:#incomplete",
+				Fact:   []*cpb.Fact{{Name: facts.Complete, Value: []byte("incomplete")}},
+			}},
+		}},
+	}).Construct(t)
+
+	req := &xpb.DecorationsRequest{
+		Location:      &xpb.Location{Ticket: file},
+		References:    true,
+		ReferenceMask: &fieldmaskpb.FieldMask{Paths: []string{"classification"}},
+	}
+	reply, err := st.Decorations(ctx, req)
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	var found []xpb.Anchor_Classification
+	for _, r := range reply.Reference {
+		found = append(found, r.Classification)
+	}
+	if err := testutil.DeepEqual([]xpb.Anchor_Classification{
+		xpb.Anchor_BINDING,
+		xpb.Anchor_DECLARATION,
+		xpb.Anchor_REFERENCE,
+		xpb.Anchor_CALL,
+	}, found); err != nil {
+		t.Error(err)
+	}
+
+	// Classifications are omitted unless named by the reference mask.
+	req.ReferenceMask = nil
+	reply, err = st.Decorations(ctx, req)
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	for _, r := range reply.Reference {
+		if r.Classification != xpb.Anchor_UNCLASSIFIED {
+			t.Errorf("Reference %v: found classification without a mask", r)
+		}
+	}
+}
+
+func TestCrossReferencesMarkUnavailableFiles(t *testing.T) {
+	const fn = "kythe:#someFunc"
+	tbl := &testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: fn,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "%/kythe/edge/defines/binding",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe://corpus?path=decorated#0"}},
+			}, {
+				Kind:   "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe://corpus?path=missing#1"}},
+			}},
+		}},
+	}
+	req := &xpb.CrossReferencesRequest{
+		Ticket:               []string{fn},
+		DefinitionKind:       xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		ReferenceKind:        xpb.CrossReferencesRequest_ALL_REFERENCES,
+		MarkUnavailableFiles: true,
+	}
+	unavailable := func(reply *xpb.CrossReferencesReply) map[string]bool {
+		res := make(map[string]bool)
+		crs := reply.CrossReferences[fn]
+		for _, ra := range append(crs.Definition, crs.Reference...) {
+			res[ra.Anchor.Ticket] = ra.Anchor.FileUnavailable
+		}
+		return res
+	}
+
+	// Without a decorations filter, no anchors are marked.
+	reply, err := tbl.Construct(t).CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if err := testutil.DeepEqual(map[string]bool{
+		"kythe://corpus?path=decorated#0": false,
+		"kythe://corpus?path=missing#1":   false,
+	}, unavailable(reply)); err != nil {
+		t.Error(err)
+	}
+
+	tbl.DecoratedFiles = []string{"kythe://corpus?path=decorated"}
+	counter := &countingProtoTable{testProtoTable: tbl.protoTable(t), lookups: make(map[string]int)}
+	st := NewCombinedTable(counter)
+	for i := 0; i < 2; i++ {
+		reply, err = st.CrossReferences(ctx, req)
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+		if err := testutil.DeepEqual(map[string]bool{
+			"kythe://corpus?path=decorated#0": false,
+			"kythe://corpus?path=missing#1":   true,
+		}, unavailable(reply)); err != nil {
+			t.Error(err)
+		}
+	}
+	// The filter is read once per table.
+	if n := counter.lookups[string(DecorationsFilterKey)]; n != 1 {
+		t.Errorf("Expected 1 decorations filter lookup; found %d", n)
+	}
+
+	// Files with decoration overrides are available.
+	st.DecorationOverrides = NewDecorationOverrides()
+	st.DecorationOverrides.Put(&srvpb.FileDecorations{File: &srvpb.File{Ticket: "kythe://corpus?path=missing"}}, 0)
+	reply, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if err := testutil.DeepEqual(map[string]bool{
+		"kythe://corpus?path=decorated#0": false,
+		"kythe://corpus?path=missing#1":   false,
+	}, unavailable(reply)); err != nil {
+		t.Error(err)
+	}
+	st.DecorationOverrides = nil
+
+	// Anchors are only marked if requested.
+	req.MarkUnavailableFiles = false
+	reply, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if err := testutil.DeepEqual(map[string]bool{
+		"kythe://corpus?path=decorated#0": false,
+		"kythe://corpus?path=missing#1":   false,
+	}, unavailable(reply)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrossReferencesResolveAnchorPaths(t *testing.T) {
+	const fn = "kythe:#resolvedFunc"
+	info := &srvpb.FileInfo{
+		CorpusPath: &cpb.CorpusPath{Corpus: "corpus", Path: "indexed"},
+		Revision:   "abc123",
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: fn,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind: "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{{
+					Ticket:   "kythe://corpus?path=indexed#0",
+					FileInfo: info,
+				}, {
+					Ticket: "kythe://corpus?path=unknown?root=r#1",
+				}},
+			}},
+		}},
+	}).Construct(t)
+
+	req := &xpb.CrossReferencesRequest{
+		Ticket:        []string{fn},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	}
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	for _, ra := range reply.CrossReferences[fn].Reference {
+		if ra.Anchor.ParentPath != nil {
+			t.Errorf("Unexpected parent_path without resolve_anchor_paths: %v", ra.Anchor)
+		}
+	}
+
+	req.ResolveAnchorPaths = true
+	reply, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	var found []*xpb.Anchor
+	for _, ra := range reply.CrossReferences[fn].Reference {
+		found = append(found, &xpb.Anchor{ParentPath: ra.Anchor.ParentPath, Revision: ra.Anchor.Revision})
+	}
+	if diff := compare.ProtoDiff([]*xpb.Anchor{{
+		ParentPath: &cpb.CorpusPath{Corpus: "corpus", Path: "indexed"},
+		Revision:   "abc123",
+	}, {
+		ParentPath: &cpb.CorpusPath{Corpus: "corpus", Root: "r", Path: "unknown"},
+	}}, found); diff != "" {
+		t.Errorf("Unexpected anchors: (- expected; + found)\n%s", diff)
+	}
+
+	req.AnchorMask = &fieldmaskpb.FieldMask{Paths: []string{"revision"}}
+	reply, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	for _, ra := range reply.CrossReferences[fn].Reference {
+		if ra.Anchor.ParentPath != nil {
+			t.Errorf("Unexpected parent_path excluded by anchor_mask: %v", ra.Anchor)
+		}
+	}
+}
+
+func TestCrossReferencesGroupByKind(t *testing.T) {
+	const fn = "kythe:#groupedFunc"
+	anchor := func(offset int32) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{
+			Ticket: fmt.Sprintf("kythe:?path=file#%d", offset),
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: offset},
+				End:   &cpb.Point{ByteOffset: offset + 1},
+			},
+		}
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: fn,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "%/kythe/edge/ref/imports",
+				Anchor: []*srvpb.ExpandedAnchor{anchor(0)},
+			}, {
+				Kind:   "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor(2), anchor(6)},
+			}, {
+				Kind:   "%/kythe/edge/ref/call",
+				Anchor: []*srvpb.ExpandedAnchor{anchor(4)},
+			}},
+		}},
+	}).Construct(t)
+
+	req := &xpb.CrossReferencesRequest{
+		Ticket:                []string{fn},
+		ReferenceKind:         xpb.CrossReferencesRequest_ALL_REFERENCES,
+		GroupReferencesByKind: true,
+	}
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	type group struct {
+		Kind    string
+		Tickets []string
+	}
+	groups := func(reply *xpb.CrossReferencesReply) (found []group) {
+		crs := reply.CrossReferences[fn]
+		if len(crs.GetReference()) != 0 {
+			t.Errorf("Unexpected ungrouped references: %v", crs.GetReference())
+		}
+		for _, g := range crs.GetReferenceGroup() {
+			var tickets []string
+			for _, ra := range g.Reference {
+				tickets = append(tickets, ra.Anchor.Ticket)
+			}
+			found = append(found, group{g.Kind, tickets})
+		}
+		return found
+	}
+	expected := []group{
+		{"/kythe/edge/ref", []string{"kythe:?path=file#2", "kythe:?path=file#6"}},
+		{"/kythe/edge/ref/call", []string{"kythe:?path=file#4"}},
+		{"/kythe/edge/ref/imports", []string{"kythe:?path=file#0"}},
+	}
+	if err := testutil.DeepEqual(expected, groups(reply)); err != nil {
+		t.Error(err)
+	}
+
+	// Grouping still works when anchor kinds are masked out of the reply.
+	req.AnchorMask = &fieldmaskpb.FieldMask{Paths: []string{"span"}}
+	reply, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if err := testutil.DeepEqual(expected, groups(reply)); err != nil {
+		t.Error(err)
+	}
+	for _, g := range reply.CrossReferences[fn].GetReferenceGroup() {
+		for _, ra := range g.Reference {
+			if ra.Anchor.Kind != "" {
+				t.Errorf("Masked anchor kind populated: %v", ra.Anchor)
+			}
+		}
+	}
+}
+
+func TestRelatedNodeList(t *testing.T) {
+	rn := func(ticket string, ordinal int32) *xpb.CrossReferencesReply_RelatedNode {
+		return &xpb.CrossReferencesReply_RelatedNode{Ticket: ticket, RelationKind: "/kythe/edge/param", Ordinal: ordinal}
+	}
+	tests := []struct {
+		nodes    []*xpb.CrossReferencesReply_RelatedNode
+		expected []string
+	}{
+		{[]*xpb.CrossReferencesReply_RelatedNode{rn("b", 1), rn("a", 0)}, []string{"a", "b"}},
+		{[]*xpb.CrossReferencesReply_RelatedNode{rn("c", 2), rn("a", 0)}, []string{"a", "", "c"}},
+		{[]*xpb.CrossReferencesReply_RelatedNode{rn("a", 0), rn("dup", 0)}, []string{"a"}},
+		{[]*xpb.CrossReferencesReply_RelatedNode{rn("a", 0), rn("far", maxRelatedNodeListOrdinal+1)}, nil},
+	}
+	for _, test := range tests {
+		l := relatedNodeList("/kythe/edge/param", test.nodes)
+		if err := testutil.DeepEqual(test.expected, l.GetTicket()); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCrossReferencesSkipMissingPages(t *testing.T) {
+	req := &xpb.CrossReferencesRequest{
+		Ticket:         []string{"kythe://someCorpus?lang=otpl#signature"},
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		ReferenceKind:  xpb.CrossReferencesRequest_ALL_REFERENCES,
+		Snippets:       xpb.SnippetsKind_DEFAULT,
+	}
+	full, err := tbl.Construct(t).CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	// Drop all cross-reference pages, keeping their page indices.
+	st := (&testTable{
+		Nodes:       tbl.Nodes,
+		Decorations: tbl.Decorations,
+		RefSets:     tbl.RefSets,
+	}).Construct(t)
+	if _, err := st.CrossReferences(ctx, req); err == nil {
+		t.Fatal("Expected error reading missing cross-references page")
+	}
+
+	req.SkipMissingPages = true
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	expected := full.CrossReferences[req.Ticket[0]]
+	found := reply.CrossReferences[req.Ticket[0]]
+	if found == nil {
+		t.Fatalf("Missing CrossReferenceSet in reply: %v", reply)
+	}
+	if err := testutil.DeepEqual([]string{"%/kythe/edge/ref"}, found.TruncatedKind); err != nil {
+		t.Errorf("Unexpected truncated kinds: %v", err)
+	}
+	if err := testutil.DeepEqual(expected.Definition, found.Definition); err != nil {
+		t.Errorf("Unexpected definitions: %v", err)
+	}
+	// The page held 2 of the references.
+	if want, got := len(expected.Reference)-2, len(found.Reference); got != want {
+		t.Errorf("Expected %d references; found %d", want, got)
+	}
+	if err := testutil.DeepEqual(full.Total, reply.Total); err != nil {
+		t.Errorf("Unexpected totals: %v", err)
+	}
+}
+
+func TestCrossReferencesSkipMissingPagesPaging(t *testing.T) {
+	const node = "kythe://c?lang=otpl#node"
+	ref := func(i int) string { return fmt.Sprintf("kythe://c?lang=otpl?path=file#ref%d", i) }
+	page := func(key string, refs ...int) *srvpb.PagedCrossReferences_Page {
+		g := &srvpb.PagedCrossReferences_Group{Kind: "%/kythe/edge/ref"}
+		for _, i := range refs {
+			g.Anchor = append(g.Anchor, &srvpb.ExpandedAnchor{Ticket: ref(i), Kind: "/kythe/edge/ref"})
+		}
+		return &srvpb.PagedCrossReferences_Page{PageKey: key, Group: g}
+	}
+	pt := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: node,
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{
+				{PageKey: "first", Kind: "%/kythe/edge/ref", Count: 2},
+				{PageKey: "flaky", Kind: "%/kythe/edge/ref", Count: 2},
+				{PageKey: "last", Kind: "%/kythe/edge/ref", Count: 2},
+			},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{page("first", 0, 1), page("last", 4, 5)},
+	}).protoTable(t)
+	st := NewCombinedTable(pt)
+
+	req := &xpb.CrossReferencesRequest{
+		Ticket:           []string{node},
+		ReferenceKind:    xpb.CrossReferencesRequest_ALL_REFERENCES,
+		PageSize:         3,
+		SkipMissingPages: true,
+	}
+	var found []string
+	for i := 0; ; i++ {
+		if i > 3 {
+			t.Fatalf("Too many pages of cross-references: %v", found)
+		}
+		reply, err := st.CrossReferences(ctx, req)
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+		for _, ra := range reply.CrossReferences[node].GetReference() {
+			found = append(found, ra.Anchor.Ticket)
+		}
+		if reply.NextPageToken == "" {
+			break
+		}
+		req.PageToken = reply.NextPageToken
+
+		// The middle page is missing only while the first page of
+		// cross-references is read.
+		pt[string(CrossReferencesPageKey("flaky"))] = page("flaky", 2, 3)
+	}
+
+	// The skipped page's references are not returned by later pages, nor are
+	// any references repeated.
+	if err := testutil.DeepEqual([]string{ref(0), ref(1), ref(4), ref(5)}, found); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrossReferencesReadQuota(t *testing.T) {
+	req := &xpb.CrossReferencesRequest{
+		Ticket:         []string{"kythe://someCorpus?lang=otpl#signature"},
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		ReferenceKind:  xpb.CrossReferencesRequest_ALL_REFERENCES,
+		Snippets:       xpb.SnippetsKind_DEFAULT,
+	}
+	full, err := tbl.Construct(t).CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	// The PagedCrossReferences lookup exhausts the quota, so its single page is
+	// skipped.
+	st := tbl.Construct(t)
+	st.ReadQuota = ReadQuota{MaxLookups: 1}
+	_, err = st.CrossReferences(ctx, req)
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected QuotaExceededError; found %v", err)
+	} else if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Errorf("Expected code %v; found %v", codes.ResourceExhausted, code)
+	}
+	if quotaErr.Limit != "lookups" || quotaErr.Max != 1 {
+		t.Errorf("Unexpected exceeded limit: %v", quotaErr)
+	}
+	reply, ok := quotaErr.Reply.(*xpb.CrossReferencesReply)
+	if !ok {
+		t.Fatalf("Expected partial CrossReferencesReply; found %T", quotaErr.Reply)
+	}
+	found := reply.CrossReferences[req.Ticket[0]]
+	if found == nil {
+		t.Fatalf("Missing CrossReferenceSet in reply: %v", reply)
+	}
+	if err := testutil.DeepEqual([]string{"%/kythe/edge/ref"}, found.TruncatedKind); err != nil {
+		t.Errorf("Unexpected truncated kinds: %v", err)
+	}
+	if want, got := len(full.CrossReferences[req.Ticket[0]].Reference)-2, len(found.Reference); got != want {
+		t.Errorf("Expected %d references; found %d", want, got)
+	}
+	// The partial reply reaches clients as a status detail.
+	if details := status.Convert(err).Details(); len(details) != 1 {
+		t.Errorf("Expected partial reply detail; found %v", details)
+	} else if diff := compare.ProtoDiff(reply, details[0].(proto.Message)); diff != "" {
+		t.Errorf("Partial reply detail (-expected; +found):\n%s", diff)
+	}
+
+	// A sufficient quota is not exceeded.
+	st.ReadQuota = ReadQuota{MaxLookups: 2, MaxPages: 1}
+	reply, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if diff := compare.ProtoDiff(full, reply); diff != "" {
+		t.Errorf("(-expected; +found):\n%s", diff)
+	}
+}
+
+func TestCrossReferencesRelatedNodeExpansion(t *testing.T) {
+	const (
+		fn    = "kythe:#expandFunc"
+		param = "kythe:#expandParam"
+		typ   = "kythe:#expandType"
+	)
+	node := func(ticket, kind string) *srvpb.Node {
+		return &srvpb.Node{Ticket: ticket, Fact: makeFactList("/kythe/node/kind", kind)}
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: fn,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:        "/kythe/edge/param",
+				RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{Node: node(param, "variable")}},
+			}},
+		}, {
+			SourceTicket: param,
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+				Kind:    "/kythe/edge/typed",
+				PageKey: "expandTypedPage",
+				Count:   1,
+			}},
+		}, {
+			SourceTicket: typ,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:        "/kythe/edge/childof",
+				RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{Node: node(fn, "function")}},
+			}},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey: "expandTypedPage",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:        "/kythe/edge/typed",
+				RelatedNode: []*srvpb.PagedCrossReferences_RelatedNode{{Node: node(typ, "tapp")}},
+			},
+		}},
+	}).Construct(t)
+
+	for depth := 0; depth <= 2; depth++ {
+		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket: []string{fn},
+			Filter: []string{"/kythe/node/kind"},
+
+			RelatedNodeExpansionDepth: int32(depth),
+		})
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+		expected := map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+			fn: {
+				Ticket: fn,
+				RelatedNode: []*xpb.CrossReferencesReply_RelatedNode{{
+					Ticket:       param,
+					RelationKind: "/kythe/edge/param",
+				}},
+			},
+		}
+		if depth >= 1 {
+			expected[param] = &xpb.CrossReferencesReply_CrossReferenceSet{
+				Ticket: param,
+				RelatedNode: []*xpb.CrossReferencesReply_RelatedNode{{
+					Ticket:       typ,
+					RelationKind: "/kythe/edge/typed",
+				}},
+			}
+		}
+		if depth >= 2 {
+			// The original node is not expanded again.
+			expected[typ] = &xpb.CrossReferencesReply_CrossReferenceSet{
+				Ticket: typ,
+				RelatedNode: []*xpb.CrossReferencesReply_RelatedNode{{
+					Ticket:       fn,
+					RelationKind: "/kythe/edge/childof",
+				}},
+			}
+		}
+		if diff := compare.ProtoDiff(expected, reply.CrossReferences); diff != "" {
+			t.Errorf("depth %d: (-expected; +found):\n%s", depth, diff)
+		}
+		if depth >= 1 && reply.Nodes[typ] == nil {
+			t.Errorf("depth %d: missing NodeInfo for %q: %v", depth, typ, reply.Nodes)
+		}
+	}
+}
+
+func TestCrossReferencesDisplayNames(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#withRelated"
+	names := &testTable{
+		RefSets: tbl.RefSets,
+		Names: []*srvpb.DisplayName{
+			{Ticket: ticket, Name: "WithRelated"},
+			{Ticket: "kythe:#someParameter0", Name: "param0"},
+		},
+	}
+
+	st := names.Construct(t)
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:       []string{ticket},
+		Filter:       []string{"**"},
+		DisplayNames: true,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	found := make(map[string]string)
+	for _, rn := range reply.CrossReferences[ticket].GetRelatedNode() {
+		found[rn.Ticket] = rn.DisplayName
+	}
+	expected := map[string]string{
+		"kythe:#someRelatedNode": "",
+		"kythe:#someParameter0":  "param0",
+		"kythe:#someParameter1":  "",
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Error(err)
+	}
+	if name := reply.Nodes[ticket].GetDisplayName(); name != "WithRelated" {
+		t.Errorf("Expected NodeInfo display name %q; found %q", "WithRelated", name)
+	}
+}
+
+func TestCrossReferencesCanonicalNodes(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#withRelated"
+	st := (&testTable{
+		RefSets: tbl.RefSets,
+		Names: []*srvpb.DisplayName{
+			{Ticket: "kythe:#someParameter0", Name: "param0"},
+		},
+	}).Construct(t)
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:         []string{ticket},
+		Filter:         []string{"**"},
+		DisplayNames:   true,
+		CanonicalNodes: true,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	for _, rn := range reply.CrossReferences[ticket].GetRelatedNode() {
+		if rn.DisplayName != "" {
+			t.Errorf("RelatedNode %q has non-canonical display name %q", rn.Ticket, rn.DisplayName)
+		}
+	}
+	if name := reply.Nodes["kythe:#someParameter0"].GetDisplayName(); name != "param0" {
+		t.Errorf("Expected NodeInfo display name %q; found %q", "param0", name)
+	}
+	if n, ok := reply.Nodes["kythe:#someParameter1"]; ok {
+		t.Errorf("Unexpected NodeInfo for unnamed parameter: %v", n)
+	}
+}
+
+func TestCrossReferencesMarkedSource(t *testing.T) {
+	const ticket = "kythe://someCorpus?lang=otpl#withRelated"
+
+	st := tbl.Construct(t)
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket: []string{ticket},
+		Filter: []string{"**"},
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+		Ticket: ticket,
+		MarkedSource: &cpb.MarkedSource{
+			Kind:    cpb.MarkedSource_IDENTIFIER,
+			PreText: "id",
+		},
+
+		RelatedNode: []*xpb.CrossReferencesReply_RelatedNode{{
+			Ticket:       "kythe:#someRelatedNode",
+			RelationKind: "/kythe/edge/extends",
+		}, {
+			Ticket:       "kythe:#someParameter0",
+			RelationKind: "/kythe/edge/param",
+			Ordinal:      0,
+		}, {
+			Ticket:       "kythe:#someParameter1",
+			RelationKind: "/kythe/edge/param",
+			Ordinal:      1,
+		}},
+	}
+
+	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+		RelatedNodesByRelation: map[string]int64{
+			"/kythe/edge/extends": 1,
+			"/kythe/edge/param":   2,
+		},
+	}, reply.Total); err != nil {
+		t.Error(err)
+	}
+
+	xr := reply.CrossReferences[ticket]
+	if xr == nil {
+		t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+	} else if err := testutil.DeepEqual(expected, xr); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCrossReferencesMerge(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#withMerge"
+
+	st := tbl.Construct(t)
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:     []string{ticket},
+		CallerKind: xpb.CrossReferencesRequest_DIRECT_CALLERS,
+		Filter:     []string{"**"},
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+		Ticket: ticket,
+		MarkedSource: &cpb.MarkedSource{
+			Kind:    cpb.MarkedSource_IDENTIFIER,
+			PreText: "id",
+		},
+
+		Caller: []*xpb.CrossReferencesReply_RelatedAnchor{{
+			Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=someFile#someCallerAnchor",
+				Parent: "kythe:?path=someFile",
+				Span:   arbitrarySpan,
+			},
+			Ticket: "kythe:#someCaller",
+			MarkedSource: &cpb.MarkedSource{
+				Kind:    cpb.MarkedSource_IDENTIFIER,
+				PreText: "id",
+			},
+			Site: []*xpb.Anchor{{
+				Ticket: "kythe:?path=someFile#someCallsiteAnchor",
+				Parent: "kythe:?path=someFile",
+			}},
+		}},
+		RelatedNode: []*xpb.CrossReferencesReply_RelatedNode{{
+			Ticket:       "kythe:#someRelatedNode",
+			RelationKind: "/kythe/edge/extends",
+		}, {
+			Ticket:       "kythe:#someParameter0",
+			RelationKind: "/kythe/edge/param",
+			Ordinal:      0,
+		}, {
+			Ticket:       "kythe:#someParameter1",
+			RelationKind: "/kythe/edge/param",
+			Ordinal:      1,
+		}},
+	}
+
+	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+		Callers: 1,
+		RelatedNodesByRelation: map[string]int64{
+			"/kythe/edge/extends": 1,
+			"/kythe/edge/param":   2,
+		},
+	}, reply.Total); err != nil {
+		t.Error(err)
+	}
+
+	xr := reply.CrossReferences[ticket]
+	if xr == nil {
+		t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+	} else if err := testutil.DeepEqual(expected, xr); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCrossReferencesIndirection(t *testing.T) {
+	ticket := "kythe:#aliasNode"
+	st := tbl.Construct(t)
+
+	t.Run("none", func(t *testing.T) {
+		experimentalCrossReferenceIndirectionKinds = nil
+
+		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:        []string{ticket},
+			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		})
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+			Ticket: ticket,
+
+			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=somewhere#0-9",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=somewhere",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+				},
+			}}},
+		}
+
+		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+			References: 1,
+		}, reply.Total); err != nil {
+			t.Error(err)
+		}
+
+		xr := reply.CrossReferences[ticket]
+		if xr == nil {
+			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+		} else if err := testutil.DeepEqual(expected, xr); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("talias", func(t *testing.T) {
+		// Enable indirection for talias nodes.
+		experimentalCrossReferenceIndirectionKinds = nil
+		experimentalCrossReferenceIndirectionKinds.Set("talias=%/kythe/edge/aliases")
+
+		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:        []string{ticket},
+			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		})
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+			Ticket: ticket,
+
+			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=some/utf16/file#0-4",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=some/utf16/file",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 4, LineNumber: 1, ColumnOffset: 4},
+				},
+			}}, {Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=somewhere#0-9",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=somewhere",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+				},
+			}}, {Anchor: &xpb.Anchor{
+				Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe://c?path=/a/path",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{
+						ByteOffset:   51,
+						LineNumber:   4,
+						ColumnOffset: 15,
+					},
+					End: &cpb.Point{
+						ByteOffset:   55,
+						LineNumber:   5,
+						ColumnOffset: 2,
+					},
+				},
+			}}},
+		}
+
+		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+			References: 3,
+		}, reply.Total); err != nil {
+			t.Error(err)
+		}
+
+		xr := reply.CrossReferences[ticket]
+		if xr == nil {
+			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+		}
+
+		if err := testutil.DeepEqual(expected, xr); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		// Enable indirection for any nodes with a reverse aliases edge.
+		experimentalCrossReferenceIndirectionKinds = nil
+		experimentalCrossReferenceIndirectionKinds.Set("*=%/kythe/edge/aliases")
+
+		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:        []string{ticket},
+			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		})
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+			Ticket: ticket,
+
+			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=some/utf16/file#0-4",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=some/utf16/file",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 4, LineNumber: 1, ColumnOffset: 4},
+				},
+			}}, {Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=somewhere#0-9",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=somewhere",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+				},
+			}}, {Anchor: &xpb.Anchor{
+				Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe://c?path=/a/path",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{
+						ByteOffset:   51,
+						LineNumber:   4,
+						ColumnOffset: 15,
+					},
+					End: &cpb.Point{
+						ByteOffset:   55,
+						LineNumber:   5,
+						ColumnOffset: 2,
+					},
+				},
+			}}},
+		}
+
+		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+			References: 3,
+		}, reply.Total); err != nil {
+			t.Error(err)
+		}
+
+		xr := reply.CrossReferences[ticket]
+		if xr == nil {
+			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+		}
+
+		if err := testutil.DeepEqual(expected, xr); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("single_indirect", func(t *testing.T) {
+		// Enable single indirection for talias nodes.
+		experimentalCrossReferenceIndirectionKinds = nil
+		experimentalCrossReferenceIndirectionKinds.Set("talias=/kythe/edge/indirect")
+
+		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:        []string{ticket},
+			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		})
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+			Ticket: ticket,
+
+			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=somewhere#0-9",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=somewhere",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+				},
+			}}, {Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=somewhereElse#0-9",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=somewhereElse",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+				},
+			}}},
+		}
+
+		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+			References: 2,
+		}, reply.Total); err != nil {
+			t.Error(err)
+		}
+
+		xr := reply.CrossReferences[ticket]
+		if xr == nil {
+			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+		}
+
+		if err := testutil.DeepEqual(expected, xr); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("double_indirect", func(t *testing.T) {
+		// Enable double indirection for talias nodes.
+		experimentalCrossReferenceIndirectionKinds = nil
+		experimentalCrossReferenceIndirectionKinds.Set("talias=/kythe/edge/indirect,indirect=/kythe/edge/indirect")
+
+		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:        []string{ticket},
+			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		})
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+			Ticket: ticket,
+
+			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=some/utf16/file#0-4",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=some/utf16/file",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 4, LineNumber: 1, ColumnOffset: 4},
+				},
+			}}, {Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=somewhere#0-9",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=somewhere",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+				},
+			}}, {Anchor: &xpb.Anchor{
+				Ticket: "kythe:?path=somewhereElse#0-9",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe:?path=somewhereElse",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{LineNumber: 1},
+					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
+				},
+			}}, {Anchor: &xpb.Anchor{
+				Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
+				Kind:   "/kythe/edge/ref",
+				Parent: "kythe://c?path=/a/path",
+
+				Span: &cpb.Span{
+					Start: &cpb.Point{
+						ByteOffset:   51,
+						LineNumber:   4,
+						ColumnOffset: 15,
+					},
+					End: &cpb.Point{
+						ByteOffset:   55,
+						LineNumber:   5,
+						ColumnOffset: 2,
+					},
+				},
+			}}},
+		}
+
+		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+			References: 4,
+		}, reply.Total); err != nil {
+			t.Error(err)
+		}
+
+		xr := reply.CrossReferences[ticket]
+		if xr == nil {
+			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+		}
+
+		if err := testutil.DeepEqual(expected, xr); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestSortCrossReferences(t *testing.T) {
+	anchor := func(parent string, start, end int32) *xpb.Anchor {
+		return &xpb.Anchor{
+			Ticket: fmt.Sprintf("%s#%d-%d", parent, start, end),
+			Parent: parent,
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: start},
+				End:   &cpb.Point{ByteOffset: end},
+			},
+		}
+	}
+	reply := &xpb.CrossReferencesReply{
+		CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+			"kythe:#node": {
+				Reference: []*xpb.CrossReferencesReply_RelatedAnchor{
+					{Anchor: anchor("kythe://b?path=a", 0, 1)},
+					{Anchor: anchor("kythe://a?path=z", 10, 12)},
+					{Anchor: anchor("kythe://a?path=z", 2, 5)},
+					{Anchor: anchor("kythe://a?path=b?root=r", 0, 1)},
+					{Anchor: anchor("kythe://a?path=b", 4, 8)},
+				},
+				Caller: []*xpb.CrossReferencesReply_RelatedAnchor{{
+					Anchor: anchor("kythe://a?path=c", 0, 20),
+					Site: []*xpb.Anchor{
+						anchor("kythe://a?path=c", 15, 18),
+						anchor("kythe://a?path=c", 5, 8),
+					},
+				}},
+			},
+		},
+	}
+
+	sortCrossReferences(reply, newAnchorOrder())
+
+	var found []string
+	for _, ra := range reply.CrossReferences["kythe:#node"].Reference {
+		found = append(found, ra.Anchor.Ticket)
+	}
+	expected := []string{
+		"kythe://a?path=b#4-8",
+		"kythe://a?path=z#2-5",
+		"kythe://a?path=z#10-12",
+		"kythe://a?path=b?root=r#0-1",
+		"kythe://b?path=a#0-1",
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Error(err)
+	}
+
+	sites := reply.CrossReferences["kythe:#node"].Caller[0].Site
+	if sites[0].Span.Start.ByteOffset != 5 || sites[1].Span.Start.ByteOffset != 15 {
+		t.Errorf("Caller sites not sorted: %v", sites)
+	}
+}
+
+func TestSortCrossReferencesMasked(t *testing.T) {
+	// Anchors masked down to their tickets are ordered by their ticket-derived
+	// files and their stored spans.
+	o := newAnchorOrder()
+	stored := func(ticket string, start, end int32) *xpb.CrossReferencesReply_RelatedAnchor {
+		o.record(&srvpb.ExpandedAnchor{
+			Ticket: ticket,
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: start},
+				End:   &cpb.Point{ByteOffset: end},
+			},
+		})
+		return &xpb.CrossReferencesReply_RelatedAnchor{Anchor: &xpb.Anchor{Ticket: ticket}}
+	}
+	reply := &xpb.CrossReferencesReply{
+		CrossReferences: map[string]*xpb.CrossReferencesReply_CrossReferenceSet{
+			"kythe:#node": {
+				Reference: []*xpb.CrossReferencesReply_RelatedAnchor{
+					stored("kythe://a?path=z#late", 10, 12),
+					stored("kythe://b?path=a#only", 0, 1),
+					stored("kythe://a?path=z#early", 2, 5),
+					stored("kythe://a?path=b#first", 4, 8),
+				},
+			},
+		},
+	}
+
+	sortCrossReferences(reply, o)
+
+	var found []string
+	for _, ra := range reply.CrossReferences["kythe:#node"].Reference {
+		found = append(found, ra.Anchor.Ticket)
+	}
+	if err := testutil.DeepEqual([]string{
+		"kythe://a?path=b#first",
+		"kythe://a?path=z#early",
+		"kythe://a?path=z#late",
+		"kythe://b?path=a#only",
+	}, found); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrossReferencesPagingOrder(t *testing.T) {
+	const ticket = "kythe://c?lang=go#sym"
+	anchor := func(path string, start int32) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{
+			Ticket: fmt.Sprintf("kythe://c?lang=go?path=%s#%d-%d", path, start, start+1),
+			Kind:   "/kythe/edge/ref",
+			Span: &cpb.Span{
+				Start: &cpb.Point{ByteOffset: start},
+				End:   &cpb.Point{ByteOffset: start + 1},
+			},
+		}
+	}
+	page := func(key, config string, as ...*srvpb.ExpandedAnchor) (*srvpb.PagedCrossReferences_PageIndex, *srvpb.PagedCrossReferences_Page) {
+		return &srvpb.PagedCrossReferences_PageIndex{PageKey: key, Kind: "/kythe/edge/ref", BuildConfig: config, Count: int32(len(as))},
+			&srvpb.PagedCrossReferences_Page{PageKey: key, Group: &srvpb.PagedCrossReferences_Group{Kind: "/kythe/edge/ref", BuildConfig: config, Anchor: as}}
+	}
+	// Each group and run of pages is sorted, but they interleave by location.
+	idx1, p1 := page("refs1", "", anchor("a", 2), anchor("b", 1))
+	idx2, p2 := page("refs2", "", anchor("b", 5), anchor("d", 0))
+	idx3, p3 := page("refs3", "opt", anchor("a", 0), anchor("c", 3))
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("a", 1), anchor("c", 0)},
+			}},
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{idx1, idx2, idx3},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{p1, p2, p3},
+	}).Construct(t)
+
+	req := &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		PageSize:      3,
+	}
+	var found []string
+	for pages := 0; ; pages++ {
+		if pages == 5 {
+			t.Fatalf("Too many pages; found %v", found)
+		}
+		reply, err := st.CrossReferences(ctx, req)
+		testutil.Fatalf(t, "CrossReferences error: %v", err)
+		if n := reply.GetTotal().GetReferences(); n != 8 {
+			t.Errorf("Expected 8 total references; found %d", n)
+		}
+		for _, ra := range reply.CrossReferences[ticket].GetReference() {
+			found = append(found, ra.Anchor.Ticket)
+		}
+		if reply.NextPageToken == "" {
+			break
+		}
+		req.PageToken = reply.NextPageToken
+	}
+
+	if err := testutil.DeepEqual([]string{
+		"kythe://c?lang=go?path=a#0-1",
+		"kythe://c?lang=go?path=a#1-2",
+		"kythe://c?lang=go?path=a#2-3",
+		"kythe://c?lang=go?path=b#1-2",
+		"kythe://c?lang=go?path=b#5-6",
+		"kythe://c?lang=go?path=c#0-1",
+		"kythe://c?lang=go?path=c#3-4",
+		"kythe://c?lang=go?path=d#0-1",
+	}, found); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrossReferencesReadahead(t *testing.T) {
+	const pageKey = "aBcDeFg"
+	counter := &countingProtoTable{testProtoTable: tbl.protoTable(t), lookups: make(map[string]int)}
+	prefetched := make(chan string, 4)
+	st := NewCombinedTable(counter)
+	st.Readahead = readahead.New[*srvpb.PagedCrossReferences_Page](&readahead.Options{
+		Prefetched: func(key string) { prefetched <- key },
+	})
+	req := &xpb.CrossReferencesRequest{
+		Ticket:         []string{"kythe://someCorpus?lang=otpl#signature"},
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+		ReferenceKind:  xpb.CrossReferencesRequest_ALL_REFERENCES,
+		PageSize:       1,
+	}
+
+	// The first page is served from the page holding the first reference by
+	// location; the rest of the page is prefetched for the following page.
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if reply.NextPageToken == "" {
+		t.Fatalf("Missing next_page_token in CrossReferencesReply: {%v}", reply)
+	}
+	if refs := reply.CrossReferences[req.Ticket[0]].GetReference(); len(refs) != 1 {
+		t.Errorf("Expected 1 reference from page %q; found %v", pageKey, refs)
+	}
+	select {
+	case key := <-prefetched:
+		if key != pageKey {
+			t.Fatalf("Expected page %q to be prefetched; found %q", pageKey, key)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Page %q was not prefetched", pageKey)
+	}
+
+	// The following page is served from the group's definition, placing it
+	// against the prefetched page.
+	req.PageToken = reply.NextPageToken
+	reply, err = st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if defs := reply.CrossReferences[req.Ticket[0]].GetDefinition(); len(defs) != 1 {
+		t.Errorf("Expected 1 definition; found %v", defs)
+	}
+	if n := counter.lookups[string(CrossReferencesPageKey(pageKey))]; n != 2 {
+		t.Errorf("Expected page %q to be read once and prefetched once; found %d reads", pageKey, n)
+	}
+}
+
+// countingProtoTable counts the lookups of each key.
+type countingProtoTable struct {
+	testProtoTable
+	mu      sync.Mutex
+	lookups map[string]int
+}
+
+func (t *countingProtoTable) Lookup(ctx context.Context, key []byte, msg proto.Message) error {
+	t.mu.Lock()
+	t.lookups[string(key)]++
+	t.mu.Unlock()
+	return t.testProtoTable.Lookup(ctx, key, msg)
+}
+
+func TestGetFile(t *testing.T) {
+	const file = "kythe://c?path=lib.go"
+	text := []byte("package lib\n")
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{
+			{File: &srvpb.File{Ticket: file, Text: text, Encoding: "utf-8"}},
+		},
+	}).Construct(t)
+	digest := xrefs.FileDigest(text)
+
+	content, err := st.GetFile(ctx, file, nil)
+	testutil.Fatalf(t, "GetFile error: %v", err)
+	if err := testutil.DeepEqual(&FileContent{
+		Text:     text,
+		End:      len(text),
+		Size:     len(text),
+		Encoding: "utf-8",
+		Digest:   digest,
+	}, content); err != nil {
+		t.Error(err)
+	}
+
+	content, err = st.GetFile(ctx, file, &GetFileOptions{Start: 8, End: 11, Digest: digest})
+	testutil.Fatalf(t, "GetFile error: %v", err)
+	if string(content.Text) != "lib" || content.Start != 8 || content.End != 11 || content.Size != len(text) {
+		t.Errorf("Unexpected range content: %+v", content)
+	}
+
+	content, err = st.GetFile(ctx, file, &GetFileOptions{Start: 8})
+	testutil.Fatalf(t, "GetFile error: %v", err)
+	if string(content.Text) != "lib\n" || content.End != len(text) || content.Digest != "" {
+		t.Errorf("Unexpected open range content: %+v", content)
+	}
+
+	// The returned text must not alias the stored text.
+	content.Text[0] = 'X'
+	content, err = st.GetFile(ctx, file, &GetFileOptions{Start: 8})
+	testutil.Fatalf(t, "GetFile error: %v", err)
+	if string(content.Text) != "lib\n" {
+		t.Errorf("GetFile text was modified through a previous reply: %q", content.Text)
+	}
+
+	st.DecorationOverrides = NewDecorationOverrides()
+	st.DecorationOverrides.Put(&srvpb.FileDecorations{
+		File: &srvpb.File{Ticket: file, Text: []byte("package override\n")},
+	}, 0)
+	content, err = st.GetFile(ctx, file, &GetFileOptions{Start: 8, End: 16})
+	testutil.Fatalf(t, "GetFile error: %v", err)
+	if string(content.Text) != "override" {
+		t.Errorf("Unexpected overridden content: %+v", content)
+	}
+	st.DecorationOverrides = nil
+
+	tests := []struct {
+		ticket string
+		opts   *GetFileOptions
+		code   codes.Code
+	}{
+		{file, &GetFileOptions{Digest: "bad"}, codes.FailedPrecondition},
+		{file, &GetFileOptions{Start: 8, End: 100}, codes.OutOfRange},
+		{file, &GetFileOptions{Start: 100}, codes.OutOfRange},
+		{file, &GetFileOptions{Start: -1}, codes.InvalidArgument},
+		{file, &GetFileOptions{Start: 8, End: 4}, codes.InvalidArgument},
+		{"kythe://c?path=missing.go", nil, codes.NotFound},
+	}
+	for _, test := range tests {
+		if _, err := st.GetFile(ctx, test.ticket, test.opts); status.Code(err) != test.code {
+			t.Errorf("GetFile(%q, %+v): expected %v; found %v", test.ticket, test.opts, test.code, err)
+		}
+	}
+}
+
+func TestDuplicateFiles(t *testing.T) {
+	const (
+		orig     = "kythe://c?path=lib.go"
+		vendored = "kythe://c?path=vendor/lib.go"
+		other    = "kythe://c?path=main.go"
+	)
+	text := []byte("package lib\n")
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{
+			{File: &srvpb.File{Ticket: orig, Text: text}},
+			{File: &srvpb.File{Ticket: vendored, Text: text}},
+			{File: &srvpb.File{Ticket: other, Text: []byte("package main\n")}},
+		},
+		Digests: []*srvpb.FileDigest{{
+			Digest: xrefs.FileDigest(text),
+			Ticket: []string{orig, vendored},
+		}},
+	}).Construct(t)
+
+	dups, err := st.DuplicateFiles(ctx, orig)
+	testutil.Fatalf(t, "DuplicateFiles error: %v", err)
+	if err := testutil.DeepEqual([]string{vendored}, dups); err != nil {
+		t.Error(err)
+	}
+
+	dups, err = st.DuplicateFiles(ctx, vendored)
+	testutil.Fatalf(t, "DuplicateFiles error: %v", err)
+	if err := testutil.DeepEqual([]string{orig}, dups); err != nil {
+		t.Error(err)
+	}
+
+	dups, err = st.DuplicateFiles(ctx, other)
+	testutil.Fatalf(t, "DuplicateFiles error: %v", err)
+	if len(dups) != 0 {
+		t.Errorf("Unexpected duplicates of %s: %v", other, dups)
+	}
+
+	if _, err := st.DuplicateFiles(ctx, "kythe://c?path=missing.go"); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for missing file; found %v", err)
+	}
+	if _, err := st.DuplicateFiles(ctx, ""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for empty ticket; found %v", err)
+	}
+
+	// Unauthorized duplicates are omitted and unauthorized files are denied.
+	st.Authorizer = &denyTickets{denied: stringset.New(vendored)}
+	dups, err = st.DuplicateFiles(ctx, orig)
+	testutil.Fatalf(t, "DuplicateFiles error: %v", err)
+	if len(dups) != 0 {
+		t.Errorf("Unexpected duplicates of %s: %v", orig, dups)
+	}
+	if _, err := st.DuplicateFiles(ctx, vendored); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for DuplicateFiles; found %v", err)
+	}
+}
+
+func TestFileReferencedNodes(t *testing.T) {
+	const file = "kythe://c?path=main.go"
+	nodes := []*srvpb.FileReferences_Node{
+		{Ticket: "kythe://c?lang=go#f", Count: 3},
+		{Ticket: "kythe://c?lang=go#g", Count: 1},
+	}
+	st := (&testTable{
+		FileRefs: []*srvpb.FileReferences{{File: file, Node: nodes}},
+	}).Construct(t)
+
+	found, err := st.FileReferencedNodes(ctx, file)
+	testutil.Fatalf(t, "FileReferencedNodes error: %v", err)
+	if err := testutil.DeepEqual(nodes, found); err != nil {
+		t.Error(err)
+	}
+
+	found, err = st.FileReferencedNodes(ctx, "kythe://c?path=other.go")
+	testutil.Fatalf(t, "FileReferencedNodes error: %v", err)
+	if len(found) != 0 {
+		t.Errorf("Unexpected nodes: %v", found)
+	}
+	if _, err := st.FileReferencedNodes(ctx, ""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for empty ticket; found %v", err)
+	}
+
+	// Unauthorized nodes are omitted and unauthorized files are denied.
+	authz := &denyTickets{denied: stringset.New("kythe://c?lang=go#f")}
+	st.Authorizer = authz
+	found, err = st.FileReferencedNodes(ctx, file)
+	testutil.Fatalf(t, "FileReferencedNodes error: %v", err)
+	if err := testutil.DeepEqual(nodes[1:], found); err != nil {
+		t.Error(err)
+	}
+	authz.denied.Add(file)
+	if _, err := st.FileReferencedNodes(ctx, file); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for FileReferencedNodes; found %v", err)
+	}
+}
+
+func TestFileRelations(t *testing.T) {
+	rels := &srvpb.FileRelations{
+		File: "kythe://c?path=a.h",
+		Incoming: []*srvpb.FileRelations_Relation{
+			{Ticket: "kythe://c?path=a.cc", Kind: "/kythe/edge/ref/includes"},
+		},
+	}
+	st := (&testTable{FileRels: []*srvpb.FileRelations{rels}}).Construct(t)
+
+	found, err := st.FileRelations(ctx, "kythe://c?path=a.h")
+	testutil.Fatalf(t, "FileRelations error: %v", err)
+	if err := testutil.DeepEqual(rels, found); err != nil {
+		t.Error(err)
+	}
+
+	found, err = st.FileRelations(ctx, "kythe://c?path=missing.h")
+	testutil.Fatalf(t, "FileRelations error: %v", err)
+	if found != nil {
+		t.Errorf("Expected no relations for missing file; found %v", found)
+	}
+
+	if _, err := st.FileRelations(ctx, "bad:ticket"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for invalid ticket; found %v", err)
+	}
+
+	// Unauthorized related files are omitted and unauthorized files are denied.
+	authz := &denyTickets{denied: stringset.New("kythe://c?path=a.cc")}
+	st.Authorizer = authz
+	found, err = st.FileRelations(ctx, "kythe://c?path=a.h")
+	testutil.Fatalf(t, "FileRelations error: %v", err)
+	if err := testutil.DeepEqual(&srvpb.FileRelations{File: "kythe://c?path=a.h"}, found); err != nil {
+		t.Error(err)
+	}
+	authz.denied.Add("kythe://c?path=a.h")
+	if _, err := st.FileRelations(ctx, "kythe://c?path=a.h"); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for FileRelations; found %v", err)
+	}
+}
+
+func TestCallDegrees(t *testing.T) {
+	degrees := []*srvpb.CallDegrees{
+		{Ticket: "kythe://c?lang=go#f", Callers: 2, Callees: 1},
+		{Ticket: "kythe://c?lang=go#g", Callers: 1},
+	}
+	st := (&testTable{CallDegrees: degrees}).Construct(t)
+
+	found, err := st.CallDegrees(ctx, []string{"kythe://c?lang=go#f", "kythe:?lang=go#missing", "kythe://c?lang=go#g"})
+	testutil.Fatalf(t, "CallDegrees error: %v", err)
+	expected := map[string]*srvpb.CallDegrees{
+		"kythe://c?lang=go#f": degrees[0],
+		"kythe://c?lang=go#g": degrees[1],
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := st.CallDegrees(ctx, nil); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for no tickets; found %v", err)
+	}
+
+	st.Authorizer = &denyTickets{denied: stringset.New("kythe://c?lang=go#g")}
+	if _, err := st.CallDegrees(ctx, []string{"kythe://c?lang=go#f", "kythe://c?lang=go#g"}); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for CallDegrees; found %v", err)
+	}
+}
+
+func TestMembers(t *testing.T) {
+	const pkg = "kythe://c?lang=go?path=pkg#pkg"
+	st := (&testTable{Members: []*srvpb.ContainerMembers{{
+		Container: pkg,
+		Kind:      "function",
+		Member: []*srvpb.ContainerMembers_Member{{
+			Ticket:      "kythe://c?lang=go?path=pkg#A",
+			DisplayName: "pkg.A",
+			Definition: &srvpb.ExpandedAnchor{
+				Ticket: "kythe://c?lang=go?path=pkg/a.go#defA",
+				Text:   "A",
+				Span:   &cpb.Span{Start: &cpb.Point{ByteOffset: 5}, End: &cpb.Point{ByteOffset: 6}},
+			},
+		}, {
+			Ticket:      "kythe://c?lang=go?path=pkg#B",
+			DisplayName: "pkg.B",
+		}, {
+			Ticket:      "kythe://c?lang=go?path=pkg#C",
+			Subkind:     "method",
+			DisplayName: "pkg.C",
+		}},
+	}}}).Construct(t)
+
+	reply, err := st.Members(ctx, &xpb.MembersRequest{Container: pkg, Kind: "function", PageSize: 2})
+	testutil.Fatalf(t, "MembersRequest error: %v", err)
+	if reply.NextPageToken == "" {
+		t.Fatalf("Missing next page token: %v", reply)
+	}
+	expected := &xpb.MembersReply{
+		Member: []*xpb.MembersReply_Member{{
+			Ticket:      "kythe://c?lang=go?path=pkg#A",
+			DisplayName: "pkg.A",
+			Definition: &xpb.Anchor{
+				Ticket: "kythe://c?lang=go?path=pkg/a.go#defA",
+				Parent: "kythe://c?path=pkg/a.go",
+				Text:   "A",
+				Span:   &cpb.Span{Start: &cpb.Point{ByteOffset: 5}, End: &cpb.Point{ByteOffset: 6}},
+			},
+		}, {
+			Ticket:      "kythe://c?lang=go?path=pkg#B",
+			DisplayName: "pkg.B",
+		}},
+		Total:         3,
+		NextPageToken: reply.NextPageToken,
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+
+	reply, err = st.Members(ctx, &xpb.MembersRequest{Container: pkg, Kind: "function", PageToken: reply.NextPageToken})
+	testutil.Fatalf(t, "MembersRequest error: %v", err)
+	expected = &xpb.MembersReply{
+		Member: []*xpb.MembersReply_Member{{
+			Ticket:      "kythe://c?lang=go?path=pkg#C",
+			Subkind:     "method",
+			DisplayName: "pkg.C",
+		}},
+		Total: 3,
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+
+	// Containers without members of the requested kind have empty replies.
+	reply, err = st.Members(ctx, &xpb.MembersRequest{Container: pkg, Kind: "record"})
+	testutil.Fatalf(t, "MembersRequest error: %v", err)
+	if err := testutil.DeepEqual(&xpb.MembersReply{}, reply); err != nil {
+		t.Error(err)
+	}
+
+	for _, req := range []*xpb.MembersRequest{
+		{Kind: "function"},
+		{Container: pkg},
+		{Container: pkg, Kind: "function", PageToken: "!invalid"},
+	} {
+		if _, err := st.Members(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v; found %v", req, err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	RegisterHTTPHandlers(ctx, st, mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/members?proto=1", strings.NewReader(fmt.Sprintf(`{"container": %q, "kind": "function", "page_size": 1}`, pkg))))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /members: %d %s", w.Code, w.Body)
+	}
+	var httpReply xpb.MembersReply
+	testutil.Fatalf(t, "Unmarshal error: %v", proto.Unmarshal(w.Body.Bytes(), &httpReply))
+	if len(httpReply.Member) != 1 || httpReply.Member[0].Ticket != "kythe://c?lang=go?path=pkg#A" || httpReply.Total != 3 {
+		t.Errorf("Unexpected /members reply: %v", &httpReply)
+	}
+
+	// Unauthorized members and definitions in unauthorized files are withheld.
+	st.Authorizer = &denyTickets{denied: stringset.New("kythe://c?lang=go?path=pkg#B", "kythe://c?path=pkg/a.go")}
+	reply, err = st.Members(ctx, &xpb.MembersRequest{Container: pkg, Kind: "function"})
+	testutil.Fatalf(t, "MembersRequest error: %v", err)
+	expected = &xpb.MembersReply{
+		Member: []*xpb.MembersReply_Member{{
+			Ticket:      "kythe://c?lang=go?path=pkg#A",
+			DisplayName: "pkg.A",
+		}, {
+			Ticket:      "kythe://c?lang=go?path=pkg#C",
+			Subkind:     "method",
+			DisplayName: "pkg.C",
+		}},
+		Total: 2,
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+
+	st.Authorizer = &denyTickets{denied: stringset.New(pkg)}
+	if _, err := st.Members(ctx, &xpb.MembersRequest{Container: pkg, Kind: "function"}); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for Members; found %v", err)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/members", strings.NewReader(fmt.Sprintf(`{"container": %q, "kind": "function"}`, pkg))))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("GET /members: expected %d; found %d %s", http.StatusForbidden, w.Code, w.Body)
+	}
+}
+
+func TestRollup(t *testing.T) {
+	const (
+		pkg = "kythe://c?lang=go?path=pkg#pkg"
+		a   = "kythe://c?lang=go?path=pkg#A"
+		b   = "kythe://c?lang=go?path=pkg#B"
+		c   = "kythe://c?lang=go?path=pkg#C"
+	)
+	base := &testTable{Rollups: []*srvpb.ContainerRollup{{
+		Container:      pkg,
+		ReferenceCount: 10,
+		Target: []*srvpb.ContainerRollup_Target{
+			{Ticket: a, Kind: "function", ReferenceCount: 6},
+			{Ticket: b, Kind: "record", ReferenceCount: 3},
+			{Ticket: c, Kind: "function", ReferenceCount: 1},
+		},
+	}}}
+	st := base.Construct(t)
+
+	reply, err := st.Rollup(ctx, &xpb.RollupRequest{Container: pkg, PageSize: 2})
+	testutil.Fatalf(t, "RollupRequest error: %v", err)
+	if reply.NextPageToken == "" {
+		t.Fatalf("Missing next page token: %v", reply)
+	}
+	expected := &xpb.RollupReply{
+		ReferenceCount: 10,
+		TotalTargets:   3,
+		Target: []*xpb.RollupReply_Target{
+			{Ticket: a, Kind: "function", ReferenceCount: 6},
+			{Ticket: b, Kind: "record", ReferenceCount: 3},
+		},
+		NextPageToken: reply.NextPageToken,
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+
+	reply, err = st.Rollup(ctx, &xpb.RollupRequest{Container: pkg, PageToken: reply.NextPageToken})
+	testutil.Fatalf(t, "RollupRequest error: %v", err)
+	expected = &xpb.RollupReply{
+		ReferenceCount: 10,
+		TotalTargets:   3,
+		Target:         []*xpb.RollupReply_Target{{Ticket: c, Kind: "function", ReferenceCount: 1}},
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+
+	// Containers without referenced members have empty replies.
+	reply, err = st.Rollup(ctx, &xpb.RollupRequest{Container: a})
+	testutil.Fatalf(t, "RollupRequest error: %v", err)
+	if err := testutil.DeepEqual(&xpb.RollupReply{}, reply); err != nil {
+		t.Error(err)
+	}
+
+	for _, req := range []*xpb.RollupRequest{
+		{},
+		{Container: pkg, PageSize: -1},
+		{Container: pkg, PageToken: "!invalid"},
+	} {
+		if _, err := st.Rollup(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v; found %v", req, err)
+		}
+	}
+
+	// An overlay's reference counts are added to those of its base.
+	overlay := &testTable{Rollups: []*srvpb.ContainerRollup{{
+		Container:      pkg,
+		ReferenceCount: 4,
+		Target:         []*srvpb.ContainerRollup_Target{{Ticket: c, Kind: "function", ReferenceCount: 4}},
+	}}}
+	reply, err = NewOverlayTable(overlay.protoTable(t), base.protoTable(t)).Rollup(ctx, &xpb.RollupRequest{Container: pkg})
+	testutil.Fatalf(t, "RollupRequest error: %v", err)
+	expected = &xpb.RollupReply{
+		ReferenceCount: 14,
+		TotalTargets:   3,
+		Target: []*xpb.RollupReply_Target{
+			{Ticket: a, Kind: "function", ReferenceCount: 6},
+			{Ticket: c, Kind: "function", ReferenceCount: 5},
+			{Ticket: b, Kind: "record", ReferenceCount: 3},
+		},
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Errorf("Overlay: %v", err)
+	}
+
+	// Unauthorized targets are withheld and their references are not counted.
+	st.Authorizer = &denyTickets{denied: stringset.New(a)}
+	reply, err = st.Rollup(ctx, &xpb.RollupRequest{Container: pkg})
+	testutil.Fatalf(t, "RollupRequest error: %v", err)
+	expected = &xpb.RollupReply{
+		ReferenceCount: 4,
+		TotalTargets:   2,
+		Target: []*xpb.RollupReply_Target{
+			{Ticket: b, Kind: "record", ReferenceCount: 3},
+			{Ticket: c, Kind: "function", ReferenceCount: 1},
+		},
+	}
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Errorf("Authorized: %v", err)
+	}
+
+	st.Authorizer = &denyTickets{denied: stringset.New(pkg)}
+	if _, err := st.Rollup(ctx, &xpb.RollupRequest{Container: pkg}); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for Rollup; found %v", err)
+	}
+}
+
+func TestOutline(t *testing.T) {
+	const (
+		file   = "kythe://c?path=outline/file"
+		class  = "kythe://c?lang=l#C"
+		method = "kythe://c?lang=l#C.m"
+		field  = "kythe://c?lang=l#C.f"
+		fn     = "kythe://c?lang=l#fn"
+	)
+	symbol := func(ticket, kind string, start, end int32, parents ...string) *srvpb.FileOutline_Symbol {
+		return &srvpb.FileOutline_Symbol{
+			Ticket: ticket,
+			Kind:   kind,
+			Definition: &srvpb.ExpandedAnchor{
+				Ticket: fmt.Sprintf("kythe://c?lang=l?path=outline/file#%d-%d", start, end),
+				Kind:   "/kythe/edge/defines/binding",
+				Span: &cpb.Span{
+					Start: &cpb.Point{ByteOffset: start},
+					End:   &cpb.Point{ByteOffset: end},
+				},
+			},
+			Parent: parents,
+		}
+	}
+	st := (&testTable{Outlines: []*srvpb.FileOutline{{
+		File: file,
+		Symbol: []*srvpb.FileOutline_Symbol{
+			symbol(class, "record", 6, 7, file),
+			symbol(field, "variable", 12, 13, class),
+			symbol(method, "function", 20, 21, class),
+			symbol(fn, "function", 40, 42, file),
+		},
+	}}}).Construct(t)
+
+	reply, err := st.Outline(ctx, &xpb.OutlineRequest{File: file})
+	testutil.Fatalf(t, "OutlineRequest error: %v", err)
+	var tree []string
+	var walk func(indent string, syms []*xpb.OutlineReply_Symbol)
+	walk = func(indent string, syms []*xpb.OutlineReply_Symbol) {
+		for _, s := range syms {
+			tree = append(tree, indent+s.Ticket)
+			walk(indent+"  ", s.Child)
+		}
+	}
+	walk("", reply.Symbol)
+	if err := testutil.DeepEqual([]string{class, "  " + field, "  " + method, fn}, tree); err != nil {
+		t.Error(err)
+	}
+	if span := reply.Symbol[0].GetDefinition().GetSpan(); span.GetStart().GetByteOffset() != 6 {
+		t.Errorf("Unexpected definition span for %s: %v", class, span)
+	}
+
+	// An overridden file's outline is derived from its patched decorations,
+	// keeping the precomputed hierarchy of the symbols still defined.
+	st.DecorationOverrides = NewDecorationOverrides()
+	st.DecorationOverrides.Put(&srvpb.FileDecorations{
+		File: &srvpb.File{Ticket: file, Text: []byte("// edited\nclass C { void m() {} }\n")},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: &srvpb.RawAnchor{Ticket: file + "#16-17", StartOffset: 16, EndOffset: 17},
+			Kind:   "/kythe/edge/defines/binding",
+			Target: class,
+		}, {
+			Anchor: &srvpb.RawAnchor{Ticket: file + "#25-26", StartOffset: 25, EndOffset: 26},
+			Kind:   "/kythe/edge/defines/binding",
+			Target: method,
+		}},
+	}, 0)
+	reply, err = st.Outline(ctx, &xpb.OutlineRequest{File: file})
+	testutil.Fatalf(t, "OutlineRequest error: %v", err)
+	tree = nil
+	walk("", reply.Symbol)
+	if err := testutil.DeepEqual([]string{class, "  " + method}, tree); err != nil {
+		t.Error(err)
+	}
+	if s := reply.Symbol[0]; s.Kind != "record" || s.GetDefinition().GetSpan().GetStart().GetByteOffset() != 16 {
+		t.Errorf("Unexpected overridden symbol: %v", s)
+	}
+
+	// Files without a precomputed outline have a flat outline derived from
+	// their decorations.
+	text := []byte("class C { int f; }\n")
+	decoration := func(start, end int32, kind, target string) *srvpb.FileDecorations_Decoration {
+		return &srvpb.FileDecorations_Decoration{
+			Anchor: &srvpb.RawAnchor{
+				Ticket:      fmt.Sprintf("kythe://c?lang=l?path=outline/file#%d-%d", start, end),
+				StartOffset: start,
+				EndOffset:   end,
+			},
+			Kind:   kind,
+			Target: target,
+		}
+	}
+	st = (&testTable{Decorations: []*srvpb.FileDecorations{{
+		File: &srvpb.File{Ticket: file, Text: text},
+		Decoration: []*srvpb.FileDecorations_Decoration{
+			decoration(6, 7, "/kythe/edge/defines/binding", class),
+			decoration(14, 15, "/kythe/edge/defines/binding", field),
+			decoration(10, 13, "/kythe/edge/ref", "kythe://c?lang=l#int"),
+		},
+		Target: []*srvpb.Node{{
+			Ticket: class,
+			Fact: []*cpb.Fact{
+				{Name: facts.NodeKind, Value: []byte("record")},
+				{Name: facts.Subkind, Value: []byte("class")},
+			},
+		}},
+	}}}).Construct(t)
+
+	reply, err = st.Outline(ctx, &xpb.OutlineRequest{File: file})
+	testutil.Fatalf(t, "OutlineRequest error: %v", err)
+	if len(reply.Symbol) != 2 {
+		t.Fatalf("Expected 2 top-level symbols; found %v", reply)
+	}
+	if s := reply.Symbol[0]; s.Ticket != class || s.Kind != "record" || s.Subkind != "class" || s.GetDefinition().GetText() != "C" {
+		t.Errorf("Unexpected symbol: %v", s)
+	}
+	if s := reply.Symbol[1]; s.Ticket != field || s.GetDefinition().GetText() != "f" {
+		t.Errorf("Unexpected symbol: %v", s)
+	}
+
+	// A file reindexed by an overlay without an outline of its own is not
+	// served the base's stale outline.
+	base := &testTable{Outlines: []*srvpb.FileOutline{{
+		File:   file,
+		Symbol: []*srvpb.FileOutline_Symbol{symbol(fn, "function", 40, 42, file)},
+	}}}
+	overlay := &testTable{Decorations: []*srvpb.FileDecorations{{
+		File:       &srvpb.File{Ticket: file, Text: text},
+		Decoration: []*srvpb.FileDecorations_Decoration{decoration(6, 7, "/kythe/edge/defines/binding", class)},
+	}}}
+	reply, err = NewOverlayTable(overlay.protoTable(t), base.protoTable(t)).Outline(ctx, &xpb.OutlineRequest{File: file})
+	testutil.Fatalf(t, "OutlineRequest error: %v", err)
+	if len(reply.Symbol) != 1 || reply.Symbol[0].Ticket != class {
+		t.Errorf("Unexpected overlay outline: %v", reply)
+	}
+
+	if _, err := st.Outline(ctx, &xpb.OutlineRequest{File: "kythe://c?path=missing"}); err != xrefs.ErrDecorationsNotFound {
+		t.Errorf("Expected ErrDecorationsNotFound for missing file; found %v", err)
+	}
+	if _, err := st.Outline(ctx, &xpb.OutlineRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for missing file; found %v", err)
+	}
+}
+
+type requestLog []*RequestLogEntry
+
+func (l *requestLog) LogRequest(ctx context.Context, e *RequestLogEntry) { *l = append(*l, e) }
+
+func TestRequestLog(t *testing.T) {
+	file := tbl.Decorations[1].File.Ticket
+	ticket := "kythe://someCorpus?lang=otpl#signature"
+
+	var entries, copies requestLog
+	st := tbl.Construct(t)
+	st.RequestLog = MultiRequestLog(&entries, nil, &copies)
+
+	decor, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		References: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	xr, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if _, err := st.Documentation(ctx, &xpb.DocumentationRequest{}); err == nil {
+		t.Fatal("Expected error for DocumentationRequest without tickets")
+	}
+
+	type entry struct {
+		Method    string
+		Tickets   []string
+		ReplySize int
+		Failed    bool
+	}
+	var found []entry
+	for _, e := range entries {
+		if e.Start.IsZero() || e.Latency < 0 {
+			t.Errorf("Invalid timing for %s: %v %v", e.Method, e.Start, e.Latency)
+		}
+		found = append(found, entry{e.Method, e.Tickets, e.ReplySize, e.Err != nil})
+	}
+	expected := []entry{
+		{"Decorations", []string{file}, proto.Size(decor), false},
+		{"CrossReferences", []string{ticket}, proto.Size(xr), false},
+		{"Documentation", nil, 0, true},
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Error(err)
+	}
+	if len(copies) != len(entries) {
+		t.Errorf("Expected %d entries sent to the second sink; found %d", len(entries), len(copies))
+	}
+	for i, e := range copies {
+		if i < len(entries) && e != entries[i] {
+			t.Errorf("Entry %d sent to the second sink differs: %+v", i, e)
+		}
+	}
+
+	// No requests are sampled at a vanishingly small rate.
+	entries = nil
+	st.RequestLogSampleRate = math.SmallestNonzeroFloat64
+	for i := 0; i < 10; i++ {
+		_, err := st.Decorations(ctx, &xpb.DecorationsRequest{Location: &xpb.Location{Ticket: file}})
+		testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no sampled requests; found %d", len(entries))
+	}
+}
+
+func TestCrossReferencesPhaseTimings(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#signature"
+
+	var entries requestLog
+	st := tbl.Construct(t)
+	st.RequestLog = &entries
+
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		PhaseTimings:  true,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	var phases []string
+	for _, pt := range reply.GetDebugInfo().GetPhaseTiming() {
+		if d := pt.GetDuration().AsDuration(); d < 0 {
+			t.Errorf("Invalid duration for phase %q: %v", pt.Phase, d)
+		}
+		phases = append(phases, pt.Phase)
+	}
+	if err := testutil.DeepEqual([]string{PhaseFilter, PhaseRead}, phases); err != nil {
+		t.Error(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 logged request; found %d", len(entries))
+	}
+	var logged []string
+	for phase := range entries[0].Phases {
+		logged = append(logged, phase)
+	}
+	sort.Strings(logged)
+	if err := testutil.DeepEqual(phases, logged); err != nil {
+		t.Errorf("Logged phases: %v", err)
+	}
+
+	// Timings are only returned when requested.
+	reply, err = st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if reply.DebugInfo != nil {
+		t.Errorf("Unexpected DebugInfo: %v", reply.DebugInfo)
+	}
+}
+
+func TestHealthMonitor(t *testing.T) {
+	file := "kythe://corpus?path=file/infos"
+	ticket := "kythe://someCorpus?lang=otpl#signature"
+
+	now := time.Unix(1000, 0)
+	health := NewHealthMonitor()
+	health.Window = time.Minute
+	health.now = func() time.Time { return now }
+	st := tbl.Construct(t)
+	st.Health = health
+
+	_, err := st.Decorations(ctx, &xpb.DecorationsRequest{Location: &xpb.Location{Ticket: file}})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	for i := 0; i < 2; i++ {
+		_, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{Ticket: []string{ticket}})
+		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	}
+	if _, err := st.Documentation(ctx, &xpb.DocumentationRequest{}); err == nil {
+		t.Fatal("Expected error for DocumentationRequest without tickets")
+	}
+	health.RecordRequest([]string{ticket}, time.Hour, status.Error(codes.Unavailable, "backend down"))
+
+	expected := []*CorpusHealth{{
+		Corpus:    "corpus",
+		Requests:  1,
+		Reachable: true,
+	}, {
+		Corpus:    "someCorpus",
+		Requests:  3,
+		Errors:    1,
+		ErrorRate: 1.0 / 3,
+		Reachable: false,
+	}}
+	found := health.Health()
+	for _, h := range found {
+		// Latencies of served requests vary; only check they are plausible.
+		if h.MedianLatency < 0 || h.MedianLatency >= time.Hour {
+			t.Errorf("Unexpected %s median latency: %v", h.Corpus, h.MedianLatency)
+		}
+		h.MedianLatency = 0
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests outside of the window are dropped.
+	now = now.Add(2 * time.Minute)
+	health.RecordRequest([]string{ticket}, time.Second, nil)
+	expected = []*CorpusHealth{{
+		Corpus:        "someCorpus",
+		Requests:      1,
+		MedianLatency: time.Second,
+		Reachable:     true,
+	}}
+	if err := testutil.DeepEqual(expected, health.Health()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Median latencies are approximated by the monitor's histogram.
+	for _, latency := range []time.Duration{time.Millisecond, time.Millisecond, time.Minute, time.Minute} {
+		health.RecordRequest([]string{ticket}, latency, nil)
+	}
+	found = health.Health()
+	if len(found) != 1 || found[0].Requests != 5 {
+		t.Fatalf("Unexpected health: %v", found)
+	} else if m := found[0].MedianLatency; m < time.Second || float64(m) > float64(time.Second)*math.Sqrt2 {
+		t.Errorf("Expected median latency within √2 of %v; found %v", time.Second, m)
+	}
+}
+
+// denyTickets is an Authorizer denying a fixed set of tickets.
+type denyTickets struct {
+	denied stringset.Set
+	calls  int
+}
+
+func (d *denyTickets) Authorize(ctx context.Context, tickets []string) ([]bool, error) {
+	d.calls++
+	allowed := make([]bool, len(tickets))
+	for i, ticket := range tickets {
+		allowed[i] = !d.denied.Contains(ticket)
+	}
+	return allowed, nil
+}
+
+func TestAuthorizer(t *testing.T) {
+	const (
+		ticket     = "kythe://someCorpus?lang=otpl#signature"
+		restricted = "kythe://c?path=/a/path"
+	)
+	req := &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	}
+	st := tbl.Construct(t)
+	expected, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	var refs []*xpb.CrossReferencesReply_RelatedAnchor
+	for _, ref := range expected.CrossReferences[ticket].Reference {
+		if ref.Anchor.Parent != restricted {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == len(expected.CrossReferences[ticket].Reference) {
+		t.Fatalf("Expected references in %q: %v", restricted, expected)
+	}
+	expected.CrossReferences[ticket].Reference = refs
+
+	authz := &denyTickets{denied: stringset.New(restricted)}
+	st.Authorizer = authz
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if err := testutil.DeepEqual(expected, reply); err != nil {
+		t.Error(err)
+	}
+	// The requested tickets and the reply are each authorized in a batch.
+	if authz.calls != 2 {
+		t.Errorf("Expected 2 Authorize calls; found %d", authz.calls)
+	}
+
+	// Anchors are withheld even if the mask excludes their parent files.
+	masked := proto.Clone(req).(*xpb.CrossReferencesRequest)
+	masked.AnchorMask = &fieldmaskpb.FieldMask{Paths: []string{"span"}}
+	reply, err = st.CrossReferences(ctx, masked)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if n, want := len(reply.CrossReferences[ticket].GetReference()), len(refs); n != want {
+		t.Errorf("Expected %d masked references; found %d", want, n)
+	}
+
+	authz.denied.Add(ticket)
+	if _, err := st.CrossReferences(ctx, req); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for CrossReferences; found %v", err)
+	}
+	if _, err := st.Documentation(ctx, &xpb.DocumentationRequest{Ticket: []string{ticket}}); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for Documentation; found %v", err)
+	}
+	file := tbl.Decorations[1].File.Ticket
+	authz.denied.Add(file)
+	if _, err := st.Decorations(ctx, &xpb.DecorationsRequest{Location: &xpb.Location{Ticket: file}}); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for Decorations; found %v", err)
 	}
 }
 
-func TestCrossReferencesIndirection(t *testing.T) {
-	ticket := "kythe:#aliasNode"
+type collectingSink struct {
+	mu   sync.Mutex
+	refs []*xpb.CrossReferencesReply_RelatedAnchor
+
+	block chan struct{}
+}
+
+func (s *collectingSink) WriteReference(ctx context.Context, ticket string, ref *xpb.CrossReferencesReply_RelatedAnchor) error {
+	s.mu.Lock()
+	s.refs = append(s.refs, ref)
+	s.mu.Unlock()
+	if s.block != nil {
+		close(s.block)
+		s.block = nil
+		<-ctx.Done()
+	}
+	return nil
+}
+
+func TestReferenceReports(t *testing.T) {
+	ticket := "kythe://someCorpus?lang=otpl#signature"
+
 	st := tbl.Construct(t)
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		TotalsQuality: xpb.CrossReferencesRequest_PRECISE_TOTALS,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	expected := reply.CrossReferences[ticket].Reference
+	if len(expected) < 2 {
+		t.Fatalf("Expected multiple references; found %v", expected)
+	}
 
-	t.Run("none", func(t *testing.T) {
-		experimentalCrossReferenceIndirectionKinds = nil
+	defer func(size int) { *referenceReportPageSize = size }(*referenceReportPageSize)
+	*referenceReportPageSize = 1
+
+	reports := NewReferenceReports(st)
+	sink := &collectingSink{}
+	id, err := reports.StartReferenceReport(ctx, []string{ticket}, sink)
+	testutil.Fatalf(t, "StartReferenceReport error: %v", err)
+	<-reports.jobs[id].done
+
+	rs, err := reports.GetReferenceReport(ctx, id)
+	testutil.Fatalf(t, "GetReferenceReport error: %v", err)
+	if err := testutil.DeepEqual(&ReferenceReportStatus{
+		ID:      id,
+		Written: int64(len(expected)),
+		Total:   int64(len(expected)),
+		Done:    true,
+	}, rs); err != nil {
+		t.Error(err)
+	}
+	if err := testutil.DeepEqual(expected, sink.refs); err != nil {
+		t.Error(err)
+	}
 
-		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
-			Ticket:        []string{ticket},
-			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	// Cancel a report blocked on its first reference.
+	blocked := make(chan struct{})
+	sink = &collectingSink{block: blocked}
+	id, err = reports.StartReferenceReport(ctx, []string{ticket}, sink)
+	testutil.Fatalf(t, "StartReferenceReport error: %v", err)
+	<-blocked
+	testutil.Fatalf(t, "CancelReferenceReport error: %v", reports.CancelReferenceReport(ctx, id))
+	<-reports.jobs[id].done
+
+	rs, err = reports.GetReferenceReport(ctx, id)
+	testutil.Fatalf(t, "GetReferenceReport error: %v", err)
+	if !rs.Done || rs.Err != context.Canceled || rs.Written != 1 {
+		t.Errorf("Expected cancelled report after 1 reference; found %+v", rs)
+	}
+
+	if _, err := reports.GetReferenceReport(ctx, "unknown"); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error for unknown report; found %v", err)
+	}
+	if _, err := reports.StartReferenceReport(ctx, nil, sink); err == nil {
+		t.Error("Expected error starting report without tickets")
+	}
+}
+
+func TestWriter(t *testing.T) {
+	const node = "kythe://c?lang=go#writerNode"
+	var anchors []*srvpb.ExpandedAnchor
+	for i := 0; i < 5; i++ {
+		anchors = append(anchors, &srvpb.ExpandedAnchor{
+			Ticket: fmt.Sprintf("kythe://c?lang=go?path=file#ref%d", i),
+			Kind:   "/kythe/edge/ref",
 		})
-		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	}
+	cr := &srvpb.PagedCrossReferences{
+		SourceTicket: node,
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind:   "%/kythe/edge/ref",
+			Anchor: anchors,
+		}},
+	}
 
-		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
-			Ticket: ticket,
+	p := make(testProtoTable)
+	w := NewCombinedWriter(p)
+	w.PageSize = 2
+	testutil.Fatalf(t, "WriteCrossReferences error: %v", w.WriteCrossReferences(ctx, cr))
+	testutil.Fatalf(t, "WriteDisplayName error: %v", w.WriteDisplayName(ctx, &srvpb.DisplayName{Ticket: node, Name: "node"}))
+	if len(cr.PageIndex) != 0 || len(cr.Group[0].Anchor) != 5 {
+		t.Errorf("WriteCrossReferences modified its input: %v", cr)
+	}
+	if n := len(p); n != 4 {
+		t.Errorf("Expected 4 table entries (set, 2 pages, name); found %d", n)
+	}
 
-			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=somewhere#0-9",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=somewhere",
+	reply, err := NewCombinedTable(p).CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{node},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if n := reply.GetTotal().GetReferences(); n != 5 {
+		t.Errorf("Expected 5 total references; found %d", n)
+	}
+	var found []string
+	for _, ra := range reply.CrossReferences[node].GetReference() {
+		found = append(found, ra.Anchor.Ticket)
+	}
+	var expected []string
+	for _, a := range anchors {
+		expected = append(expected, a.Ticket)
+	}
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Error(err)
+	}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
-				},
-			}}},
-		}
+	if err := NewSplitWriter(&SplitTable{}).WriteDisplayName(ctx, &srvpb.DisplayName{Ticket: node}); err == nil {
+		t.Error("Expected error writing to unset split table")
+	}
+}
 
-		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
-			References: 1,
-		}, reply.Total); err != nil {
-			t.Error(err)
+func TestWriterDecorationPages(t *testing.T) {
+	const file = "kythe://c?path=file"
+	fd := &srvpb.FileDecorations{File: &srvpb.File{Ticket: file, Text: []byte("a b c d e")}}
+	var expected []string
+	for i := 4; i >= 0; i-- {
+		d := &srvpb.FileDecorations_Decoration{
+			Anchor: &srvpb.RawAnchor{StartOffset: int32(2 * i), EndOffset: int32(2*i + 1)},
+			Kind:   "/kythe/edge/ref",
+			Target: fmt.Sprintf("kythe://c?lang=go#node%d", i),
 		}
+		fd.Decoration = append(fd.Decoration, d)
+		expected = append([]string{d.Target}, expected...)
+	}
 
-		xr := reply.CrossReferences[ticket]
-		if xr == nil {
-			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
-		} else if err := testutil.DeepEqual(expected, xr); err != nil {
-			t.Fatal(err)
+	p := make(testProtoTable)
+	w := NewCombinedWriter(p)
+	w.PageSize = 2
+	testutil.Fatalf(t, "WriteDecorations error: %v", w.WriteDecorations(ctx, fd))
+	if len(fd.DecorationPage) != 0 || len(fd.Decoration) != 5 {
+		t.Errorf("WriteDecorations modified its input: %v", fd)
+	}
+	if n := len(p); n != 3 {
+		t.Errorf("Expected 3 table entries (decorations, 2 pages); found %d", n)
+	}
+
+	targets := func(loc *xpb.Location) ([]string, error) {
+		reply, err := NewCombinedTable(p).Decorations(ctx, &xpb.DecorationsRequest{
+			Location:   loc,
+			References: true,
+		})
+		if err != nil {
+			return nil, err
 		}
-	})
+		var found []string
+		for _, r := range reply.Reference {
+			found = append(found, r.TargetTicket)
+		}
+		return found, nil
+	}
+	found, err := targets(&xpb.Location{Ticket: file})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if err := testutil.DeepEqual(expected, found); err != nil {
+		t.Error(err)
+	}
 
-	t.Run("talias", func(t *testing.T) {
-		// Enable indirection for talias nodes.
-		experimentalCrossReferenceIndirectionKinds = nil
-		experimentalCrossReferenceIndirectionKinds.Set("talias=%/kythe/edge/aliases")
+	// Remove the last page; requests for spans outside of it should not read it.
+	delete(p, string(DecorationsPageKey(pagekey.New(pagekey.DecorationsPage, file, "", 1))))
+	spanLoc := &xpb.Location{
+		Ticket: file,
+		Kind:   xpb.Location_SPAN,
+		Span: &cpb.Span{
+			Start: &cpb.Point{ByteOffset: 6},
+			End:   &cpb.Point{ByteOffset: 7},
+		},
+	}
+	found, err = targets(spanLoc)
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if err := testutil.DeepEqual(expected[3:4], found); err != nil {
+		t.Error(err)
+	}
+	if _, err := targets(&xpb.Location{Ticket: file}); err == nil {
+		t.Error("Expected error reading missing decorations page")
+	}
+}
 
-		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
-			Ticket:        []string{ticket},
-			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
-		})
-		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+// largeCrossReferences returns a PagedCrossReferences with a group of n
+// anchors of each of the given kinds.
+func largeCrossReferences(n int, kinds ...string) *srvpb.PagedCrossReferences {
+	cr := &srvpb.PagedCrossReferences{
+		SourceTicket: "kythe://someCorpus?lang=otpl#large",
+		SourceNode:   &srvpb.Node{Ticket: "kythe://someCorpus?lang=otpl#large"},
+		PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+			PageKey: "largePage",
+			Kind:    "/kythe/edge/ref",
+			Count:   1,
+		}},
+	}
+	for _, kind := range kinds {
+		g := &srvpb.PagedCrossReferences_Group{Kind: kind, BuildConfig: "config"}
+		for i := 0; i < n; i++ {
+			g.Anchor = append(g.Anchor, &srvpb.ExpandedAnchor{
+				Ticket:  fmt.Sprintf("kythe://someCorpus?lang=otpl?path=%s#%d", kind, i),
+				Kind:    kind,
+				Text:    "text",
+				Span:    &cpb.Span{Start: &cpb.Point{ByteOffset: int32(i)}, End: &cpb.Point{ByteOffset: int32(i + 4)}},
+				Snippet: "some snippet text",
+			})
+		}
+		cr.Group = append(cr.Group, g)
+	}
+	return cr
+}
 
-		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
-			Ticket: ticket,
+func TestDecodeCrossReferences(t *testing.T) {
+	cr := largeCrossReferences(3, "/kythe/edge/defines/binding", "/kythe/edge/ref", "%/kythe/edge/childof")
+	rec, err := proto.Marshal(cr)
+	testutil.Fatalf(t, "Error marshaling PagedCrossReferences: %v", err)
 
-			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=somewhere#0-9",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=somewhere",
+	var headers []string
+	found, err := decodeCrossReferences(rec, func(header *srvpb.PagedCrossReferences, kind, buildConfig string) bool {
+		if len(header.PageIndex) != 1 || len(header.Group) != 0 {
+			t.Errorf("Unexpected header: %v", header)
+		}
+		headers = append(headers, kind+" "+buildConfig)
+		return kind == "/kythe/edge/ref"
+	})
+	testutil.Fatalf(t, "decodeCrossReferences error: %v", err)
+	expected := proto.Clone(cr).(*srvpb.PagedCrossReferences)
+	expected.Group = expected.Group[1:2]
+	if diff := compare.ProtoDiff(expected, found); diff != "" {
+		t.Errorf("Unexpected PagedCrossReferences: (-: expected; +: found)\n%s", diff)
+	}
+	if err := testutil.DeepEqual([]string{
+		"/kythe/edge/defines/binding config",
+		"/kythe/edge/ref config",
+		"%/kythe/edge/childof config",
+	}, headers); err != nil {
+		t.Errorf("Unexpected group headers: %v", err)
+	}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
-				},
-			}}, {Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=some/utf16/file#0-4",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=some/utf16/file",
+	if _, err := decodeCrossReferences(rec[:len(rec)-1], func(*srvpb.PagedCrossReferences, string, string) bool { return false }); err == nil {
+		t.Error("Expected error decoding truncated PagedCrossReferences")
+	}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 4, LineNumber: 1, ColumnOffset: 4},
-				},
-			}}, {Anchor: &xpb.Anchor{
-				Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe://c?path=/a/path",
+	// Requests read through a KVProto decode only the groups they use.
+	kv := &table.KVProto{inmemory.NewKeyValueDB()}
+	testutil.Fatalf(t, "Put error: %v", kv.Put(ctx, []byte(cr.SourceTicket), cr))
+	st := NewSplitTable(&SplitTable{
+		CrossReferences:  kv,
+		RewriteEdgeLabel: func(context.Context) func(string) string { return func(k string) string { return k } },
+	})
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:         []string{cr.SourceTicket},
+		DefinitionKind: xpb.CrossReferencesRequest_BINDING_DEFINITIONS,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if n := len(reply.CrossReferences[cr.SourceTicket].GetDefinition()); n != 3 {
+		t.Errorf("Expected 3 definitions; found %d", n)
+	} else if n := len(reply.CrossReferences[cr.SourceTicket].GetReference()); n != 0 {
+		t.Errorf("Expected no references; found %d", n)
+	}
+}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{
-						ByteOffset:   51,
-						LineNumber:   4,
-						ColumnOffset: 15,
-					},
-					End: &cpb.Point{
-						ByteOffset:   55,
-						LineNumber:   5,
-						ColumnOffset: 2,
-					},
-				},
-			}}},
-		}
+func TestDecodeFile(t *testing.T) {
+	const file = "kythe://c?path=lib.go"
+	fd := &srvpb.FileDecorations{
+		File: &srvpb.File{Ticket: file, Text: []byte("package lib\n"), Encoding: "utf-8"},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: &srvpb.RawAnchor{Ticket: file + "#a", StartOffset: 8, EndOffset: 11},
+			Kind:   "/kythe/edge/defines/binding",
+			Target: "kythe://c?lang=go#lib",
+		}},
+	}
+	rec, err := proto.Marshal(fd)
+	testutil.Fatalf(t, "Error marshaling FileDecorations: %v", err)
 
-		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
-			References: 3,
-		}, reply.Total); err != nil {
-			t.Error(err)
-		}
+	found, err := decodeFile(rec)
+	testutil.Fatalf(t, "decodeFile error: %v", err)
+	if diff := compare.ProtoDiff(fd.File, found); diff != "" {
+		t.Errorf("Unexpected File: (-: expected; +: found)\n%s", diff)
+	}
+	if _, err := decodeFile(rec[:len(rec)-1]); err == nil {
+		t.Error("Expected error decoding truncated FileDecorations")
+	}
 
-		xr := reply.CrossReferences[ticket]
-		if xr == nil {
-			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
-		}
+	// GetFile reads only the File through a KVProto.
+	kv := &table.KVProto{inmemory.NewKeyValueDB()}
+	testutil.Fatalf(t, "Put error: %v", kv.Put(ctx, []byte(file), fd))
+	st := NewSplitTable(&SplitTable{Decorations: kv})
+	content, err := st.GetFile(ctx, file, &GetFileOptions{Start: 8, End: 11})
+	testutil.Fatalf(t, "GetFile error: %v", err)
+	if string(content.Text) != "lib" || content.Encoding != "utf-8" {
+		t.Errorf("Unexpected file content: %+v", content)
+	}
+}
 
-		sort.Sort(byOffset(xr.Reference))
-		if err := testutil.DeepEqual(expected, xr); err != nil {
-			t.Fatal(err)
+// BenchmarkDecodeCrossReferences compares decoding a PagedCrossReferences
+// whole with decoding only the group of a single kind, as for a
+// CrossReferencesRequest for definitions only.
+func BenchmarkDecodeCrossReferences(b *testing.B) {
+	cr := largeCrossReferences(5000, "/kythe/edge/defines/binding", "/kythe/edge/ref", "/kythe/edge/ref/call", "%/kythe/edge/childof")
+	rec, err := proto.Marshal(cr)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("whole", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var cr srvpb.PagedCrossReferences
+			if err := proto.Unmarshal(rec, &cr); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("definitions", func(b *testing.B) {
+		b.ReportAllocs()
+		want := func(_ *srvpb.PagedCrossReferences, kind, _ string) bool { return kind == "/kythe/edge/defines/binding" }
+		for i := 0; i < b.N; i++ {
+			if _, err := decodeCrossReferences(rec, want); err != nil {
+				b.Fatal(err)
+			}
 		}
 	})
+}
 
-	t.Run("wildcard", func(t *testing.T) {
-		// Enable indirection for any nodes with a reverse aliases edge.
-		experimentalCrossReferenceIndirectionKinds = nil
-		experimentalCrossReferenceIndirectionKinds.Set("*=%/kythe/edge/aliases")
+func TestConvertTables(t *testing.T) {
+	const (
+		file = "kythe://c?path=file"
+		node = "kythe://c?lang=go#node"
+	)
+	combined := inmemory.NewKeyValueDB()
+	w := NewCombinedWriter(&table.KVProto{DB: combined})
+	testutil.Fatalf(t, "WriteDecorations error: %v", w.WriteDecorations(ctx, &srvpb.FileDecorations{File: &srvpb.File{Ticket: file}}))
+	testutil.Fatalf(t, "WriteDocument error: %v", w.WriteDocument(ctx, &srvpb.Document{Ticket: node}))
+	testutil.Fatalf(t, "WriteDisplayName error: %v", w.WriteDisplayName(ctx, &srvpb.DisplayName{Ticket: node, Name: "node"}))
+	testutil.Fatalf(t, "WriteContainerRollup error: %v", w.WriteContainerRollup(ctx, &srvpb.ContainerRollup{Container: node}))
+	wr, err := combined.Writer(ctx)
+	testutil.Fatalf(t, "Writer error: %v", err)
+	testutil.Fatalf(t, "Write error: %v", wr.Write(DecorationsFilterKey, []byte("filter")))
+	// The graph entries sharing the combined table are converted too.
+	testutil.Fatalf(t, "Write error: %v", wr.Write(gsrv.EdgeSetKey(node), []byte("edges")))
+	testutil.Fatalf(t, "Write error: %v", wr.Write(gsrv.FactHashesKey(node), []byte("hashes")))
+	testutil.Fatalf(t, "Write error: %v", wr.Write(gsrv.FactHashesGenerationKey, []byte("generation")))
+	testutil.Fatalf(t, "Close error: %v", wr.Close())
+
+	newSplit := func() *SplitDBs {
+		return &SplitDBs{
+			Decorations:         inmemory.NewKeyValueDB(),
+			DecorationPages:     inmemory.NewKeyValueDB(),
+			DecorationsFilter:   inmemory.NewKeyValueDB(),
+			CrossReferences:     inmemory.NewKeyValueDB(),
+			CrossReferencePages: inmemory.NewKeyValueDB(),
+			Documentation:       inmemory.NewKeyValueDB(),
+			DisplayNames:        inmemory.NewKeyValueDB(),
+			ContainerRollups:    inmemory.NewKeyValueDB(),
+			Edges:               inmemory.NewKeyValueDB(),
+			FactHashes:          inmemory.NewKeyValueDB(),
+		}
+	}
+	entries := func(db keyvalue.DB) map[string]string {
+		it, err := db.ScanPrefix(ctx, nil, nil)
+		testutil.Fatalf(t, "ScanPrefix error: %v", err)
+		defer it.Close()
+		m := make(map[string]string)
+		for {
+			k, v, err := it.Next()
+			if err == io.EOF {
+				return m
+			}
+			testutil.Fatalf(t, "Next error: %v", err)
+			m[string(k)] = string(v)
+		}
+	}
 
-		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
-			Ticket:        []string{ticket},
-			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
-		})
-		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	split := newSplit()
+	var checkpoints []*ConvertCheckpoint
+	err = CombinedToSplit(ctx, combined, split, &ConvertOptions{
+		CheckpointInterval: 1,
+		Progress:           func(c *ConvertCheckpoint) { checkpoints = append(checkpoints, c) },
+	})
+	testutil.Fatalf(t, "CombinedToSplit error: %v", err)
+	if len(checkpoints) != 8 || checkpoints[7].Entries != 8 {
+		t.Fatalf("Expected 8 checkpoints; found %v", checkpoints)
+	}
 
-		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
-			Ticket: ticket,
+	st := NewSplitTable(&SplitTable{
+		Decorations:         &table.KVProto{DB: split.Decorations},
+		CrossReferences:     &table.KVProto{DB: split.CrossReferences},
+		CrossReferencePages: &table.KVProto{DB: split.CrossReferencePages},
+		Documentation:       &table.KVProto{DB: split.Documentation},
+		DisplayNames:        &table.KVProto{DB: split.DisplayNames},
+	})
+	dn, err := st.displayName(ctx, node)
+	testutil.Fatalf(t, "displayName error: %v", err)
+	if dn.GetName() != "node" {
+		t.Errorf("Expected display name %q; found %v", "node", dn)
+	}
+	if err := testutil.DeepEqual(map[string]string{string(DecorationsFilterKey): "filter"}, entries(split.DecorationsFilter)); err != nil {
+		t.Error(err)
+	}
+	if err := testutil.DeepEqual(map[string]string{node: "edges"}, entries(split.Edges)); err != nil {
+		t.Error(err)
+	}
+	if err := testutil.DeepEqual(map[string]string{
+		node:                                 "hashes",
+		string(gsrv.FactHashesGenerationKey): "generation",
+	}, entries(split.FactHashes)); err != nil {
+		t.Error(err)
+	}
 
-			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=somewhere#0-9",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=somewhere",
+	// Converting back yields the original entries.
+	expected := entries(combined)
+	back := inmemory.NewKeyValueDB()
+	testutil.Fatalf(t, "SplitToCombined error: %v", SplitToCombined(ctx, split, back, nil))
+	if err := testutil.DeepEqual(expected, entries(back)); err != nil {
+		t.Error(err)
+	}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
-				},
-			}}, {Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=some/utf16/file#0-4",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=some/utf16/file",
+	// Checkpoints are independent of the layout and resume a conversion.
+	resumed := inmemory.NewKeyValueDB()
+	cp := checkpoints[1]
+	testutil.Fatalf(t, "SplitToCombined error: %v", SplitToCombined(ctx, split, resumed, &ConvertOptions{Resume: cp}))
+	found := entries(resumed)
+	if len(found) != len(expected)-int(cp.Entries) {
+		t.Errorf("Expected %d resumed entries; found %v", len(expected)-int(cp.Entries), found)
+	}
+	for k, v := range found {
+		if expected[k] != v {
+			t.Errorf("Unexpected entry %q: %q", k, v)
+		}
+	}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 4, LineNumber: 1, ColumnOffset: 4},
-				},
-			}}, {Anchor: &xpb.Anchor{
-				Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe://c?path=/a/path",
+	// Optional tables missing from the destination are an error.
+	split = newSplit()
+	split.ContainerRollups = nil
+	if err := CombinedToSplit(ctx, combined, split, nil); err == nil {
+		t.Error("Expected error for missing ContainerRollups table")
+	}
+}
+func TestCrossReferencesPageKeyMismatch(t *testing.T) {
+	const (
+		node  = "kythe://c?lang=go#node"
+		other = "kythe://c?lang=go#other"
+	)
+	key := pagekey.New(pagekey.CrossReferencesPage, node, "%/kythe/edge/ref", 0)
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: node,
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+				Kind:    "%/kythe/edge/ref",
+				Count:   1,
+				PageKey: key,
+			}},
+		}},
+		// A page of another node stored under the key, e.g. by a stale write.
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey:      key,
+			SourceTicket: other,
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind:   "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe://c?lang=go?path=file#ref"}},
+			},
+		}},
+	}).Construct(t)
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{
-						ByteOffset:   51,
-						LineNumber:   4,
-						ColumnOffset: 15,
-					},
-					End: &cpb.Point{
-						ByteOffset:   55,
-						LineNumber:   5,
-						ColumnOffset: 2,
-					},
-				},
-			}}},
-		}
+	if _, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{node},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	}); err == nil {
+		t.Error("Expected error reading cross-references page of another node")
+	}
 
-		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
-			References: 3,
-		}, reply.Total); err != nil {
-			t.Error(err)
-		}
+	w := NewCombinedWriter(make(testProtoTable))
+	if err := w.WriteCrossReferencesPage(ctx, &srvpb.PagedCrossReferences_Page{PageKey: key, SourceTicket: other}); err == nil {
+		t.Error("Expected error writing cross-references page under a key of another node")
+	}
+}
 
-		xr := reply.CrossReferences[ticket]
-		if xr == nil {
-			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
-		}
+func TestMemoryTable(t *testing.T) {
+	const (
+		file = "kythe://c?path=file"
+		node = "kythe://c?path=file?lang=go#node"
+	)
+	m := NewMemoryTables()
+	testutil.Fatalf(t, "PutDecorations error: %v", m.PutDecorations(&srvpb.FileDecorations{
+		File: &srvpb.File{Ticket: file, Text: []byte("text")},
+	}))
+	testutil.Fatalf(t, "PutCrossReferences error: %v", m.PutCrossReferences(&srvpb.PagedCrossReferences{
+		SourceTicket: node,
+		Group: []*srvpb.PagedCrossReferences_Group{{
+			Kind:   "%/kythe/edge/ref",
+			Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe://c?path=file#ref", Kind: "/kythe/edge/ref"}},
+		}},
+	}))
+	if err := m.PutDisplayName(&srvpb.DisplayName{Ticket: "bad:ticket"}); err == nil {
+		t.Error("Expected error for invalid ticket")
+	}
+	tbl := NewMemoryTable(m)
 
-		sort.Sort(byOffset(xr.Reference))
-		if err := testutil.DeepEqual(expected, xr); err != nil {
-			t.Fatal(err)
-		}
+	decor, err := tbl.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		SourceText: true,
 	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if string(decor.SourceText) != "text" {
+		t.Errorf("Unexpected decorations: %v", decor)
+	}
 
-	t.Run("single_indirect", func(t *testing.T) {
-		// Enable single indirection for talias nodes.
-		experimentalCrossReferenceIndirectionKinds = nil
-		experimentalCrossReferenceIndirectionKinds.Set("talias=/kythe/edge/indirect")
+	// Tickets are canonicalized when added.
+	canonical := "kythe://c?lang=go?path=file#node"
+	reply, err := tbl.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{canonical},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if refs := reply.CrossReferences[canonical].GetReference(); len(refs) != 1 {
+		t.Errorf("Unexpected references: %v", refs)
+	}
 
-		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
-			Ticket:        []string{ticket},
-			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
-		})
-		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	// Memory tables know exactly which files are decorated.
+	reply, err = tbl.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:               []string{canonical},
+		ReferenceKind:        xpb.CrossReferencesRequest_ALL_REFERENCES,
+		MarkUnavailableFiles: true,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	for _, ra := range reply.CrossReferences[canonical].GetReference() {
+		if ra.Anchor.FileUnavailable {
+			t.Errorf("Anchor in decorated file marked unavailable: %v", ra.Anchor)
+		}
+	}
 
-		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
-			Ticket: ticket,
+	if _, err := tbl.Decorations(ctx, &xpb.DecorationsRequest{Location: &xpb.Location{Ticket: "kythe://c?path=missing"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound error; found %v", err)
+	}
+}
 
-			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=somewhere#0-9",
+func TestDecorationOverrides(t *testing.T) {
+	const file = "kythe://c?path=file"
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("old text")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: file + "#0-3", StartOffset: 0, EndOffset: 3},
 				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=somewhere",
+				Target: "kythe://c#old",
+			}},
+		}},
+	}).Construct(t)
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
-				},
-			}}, {Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=somewhereElse#0-9",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=somewhereElse",
+	now := time.Unix(0, 0)
+	overrides := NewDecorationOverrides()
+	overrides.now = func() time.Time { return now }
+	var invalidated []string
+	overrides.OnInvalidate(func(ticket string) { invalidated = append(invalidated, ticket) })
+	st.DecorationOverrides = overrides
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
-				},
-			}}},
+	req := &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		SourceText: true,
+		References: true,
+	}
+	targets := func() (string, []string) {
+		reply, err := st.Decorations(ctx, req)
+		testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+		var ts []string
+		for _, r := range reply.Reference {
+			ts = append(ts, r.TargetTicket)
 		}
-
-		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
-			References: 2,
-		}, reply.Total); err != nil {
+		return string(reply.SourceText), ts
+	}
+	check := func(expectedText, expectedTarget string) {
+		t.Helper()
+		text, ts := targets()
+		if text != expectedText {
+			t.Errorf("Expected text %q; found %q", expectedText, text)
+		}
+		if err := testutil.DeepEqual([]string{expectedTarget}, ts); err != nil {
 			t.Error(err)
 		}
+	}
 
-		xr := reply.CrossReferences[ticket]
-		if xr == nil {
-			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
-		}
+	check("old text", "kythe://c#old")
 
-		sort.Sort(byOffset(xr.Reference))
-		if err := testutil.DeepEqual(expected, xr); err != nil {
-			t.Fatal(err)
-		}
-	})
+	overrides.Put(&srvpb.FileDecorations{
+		File: &srvpb.File{Ticket: file, Text: []byte("new text!")},
+		Decoration: []*srvpb.FileDecorations_Decoration{{
+			Anchor: &srvpb.RawAnchor{Ticket: file + "#4-8", StartOffset: 4, EndOffset: 8},
+			Kind:   "/kythe/edge/ref",
+			Target: "kythe://c#new",
+		}},
+	}, time.Minute)
+	check("new text!", "kythe://c#new")
+
+	// Expired overrides are dropped.
+	now = now.Add(2 * time.Minute)
+	check("old text", "kythe://c#old")
+	if err := testutil.DeepEqual([]string{file}, invalidated); err != nil {
+		t.Errorf("Unexpected invalidations: %v", err)
+	}
 
-	t.Run("double_indirect", func(t *testing.T) {
-		// Enable double indirection for talias nodes.
-		experimentalCrossReferenceIndirectionKinds = nil
-		experimentalCrossReferenceIndirectionKinds.Set("talias=/kythe/edge/indirect,indirect=/kythe/edge/indirect")
+	overrides.Put(&srvpb.FileDecorations{File: &srvpb.File{Ticket: file, Text: []byte("new text!")}}, 0)
+	text, _ := targets()
+	if text != "new text!" {
+		t.Errorf("Expected override text; found %q", text)
+	}
+	overrides.Invalidate(file)
+	check("old text", "kythe://c#old")
+	if err := testutil.DeepEqual([]string{file, file}, invalidated); err != nil {
+		t.Errorf("Unexpected invalidations: %v", err)
+	}
+}
 
-		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
-			Ticket:        []string{ticket},
-			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
-		})
-		testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+func TestOverlayTable(t *testing.T) {
+	const (
+		file = "kythe://c?path=file"
+		node = "kythe://c#node"
+	)
+	anchor := func(sig string) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{Ticket: "kythe://c?path=file#" + sig}
+	}
+	other := func(sig string) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{Ticket: "kythe://c?path=other#" + sig}
+	}
+	base := &testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("old text")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: file + "#0-3", StartOffset: 0, EndOffset: 3},
+				Kind:   "/kythe/edge/ref",
+				Target: "kythe://c#old",
+			}},
+			Target: []*srvpb.Node{{Ticket: "kythe://c#old"}},
+		}},
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: node,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("a1"), anchor("a2"), other("o1")},
+			}, {
+				Kind:   "/kythe/edge/defines/binding",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("def"), other("def")},
+			}, {
+				Kind:   "/kythe/edge/ref/call",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("call")},
+			}},
+			PageSearchIndex: &srvpb.PagedCrossReferences_PageSearchIndex{},
+		}},
+		Documents: []*srvpb.Document{{Ticket: node, RawText: "base docs"}},
+	}
+	overlay := &testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("new text!")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: file + "#4-8", StartOffset: 4, EndOffset: 8},
+				Kind:   "/kythe/edge/ref",
+				Target: "kythe://c#new",
+			}},
+			Target: []*srvpb.Node{{Ticket: "kythe://c#new"}},
+		}},
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: node,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{anchor("a2"), anchor("a3")},
+			}},
+		}},
+	}
+	st := NewOverlayTable(overlay.protoTable(t), base.protoTable(t))
 
-		expected := &xpb.CrossReferencesReply_CrossReferenceSet{
-			Ticket: ticket,
+	decor, err := st.fileDecorations(ctx, file)
+	testutil.Fatalf(t, "fileDecorations error: %v", err)
+	if text := string(decor.File.Text); text != "new text!" {
+		t.Errorf("Expected overlay text; found %q", text)
+	}
+	if len(decor.Decoration) != 1 || decor.Decoration[0].Target != "kythe://c#new" {
+		t.Errorf("Expected only overlay decorations; found %v", decor.Decoration)
+	}
+	if len(decor.Target) != 2 {
+		t.Errorf("Expected merged targets; found %v", decor.Target)
+	}
 
-			Reference: []*xpb.CrossReferencesReply_RelatedAnchor{{Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=somewhere#0-9",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=somewhere",
+	cr, err := st.crossReferences(ctx, node)
+	testutil.Fatalf(t, "crossReferences error: %v", err)
+	var refs []string
+	for _, a := range cr.Group[0].Anchor {
+		refs = append(refs, a.Ticket)
+	}
+	// Base anchors in the reindexed file are dropped.
+	if err := testutil.DeepEqual([]string{
+		"kythe://c?path=file#a2",
+		"kythe://c?path=file#a3",
+		"kythe://c?path=other#o1",
+	}, refs); err != nil {
+		t.Error(err)
+	}
+	if len(cr.Group) != 2 || cr.Group[1].Kind != "/kythe/edge/defines/binding" {
+		t.Fatalf("Expected base definitions group; found %v", cr.Group)
+	}
+	if defs := cr.Group[1].Anchor; len(defs) != 1 || defs[0].Ticket != "kythe://c?path=other#def" {
+		t.Errorf("Expected only definitions outside the reindexed file; found %v", defs)
+	}
+	if cr.PageSearchIndex != nil {
+		t.Errorf("Unexpected page search index for merged set: %v", cr.PageSearchIndex)
+	}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
-				},
-			}}, {Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=somewhereElse#0-9",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=somewhereElse",
+	doc, err := st.documentation(ctx, node)
+	testutil.Fatalf(t, "documentation error: %v", err)
+	if doc.RawText != "base docs" {
+		t.Errorf("Expected base documentation; found %v", doc)
+	}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 9, LineNumber: 1, ColumnOffset: 9},
-				},
-			}}, {Anchor: &xpb.Anchor{
-				Ticket: "kythe:?path=some/utf16/file#0-4",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe:?path=some/utf16/file",
+	if _, err := st.crossReferences(ctx, "kythe://c#missing"); err != table.ErrNoSuchKey {
+		t.Errorf("Expected ErrNoSuchKey; found %v", err)
+	}
+}
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{LineNumber: 1},
-					End:   &cpb.Point{ByteOffset: 4, LineNumber: 1, ColumnOffset: 4},
+func TestOverlayTablePageCounts(t *testing.T) {
+	const (
+		file = "kythe://c?path=file"
+		node = "kythe://c#node"
+	)
+	base := &testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: node,
+			PageIndex: []*srvpb.PagedCrossReferences_PageIndex{{
+				PageKey: "refs",
+				Kind:    "/kythe/edge/ref",
+				Count:   3,
+			}},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey: "refs",
+			Group: &srvpb.PagedCrossReferences_Group{
+				Kind: "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{
+					{Ticket: file + "#a1"},
+					{Ticket: file + "#a2"},
+					{Ticket: "kythe://c?path=other#o1"},
 				},
-			}}, {Anchor: &xpb.Anchor{
-				Ticket: "kythe://c?lang=otpl?path=/a/path#51-55",
-				Kind:   "/kythe/edge/ref",
-				Parent: "kythe://c?path=/a/path",
+			},
+		}},
+	}
+	overlay := &testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("new text")},
+		}},
+	}
+	st := NewOverlayTable(overlay.protoTable(t), base.protoTable(t))
 
-				Span: &cpb.Span{
-					Start: &cpb.Point{
-						ByteOffset:   51,
-						LineNumber:   4,
-						ColumnOffset: 15,
-					},
-					End: &cpb.Point{
-						ByteOffset:   55,
-						LineNumber:   5,
-						ColumnOffset: 2,
-					},
-				},
-			}}},
-		}
+	// The page's anchors in the reindexed file are neither counted nor paged.
+	req := &xpb.CrossReferencesRequest{
+		Ticket:        []string{node},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		PageSize:      1,
+	}
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+	if n := reply.GetTotal().GetReferences(); n != 1 {
+		t.Errorf("Expected 1 total reference; found %d", n)
+	}
+	var refs []string
+	for _, ra := range reply.CrossReferences[node].GetReference() {
+		refs = append(refs, ra.Anchor.Ticket)
+	}
+	if err := testutil.DeepEqual([]string{"kythe://c?path=other#o1"}, refs); err != nil {
+		t.Error(err)
+	}
+	if reply.NextPageToken != "" {
+		t.Errorf("Unexpected next_page_token: %q", reply.NextPageToken)
+	}
+}
 
-		if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
-			References: 4,
-		}, reply.Total); err != nil {
-			t.Error(err)
-		}
+func TestCrossReferencesInvalidRequest(t *testing.T) {
+	st := tbl.Construct(t)
+	_, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:    []string{"kythe://someCorpus?lang=otpl#signature", "bad:ticket"},
+		PageSize:  -1,
+		PageToken: "!notbase64",
+	})
+	if c := status.Code(err); c != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument; found %v", err)
+	}
+	var fields []string
+	for _, v := range validate.FieldViolations(err) {
+		fields = append(fields, v.Field)
+	}
+	if err := testutil.DeepEqual([]string{"ticket[1]", "page_size", "page_token"}, fields); err != nil {
+		t.Error(err)
+	}
+}
 
-		xr := reply.CrossReferences[ticket]
-		if xr == nil {
-			t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
-		}
+func TestCrossReferencesAcceptPartialTickets(t *testing.T) {
+	const (
+		found   = "kythe://someCorpus?lang=otpl#signature"
+		invalid = "bad:ticket"
+	)
+	st := tbl.Construct(t)
+	req := &xpb.CrossReferencesRequest{
+		Ticket:               []string{found, invalid, "", invalid, ""},
+		ReferenceKind:        xpb.CrossReferencesRequest_ALL_REFERENCES,
+		AcceptPartialTickets: true,
+	}
+	reply, err := st.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if _, ok := reply.CrossReferences[found]; !ok {
+		t.Errorf("Missing expected CrossReferences; found: %v", reply)
+	}
+	// Each invalid ticket is reported by its position, even if empty or
+	// repeated.
+	var fields []string
+	for field := range reply.InvalidTicket {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	if err := testutil.DeepEqual([]string{"ticket[1]", "ticket[2]", "ticket[3]", "ticket[4]"}, fields); err != nil {
+		t.Errorf("Invalid tickets: %v", err)
+	}
 
-		sort.Sort(byOffset(xr.Reference))
-		if err := testutil.DeepEqual(expected, xr); err != nil {
-			t.Fatal(err)
-		}
-	})
+	// Requests without a valid ticket still fail.
+	req.Ticket = []string{invalid}
+	if _, err := st.CrossReferences(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument error without valid tickets; found %v", err)
+	}
 }
 
 func TestCrossReferencesDirectCallers(t *testing.T) {
@@ -2486,17 +6920,219 @@ func TestCrossReferencesOverrideCallers(t *testing.T) {
 		}},
 	}
 
-	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
-		Callers: 3,
-	}, reply.Total); err != nil {
-		t.Error(err)
+	if err := testutil.DeepEqual(&xpb.CrossReferencesReply_Total{
+		Callers: 3,
+	}, reply.Total); err != nil {
+		t.Error(err)
+	}
+
+	xr := reply.CrossReferences[ticket]
+	if xr == nil {
+		t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
+	} else if err := testutil.DeepEqual(expected, xr); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCrossReferencesCallSitesByCaller(t *testing.T) {
+	const (
+		ticket = "kythe://c?lang=otpl#callee"
+		file   = "kythe://c?path=file"
+	)
+	span := func(start, end int32) *cpb.Span {
+		return &cpb.Span{
+			Start: &cpb.Point{ByteOffset: start},
+			End:   &cpb.Point{ByteOffset: end},
+		}
+	}
+	anchor := func(name string, start, end int32) *srvpb.ExpandedAnchor {
+		return &srvpb.ExpandedAnchor{Ticket: file + "#" + name, Span: span(start, end)}
+	}
+	st := (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind: "#internal/ref/call/direct",
+				Caller: []*srvpb.PagedCrossReferences_Caller{{
+					Caller:         anchor("f", 0, 10),
+					SemanticCaller: "kythe://c?lang=otpl#f",
+					Callsite:       []*srvpb.ExpandedAnchor{anchor("call2", 6, 8)},
+				}, {
+					Caller:         anchor("g", 20, 30),
+					SemanticCaller: "kythe://c?lang=otpl#g",
+					Callsite:       []*srvpb.ExpandedAnchor{anchor("call3", 22, 24)},
+				}},
+			}, {
+				Kind: "#internal/ref/call/override",
+				Caller: []*srvpb.PagedCrossReferences_Caller{{
+					Caller:         anchor("f", 0, 10),
+					SemanticCaller: "kythe://c?lang=otpl#f",
+					Callsite:       []*srvpb.ExpandedAnchor{anchor("call1", 2, 4), anchor("call2", 6, 8)},
+				}},
+			}},
+		}},
+	}).Construct(t)
+
+	reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:            []string{ticket},
+		CallerKind:        xpb.CrossReferencesRequest_OVERRIDE_CALLERS,
+		CallSitesByCaller: true,
+	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+
+	site := func(name string, start, end int32) *xpb.Anchor {
+		return &xpb.Anchor{Ticket: file + "#" + name, Parent: file, Span: span(start, end)}
+	}
+	expected := &xpb.CrossReferencesReply_CrossReferenceSet{
+		Ticket: ticket,
+		CallerCallSites: []*xpb.CrossReferencesReply_CallerCallSites{{
+			Caller:        "kythe://c?lang=otpl#f",
+			CallerAnchor:  site("f", 0, 10),
+			CallSiteCount: 2,
+			CallSite:      []*xpb.Anchor{site("call1", 2, 4), site("call2", 6, 8)},
+		}, {
+			Caller:        "kythe://c?lang=otpl#g",
+			CallerAnchor:  site("g", 20, 30),
+			CallSiteCount: 1,
+			CallSite:      []*xpb.Anchor{site("call3", 22, 24)},
+		}},
+	}
+	if err := testutil.DeepEqual(expected, reply.CrossReferences[ticket]); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParanoidCorruptEntries(t *testing.T) {
+	const (
+		file   = "kythe://c?path=file"
+		ticket = "kythe://c?lang=otpl#sym"
+	)
+	tbl := &testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: []byte("sym")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{Ticket: file + "#a", StartOffset: 0, EndOffset: 30},
+				Kind:   "/kythe/edge/ref",
+				Target: ticket,
+			}},
+		}},
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind:   "/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{nil},
+			}},
+		}},
+	}
+	st := tbl.Construct(t)
+	st.Paranoid = true
+
+	_, err := st.Decorations(ctx, &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		References: true,
+	})
+	if code := status.Code(err); code != codes.DataLoss {
+		t.Errorf("Expected DataLoss error for corrupt FileDecorations; found %v", err)
 	}
 
-	xr := reply.CrossReferences[ticket]
-	if xr == nil {
-		t.Fatalf("Missing expected CrossReferences; found: %#v", reply)
-	} else if err := testutil.DeepEqual(expected, xr); err != nil {
-		t.Fatal(err)
+	_, err = st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	})
+	if code := status.Code(err); code != codes.DataLoss {
+		t.Errorf("Expected DataLoss error for corrupt PagedCrossReferences; found %v", err)
+	}
+
+	// Pages are validated as well.
+	const kind = "%/kythe/edge/ref"
+	key := pagekey.New(pagekey.CrossReferencesPage, ticket, kind, 0)
+	st = (&testTable{
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: ticket,
+			PageIndex:    []*srvpb.PagedCrossReferences_PageIndex{{PageKey: key, Kind: kind, Count: 1}},
+		}},
+		RefPages: []*srvpb.PagedCrossReferences_Page{{
+			PageKey:      key,
+			SourceTicket: ticket,
+			Group:        &srvpb.PagedCrossReferences_Group{Kind: kind, Anchor: []*srvpb.ExpandedAnchor{{}}},
+		}},
+	}).Construct(t)
+	st.Paranoid = true
+	_, err = st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:        []string{ticket},
+		ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+	})
+	if code := status.Code(err); code != codes.DataLoss {
+		t.Errorf("Expected DataLoss error for corrupt PagedCrossReferences_Page; found %v", err)
+	}
+}
+
+func TestCrossReferencesPageRewriteEdgeLabel(t *testing.T) {
+	const (
+		ticket = "kythe://c?lang=go#sym"
+		kind   = "/kythe/edge/ref"
+	)
+	key := pagekey.New(pagekey.CrossReferencesPage, ticket, kind, 0)
+	sets := &table.KVProto{inmemory.NewKeyValueDB()}
+	pages := &table.KVProto{inmemory.NewKeyValueDB()}
+	testutil.Fatalf(t, "Put error: %v", sets.Put(ctx, []byte(ticket), &srvpb.PagedCrossReferences{
+		SourceTicket: ticket,
+		PageIndex:    []*srvpb.PagedCrossReferences_PageIndex{{PageKey: key, Kind: kind, Count: 1}},
+	}))
+	testutil.Fatalf(t, "Put error: %v", pages.Put(ctx, []byte(key), &srvpb.PagedCrossReferences_Page{
+		PageKey:      key,
+		SourceTicket: ticket,
+		Group: &srvpb.PagedCrossReferences_Group{
+			Kind:   kind,
+			Anchor: []*srvpb.ExpandedAnchor{{Ticket: "kythe://c?lang=go?path=file#ref", Span: &cpb.Span{}}},
+		},
+	}))
+
+	for _, paranoid := range []bool{false, true} {
+		st := NewSplitTable(&SplitTable{
+			CrossReferences:     sets,
+			CrossReferencePages: pages,
+			RewriteEdgeLabel: func(context.Context) func(string) string {
+				return func(k string) string { return k + "/call" }
+			},
+		})
+		st.Paranoid = paranoid
+		reply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+			Ticket:        []string{ticket},
+			ReferenceKind: xpb.CrossReferencesRequest_ALL_REFERENCES,
+		})
+		if err != nil {
+			t.Errorf("CrossReferencesRequest (paranoid: %v) error: %v", paranoid, err)
+			continue
+		}
+		// Pages are checked against their stored kind and served rewritten.
+		refs := reply.CrossReferences[ticket].GetReference()
+		if len(refs) != 1 || refs[0].GetAnchor().GetKind() != kind+"/call" {
+			t.Errorf("Expected 1 rewritten reference (paranoid: %v); found %v", paranoid, refs)
+		}
+	}
+}
+
+func TestDecorationsPageKeyMismatch(t *testing.T) {
+	const (
+		file  = "kythe://c?path=file"
+		other = "kythe://c?path=other"
+	)
+	// A page of another file indexed by the FileDecorations, e.g. by a stale
+	// write.
+	key := pagekey.New(pagekey.DecorationsPage, other, "", 0)
+	tbl := make(testProtoTable)
+	testutil.Fatalf(t, "Put error: %v", tbl.Put(ctx, DecorationsKey(file), &srvpb.FileDecorations{
+		File:           &srvpb.File{Ticket: file, Text: []byte("text")},
+		DecorationPage: []*srvpb.FileDecorations_DecorationPage{{PageKey: key, EndOffset: 4}},
+	}))
+	testutil.Fatalf(t, "Put error: %v", tbl.Put(ctx, DecorationsPageKey(key), &srvpb.FileDecorationsPage{PageKey: key}))
+
+	if _, err := NewCombinedTable(tbl).Decorations(ctx, &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		References: true,
+	}); err == nil {
+		t.Error("Expected error reading decorations page of another file")
 	}
 }
 
@@ -2676,130 +7312,738 @@ func TestDocumentationChildren(t *testing.T) {
 					},
 				},
 			},
-		},
+		},
+	}
+
+	if reply == nil || err != nil {
+		t.Fatalf("Documentation call failed: (reply: %v; error: %v)", reply, err)
+	} else if diff := compare.ProtoDiff(expected, reply); diff != "" {
+		t.Fatalf("(-expected; +found):\n%s", diff)
+	}
+}
+
+func TestDocumentationPatching(t *testing.T) {
+	st := tbl.Construct(t)
+
+	patcher := &mockPatcher{}
+	st.MakePatcher = func(ctx context.Context, ws *xpb.Workspace) (MultiFilePatcher, error) {
+		return patcher, nil
+	}
+
+	reply, err := st.Documentation(ctx, &xpb.DocumentationRequest{
+		Ticket: []string{"kythe:#documented"},
+
+		IncludeChildren: true,
+
+		Workspace:             &xpb.Workspace{Uri: "test:"},
+		PatchAgainstWorkspace: true,
+	})
+
+	expected := &xpb.DocumentationReply{
+		Document: []*xpb.DocumentationReply_Document{{
+			Ticket: "kythe:#documented",
+			Text: &xpb.Printable{
+				RawText: "some documentation text",
+			},
+			MarkedSource: &cpb.MarkedSource{
+				Kind:    cpb.MarkedSource_IDENTIFIER,
+				PreText: "DocumentBuilderFactory",
+			},
+			Children: []*xpb.DocumentationReply_Document{{
+				Ticket: "kythe:#childDoc",
+				Text: &xpb.Printable{
+					RawText: "child document text",
+				},
+			}, {
+				Ticket: "kythe:#childDocBy",
+				Text: &xpb.Printable{
+					RawText: "second child document text",
+				},
+			}},
+		}},
+		Nodes: nodeInfos(getNodes(
+			"kythe:#childDoc",
+			"kythe:#childDocBy",
+			"kythe:#documented",
+			"kythe:#secondChildDoc",
+		)),
+		DefinitionLocations: map[string]*xpb.Anchor{
+			"kythe:?path=def/location#defDoc": &xpb.Anchor{
+				Ticket: "kythe:?path=def/location#defDoc",
+				Parent: "kythe:?path=def/location",
+				Span: &cpb.Span{
+					Start: &cpb.Point{
+						ByteOffset:   2,
+						LineNumber:   2,
+						ColumnOffset: 2,
+					},
+					End: &cpb.Point{
+						ByteOffset:   5,
+						LineNumber:   2,
+						ColumnOffset: 5,
+					},
+				},
+			},
+		},
+	}
+
+	if reply == nil || err != nil {
+		t.Fatalf("Documentation call failed: (reply: %v; error: %v)", reply, err)
+	} else if diff := compare.ProtoDiff(expected, reply); diff != "" {
+		t.Fatalf("(-expected; +found):\n%s", diff)
+	}
+}
+
+func TestDocumentationIndirection(t *testing.T) {
+	st := tbl.Construct(t)
+	reply, err := st.Documentation(ctx, &xpb.DocumentationRequest{
+		Ticket: []string{"kythe:#documentedBy"},
+	})
+
+	expected := &xpb.DocumentationReply{
+		Document: []*xpb.DocumentationReply_Document{{
+			Ticket: "kythe:#documentedBy",
+			Text: &xpb.Printable{
+				RawText: "some documentation text",
+			},
+			MarkedSource: &cpb.MarkedSource{
+				Kind:    cpb.MarkedSource_IDENTIFIER,
+				PreText: "DocumentBuilderFactory",
+			},
+		}},
+		Nodes: nodeInfos(getNodes("kythe:#documented", "kythe:#documentedBy")),
+		DefinitionLocations: map[string]*xpb.Anchor{
+			"kythe:?path=def/location#defDoc": &xpb.Anchor{
+				Ticket: "kythe:?path=def/location#defDoc",
+				Parent: "kythe:?path=def/location",
+				Span: &cpb.Span{
+					Start: &cpb.Point{
+						ByteOffset:   1,
+						LineNumber:   1,
+						ColumnOffset: 1,
+					},
+					End: &cpb.Point{
+						ByteOffset:   4,
+						LineNumber:   1,
+						ColumnOffset: 4,
+					},
+				},
+			},
+		},
+	}
+
+	if reply == nil || err != nil {
+		t.Fatalf("Documentation call failed: (reply: %v; error: %v)", reply, err)
+	} else if diff := compare.ProtoDiff(expected, reply); diff != "" {
+		t.Fatalf("(-expected; +found):\n%s", diff)
+	}
+}
+
+func TestBatchDocumentation(t *testing.T) {
+	p := &countingProtoTable{testProtoTable: tbl.protoTable(t), lookups: make(map[string]int)}
+	st := NewCombinedTable(p)
+	req := &xpb.DocumentationRequest{
+		Ticket: []string{
+			"kythe:#documented",
+			"kythe:#documentedBy",
+			"kythe:#undocumented",
+			"kythe:#documented",
+		},
+		IncludeChildren: true,
+	}
+	replies, err := st.BatchDocumentation(ctx, req)
+	if err != nil {
+		t.Fatalf("BatchDocumentation error: %v", err)
+	}
+
+	for key, n := range p.lookups {
+		if strings.HasPrefix(key, documentationTablePrefix) && n != 1 {
+			t.Errorf("Lookup(%q) called %d times; expected 1", key, n)
+		}
+	}
+	if len(replies) != 2 {
+		t.Errorf("Found %d replies; expected 2: %v", len(replies), replies)
+	}
+	for _, ticket := range []string{"kythe:#documented", "kythe:#documentedBy"} {
+		expected, err := tbl.Construct(t).Documentation(ctx, &xpb.DocumentationRequest{
+			Ticket:          []string{ticket},
+			IncludeChildren: true,
+		})
+		if err != nil {
+			t.Fatalf("Documentation error: %v", err)
+		}
+		if diff := compare.ProtoDiff(expected, replies[ticket]); diff != "" {
+			t.Errorf("%s: (-expected; +found):\n%s", ticket, diff)
+		}
+	}
+}
+
+func TestBatchDocumentationHTTP(t *testing.T) {
+	st := tbl.Construct(t)
+	mux := http.NewServeMux()
+	RegisterHTTPHandlers(ctx, st, mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/documentation/batch?proto=1", strings.NewReader(`{"ticket": ["kythe:#documented", "kythe:#undocumented"]}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", w.Code, w.Body)
+	}
+	var found xpb.BatchDocumentationReply
+	testutil.Fatalf(t, "Error decoding reply: %v", proto.Unmarshal(w.Body.Bytes(), &found))
+	replies, err := st.BatchDocumentation(ctx, &xpb.DocumentationRequest{Ticket: []string{"kythe:#documented"}})
+	testutil.Fatalf(t, "BatchDocumentation error: %v", err)
+	if diff := compare.ProtoDiff(&xpb.BatchDocumentationReply{Replies: replies}, &found); diff != "" {
+		t.Errorf("(-expected; +found):\n%s", diff)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/documentation/batch", strings.NewReader(`{"ticket": [""]}`)))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected invalid request to fail; found status %d", w.Code)
+	}
+}
+
+func TestDocumentationReadQuota(t *testing.T) {
+	st := tbl.Construct(t)
+	st.ReadQuota = ReadQuota{MaxLookups: 1}
+
+	// The indirect document requires a second lookup.
+	if _, err := st.Documentation(ctx, &xpb.DocumentationRequest{
+		Ticket: []string{"kythe:#documented"},
+	}); err != nil {
+		t.Errorf("Documentation error: %v", err)
+	}
+	_, err := st.Documentation(ctx, &xpb.DocumentationRequest{
+		Ticket: []string{"kythe:#documentedBy"},
+	})
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected QuotaExceededError; found %v", err)
+	} else if quotaErr.Reply != nil {
+		t.Errorf("Unexpected partial reply: %v", quotaErr.Reply)
+	}
+
+	// The quota applies to a batch as a whole.
+	_, err = st.BatchDocumentation(ctx, &xpb.DocumentationRequest{
+		Ticket: []string{"kythe:#documented", "kythe:#childDoc"},
+	})
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected QuotaExceededError; found %v", err)
+	}
+}
+
+type countingLimiter struct {
+	waits int
+	err   error
+}
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.waits++
+	return l.err
+}
+
+func TestReadLimiter(t *testing.T) {
+	st := tbl.Construct(t)
+	limiter := &countingLimiter{}
+	st.ReadLimiter = limiter
+	req := &xpb.DocumentationRequest{Ticket: []string{"kythe:#documentedBy"}}
+	if _, err := st.Documentation(ctx, req); err != nil {
+		t.Fatalf("Documentation error: %v", err)
+	} else if limiter.waits != 2 {
+		t.Errorf("Expected 2 limiter waits; found %d", limiter.waits)
+	}
+
+	limiter.err = status.Error(codes.ResourceExhausted, "rate limited")
+	if _, err := st.Documentation(ctx, req); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected rate limiter error; found %v", err)
+	}
+}
+
+func TestNegativeCache(t *testing.T) {
+	const missing = "kythe://c?path=missing"
+	counter := &countingProtoTable{testProtoTable: tbl.protoTable(t), lookups: make(map[string]int)}
+	nc := NewNegativeCache(&NegativeCacheOptions{Size: 2, TTL: time.Minute})
+	now := time.Unix(1700000000, 0)
+	nc.now = func() time.Time { return now }
+	st := NewCombinedTable(counter)
+	st.NegativeCache = nc
+
+	docs := func(ticket string) {
+		t.Helper()
+		_, err := st.Documentation(ctx, &xpb.DocumentationRequest{Ticket: []string{ticket}})
+		testutil.Fatalf(t, "Documentation error: %v", err)
+	}
+	key := string(DocumentationKey(missing))
+	docs(missing)
+	docs(missing)
+	if n := counter.lookups[key]; n != 1 {
+		t.Errorf("Expected 1 lookup of missing ticket; found %d", n)
+	}
+	stats := nc.Stats()
+	if stats.Entries != 1 || stats.Lookups[negDocumentation] != 2 || stats.Hits[negDocumentation] != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	// Entries expire after their TTL.
+	now = now.Add(2 * time.Minute)
+	docs(missing)
+	if n := counter.lookups[key]; n != 2 {
+		t.Errorf("Expected expired entry to be read again; found %d lookups", n)
+	}
+
+	// Invalidated entries are read again, as are those evicted to make room.
+	nc.Invalidate(missing)
+	docs(missing)
+	docs("kythe://c?path=missing2")
+	docs("kythe://c?path=missing3")
+	docs(missing)
+	if n := counter.lookups[key]; n != 4 {
+		t.Errorf("Expected invalidated and evicted entry to be read again; found %d lookups", n)
+	} else if n := nc.Stats().Entries; n != 2 {
+		t.Errorf("Expected 2 entries; found %d", n)
+	}
+
+	// Existing tickets are never cached.
+	docs("kythe:#documented")
+	docs("kythe:#documented")
+	if n := counter.lookups[string(DocumentationKey("kythe:#documented"))]; n != 2 {
+		t.Errorf("Expected 2 lookups of existing ticket; found %d", n)
+	}
+
+	// Lookups in flight when their tickets are invalidated are not recorded,
+	// since the tickets may have been written in the meantime.
+	const written = "kythe://c?path=written"
+	if missing, epoch := nc.contains(negDocumentation, written); missing {
+		t.Errorf("Unexpected negative cache hit for %q", written)
+	} else {
+		nc.Invalidate(written)
+		nc.put(negDocumentation, written, epoch)
+	}
+	if missing, _ := nc.contains(negDocumentation, written); missing {
+		t.Errorf("Stale miss of %q recorded after invalidation", written)
+	}
+
+	admin := &Admin{NegativeCache: nc}
+	st2, err := admin.Run(ctx, &AdminCommand{Command: AdminFlush})
+	testutil.Fatalf(t, "Run error: %v", err)
+	if st2.NegativeCache == nil || st2.NegativeCache.Entries != 0 {
+		t.Errorf("Expected flushed negative cache; found %+v", st2.NegativeCache)
+	}
+}
+
+func TestNegativeCacheShards(t *testing.T) {
+	nc := NewNegativeCache(&NegativeCacheOptions{Size: 4096})
+	if n := len(nc.shards); n != maxNegativeShards {
+		t.Fatalf("Expected %d shards; found %d", maxNegativeShards, n)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ticket := fmt.Sprintf("kythe://c?path=%d/%d", i, j)
+				if missing, epoch := nc.contains(negDecorations, ticket); !missing {
+					nc.put(negDecorations, ticket, epoch)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if stats := nc.Stats(); stats.Entries != 800 || stats.Lookups[negDecorations] != 800 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	nc.Flush()
+	if n := nc.Stats().Entries; n != 0 {
+		t.Errorf("Expected flushed cache; found %d entries", n)
+	}
+}
+
+func TestWarm(t *testing.T) {
+	hot := NewHotKeys(0)
+	logRequests := func(h *HotKeys, method string, err error, tickets ...string) {
+		for _, ticket := range tickets {
+			h.LogRequest(ctx, &RequestLogEntry{Method: method, Tickets: []string{ticket}, Err: err})
+		}
+	}
+	logRequests(hot, "CrossReferences", nil, "kythe:#a", "kythe:#b", "kythe:#a", "kythe:#c", "kythe:#b", "kythe:#a")
+	logRequests(hot, "CrossReferences", errors.New("failed"), "kythe:#c", "kythe:#c", "kythe:#c")
+	logRequests(hot, "Documentation", nil, "kythe:#documented")
+	logRequests(hot, "Unknown", nil, "kythe:#a")
+
+	m := hot.Manifest(2)
+	expected := &WarmingManifest{Keys: map[string][]string{
+		"CrossReferences": {"kythe:#a", "kythe:#b"},
+		"Documentation":   {"kythe:#documented"},
+	}}
+	if err := testutil.DeepEqual(expected, m); err != nil {
+		t.Errorf("Unexpected manifest: %v", err)
+	}
+	var buf bytes.Buffer
+	testutil.Fatalf(t, "Write error: %v", m.Write(&buf))
+	m, err := ReadWarmingManifest(&buf)
+	testutil.Fatalf(t, "ReadWarmingManifest error: %v", err)
+	if err := testutil.DeepEqual(expected, m); err != nil {
+		t.Errorf("Unexpected decoded manifest: %v", err)
+	}
+
+	// Each method's hottest keys are read first.
+	counter := &countingProtoTable{testProtoTable: tbl.protoTable(t), lookups: make(map[string]int)}
+	st := NewCombinedTable(counter)
+	stats := st.Warm(ctx, m, &WarmingBudget{MaxKeys: 2})
+	if stats.Keys != 2 || stats.Missing != 1 || stats.Errors != 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if err := testutil.DeepEqual(map[string]int{
+		string(CrossReferencesKey("kythe:#a")):        1,
+		string(DocumentationKey("kythe:#documented")): 1,
+	}, counter.lookups); err != nil {
+		t.Errorf("Unexpected lookups: %v", err)
+	}
+	if stats := st.Warm(ctx, m, nil); stats.Keys != 3 {
+		t.Errorf("Expected 3 keys read without a budget; found %+v", stats)
+	}
+
+	// Once full, each new key replaces the least requested key.
+	small := NewHotKeys(2)
+	logRequests(small, "Decorations", nil, "kythe:?path=x", "kythe:?path=x", "kythe:?path=y", "kythe:?path=z")
+	if err := testutil.DeepEqual([]string{"kythe:?path=x", "kythe:?path=z"}, small.Manifest(10).Keys["Decorations"]); err != nil {
+		t.Errorf("Unexpected hot keys: %v", err)
+	}
+}
+
+func TestResolveLocation(t *testing.T) {
+	const file = "kythe://corpus?path=resolve/file"
+	text := []byte("func f() { g() }\n")
+	decoration := func(start, end int32, kind, target, scope string) *srvpb.FileDecorations_Decoration {
+		return &srvpb.FileDecorations_Decoration{
+			Anchor: &srvpb.RawAnchor{
+				Ticket:      fmt.Sprintf("kythe://corpus?lang=l?path=resolve/file#%d-%d", start, end),
+				StartOffset: start,
+				EndOffset:   end,
+			},
+			Kind:          kind,
+			Target:        target,
+			SemanticScope: scope,
+		}
 	}
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{Ticket: file, Text: text},
+			Decoration: []*srvpb.FileDecorations_Decoration{
+				decoration(0, 16, "/kythe/edge/defines", "kythe://corpus?lang=l#f", ""),
+				decoration(5, 6, "/kythe/edge/defines/binding", "kythe://corpus?lang=l#f", ""),
+				decoration(11, 12, "/kythe/edge/ref", "kythe://corpus?lang=l#g", "kythe://corpus?lang=l#f"),
+				decoration(11, 12, "/kythe/edge/ref/call", "kythe://corpus?lang=l#g", "kythe://corpus?lang=l#f"),
+				decoration(11, 14, "/kythe/edge/ref/call", "kythe://corpus?lang=l#g", "kythe://corpus?lang=l#f"),
+			},
+		}},
+	}).Construct(t)
 
-	if reply == nil || err != nil {
-		t.Fatalf("Documentation call failed: (reply: %v; error: %v)", reply, err)
-	} else if diff := compare.ProtoDiff(expected, reply); diff != "" {
-		t.Fatalf("(-expected; +found):\n%s", diff)
+	tests := []struct {
+		offset   int32
+		expected []*LocationTarget
+	}{
+		{11, []*LocationTarget{{
+			Kind:          "/kythe/edge/ref",
+			Ticket:        "kythe://corpus?lang=l#g",
+			SemanticScope: "kythe://corpus?lang=l#f",
+		}, {
+			Kind:          "/kythe/edge/ref/call",
+			Ticket:        "kythe://corpus?lang=l#g",
+			SemanticScope: "kythe://corpus?lang=l#f",
+		}}},
+		{13, []*LocationTarget{{
+			Kind:          "/kythe/edge/ref/call",
+			Ticket:        "kythe://corpus?lang=l#g",
+			SemanticScope: "kythe://corpus?lang=l#f",
+		}}},
+		{5, []*LocationTarget{{Kind: "/kythe/edge/defines/binding", Ticket: "kythe://corpus?lang=l#f"}}},
+		{8, []*LocationTarget{{Kind: "/kythe/edge/defines", Ticket: "kythe://corpus?lang=l#f"}}},
+		{16, nil},
+	}
+	for _, test := range tests {
+		found, err := st.ResolveLocation(ctx, file, test.offset)
+		testutil.Fatalf(t, "ResolveLocation error: %v", err)
+		for _, target := range found {
+			if start := target.Anchor.GetSpan().GetStart().GetByteOffset(); start > test.offset {
+				t.Errorf("ResolveLocation(%d): anchor %s starts after offset", test.offset, target.Anchor.Ticket)
+			}
+			target.Anchor = nil
+		}
+		if err := testutil.DeepEqual(test.expected, found); err != nil {
+			t.Errorf("ResolveLocation(%d): %v", test.offset, err)
+		}
+	}
+
+	if _, err := st.ResolveLocation(ctx, file, -1); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for negative offset; found %v", err)
+	}
+	if _, err := st.ResolveLocation(ctx, "kythe://corpus?path=missing", 0); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for missing file; found %v", err)
 	}
 }
 
-func TestDocumentationPatching(t *testing.T) {
-	st := tbl.Construct(t)
+func TestResolveFingerprint(t *testing.T) {
+	const (
+		file   = "kythe://corpus?path=resolve/file"
+		target = "kythe://corpus?lang=l#g"
+	)
+	build := func(text string, offsets ...int32) *srvpb.FileDecorations {
+		fd := &srvpb.FileDecorations{File: &srvpb.File{Ticket: file, Text: []byte(text)}}
+		for _, start := range offsets {
+			fd.Decoration = append(fd.Decoration, &srvpb.FileDecorations_Decoration{
+				Anchor: &srvpb.RawAnchor{
+					Ticket:      fmt.Sprintf("kythe://corpus?lang=l?path=resolve/file#%d", start),
+					StartOffset: start,
+					EndOffset:   start + 1,
+				},
+				Kind:   "/kythe/edge/ref",
+				Target: target,
+			})
+		}
+		assemble.AddAnchorFingerprints(fd)
+		return fd
+	}
 
-	patcher := &mockPatcher{}
-	st.MakePatcher = func(ctx context.Context, ws *xpb.Workspace) (MultiFilePatcher, error) {
-		return patcher, nil
+	// Link to the second reference before the file is edited.
+	old := build("g()\ng()\n", 0, 4)
+	fingerprint := old.Decoration[1].Anchor.Fingerprint
+	reply, err := (&testTable{Decorations: []*srvpb.FileDecorations{old}}).Construct(t).Decorations(ctx, &xpb.DecorationsRequest{
+		Location:   &xpb.Location{Ticket: file},
+		References: true,
+	})
+	testutil.Fatalf(t, "DecorationsRequest error: %v", err)
+	if found := reply.Reference[1].Fingerprint; found != fingerprint {
+		t.Errorf("Found reference fingerprint %q; expected %q", found, fingerprint)
 	}
 
-	reply, err := st.Documentation(ctx, &xpb.DocumentationRequest{
-		Ticket: []string{"kythe:#documented"},
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{build("// c\ng()\ng()\n", 5, 9)},
+		RefSets: []*srvpb.PagedCrossReferences{{
+			SourceTicket: target,
+			Group: []*srvpb.PagedCrossReferences_Group{{
+				Kind: "%/kythe/edge/ref",
+				Anchor: []*srvpb.ExpandedAnchor{{
+					Ticket: "kythe://corpus?lang=l?path=resolve/file#9",
+					Kind:   "/kythe/edge/ref",
+					Text:   "g",
+				}},
+			}},
+		}},
+	}).Construct(t)
+	found, err := st.ResolveFingerprint(ctx, file, fingerprint)
+	testutil.Fatalf(t, "ResolveFingerprint error: %v", err)
+	if found.TargetTicket != target {
+		t.Errorf("Found target %q; expected %q", found.TargetTicket, target)
+	}
+	if start := found.Anchor.GetSpan().GetStart(); start.GetByteOffset() != 9 || start.GetLineNumber() != 3 {
+		t.Errorf("Unexpected anchor start: %v", start)
+	} else if found.Anchor.Fingerprint != fingerprint {
+		t.Errorf("Found anchor fingerprint %q; expected %q", found.Anchor.Fingerprint, fingerprint)
+	}
 
-		IncludeChildren: true,
+	if _, err := st.ResolveFingerprint(ctx, file, "unknown"); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for unknown fingerprint; found %v", err)
+	}
+	if _, err := st.ResolveFingerprint(ctx, file, ""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for missing fingerprint; found %v", err)
+	}
 
-		Workspace:             &xpb.Workspace{Uri: "test:"},
-		PatchAgainstWorkspace: true,
+	// Cross-references carry their anchors' fingerprints when requested.
+	xrefsReply, err := st.CrossReferences(ctx, &xpb.CrossReferencesRequest{
+		Ticket:             []string{target},
+		ReferenceKind:      xpb.CrossReferencesRequest_ALL_REFERENCES,
+		AnchorFingerprints: true,
 	})
+	testutil.Fatalf(t, "CrossReferencesRequest error: %v", err)
+	if refs := xrefsReply.CrossReferences[target].GetReference(); len(refs) != 1 {
+		t.Errorf("Expected 1 reference; found %v", refs)
+	} else if refs[0].Anchor.Fingerprint != fingerprint {
+		t.Errorf("Found cross-reference fingerprint %q; expected %q", refs[0].Anchor.Fingerprint, fingerprint)
+	}
 
-	expected := &xpb.DocumentationReply{
-		Document: []*xpb.DocumentationReply_Document{{
-			Ticket: "kythe:#documented",
-			Text: &xpb.Printable{
-				RawText: "some documentation text",
-			},
-			MarkedSource: &cpb.MarkedSource{
-				Kind:    cpb.MarkedSource_IDENTIFIER,
-				PreText: "DocumentBuilderFactory",
+	// Fingerprints are resolved over HTTP.
+	mux := http.NewServeMux()
+	RegisterHTTPHandlers(ctx, st, mux)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/anchors/fingerprint?proto=1", strings.NewReader(fmt.Sprintf(`{"ticket": %q, "fingerprint": %q}`, file, fingerprint))))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", w.Code, w.Body)
+	}
+	var httpReply xpb.ResolveFingerprintReply
+	testutil.Fatalf(t, "Error decoding reply: %v", proto.Unmarshal(w.Body.Bytes(), &httpReply))
+	if diff := compare.ProtoDiff(&xpb.ResolveFingerprintReply{Anchor: found.Anchor, TargetTicket: target}, &httpReply); diff != "" {
+		t.Errorf("(-expected; +found):\n%s", diff)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/anchors/fingerprint", strings.NewReader(fmt.Sprintf(`{"ticket": %q, "fingerprint": "unknown"}`, file))))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected unknown fingerprint to be not found; found status %d", w.Code)
+	}
+}
+
+func TestResolveAnchors(t *testing.T) {
+	file := "kythe://corpus?path=resolve/file"
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File: &srvpb.File{
+				Ticket: file,
+				Text:   []byte("first line\nsecond line\n"),
 			},
-			Children: []*xpb.DocumentationReply_Document{{
-				Ticket: "kythe:#childDoc",
-				Text: &xpb.Printable{
-					RawText: "child document text",
+			FileInfo: []*srvpb.FileInfo{fi(cp("corpus", "", "resolve/file"), "rev")},
+			Decoration: []*srvpb.FileDecorations_Decoration{{
+				Anchor: &srvpb.RawAnchor{
+					Ticket:      "kythe://corpus?lang=l?path=resolve/file#0-5",
+					StartOffset: 0,
+					EndOffset:   5,
 				},
+				Kind:   "/kythe/edge/ref",
+				Target: "kythe://corpus?lang=l#first",
 			}, {
-				Ticket: "kythe:#childDocBy",
-				Text: &xpb.Printable{
-					RawText: "second child document text",
+				Anchor: &srvpb.RawAnchor{
+					Ticket:      "kythe://corpus?lang=l?path=resolve/file#18-22",
+					StartOffset: 18,
+					EndOffset:   22,
+
+					SnippetStart: 11,
+					SnippetEnd:   17,
 				},
+				Kind:   "/kythe/edge/defines/binding",
+				Target: "kythe://corpus?lang=l#line",
 			}},
 		}},
-		Nodes: nodeInfos(getNodes(
-			"kythe:#childDoc",
-			"kythe:#childDocBy",
-			"kythe:#documented",
-			"kythe:#secondChildDoc",
-		)),
-		DefinitionLocations: map[string]*xpb.Anchor{
-			"kythe:?path=def/location#defDoc": &xpb.Anchor{
-				Ticket: "kythe:?path=def/location#defDoc",
-				Parent: "kythe:?path=def/location",
-				Span: &cpb.Span{
-					Start: &cpb.Point{
-						ByteOffset:   2,
-						LineNumber:   2,
-						ColumnOffset: 2,
-					},
-					End: &cpb.Point{
-						ByteOffset:   5,
-						LineNumber:   2,
-						ColumnOffset: 5,
-					},
-				},
+	}).Construct(t)
+
+	found, err := st.ResolveAnchors(ctx, []string{
+		"kythe://corpus?lang=l?path=resolve/file#0-5",
+		"kythe://corpus?lang=l?path=resolve/file#18-22",
+		"kythe://corpus?lang=l?path=resolve/file#missing",
+		"kythe://corpus?lang=l?path=missing/file#0-5",
+	})
+	testutil.Fatalf(t, "ResolveAnchors error: %v", err)
+
+	norm := span.NewNormalizer([]byte("first line\nsecond line\n"))
+	expected := map[string]*ResolvedAnchor{
+		"kythe://corpus?lang=l?path=resolve/file#0-5": {
+			Anchor: &xpb.Anchor{
+				Ticket:           "kythe://corpus?lang=l?path=resolve/file#0-5",
+				Kind:             "/kythe/edge/ref",
+				Parent:           file,
+				Text:             "first",
+				Span:             norm.SpanOffsets(0, 5),
+				Snippet:          "first line",
+				SnippetSpan:      norm.SpanOffsets(0, 10),
+				SnippetHighlight: &xpb.Anchor_SnippetRange{End: 5},
+				Revision:         "rev",
+			},
+			TargetTicket: "kythe://corpus?lang=l#first",
+		},
+		"kythe://corpus?lang=l?path=resolve/file#18-22": {
+			Anchor: &xpb.Anchor{
+				Ticket:      "kythe://corpus?lang=l?path=resolve/file#18-22",
+				Kind:        "/kythe/edge/defines/binding",
+				Parent:      file,
+				Text:        "line",
+				Span:        norm.SpanOffsets(18, 22),
+				Snippet:     "second",
+				SnippetSpan: norm.SpanOffsets(11, 17),
+				Revision:    "rev",
 			},
+			TargetTicket: "kythe://corpus?lang=l#line",
 		},
 	}
-
-	if reply == nil || err != nil {
-		t.Fatalf("Documentation call failed: (reply: %v; error: %v)", reply, err)
-	} else if diff := compare.ProtoDiff(expected, reply); diff != "" {
+	if diff := compare.ProtoDiff(expected, found); diff != "" {
 		t.Fatalf("(-expected; +found):\n%s", diff)
 	}
-}
 
-func TestDocumentationIndirection(t *testing.T) {
-	st := tbl.Construct(t)
-	reply, err := st.Documentation(ctx, &xpb.DocumentationRequest{
-		Ticket: []string{"kythe:#documentedBy"},
+	// Anchors with unauthorized targets are omitted; anchors in unauthorized
+	// files are denied.
+	authz := &denyTickets{denied: stringset.New("kythe://corpus?lang=l#line")}
+	st.Authorizer = authz
+	found, err = st.ResolveAnchors(ctx, []string{
+		"kythe://corpus?lang=l?path=resolve/file#0-5",
+		"kythe://corpus?lang=l?path=resolve/file#18-22",
 	})
+	testutil.Fatalf(t, "ResolveAnchors error: %v", err)
+	delete(expected, "kythe://corpus?lang=l?path=resolve/file#18-22")
+	if diff := compare.ProtoDiff(expected, found); diff != "" {
+		t.Errorf("(-expected; +found):\n%s", diff)
+	}
+	authz.denied.Add(file)
+	if _, err := st.ResolveAnchors(ctx, []string{"kythe://corpus?lang=l?path=resolve/file#0-5"}); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for ResolveAnchors; found %v", err)
+	}
+}
 
-	expected := &xpb.DocumentationReply{
-		Document: []*xpb.DocumentationReply_Document{{
-			Ticket: "kythe:#documentedBy",
-			Text: &xpb.Printable{
-				RawText: "some documentation text",
-			},
-			MarkedSource: &cpb.MarkedSource{
-				Kind:    cpb.MarkedSource_IDENTIFIER,
-				PreText: "DocumentBuilderFactory",
+func TestGetAnchorContext(t *testing.T) {
+	const (
+		file = "kythe://corpus?path=context/file"
+		text = "one\ntwo\nthree\nfour\nfive"
+	)
+	anchor := func(start, end int32) *srvpb.FileDecorations_Decoration {
+		return &srvpb.FileDecorations_Decoration{
+			Anchor: &srvpb.RawAnchor{
+				Ticket:      fmt.Sprintf("kythe://corpus?lang=l?path=context/file#%d-%d", start, end),
+				StartOffset: start,
+				EndOffset:   end,
 			},
+			Kind:   "/kythe/edge/ref",
+			Target: "kythe://corpus?lang=l#target",
+		}
+	}
+	decorations := []*srvpb.FileDecorations_Decoration{
+		anchor(0, 3),   // one
+		anchor(8, 13),  // three
+		anchor(19, 23), // five
+		anchor(4, 13),  // two\nthree
+	}
+	st := (&testTable{
+		Decorations: []*srvpb.FileDecorations{{
+			File:       &srvpb.File{Ticket: file, Text: []byte(text)},
+			Decoration: decorations,
 		}},
-		Nodes: nodeInfos(getNodes("kythe:#documented", "kythe:#documentedBy")),
-		DefinitionLocations: map[string]*xpb.Anchor{
-			"kythe:?path=def/location#defDoc": &xpb.Anchor{
-				Ticket: "kythe:?path=def/location#defDoc",
-				Parent: "kythe:?path=def/location",
-				Span: &cpb.Span{
-					Start: &cpb.Point{
-						ByteOffset:   1,
-						LineNumber:   1,
-						ColumnOffset: 1,
-					},
-					End: &cpb.Point{
-						ByteOffset:   4,
-						LineNumber:   1,
-						ColumnOffset: 4,
-					},
-				},
-			},
-		},
+	}).Construct(t)
+
+	norm := span.NewNormalizer([]byte(text))
+	tests := []struct {
+		lines    int
+		expected map[int32][2]int32 // anchor start -> context offsets
+	}{
+		{0, map[int32][2]int32{0: {0, 3}, 8: {8, 13}, 19: {19, 23}, 4: {4, 13}}},
+		{1, map[int32][2]int32{0: {0, 7}, 8: {4, 18}, 19: {14, 23}, 4: {0, 18}}},
+		{10, map[int32][2]int32{0: {0, 23}, 8: {0, 23}, 19: {0, 23}, 4: {0, 23}}},
+	}
+	var anchors []string
+	for _, d := range decorations {
+		anchors = append(anchors, d.Anchor.Ticket)
 	}
+	anchors = append(anchors, "kythe://corpus?lang=l?path=missing/file#0-3")
 
-	if reply == nil || err != nil {
-		t.Fatalf("Documentation call failed: (reply: %v; error: %v)", reply, err)
-	} else if diff := compare.ProtoDiff(expected, reply); diff != "" {
-		t.Fatalf("(-expected; +found):\n%s", diff)
+	for _, test := range tests {
+		found, err := st.GetAnchorContext(ctx, anchors, test.lines)
+		testutil.Fatalf(t, "GetAnchorContext error: %v", err)
+		if len(found) != len(test.expected) {
+			t.Errorf("GetAnchorContext(%d): expected %d contexts; found %d", test.lines, len(test.expected), len(found))
+		}
+		for _, c := range found {
+			bounds := test.expected[c.Anchor.Span.Start.ByteOffset]
+			if want := text[bounds[0]:bounds[1]]; c.Text != want {
+				t.Errorf("GetAnchorContext(%d): %s: expected context %q; found %q", test.lines, c.Anchor.Ticket, want, c.Text)
+			}
+			if diff := compare.ProtoDiff(norm.SpanOffsets(bounds[0], bounds[1]), c.Span); diff != "" {
+				t.Errorf("GetAnchorContext(%d): %s: (-expected; +found):\n%s", test.lines, c.Anchor.Ticket, diff)
+			}
+		}
+	}
+
+	if _, err := st.GetAnchorContext(ctx, anchors, -1); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for negative lines; found %v", err)
+	}
+
+	st.Authorizer = &denyTickets{denied: stringset.New(file)}
+	if _, err := st.GetAnchorContext(ctx, anchors, 0); err != xrefs.ErrPermissionDenied {
+		t.Errorf("Expected PermissionDenied for GetAnchorContext; found %v", err)
 	}
 }
 
@@ -2905,7 +8149,7 @@ func TestPageSearchIndex(t *testing.T) {
 			t.Logf("CorpusPathFilters: %s", test.Filter)
 			filter, err := compileCorpusPathFilters(test.Filter, nil)
 			testutil.Fatalf(t, "compileCorpusPathFilters: %v", err)
-			found := filter.PageSet(set)
+			found := filter.PageSet(ctx, set)
 
 			var expected *pageSet
 			if test.Keys != nil {
@@ -3029,22 +8273,299 @@ func makeFactList(keyVals ...string) []*cpb.Fact {
 func refs(norm *span.Normalizer, ds []*srvpb.FileDecorations_Decoration, infos []*srvpb.FileInfo) (refs []*xpb.DecorationsReply_Reference) {
 	fileInfos := makeFileInfoMap(infos)
 	for _, d := range ds {
-		r := decorationToReference(norm, d)
+		r := decorationToReference(norm, d, nil)
 		r.TargetRevision = fileInfos[r.TargetTicket].GetRevision()
 		refs = append(refs, r)
 	}
 	return
 }
 
+// tracingService records the order in which it receives Documentation
+// requests under its name.
+type tracingService struct {
+	xrefs.Service
+	name  string
+	trace *[]string
+}
+
+func (s tracingService) Documentation(ctx context.Context, req *xpb.DocumentationRequest) (*xpb.DocumentationReply, error) {
+	*s.trace = append(*s.trace, s.name)
+	return s.Service.Documentation(ctx, req)
+}
+
+func TestNewSplitTableWithMiddleware(t *testing.T) {
+	var trace []string
+	tracing := func(name string) Middleware {
+		return func(s xrefs.Service) xrefs.Service {
+			return tracingService{Service: s, name: name, trace: &trace}
+		}
+	}
+	doc := &srvpb.Document{Ticket: "kythe:#doc", RawText: "text"}
+	docs := make(testProtoTable)
+	testutil.Fatalf(t, "Put error: %v", docs.Put(ctx, []byte(doc.Ticket), doc))
+
+	xs := NewSplitTableWithMiddleware(&SplitTable{Documentation: docs}, tracing("outer"), nil, tracing("inner"))
+	reply, err := xs.Documentation(ctx, &xpb.DocumentationRequest{Ticket: []string{doc.Ticket}})
+	testutil.Fatalf(t, "Documentation error: %v", err)
+	if len(reply.Document) != 1 || reply.Document[0].Text.GetRawText() != doc.RawText {
+		t.Errorf("Unexpected Documentation reply: %v", reply)
+	}
+	if err := testutil.DeepEqual([]string{"outer", "inner"}, trace); err != nil {
+		t.Errorf("Middleware order: %v", err)
+	}
+}
+
+// recordingService records the CrossReferencesRequests it receives.
+type recordingService struct {
+	xrefs.Service
+	reqs []*xpb.CrossReferencesRequest
+}
+
+func (s *recordingService) CrossReferences(ctx context.Context, req *xpb.CrossReferencesRequest) (*xpb.CrossReferencesReply, error) {
+	s.reqs = append(s.reqs, req)
+	return &xpb.CrossReferencesReply{}, nil
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	rec := &recordingService{}
+	admin := &Admin{}
+	xs := Chain(rec, admin.Middleware())
+
+	_, err := xs.CrossReferences(ctx, &xpb.CrossReferencesRequest{Ticket: []string{"kythe:#a"}})
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+
+	_, err = admin.Run(ctx, &AdminCommand{Command: AdminLenient, Lenient: true})
+	testutil.Fatalf(t, "Run error: %v", err)
+	_, err = admin.Run(ctx, &AdminCommand{Command: AdminPageLimits, MaxPageSize: 10, MaxTickets: 1})
+	testutil.Fatalf(t, "Run error: %v", err)
+
+	req := &xpb.CrossReferencesRequest{Ticket: []string{"kythe:#a"}, PageSize: 100}
+	_, err = xs.CrossReferences(ctx, req)
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+	if req.SkipMissingPages || req.PageSize != 100 {
+		t.Errorf("Request modified: %v", req)
+	}
+	expected := []*xpb.CrossReferencesRequest{
+		{Ticket: []string{"kythe:#a"}},
+		{Ticket: []string{"kythe:#a"}, PageSize: 10, SkipMissingPages: true},
+	}
+	if err := testutil.DeepEqual(expected, rec.reqs); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := xs.CrossReferences(ctx, &xpb.CrossReferencesRequest{Ticket: []string{"kythe:#a", "kythe:#b"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for too many tickets; found %v", err)
+	}
+	if _, err := admin.Run(ctx, &AdminCommand{Command: "reboot"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for unknown command; found %v", err)
+	}
+
+	st, err := admin.Run(ctx, &AdminCommand{Command: AdminStats})
+	testutil.Fatalf(t, "Run error: %v", err)
+	if err := testutil.DeepEqual(&AdminStatus{
+		Lenient:     true,
+		MaxPageSize: 10,
+		MaxTickets:  1,
+		Requests:    map[string]int64{"CrossReferences": 3},
+		Errors:      map[string]int64{"CrossReferences": 1},
+	}, st); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAdminSwap(t *testing.T) {
+	old, next, unnamed := &recordingService{}, &recordingService{}, &recordingService{}
+	rebuilt := &recordingService{}
+	gens := &xrefs.Generations{}
+	testutil.Fatalf(t, "Add error: %v", gens.Add(xrefs.Provenance{BuildID: "old", Service: old}))
+
+	var retired []xrefs.Service
+	admin := &Admin{
+		Generations: gens,
+		OpenTable: func(_ context.Context, path string) (xrefs.Provenance, error) {
+			switch path {
+			case "/tables/new":
+				return xrefs.Provenance{BuildID: "new", Service: next}, nil
+			case "/tables/unnamed":
+				return xrefs.Provenance{Service: unnamed}, nil
+			case "/tables/rebuilt":
+				return xrefs.Provenance{BuildID: "new", Service: rebuilt}, nil
+			}
+			return xrefs.Provenance{}, fmt.Errorf("no table at %q", path)
+		},
+		Retire: func(s xrefs.Service) { retired = append(retired, s) },
+	}
+	var warmed []string
+	serving := []string{"old"}
+	admin.Warm = func(_ context.Context, p xrefs.Provenance) {
+		if err := testutil.DeepEqual(serving, gens.BuildIDs()); err != nil {
+			t.Errorf("Generation %q warmed after it was added: %v", p.BuildID, err)
+		}
+		warmed = append(warmed, p.BuildID)
+	}
+
+	if _, err := admin.Run(ctx, &AdminCommand{Command: AdminSwap, Path: "/tables/missing"}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable for missing table; found %v", err)
+	}
+	// A table that cannot be added is retired.
+	if _, err := admin.Run(ctx, &AdminCommand{Command: AdminSwap, Path: "/tables/unnamed"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for table without a build ID; found %v", err)
+	}
+	if len(retired) != 1 || retired[0] != unnamed {
+		t.Errorf("Expected table without a build ID to be retired; found %v", retired)
+	}
+	retired, warmed = nil, nil
+	st, err := admin.Run(ctx, &AdminCommand{Command: AdminSwap, Path: "/tables/new"})
+	testutil.Fatalf(t, "Run error: %v", err)
+	if err := testutil.DeepEqual([]string{"new"}, st.BuildIDs); err != nil {
+		t.Error(err)
+	}
+	if len(retired) != 1 || retired[0] != old {
+		t.Errorf("Expected old generation to be retired; found %v", retired)
+	}
+	if err := testutil.DeepEqual([]string{"new"}, warmed); err != nil {
+		t.Errorf("Unexpected warmed generations: %v", err)
+	}
+
+	_, err = gens.CrossReferences(ctx, &xpb.CrossReferencesRequest{Ticket: []string{"kythe:#a"}})
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+	if len(next.reqs) != 1 || len(old.reqs) != 0 {
+		t.Errorf("Expected request served by the new generation; found %d new, %d old", len(next.reqs), len(old.reqs))
+	}
+
+	// A rebuilt table of the same build ID replaces (and retires) its
+	// generation.
+	retired, serving = nil, []string{"new"}
+	st, err = admin.Run(ctx, &AdminCommand{Command: AdminSwap, Path: "/tables/rebuilt"})
+	testutil.Fatalf(t, "Run error: %v", err)
+	if err := testutil.DeepEqual([]string{"new"}, st.BuildIDs); err != nil {
+		t.Error(err)
+	}
+	if len(retired) != 1 || retired[0] != next {
+		t.Errorf("Expected replaced generation to be retired; found %v", retired)
+	}
+	_, err = gens.CrossReferences(ctx, &xpb.CrossReferencesRequest{Ticket: []string{"kythe:#a"}})
+	testutil.Fatalf(t, "CrossReferences error: %v", err)
+	if len(rebuilt.reqs) != 1 || len(next.reqs) != 1 {
+		t.Errorf("Expected request served by the rebuilt generation; found %d rebuilt, %d replaced", len(rebuilt.reqs), len(next.reqs))
+	}
+}
+
+func TestAdminSwapSerialized(t *testing.T) {
+	gens := &xrefs.Generations{}
+	testutil.Fatalf(t, "Add error: %v", gens.Add(xrefs.Provenance{BuildID: "old", Service: &recordingService{}}))
+	opening, open := make(chan struct{}), make(chan struct{})
+	admin := &Admin{
+		Generations: gens,
+		OpenTable: func(_ context.Context, path string) (xrefs.Provenance, error) {
+			if path == "/tables/slow" {
+				close(opening)
+				<-open
+			}
+			return xrefs.Provenance{BuildID: path, Service: &recordingService{}}, nil
+		},
+	}
+
+	swapped := make(chan error)
+	go func() {
+		_, err := admin.Run(ctx, &AdminCommand{Command: AdminSwap, Path: "/tables/slow"})
+		swapped <- err
+	}()
+	<-opening
+	go func() {
+		_, err := admin.Run(ctx, &AdminCommand{Command: AdminSwap, Path: "/tables/fast"})
+		swapped <- err
+	}()
+	select {
+	case err := <-swapped:
+		t.Fatalf("Swap finished (error: %v) while another swap was in progress", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(open)
+	for i := 0; i < 2; i++ {
+		testutil.Fatalf(t, "Swap error: %v", <-swapped)
+	}
+	if err := testutil.DeepEqual([]string{"/tables/fast"}, gens.BuildIDs()); err != nil {
+		t.Errorf("Generations after swaps: %v", err)
+	}
+}
+
+func TestAdminHTTP(t *testing.T) {
+	var flushed int
+	admin := &Admin{Caches: []func(){func() { flushed++ }, func() { flushed++ }}}
+
+	// Commands are rejected without an Authorize hook.
+	w := httptest.NewRecorder()
+	admin.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin", strings.NewReader(`{"command": "flush"}`)))
+	if w.Code != http.StatusForbidden || flushed != 0 {
+		t.Errorf("Expected command to be rejected without an Authorize hook; found status %d", w.Code)
+	}
+	admin.Authorize = func(r *http.Request) error {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			return errors.New("unauthorized")
+		}
+		return nil
+	}
+	w = httptest.NewRecorder()
+	admin.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin", strings.NewReader(`{"command": "flush"}`)))
+	if w.Code != http.StatusForbidden || flushed != 0 {
+		t.Errorf("Expected unauthorized command to be rejected; found status %d", w.Code)
+	}
+	post := func(body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/admin", strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer secret")
+		return r
+	}
+
+	w = httptest.NewRecorder()
+	admin.ServeHTTP(w, post(`{"command": "flush"}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", w.Code, w.Body)
+	} else if flushed != 2 {
+		t.Errorf("Expected 2 caches flushed; found %d", flushed)
+	}
+	var st AdminStatus
+	testutil.Fatalf(t, "Error decoding status: %v", json.NewDecoder(w.Body).Decode(&st))
+
+	w = httptest.NewRecorder()
+	admin.ServeHTTP(w, post(`{"command": "swap", "path": "/tables/new"}`))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected swap without generations to fail; found status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	admin.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected GET to be rejected; found status %d", w.Code)
+	}
+}
+
 type testTable struct {
 	Nodes       []*srvpb.Node
 	Decorations []*srvpb.FileDecorations
 	RefSets     []*srvpb.PagedCrossReferences
 	RefPages    []*srvpb.PagedCrossReferences_Page
 	Documents   []*srvpb.Document
+	Names       []*srvpb.DisplayName
+	Digests     []*srvpb.FileDigest
+	FileRefs    []*srvpb.FileReferences
+	Aliases     []*srvpb.TicketAlias
+	FileRels    []*srvpb.FileRelations
+	CallDegrees []*srvpb.CallDegrees
+	Members     []*srvpb.ContainerMembers
+	Rollups     []*srvpb.ContainerRollup
+	Outlines    []*srvpb.FileOutline
+
+	// DecoratedFiles, if non-nil, are the file tickets added to the table's
+	// decorations existence filter.
+	DecoratedFiles []string
 }
 
 func (tbl *testTable) Construct(t *testing.T) *Table {
+	return NewCombinedTable(tbl.protoTable(t))
+}
+
+func (tbl *testTable) protoTable(t *testing.T) testProtoTable {
 	p := make(testProtoTable)
 	for _, d := range tbl.Decorations {
 		testutil.Fatalf(t, "Error writing file decorations: %v", p.Put(ctx, DecorationsKey(mustFix(t, d.File.Ticket)), d))
@@ -3058,7 +8579,45 @@ func (tbl *testTable) Construct(t *testing.T) *Table {
 	for _, doc := range tbl.Documents {
 		testutil.Fatalf(t, "Error writing documents: %v", p.Put(ctx, DocumentationKey(doc.Ticket), doc))
 	}
-	return NewCombinedTable(p)
+	for _, n := range tbl.Names {
+		testutil.Fatalf(t, "Error writing display names: %v", p.Put(ctx, DisplayNameKey(n.Ticket), n))
+	}
+	for _, d := range tbl.Digests {
+		testutil.Fatalf(t, "Error writing file digests: %v", p.Put(ctx, FileDigestKey(d.Digest), d))
+	}
+	for _, r := range tbl.FileRefs {
+		testutil.Fatalf(t, "Error writing file references: %v", p.Put(ctx, FileReferencesKey(mustFix(t, r.File)), r))
+	}
+	for _, a := range tbl.Aliases {
+		testutil.Fatalf(t, "Error writing ticket aliases: %v", p.Put(ctx, TicketAliasKey(mustFix(t, a.Alias)), a))
+	}
+	for _, r := range tbl.FileRels {
+		testutil.Fatalf(t, "Error writing file relations: %v", p.Put(ctx, FileRelationsKey(mustFix(t, r.File)), r))
+	}
+	for _, d := range tbl.CallDegrees {
+		testutil.Fatalf(t, "Error writing call degrees: %v", p.Put(ctx, CallDegreesKey(mustFix(t, d.Ticket)), d))
+	}
+	for _, m := range tbl.Members {
+		testutil.Fatalf(t, "Error writing container members: %v", p.Put(ctx, MembersKey(mustFix(t, m.Container), m.Kind), m))
+	}
+	for _, r := range tbl.Rollups {
+		testutil.Fatalf(t, "Error writing container rollups: %v", p.Put(ctx, RollupsKey(mustFix(t, r.Container)), r))
+	}
+	for _, o := range tbl.Outlines {
+		testutil.Fatalf(t, "Error writing file outlines: %v", p.Put(ctx, OutlineKey(mustFix(t, o.File)), o))
+	}
+	if tbl.DecoratedFiles != nil {
+		f := bloom.New(len(tbl.DecoratedFiles), 0.01)
+		for _, file := range tbl.DecoratedFiles {
+			f.Add(file)
+		}
+		testutil.Fatalf(t, "Error writing decorations filter: %v", p.Put(ctx, DecorationsFilterKey, &srvpb.ExistenceFilter{
+			Bits:      f.Bits(),
+			HashCount: int32(f.Hashes()),
+			Size:      int64(len(tbl.DecoratedFiles)),
+		}))
+	}
+	return p
 }
 
 func mustFix(t *testing.T, ticket string) string {
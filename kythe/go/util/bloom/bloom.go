@@ -0,0 +1,99 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package bloom implements a Bloom filter of strings: a compact set that may
+// report false positives but never false negatives.
+package bloom // import "kythe.io/kythe/go/util/bloom"
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// Filter is a Bloom filter of strings.  The zero value contains nothing and
+// cannot be added to; use New to construct a Filter.
+type Filter struct {
+	bits   []byte
+	hashes int
+}
+
+// New returns an empty Filter sized to hold n strings with a false positive
+// rate of at most fpRate.
+func New(n int, fpRate float64) *Filter {
+	if n < 1 {
+		n = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.01
+	}
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &Filter{bits: make([]byte, (int(m)+7)/8), hashes: k}
+}
+
+// FromBits returns the Filter with the given bits and number of hash
+// functions, as returned by a Filter's Bits and Hashes methods.
+func FromBits(bits []byte, hashes int) *Filter {
+	return &Filter{bits: bits, hashes: hashes}
+}
+
+// Bits returns the filter's bit array.  It is not copied.
+func (f *Filter) Bits() []byte { return f.bits }
+
+// Hashes returns the number of hash functions used by the filter.
+func (f *Filter) Hashes() int { return f.hashes }
+
+// Add adds s to the filter.
+func (f *Filter) Add(s string) {
+	h1, h2, m := hash(s), hash2(s), uint64(len(f.bits))*8
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % m
+		f.bits[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// MayContain reports whether s may have been added to the filter.  A false
+// result is definite.
+func (f *Filter) MayContain(s string) bool {
+	if f == nil || len(f.bits) == 0 || f.hashes < 1 {
+		return false
+	}
+	h1, h2, m := hash(s), hash2(s), uint64(len(f.bits))*8
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % m
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hash and hash2 are the independent hashes combined to derive each of a
+// filter's hash functions (Kirsch and Mitzenmacher's double hashing).
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func hash2(s string) uint64 {
+	h := fnv.New64()
+	h.Write([]byte(s))
+	return h.Sum64() | 1 // odd, so that successive bits differ
+}
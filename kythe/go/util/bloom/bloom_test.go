@@ -0,0 +1,56 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bloom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	const n = 1000
+	f := New(n, 0.01)
+	for i := 0; i < n; i++ {
+		f.Add(fmt.Sprintf("kythe://corpus?path=file%d", i))
+	}
+
+	// Round-trip the filter through its serialized form.
+	f = FromBits(f.Bits(), f.Hashes())
+	for i := 0; i < n; i++ {
+		if s := fmt.Sprintf("kythe://corpus?path=file%d", i); !f.MayContain(s) {
+			t.Errorf("False negative for %q", s)
+		}
+	}
+
+	var falsePositives int
+	for i := n; i < 11*n; i++ {
+		if f.MayContain(fmt.Sprintf("kythe://corpus?path=file%d", i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / (10 * n); rate > 0.02 {
+		t.Errorf("False positive rate too high: %v", rate)
+	}
+}
+
+func TestEmptyFilter(t *testing.T) {
+	for _, f := range []*Filter{nil, {}, New(0, 0)} {
+		if f.MayContain("") || f.MayContain("kythe:?path=file") {
+			t.Errorf("Empty filter %v contains a string", f)
+		}
+	}
+}
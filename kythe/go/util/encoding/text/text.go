@@ -19,6 +19,7 @@ package text // import "kythe.io/kythe/go/util/encoding/text"
 
 import (
 	"errors"
+	"sort"
 
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/htmlindex"
@@ -47,6 +48,104 @@ func ToUTF8(encodingName string, b []byte) (string, error) {
 	return transformBytes(t, b)
 }
 
+// IsUTF8 reports whether text with the given encoding is UTF-8 text.  As in
+// ToUTF8, the empty encoding is taken to be UTF-8.
+func IsUTF8(encodingName string) bool {
+	if encodingName == "" {
+		return true
+	}
+	e, err := htmlindex.Get(encodingName)
+	if err != nil {
+		return false
+	}
+	name, err := htmlindex.Name(e)
+	return err == nil && name == "utf-8"
+}
+
+// ToUTF8WithOffsets converts the given encoded text to UTF-8 text, as ToUTF8,
+// and also returns an OffsetMap from byte offsets within b to byte offsets
+// within the UTF-8 text.
+func ToUTF8WithOffsets(encodingName string, b []byte) ([]byte, *OffsetMap, error) {
+	var t transform.Transformer
+	if encodingName == "" {
+		t = encoding.Replacement.NewEncoder()
+	} else if e, err := htmlindex.Get(encodingName); err != nil {
+		return nil, nil, ErrUnsupportedEncoding
+	} else if e == encoding.Replacement {
+		t = encoding.Replacement.NewEncoder()
+	} else {
+		t = e.NewDecoder()
+	}
+
+	// Each encoded character is transformed separately by offering the
+	// Transformer the shortest source window it will consume.
+	res := make([]byte, 0, len(b))
+	m := &OffsetMap{}
+	buf := make([]byte, 64)
+	for i := 0; i < len(b); {
+		var nDst, nSrc int
+		for n := 1; nSrc == 0; {
+			end := i + n
+			atEOF := end >= len(b)
+			if atEOF {
+				end = len(b)
+			}
+			var err error
+			nDst, nSrc, err = t.Transform(buf, b[i:end], atEOF)
+			switch {
+			case err == transform.ErrShortDst:
+				buf = make([]byte, 2*len(buf))
+			case err != nil && err != transform.ErrShortSrc:
+				return nil, nil, err
+			case nSrc == 0 && atEOF:
+				return nil, nil, errors.New("incomplete encoded text")
+			case nSrc == 0:
+				n++
+			}
+		}
+		m.add(int32(i), int32(len(res)), nSrc == 1 && nDst == 1)
+		res = append(res, buf[:nDst]...)
+		i += nSrc
+	}
+	m.add(int32(len(b)), int32(len(res)), false)
+	return res, m, nil
+}
+
+// An OffsetMap maps byte offsets within an encoded text to byte offsets within
+// its UTF-8 transcoding.  A nil *OffsetMap is the identity mapping.
+type OffsetMap struct {
+	// The text is split into segments, each starting at src[i] in the encoded
+	// text and at dst[i] in the UTF-8 text.  A linear segment is transcoded
+	// byte-for-byte; any other segment is a single encoded character.  The
+	// last segment is an empty sentinel at the end of both texts.
+	src, dst []int32
+	linear   []bool
+}
+
+func (m *OffsetMap) add(src, dst int32, linear bool) {
+	if last := len(m.linear) - 1; linear && last >= 0 && m.linear[last] {
+		return // extend the previous linear segment
+	}
+	m.src = append(m.src, src)
+	m.dst = append(m.dst, dst)
+	m.linear = append(m.linear, linear)
+}
+
+// ToUTF8 returns the byte offset within the UTF-8 text of the given byte offset
+// within the encoded text.  An offset within an encoded character maps to the
+// start of its transcoding and an offset past the end of the encoded text maps
+// to the end of the UTF-8 text.
+func (m *OffsetMap) ToUTF8(offset int32) int32 {
+	if m == nil || offset <= 0 {
+		return offset
+	}
+	i := sort.Search(len(m.src), func(i int) bool { return m.src[i] > offset }) - 1
+	if m.linear[i] {
+		return m.dst[i] + offset - m.src[i]
+	}
+	return m.dst[i]
+}
+
 func transformBytes(e transform.Transformer, text []byte) (string, error) {
 	res, _, err := transform.Bytes(e, text)
 	return string(res), err
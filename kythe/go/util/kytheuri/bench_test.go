@@ -39,3 +39,12 @@ func BenchmarkString(b *testing.B) {
 		_ = p.Encode().String()
 	}
 }
+
+func BenchmarkFixCache(b *testing.B) {
+	c := NewFixCache(16)
+	for i := 0; i < b.N; i++ {
+		if _, err := c.Fix(benchURI); err != nil {
+			panic(err)
+		}
+	}
+}
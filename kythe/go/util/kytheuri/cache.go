@@ -0,0 +1,125 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kytheuri
+
+import "sync"
+
+// DefaultFixCache is a process-wide cache of canonical Kythe URIs used by the
+// serving request handlers.
+var DefaultFixCache = NewFixCache(1 << 16)
+
+// A FixCache caches the canonical forms of Kythe URIs so that frequently seen
+// URIs need not be repeatedly parsed.  Invalid URIs are not cached.  A
+// FixCache is safe for concurrent use.
+//
+// The cache holds two generations of entries; once the current generation
+// holds half of the cache's capacity, the previous generation is discarded.
+// Entries found in the previous generation are promoted to the current one.
+type FixCache struct {
+	mu       sync.Mutex
+	genSize  int
+	cur, old map[string]string
+	stats    FixCacheStats
+}
+
+// FixCacheStats are cumulative usage counters for a FixCache.
+type FixCacheStats struct {
+	Hits, Misses, Evictions uint64
+
+	// Size is the current number of cached URIs.
+	Size int
+}
+
+// NewFixCache returns a FixCache holding at most maxEntries URIs.
+func NewFixCache(maxEntries int) *FixCache {
+	genSize := maxEntries / 2
+	if genSize < 1 {
+		genSize = 1
+	}
+	return &FixCache{
+		genSize: genSize,
+		cur:     make(map[string]string),
+		old:     make(map[string]string),
+	}
+}
+
+// Fix returns the canonical form of the given Kythe URI, if possible.  It is
+// equivalent to the package-level Fix function.
+func (c *FixCache) Fix(s string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fix(s)
+}
+
+// FixAll returns the canonical form of each of the given Kythe URIs.  If any
+// URI is invalid, a slice of errors parallel to uris is also returned with a
+// non-nil error for each invalid URI; the corresponding fixed URI is "".
+func (c *FixCache) FixAll(uris []string) ([]string, []error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fixed := make([]string, len(uris))
+	var errs []error
+	for i, s := range uris {
+		f, err := c.fix(s)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(uris))
+			}
+			errs[i] = err
+			continue
+		}
+		fixed[i] = f
+	}
+	return fixed, errs
+}
+
+// Stats returns the cache's current usage counters.
+func (c *FixCache) Stats() FixCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = len(c.cur) + len(c.old)
+	return stats
+}
+
+// fix implements Fix; c.mu must be held.
+func (c *FixCache) fix(s string) (string, error) {
+	if f, ok := c.cur[s]; ok {
+		c.stats.Hits++
+		return f, nil
+	} else if f, ok := c.old[s]; ok {
+		c.stats.Hits++
+		delete(c.old, s)
+		c.add(s, f)
+		return f, nil
+	}
+	c.stats.Misses++
+	f, err := Fix(s)
+	if err != nil {
+		return "", err
+	}
+	c.add(s, f)
+	return f, nil
+}
+
+func (c *FixCache) add(s, fixed string) {
+	if len(c.cur) >= c.genSize {
+		c.stats.Evictions += uint64(len(c.old))
+		c.old, c.cur = c.cur, make(map[string]string, c.genSize)
+	}
+	c.cur[s] = fixed
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kytheuri
+
+import "testing"
+
+func TestFixCache(t *testing.T) {
+	c := NewFixCache(4)
+	for i := 0; i < 2; i++ {
+		fixed, err := c.Fix("kythe://c?path=p?lang=go#sig")
+		if err != nil {
+			t.Fatalf("Fix error: %v", err)
+		} else if want := "kythe://c?lang=go?path=p#sig"; fixed != want {
+			t.Errorf("Fix: got %q, want %q", fixed, want)
+		}
+	}
+	if _, err := c.Fix("bad:ticket"); err == nil {
+		t.Error("Fix: expected error for invalid ticket")
+	}
+	if got, want := c.Stats(), (FixCacheStats{Hits: 1, Misses: 2, Size: 1}); got != want {
+		t.Errorf("Stats: got %+v, want %+v", got, want)
+	}
+}
+
+func TestFixCacheEviction(t *testing.T) {
+	c := NewFixCache(4)
+	for _, sig := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := c.Fix("kythe:#" + sig); err != nil {
+			t.Fatalf("Fix error: %v", err)
+		}
+	}
+	// "a" and "b" were discarded when "e" started a new generation.
+	if got, want := c.Stats(), (FixCacheStats{Misses: 5, Evictions: 2, Size: 3}); got != want {
+		t.Errorf("Stats: got %+v, want %+v", got, want)
+	}
+
+	// Hits in the previous generation are promoted.
+	c.Fix("kythe:#c")
+	c.Fix("kythe:#f")
+	if got, want := c.Stats(), (FixCacheStats{Hits: 1, Misses: 6, Evictions: 3, Size: 3}); got != want {
+		t.Errorf("Stats: got %+v, want %+v", got, want)
+	}
+	if _, ok := c.old["kythe:#c"]; !ok {
+		t.Error("Expected kythe:#c to be retained")
+	}
+}
+
+func TestFixCacheFixAll(t *testing.T) {
+	c := NewFixCache(16)
+	fixed, errs := c.FixAll([]string{"kythe:#a", "kythe:#b"})
+	if errs != nil {
+		t.Fatalf("FixAll errors: %v", errs)
+	} else if fixed[0] != "kythe:#a" || fixed[1] != "kythe:#b" {
+		t.Errorf("FixAll: unexpected result %q", fixed)
+	}
+
+	fixed, errs = c.FixAll([]string{"kythe:#a", "bad:ticket"})
+	if len(errs) != 2 || errs[0] != nil || errs[1] == nil {
+		t.Errorf("FixAll: unexpected errors %v", errs)
+	} else if fixed[0] != "kythe:#a" || fixed[1] != "" {
+		t.Errorf("FixAll: unexpected result %q", fixed)
+	}
+}
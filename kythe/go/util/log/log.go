@@ -0,0 +1,175 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package log provides leveled logging with optional request-scoped IDs
+// threaded through a context.Context.  By default, messages at or above
+// InfoLevel are written using the standard library's log package; both the
+// minimum level and the underlying Logger may be replaced.
+package log // import "kythe.io/kythe/go/util/log"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	stdlog "log"
+	"sync"
+	"sync/atomic"
+)
+
+// Level is the severity of a log message.
+type Level int32
+
+// Supported logging levels in increasing order of severity.
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarningLevel
+	ErrorLevel
+)
+
+// String implements the fmt.Stringer interface.
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarningLevel:
+		return "WARNING"
+	case ErrorLevel:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LEVEL(%d)", int32(l))
+	}
+}
+
+// A Logger emits formatted log messages.  Implementations must be safe for
+// concurrent use.
+type Logger interface {
+	// Log emits msg at the given level.  The given context may carry a request
+	// ID (see RequestID).
+	Log(ctx context.Context, level Level, msg string)
+}
+
+// StdLogger is a Logger that writes to the standard library's log package.
+// Each message is prefixed with its level and, if present, its request ID.
+type StdLogger struct{}
+
+// Log implements part of the Logger interface.
+func (StdLogger) Log(ctx context.Context, level Level, msg string) {
+	if id := RequestID(ctx); id != "" {
+		stdlog.Printf("%s: [%s] %s", level, id, msg)
+	} else {
+		stdlog.Printf("%s: %s", level, msg)
+	}
+}
+
+var (
+	mu     sync.RWMutex
+	logger Logger = StdLogger{}
+
+	minLevel = int32(InfoLevel)
+)
+
+// SetLogger replaces the Logger used by this package.  A nil Logger discards
+// all messages.
+func SetLogger(l Logger) {
+	mu.Lock()
+	defer mu.Unlock()
+	logger = l
+}
+
+// SetLevel sets the minimum Level of messages to emit.
+func SetLevel(l Level) { atomic.StoreInt32(&minLevel, int32(l)) }
+
+// Enabled reports whether messages at the given level will be emitted.
+func Enabled(l Level) bool { return int32(l) >= atomic.LoadInt32(&minLevel) }
+
+func logf(ctx context.Context, level Level, format string, args []interface{}) {
+	if !Enabled(level) {
+		return
+	}
+	mu.RLock()
+	l := logger
+	mu.RUnlock()
+	if l != nil {
+		l.Log(ctx, level, fmt.Sprintf(format, args...))
+	}
+}
+
+// Debugf logs a message at DebugLevel.
+func Debugf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, DebugLevel, format, args)
+}
+
+// Infof logs a message at InfoLevel.
+func Infof(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, InfoLevel, format, args)
+}
+
+// Warningf logs a message at WarningLevel.
+func Warningf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, WarningLevel, format, args)
+}
+
+// Errorf logs a message at ErrorLevel.
+func Errorf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, ErrorLevel, format, args)
+}
+
+// Panicf logs a message at ErrorLevel and then panics with the same message.
+func Panicf(ctx context.Context, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logf(ctx, ErrorLevel, "%s", []interface{}{msg})
+	panic(msg)
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a derived context carrying the given request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx or "" if there is none.
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// EnsureRequestID returns ctx if it already carries a request ID.  Otherwise,
+// a derived context carrying a newly generated ID is returned.
+func EnsureRequestID(ctx context.Context) context.Context {
+	if RequestID(ctx) != "" {
+		return ctx
+	}
+	return WithRequestID(ctx, NewRequestID())
+}
+
+var requestCounter uint64
+
+// NewRequestID returns a new process-unique request ID.
+func NewRequestID() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", atomic.AddUint64(&requestCounter, 1))
+	}
+	return fmt.Sprintf("%s-%x", hex.EncodeToString(b[:]), atomic.AddUint64(&requestCounter, 1))
+}
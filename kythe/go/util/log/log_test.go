@@ -0,0 +1,86 @@
+/*
+ * Copyright 2023 The Kythe Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+type message struct {
+	level Level
+	id    string
+	msg   string
+}
+
+type recorder struct {
+	mu   sync.Mutex
+	msgs []message
+}
+
+func (r *recorder) Log(ctx context.Context, level Level, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msgs = append(r.msgs, message{level, RequestID(ctx), msg})
+}
+
+func TestLevels(t *testing.T) {
+	rec := &recorder{}
+	SetLogger(rec)
+	defer SetLogger(StdLogger{})
+	SetLevel(WarningLevel)
+	defer SetLevel(InfoLevel)
+
+	ctx := WithRequestID(context.Background(), "req1")
+	Debugf(ctx, "debug %d", 1)
+	Infof(ctx, "info %d", 2)
+	Warningf(ctx, "warning %d", 3)
+	Errorf(context.Background(), "error %d", 4)
+
+	expected := []message{
+		{WarningLevel, "req1", "warning 3"},
+		{ErrorLevel, "", "error 4"},
+	}
+	if len(rec.msgs) != len(expected) {
+		t.Fatalf("Expected %d messages; found: %v", len(expected), rec.msgs)
+	}
+	for i, m := range rec.msgs {
+		if m != expected[i] {
+			t.Errorf("Expected message %v; found: %v", expected[i], m)
+		}
+	}
+}
+
+func TestEnsureRequestID(t *testing.T) {
+	ctx := context.Background()
+	if id := RequestID(ctx); id != "" {
+		t.Fatalf("Unexpected request ID: %q", id)
+	}
+
+	ctx = EnsureRequestID(ctx)
+	id := RequestID(ctx)
+	if id == "" {
+		t.Fatal("Missing request ID")
+	}
+	if found := RequestID(EnsureRequestID(ctx)); found != id {
+		t.Errorf("Request ID changed: %q -> %q", id, found)
+	}
+	if other := NewRequestID(); other == id {
+		t.Errorf("Request IDs not unique: %q", other)
+	}
+}
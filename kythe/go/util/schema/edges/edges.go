@@ -61,6 +61,7 @@ const (
 	RefImplicit       = Prefix + "ref/implicit"
 	RefCallImplicit   = Prefix + "ref/call/implicit"
 	RefImports        = Prefix + "ref/imports"
+	RefIncludes       = Prefix + "ref/includes"
 	RefInit           = Prefix + "ref/init"
 	RefInitImplicit   = Prefix + "ref/init/implicit"
 	RefWrites         = Prefix + "ref/writes"
@@ -129,7 +130,7 @@ func ParseOrdinal(kind string) (base string, ordinal int, hasOrdinal bool) {
 // generally has an associated ordinal (e.g. /kythe/edge/param edges).
 func OrdinalKind(kind string) bool {
 	switch Canonical(kind) {
-	case Param:
+	case Param, TParam:
 		return true
 	default:
 		return false
@@ -30,6 +30,7 @@ const (
 	Deprecated   = prefix + "tag/deprecated"
 	Details      = prefix + "details"
 	DocURI       = prefix + "doc/uri"
+	Generated    = prefix + "generated"
 	Message      = prefix + "message"
 	NodeKind     = prefix + "node/kind"
 	ParamDefault = prefix + "param/default"
@@ -38,6 +39,7 @@ const (
 	Subkind      = prefix + "subkind"
 	Text         = prefix + "text"
 	TextEncoding = prefix + "text/encoding"
+	VCSID        = prefix + "vcs/id"
 )
 
 // DefaultTextEncoding is the implicit value for TextEncoding if it is empty or
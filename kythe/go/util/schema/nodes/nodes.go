@@ -37,6 +37,7 @@ const (
 	TNominal   = "tnominal"
 	TVar       = "tvar"
 	Variable   = "variable"
+	VCS        = "vcs"
 )
 
 // Node subkinds
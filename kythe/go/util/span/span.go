@@ -326,6 +326,21 @@ func NewNormalizer(text []byte) *Normalizer {
 	return &Normalizer{int32(len(text)), lineLen, prefixLen}
 }
 
+// LineTable returns the byte offset of the start of each line in the
+// Normalizer's text along with each line's length in bytes, excluding its line
+// terminator.  The text's final line may be empty.
+func (n *Normalizer) LineTable() (starts, lengths []int32) {
+	starts = make([]int32, len(n.prefixLen))
+	lengths = make([]int32, len(n.lineLen))
+	copy(starts, n.prefixLen)
+	for i, l := range n.lineLen[:len(n.lineLen)-1] {
+		lengths[i] = l - int32(len(lineEnd))
+	}
+	last := len(n.lineLen) - 1
+	lengths[last] = n.textLen - n.prefixLen[last]
+	return starts, lengths
+}
+
 // Location returns a normalized location within the Normalizer's text.
 // Normalized FILE locations have no start/end points.  Normalized SPAN
 // locations have fully populated start/end points clamped in the range [0,
@@ -118,6 +118,27 @@ last line without newline`
 	}
 }
 
+func TestNormalizerLineTable(t *testing.T) {
+	tests := []struct {
+		text            string
+		starts, lengths []int32
+	}{
+		{"", []int32{0}, []int32{0}},
+		{"line", []int32{0}, []int32{4}},
+		{"line 1\nline 2\n", []int32{0, 7, 14}, []int32{6, 6, 0}},
+		{"a\n\nlast line", []int32{0, 2, 3}, []int32{1, 0, 9}},
+	}
+	for _, test := range tests {
+		starts, lengths := NewNormalizer([]byte(test.text)).LineTable()
+		if err := testutil.DeepEqual(test.starts, starts); err != nil {
+			t.Errorf("LineTable(%q) starts: %v", test.text, err)
+		}
+		if err := testutil.DeepEqual(test.lengths, lengths); err != nil {
+			t.Errorf("LineTable(%q) lengths: %v", test.text, err)
+		}
+	}
+}
+
 func TestPatcher(t *testing.T) {
 	tests := []struct {
 		oldText, newText string
@@ -9,6 +9,7 @@ package graph_go_proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	common_go_proto "kythe.io/kythe/proto/common_go_proto"
 	reflect "reflect"
 	sync "sync"
@@ -21,13 +22,132 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type NodesReply_TicketStatus_Code int32
+
+const (
+	NodesReply_TicketStatus_UNSPECIFIED_STATUS NodesReply_TicketStatus_Code = 0
+	NodesReply_TicketStatus_FOUND              NodesReply_TicketStatus_Code = 1
+	NodesReply_TicketStatus_NO_MATCHING_FACTS  NodesReply_TicketStatus_Code = 2
+	NodesReply_TicketStatus_NOT_FOUND          NodesReply_TicketStatus_Code = 3
+	NodesReply_TicketStatus_ERROR              NodesReply_TicketStatus_Code = 4
+	NodesReply_TicketStatus_UNCHANGED          NodesReply_TicketStatus_Code = 5
+)
+
+// Enum value maps for NodesReply_TicketStatus_Code.
+var (
+	NodesReply_TicketStatus_Code_name = map[int32]string{
+		0: "UNSPECIFIED_STATUS",
+		1: "FOUND",
+		2: "NO_MATCHING_FACTS",
+		3: "NOT_FOUND",
+		4: "ERROR",
+		5: "UNCHANGED",
+	}
+	NodesReply_TicketStatus_Code_value = map[string]int32{
+		"UNSPECIFIED_STATUS": 0,
+		"FOUND":              1,
+		"NO_MATCHING_FACTS":  2,
+		"NOT_FOUND":          3,
+		"ERROR":              4,
+		"UNCHANGED":          5,
+	}
+)
+
+func (x NodesReply_TicketStatus_Code) Enum() *NodesReply_TicketStatus_Code {
+	p := new(NodesReply_TicketStatus_Code)
+	*p = x
+	return p
+}
+
+func (x NodesReply_TicketStatus_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NodesReply_TicketStatus_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_kythe_proto_graph_proto_enumTypes[0].Descriptor()
+}
+
+func (NodesReply_TicketStatus_Code) Type() protoreflect.EnumType {
+	return &file_kythe_proto_graph_proto_enumTypes[0]
+}
+
+func (x NodesReply_TicketStatus_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NodesReply_TicketStatus_Code.Descriptor instead.
+func (NodesReply_TicketStatus_Code) EnumDescriptor() ([]byte, []int) {
+	return file_kythe_proto_graph_proto_rawDescGZIP(), []int{1, 2, 0}
+}
+
+type EdgesRequest_KindPreset int32
+
+const (
+	EdgesRequest_ALL_KINDS   EdgesRequest_KindPreset = 0
+	EdgesRequest_SEMANTIC    EdgesRequest_KindPreset = 1
+	EdgesRequest_STRUCTURE   EdgesRequest_KindPreset = 2
+	EdgesRequest_ALL_FORWARD EdgesRequest_KindPreset = 3
+	EdgesRequest_ALL_REVERSE EdgesRequest_KindPreset = 4
+)
+
+// Enum value maps for EdgesRequest_KindPreset.
+var (
+	EdgesRequest_KindPreset_name = map[int32]string{
+		0: "ALL_KINDS",
+		1: "SEMANTIC",
+		2: "STRUCTURE",
+		3: "ALL_FORWARD",
+		4: "ALL_REVERSE",
+	}
+	EdgesRequest_KindPreset_value = map[string]int32{
+		"ALL_KINDS":   0,
+		"SEMANTIC":    1,
+		"STRUCTURE":   2,
+		"ALL_FORWARD": 3,
+		"ALL_REVERSE": 4,
+	}
+)
+
+func (x EdgesRequest_KindPreset) Enum() *EdgesRequest_KindPreset {
+	p := new(EdgesRequest_KindPreset)
+	*p = x
+	return p
+}
+
+func (x EdgesRequest_KindPreset) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EdgesRequest_KindPreset) Descriptor() protoreflect.EnumDescriptor {
+	return file_kythe_proto_graph_proto_enumTypes[1].Descriptor()
+}
+
+func (EdgesRequest_KindPreset) Type() protoreflect.EnumType {
+	return &file_kythe_proto_graph_proto_enumTypes[1]
+}
+
+func (x EdgesRequest_KindPreset) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EdgesRequest_KindPreset.Descriptor instead.
+func (EdgesRequest_KindPreset) EnumDescriptor() ([]byte, []int) {
+	return file_kythe_proto_graph_proto_rawDescGZIP(), []int{2, 0}
+}
+
 type NodesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket []string `protobuf:"bytes,1,rep,name=ticket,proto3" json:"ticket,omitempty"`
-	Filter []string `protobuf:"bytes,2,rep,name=filter,proto3" json:"filter,omitempty"`
+	Ticket              []string `protobuf:"bytes,1,rep,name=ticket,proto3" json:"ticket,omitempty"`
+	Filter              []string `protobuf:"bytes,2,rep,name=filter,proto3" json:"filter,omitempty"`
+	MaxFactBytes        int32    `protobuf:"varint,3,opt,name=max_fact_bytes,json=maxFactBytes,proto3" json:"max_fact_bytes,omitempty"`
+	Strict              bool     `protobuf:"varint,4,opt,name=strict,proto3" json:"strict,omitempty"`
+	TicketPattern       string   `protobuf:"bytes,5,opt,name=ticket_pattern,json=ticketPattern,proto3" json:"ticket_pattern,omitempty"`
+	TicketStatuses      bool     `protobuf:"varint,6,opt,name=ticket_statuses,json=ticketStatuses,proto3" json:"ticket_statuses,omitempty"`
+	ChangedSinceBuildId string   `protobuf:"bytes,7,opt,name=changed_since_build_id,json=changedSinceBuildId,proto3" json:"changed_since_build_id,omitempty"`
+	BuildId             string   `protobuf:"bytes,8,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
 }
 
 func (x *NodesRequest) Reset() {
@@ -76,12 +196,61 @@ func (x *NodesRequest) GetFilter() []string {
 	return nil
 }
 
+func (x *NodesRequest) GetMaxFactBytes() int32 {
+	if x != nil {
+		return x.MaxFactBytes
+	}
+	return 0
+}
+
+func (x *NodesRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+func (x *NodesRequest) GetTicketPattern() string {
+	if x != nil {
+		return x.TicketPattern
+	}
+	return ""
+}
+
+func (x *NodesRequest) GetTicketStatuses() bool {
+	if x != nil {
+		return x.TicketStatuses
+	}
+	return false
+}
+
+func (x *NodesRequest) GetChangedSinceBuildId() string {
+	if x != nil {
+		return x.ChangedSinceBuildId
+	}
+	return ""
+}
+
+func (x *NodesRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
 type NodesReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodes map[string]*common_go_proto.NodeInfo `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Nodes            map[string]*common_go_proto.NodeInfo `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MissingTicket    []string                             `protobuf:"bytes,2,rep,name=missing_ticket,json=missingTicket,proto3" json:"missing_ticket,omitempty"`
+	RedirectedTicket map[string]string                    `protobuf:"bytes,3,rep,name=redirected_ticket,json=redirectedTicket,proto3" json:"redirected_ticket,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PatternTruncated bool                                 `protobuf:"varint,4,opt,name=pattern_truncated,json=patternTruncated,proto3" json:"pattern_truncated,omitempty"`
+	TicketStatus     map[string]*NodesReply_TicketStatus  `protobuf:"bytes,5,rep,name=ticket_status,json=ticketStatus,proto3" json:"ticket_status,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BuildId          string                               `protobuf:"bytes,6,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	BuildTime        *timestamppb.Timestamp               `protobuf:"bytes,7,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	RemovedFacts     map[string]*NodesReply_RemovedFacts  `protobuf:"bytes,8,rep,name=removed_facts,json=removedFacts,proto3" json:"removed_facts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *NodesReply) Reset() {
@@ -123,16 +292,71 @@ func (x *NodesReply) GetNodes() map[string]*common_go_proto.NodeInfo {
 	return nil
 }
 
+func (x *NodesReply) GetMissingTicket() []string {
+	if x != nil {
+		return x.MissingTicket
+	}
+	return nil
+}
+
+func (x *NodesReply) GetRedirectedTicket() map[string]string {
+	if x != nil {
+		return x.RedirectedTicket
+	}
+	return nil
+}
+
+func (x *NodesReply) GetPatternTruncated() bool {
+	if x != nil {
+		return x.PatternTruncated
+	}
+	return false
+}
+
+func (x *NodesReply) GetTicketStatus() map[string]*NodesReply_TicketStatus {
+	if x != nil {
+		return x.TicketStatus
+	}
+	return nil
+}
+
+func (x *NodesReply) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *NodesReply) GetBuildTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildTime
+	}
+	return nil
+}
+
+func (x *NodesReply) GetRemovedFacts() map[string]*NodesReply_RemovedFacts {
+	if x != nil {
+		return x.RemovedFacts
+	}
+	return nil
+}
+
 type EdgesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket    []string `protobuf:"bytes,1,rep,name=ticket,proto3" json:"ticket,omitempty"`
-	Kind      []string `protobuf:"bytes,2,rep,name=kind,proto3" json:"kind,omitempty"`
-	Filter    []string `protobuf:"bytes,3,rep,name=filter,proto3" json:"filter,omitempty"`
-	PageSize  int32    `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken string   `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Ticket         []string                   `protobuf:"bytes,1,rep,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind           []string                   `protobuf:"bytes,2,rep,name=kind,proto3" json:"kind,omitempty"`
+	Filter         []string                   `protobuf:"bytes,3,rep,name=filter,proto3" json:"filter,omitempty"`
+	PageSize       int32                      `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string                     `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Strict         bool                       `protobuf:"varint,10,opt,name=strict,proto3" json:"strict,omitempty"`
+	CountOnly      bool                       `protobuf:"varint,11,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+	CanonicalKinds bool                       `protobuf:"varint,12,opt,name=canonical_kinds,json=canonicalKinds,proto3" json:"canonical_kinds,omitempty"`
+	KindPreset     EdgesRequest_KindPreset    `protobuf:"varint,13,opt,name=kind_preset,json=kindPreset,proto3,enum=kythe.proto.EdgesRequest_KindPreset" json:"kind_preset,omitempty"`
+	BuildId        string                     `protobuf:"bytes,14,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	KindFilter     []*EdgesRequest_KindFilter `protobuf:"bytes,15,rep,name=kind_filter,json=kindFilter,proto3" json:"kind_filter,omitempty"`
 }
 
 func (x *EdgesRequest) Reset() {
@@ -202,6 +426,48 @@ func (x *EdgesRequest) GetPageToken() string {
 	return ""
 }
 
+func (x *EdgesRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+func (x *EdgesRequest) GetCountOnly() bool {
+	if x != nil {
+		return x.CountOnly
+	}
+	return false
+}
+
+func (x *EdgesRequest) GetCanonicalKinds() bool {
+	if x != nil {
+		return x.CanonicalKinds
+	}
+	return false
+}
+
+func (x *EdgesRequest) GetKindPreset() EdgesRequest_KindPreset {
+	if x != nil {
+		return x.KindPreset
+	}
+	return EdgesRequest_ALL_KINDS
+}
+
+func (x *EdgesRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *EdgesRequest) GetKindFilter() []*EdgesRequest_KindFilter {
+	if x != nil {
+		return x.KindFilter
+	}
+	return nil
+}
+
 type EdgeSet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -249,6 +515,53 @@ func (x *EdgeSet) GetGroups() map[string]*EdgeSet_Group {
 	return nil
 }
 
+type EdgeCounts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ByKind map[string]int64 `protobuf:"bytes,1,rep,name=by_kind,json=byKind,proto3" json:"by_kind,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *EdgeCounts) Reset() {
+	*x = EdgeCounts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_graph_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EdgeCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgeCounts) ProtoMessage() {}
+
+func (x *EdgeCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_graph_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgeCounts.ProtoReflect.Descriptor instead.
+func (*EdgeCounts) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_graph_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EdgeCounts) GetByKind() map[string]int64 {
+	if x != nil {
+		return x.ByKind
+	}
+	return nil
+}
+
 type EdgesReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -257,13 +570,18 @@ type EdgesReply struct {
 	EdgeSets         map[string]*EdgeSet                  `protobuf:"bytes,1,rep,name=edge_sets,json=edgeSets,proto3" json:"edge_sets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Nodes            map[string]*common_go_proto.NodeInfo `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	TotalEdgesByKind map[string]int64                     `protobuf:"bytes,5,rep,name=total_edges_by_kind,json=totalEdgesByKind,proto3" json:"total_edges_by_kind,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	EdgeCounts       map[string]*EdgeCounts               `protobuf:"bytes,12,rep,name=edge_counts,json=edgeCounts,proto3" json:"edge_counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	NextPageToken    string                               `protobuf:"bytes,9,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	MissingTicket    []string                             `protobuf:"bytes,10,rep,name=missing_ticket,json=missingTicket,proto3" json:"missing_ticket,omitempty"`
+	RedirectedTicket map[string]string                    `protobuf:"bytes,11,rep,name=redirected_ticket,json=redirectedTicket,proto3" json:"redirected_ticket,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BuildId          string                               `protobuf:"bytes,13,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	BuildTime        *timestamppb.Timestamp               `protobuf:"bytes,14,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
 }
 
 func (x *EdgesReply) Reset() {
 	*x = EdgesReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_graph_proto_msgTypes[4]
+		mi := &file_kythe_proto_graph_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -276,7 +594,7 @@ func (x *EdgesReply) String() string {
 func (*EdgesReply) ProtoMessage() {}
 
 func (x *EdgesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_graph_proto_msgTypes[4]
+	mi := &file_kythe_proto_graph_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,7 +607,7 @@ func (x *EdgesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EdgesReply.ProtoReflect.Descriptor instead.
 func (*EdgesReply) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_graph_proto_rawDescGZIP(), []int{4}
+	return file_kythe_proto_graph_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *EdgesReply) GetEdgeSets() map[string]*EdgeSet {
@@ -313,6 +631,13 @@ func (x *EdgesReply) GetTotalEdgesByKind() map[string]int64 {
 	return nil
 }
 
+func (x *EdgesReply) GetEdgeCounts() map[string]*EdgeCounts {
+	if x != nil {
+		return x.EdgeCounts
+	}
+	return nil
+}
+
 func (x *EdgesReply) GetNextPageToken() string {
 	if x != nil {
 		return x.NextPageToken
@@ -320,6 +645,191 @@ func (x *EdgesReply) GetNextPageToken() string {
 	return ""
 }
 
+func (x *EdgesReply) GetMissingTicket() []string {
+	if x != nil {
+		return x.MissingTicket
+	}
+	return nil
+}
+
+func (x *EdgesReply) GetRedirectedTicket() map[string]string {
+	if x != nil {
+		return x.RedirectedTicket
+	}
+	return nil
+}
+
+func (x *EdgesReply) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *EdgesReply) GetBuildTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildTime
+	}
+	return nil
+}
+
+type NodesReply_TicketStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    NodesReply_TicketStatus_Code `protobuf:"varint,1,opt,name=code,proto3,enum=kythe.proto.NodesReply_TicketStatus_Code" json:"code,omitempty"`
+	Message string                       `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *NodesReply_TicketStatus) Reset() {
+	*x = NodesReply_TicketStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_graph_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodesReply_TicketStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodesReply_TicketStatus) ProtoMessage() {}
+
+func (x *NodesReply_TicketStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_graph_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodesReply_TicketStatus.ProtoReflect.Descriptor instead.
+func (*NodesReply_TicketStatus) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_graph_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *NodesReply_TicketStatus) GetCode() NodesReply_TicketStatus_Code {
+	if x != nil {
+		return x.Code
+	}
+	return NodesReply_TicketStatus_UNSPECIFIED_STATUS
+}
+
+func (x *NodesReply_TicketStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type NodesReply_RemovedFacts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name []string `protobuf:"bytes,1,rep,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *NodesReply_RemovedFacts) Reset() {
+	*x = NodesReply_RemovedFacts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_graph_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodesReply_RemovedFacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodesReply_RemovedFacts) ProtoMessage() {}
+
+func (x *NodesReply_RemovedFacts) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_graph_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodesReply_RemovedFacts.ProtoReflect.Descriptor instead.
+func (*NodesReply_RemovedFacts) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_graph_proto_rawDescGZIP(), []int{1, 4}
+}
+
+func (x *NodesReply_RemovedFacts) GetName() []string {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+type EdgesRequest_KindFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind   string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Filter []string `protobuf:"bytes,2,rep,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *EdgesRequest_KindFilter) Reset() {
+	*x = EdgesRequest_KindFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_graph_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EdgesRequest_KindFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgesRequest_KindFilter) ProtoMessage() {}
+
+func (x *EdgesRequest_KindFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_graph_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgesRequest_KindFilter.ProtoReflect.Descriptor instead.
+func (*EdgesRequest_KindFilter) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_graph_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *EdgesRequest_KindFilter) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *EdgesRequest_KindFilter) GetFilter() []string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
 type EdgeSet_Group struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -331,7 +841,7 @@ type EdgeSet_Group struct {
 func (x *EdgeSet_Group) Reset() {
 	*x = EdgeSet_Group{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_graph_proto_msgTypes[6]
+		mi := &file_kythe_proto_graph_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -344,7 +854,7 @@ func (x *EdgeSet_Group) String() string {
 func (*EdgeSet_Group) ProtoMessage() {}
 
 func (x *EdgeSet_Group) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_graph_proto_msgTypes[6]
+	mi := &file_kythe_proto_graph_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,7 +889,7 @@ type EdgeSet_Group_Edge struct {
 func (x *EdgeSet_Group_Edge) Reset() {
 	*x = EdgeSet_Group_Edge{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_graph_proto_msgTypes[8]
+		mi := &file_kythe_proto_graph_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -392,7 +902,7 @@ func (x *EdgeSet_Group_Edge) String() string {
 func (*EdgeSet_Group_Edge) ProtoMessage() {}
 
 func (x *EdgeSet_Group_Edge) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_graph_proto_msgTypes[8]
+	mi := &file_kythe_proto_graph_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,97 +937,234 @@ var File_kythe_proto_graph_proto protoreflect.FileDescriptor
 var file_kythe_proto_graph_proto_rawDesc = []byte{
 	0x0a, 0x17, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72,
 	0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0x3e, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x22, 0x9e, 0x01, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x9c, 0x02, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x46,
+	0x61, 0x63, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69,
+	0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73,
+	0x12, 0x33, 0x0a, 0x16, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x13, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64,
+	0x22, 0xd0, 0x08, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
 	0x38, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
 	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64,
 	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x56, 0x0a, 0x0a, 0x4e, 0x6f, 0x64,
-	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f,
-	0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0x8e, 0x01, 0x0a, 0x0c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
-	0x6e, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
-	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
-	0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
-	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x22, 0xc1, 0x02, 0x0a, 0x07, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74, 0x12, 0x38,
-	0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
-	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67,
-	0x65, 0x53, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x1a, 0x8f, 0x01, 0x0a, 0x05, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x12, 0x33, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45,
-	0x64, 0x67, 0x65, 0x53, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x45, 0x64, 0x67,
-	0x65, 0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x1a, 0x45, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12,
-	0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x69,
-	0x63, 0x6b, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x4a, 0x04,
-	0x08, 0x01, 0x10, 0x02, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x1a, 0x55, 0x0a, 0x0b, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6b, 0x79, 0x74,
-	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74,
-	0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x52, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
-	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x80, 0x04, 0x0a, 0x0a, 0x45, 0x64, 0x67, 0x65, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x42, 0x0a, 0x09, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x73, 0x65,
-	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x08, 0x65, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74, 0x73, 0x12, 0x38, 0x0a, 0x05, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f,
-	0x64, 0x65, 0x73, 0x12, 0x5c, 0x0a, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x65, 0x64, 0x67,
-	0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x2d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45,
-	0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45,
-	0x64, 0x67, 0x65, 0x73, 0x42, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x42, 0x79, 0x4b, 0x69, 0x6e,
-	0x64, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74,
-	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x51, 0x0a, 0x0d, 0x45, 0x64, 0x67,
-	0x65, 0x53, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65,
-	0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x56, 0x0a, 0x0a,
-	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x64, 0x67,
-	0x65, 0x73, 0x42, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x5a, 0x0a, 0x11, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x72, 0x65, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x11,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x4e, 0x0a, 0x0d, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x4e, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x46, 0x61, 0x63, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x46, 0x61, 0x63, 0x74, 0x73, 0x1a,
+	0x56, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
 	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x8c, 0x01, 0x0a, 0x0c, 0x47, 0x72,
-	0x61, 0x70, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x4e, 0x6f,
-	0x64, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64,
-	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x05, 0x45, 0x64, 0x67,
-	0x65, 0x73, 0x12, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
-	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65,
-	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x31, 0x0a, 0x1f, 0x63, 0x6f, 0x6d, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x64, 0x65, 0x76, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e,
-	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5a, 0x0e, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x5f, 0x67, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x52, 0x65, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xd2, 0x01, 0x0a,
+	0x0c, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3d, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x69, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x12, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10,
+	0x01, 0x12, 0x15, 0x0a, 0x11, 0x4e, 0x4f, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x49, 0x4e, 0x47,
+	0x5f, 0x46, 0x41, 0x43, 0x54, 0x53, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f,
+	0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10,
+	0x05, 0x1a, 0x65, 0x0a, 0x11, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x22, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x64, 0x46, 0x61, 0x63, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x65, 0x0a, 0x11,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x46, 0x61, 0x63, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x64, 0x46, 0x61, 0x63, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xad, 0x04, 0x0a, 0x0c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63,
+	0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x4b,
+	0x69, 0x6e, 0x64, 0x73, 0x12, 0x45, 0x0a, 0x0b, 0x6b, 0x69, 0x6e, 0x64, 0x5f, 0x70, 0x72, 0x65,
+	0x73, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x52,
+	0x0a, 0x6b, 0x69, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x73, 0x65, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x45, 0x0a, 0x0b, 0x6b, 0x69, 0x6e, 0x64, 0x5f, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x52, 0x0a, 0x6b, 0x69, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x38, 0x0a,
+	0x0a, 0x4b, 0x69, 0x6e, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x5a, 0x0a, 0x0a, 0x4b, 0x69, 0x6e, 0x64, 0x50,
+	0x72, 0x65, 0x73, 0x65, 0x74, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x4c, 0x4c, 0x5f, 0x4b, 0x49, 0x4e,
+	0x44, 0x53, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x45, 0x4d, 0x41, 0x4e, 0x54, 0x49, 0x43,
+	0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x55, 0x52, 0x45, 0x10,
+	0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x4c, 0x4c, 0x5f, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44,
+	0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x56, 0x45, 0x52, 0x53,
+	0x45, 0x10, 0x04, 0x22, 0xc1, 0x02, 0x0a, 0x07, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74, 0x12,
+	0x38, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64,
+	0x67, 0x65, 0x53, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x1a, 0x8f, 0x01, 0x0a, 0x05, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x12, 0x33, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x45, 0x64,
+	0x67, 0x65, 0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x1a, 0x45, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x4a,
+	0x04, 0x08, 0x01, 0x10, 0x02, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x1a, 0x55, 0x0a, 0x0b, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65,
+	0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x52, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x85, 0x01, 0x0a, 0x0a, 0x45, 0x64, 0x67, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x3c, 0x0a, 0x07, 0x62, 0x79, 0x5f, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x2e, 0x42, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x62, 0x79,
+	0x4b, 0x69, 0x6e, 0x64, 0x1a, 0x39, 0x0a, 0x0b, 0x42, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xc0, 0x07, 0x0a, 0x0a, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x42,
+	0x0a, 0x09, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x53,
+	0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x65, 0x64, 0x67, 0x65, 0x53, 0x65,
+	0x74, 0x73, 0x12, 0x38, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x5c, 0x0a, 0x13,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x65, 0x64, 0x67, 0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x42, 0x79, 0x4b,
+	0x69, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x45,
+	0x64, 0x67, 0x65, 0x73, 0x42, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x48, 0x0a, 0x0b, 0x65, 0x64,
+	0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x65, 0x64, 0x67, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e,
+	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x25, 0x0a, 0x0e,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x5a, 0x0a, 0x11, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x72,
+	0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x1a, 0x51, 0x0a, 0x0d, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x56, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x43, 0x0a, 0x15, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x42, 0x79,
+	0x4b, 0x69, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x56, 0x0a, 0x0f, 0x45, 0x64, 0x67, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a,
+	0x15, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x32, 0x8c, 0x01, 0x0a, 0x0c, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x3d, 0x0a, 0x05, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x42, 0x31, 0x0a, 0x1f, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x64, 0x65, 0x76, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x5a, 0x0e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x5f, 0x67, 0x6f, 0x5f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -532,42 +1179,70 @@ func file_kythe_proto_graph_proto_rawDescGZIP() []byte {
 	return file_kythe_proto_graph_proto_rawDescData
 }
 
-var file_kythe_proto_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_kythe_proto_graph_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_kythe_proto_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_kythe_proto_graph_proto_goTypes = []interface{}{
-	(*NodesRequest)(nil),             // 0: kythe.proto.NodesRequest
-	(*NodesReply)(nil),               // 1: kythe.proto.NodesReply
-	(*EdgesRequest)(nil),             // 2: kythe.proto.EdgesRequest
-	(*EdgeSet)(nil),                  // 3: kythe.proto.EdgeSet
-	(*EdgesReply)(nil),               // 4: kythe.proto.EdgesReply
-	nil,                              // 5: kythe.proto.NodesReply.NodesEntry
-	(*EdgeSet_Group)(nil),            // 6: kythe.proto.EdgeSet.Group
-	nil,                              // 7: kythe.proto.EdgeSet.GroupsEntry
-	(*EdgeSet_Group_Edge)(nil),       // 8: kythe.proto.EdgeSet.Group.Edge
-	nil,                              // 9: kythe.proto.EdgesReply.EdgeSetsEntry
-	nil,                              // 10: kythe.proto.EdgesReply.NodesEntry
-	nil,                              // 11: kythe.proto.EdgesReply.TotalEdgesByKindEntry
-	(*common_go_proto.NodeInfo)(nil), // 12: kythe.proto.common.NodeInfo
+	(NodesReply_TicketStatus_Code)(0), // 0: kythe.proto.NodesReply.TicketStatus.Code
+	(EdgesRequest_KindPreset)(0),      // 1: kythe.proto.EdgesRequest.KindPreset
+	(*NodesRequest)(nil),              // 2: kythe.proto.NodesRequest
+	(*NodesReply)(nil),                // 3: kythe.proto.NodesReply
+	(*EdgesRequest)(nil),              // 4: kythe.proto.EdgesRequest
+	(*EdgeSet)(nil),                   // 5: kythe.proto.EdgeSet
+	(*EdgeCounts)(nil),                // 6: kythe.proto.EdgeCounts
+	(*EdgesReply)(nil),                // 7: kythe.proto.EdgesReply
+	nil,                               // 8: kythe.proto.NodesReply.NodesEntry
+	nil,                               // 9: kythe.proto.NodesReply.RedirectedTicketEntry
+	(*NodesReply_TicketStatus)(nil),   // 10: kythe.proto.NodesReply.TicketStatus
+	nil,                               // 11: kythe.proto.NodesReply.TicketStatusEntry
+	(*NodesReply_RemovedFacts)(nil),   // 12: kythe.proto.NodesReply.RemovedFacts
+	nil,                               // 13: kythe.proto.NodesReply.RemovedFactsEntry
+	(*EdgesRequest_KindFilter)(nil),   // 14: kythe.proto.EdgesRequest.KindFilter
+	(*EdgeSet_Group)(nil),             // 15: kythe.proto.EdgeSet.Group
+	nil,                               // 16: kythe.proto.EdgeSet.GroupsEntry
+	(*EdgeSet_Group_Edge)(nil),        // 17: kythe.proto.EdgeSet.Group.Edge
+	nil,                               // 18: kythe.proto.EdgeCounts.ByKindEntry
+	nil,                               // 19: kythe.proto.EdgesReply.EdgeSetsEntry
+	nil,                               // 20: kythe.proto.EdgesReply.NodesEntry
+	nil,                               // 21: kythe.proto.EdgesReply.TotalEdgesByKindEntry
+	nil,                               // 22: kythe.proto.EdgesReply.EdgeCountsEntry
+	nil,                               // 23: kythe.proto.EdgesReply.RedirectedTicketEntry
+	(*timestamppb.Timestamp)(nil),     // 24: google.protobuf.Timestamp
+	(*common_go_proto.NodeInfo)(nil),  // 25: kythe.proto.common.NodeInfo
 }
 var file_kythe_proto_graph_proto_depIdxs = []int32{
-	5,  // 0: kythe.proto.NodesReply.nodes:type_name -> kythe.proto.NodesReply.NodesEntry
-	7,  // 1: kythe.proto.EdgeSet.groups:type_name -> kythe.proto.EdgeSet.GroupsEntry
-	9,  // 2: kythe.proto.EdgesReply.edge_sets:type_name -> kythe.proto.EdgesReply.EdgeSetsEntry
-	10, // 3: kythe.proto.EdgesReply.nodes:type_name -> kythe.proto.EdgesReply.NodesEntry
-	11, // 4: kythe.proto.EdgesReply.total_edges_by_kind:type_name -> kythe.proto.EdgesReply.TotalEdgesByKindEntry
-	12, // 5: kythe.proto.NodesReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
-	8,  // 6: kythe.proto.EdgeSet.Group.edge:type_name -> kythe.proto.EdgeSet.Group.Edge
-	6,  // 7: kythe.proto.EdgeSet.GroupsEntry.value:type_name -> kythe.proto.EdgeSet.Group
-	3,  // 8: kythe.proto.EdgesReply.EdgeSetsEntry.value:type_name -> kythe.proto.EdgeSet
-	12, // 9: kythe.proto.EdgesReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
-	0,  // 10: kythe.proto.GraphService.Nodes:input_type -> kythe.proto.NodesRequest
-	2,  // 11: kythe.proto.GraphService.Edges:input_type -> kythe.proto.EdgesRequest
-	1,  // 12: kythe.proto.GraphService.Nodes:output_type -> kythe.proto.NodesReply
-	4,  // 13: kythe.proto.GraphService.Edges:output_type -> kythe.proto.EdgesReply
-	12, // [12:14] is the sub-list for method output_type
-	10, // [10:12] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	8,  // 0: kythe.proto.NodesReply.nodes:type_name -> kythe.proto.NodesReply.NodesEntry
+	9,  // 1: kythe.proto.NodesReply.redirected_ticket:type_name -> kythe.proto.NodesReply.RedirectedTicketEntry
+	11, // 2: kythe.proto.NodesReply.ticket_status:type_name -> kythe.proto.NodesReply.TicketStatusEntry
+	24, // 3: kythe.proto.NodesReply.build_time:type_name -> google.protobuf.Timestamp
+	13, // 4: kythe.proto.NodesReply.removed_facts:type_name -> kythe.proto.NodesReply.RemovedFactsEntry
+	1,  // 5: kythe.proto.EdgesRequest.kind_preset:type_name -> kythe.proto.EdgesRequest.KindPreset
+	14, // 6: kythe.proto.EdgesRequest.kind_filter:type_name -> kythe.proto.EdgesRequest.KindFilter
+	16, // 7: kythe.proto.EdgeSet.groups:type_name -> kythe.proto.EdgeSet.GroupsEntry
+	18, // 8: kythe.proto.EdgeCounts.by_kind:type_name -> kythe.proto.EdgeCounts.ByKindEntry
+	19, // 9: kythe.proto.EdgesReply.edge_sets:type_name -> kythe.proto.EdgesReply.EdgeSetsEntry
+	20, // 10: kythe.proto.EdgesReply.nodes:type_name -> kythe.proto.EdgesReply.NodesEntry
+	21, // 11: kythe.proto.EdgesReply.total_edges_by_kind:type_name -> kythe.proto.EdgesReply.TotalEdgesByKindEntry
+	22, // 12: kythe.proto.EdgesReply.edge_counts:type_name -> kythe.proto.EdgesReply.EdgeCountsEntry
+	23, // 13: kythe.proto.EdgesReply.redirected_ticket:type_name -> kythe.proto.EdgesReply.RedirectedTicketEntry
+	24, // 14: kythe.proto.EdgesReply.build_time:type_name -> google.protobuf.Timestamp
+	25, // 15: kythe.proto.NodesReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
+	0,  // 16: kythe.proto.NodesReply.TicketStatus.code:type_name -> kythe.proto.NodesReply.TicketStatus.Code
+	10, // 17: kythe.proto.NodesReply.TicketStatusEntry.value:type_name -> kythe.proto.NodesReply.TicketStatus
+	12, // 18: kythe.proto.NodesReply.RemovedFactsEntry.value:type_name -> kythe.proto.NodesReply.RemovedFacts
+	17, // 19: kythe.proto.EdgeSet.Group.edge:type_name -> kythe.proto.EdgeSet.Group.Edge
+	15, // 20: kythe.proto.EdgeSet.GroupsEntry.value:type_name -> kythe.proto.EdgeSet.Group
+	5,  // 21: kythe.proto.EdgesReply.EdgeSetsEntry.value:type_name -> kythe.proto.EdgeSet
+	25, // 22: kythe.proto.EdgesReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
+	6,  // 23: kythe.proto.EdgesReply.EdgeCountsEntry.value:type_name -> kythe.proto.EdgeCounts
+	2,  // 24: kythe.proto.GraphService.Nodes:input_type -> kythe.proto.NodesRequest
+	4,  // 25: kythe.proto.GraphService.Edges:input_type -> kythe.proto.EdgesRequest
+	3,  // 26: kythe.proto.GraphService.Nodes:output_type -> kythe.proto.NodesReply
+	7,  // 27: kythe.proto.GraphService.Edges:output_type -> kythe.proto.EdgesReply
+	26, // [26:28] is the sub-list for method output_type
+	24, // [24:26] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_kythe_proto_graph_proto_init() }
@@ -625,6 +1300,18 @@ func file_kythe_proto_graph_proto_init() {
 			}
 		}
 		file_kythe_proto_graph_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgeCounts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_graph_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EdgesReply); i {
 			case 0:
 				return &v.state
@@ -636,7 +1323,43 @@ func file_kythe_proto_graph_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_graph_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_graph_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodesReply_TicketStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_graph_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodesReply_RemovedFacts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_graph_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgesRequest_KindFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_graph_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EdgeSet_Group); i {
 			case 0:
 				return &v.state
@@ -648,7 +1371,7 @@ func file_kythe_proto_graph_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_graph_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_graph_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EdgeSet_Group_Edge); i {
 			case 0:
 				return &v.state
@@ -666,13 +1389,14 @@ func file_kythe_proto_graph_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kythe_proto_graph_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_kythe_proto_graph_proto_goTypes,
 		DependencyIndexes: file_kythe_proto_graph_proto_depIdxs,
+		EnumInfos:         file_kythe_proto_graph_proto_enumTypes,
 		MessageInfos:      file_kythe_proto_graph_proto_msgTypes,
 	}.Build()
 	File_kythe_proto_graph_proto = out.File
@@ -9,6 +9,7 @@ package pipeline_go_proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	common_go_proto "kythe.io/kythe/proto/common_go_proto"
 	schema_go_proto "kythe.io/kythe/proto/schema_go_proto"
 	serving_go_proto "kythe.io/kythe/proto/serving_go_proto"
@@ -32,7 +33,6 @@ type Reference struct {
 
 	Source *storage_go_proto.VName `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
 	// Types that are assignable to Kind:
-	//
 	//	*Reference_KytheKind
 	//	*Reference_GenericKind
 	Kind   isReference_Kind                 `protobuf_oneof:"kind"`
@@ -137,13 +137,14 @@ type DecorationPiece struct {
 
 	FileVName *storage_go_proto.VName `protobuf:"bytes,1,opt,name=file_v_name,json=fileVName,proto3" json:"file_v_name,omitempty"`
 	// Types that are assignable to Piece:
-	//
 	//	*DecorationPiece_File
 	//	*DecorationPiece_Reference
 	//	*DecorationPiece_Node
 	//	*DecorationPiece_Definition_
 	//	*DecorationPiece_Diagnostic
 	//	*DecorationPiece_TargetOverride
+	//	*DecorationPiece_TargetOverriddenBy
+	//	*DecorationPiece_ReferenceCount_
 	Piece isDecorationPiece_Piece `protobuf_oneof:"piece"`
 }
 
@@ -235,6 +236,20 @@ func (x *DecorationPiece) GetTargetOverride() *xref_serving_go_proto.FileDecorat
 	return nil
 }
 
+func (x *DecorationPiece) GetTargetOverriddenBy() *xref_serving_go_proto.FileDecorations_TargetOverride {
+	if x, ok := x.GetPiece().(*DecorationPiece_TargetOverriddenBy); ok {
+		return x.TargetOverriddenBy
+	}
+	return nil
+}
+
+func (x *DecorationPiece) GetReferenceCount() *DecorationPiece_ReferenceCount {
+	if x, ok := x.GetPiece().(*DecorationPiece_ReferenceCount_); ok {
+		return x.ReferenceCount
+	}
+	return nil
+}
+
 type isDecorationPiece_Piece interface {
 	isDecorationPiece_Piece()
 }
@@ -263,6 +278,14 @@ type DecorationPiece_TargetOverride struct {
 	TargetOverride *xref_serving_go_proto.FileDecorations_TargetOverride `protobuf:"bytes,7,opt,name=target_override,json=targetOverride,proto3,oneof"`
 }
 
+type DecorationPiece_TargetOverriddenBy struct {
+	TargetOverriddenBy *xref_serving_go_proto.FileDecorations_TargetOverride `protobuf:"bytes,8,opt,name=target_overridden_by,json=targetOverriddenBy,proto3,oneof"`
+}
+
+type DecorationPiece_ReferenceCount_ struct {
+	ReferenceCount *DecorationPiece_ReferenceCount `protobuf:"bytes,9,opt,name=reference_count,json=referenceCount,proto3,oneof"`
+}
+
 func (*DecorationPiece_File) isDecorationPiece_Piece() {}
 
 func (*DecorationPiece_Reference) isDecorationPiece_Piece() {}
@@ -275,6 +298,65 @@ func (*DecorationPiece_Diagnostic) isDecorationPiece_Piece() {}
 
 func (*DecorationPiece_TargetOverride) isDecorationPiece_Piece() {}
 
+func (*DecorationPiece_TargetOverriddenBy) isDecorationPiece_Piece() {}
+
+func (*DecorationPiece_ReferenceCount_) isDecorationPiece_Piece() {}
+
+type FileBlame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	File *storage_go_proto.VName `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Hunk []*FileBlame_Hunk       `protobuf:"bytes,2,rep,name=hunk,proto3" json:"hunk,omitempty"`
+}
+
+func (x *FileBlame) Reset() {
+	*x = FileBlame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_pipeline_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileBlame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileBlame) ProtoMessage() {}
+
+func (x *FileBlame) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_pipeline_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileBlame.ProtoReflect.Descriptor instead.
+func (*FileBlame) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_pipeline_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FileBlame) GetFile() *storage_go_proto.VName {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *FileBlame) GetHunk() []*FileBlame_Hunk {
+	if x != nil {
+		return x.Hunk
+	}
+	return nil
+}
+
 type DecorationPiece_Definition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -287,7 +369,7 @@ type DecorationPiece_Definition struct {
 func (x *DecorationPiece_Definition) Reset() {
 	*x = DecorationPiece_Definition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_pipeline_proto_msgTypes[2]
+		mi := &file_kythe_proto_pipeline_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -300,7 +382,7 @@ func (x *DecorationPiece_Definition) String() string {
 func (*DecorationPiece_Definition) ProtoMessage() {}
 
 func (x *DecorationPiece_Definition) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_pipeline_proto_msgTypes[2]
+	mi := &file_kythe_proto_pipeline_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -330,6 +412,132 @@ func (x *DecorationPiece_Definition) GetDefinition() *serving_go_proto.ExpandedA
 	return nil
 }
 
+type DecorationPiece_ReferenceCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node  *storage_go_proto.VName `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Count int64                   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *DecorationPiece_ReferenceCount) Reset() {
+	*x = DecorationPiece_ReferenceCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_pipeline_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecorationPiece_ReferenceCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecorationPiece_ReferenceCount) ProtoMessage() {}
+
+func (x *DecorationPiece_ReferenceCount) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_pipeline_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecorationPiece_ReferenceCount.ProtoReflect.Descriptor instead.
+func (*DecorationPiece_ReferenceCount) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_pipeline_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *DecorationPiece_ReferenceCount) GetNode() *storage_go_proto.VName {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *DecorationPiece_ReferenceCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type FileBlame_Hunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartLine  int32                  `protobuf:"varint,1,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`
+	EndLine    int32                  `protobuf:"varint,2,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+	Revision   string                 `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	CommitTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=commit_time,json=commitTime,proto3" json:"commit_time,omitempty"`
+}
+
+func (x *FileBlame_Hunk) Reset() {
+	*x = FileBlame_Hunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_pipeline_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileBlame_Hunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileBlame_Hunk) ProtoMessage() {}
+
+func (x *FileBlame_Hunk) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_pipeline_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileBlame_Hunk.ProtoReflect.Descriptor instead.
+func (*FileBlame_Hunk) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_pipeline_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *FileBlame_Hunk) GetStartLine() int32 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *FileBlame_Hunk) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *FileBlame_Hunk) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *FileBlame_Hunk) GetCommitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommitTime
+	}
+	return nil
+}
+
 var File_kythe_proto_pipeline_proto protoreflect.FileDescriptor
 
 var file_kythe_proto_pipeline_proto_rawDesc = []byte{
@@ -344,67 +552,103 @@ var file_kythe_proto_pipeline_proto_rawDesc = []byte{
 	0x6f, 0x1a, 0x19, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
 	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x6b, 0x79,
 	0x74, 0x68, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x78, 0x72, 0x65, 0x66, 0x5f, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8a, 0x02, 0x0a,
-	0x09, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x79, 0x74,
-	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0a, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x5f,
-	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6b, 0x79, 0x74,
-	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
-	0x45, 0x64, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x09, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63,
-	0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x67,
-	0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x3b, 0x0a, 0x06, 0x61, 0x6e,
-	0x63, 0x68, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74,
+	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8a, 0x02,
+	0x0a, 0x09, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x4e, 0x61, 0x6d, 0x65, 0x52,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0a, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x45, 0x64, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x09, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x69,
+	0x63, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x3b, 0x0a, 0x06, 0x61,
+	0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e,
+	0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x52, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x87, 0x07, 0x0a, 0x0f, 0x44,
+	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x12, 0x32,
+	0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x76, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x56, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x56, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x04, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x04,
+	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x52, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
+	0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0a, 0x64, 0x69, 0x61, 0x67,
+	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x48, 0x00, 0x52, 0x0a,
+	0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x64, 0x0a, 0x0f, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x78, 0x72, 0x65, 0x66, 0x73, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x48, 0x00,
+	0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x12, 0x6d, 0x0a, 0x14, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x62, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x2e, 0x78, 0x72, 0x65, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44,
+	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x48, 0x00, 0x52, 0x12, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x42, 0x79, 0x12,
+	0x5f, 0x0a, 0x0f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
+	0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x1a, 0x79, 0x0a, 0x0a, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26,
+	0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x4e, 0x61, 0x6d, 0x65,
+	0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74,
 	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67,
 	0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52,
-	0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70,
-	0x65, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xe7, 0x04, 0x0a, 0x0f, 0x44, 0x65,
-	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x12, 0x32, 0x0a,
-	0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x76, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x56, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x56, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x2f, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69,
-	0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6e,
-	0x6f, 0x64, 0x65, 0x12, 0x52, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x44,
-	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x69, 0x65, 0x63, 0x65, 0x2e, 0x44,
-	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e,
-	0x6f, 0x73, 0x74, 0x69, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x48, 0x00, 0x52, 0x0a, 0x64,
-	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x64, 0x0a, 0x0f, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x78, 0x72, 0x65, 0x66, 0x73, 0x2e, 0x46,
-	0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x48, 0x00, 0x52,
-	0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x1a,
-	0x79, 0x0a, 0x0a, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a,
+	0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x4e, 0x0a, 0x0e, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a,
 	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x79,
 	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x4e, 0x61, 0x6d, 0x65, 0x52,
-	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e,
-	0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x0a,
-	0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x70, 0x69,
-	0x65, 0x63, 0x65, 0x42, 0x34, 0x0a, 0x1f, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x64, 0x65, 0x76, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x5a, 0x11, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
-	0x5f, 0x67, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x70,
+	0x69, 0x65, 0x63, 0x65, 0x22, 0x89, 0x02, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x61,
+	0x6d, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56,
+	0x4e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x04, 0x68, 0x75,
+	0x6e, 0x6b, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x2e, 0x48, 0x75, 0x6e, 0x6b, 0x52, 0x04,
+	0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x99, 0x01, 0x0a, 0x04, 0x48, 0x75, 0x6e, 0x6b, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x42, 0x34, 0x0a, 0x1f, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x64,
+	0x65, 0x76, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x5a, 0x11, 0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x67, 0x6f,
+	0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -419,38 +663,48 @@ func file_kythe_proto_pipeline_proto_rawDescGZIP() []byte {
 	return file_kythe_proto_pipeline_proto_rawDescData
 }
 
-var file_kythe_proto_pipeline_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_kythe_proto_pipeline_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_kythe_proto_pipeline_proto_goTypes = []interface{}{
 	(*Reference)(nil),                                            // 0: kythe.proto.pipeline.Reference
 	(*DecorationPiece)(nil),                                      // 1: kythe.proto.pipeline.DecorationPiece
-	(*DecorationPiece_Definition)(nil),                           // 2: kythe.proto.pipeline.DecorationPiece.Definition
-	(*storage_go_proto.VName)(nil),                               // 3: kythe.proto.VName
-	(schema_go_proto.EdgeKind)(0),                                // 4: kythe.proto.schema.EdgeKind
-	(*serving_go_proto.ExpandedAnchor)(nil),                      // 5: kythe.proto.serving.ExpandedAnchor
-	(*serving_go_proto.File)(nil),                                // 6: kythe.proto.serving.File
-	(*schema_go_proto.Node)(nil),                                 // 7: kythe.proto.schema.Node
-	(*common_go_proto.Diagnostic)(nil),                           // 8: kythe.proto.common.Diagnostic
-	(*xref_serving_go_proto.FileDecorations_TargetOverride)(nil), // 9: kythe.proto.serving.xrefs.FileDecorations.TargetOverride
+	(*FileBlame)(nil),                                            // 2: kythe.proto.pipeline.FileBlame
+	(*DecorationPiece_Definition)(nil),                           // 3: kythe.proto.pipeline.DecorationPiece.Definition
+	(*DecorationPiece_ReferenceCount)(nil),                       // 4: kythe.proto.pipeline.DecorationPiece.ReferenceCount
+	(*FileBlame_Hunk)(nil),                                       // 5: kythe.proto.pipeline.FileBlame.Hunk
+	(*storage_go_proto.VName)(nil),                               // 6: kythe.proto.VName
+	(schema_go_proto.EdgeKind)(0),                                // 7: kythe.proto.schema.EdgeKind
+	(*serving_go_proto.ExpandedAnchor)(nil),                      // 8: kythe.proto.serving.ExpandedAnchor
+	(*serving_go_proto.File)(nil),                                // 9: kythe.proto.serving.File
+	(*schema_go_proto.Node)(nil),                                 // 10: kythe.proto.schema.Node
+	(*common_go_proto.Diagnostic)(nil),                           // 11: kythe.proto.common.Diagnostic
+	(*xref_serving_go_proto.FileDecorations_TargetOverride)(nil), // 12: kythe.proto.serving.xrefs.FileDecorations.TargetOverride
+	(*timestamppb.Timestamp)(nil),                                // 13: google.protobuf.Timestamp
 }
 var file_kythe_proto_pipeline_proto_depIdxs = []int32{
-	3,  // 0: kythe.proto.pipeline.Reference.source:type_name -> kythe.proto.VName
-	4,  // 1: kythe.proto.pipeline.Reference.kythe_kind:type_name -> kythe.proto.schema.EdgeKind
-	5,  // 2: kythe.proto.pipeline.Reference.anchor:type_name -> kythe.proto.serving.ExpandedAnchor
-	3,  // 3: kythe.proto.pipeline.Reference.scope:type_name -> kythe.proto.VName
-	3,  // 4: kythe.proto.pipeline.DecorationPiece.file_v_name:type_name -> kythe.proto.VName
-	6,  // 5: kythe.proto.pipeline.DecorationPiece.file:type_name -> kythe.proto.serving.File
+	6,  // 0: kythe.proto.pipeline.Reference.source:type_name -> kythe.proto.VName
+	7,  // 1: kythe.proto.pipeline.Reference.kythe_kind:type_name -> kythe.proto.schema.EdgeKind
+	8,  // 2: kythe.proto.pipeline.Reference.anchor:type_name -> kythe.proto.serving.ExpandedAnchor
+	6,  // 3: kythe.proto.pipeline.Reference.scope:type_name -> kythe.proto.VName
+	6,  // 4: kythe.proto.pipeline.DecorationPiece.file_v_name:type_name -> kythe.proto.VName
+	9,  // 5: kythe.proto.pipeline.DecorationPiece.file:type_name -> kythe.proto.serving.File
 	0,  // 6: kythe.proto.pipeline.DecorationPiece.reference:type_name -> kythe.proto.pipeline.Reference
-	7,  // 7: kythe.proto.pipeline.DecorationPiece.node:type_name -> kythe.proto.schema.Node
-	2,  // 8: kythe.proto.pipeline.DecorationPiece.definition:type_name -> kythe.proto.pipeline.DecorationPiece.Definition
-	8,  // 9: kythe.proto.pipeline.DecorationPiece.diagnostic:type_name -> kythe.proto.common.Diagnostic
-	9,  // 10: kythe.proto.pipeline.DecorationPiece.target_override:type_name -> kythe.proto.serving.xrefs.FileDecorations.TargetOverride
-	3,  // 11: kythe.proto.pipeline.DecorationPiece.Definition.node:type_name -> kythe.proto.VName
-	5,  // 12: kythe.proto.pipeline.DecorationPiece.Definition.definition:type_name -> kythe.proto.serving.ExpandedAnchor
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	10, // 7: kythe.proto.pipeline.DecorationPiece.node:type_name -> kythe.proto.schema.Node
+	3,  // 8: kythe.proto.pipeline.DecorationPiece.definition:type_name -> kythe.proto.pipeline.DecorationPiece.Definition
+	11, // 9: kythe.proto.pipeline.DecorationPiece.diagnostic:type_name -> kythe.proto.common.Diagnostic
+	12, // 10: kythe.proto.pipeline.DecorationPiece.target_override:type_name -> kythe.proto.serving.xrefs.FileDecorations.TargetOverride
+	12, // 11: kythe.proto.pipeline.DecorationPiece.target_overridden_by:type_name -> kythe.proto.serving.xrefs.FileDecorations.TargetOverride
+	4,  // 12: kythe.proto.pipeline.DecorationPiece.reference_count:type_name -> kythe.proto.pipeline.DecorationPiece.ReferenceCount
+	6,  // 13: kythe.proto.pipeline.FileBlame.file:type_name -> kythe.proto.VName
+	5,  // 14: kythe.proto.pipeline.FileBlame.hunk:type_name -> kythe.proto.pipeline.FileBlame.Hunk
+	6,  // 15: kythe.proto.pipeline.DecorationPiece.Definition.node:type_name -> kythe.proto.VName
+	8,  // 16: kythe.proto.pipeline.DecorationPiece.Definition.definition:type_name -> kythe.proto.serving.ExpandedAnchor
+	6,  // 17: kythe.proto.pipeline.DecorationPiece.ReferenceCount.node:type_name -> kythe.proto.VName
+	13, // 18: kythe.proto.pipeline.FileBlame.Hunk.commit_time:type_name -> google.protobuf.Timestamp
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_kythe_proto_pipeline_proto_init() }
@@ -484,6 +738,18 @@ func file_kythe_proto_pipeline_proto_init() {
 			}
 		}
 		file_kythe_proto_pipeline_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileBlame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_pipeline_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DecorationPiece_Definition); i {
 			case 0:
 				return &v.state
@@ -495,6 +761,30 @@ func file_kythe_proto_pipeline_proto_init() {
 				return nil
 			}
 		}
+		file_kythe_proto_pipeline_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecorationPiece_ReferenceCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_pipeline_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileBlame_Hunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_kythe_proto_pipeline_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*Reference_KytheKind)(nil),
@@ -507,6 +797,8 @@ func file_kythe_proto_pipeline_proto_init() {
 		(*DecorationPiece_Definition_)(nil),
 		(*DecorationPiece_Diagnostic)(nil),
 		(*DecorationPiece_TargetOverride)(nil),
+		(*DecorationPiece_TargetOverriddenBy)(nil),
+		(*DecorationPiece_ReferenceCount_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -514,7 +806,7 @@ func file_kythe_proto_pipeline_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kythe_proto_pipeline_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -9,6 +9,7 @@ package serving_go_proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	common_go_proto "kythe.io/kythe/proto/common_go_proto"
 	reflect "reflect"
 	sync "sync"
@@ -162,7 +163,7 @@ func (x Relatives_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Relatives_Type.Descriptor instead.
 func (Relatives_Type) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{16, 0}
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{30, 0}
 }
 
 type Callgraph_Type int32
@@ -211,7 +212,7 @@ func (x Callgraph_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Callgraph_Type.Descriptor instead.
 func (Callgraph_Type) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{17, 0}
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{31, 0}
 }
 
 type Diff_Type int32
@@ -260,7 +261,7 @@ func (x Diff_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Diff_Type.Descriptor instead.
 func (Diff_Type) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{18, 0}
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{32, 0}
 }
 
 type Node struct {
@@ -272,6 +273,8 @@ type Node struct {
 	Fact               []*common_go_proto.Fact `protobuf:"bytes,2,rep,name=fact,proto3" json:"fact,omitempty"`
 	DefinitionLocation *ExpandedAnchor         `protobuf:"bytes,3,opt,name=definition_location,json=definitionLocation,proto3" json:"definition_location,omitempty"`
 	Rank               float64                 `protobuf:"fixed64,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	Deprecated         bool                    `protobuf:"varint,5,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	DeprecationMessage string                  `protobuf:"bytes,6,opt,name=deprecation_message,json=deprecationMessage,proto3" json:"deprecation_message,omitempty"`
 }
 
 func (x *Node) Reset() {
@@ -334,6 +337,20 @@ func (x *Node) GetRank() float64 {
 	return 0
 }
 
+func (x *Node) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *Node) GetDeprecationMessage() string {
+	if x != nil {
+		return x.DeprecationMessage
+	}
+	return ""
+}
+
 type Edge struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -546,9 +563,10 @@ type PageIndex struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EdgeKind  string `protobuf:"bytes,1,opt,name=edge_kind,json=edgeKind,proto3" json:"edge_kind,omitempty"`
-	EdgeCount int32  `protobuf:"varint,2,opt,name=edge_count,json=edgeCount,proto3" json:"edge_count,omitempty"`
-	PageKey   string `protobuf:"bytes,3,opt,name=page_key,json=pageKey,proto3" json:"page_key,omitempty"`
+	EdgeKind         string `protobuf:"bytes,1,opt,name=edge_kind,json=edgeKind,proto3" json:"edge_kind,omitempty"`
+	EdgeCount        int32  `protobuf:"varint,2,opt,name=edge_count,json=edgeCount,proto3" json:"edge_count,omitempty"`
+	PageKey          string `protobuf:"bytes,3,opt,name=page_key,json=pageKey,proto3" json:"page_key,omitempty"`
+	UncompressedSize int64  `protobuf:"varint,4,opt,name=uncompressed_size,json=uncompressedSize,proto3" json:"uncompressed_size,omitempty"`
 }
 
 func (x *PageIndex) Reset() {
@@ -604,6 +622,13 @@ func (x *PageIndex) GetPageKey() string {
 	return ""
 }
 
+func (x *PageIndex) GetUncompressedSize() int64 {
+	if x != nil {
+		return x.UncompressedSize
+	}
+	return 0
+}
+
 type EdgePage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -863,6 +888,7 @@ type RawAnchor struct {
 	SnippetStart       int32  `protobuf:"varint,4,opt,name=snippet_start,json=snippetStart,proto3" json:"snippet_start,omitempty"`
 	SnippetEnd         int32  `protobuf:"varint,5,opt,name=snippet_end,json=snippetEnd,proto3" json:"snippet_end,omitempty"`
 	BuildConfiguration string `protobuf:"bytes,6,opt,name=build_configuration,json=buildConfiguration,proto3" json:"build_configuration,omitempty"`
+	Fingerprint        string `protobuf:"bytes,7,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
 }
 
 func (x *RawAnchor) Reset() {
@@ -939,20 +965,28 @@ func (x *RawAnchor) GetBuildConfiguration() string {
 	return ""
 }
 
+func (x *RawAnchor) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
 type ExpandedAnchor struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket             string                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
-	Kind               string                `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	Text               string                `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
-	Span               *common_go_proto.Span `protobuf:"bytes,5,opt,name=span,proto3" json:"span,omitempty"`
-	Snippet            string                `protobuf:"bytes,6,opt,name=snippet,proto3" json:"snippet,omitempty"`
-	SnippetSpan        *common_go_proto.Span `protobuf:"bytes,7,opt,name=snippet_span,json=snippetSpan,proto3" json:"snippet_span,omitempty"`
-	BuildConfiguration string                `protobuf:"bytes,8,opt,name=build_configuration,json=buildConfiguration,proto3" json:"build_configuration,omitempty"`
-	Rank               float64               `protobuf:"fixed64,9,opt,name=rank,proto3" json:"rank,omitempty"`
-	FileInfo           *FileInfo             `protobuf:"bytes,10,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	Ticket             string                 `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind               string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Text               string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Span               *common_go_proto.Span  `protobuf:"bytes,5,opt,name=span,proto3" json:"span,omitempty"`
+	Snippet            string                 `protobuf:"bytes,6,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	SnippetSpan        *common_go_proto.Span  `protobuf:"bytes,7,opt,name=snippet_span,json=snippetSpan,proto3" json:"snippet_span,omitempty"`
+	BuildConfiguration string                 `protobuf:"bytes,8,opt,name=build_configuration,json=buildConfiguration,proto3" json:"build_configuration,omitempty"`
+	Rank               float64                `protobuf:"fixed64,9,opt,name=rank,proto3" json:"rank,omitempty"`
+	FileInfo           *FileInfo              `protobuf:"bytes,10,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	CommitTime         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=commit_time,json=commitTime,proto3" json:"commit_time,omitempty"`
 }
 
 func (x *ExpandedAnchor) Reset() {
@@ -1050,6 +1084,13 @@ func (x *ExpandedAnchor) GetFileInfo() *FileInfo {
 	return nil
 }
 
+func (x *ExpandedAnchor) GetCommitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommitTime
+	}
+	return nil
+}
+
 type FileInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1059,6 +1100,7 @@ type FileInfo struct {
 	Revision       string                      `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
 	Hash           *common_go_proto.Hash       `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
 	ViewRestricted bool                        `protobuf:"varint,4,opt,name=view_restricted,json=viewRestricted,proto3" json:"view_restricted,omitempty"`
+	Generated      bool                        `protobuf:"varint,5,opt,name=generated,proto3" json:"generated,omitempty"`
 }
 
 func (x *FileInfo) Reset() {
@@ -1121,19 +1163,29 @@ func (x *FileInfo) GetViewRestricted() bool {
 	return false
 }
 
+func (x *FileInfo) GetGenerated() bool {
+	if x != nil {
+		return x.Generated
+	}
+	return false
+}
+
 type FileDecorations struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	File              *File                         `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	Decoration        []*FileDecorations_Decoration `protobuf:"bytes,2,rep,name=decoration,proto3" json:"decoration,omitempty"`
-	Target            []*Node                       `protobuf:"bytes,4,rep,name=target,proto3" json:"target,omitempty"`
-	TargetDefinitions []*ExpandedAnchor             `protobuf:"bytes,3,rep,name=target_definitions,json=targetDefinitions,proto3" json:"target_definitions,omitempty"`
-	TargetOverride    []*FileDecorations_Override   `protobuf:"bytes,5,rep,name=target_override,json=targetOverride,proto3" json:"target_override,omitempty"`
-	Diagnostic        []*common_go_proto.Diagnostic `protobuf:"bytes,6,rep,name=diagnostic,proto3" json:"diagnostic,omitempty"`
-	GeneratedBy       []string                      `protobuf:"bytes,7,rep,name=generated_by,json=generatedBy,proto3" json:"generated_by,omitempty"`
-	FileInfo          []*FileInfo                   `protobuf:"bytes,8,rep,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	File                 *File                             `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Decoration           []*FileDecorations_Decoration     `protobuf:"bytes,2,rep,name=decoration,proto3" json:"decoration,omitempty"`
+	Target               []*Node                           `protobuf:"bytes,4,rep,name=target,proto3" json:"target,omitempty"`
+	TargetDefinitions    []*ExpandedAnchor                 `protobuf:"bytes,3,rep,name=target_definitions,json=targetDefinitions,proto3" json:"target_definitions,omitempty"`
+	TargetOverride       []*FileDecorations_Override       `protobuf:"bytes,5,rep,name=target_override,json=targetOverride,proto3" json:"target_override,omitempty"`
+	Diagnostic           []*common_go_proto.Diagnostic     `protobuf:"bytes,6,rep,name=diagnostic,proto3" json:"diagnostic,omitempty"`
+	GeneratedBy          []string                          `protobuf:"bytes,7,rep,name=generated_by,json=generatedBy,proto3" json:"generated_by,omitempty"`
+	FileInfo             []*FileInfo                       `protobuf:"bytes,8,rep,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	TargetOverriddenBy   []*FileDecorations_Override       `protobuf:"bytes,9,rep,name=target_overridden_by,json=targetOverriddenBy,proto3" json:"target_overridden_by,omitempty"`
+	TargetReferenceCount map[string]int64                  `protobuf:"bytes,10,rep,name=target_reference_count,json=targetReferenceCount,proto3" json:"target_reference_count,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	DecorationPage       []*FileDecorations_DecorationPage `protobuf:"bytes,11,rep,name=decoration_page,json=decorationPage,proto3" json:"decoration_page,omitempty"`
 }
 
 func (x *FileDecorations) Reset() {
@@ -1224,6 +1276,82 @@ func (x *FileDecorations) GetFileInfo() []*FileInfo {
 	return nil
 }
 
+func (x *FileDecorations) GetTargetOverriddenBy() []*FileDecorations_Override {
+	if x != nil {
+		return x.TargetOverriddenBy
+	}
+	return nil
+}
+
+func (x *FileDecorations) GetTargetReferenceCount() map[string]int64 {
+	if x != nil {
+		return x.TargetReferenceCount
+	}
+	return nil
+}
+
+func (x *FileDecorations) GetDecorationPage() []*FileDecorations_DecorationPage {
+	if x != nil {
+		return x.DecorationPage
+	}
+	return nil
+}
+
+type FileDecorationsPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageKey    string                        `protobuf:"bytes,1,opt,name=page_key,json=pageKey,proto3" json:"page_key,omitempty"`
+	Decoration []*FileDecorations_Decoration `protobuf:"bytes,2,rep,name=decoration,proto3" json:"decoration,omitempty"`
+}
+
+func (x *FileDecorationsPage) Reset() {
+	*x = FileDecorationsPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileDecorationsPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDecorationsPage) ProtoMessage() {}
+
+func (x *FileDecorationsPage) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDecorationsPage.ProtoReflect.Descriptor instead.
+func (*FileDecorationsPage) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FileDecorationsPage) GetPageKey() string {
+	if x != nil {
+		return x.PageKey
+	}
+	return ""
+}
+
+func (x *FileDecorationsPage) GetDecoration() []*FileDecorations_Decoration {
+	if x != nil {
+		return x.Decoration
+	}
+	return nil
+}
+
 type PagedCrossReferences struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1244,7 +1372,7 @@ type PagedCrossReferences struct {
 func (x *PagedCrossReferences) Reset() {
 	*x = PagedCrossReferences{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[13]
+		mi := &file_kythe_proto_serving_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1257,7 +1385,7 @@ func (x *PagedCrossReferences) String() string {
 func (*PagedCrossReferences) ProtoMessage() {}
 
 func (x *PagedCrossReferences) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[13]
+	mi := &file_kythe_proto_serving_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1270,7 +1398,7 @@ func (x *PagedCrossReferences) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PagedCrossReferences.ProtoReflect.Descriptor instead.
 func (*PagedCrossReferences) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13}
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PagedCrossReferences) GetMergeWith() []string {
@@ -1354,7 +1482,7 @@ type Document struct {
 func (x *Document) Reset() {
 	*x = Document{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[14]
+		mi := &file_kythe_proto_serving_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1367,7 +1495,7 @@ func (x *Document) String() string {
 func (*Document) ProtoMessage() {}
 
 func (x *Document) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[14]
+	mi := &file_kythe_proto_serving_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,7 +1508,7 @@ func (x *Document) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Document.ProtoReflect.Descriptor instead.
 func (*Document) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14}
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Document) GetTicket() string {
@@ -1432,33 +1560,32 @@ func (x *Document) GetDocumentedBy() string {
 	return ""
 }
 
-type IdentifierMatch struct {
+type DisplayName struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	QualifiedName string                  `protobuf:"bytes,1,opt,name=qualified_name,json=qualifiedName,proto3" json:"qualified_name,omitempty"`
-	BaseName      string                  `protobuf:"bytes,2,opt,name=base_name,json=baseName,proto3" json:"base_name,omitempty"`
-	Node          []*IdentifierMatch_Node `protobuf:"bytes,3,rep,name=node,proto3" json:"node,omitempty"`
+	Ticket string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *IdentifierMatch) Reset() {
-	*x = IdentifierMatch{}
+func (x *DisplayName) Reset() {
+	*x = DisplayName{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[15]
+		mi := &file_kythe_proto_serving_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IdentifierMatch) String() string {
+func (x *DisplayName) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IdentifierMatch) ProtoMessage() {}
+func (*DisplayName) ProtoMessage() {}
 
-func (x *IdentifierMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[15]
+func (x *DisplayName) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1469,58 +1596,51 @@ func (x *IdentifierMatch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IdentifierMatch.ProtoReflect.Descriptor instead.
-func (*IdentifierMatch) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use DisplayName.ProtoReflect.Descriptor instead.
+func (*DisplayName) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *IdentifierMatch) GetQualifiedName() string {
+func (x *DisplayName) GetTicket() string {
 	if x != nil {
-		return x.QualifiedName
+		return x.Ticket
 	}
 	return ""
 }
 
-func (x *IdentifierMatch) GetBaseName() string {
+func (x *DisplayName) GetName() string {
 	if x != nil {
-		return x.BaseName
+		return x.Name
 	}
 	return ""
 }
 
-func (x *IdentifierMatch) GetNode() []*IdentifierMatch_Node {
-	if x != nil {
-		return x.Node
-	}
-	return nil
-}
-
-type Relatives struct {
+type FileDigest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tickets []string       `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
-	Type    Relatives_Type `protobuf:"varint,2,opt,name=type,proto3,enum=kythe.proto.serving.Relatives_Type" json:"type,omitempty"`
+	Digest string   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Ticket []string `protobuf:"bytes,2,rep,name=ticket,proto3" json:"ticket,omitempty"`
 }
 
-func (x *Relatives) Reset() {
-	*x = Relatives{}
+func (x *FileDigest) Reset() {
+	*x = FileDigest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[16]
+		mi := &file_kythe_proto_serving_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Relatives) String() string {
+func (x *FileDigest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Relatives) ProtoMessage() {}
+func (*FileDigest) ProtoMessage() {}
 
-func (x *Relatives) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[16]
+func (x *FileDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1531,51 +1651,51 @@ func (x *Relatives) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Relatives.ProtoReflect.Descriptor instead.
-func (*Relatives) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use FileDigest.ProtoReflect.Descriptor instead.
+func (*FileDigest) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *Relatives) GetTickets() []string {
+func (x *FileDigest) GetDigest() string {
 	if x != nil {
-		return x.Tickets
+		return x.Digest
 	}
-	return nil
+	return ""
 }
 
-func (x *Relatives) GetType() Relatives_Type {
+func (x *FileDigest) GetTicket() []string {
 	if x != nil {
-		return x.Type
+		return x.Ticket
 	}
-	return Relatives_UNKNOWN
+	return nil
 }
 
-type Callgraph struct {
+type FileReferences struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tickets []string       `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
-	Type    Callgraph_Type `protobuf:"varint,2,opt,name=type,proto3,enum=kythe.proto.serving.Callgraph_Type" json:"type,omitempty"`
+	File string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Node []*FileReferences_Node `protobuf:"bytes,2,rep,name=node,proto3" json:"node,omitempty"`
 }
 
-func (x *Callgraph) Reset() {
-	*x = Callgraph{}
+func (x *FileReferences) Reset() {
+	*x = FileReferences{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[17]
+		mi := &file_kythe_proto_serving_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Callgraph) String() string {
+func (x *FileReferences) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Callgraph) ProtoMessage() {}
+func (*FileReferences) ProtoMessage() {}
 
-func (x *Callgraph) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[17]
+func (x *FileReferences) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1586,54 +1706,52 @@ func (x *Callgraph) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Callgraph.ProtoReflect.Descriptor instead.
-func (*Callgraph) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use FileReferences.ProtoReflect.Descriptor instead.
+func (*FileReferences) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Callgraph) GetTickets() []string {
+func (x *FileReferences) GetFile() string {
 	if x != nil {
-		return x.Tickets
+		return x.File
 	}
-	return nil
+	return ""
 }
 
-func (x *Callgraph) GetType() Callgraph_Type {
+func (x *FileReferences) GetNode() []*FileReferences_Node {
 	if x != nil {
-		return x.Type
+		return x.Node
 	}
-	return Callgraph_UNKNOWN
+	return nil
 }
 
-type Diff struct {
+type FileRelations struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SpanLength       []int32     `protobuf:"varint,1,rep,packed,name=span_length,json=spanLength,proto3" json:"span_length,omitempty"`
-	SpanType         []Diff_Type `protobuf:"varint,2,rep,packed,name=span_type,json=spanType,proto3,enum=kythe.proto.serving.Diff_Type" json:"span_type,omitempty"`
-	SpanNewlines     []int32     `protobuf:"varint,3,rep,packed,name=span_newlines,json=spanNewlines,proto3" json:"span_newlines,omitempty"`
-	SpanFirstNewline []int32     `protobuf:"varint,4,rep,packed,name=span_first_newline,json=spanFirstNewline,proto3" json:"span_first_newline,omitempty"`
-	SpanLastNewline  []int32     `protobuf:"varint,5,rep,packed,name=span_last_newline,json=spanLastNewline,proto3" json:"span_last_newline,omitempty"`
+	File     string                    `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Outgoing []*FileRelations_Relation `protobuf:"bytes,2,rep,name=outgoing,proto3" json:"outgoing,omitempty"`
+	Incoming []*FileRelations_Relation `protobuf:"bytes,3,rep,name=incoming,proto3" json:"incoming,omitempty"`
 }
 
-func (x *Diff) Reset() {
-	*x = Diff{}
+func (x *FileRelations) Reset() {
+	*x = FileRelations{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[18]
+		mi := &file_kythe_proto_serving_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Diff) String() string {
+func (x *FileRelations) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Diff) ProtoMessage() {}
+func (*FileRelations) ProtoMessage() {}
 
-func (x *Diff) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[18]
+func (x *FileRelations) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1644,72 +1762,59 @@ func (x *Diff) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Diff.ProtoReflect.Descriptor instead.
-func (*Diff) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *Diff) GetSpanLength() []int32 {
-	if x != nil {
-		return x.SpanLength
-	}
-	return nil
-}
-
-func (x *Diff) GetSpanType() []Diff_Type {
-	if x != nil {
-		return x.SpanType
-	}
-	return nil
+// Deprecated: Use FileRelations.ProtoReflect.Descriptor instead.
+func (*FileRelations) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *Diff) GetSpanNewlines() []int32 {
+func (x *FileRelations) GetFile() string {
 	if x != nil {
-		return x.SpanNewlines
+		return x.File
 	}
-	return nil
+	return ""
 }
 
-func (x *Diff) GetSpanFirstNewline() []int32 {
+func (x *FileRelations) GetOutgoing() []*FileRelations_Relation {
 	if x != nil {
-		return x.SpanFirstNewline
+		return x.Outgoing
 	}
 	return nil
 }
 
-func (x *Diff) GetSpanLastNewline() []int32 {
+func (x *FileRelations) GetIncoming() []*FileRelations_Relation {
 	if x != nil {
-		return x.SpanLastNewline
+		return x.Incoming
 	}
 	return nil
 }
 
-type EdgeGroup_Edge struct {
+type CallDegrees struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Target  *Node `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	Ordinal int32 `protobuf:"varint,2,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	Ticket  string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Callers int64  `protobuf:"varint,2,opt,name=callers,proto3" json:"callers,omitempty"`
+	Callees int64  `protobuf:"varint,3,opt,name=callees,proto3" json:"callees,omitempty"`
 }
 
-func (x *EdgeGroup_Edge) Reset() {
-	*x = EdgeGroup_Edge{}
+func (x *CallDegrees) Reset() {
+	*x = CallDegrees{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[19]
+		mi := &file_kythe_proto_serving_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EdgeGroup_Edge) String() string {
+func (x *CallDegrees) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EdgeGroup_Edge) ProtoMessage() {}
+func (*CallDegrees) ProtoMessage() {}
 
-func (x *EdgeGroup_Edge) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[19]
+func (x *CallDegrees) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1720,53 +1825,59 @@ func (x *EdgeGroup_Edge) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EdgeGroup_Edge.ProtoReflect.Descriptor instead.
-func (*EdgeGroup_Edge) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use CallDegrees.ProtoReflect.Descriptor instead.
+func (*CallDegrees) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *EdgeGroup_Edge) GetTarget() *Node {
+func (x *CallDegrees) GetTicket() string {
 	if x != nil {
-		return x.Target
+		return x.Ticket
 	}
-	return nil
+	return ""
 }
 
-func (x *EdgeGroup_Edge) GetOrdinal() int32 {
+func (x *CallDegrees) GetCallers() int64 {
 	if x != nil {
-		return x.Ordinal
+		return x.Callers
 	}
 	return 0
 }
 
-type FileDirectory_Entry struct {
+func (x *CallDegrees) GetCallees() int64 {
+	if x != nil {
+		return x.Callees
+	}
+	return 0
+}
+
+type ContainerMembers struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Kind        FileDirectory_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=kythe.proto.serving.FileDirectory_Kind" json:"kind,omitempty"`
-	Name        string             `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	BuildConfig []string           `protobuf:"bytes,3,rep,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
-	Generated   bool               `protobuf:"varint,4,opt,name=generated,proto3" json:"generated,omitempty"`
+	Container string                     `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Kind      string                     `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Member    []*ContainerMembers_Member `protobuf:"bytes,3,rep,name=member,proto3" json:"member,omitempty"`
 }
 
-func (x *FileDirectory_Entry) Reset() {
-	*x = FileDirectory_Entry{}
+func (x *ContainerMembers) Reset() {
+	*x = ContainerMembers{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[20]
+		mi := &file_kythe_proto_serving_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FileDirectory_Entry) String() string {
+func (x *ContainerMembers) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileDirectory_Entry) ProtoMessage() {}
+func (*ContainerMembers) ProtoMessage() {}
 
-func (x *FileDirectory_Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[20]
+func (x *ContainerMembers) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1777,66 +1888,59 @@ func (x *FileDirectory_Entry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileDirectory_Entry.ProtoReflect.Descriptor instead.
-func (*FileDirectory_Entry) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{6, 0}
+// Deprecated: Use ContainerMembers.ProtoReflect.Descriptor instead.
+func (*ContainerMembers) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *FileDirectory_Entry) GetKind() FileDirectory_Kind {
+func (x *ContainerMembers) GetContainer() string {
 	if x != nil {
-		return x.Kind
+		return x.Container
 	}
-	return FileDirectory_UNKNOWN
+	return ""
 }
 
-func (x *FileDirectory_Entry) GetName() string {
+func (x *ContainerMembers) GetKind() string {
 	if x != nil {
-		return x.Name
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *FileDirectory_Entry) GetBuildConfig() []string {
+func (x *ContainerMembers) GetMember() []*ContainerMembers_Member {
 	if x != nil {
-		return x.BuildConfig
+		return x.Member
 	}
 	return nil
 }
 
-func (x *FileDirectory_Entry) GetGenerated() bool {
-	if x != nil {
-		return x.Generated
-	}
-	return false
-}
-
-type CorpusRoots_Corpus struct {
+type ExistenceFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Corpus      string   `protobuf:"bytes,1,opt,name=corpus,proto3" json:"corpus,omitempty"`
-	Root        []string `protobuf:"bytes,2,rep,name=root,proto3" json:"root,omitempty"`
-	BuildConfig []string `protobuf:"bytes,3,rep,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	Bits      []byte `protobuf:"bytes,1,opt,name=bits,proto3" json:"bits,omitempty"`
+	HashCount int32  `protobuf:"varint,2,opt,name=hash_count,json=hashCount,proto3" json:"hash_count,omitempty"`
+	Size      int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *CorpusRoots_Corpus) Reset() {
-	*x = CorpusRoots_Corpus{}
+func (x *ExistenceFilter) Reset() {
+	*x = ExistenceFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[21]
+		mi := &file_kythe_proto_serving_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CorpusRoots_Corpus) String() string {
+func (x *ExistenceFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CorpusRoots_Corpus) ProtoMessage() {}
+func (*ExistenceFilter) ProtoMessage() {}
 
-func (x *CorpusRoots_Corpus) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[21]
+func (x *ExistenceFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1847,61 +1951,58 @@ func (x *CorpusRoots_Corpus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CorpusRoots_Corpus.ProtoReflect.Descriptor instead.
-func (*CorpusRoots_Corpus) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{7, 0}
+// Deprecated: Use ExistenceFilter.ProtoReflect.Descriptor instead.
+func (*ExistenceFilter) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *CorpusRoots_Corpus) GetCorpus() string {
+func (x *ExistenceFilter) GetBits() []byte {
 	if x != nil {
-		return x.Corpus
+		return x.Bits
 	}
-	return ""
+	return nil
 }
 
-func (x *CorpusRoots_Corpus) GetRoot() []string {
+func (x *ExistenceFilter) GetHashCount() int32 {
 	if x != nil {
-		return x.Root
+		return x.HashCount
 	}
-	return nil
+	return 0
 }
 
-func (x *CorpusRoots_Corpus) GetBuildConfig() []string {
+func (x *ExistenceFilter) GetSize() int64 {
 	if x != nil {
-		return x.BuildConfig
+		return x.Size
 	}
-	return nil
+	return 0
 }
 
-type FileDecorations_Decoration struct {
+type NodeFactHashes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Anchor           *RawAnchor `protobuf:"bytes,1,opt,name=anchor,proto3" json:"anchor,omitempty"`
-	Kind             string     `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	Target           string     `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
-	TargetDefinition string     `protobuf:"bytes,4,opt,name=target_definition,json=targetDefinition,proto3" json:"target_definition,omitempty"`
-	SemanticScope    string     `protobuf:"bytes,6,opt,name=semantic_scope,json=semanticScope,proto3" json:"semantic_scope,omitempty"`
+	Ticket string                 `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Fact   []*NodeFactHashes_Fact `protobuf:"bytes,2,rep,name=fact,proto3" json:"fact,omitempty"`
 }
 
-func (x *FileDecorations_Decoration) Reset() {
-	*x = FileDecorations_Decoration{}
+func (x *NodeFactHashes) Reset() {
+	*x = NodeFactHashes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[22]
+		mi := &file_kythe_proto_serving_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FileDecorations_Decoration) String() string {
+func (x *NodeFactHashes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileDecorations_Decoration) ProtoMessage() {}
+func (*NodeFactHashes) ProtoMessage() {}
 
-func (x *FileDecorations_Decoration) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[22]
+func (x *NodeFactHashes) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1912,75 +2013,99 @@ func (x *FileDecorations_Decoration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileDecorations_Decoration.ProtoReflect.Descriptor instead.
-func (*FileDecorations_Decoration) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{12, 0}
+// Deprecated: Use NodeFactHashes.ProtoReflect.Descriptor instead.
+func (*NodeFactHashes) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *FileDecorations_Decoration) GetAnchor() *RawAnchor {
+func (x *NodeFactHashes) GetTicket() string {
 	if x != nil {
-		return x.Anchor
+		return x.Ticket
 	}
-	return nil
+	return ""
 }
 
-func (x *FileDecorations_Decoration) GetKind() string {
+func (x *NodeFactHashes) GetFact() []*NodeFactHashes_Fact {
 	if x != nil {
-		return x.Kind
+		return x.Fact
 	}
-	return ""
+	return nil
 }
 
-func (x *FileDecorations_Decoration) GetTarget() string {
-	if x != nil {
-		return x.Target
+type FactHashesGeneration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildId string `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+}
+
+func (x *FactHashesGeneration) Reset() {
+	*x = FactHashesGeneration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *FileDecorations_Decoration) GetTargetDefinition() string {
-	if x != nil {
-		return x.TargetDefinition
+func (x *FactHashesGeneration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactHashesGeneration) ProtoMessage() {}
+
+func (x *FactHashesGeneration) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *FileDecorations_Decoration) GetSemanticScope() string {
+// Deprecated: Use FactHashesGeneration.ProtoReflect.Descriptor instead.
+func (*FactHashesGeneration) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FactHashesGeneration) GetBuildId() string {
 	if x != nil {
-		return x.SemanticScope
+		return x.BuildId
 	}
 	return ""
 }
 
-type FileDecorations_Override struct {
+type ContainerRollup struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Overriding           string                        `protobuf:"bytes,1,opt,name=overriding,proto3" json:"overriding,omitempty"`
-	Overridden           string                        `protobuf:"bytes,2,opt,name=overridden,proto3" json:"overridden,omitempty"`
-	OverriddenDefinition string                        `protobuf:"bytes,5,opt,name=overridden_definition,json=overriddenDefinition,proto3" json:"overridden_definition,omitempty"`
-	Kind                 FileDecorations_Override_Kind `protobuf:"varint,3,opt,name=kind,proto3,enum=kythe.proto.serving.FileDecorations_Override_Kind" json:"kind,omitempty"`
-	MarkedSource         *common_go_proto.MarkedSource `protobuf:"bytes,4,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	Container      string                    `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	ReferenceCount int64                     `protobuf:"varint,2,opt,name=reference_count,json=referenceCount,proto3" json:"reference_count,omitempty"`
+	Target         []*ContainerRollup_Target `protobuf:"bytes,3,rep,name=target,proto3" json:"target,omitempty"`
 }
 
-func (x *FileDecorations_Override) Reset() {
-	*x = FileDecorations_Override{}
+func (x *ContainerRollup) Reset() {
+	*x = ContainerRollup{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[23]
+		mi := &file_kythe_proto_serving_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FileDecorations_Override) String() string {
+func (x *ContainerRollup) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileDecorations_Override) ProtoMessage() {}
+func (*ContainerRollup) ProtoMessage() {}
 
-func (x *FileDecorations_Override) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[23]
+func (x *ContainerRollup) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1991,72 +2116,58 @@ func (x *FileDecorations_Override) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileDecorations_Override.ProtoReflect.Descriptor instead.
-func (*FileDecorations_Override) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{12, 1}
-}
-
-func (x *FileDecorations_Override) GetOverriding() string {
-	if x != nil {
-		return x.Overriding
-	}
-	return ""
-}
-
-func (x *FileDecorations_Override) GetOverridden() string {
-	if x != nil {
-		return x.Overridden
-	}
-	return ""
+// Deprecated: Use ContainerRollup.ProtoReflect.Descriptor instead.
+func (*ContainerRollup) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *FileDecorations_Override) GetOverriddenDefinition() string {
+func (x *ContainerRollup) GetContainer() string {
 	if x != nil {
-		return x.OverriddenDefinition
+		return x.Container
 	}
 	return ""
 }
 
-func (x *FileDecorations_Override) GetKind() FileDecorations_Override_Kind {
+func (x *ContainerRollup) GetReferenceCount() int64 {
 	if x != nil {
-		return x.Kind
+		return x.ReferenceCount
 	}
-	return FileDecorations_Override_OVERRIDES
+	return 0
 }
 
-func (x *FileDecorations_Override) GetMarkedSource() *common_go_proto.MarkedSource {
+func (x *ContainerRollup) GetTarget() []*ContainerRollup_Target {
 	if x != nil {
-		return x.MarkedSource
+		return x.Target
 	}
 	return nil
 }
 
-type PagedCrossReferences_RelatedNode struct {
+type FileOutline struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Node    *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	Ordinal int32 `protobuf:"varint,2,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	File   string                `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Symbol []*FileOutline_Symbol `protobuf:"bytes,2,rep,name=symbol,proto3" json:"symbol,omitempty"`
 }
 
-func (x *PagedCrossReferences_RelatedNode) Reset() {
-	*x = PagedCrossReferences_RelatedNode{}
+func (x *FileOutline) Reset() {
+	*x = FileOutline{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[24]
+		mi := &file_kythe_proto_serving_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_RelatedNode) String() string {
+func (x *FileOutline) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_RelatedNode) ProtoMessage() {}
+func (*FileOutline) ProtoMessage() {}
 
-func (x *PagedCrossReferences_RelatedNode) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[24]
+func (x *FileOutline) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2067,53 +2178,51 @@ func (x *PagedCrossReferences_RelatedNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_RelatedNode.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_RelatedNode) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 0}
+// Deprecated: Use FileOutline.ProtoReflect.Descriptor instead.
+func (*FileOutline) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *PagedCrossReferences_RelatedNode) GetNode() *Node {
+func (x *FileOutline) GetFile() string {
 	if x != nil {
-		return x.Node
+		return x.File
 	}
-	return nil
+	return ""
 }
 
-func (x *PagedCrossReferences_RelatedNode) GetOrdinal() int32 {
+func (x *FileOutline) GetSymbol() []*FileOutline_Symbol {
 	if x != nil {
-		return x.Ordinal
+		return x.Symbol
 	}
-	return 0
+	return nil
 }
 
-type PagedCrossReferences_ScopedReference struct {
+type TicketAlias struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Scope         *ExpandedAnchor               `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
-	SemanticScope string                        `protobuf:"bytes,2,opt,name=semantic_scope,json=semanticScope,proto3" json:"semantic_scope,omitempty"`
-	MarkedSource  *common_go_proto.MarkedSource `protobuf:"bytes,3,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
-	Reference     []*ExpandedAnchor             `protobuf:"bytes,4,rep,name=reference,proto3" json:"reference,omitempty"`
+	Alias  string `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Ticket string `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
 }
 
-func (x *PagedCrossReferences_ScopedReference) Reset() {
-	*x = PagedCrossReferences_ScopedReference{}
+func (x *TicketAlias) Reset() {
+	*x = TicketAlias{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[25]
+		mi := &file_kythe_proto_serving_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_ScopedReference) String() string {
+func (x *TicketAlias) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_ScopedReference) ProtoMessage() {}
+func (*TicketAlias) ProtoMessage() {}
 
-func (x *PagedCrossReferences_ScopedReference) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[25]
+func (x *TicketAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2124,67 +2233,50 @@ func (x *PagedCrossReferences_ScopedReference) ProtoReflect() protoreflect.Messa
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_ScopedReference.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_ScopedReference) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 1}
-}
-
-func (x *PagedCrossReferences_ScopedReference) GetScope() *ExpandedAnchor {
-	if x != nil {
-		return x.Scope
-	}
-	return nil
+// Deprecated: Use TicketAlias.ProtoReflect.Descriptor instead.
+func (*TicketAlias) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *PagedCrossReferences_ScopedReference) GetSemanticScope() string {
+func (x *TicketAlias) GetAlias() string {
 	if x != nil {
-		return x.SemanticScope
+		return x.Alias
 	}
 	return ""
 }
 
-func (x *PagedCrossReferences_ScopedReference) GetMarkedSource() *common_go_proto.MarkedSource {
-	if x != nil {
-		return x.MarkedSource
-	}
-	return nil
-}
-
-func (x *PagedCrossReferences_ScopedReference) GetReference() []*ExpandedAnchor {
+func (x *TicketAlias) GetTicket() string {
 	if x != nil {
-		return x.Reference
+		return x.Ticket
 	}
-	return nil
+	return ""
 }
 
-type PagedCrossReferences_Caller struct {
+type TicketIndexEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Caller         *ExpandedAnchor               `protobuf:"bytes,1,opt,name=caller,proto3" json:"caller,omitempty"`
-	SemanticCaller string                        `protobuf:"bytes,2,opt,name=semantic_caller,json=semanticCaller,proto3" json:"semantic_caller,omitempty"`
-	MarkedSource   *common_go_proto.MarkedSource `protobuf:"bytes,3,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
-	Callsite       []*ExpandedAnchor             `protobuf:"bytes,4,rep,name=callsite,proto3" json:"callsite,omitempty"`
+	Ticket string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
 }
 
-func (x *PagedCrossReferences_Caller) Reset() {
-	*x = PagedCrossReferences_Caller{}
+func (x *TicketIndexEntry) Reset() {
+	*x = TicketIndexEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[26]
+		mi := &file_kythe_proto_serving_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_Caller) String() string {
+func (x *TicketIndexEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_Caller) ProtoMessage() {}
+func (*TicketIndexEntry) ProtoMessage() {}
 
-func (x *PagedCrossReferences_Caller) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[26]
+func (x *TicketIndexEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2195,70 +2287,1307 @@ func (x *PagedCrossReferences_Caller) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_Caller.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_Caller) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 2}
-}
-
-func (x *PagedCrossReferences_Caller) GetCaller() *ExpandedAnchor {
-	if x != nil {
-		return x.Caller
-	}
-	return nil
+// Deprecated: Use TicketIndexEntry.ProtoReflect.Descriptor instead.
+func (*TicketIndexEntry) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *PagedCrossReferences_Caller) GetSemanticCaller() string {
+func (x *TicketIndexEntry) GetTicket() string {
 	if x != nil {
-		return x.SemanticCaller
+		return x.Ticket
 	}
 	return ""
 }
 
-func (x *PagedCrossReferences_Caller) GetMarkedSource() *common_go_proto.MarkedSource {
-	if x != nil {
-		return x.MarkedSource
-	}
-	return nil
-}
+type IdentifierMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *PagedCrossReferences_Caller) GetCallsite() []*ExpandedAnchor {
+	QualifiedName string                  `protobuf:"bytes,1,opt,name=qualified_name,json=qualifiedName,proto3" json:"qualified_name,omitempty"`
+	BaseName      string                  `protobuf:"bytes,2,opt,name=base_name,json=baseName,proto3" json:"base_name,omitempty"`
+	Node          []*IdentifierMatch_Node `protobuf:"bytes,3,rep,name=node,proto3" json:"node,omitempty"`
+}
+
+func (x *IdentifierMatch) Reset() {
+	*x = IdentifierMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IdentifierMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentifierMatch) ProtoMessage() {}
+
+func (x *IdentifierMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentifierMatch.ProtoReflect.Descriptor instead.
+func (*IdentifierMatch) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *IdentifierMatch) GetQualifiedName() string {
+	if x != nil {
+		return x.QualifiedName
+	}
+	return ""
+}
+
+func (x *IdentifierMatch) GetBaseName() string {
+	if x != nil {
+		return x.BaseName
+	}
+	return ""
+}
+
+func (x *IdentifierMatch) GetNode() []*IdentifierMatch_Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type Relatives struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tickets []string       `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	Type    Relatives_Type `protobuf:"varint,2,opt,name=type,proto3,enum=kythe.proto.serving.Relatives_Type" json:"type,omitempty"`
+}
+
+func (x *Relatives) Reset() {
+	*x = Relatives{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Relatives) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relatives) ProtoMessage() {}
+
+func (x *Relatives) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relatives.ProtoReflect.Descriptor instead.
+func (*Relatives) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Relatives) GetTickets() []string {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *Relatives) GetType() Relatives_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Relatives_UNKNOWN
+}
+
+type Callgraph struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tickets []string       `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	Type    Callgraph_Type `protobuf:"varint,2,opt,name=type,proto3,enum=kythe.proto.serving.Callgraph_Type" json:"type,omitempty"`
+}
+
+func (x *Callgraph) Reset() {
+	*x = Callgraph{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Callgraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Callgraph) ProtoMessage() {}
+
+func (x *Callgraph) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Callgraph.ProtoReflect.Descriptor instead.
+func (*Callgraph) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Callgraph) GetTickets() []string {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *Callgraph) GetType() Callgraph_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Callgraph_UNKNOWN
+}
+
+type Diff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SpanLength       []int32     `protobuf:"varint,1,rep,packed,name=span_length,json=spanLength,proto3" json:"span_length,omitempty"`
+	SpanType         []Diff_Type `protobuf:"varint,2,rep,packed,name=span_type,json=spanType,proto3,enum=kythe.proto.serving.Diff_Type" json:"span_type,omitempty"`
+	SpanNewlines     []int32     `protobuf:"varint,3,rep,packed,name=span_newlines,json=spanNewlines,proto3" json:"span_newlines,omitempty"`
+	SpanFirstNewline []int32     `protobuf:"varint,4,rep,packed,name=span_first_newline,json=spanFirstNewline,proto3" json:"span_first_newline,omitempty"`
+	SpanLastNewline  []int32     `protobuf:"varint,5,rep,packed,name=span_last_newline,json=spanLastNewline,proto3" json:"span_last_newline,omitempty"`
+}
+
+func (x *Diff) Reset() {
+	*x = Diff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Diff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diff) ProtoMessage() {}
+
+func (x *Diff) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diff.ProtoReflect.Descriptor instead.
+func (*Diff) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Diff) GetSpanLength() []int32 {
+	if x != nil {
+		return x.SpanLength
+	}
+	return nil
+}
+
+func (x *Diff) GetSpanType() []Diff_Type {
+	if x != nil {
+		return x.SpanType
+	}
+	return nil
+}
+
+func (x *Diff) GetSpanNewlines() []int32 {
+	if x != nil {
+		return x.SpanNewlines
+	}
+	return nil
+}
+
+func (x *Diff) GetSpanFirstNewline() []int32 {
+	if x != nil {
+		return x.SpanFirstNewline
+	}
+	return nil
+}
+
+func (x *Diff) GetSpanLastNewline() []int32 {
+	if x != nil {
+		return x.SpanLastNewline
+	}
+	return nil
+}
+
+type TableManifest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries  int64  `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	Checksum []byte `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *TableManifest) Reset() {
+	*x = TableManifest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableManifest) ProtoMessage() {}
+
+func (x *TableManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableManifest.ProtoReflect.Descriptor instead.
+func (*TableManifest) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TableManifest) GetEntries() int64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *TableManifest) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+type TableMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildId   string                 `protobuf:"bytes,1,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	BuildTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+}
+
+func (x *TableMetadata) Reset() {
+	*x = TableMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableMetadata) ProtoMessage() {}
+
+func (x *TableMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableMetadata.ProtoReflect.Descriptor instead.
+func (*TableMetadata) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TableMetadata) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *TableMetadata) GetBuildTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildTime
+	}
+	return nil
+}
+
+type KindsSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeKinds map[string]int64 `protobuf:"bytes,1,rep,name=node_kinds,json=nodeKinds,proto3" json:"node_kinds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	EdgeKinds map[string]int64 `protobuf:"bytes,2,rep,name=edge_kinds,json=edgeKinds,proto3" json:"edge_kinds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *KindsSummary) Reset() {
+	*x = KindsSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KindsSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KindsSummary) ProtoMessage() {}
+
+func (x *KindsSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KindsSummary.ProtoReflect.Descriptor instead.
+func (*KindsSummary) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *KindsSummary) GetNodeKinds() map[string]int64 {
+	if x != nil {
+		return x.NodeKinds
+	}
+	return nil
+}
+
+func (x *KindsSummary) GetEdgeKinds() map[string]int64 {
+	if x != nil {
+		return x.EdgeKinds
+	}
+	return nil
+}
+
+type EdgeGroup_Edge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target  *Node `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Ordinal int32 `protobuf:"varint,2,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+}
+
+func (x *EdgeGroup_Edge) Reset() {
+	*x = EdgeGroup_Edge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EdgeGroup_Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgeGroup_Edge) ProtoMessage() {}
+
+func (x *EdgeGroup_Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgeGroup_Edge.ProtoReflect.Descriptor instead.
+func (*EdgeGroup_Edge) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *EdgeGroup_Edge) GetTarget() *Node {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *EdgeGroup_Edge) GetOrdinal() int32 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+type FileDirectory_Entry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind        FileDirectory_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=kythe.proto.serving.FileDirectory_Kind" json:"kind,omitempty"`
+	Name        string             `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	BuildConfig []string           `protobuf:"bytes,3,rep,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	Generated   bool               `protobuf:"varint,4,opt,name=generated,proto3" json:"generated,omitempty"`
+}
+
+func (x *FileDirectory_Entry) Reset() {
+	*x = FileDirectory_Entry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileDirectory_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDirectory_Entry) ProtoMessage() {}
+
+func (x *FileDirectory_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDirectory_Entry.ProtoReflect.Descriptor instead.
+func (*FileDirectory_Entry) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *FileDirectory_Entry) GetKind() FileDirectory_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return FileDirectory_UNKNOWN
+}
+
+func (x *FileDirectory_Entry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileDirectory_Entry) GetBuildConfig() []string {
+	if x != nil {
+		return x.BuildConfig
+	}
+	return nil
+}
+
+func (x *FileDirectory_Entry) GetGenerated() bool {
+	if x != nil {
+		return x.Generated
+	}
+	return false
+}
+
+type CorpusRoots_Corpus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Corpus      string   `protobuf:"bytes,1,opt,name=corpus,proto3" json:"corpus,omitempty"`
+	Root        []string `protobuf:"bytes,2,rep,name=root,proto3" json:"root,omitempty"`
+	BuildConfig []string `protobuf:"bytes,3,rep,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+}
+
+func (x *CorpusRoots_Corpus) Reset() {
+	*x = CorpusRoots_Corpus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CorpusRoots_Corpus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorpusRoots_Corpus) ProtoMessage() {}
+
+func (x *CorpusRoots_Corpus) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorpusRoots_Corpus.ProtoReflect.Descriptor instead.
+func (*CorpusRoots_Corpus) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *CorpusRoots_Corpus) GetCorpus() string {
+	if x != nil {
+		return x.Corpus
+	}
+	return ""
+}
+
+func (x *CorpusRoots_Corpus) GetRoot() []string {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *CorpusRoots_Corpus) GetBuildConfig() []string {
+	if x != nil {
+		return x.BuildConfig
+	}
+	return nil
+}
+
+type FileDecorations_Decoration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Anchor           *RawAnchor `protobuf:"bytes,1,opt,name=anchor,proto3" json:"anchor,omitempty"`
+	Kind             string     `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Target           string     `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	TargetDefinition string     `protobuf:"bytes,4,opt,name=target_definition,json=targetDefinition,proto3" json:"target_definition,omitempty"`
+	SemanticScope    string     `protobuf:"bytes,6,opt,name=semantic_scope,json=semanticScope,proto3" json:"semantic_scope,omitempty"`
+}
+
+func (x *FileDecorations_Decoration) Reset() {
+	*x = FileDecorations_Decoration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileDecorations_Decoration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDecorations_Decoration) ProtoMessage() {}
+
+func (x *FileDecorations_Decoration) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDecorations_Decoration.ProtoReflect.Descriptor instead.
+func (*FileDecorations_Decoration) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *FileDecorations_Decoration) GetAnchor() *RawAnchor {
+	if x != nil {
+		return x.Anchor
+	}
+	return nil
+}
+
+func (x *FileDecorations_Decoration) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *FileDecorations_Decoration) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *FileDecorations_Decoration) GetTargetDefinition() string {
+	if x != nil {
+		return x.TargetDefinition
+	}
+	return ""
+}
+
+func (x *FileDecorations_Decoration) GetSemanticScope() string {
+	if x != nil {
+		return x.SemanticScope
+	}
+	return ""
+}
+
+type FileDecorations_Override struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Overriding           string                        `protobuf:"bytes,1,opt,name=overriding,proto3" json:"overriding,omitempty"`
+	Overridden           string                        `protobuf:"bytes,2,opt,name=overridden,proto3" json:"overridden,omitempty"`
+	OverriddenDefinition string                        `protobuf:"bytes,5,opt,name=overridden_definition,json=overriddenDefinition,proto3" json:"overridden_definition,omitempty"`
+	Kind                 FileDecorations_Override_Kind `protobuf:"varint,3,opt,name=kind,proto3,enum=kythe.proto.serving.FileDecorations_Override_Kind" json:"kind,omitempty"`
+	MarkedSource         *common_go_proto.MarkedSource `protobuf:"bytes,4,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+}
+
+func (x *FileDecorations_Override) Reset() {
+	*x = FileDecorations_Override{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileDecorations_Override) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDecorations_Override) ProtoMessage() {}
+
+func (x *FileDecorations_Override) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDecorations_Override.ProtoReflect.Descriptor instead.
+func (*FileDecorations_Override) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{12, 1}
+}
+
+func (x *FileDecorations_Override) GetOverriding() string {
+	if x != nil {
+		return x.Overriding
+	}
+	return ""
+}
+
+func (x *FileDecorations_Override) GetOverridden() string {
+	if x != nil {
+		return x.Overridden
+	}
+	return ""
+}
+
+func (x *FileDecorations_Override) GetOverriddenDefinition() string {
+	if x != nil {
+		return x.OverriddenDefinition
+	}
+	return ""
+}
+
+func (x *FileDecorations_Override) GetKind() FileDecorations_Override_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return FileDecorations_Override_OVERRIDES
+}
+
+func (x *FileDecorations_Override) GetMarkedSource() *common_go_proto.MarkedSource {
+	if x != nil {
+		return x.MarkedSource
+	}
+	return nil
+}
+
+type FileDecorations_DecorationPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageKey     string `protobuf:"bytes,1,opt,name=page_key,json=pageKey,proto3" json:"page_key,omitempty"`
+	StartOffset int32  `protobuf:"varint,2,opt,name=start_offset,json=startOffset,proto3" json:"start_offset,omitempty"`
+	EndOffset   int32  `protobuf:"varint,3,opt,name=end_offset,json=endOffset,proto3" json:"end_offset,omitempty"`
+}
+
+func (x *FileDecorations_DecorationPage) Reset() {
+	*x = FileDecorations_DecorationPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileDecorations_DecorationPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDecorations_DecorationPage) ProtoMessage() {}
+
+func (x *FileDecorations_DecorationPage) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDecorations_DecorationPage.ProtoReflect.Descriptor instead.
+func (*FileDecorations_DecorationPage) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{12, 3}
+}
+
+func (x *FileDecorations_DecorationPage) GetPageKey() string {
+	if x != nil {
+		return x.PageKey
+	}
+	return ""
+}
+
+func (x *FileDecorations_DecorationPage) GetStartOffset() int32 {
+	if x != nil {
+		return x.StartOffset
+	}
+	return 0
+}
+
+func (x *FileDecorations_DecorationPage) GetEndOffset() int32 {
+	if x != nil {
+		return x.EndOffset
+	}
+	return 0
+}
+
+type PagedCrossReferences_RelatedNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node    *Node `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Ordinal int32 `protobuf:"varint,2,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+}
+
+func (x *PagedCrossReferences_RelatedNode) Reset() {
+	*x = PagedCrossReferences_RelatedNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PagedCrossReferences_RelatedNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedCrossReferences_RelatedNode) ProtoMessage() {}
+
+func (x *PagedCrossReferences_RelatedNode) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedCrossReferences_RelatedNode.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_RelatedNode) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *PagedCrossReferences_RelatedNode) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_RelatedNode) GetOrdinal() int32 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+type PagedCrossReferences_ScopedReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scope         *ExpandedAnchor               `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	SemanticScope string                        `protobuf:"bytes,2,opt,name=semantic_scope,json=semanticScope,proto3" json:"semantic_scope,omitempty"`
+	MarkedSource  *common_go_proto.MarkedSource `protobuf:"bytes,3,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	Reference     []*ExpandedAnchor             `protobuf:"bytes,4,rep,name=reference,proto3" json:"reference,omitempty"`
+}
+
+func (x *PagedCrossReferences_ScopedReference) Reset() {
+	*x = PagedCrossReferences_ScopedReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PagedCrossReferences_ScopedReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedCrossReferences_ScopedReference) ProtoMessage() {}
+
+func (x *PagedCrossReferences_ScopedReference) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedCrossReferences_ScopedReference.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_ScopedReference) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 1}
+}
+
+func (x *PagedCrossReferences_ScopedReference) GetScope() *ExpandedAnchor {
+	if x != nil {
+		return x.Scope
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_ScopedReference) GetSemanticScope() string {
+	if x != nil {
+		return x.SemanticScope
+	}
+	return ""
+}
+
+func (x *PagedCrossReferences_ScopedReference) GetMarkedSource() *common_go_proto.MarkedSource {
+	if x != nil {
+		return x.MarkedSource
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_ScopedReference) GetReference() []*ExpandedAnchor {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+type PagedCrossReferences_Caller struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Caller         *ExpandedAnchor               `protobuf:"bytes,1,opt,name=caller,proto3" json:"caller,omitempty"`
+	SemanticCaller string                        `protobuf:"bytes,2,opt,name=semantic_caller,json=semanticCaller,proto3" json:"semantic_caller,omitempty"`
+	MarkedSource   *common_go_proto.MarkedSource `protobuf:"bytes,3,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	Callsite       []*ExpandedAnchor             `protobuf:"bytes,4,rep,name=callsite,proto3" json:"callsite,omitempty"`
+}
+
+func (x *PagedCrossReferences_Caller) Reset() {
+	*x = PagedCrossReferences_Caller{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PagedCrossReferences_Caller) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedCrossReferences_Caller) ProtoMessage() {}
+
+func (x *PagedCrossReferences_Caller) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedCrossReferences_Caller.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_Caller) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 2}
+}
+
+func (x *PagedCrossReferences_Caller) GetCaller() *ExpandedAnchor {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_Caller) GetSemanticCaller() string {
+	if x != nil {
+		return x.SemanticCaller
+	}
+	return ""
+}
+
+func (x *PagedCrossReferences_Caller) GetMarkedSource() *common_go_proto.MarkedSource {
+	if x != nil {
+		return x.MarkedSource
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_Caller) GetCallsite() []*ExpandedAnchor {
+	if x != nil {
+		return x.Callsite
+	}
+	return nil
+}
+
+type PagedCrossReferences_Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind            string                                  `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	BuildConfig     string                                  `protobuf:"bytes,5,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	Anchor          []*ExpandedAnchor                       `protobuf:"bytes,2,rep,name=anchor,proto3" json:"anchor,omitempty"`
+	RelatedNode     []*PagedCrossReferences_RelatedNode     `protobuf:"bytes,3,rep,name=related_node,json=relatedNode,proto3" json:"related_node,omitempty"`
+	Caller          []*PagedCrossReferences_Caller          `protobuf:"bytes,4,rep,name=caller,proto3" json:"caller,omitempty"`
+	ScopedReference []*PagedCrossReferences_ScopedReference `protobuf:"bytes,7,rep,name=scoped_reference,json=scopedReference,proto3" json:"scoped_reference,omitempty"`
+	FileInfo        []*FileInfo                             `protobuf:"bytes,6,rep,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+}
+
+func (x *PagedCrossReferences_Group) Reset() {
+	*x = PagedCrossReferences_Group{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PagedCrossReferences_Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedCrossReferences_Group) ProtoMessage() {}
+
+func (x *PagedCrossReferences_Group) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedCrossReferences_Group.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_Group) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 3}
+}
+
+func (x *PagedCrossReferences_Group) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PagedCrossReferences_Group) GetBuildConfig() string {
+	if x != nil {
+		return x.BuildConfig
+	}
+	return ""
+}
+
+func (x *PagedCrossReferences_Group) GetAnchor() []*ExpandedAnchor {
+	if x != nil {
+		return x.Anchor
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_Group) GetRelatedNode() []*PagedCrossReferences_RelatedNode {
+	if x != nil {
+		return x.RelatedNode
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_Group) GetCaller() []*PagedCrossReferences_Caller {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_Group) GetScopedReference() []*PagedCrossReferences_ScopedReference {
+	if x != nil {
+		return x.ScopedReference
+	}
+	return nil
+}
+
+func (x *PagedCrossReferences_Group) GetFileInfo() []*FileInfo {
+	if x != nil {
+		return x.FileInfo
+	}
+	return nil
+}
+
+type PagedCrossReferences_Page struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageKey      string                      `protobuf:"bytes,1,opt,name=page_key,json=pageKey,proto3" json:"page_key,omitempty"`
+	SourceTicket string                      `protobuf:"bytes,2,opt,name=source_ticket,json=sourceTicket,proto3" json:"source_ticket,omitempty"`
+	Group        *PagedCrossReferences_Group `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *PagedCrossReferences_Page) Reset() {
+	*x = PagedCrossReferences_Page{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PagedCrossReferences_Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedCrossReferences_Page) ProtoMessage() {}
+
+func (x *PagedCrossReferences_Page) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedCrossReferences_Page.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_Page) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 4}
+}
+
+func (x *PagedCrossReferences_Page) GetPageKey() string {
+	if x != nil {
+		return x.PageKey
+	}
+	return ""
+}
+
+func (x *PagedCrossReferences_Page) GetSourceTicket() string {
+	if x != nil {
+		return x.SourceTicket
+	}
+	return ""
+}
+
+func (x *PagedCrossReferences_Page) GetGroup() *PagedCrossReferences_Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type PagedCrossReferences_PageIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind             string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Count            int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	PageKey          string `protobuf:"bytes,3,opt,name=page_key,json=pageKey,proto3" json:"page_key,omitempty"`
+	BuildConfig      string `protobuf:"bytes,4,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	UncompressedSize int64  `protobuf:"varint,5,opt,name=uncompressed_size,json=uncompressedSize,proto3" json:"uncompressed_size,omitempty"`
+}
+
+func (x *PagedCrossReferences_PageIndex) Reset() {
+	*x = PagedCrossReferences_PageIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PagedCrossReferences_PageIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedCrossReferences_PageIndex) ProtoMessage() {}
+
+func (x *PagedCrossReferences_PageIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedCrossReferences_PageIndex.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_PageIndex) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 5}
+}
+
+func (x *PagedCrossReferences_PageIndex) GetKind() string {
 	if x != nil {
-		return x.Callsite
+		return x.Kind
 	}
-	return nil
+	return ""
 }
 
-type PagedCrossReferences_Group struct {
+func (x *PagedCrossReferences_PageIndex) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PagedCrossReferences_PageIndex) GetPageKey() string {
+	if x != nil {
+		return x.PageKey
+	}
+	return ""
+}
+
+func (x *PagedCrossReferences_PageIndex) GetBuildConfig() string {
+	if x != nil {
+		return x.BuildConfig
+	}
+	return ""
+}
+
+func (x *PagedCrossReferences_PageIndex) GetUncompressedSize() int64 {
+	if x != nil {
+		return x.UncompressedSize
+	}
+	return 0
+}
+
+type PagedCrossReferences_PageSearchIndex struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Kind            string                                  `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
-	BuildConfig     string                                  `protobuf:"bytes,5,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
-	Anchor          []*ExpandedAnchor                       `protobuf:"bytes,2,rep,name=anchor,proto3" json:"anchor,omitempty"`
-	RelatedNode     []*PagedCrossReferences_RelatedNode     `protobuf:"bytes,3,rep,name=related_node,json=relatedNode,proto3" json:"related_node,omitempty"`
-	Caller          []*PagedCrossReferences_Caller          `protobuf:"bytes,4,rep,name=caller,proto3" json:"caller,omitempty"`
-	ScopedReference []*PagedCrossReferences_ScopedReference `protobuf:"bytes,7,rep,name=scoped_reference,json=scopedReference,proto3" json:"scoped_reference,omitempty"`
-	FileInfo        []*FileInfo                             `protobuf:"bytes,6,rep,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	ByCorpus       *PagedCrossReferences_PageSearchIndex_Postings `protobuf:"bytes,1,opt,name=by_corpus,json=byCorpus,proto3" json:"by_corpus,omitempty"`
+	ByRoot         *PagedCrossReferences_PageSearchIndex_Postings `protobuf:"bytes,2,opt,name=by_root,json=byRoot,proto3" json:"by_root,omitempty"`
+	ByPath         *PagedCrossReferences_PageSearchIndex_Postings `protobuf:"bytes,3,opt,name=by_path,json=byPath,proto3" json:"by_path,omitempty"`
+	ByResolvedPath *PagedCrossReferences_PageSearchIndex_Postings `protobuf:"bytes,4,opt,name=by_resolved_path,json=byResolvedPath,proto3" json:"by_resolved_path,omitempty"`
 }
 
-func (x *PagedCrossReferences_Group) Reset() {
-	*x = PagedCrossReferences_Group{}
+func (x *PagedCrossReferences_PageSearchIndex) Reset() {
+	*x = PagedCrossReferences_PageSearchIndex{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[27]
+		mi := &file_kythe_proto_serving_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_Group) String() string {
+func (x *PagedCrossReferences_PageSearchIndex) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_Group) ProtoMessage() {}
+func (*PagedCrossReferences_PageSearchIndex) ProtoMessage() {}
 
-func (x *PagedCrossReferences_Group) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[27]
+func (x *PagedCrossReferences_PageSearchIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2269,87 +3598,159 @@ func (x *PagedCrossReferences_Group) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_Group.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_Group) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 3}
+// Deprecated: Use PagedCrossReferences_PageSearchIndex.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_PageSearchIndex) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 6}
 }
 
-func (x *PagedCrossReferences_Group) GetKind() string {
+func (x *PagedCrossReferences_PageSearchIndex) GetByCorpus() *PagedCrossReferences_PageSearchIndex_Postings {
 	if x != nil {
-		return x.Kind
+		return x.ByCorpus
 	}
-	return ""
+	return nil
 }
 
-func (x *PagedCrossReferences_Group) GetBuildConfig() string {
+func (x *PagedCrossReferences_PageSearchIndex) GetByRoot() *PagedCrossReferences_PageSearchIndex_Postings {
 	if x != nil {
-		return x.BuildConfig
+		return x.ByRoot
 	}
-	return ""
+	return nil
 }
 
-func (x *PagedCrossReferences_Group) GetAnchor() []*ExpandedAnchor {
+func (x *PagedCrossReferences_PageSearchIndex) GetByPath() *PagedCrossReferences_PageSearchIndex_Postings {
 	if x != nil {
-		return x.Anchor
+		return x.ByPath
 	}
 	return nil
 }
 
-func (x *PagedCrossReferences_Group) GetRelatedNode() []*PagedCrossReferences_RelatedNode {
+func (x *PagedCrossReferences_PageSearchIndex) GetByResolvedPath() *PagedCrossReferences_PageSearchIndex_Postings {
 	if x != nil {
-		return x.RelatedNode
+		return x.ByResolvedPath
 	}
 	return nil
 }
 
-func (x *PagedCrossReferences_Group) GetCaller() []*PagedCrossReferences_Caller {
-	if x != nil {
-		return x.Caller
+type PagedCrossReferences_PageSearchIndex_Pages struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageIndex []uint32 `protobuf:"varint,1,rep,packed,name=page_index,json=pageIndex,proto3" json:"page_index,omitempty"`
+}
+
+func (x *PagedCrossReferences_PageSearchIndex_Pages) Reset() {
+	*x = PagedCrossReferences_PageSearchIndex_Pages{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *PagedCrossReferences_Group) GetScopedReference() []*PagedCrossReferences_ScopedReference {
+func (x *PagedCrossReferences_PageSearchIndex_Pages) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedCrossReferences_PageSearchIndex_Pages) ProtoMessage() {}
+
+func (x *PagedCrossReferences_PageSearchIndex_Pages) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedCrossReferences_PageSearchIndex_Pages.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_PageSearchIndex_Pages) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 6, 0}
+}
+
+func (x *PagedCrossReferences_PageSearchIndex_Pages) GetPageIndex() []uint32 {
 	if x != nil {
-		return x.ScopedReference
+		return x.PageIndex
 	}
 	return nil
 }
 
-func (x *PagedCrossReferences_Group) GetFileInfo() []*FileInfo {
+type PagedCrossReferences_PageSearchIndex_Postings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index map[uint32]*PagedCrossReferences_PageSearchIndex_Pages `protobuf:"bytes,1,rep,name=index,proto3" json:"index,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *PagedCrossReferences_PageSearchIndex_Postings) Reset() {
+	*x = PagedCrossReferences_PageSearchIndex_Postings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PagedCrossReferences_PageSearchIndex_Postings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagedCrossReferences_PageSearchIndex_Postings) ProtoMessage() {}
+
+func (x *PagedCrossReferences_PageSearchIndex_Postings) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagedCrossReferences_PageSearchIndex_Postings.ProtoReflect.Descriptor instead.
+func (*PagedCrossReferences_PageSearchIndex_Postings) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{14, 6, 1}
+}
+
+func (x *PagedCrossReferences_PageSearchIndex_Postings) GetIndex() map[uint32]*PagedCrossReferences_PageSearchIndex_Pages {
 	if x != nil {
-		return x.FileInfo
+		return x.Index
 	}
 	return nil
 }
 
-type PagedCrossReferences_Page struct {
+type FileReferences_Node struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PageKey      string                      `protobuf:"bytes,1,opt,name=page_key,json=pageKey,proto3" json:"page_key,omitempty"`
-	SourceTicket string                      `protobuf:"bytes,2,opt,name=source_ticket,json=sourceTicket,proto3" json:"source_ticket,omitempty"`
-	Group        *PagedCrossReferences_Group `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	Ticket string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Count  int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *PagedCrossReferences_Page) Reset() {
-	*x = PagedCrossReferences_Page{}
+func (x *FileReferences_Node) Reset() {
+	*x = FileReferences_Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[28]
+		mi := &file_kythe_proto_serving_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_Page) String() string {
+func (x *FileReferences_Node) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_Page) ProtoMessage() {}
+func (*FileReferences_Node) ProtoMessage() {}
 
-func (x *PagedCrossReferences_Page) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[28]
+func (x *FileReferences_Node) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2360,60 +3761,108 @@ func (x *PagedCrossReferences_Page) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_Page.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_Page) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 4}
+// Deprecated: Use FileReferences_Node.ProtoReflect.Descriptor instead.
+func (*FileReferences_Node) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{18, 0}
 }
 
-func (x *PagedCrossReferences_Page) GetPageKey() string {
+func (x *FileReferences_Node) GetTicket() string {
 	if x != nil {
-		return x.PageKey
+		return x.Ticket
 	}
 	return ""
 }
 
-func (x *PagedCrossReferences_Page) GetSourceTicket() string {
+func (x *FileReferences_Node) GetCount() int32 {
 	if x != nil {
-		return x.SourceTicket
+		return x.Count
+	}
+	return 0
+}
+
+type FileRelations_Relation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind   string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+}
+
+func (x *FileRelations_Relation) Reset() {
+	*x = FileRelations_Relation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_serving_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileRelations_Relation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileRelations_Relation) ProtoMessage() {}
+
+func (x *FileRelations_Relation) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileRelations_Relation.ProtoReflect.Descriptor instead.
+func (*FileRelations_Relation) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{19, 0}
+}
+
+func (x *FileRelations_Relation) GetTicket() string {
+	if x != nil {
+		return x.Ticket
 	}
 	return ""
 }
 
-func (x *PagedCrossReferences_Page) GetGroup() *PagedCrossReferences_Group {
+func (x *FileRelations_Relation) GetKind() string {
 	if x != nil {
-		return x.Group
+		return x.Kind
 	}
-	return nil
+	return ""
 }
 
-type PagedCrossReferences_PageIndex struct {
+type ContainerMembers_Member struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Kind        string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
-	Count       int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
-	PageKey     string `protobuf:"bytes,3,opt,name=page_key,json=pageKey,proto3" json:"page_key,omitempty"`
-	BuildConfig string `protobuf:"bytes,4,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	Ticket      string          `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Subkind     string          `protobuf:"bytes,2,opt,name=subkind,proto3" json:"subkind,omitempty"`
+	DisplayName string          `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Definition  *ExpandedAnchor `protobuf:"bytes,4,opt,name=definition,proto3" json:"definition,omitempty"`
 }
 
-func (x *PagedCrossReferences_PageIndex) Reset() {
-	*x = PagedCrossReferences_PageIndex{}
+func (x *ContainerMembers_Member) Reset() {
+	*x = ContainerMembers_Member{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[29]
+		mi := &file_kythe_proto_serving_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_PageIndex) String() string {
+func (x *ContainerMembers_Member) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_PageIndex) ProtoMessage() {}
+func (*ContainerMembers_Member) ProtoMessage() {}
 
-func (x *PagedCrossReferences_PageIndex) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[29]
+func (x *ContainerMembers_Member) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2424,67 +3873,65 @@ func (x *PagedCrossReferences_PageIndex) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_PageIndex.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_PageIndex) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 5}
+// Deprecated: Use ContainerMembers_Member.ProtoReflect.Descriptor instead.
+func (*ContainerMembers_Member) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{21, 0}
 }
 
-func (x *PagedCrossReferences_PageIndex) GetKind() string {
+func (x *ContainerMembers_Member) GetTicket() string {
 	if x != nil {
-		return x.Kind
+		return x.Ticket
 	}
 	return ""
 }
 
-func (x *PagedCrossReferences_PageIndex) GetCount() int32 {
+func (x *ContainerMembers_Member) GetSubkind() string {
 	if x != nil {
-		return x.Count
+		return x.Subkind
 	}
-	return 0
+	return ""
 }
 
-func (x *PagedCrossReferences_PageIndex) GetPageKey() string {
+func (x *ContainerMembers_Member) GetDisplayName() string {
 	if x != nil {
-		return x.PageKey
+		return x.DisplayName
 	}
 	return ""
 }
 
-func (x *PagedCrossReferences_PageIndex) GetBuildConfig() string {
+func (x *ContainerMembers_Member) GetDefinition() *ExpandedAnchor {
 	if x != nil {
-		return x.BuildConfig
+		return x.Definition
 	}
-	return ""
+	return nil
 }
 
-type PagedCrossReferences_PageSearchIndex struct {
+type NodeFactHashes_Fact struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ByCorpus       *PagedCrossReferences_PageSearchIndex_Postings `protobuf:"bytes,1,opt,name=by_corpus,json=byCorpus,proto3" json:"by_corpus,omitempty"`
-	ByRoot         *PagedCrossReferences_PageSearchIndex_Postings `protobuf:"bytes,2,opt,name=by_root,json=byRoot,proto3" json:"by_root,omitempty"`
-	ByPath         *PagedCrossReferences_PageSearchIndex_Postings `protobuf:"bytes,3,opt,name=by_path,json=byPath,proto3" json:"by_path,omitempty"`
-	ByResolvedPath *PagedCrossReferences_PageSearchIndex_Postings `protobuf:"bytes,4,opt,name=by_resolved_path,json=byResolvedPath,proto3" json:"by_resolved_path,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Hash []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (x *PagedCrossReferences_PageSearchIndex) Reset() {
-	*x = PagedCrossReferences_PageSearchIndex{}
+func (x *NodeFactHashes_Fact) Reset() {
+	*x = NodeFactHashes_Fact{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[30]
+		mi := &file_kythe_proto_serving_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_PageSearchIndex) String() string {
+func (x *NodeFactHashes_Fact) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_PageSearchIndex) ProtoMessage() {}
+func (*NodeFactHashes_Fact) ProtoMessage() {}
 
-func (x *PagedCrossReferences_PageSearchIndex) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[30]
+func (x *NodeFactHashes_Fact) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2495,64 +3942,52 @@ func (x *PagedCrossReferences_PageSearchIndex) ProtoReflect() protoreflect.Messa
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_PageSearchIndex.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_PageSearchIndex) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 6}
-}
-
-func (x *PagedCrossReferences_PageSearchIndex) GetByCorpus() *PagedCrossReferences_PageSearchIndex_Postings {
-	if x != nil {
-		return x.ByCorpus
-	}
-	return nil
-}
-
-func (x *PagedCrossReferences_PageSearchIndex) GetByRoot() *PagedCrossReferences_PageSearchIndex_Postings {
-	if x != nil {
-		return x.ByRoot
-	}
-	return nil
+// Deprecated: Use NodeFactHashes_Fact.ProtoReflect.Descriptor instead.
+func (*NodeFactHashes_Fact) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{23, 0}
 }
 
-func (x *PagedCrossReferences_PageSearchIndex) GetByPath() *PagedCrossReferences_PageSearchIndex_Postings {
+func (x *NodeFactHashes_Fact) GetName() string {
 	if x != nil {
-		return x.ByPath
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *PagedCrossReferences_PageSearchIndex) GetByResolvedPath() *PagedCrossReferences_PageSearchIndex_Postings {
+func (x *NodeFactHashes_Fact) GetHash() []byte {
 	if x != nil {
-		return x.ByResolvedPath
+		return x.Hash
 	}
 	return nil
 }
 
-type PagedCrossReferences_PageSearchIndex_Pages struct {
+type ContainerRollup_Target struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PageIndex []uint32 `protobuf:"varint,1,rep,packed,name=page_index,json=pageIndex,proto3" json:"page_index,omitempty"`
+	Ticket         string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind           string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	ReferenceCount int64  `protobuf:"varint,3,opt,name=reference_count,json=referenceCount,proto3" json:"reference_count,omitempty"`
 }
 
-func (x *PagedCrossReferences_PageSearchIndex_Pages) Reset() {
-	*x = PagedCrossReferences_PageSearchIndex_Pages{}
+func (x *ContainerRollup_Target) Reset() {
+	*x = ContainerRollup_Target{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[31]
+		mi := &file_kythe_proto_serving_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_PageSearchIndex_Pages) String() string {
+func (x *ContainerRollup_Target) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_PageSearchIndex_Pages) ProtoMessage() {}
+func (*ContainerRollup_Target) ProtoMessage() {}
 
-func (x *PagedCrossReferences_PageSearchIndex_Pages) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[31]
+func (x *ContainerRollup_Target) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2563,43 +3998,62 @@ func (x *PagedCrossReferences_PageSearchIndex_Pages) ProtoReflect() protoreflect
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_PageSearchIndex_Pages.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_PageSearchIndex_Pages) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 6, 0}
+// Deprecated: Use ContainerRollup_Target.ProtoReflect.Descriptor instead.
+func (*ContainerRollup_Target) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{25, 0}
 }
 
-func (x *PagedCrossReferences_PageSearchIndex_Pages) GetPageIndex() []uint32 {
+func (x *ContainerRollup_Target) GetTicket() string {
 	if x != nil {
-		return x.PageIndex
+		return x.Ticket
 	}
-	return nil
+	return ""
 }
 
-type PagedCrossReferences_PageSearchIndex_Postings struct {
+func (x *ContainerRollup_Target) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ContainerRollup_Target) GetReferenceCount() int64 {
+	if x != nil {
+		return x.ReferenceCount
+	}
+	return 0
+}
+
+type FileOutline_Symbol struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Index map[uint32]*PagedCrossReferences_PageSearchIndex_Pages `protobuf:"bytes,1,rep,name=index,proto3" json:"index,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Ticket      string          `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind        string          `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Subkind     string          `protobuf:"bytes,3,opt,name=subkind,proto3" json:"subkind,omitempty"`
+	DisplayName string          `protobuf:"bytes,4,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Definition  *ExpandedAnchor `protobuf:"bytes,5,opt,name=definition,proto3" json:"definition,omitempty"`
+	Parent      []string        `protobuf:"bytes,6,rep,name=parent,proto3" json:"parent,omitempty"`
 }
 
-func (x *PagedCrossReferences_PageSearchIndex_Postings) Reset() {
-	*x = PagedCrossReferences_PageSearchIndex_Postings{}
+func (x *FileOutline_Symbol) Reset() {
+	*x = FileOutline_Symbol{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[32]
+		mi := &file_kythe_proto_serving_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PagedCrossReferences_PageSearchIndex_Postings) String() string {
+func (x *FileOutline_Symbol) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PagedCrossReferences_PageSearchIndex_Postings) ProtoMessage() {}
+func (*FileOutline_Symbol) ProtoMessage() {}
 
-func (x *PagedCrossReferences_PageSearchIndex_Postings) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[32]
+func (x *FileOutline_Symbol) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_serving_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2610,14 +4064,49 @@ func (x *PagedCrossReferences_PageSearchIndex_Postings) ProtoReflect() protorefl
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PagedCrossReferences_PageSearchIndex_Postings.ProtoReflect.Descriptor instead.
-func (*PagedCrossReferences_PageSearchIndex_Postings) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{13, 6, 1}
+// Deprecated: Use FileOutline_Symbol.ProtoReflect.Descriptor instead.
+func (*FileOutline_Symbol) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{26, 0}
 }
 
-func (x *PagedCrossReferences_PageSearchIndex_Postings) GetIndex() map[uint32]*PagedCrossReferences_PageSearchIndex_Pages {
+func (x *FileOutline_Symbol) GetTicket() string {
 	if x != nil {
-		return x.Index
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *FileOutline_Symbol) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *FileOutline_Symbol) GetSubkind() string {
+	if x != nil {
+		return x.Subkind
+	}
+	return ""
+}
+
+func (x *FileOutline_Symbol) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *FileOutline_Symbol) GetDefinition() *ExpandedAnchor {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+func (x *FileOutline_Symbol) GetParent() []string {
+	if x != nil {
+		return x.Parent
 	}
 	return nil
 }
@@ -2635,7 +4124,7 @@ type IdentifierMatch_Node struct {
 func (x *IdentifierMatch_Node) Reset() {
 	*x = IdentifierMatch_Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_serving_proto_msgTypes[34]
+		mi := &file_kythe_proto_serving_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2648,7 +4137,7 @@ func (x *IdentifierMatch_Node) String() string {
 func (*IdentifierMatch_Node) ProtoMessage() {}
 
 func (x *IdentifierMatch_Node) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_serving_proto_msgTypes[34]
+	mi := &file_kythe_proto_serving_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2661,7 +4150,7 @@ func (x *IdentifierMatch_Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IdentifierMatch_Node.ProtoReflect.Descriptor instead.
 func (*IdentifierMatch_Node) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{15, 0}
+	return file_kythe_proto_serving_proto_rawDescGZIP(), []int{29, 0}
 }
 
 func (x *IdentifierMatch_Node) GetTicket() string {
@@ -2691,63 +4180,73 @@ var file_kythe_proto_serving_proto_rawDesc = []byte{
 	0x0a, 0x19, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65,
 	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x6b, 0x79, 0x74,
 	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67,
-	0x1a, 0x18, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb6, 0x01, 0x0a, 0x04, 0x4e,
-	0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x66,
-	0x61, 0x63, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46,
-	0x61, 0x63, 0x74, 0x52, 0x04, 0x66, 0x61, 0x63, 0x74, 0x12, 0x54, 0x0a, 0x13, 0x64, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70,
-	0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x12, 0x64, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72,
-	0x61, 0x6e, 0x6b, 0x22, 0xc8, 0x01, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x31, 0x0a, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
-	0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x31, 0x0a,
-	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x12, 0x2c, 0x0a, 0x04, 0x66, 0x61, 0x63, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
-	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x61, 0x63, 0x74, 0x52, 0x04, 0x66, 0x61, 0x63, 0x74, 0x22, 0xad,
-	0x01, 0x0a, 0x09, 0x45, 0x64, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04,
-	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
-	0x12, 0x37, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x18, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x87, 0x02, 0x0a, 0x04,
+	0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x04,
+	0x66, 0x61, 0x63, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x46, 0x61, 0x63, 0x74, 0x52, 0x04, 0x66, 0x61, 0x63, 0x74, 0x12, 0x54, 0x0a, 0x13, 0x64, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78,
+	0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x12, 0x64, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xc8, 0x01, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x31,
+	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
 	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x2e, 0x45,
-	0x64, 0x67, 0x65, 0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x1a, 0x53, 0x0a, 0x04, 0x45, 0x64, 0x67,
-	0x65, 0x12, 0x31, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x22, 0xdb,
-	0x01, 0x0a, 0x0c, 0x50, 0x61, 0x67, 0x65, 0x64, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65, 0x74, 0x12,
-	0x31, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x12,
+	0x31, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x23, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x5f, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18,
-	0x01, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x3d, 0x0a,
-	0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x62, 0x0a, 0x09,
-	0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x64, 0x67,
-	0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x64,
-	0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x64, 0x67, 0x65,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65,
-	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79,
+	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x66, 0x61, 0x63, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x46, 0x61, 0x63, 0x74, 0x52, 0x04, 0x66, 0x61, 0x63, 0x74,
+	0x22, 0xad, 0x01, 0x0a, 0x09, 0x45, 0x64, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x37, 0x0a, 0x04, 0x65, 0x64, 0x67, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x04, 0x65, 0x64, 0x67, 0x65, 0x1a, 0x53, 0x0a, 0x04, 0x45,
+	0x64, 0x67, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c,
+	0x22, 0xdb, 0x01, 0x0a, 0x0c, 0x50, 0x61, 0x67, 0x65, 0x64, 0x45, 0x64, 0x67, 0x65, 0x53, 0x65,
+	0x74, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x23, 0x0a, 0x0b, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42,
+	0x02, 0x18, 0x01, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12,
+	0x3d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x8f,
+	0x01, 0x0a, 0x09, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09,
+	0x65, 0x64, 0x67, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x65, 0x64, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x64, 0x67,
+	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65,
+	0x64, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x67, 0x65,
+	0x4b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65,
 	0x22, 0x8b, 0x01, 0x0a, 0x08, 0x45, 0x64, 0x67, 0x65, 0x50, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a,
 	0x08, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x07, 0x70, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6f, 0x75, 0x72,
@@ -2798,7 +4297,7 @@ var file_kythe_proto_serving_proto_rawDesc = []byte{
 	0x6e, 0x67, 0x12, 0x31, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
 	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0xdc, 0x01, 0x0a, 0x09, 0x52, 0x61, 0x77, 0x41, 0x6e, 0x63,
+	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0xfe, 0x01, 0x0a, 0x09, 0x52, 0x61, 0x77, 0x41, 0x6e, 0x63,
 	0x68, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73,
 	0x74, 0x61, 0x72, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
@@ -2812,340 +4311,537 @@ var file_kythe_proto_serving_proto_rawDesc = []byte{
 	0x45, 0x6e, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x12, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xdc, 0x02, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65,
-	0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
-	0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52,
-	0x04, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12,
-	0x3b, 0x0a, 0x0c, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x61, 0x6e, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52,
-	0x0b, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x2f, 0x0a, 0x13,
-	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x62, 0x75, 0x69, 0x6c, 0x64,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
-	0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x6e,
-	0x6b, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x4a, 0x04, 0x08,
-	0x03, 0x10, 0x04, 0x22, 0xbe, 0x01, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x3f, 0x0a, 0x0b, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75,
-	0x73, 0x50, 0x61, 0x74, 0x68, 0x52, 0x0a, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74,
-	0x68, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a,
-	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x76,
-	0x69, 0x65, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69,
-	0x63, 0x74, 0x65, 0x64, 0x22, 0x8b, 0x08, 0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x63,
-	0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x22, 0x99, 0x03, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x61, 0x6e,
+	0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x73, 0x70, 0x61,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61,
+	0x6e, 0x52, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70,
+	0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65,
+	0x74, 0x12, 0x3b, 0x0a, 0x0c, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x61,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61,
+	0x6e, 0x52, 0x0b, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x2f,
+	0x0a, 0x13, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c,
-	0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x64, 0x65, 0x63, 0x6f, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e,
-	0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x65,
-	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x52, 0x0a, 0x12, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78,
-	0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x11, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x56, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69,
-	0x64, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4a, 0x04, 0x08, 0x03,
+	0x10, 0x04, 0x22, 0xdc, 0x01, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x3f, 0x0a, 0x0b, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73,
+	0x50, 0x61, 0x74, 0x68, 0x52, 0x0a, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x48, 0x61, 0x73, 0x68, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x69,
+	0x65, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63,
+	0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x22, 0xf8, 0x0b, 0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x64, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46,
-	0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f,
-	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f,
-	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e,
-	0x6f, 0x73, 0x74, 0x69, 0x63, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x64, 0x69, 0x61,
-	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x67,
-	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x69,
-	0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x66, 0x69,
-	0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0xc4, 0x01, 0x0a, 0x0a, 0x44, 0x65, 0x63, 0x6f, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x61, 0x77, 0x41,
-	0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x12, 0x0a,
-	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74,
-	0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x1a, 0xb2, 0x02,
-	0x0a, 0x08, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x76,
-	0x65, 0x72, 0x72, 0x69, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x76,
-	0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x12, 0x33, 0x0a, 0x15, 0x6f, 0x76,
-	0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x6f, 0x76, 0x65, 0x72, 0x72,
-	0x69, 0x64, 0x64, 0x65, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x46, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x32, 0x2e,
-	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4b, 0x69, 0x6e,
-	0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65,
-	0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
-	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x22,
-	0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52, 0x52, 0x49,
-	0x44, 0x45, 0x53, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x54, 0x45, 0x4e, 0x44, 0x53,
-	0x10, 0x01, 0x22, 0x84, 0x14, 0x0a, 0x14, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73,
-	0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
-	0x65, 0x72, 0x67, 0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x09, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x57, 0x69, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
-	0x3a, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52,
-	0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x45, 0x0a, 0x05, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74,
+	0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44,
+	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x65, 0x63, 0x6f, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x52, 0x0a, 0x12, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e,
+	0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x11, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x56, 0x0a, 0x0f,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x44, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x79, 0x74,
 	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67,
-	0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72,
-	0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x12, 0x52, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67,
-	0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
-	0x73, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x09, 0x70, 0x61, 0x67,
-	0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2d, 0x0a, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
-	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
-	0x42, 0x02, 0x18, 0x01, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72,
-	0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
-	0x65, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x6e, 0x63, 0x6f, 0x6d,
-	0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c,
-	0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x65, 0x0a, 0x11,
-	0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61,
-	0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x73, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x52, 0x0f, 0x70, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x1a, 0x56, 0x0a, 0x0b, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x1a, 0xfd, 0x01, 0x0a, 0x0f,
-	0x53, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12,
-	0x39, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
-	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63,
-	0x68, 0x6f, 0x72, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65,
-	0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x53, 0x63, 0x6f, 0x70,
-	0x65, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61,
-	0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b,
-	0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65,
-	0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e,
-	0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72,
-	0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x1a, 0xf6, 0x01, 0x0a, 0x06,
-	0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70,
-	0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x61, 0x6c,
-	0x6c, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x5f,
-	0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x65,
-	0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x0d,
-	0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x69, 0x74, 0x65, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61,
-	0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x08, 0x63, 0x61, 0x6c, 0x6c,
-	0x73, 0x69, 0x74, 0x65, 0x1a, 0xc1, 0x03, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12,
-	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
-	0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3b, 0x0a, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61,
-	0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x63, 0x68,
-	0x6f, 0x72, 0x12, 0x58, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f,
-	0x64, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x5f, 0x0a, 0x14, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x62, 0x79, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x52, 0x12, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64,
+	0x65, 0x6e, 0x42, 0x79, 0x12, 0x74, 0x0a, 0x16, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44,
+	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x14, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x5c, 0x0a, 0x0f, 0x64, 0x65,
+	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65,
+	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x64, 0x65, 0x63, 0x6f, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x1a, 0xc4, 0x01, 0x0a, 0x0a, 0x44, 0x65, 0x63,
+	0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x61,
+	0x77, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x6d, 0x61,
+	0x6e, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x1a,
+	0xb2, 0x02, 0x0a, 0x08, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x12, 0x33, 0x0a, 0x15,
+	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x6f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x46, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x32, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4b,
+	0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x22, 0x22, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52,
+	0x52, 0x49, 0x44, 0x45, 0x53, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x54, 0x45, 0x4e,
+	0x44, 0x53, 0x10, 0x01, 0x1a, 0x47, 0x0a, 0x19, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x6d, 0x0a,
+	0x0e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x81, 0x01, 0x0a,
+	0x13, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x50, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12,
+	0x4f, 0x0a, 0x0a, 0x64, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65,
+	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0xb2, 0x14, 0x0a, 0x14, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x72,
+	0x67, 0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6d,
+	0x65, 0x72, 0x67, 0x65, 0x57, 0x69, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x3a, 0x0a,
+	0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x45, 0x0a, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50,
 	0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
-	0x63, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52,
-	0x0b, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x48, 0x0a, 0x06,
-	0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x06,
-	0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x64, 0x0a, 0x10, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x64,
-	0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x39, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73,
-	0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x6f, 0x70,
-	0x65, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0f, 0x73, 0x63, 0x6f,
-	0x70, 0x65, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x09,
-	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08,
-	0x66, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x8d, 0x01, 0x0a, 0x04, 0x50, 0x61, 0x67,
-	0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65,
-	0x74, 0x12, 0x45, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73,
-	0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x1a, 0x73, 0x0a, 0x09, 0x50, 0x61, 0x67, 0x65,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
-	0x19, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75,
-	0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0xb3, 0x05,
-	0x0a, 0x0f, 0x50, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x5f, 0x0a, 0x09, 0x62, 0x79, 0x5f, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x42, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x52, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64,
 	0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e,
-	0x50, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x2e,
-	0x50, 0x6f, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x62, 0x79, 0x43, 0x6f, 0x72, 0x70,
-	0x75, 0x73, 0x12, 0x5b, 0x0a, 0x07, 0x62, 0x79, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x42, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43,
-	0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x50,
-	0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x50,
-	0x6f, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x06, 0x62, 0x79, 0x52, 0x6f, 0x6f, 0x74, 0x12,
-	0x5b, 0x0a, 0x07, 0x62, 0x79, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x2d, 0x0a, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02,
+	0x18, 0x01, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61,
+	0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x65, 0x0a, 0x11, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65,
+	0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x2e, 0x50, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x52, 0x0f, 0x70, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x1a, 0x56, 0x0a, 0x0b, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x2d, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x1a, 0xfd, 0x01, 0x0a, 0x0f, 0x53, 0x63,
+	0x6f, 0x70, 0x65, 0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a,
+	0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x6d, 0x61,
+	0x6e, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12,
+	0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e,
+	0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x09,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x1a, 0xf6, 0x01, 0x0a, 0x06, 0x43, 0x61,
+	0x6c, 0x6c, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e,
+	0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65,
+	0x72, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x5f, 0x63, 0x61,
+	0x6c, 0x6c, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x65, 0x6d, 0x61,
+	0x6e, 0x74, 0x69, 0x63, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61,
+	0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x3f, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x69, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64,
+	0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x08, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x69,
+	0x74, 0x65, 0x1a, 0xc1, 0x03, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x3b, 0x0a, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64,
+	0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x12, 0x58, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67,
+	0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x73, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0b, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x48, 0x0a, 0x06, 0x63, 0x61,
+	0x6c, 0x6c, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67,
+	0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x06, 0x63, 0x61,
+	0x6c, 0x6c, 0x65, 0x72, 0x12, 0x64, 0x0a, 0x10, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x5f, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x64,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0f, 0x73, 0x63, 0x6f, 0x70, 0x65,
+	0x64, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x8d, 0x01, 0x0a, 0x04, 0x50, 0x61, 0x67, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x45, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x1a, 0xa0, 0x01, 0x0a, 0x09, 0x50, 0x61, 0x67, 0x65, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x70, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2b, 0x0a, 0x11,
+	0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x1a, 0xb3, 0x05, 0x0a, 0x0f, 0x50, 0x61,
+	0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x5f, 0x0a,
+	0x09, 0x62, 0x79, 0x5f, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x42, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
 	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73,
 	0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x67, 0x65,
 	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x50, 0x6f, 0x73, 0x74,
-	0x69, 0x6e, 0x67, 0x73, 0x52, 0x06, 0x62, 0x79, 0x50, 0x61, 0x74, 0x68, 0x12, 0x6c, 0x0a, 0x10,
-	0x62, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x42, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67,
-	0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
-	0x73, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x0e, 0x62, 0x79, 0x52, 0x65,
-	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x50, 0x61, 0x74, 0x68, 0x1a, 0x2a, 0x0a, 0x05, 0x50, 0x61,
-	0x67, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x42, 0x02, 0x10, 0x01, 0x52, 0x09, 0x70, 0x61, 0x67,
-	0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x1a, 0xea, 0x01, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x74, 0x69,
-	0x6e, 0x67, 0x73, 0x12, 0x63, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x4d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x62, 0x79, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x12, 0x5b,
+	0x0a, 0x07, 0x62, 0x79, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x42, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x06, 0x62, 0x79, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x5b, 0x0a, 0x07, 0x62,
+	0x79, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x42, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x52, 0x06, 0x62, 0x79, 0x50, 0x61, 0x74, 0x68, 0x12, 0x6c, 0x0a, 0x10, 0x62, 0x79, 0x5f, 0x72,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x42, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72,
 	0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x50, 0x61,
 	0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x50, 0x6f,
-	0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x1a, 0x79, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x55, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61,
-	0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x73, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x4a, 0x04, 0x08, 0x0a, 0x10, 0x0b, 0x22, 0xa9, 0x02, 0x0a, 0x08, 0x44, 0x6f,
-	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x45,
-	0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65,
-	0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x74, 0x65, 0x78,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x61, 0x77, 0x54, 0x65, 0x78, 0x74,
-	0x12, 0x2c, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
-	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x21,
-	0x0a, 0x0c, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x05,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65,
-	0x74, 0x12, 0x2d, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65,
-	0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x62,
-	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x65, 0x64, 0x42, 0x79, 0x22, 0xf4, 0x01, 0x0a, 0x0f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x61,
-	0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a,
-	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x79,
+	0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x0e, 0x62, 0x79, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x50, 0x61, 0x74, 0x68, 0x1a, 0x2a, 0x0a, 0x05, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12,
+	0x21, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0d, 0x42, 0x02, 0x10, 0x01, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x1a, 0xea, 0x01, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x63, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4d,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x1a, 0x79, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x55, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x64, 0x43,
+	0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x2e, 0x50,
+	0x61, 0x67, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x2e, 0x50,
+	0x61, 0x67, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a,
+	0x04, 0x08, 0x0a, 0x10, 0x0b, 0x22, 0xa9, 0x02, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61,
+	0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x61, 0x77, 0x54, 0x65, 0x78, 0x74, 0x12, 0x2c, 0x0a, 0x04,
+	0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68,
+	0x69, 0x6c, 0x64, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2d, 0x0a,
+	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6b, 0x79,
 	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e,
-	0x67, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x1a, 0x5e, 0x0a, 0x04,
-	0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09,
-	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f, 0x64,
-	0x65, 0x5f, 0x73, 0x75, 0x62, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x8e, 0x01, 0x0a,
-	0x09, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69,
-	0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x69, 0x63,
-	0x6b, 0x65, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76,
-	0x65, 0x73, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x2e, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
-	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x52, 0x45, 0x4e, 0x54, 0x53, 0x10, 0x01, 0x12,
-	0x0c, 0x0a, 0x08, 0x43, 0x48, 0x49, 0x4c, 0x44, 0x52, 0x45, 0x4e, 0x10, 0x02, 0x22, 0x8b, 0x01,
-	0x0a, 0x09, 0x43, 0x61, 0x6c, 0x6c, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x74,
+	0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x42,
+	0x79, 0x22, 0x39, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3c, 0x0a, 0x0a,
+	0x46, 0x69, 0x6c, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x98, 0x01, 0x0a, 0x0e, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c,
+	0x65, 0x12, 0x3c, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x1a,
+	0x34, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xed, 0x01, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x47, 0x0a, 0x08, 0x6f,
+	0x75, 0x74, 0x67, 0x6f, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6f, 0x75, 0x74, 0x67,
+	0x6f, 0x69, 0x6e, 0x67, 0x12, 0x47, 0x0a, 0x08, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x1a, 0x36, 0x0a,
+	0x08, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x59, 0x0a, 0x0b, 0x43, 0x61, 0x6c, 0x6c, 0x44, 0x65, 0x67,
+	0x72, 0x65, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63,
+	0x61, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x73,
+	0x22, 0xaf, 0x02, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x44, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x2e, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0xa2, 0x01,
+	0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x43, 0x0a,
+	0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64,
+	0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x58, 0x0a, 0x0f, 0x45, 0x78, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x69, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x61, 0x73,
+	0x68, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x68,
+	0x61, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x96, 0x01, 0x0a,
+	0x0e, 0x4e, 0x6f, 0x64, 0x65, 0x46, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x3c, 0x0a, 0x04, 0x66, 0x61, 0x63, 0x74, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x46, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x2e, 0x46, 0x61, 0x63, 0x74, 0x52,
+	0x04, 0x66, 0x61, 0x63, 0x74, 0x1a, 0x2e, 0x0a, 0x04, 0x46, 0x61, 0x63, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x31, 0x0a, 0x14, 0x46, 0x61, 0x63, 0x74, 0x48, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x22, 0xfc, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x12, 0x1c, 0x0a, 0x09,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x43, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x1a, 0x5d, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x27,
+	0x0a, 0x0f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb3, 0x02, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x65,
+	0x4f, 0x75, 0x74, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e,
+	0x67, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x53, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x1a, 0xce, 0x01, 0x0a,
+	0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x43, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e,
+	0x64, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x3b, 0x0a,
+	0x0b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x2a, 0x0a, 0x10, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0xf4, 0x01, 0x0a, 0x0f, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75,
+	0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3d,
+	0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x6e, 0x67, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x1a, 0x5e, 0x0a,
+	0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x8e, 0x01,
+	0x0a, 0x09, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74,
 	0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x69,
 	0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x67, 0x72,
-	0x61, 0x70, 0x68, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x2b,
+	0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x73, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x2e,
 	0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
-	0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x41, 0x4c, 0x4c, 0x45, 0x52, 0x10, 0x01, 0x12,
-	0x0a, 0x0a, 0x06, 0x43, 0x41, 0x4c, 0x4c, 0x45, 0x45, 0x10, 0x02, 0x22, 0xa2, 0x02, 0x0a, 0x04,
-	0x44, 0x69, 0x66, 0x66, 0x12, 0x23, 0x0a, 0x0b, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x6c, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0a, 0x73,
-	0x70, 0x61, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x3f, 0x0a, 0x09, 0x73, 0x70, 0x61,
-	0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x6e, 0x67, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x42, 0x02, 0x10, 0x01,
-	0x52, 0x08, 0x73, 0x70, 0x61, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x0d, 0x73, 0x70,
-	0x61, 0x6e, 0x5f, 0x6e, 0x65, 0x77, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x05, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0c, 0x73, 0x70, 0x61, 0x6e, 0x4e, 0x65, 0x77, 0x6c, 0x69,
-	0x6e, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x12, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x66, 0x69, 0x72, 0x73,
-	0x74, 0x5f, 0x6e, 0x65, 0x77, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x42,
-	0x02, 0x10, 0x01, 0x52, 0x10, 0x73, 0x70, 0x61, 0x6e, 0x46, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x65,
-	0x77, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2e, 0x0a, 0x11, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x6c, 0x61,
-	0x73, 0x74, 0x5f, 0x6e, 0x65, 0x77, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28, 0x05,
-	0x42, 0x02, 0x10, 0x01, 0x52, 0x0f, 0x73, 0x70, 0x61, 0x6e, 0x4c, 0x61, 0x73, 0x74, 0x4e, 0x65,
-	0x77, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x29, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a,
-	0x05, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x4e, 0x53, 0x45,
-	0x52, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02,
-	0x42, 0x33, 0x0a, 0x1f, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x64,
-	0x65, 0x76, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x5a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x6f, 0x5f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x52, 0x45, 0x4e, 0x54, 0x53, 0x10, 0x01,
+	0x12, 0x0c, 0x0a, 0x08, 0x43, 0x48, 0x49, 0x4c, 0x44, 0x52, 0x45, 0x4e, 0x10, 0x02, 0x22, 0x8b,
+	0x01, 0x0a, 0x09, 0x43, 0x61, 0x6c, 0x6c, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x18, 0x0a, 0x07,
+	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22,
+	0x2b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x41, 0x4c, 0x4c, 0x45, 0x52, 0x10, 0x01,
+	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x41, 0x4c, 0x4c, 0x45, 0x45, 0x10, 0x02, 0x22, 0xa2, 0x02, 0x0a,
+	0x04, 0x44, 0x69, 0x66, 0x66, 0x12, 0x23, 0x0a, 0x0b, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0a,
+	0x73, 0x70, 0x61, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x3f, 0x0a, 0x09, 0x73, 0x70,
+	0x61, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1e, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x6e, 0x67, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x42, 0x02, 0x10,
+	0x01, 0x52, 0x08, 0x73, 0x70, 0x61, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x0d, 0x73,
+	0x70, 0x61, 0x6e, 0x5f, 0x6e, 0x65, 0x77, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x05, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0c, 0x73, 0x70, 0x61, 0x6e, 0x4e, 0x65, 0x77, 0x6c,
+	0x69, 0x6e, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x12, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x5f, 0x6e, 0x65, 0x77, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05,
+	0x42, 0x02, 0x10, 0x01, 0x52, 0x10, 0x73, 0x70, 0x61, 0x6e, 0x46, 0x69, 0x72, 0x73, 0x74, 0x4e,
+	0x65, 0x77, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x2e, 0x0a, 0x11, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x6e, 0x65, 0x77, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x05, 0x42, 0x02, 0x10, 0x01, 0x52, 0x0f, 0x73, 0x70, 0x61, 0x6e, 0x4c, 0x61, 0x73, 0x74, 0x4e,
+	0x65, 0x77, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x29, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09,
+	0x0a, 0x05, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x4e, 0x53,
+	0x45, 0x52, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10,
+	0x02, 0x22, 0x45, 0x0a, 0x0d, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x22, 0x65, 0x0a, 0x0d, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0xac, 0x02, 0x0a, 0x0c, 0x4b, 0x69, 0x6e, 0x64, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x4f, 0x0a, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x73,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x4b, 0x69, 0x6e, 0x64,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x4b, 0x69, 0x6e, 0x64,
+	0x73, 0x12, 0x4f, 0x0a, 0x0a, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x2e, 0x4b, 0x69, 0x6e, 0x64,
+	0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x4b, 0x69, 0x6e,
+	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x65, 0x64, 0x67, 0x65, 0x4b, 0x69, 0x6e,
+	0x64, 0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x4e, 0x6f, 0x64, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x3c, 0x0a, 0x0e, 0x45, 0x64, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x33,
+	0x0a, 0x1f, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x64, 0x65, 0x76,
+	0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x5a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x6f, 0x5f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -3161,7 +4857,7 @@ func file_kythe_proto_serving_proto_rawDescGZIP() []byte {
 }
 
 var file_kythe_proto_serving_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_kythe_proto_serving_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_kythe_proto_serving_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
 var file_kythe_proto_serving_proto_goTypes = []interface{}{
 	(FileDirectory_Kind)(0),                               // 0: kythe.proto.serving.FileDirectory.Kind
 	(FileDecorations_Override_Kind)(0),                    // 1: kythe.proto.serving.FileDecorations.Override.Kind
@@ -3181,103 +4877,148 @@ var file_kythe_proto_serving_proto_goTypes = []interface{}{
 	(*ExpandedAnchor)(nil),                                // 15: kythe.proto.serving.ExpandedAnchor
 	(*FileInfo)(nil),                                      // 16: kythe.proto.serving.FileInfo
 	(*FileDecorations)(nil),                               // 17: kythe.proto.serving.FileDecorations
-	(*PagedCrossReferences)(nil),                          // 18: kythe.proto.serving.PagedCrossReferences
-	(*Document)(nil),                                      // 19: kythe.proto.serving.Document
-	(*IdentifierMatch)(nil),                               // 20: kythe.proto.serving.IdentifierMatch
-	(*Relatives)(nil),                                     // 21: kythe.proto.serving.Relatives
-	(*Callgraph)(nil),                                     // 22: kythe.proto.serving.Callgraph
-	(*Diff)(nil),                                          // 23: kythe.proto.serving.Diff
-	(*EdgeGroup_Edge)(nil),                                // 24: kythe.proto.serving.EdgeGroup.Edge
-	(*FileDirectory_Entry)(nil),                           // 25: kythe.proto.serving.FileDirectory.Entry
-	(*CorpusRoots_Corpus)(nil),                            // 26: kythe.proto.serving.CorpusRoots.Corpus
-	(*FileDecorations_Decoration)(nil),                    // 27: kythe.proto.serving.FileDecorations.Decoration
-	(*FileDecorations_Override)(nil),                      // 28: kythe.proto.serving.FileDecorations.Override
-	(*PagedCrossReferences_RelatedNode)(nil),              // 29: kythe.proto.serving.PagedCrossReferences.RelatedNode
-	(*PagedCrossReferences_ScopedReference)(nil),          // 30: kythe.proto.serving.PagedCrossReferences.ScopedReference
-	(*PagedCrossReferences_Caller)(nil),                   // 31: kythe.proto.serving.PagedCrossReferences.Caller
-	(*PagedCrossReferences_Group)(nil),                    // 32: kythe.proto.serving.PagedCrossReferences.Group
-	(*PagedCrossReferences_Page)(nil),                     // 33: kythe.proto.serving.PagedCrossReferences.Page
-	(*PagedCrossReferences_PageIndex)(nil),                // 34: kythe.proto.serving.PagedCrossReferences.PageIndex
-	(*PagedCrossReferences_PageSearchIndex)(nil),          // 35: kythe.proto.serving.PagedCrossReferences.PageSearchIndex
-	(*PagedCrossReferences_PageSearchIndex_Pages)(nil),    // 36: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Pages
-	(*PagedCrossReferences_PageSearchIndex_Postings)(nil), // 37: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
-	nil,                                  // 38: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings.IndexEntry
-	(*IdentifierMatch_Node)(nil),         // 39: kythe.proto.serving.IdentifierMatch.Node
-	(*common_go_proto.Fact)(nil),         // 40: kythe.proto.common.Fact
-	(*common_go_proto.Span)(nil),         // 41: kythe.proto.common.Span
-	(*common_go_proto.CorpusPath)(nil),   // 42: kythe.proto.common.CorpusPath
-	(*common_go_proto.Hash)(nil),         // 43: kythe.proto.common.Hash
-	(*common_go_proto.Diagnostic)(nil),   // 44: kythe.proto.common.Diagnostic
-	(*common_go_proto.MarkedSource)(nil), // 45: kythe.proto.common.MarkedSource
-	(*common_go_proto.Link)(nil),         // 46: kythe.proto.common.Link
+	(*FileDecorationsPage)(nil),                           // 18: kythe.proto.serving.FileDecorationsPage
+	(*PagedCrossReferences)(nil),                          // 19: kythe.proto.serving.PagedCrossReferences
+	(*Document)(nil),                                      // 20: kythe.proto.serving.Document
+	(*DisplayName)(nil),                                   // 21: kythe.proto.serving.DisplayName
+	(*FileDigest)(nil),                                    // 22: kythe.proto.serving.FileDigest
+	(*FileReferences)(nil),                                // 23: kythe.proto.serving.FileReferences
+	(*FileRelations)(nil),                                 // 24: kythe.proto.serving.FileRelations
+	(*CallDegrees)(nil),                                   // 25: kythe.proto.serving.CallDegrees
+	(*ContainerMembers)(nil),                              // 26: kythe.proto.serving.ContainerMembers
+	(*ExistenceFilter)(nil),                               // 27: kythe.proto.serving.ExistenceFilter
+	(*NodeFactHashes)(nil),                                // 28: kythe.proto.serving.NodeFactHashes
+	(*FactHashesGeneration)(nil),                          // 29: kythe.proto.serving.FactHashesGeneration
+	(*ContainerRollup)(nil),                               // 30: kythe.proto.serving.ContainerRollup
+	(*FileOutline)(nil),                                   // 31: kythe.proto.serving.FileOutline
+	(*TicketAlias)(nil),                                   // 32: kythe.proto.serving.TicketAlias
+	(*TicketIndexEntry)(nil),                              // 33: kythe.proto.serving.TicketIndexEntry
+	(*IdentifierMatch)(nil),                               // 34: kythe.proto.serving.IdentifierMatch
+	(*Relatives)(nil),                                     // 35: kythe.proto.serving.Relatives
+	(*Callgraph)(nil),                                     // 36: kythe.proto.serving.Callgraph
+	(*Diff)(nil),                                          // 37: kythe.proto.serving.Diff
+	(*TableManifest)(nil),                                 // 38: kythe.proto.serving.TableManifest
+	(*TableMetadata)(nil),                                 // 39: kythe.proto.serving.TableMetadata
+	(*KindsSummary)(nil),                                  // 40: kythe.proto.serving.KindsSummary
+	(*EdgeGroup_Edge)(nil),                                // 41: kythe.proto.serving.EdgeGroup.Edge
+	(*FileDirectory_Entry)(nil),                           // 42: kythe.proto.serving.FileDirectory.Entry
+	(*CorpusRoots_Corpus)(nil),                            // 43: kythe.proto.serving.CorpusRoots.Corpus
+	(*FileDecorations_Decoration)(nil),                    // 44: kythe.proto.serving.FileDecorations.Decoration
+	(*FileDecorations_Override)(nil),                      // 45: kythe.proto.serving.FileDecorations.Override
+	nil,                                                   // 46: kythe.proto.serving.FileDecorations.TargetReferenceCountEntry
+	(*FileDecorations_DecorationPage)(nil),                // 47: kythe.proto.serving.FileDecorations.DecorationPage
+	(*PagedCrossReferences_RelatedNode)(nil),              // 48: kythe.proto.serving.PagedCrossReferences.RelatedNode
+	(*PagedCrossReferences_ScopedReference)(nil),          // 49: kythe.proto.serving.PagedCrossReferences.ScopedReference
+	(*PagedCrossReferences_Caller)(nil),                   // 50: kythe.proto.serving.PagedCrossReferences.Caller
+	(*PagedCrossReferences_Group)(nil),                    // 51: kythe.proto.serving.PagedCrossReferences.Group
+	(*PagedCrossReferences_Page)(nil),                     // 52: kythe.proto.serving.PagedCrossReferences.Page
+	(*PagedCrossReferences_PageIndex)(nil),                // 53: kythe.proto.serving.PagedCrossReferences.PageIndex
+	(*PagedCrossReferences_PageSearchIndex)(nil),          // 54: kythe.proto.serving.PagedCrossReferences.PageSearchIndex
+	(*PagedCrossReferences_PageSearchIndex_Pages)(nil),    // 55: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Pages
+	(*PagedCrossReferences_PageSearchIndex_Postings)(nil), // 56: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
+	nil,                                  // 57: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings.IndexEntry
+	(*FileReferences_Node)(nil),          // 58: kythe.proto.serving.FileReferences.Node
+	(*FileRelations_Relation)(nil),       // 59: kythe.proto.serving.FileRelations.Relation
+	(*ContainerMembers_Member)(nil),      // 60: kythe.proto.serving.ContainerMembers.Member
+	(*NodeFactHashes_Fact)(nil),          // 61: kythe.proto.serving.NodeFactHashes.Fact
+	(*ContainerRollup_Target)(nil),       // 62: kythe.proto.serving.ContainerRollup.Target
+	(*FileOutline_Symbol)(nil),           // 63: kythe.proto.serving.FileOutline.Symbol
+	(*IdentifierMatch_Node)(nil),         // 64: kythe.proto.serving.IdentifierMatch.Node
+	nil,                                  // 65: kythe.proto.serving.KindsSummary.NodeKindsEntry
+	nil,                                  // 66: kythe.proto.serving.KindsSummary.EdgeKindsEntry
+	(*common_go_proto.Fact)(nil),         // 67: kythe.proto.common.Fact
+	(*common_go_proto.Span)(nil),         // 68: kythe.proto.common.Span
+	(*timestamppb.Timestamp)(nil),        // 69: google.protobuf.Timestamp
+	(*common_go_proto.CorpusPath)(nil),   // 70: kythe.proto.common.CorpusPath
+	(*common_go_proto.Hash)(nil),         // 71: kythe.proto.common.Hash
+	(*common_go_proto.Diagnostic)(nil),   // 72: kythe.proto.common.Diagnostic
+	(*common_go_proto.MarkedSource)(nil), // 73: kythe.proto.common.MarkedSource
+	(*common_go_proto.Link)(nil),         // 74: kythe.proto.common.Link
 }
 var file_kythe_proto_serving_proto_depIdxs = []int32{
-	40, // 0: kythe.proto.serving.Node.fact:type_name -> kythe.proto.common.Fact
+	67, // 0: kythe.proto.serving.Node.fact:type_name -> kythe.proto.common.Fact
 	15, // 1: kythe.proto.serving.Node.definition_location:type_name -> kythe.proto.serving.ExpandedAnchor
 	5,  // 2: kythe.proto.serving.Edge.source:type_name -> kythe.proto.serving.Node
 	5,  // 3: kythe.proto.serving.Edge.target:type_name -> kythe.proto.serving.Node
-	40, // 4: kythe.proto.serving.Edge.fact:type_name -> kythe.proto.common.Fact
-	24, // 5: kythe.proto.serving.EdgeGroup.edge:type_name -> kythe.proto.serving.EdgeGroup.Edge
+	67, // 4: kythe.proto.serving.Edge.fact:type_name -> kythe.proto.common.Fact
+	41, // 5: kythe.proto.serving.EdgeGroup.edge:type_name -> kythe.proto.serving.EdgeGroup.Edge
 	5,  // 6: kythe.proto.serving.PagedEdgeSet.source:type_name -> kythe.proto.serving.Node
 	7,  // 7: kythe.proto.serving.PagedEdgeSet.group:type_name -> kythe.proto.serving.EdgeGroup
 	9,  // 8: kythe.proto.serving.PagedEdgeSet.page_index:type_name -> kythe.proto.serving.PageIndex
 	7,  // 9: kythe.proto.serving.EdgePage.edges_group:type_name -> kythe.proto.serving.EdgeGroup
-	25, // 10: kythe.proto.serving.FileDirectory.entry:type_name -> kythe.proto.serving.FileDirectory.Entry
-	26, // 11: kythe.proto.serving.CorpusRoots.corpus:type_name -> kythe.proto.serving.CorpusRoots.Corpus
+	42, // 10: kythe.proto.serving.FileDirectory.entry:type_name -> kythe.proto.serving.FileDirectory.Entry
+	43, // 11: kythe.proto.serving.CorpusRoots.corpus:type_name -> kythe.proto.serving.CorpusRoots.Corpus
 	16, // 12: kythe.proto.serving.File.info:type_name -> kythe.proto.serving.FileInfo
-	41, // 13: kythe.proto.serving.ExpandedAnchor.span:type_name -> kythe.proto.common.Span
-	41, // 14: kythe.proto.serving.ExpandedAnchor.snippet_span:type_name -> kythe.proto.common.Span
+	68, // 13: kythe.proto.serving.ExpandedAnchor.span:type_name -> kythe.proto.common.Span
+	68, // 14: kythe.proto.serving.ExpandedAnchor.snippet_span:type_name -> kythe.proto.common.Span
 	16, // 15: kythe.proto.serving.ExpandedAnchor.file_info:type_name -> kythe.proto.serving.FileInfo
-	42, // 16: kythe.proto.serving.FileInfo.corpus_path:type_name -> kythe.proto.common.CorpusPath
-	43, // 17: kythe.proto.serving.FileInfo.hash:type_name -> kythe.proto.common.Hash
-	13, // 18: kythe.proto.serving.FileDecorations.file:type_name -> kythe.proto.serving.File
-	27, // 19: kythe.proto.serving.FileDecorations.decoration:type_name -> kythe.proto.serving.FileDecorations.Decoration
-	5,  // 20: kythe.proto.serving.FileDecorations.target:type_name -> kythe.proto.serving.Node
-	15, // 21: kythe.proto.serving.FileDecorations.target_definitions:type_name -> kythe.proto.serving.ExpandedAnchor
-	28, // 22: kythe.proto.serving.FileDecorations.target_override:type_name -> kythe.proto.serving.FileDecorations.Override
-	44, // 23: kythe.proto.serving.FileDecorations.diagnostic:type_name -> kythe.proto.common.Diagnostic
-	16, // 24: kythe.proto.serving.FileDecorations.file_info:type_name -> kythe.proto.serving.FileInfo
-	5,  // 25: kythe.proto.serving.PagedCrossReferences.source_node:type_name -> kythe.proto.serving.Node
-	32, // 26: kythe.proto.serving.PagedCrossReferences.group:type_name -> kythe.proto.serving.PagedCrossReferences.Group
-	34, // 27: kythe.proto.serving.PagedCrossReferences.page_index:type_name -> kythe.proto.serving.PagedCrossReferences.PageIndex
-	45, // 28: kythe.proto.serving.PagedCrossReferences.marked_source:type_name -> kythe.proto.common.MarkedSource
-	35, // 29: kythe.proto.serving.PagedCrossReferences.page_search_index:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex
-	45, // 30: kythe.proto.serving.Document.marked_source:type_name -> kythe.proto.common.MarkedSource
-	46, // 31: kythe.proto.serving.Document.link:type_name -> kythe.proto.common.Link
-	5,  // 32: kythe.proto.serving.Document.node:type_name -> kythe.proto.serving.Node
-	39, // 33: kythe.proto.serving.IdentifierMatch.node:type_name -> kythe.proto.serving.IdentifierMatch.Node
-	2,  // 34: kythe.proto.serving.Relatives.type:type_name -> kythe.proto.serving.Relatives.Type
-	3,  // 35: kythe.proto.serving.Callgraph.type:type_name -> kythe.proto.serving.Callgraph.Type
-	4,  // 36: kythe.proto.serving.Diff.span_type:type_name -> kythe.proto.serving.Diff.Type
-	5,  // 37: kythe.proto.serving.EdgeGroup.Edge.target:type_name -> kythe.proto.serving.Node
-	0,  // 38: kythe.proto.serving.FileDirectory.Entry.kind:type_name -> kythe.proto.serving.FileDirectory.Kind
-	14, // 39: kythe.proto.serving.FileDecorations.Decoration.anchor:type_name -> kythe.proto.serving.RawAnchor
-	1,  // 40: kythe.proto.serving.FileDecorations.Override.kind:type_name -> kythe.proto.serving.FileDecorations.Override.Kind
-	45, // 41: kythe.proto.serving.FileDecorations.Override.marked_source:type_name -> kythe.proto.common.MarkedSource
-	5,  // 42: kythe.proto.serving.PagedCrossReferences.RelatedNode.node:type_name -> kythe.proto.serving.Node
-	15, // 43: kythe.proto.serving.PagedCrossReferences.ScopedReference.scope:type_name -> kythe.proto.serving.ExpandedAnchor
-	45, // 44: kythe.proto.serving.PagedCrossReferences.ScopedReference.marked_source:type_name -> kythe.proto.common.MarkedSource
-	15, // 45: kythe.proto.serving.PagedCrossReferences.ScopedReference.reference:type_name -> kythe.proto.serving.ExpandedAnchor
-	15, // 46: kythe.proto.serving.PagedCrossReferences.Caller.caller:type_name -> kythe.proto.serving.ExpandedAnchor
-	45, // 47: kythe.proto.serving.PagedCrossReferences.Caller.marked_source:type_name -> kythe.proto.common.MarkedSource
-	15, // 48: kythe.proto.serving.PagedCrossReferences.Caller.callsite:type_name -> kythe.proto.serving.ExpandedAnchor
-	15, // 49: kythe.proto.serving.PagedCrossReferences.Group.anchor:type_name -> kythe.proto.serving.ExpandedAnchor
-	29, // 50: kythe.proto.serving.PagedCrossReferences.Group.related_node:type_name -> kythe.proto.serving.PagedCrossReferences.RelatedNode
-	31, // 51: kythe.proto.serving.PagedCrossReferences.Group.caller:type_name -> kythe.proto.serving.PagedCrossReferences.Caller
-	30, // 52: kythe.proto.serving.PagedCrossReferences.Group.scoped_reference:type_name -> kythe.proto.serving.PagedCrossReferences.ScopedReference
-	16, // 53: kythe.proto.serving.PagedCrossReferences.Group.file_info:type_name -> kythe.proto.serving.FileInfo
-	32, // 54: kythe.proto.serving.PagedCrossReferences.Page.group:type_name -> kythe.proto.serving.PagedCrossReferences.Group
-	37, // 55: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.by_corpus:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
-	37, // 56: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.by_root:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
-	37, // 57: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.by_path:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
-	37, // 58: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.by_resolved_path:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
-	38, // 59: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings.index:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings.IndexEntry
-	36, // 60: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings.IndexEntry.value:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Pages
-	61, // [61:61] is the sub-list for method output_type
-	61, // [61:61] is the sub-list for method input_type
-	61, // [61:61] is the sub-list for extension type_name
-	61, // [61:61] is the sub-list for extension extendee
-	0,  // [0:61] is the sub-list for field type_name
+	69, // 16: kythe.proto.serving.ExpandedAnchor.commit_time:type_name -> google.protobuf.Timestamp
+	70, // 17: kythe.proto.serving.FileInfo.corpus_path:type_name -> kythe.proto.common.CorpusPath
+	71, // 18: kythe.proto.serving.FileInfo.hash:type_name -> kythe.proto.common.Hash
+	13, // 19: kythe.proto.serving.FileDecorations.file:type_name -> kythe.proto.serving.File
+	44, // 20: kythe.proto.serving.FileDecorations.decoration:type_name -> kythe.proto.serving.FileDecorations.Decoration
+	5,  // 21: kythe.proto.serving.FileDecorations.target:type_name -> kythe.proto.serving.Node
+	15, // 22: kythe.proto.serving.FileDecorations.target_definitions:type_name -> kythe.proto.serving.ExpandedAnchor
+	45, // 23: kythe.proto.serving.FileDecorations.target_override:type_name -> kythe.proto.serving.FileDecorations.Override
+	72, // 24: kythe.proto.serving.FileDecorations.diagnostic:type_name -> kythe.proto.common.Diagnostic
+	16, // 25: kythe.proto.serving.FileDecorations.file_info:type_name -> kythe.proto.serving.FileInfo
+	45, // 26: kythe.proto.serving.FileDecorations.target_overridden_by:type_name -> kythe.proto.serving.FileDecorations.Override
+	46, // 27: kythe.proto.serving.FileDecorations.target_reference_count:type_name -> kythe.proto.serving.FileDecorations.TargetReferenceCountEntry
+	47, // 28: kythe.proto.serving.FileDecorations.decoration_page:type_name -> kythe.proto.serving.FileDecorations.DecorationPage
+	44, // 29: kythe.proto.serving.FileDecorationsPage.decoration:type_name -> kythe.proto.serving.FileDecorations.Decoration
+	5,  // 30: kythe.proto.serving.PagedCrossReferences.source_node:type_name -> kythe.proto.serving.Node
+	51, // 31: kythe.proto.serving.PagedCrossReferences.group:type_name -> kythe.proto.serving.PagedCrossReferences.Group
+	53, // 32: kythe.proto.serving.PagedCrossReferences.page_index:type_name -> kythe.proto.serving.PagedCrossReferences.PageIndex
+	73, // 33: kythe.proto.serving.PagedCrossReferences.marked_source:type_name -> kythe.proto.common.MarkedSource
+	54, // 34: kythe.proto.serving.PagedCrossReferences.page_search_index:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex
+	73, // 35: kythe.proto.serving.Document.marked_source:type_name -> kythe.proto.common.MarkedSource
+	74, // 36: kythe.proto.serving.Document.link:type_name -> kythe.proto.common.Link
+	5,  // 37: kythe.proto.serving.Document.node:type_name -> kythe.proto.serving.Node
+	58, // 38: kythe.proto.serving.FileReferences.node:type_name -> kythe.proto.serving.FileReferences.Node
+	59, // 39: kythe.proto.serving.FileRelations.outgoing:type_name -> kythe.proto.serving.FileRelations.Relation
+	59, // 40: kythe.proto.serving.FileRelations.incoming:type_name -> kythe.proto.serving.FileRelations.Relation
+	60, // 41: kythe.proto.serving.ContainerMembers.member:type_name -> kythe.proto.serving.ContainerMembers.Member
+	61, // 42: kythe.proto.serving.NodeFactHashes.fact:type_name -> kythe.proto.serving.NodeFactHashes.Fact
+	62, // 43: kythe.proto.serving.ContainerRollup.target:type_name -> kythe.proto.serving.ContainerRollup.Target
+	63, // 44: kythe.proto.serving.FileOutline.symbol:type_name -> kythe.proto.serving.FileOutline.Symbol
+	64, // 45: kythe.proto.serving.IdentifierMatch.node:type_name -> kythe.proto.serving.IdentifierMatch.Node
+	2,  // 46: kythe.proto.serving.Relatives.type:type_name -> kythe.proto.serving.Relatives.Type
+	3,  // 47: kythe.proto.serving.Callgraph.type:type_name -> kythe.proto.serving.Callgraph.Type
+	4,  // 48: kythe.proto.serving.Diff.span_type:type_name -> kythe.proto.serving.Diff.Type
+	69, // 49: kythe.proto.serving.TableMetadata.build_time:type_name -> google.protobuf.Timestamp
+	65, // 50: kythe.proto.serving.KindsSummary.node_kinds:type_name -> kythe.proto.serving.KindsSummary.NodeKindsEntry
+	66, // 51: kythe.proto.serving.KindsSummary.edge_kinds:type_name -> kythe.proto.serving.KindsSummary.EdgeKindsEntry
+	5,  // 52: kythe.proto.serving.EdgeGroup.Edge.target:type_name -> kythe.proto.serving.Node
+	0,  // 53: kythe.proto.serving.FileDirectory.Entry.kind:type_name -> kythe.proto.serving.FileDirectory.Kind
+	14, // 54: kythe.proto.serving.FileDecorations.Decoration.anchor:type_name -> kythe.proto.serving.RawAnchor
+	1,  // 55: kythe.proto.serving.FileDecorations.Override.kind:type_name -> kythe.proto.serving.FileDecorations.Override.Kind
+	73, // 56: kythe.proto.serving.FileDecorations.Override.marked_source:type_name -> kythe.proto.common.MarkedSource
+	5,  // 57: kythe.proto.serving.PagedCrossReferences.RelatedNode.node:type_name -> kythe.proto.serving.Node
+	15, // 58: kythe.proto.serving.PagedCrossReferences.ScopedReference.scope:type_name -> kythe.proto.serving.ExpandedAnchor
+	73, // 59: kythe.proto.serving.PagedCrossReferences.ScopedReference.marked_source:type_name -> kythe.proto.common.MarkedSource
+	15, // 60: kythe.proto.serving.PagedCrossReferences.ScopedReference.reference:type_name -> kythe.proto.serving.ExpandedAnchor
+	15, // 61: kythe.proto.serving.PagedCrossReferences.Caller.caller:type_name -> kythe.proto.serving.ExpandedAnchor
+	73, // 62: kythe.proto.serving.PagedCrossReferences.Caller.marked_source:type_name -> kythe.proto.common.MarkedSource
+	15, // 63: kythe.proto.serving.PagedCrossReferences.Caller.callsite:type_name -> kythe.proto.serving.ExpandedAnchor
+	15, // 64: kythe.proto.serving.PagedCrossReferences.Group.anchor:type_name -> kythe.proto.serving.ExpandedAnchor
+	48, // 65: kythe.proto.serving.PagedCrossReferences.Group.related_node:type_name -> kythe.proto.serving.PagedCrossReferences.RelatedNode
+	50, // 66: kythe.proto.serving.PagedCrossReferences.Group.caller:type_name -> kythe.proto.serving.PagedCrossReferences.Caller
+	49, // 67: kythe.proto.serving.PagedCrossReferences.Group.scoped_reference:type_name -> kythe.proto.serving.PagedCrossReferences.ScopedReference
+	16, // 68: kythe.proto.serving.PagedCrossReferences.Group.file_info:type_name -> kythe.proto.serving.FileInfo
+	51, // 69: kythe.proto.serving.PagedCrossReferences.Page.group:type_name -> kythe.proto.serving.PagedCrossReferences.Group
+	56, // 70: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.by_corpus:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
+	56, // 71: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.by_root:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
+	56, // 72: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.by_path:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
+	56, // 73: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.by_resolved_path:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings
+	57, // 74: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings.index:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings.IndexEntry
+	55, // 75: kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Postings.IndexEntry.value:type_name -> kythe.proto.serving.PagedCrossReferences.PageSearchIndex.Pages
+	15, // 76: kythe.proto.serving.ContainerMembers.Member.definition:type_name -> kythe.proto.serving.ExpandedAnchor
+	15, // 77: kythe.proto.serving.FileOutline.Symbol.definition:type_name -> kythe.proto.serving.ExpandedAnchor
+	78, // [78:78] is the sub-list for method output_type
+	78, // [78:78] is the sub-list for method input_type
+	78, // [78:78] is the sub-list for extension type_name
+	78, // [78:78] is the sub-list for extension extendee
+	0,  // [0:78] is the sub-list for field type_name
 }
 
 func init() { file_kythe_proto_serving_proto_init() }
@@ -3298,8 +5039,92 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Edge); i {
+		file_kythe_proto_serving_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Edge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgeGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PagedEdgeSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PageIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgePage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileDirectory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CorpusRoots); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*File); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3310,8 +5135,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EdgeGroup); i {
+		file_kythe_proto_serving_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawAnchor); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3322,8 +5147,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PagedEdgeSet); i {
+		file_kythe_proto_serving_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpandedAnchor); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3334,8 +5159,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PageIndex); i {
+		file_kythe_proto_serving_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3346,8 +5171,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EdgePage); i {
+		file_kythe_proto_serving_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileDecorations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3358,8 +5183,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileDirectory); i {
+		file_kythe_proto_serving_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileDecorationsPage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3370,8 +5195,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CorpusRoots); i {
+		file_kythe_proto_serving_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PagedCrossReferences); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3382,8 +5207,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*File); i {
+		file_kythe_proto_serving_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Document); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3394,8 +5219,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RawAnchor); i {
+		file_kythe_proto_serving_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisplayName); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3406,8 +5231,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExpandedAnchor); i {
+		file_kythe_proto_serving_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileDigest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3418,8 +5243,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileInfo); i {
+		file_kythe_proto_serving_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileReferences); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3430,8 +5255,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileDecorations); i {
+		file_kythe_proto_serving_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileRelations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3442,8 +5267,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PagedCrossReferences); i {
+		file_kythe_proto_serving_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallDegrees); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3454,8 +5279,8 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Document); i {
+		file_kythe_proto_serving_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerMembers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3466,7 +5291,91 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExistenceFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeFactHashes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FactHashesGeneration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerRollup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileOutline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TicketAlias); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TicketIndexEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IdentifierMatch); i {
 			case 0:
 				return &v.state
@@ -3478,7 +5387,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Relatives); i {
 			case 0:
 				return &v.state
@@ -3490,7 +5399,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Callgraph); i {
 			case 0:
 				return &v.state
@@ -3502,7 +5411,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Diff); i {
 			case 0:
 				return &v.state
@@ -3514,7 +5423,43 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableManifest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KindsSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EdgeGroup_Edge); i {
 			case 0:
 				return &v.state
@@ -3526,7 +5471,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FileDirectory_Entry); i {
 			case 0:
 				return &v.state
@@ -3538,7 +5483,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CorpusRoots_Corpus); i {
 			case 0:
 				return &v.state
@@ -3550,7 +5495,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FileDecorations_Decoration); i {
 			case 0:
 				return &v.state
@@ -3562,7 +5507,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FileDecorations_Override); i {
 			case 0:
 				return &v.state
@@ -3574,7 +5519,19 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileDecorations_DecorationPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_RelatedNode); i {
 			case 0:
 				return &v.state
@@ -3586,7 +5543,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_ScopedReference); i {
 			case 0:
 				return &v.state
@@ -3598,7 +5555,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_Caller); i {
 			case 0:
 				return &v.state
@@ -3610,7 +5567,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_Group); i {
 			case 0:
 				return &v.state
@@ -3622,7 +5579,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_Page); i {
 			case 0:
 				return &v.state
@@ -3634,7 +5591,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_PageIndex); i {
 			case 0:
 				return &v.state
@@ -3646,7 +5603,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_PageSearchIndex); i {
 			case 0:
 				return &v.state
@@ -3658,7 +5615,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_PageSearchIndex_Pages); i {
 			case 0:
 				return &v.state
@@ -3670,7 +5627,7 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PagedCrossReferences_PageSearchIndex_Postings); i {
 			case 0:
 				return &v.state
@@ -3682,7 +5639,79 @@ func file_kythe_proto_serving_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_serving_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_serving_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileReferences_Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileRelations_Relation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerMembers_Member); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeFactHashes_Fact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerRollup_Target); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileOutline_Symbol); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_serving_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IdentifierMatch_Node); i {
 			case 0:
 				return &v.state
@@ -3701,7 +5730,7 @@ func file_kythe_proto_serving_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kythe_proto_serving_proto_rawDesc,
 			NumEnums:      5,
-			NumMessages:   35,
+			NumMessages:   62,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -9,6 +9,9 @@ package xref_go_proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	common_go_proto "kythe.io/kythe/proto/common_go_proto"
 	reflect "reflect"
 	sync "sync"
@@ -67,6 +70,58 @@ func (SnippetsKind) EnumDescriptor() ([]byte, []int) {
 	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{0}
 }
 
+type TargetDefinitionKind int32
+
+const (
+	TargetDefinitionKind_UNKNOWN_TARGET_DEFINITION TargetDefinitionKind = 0
+	TargetDefinitionKind_FULL_DEFINITION           TargetDefinitionKind = 1
+	TargetDefinitionKind_COMPLETION                TargetDefinitionKind = 2
+	TargetDefinitionKind_DECLARATION               TargetDefinitionKind = 3
+)
+
+// Enum value maps for TargetDefinitionKind.
+var (
+	TargetDefinitionKind_name = map[int32]string{
+		0: "UNKNOWN_TARGET_DEFINITION",
+		1: "FULL_DEFINITION",
+		2: "COMPLETION",
+		3: "DECLARATION",
+	}
+	TargetDefinitionKind_value = map[string]int32{
+		"UNKNOWN_TARGET_DEFINITION": 0,
+		"FULL_DEFINITION":           1,
+		"COMPLETION":                2,
+		"DECLARATION":               3,
+	}
+)
+
+func (x TargetDefinitionKind) Enum() *TargetDefinitionKind {
+	p := new(TargetDefinitionKind)
+	*p = x
+	return p
+}
+
+func (x TargetDefinitionKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TargetDefinitionKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_kythe_proto_xref_proto_enumTypes[1].Descriptor()
+}
+
+func (TargetDefinitionKind) Type() protoreflect.EnumType {
+	return &file_kythe_proto_xref_proto_enumTypes[1]
+}
+
+func (x TargetDefinitionKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TargetDefinitionKind.Descriptor instead.
+func (TargetDefinitionKind) EnumDescriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{1}
+}
+
 type Location_Kind int32
 
 const (
@@ -97,11 +152,11 @@ func (x Location_Kind) String() string {
 }
 
 func (Location_Kind) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[1].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[2].Descriptor()
 }
 
 func (Location_Kind) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[1]
+	return &file_kythe_proto_xref_proto_enumTypes[2]
 }
 
 func (x Location_Kind) Number() protoreflect.EnumNumber {
@@ -143,11 +198,11 @@ func (x DecorationsRequest_SpanKind) String() string {
 }
 
 func (DecorationsRequest_SpanKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[2].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[3].Descriptor()
 }
 
 func (DecorationsRequest_SpanKind) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[2]
+	return &file_kythe_proto_xref_proto_enumTypes[3]
 }
 
 func (x DecorationsRequest_SpanKind) Number() protoreflect.EnumNumber {
@@ -159,6 +214,52 @@ func (DecorationsRequest_SpanKind) EnumDescriptor() ([]byte, []int) {
 	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{1, 0}
 }
 
+type DecorationsRequest_DefinitionSelection int32
+
+const (
+	DecorationsRequest_BEST_DEFINITION DecorationsRequest_DefinitionSelection = 0
+	DecorationsRequest_ALL_DEFINITIONS DecorationsRequest_DefinitionSelection = 1
+)
+
+// Enum value maps for DecorationsRequest_DefinitionSelection.
+var (
+	DecorationsRequest_DefinitionSelection_name = map[int32]string{
+		0: "BEST_DEFINITION",
+		1: "ALL_DEFINITIONS",
+	}
+	DecorationsRequest_DefinitionSelection_value = map[string]int32{
+		"BEST_DEFINITION": 0,
+		"ALL_DEFINITIONS": 1,
+	}
+)
+
+func (x DecorationsRequest_DefinitionSelection) Enum() *DecorationsRequest_DefinitionSelection {
+	p := new(DecorationsRequest_DefinitionSelection)
+	*p = x
+	return p
+}
+
+func (x DecorationsRequest_DefinitionSelection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DecorationsRequest_DefinitionSelection) Descriptor() protoreflect.EnumDescriptor {
+	return file_kythe_proto_xref_proto_enumTypes[4].Descriptor()
+}
+
+func (DecorationsRequest_DefinitionSelection) Type() protoreflect.EnumType {
+	return &file_kythe_proto_xref_proto_enumTypes[4]
+}
+
+func (x DecorationsRequest_DefinitionSelection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DecorationsRequest_DefinitionSelection.Descriptor instead.
+func (DecorationsRequest_DefinitionSelection) EnumDescriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{1, 1}
+}
+
 type DecorationsReply_Override_Kind int32
 
 const (
@@ -189,11 +290,11 @@ func (x DecorationsReply_Override_Kind) String() string {
 }
 
 func (DecorationsReply_Override_Kind) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[3].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[5].Descriptor()
 }
 
 func (DecorationsReply_Override_Kind) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[3]
+	return &file_kythe_proto_xref_proto_enumTypes[5]
 }
 
 func (x DecorationsReply_Override_Kind) Number() protoreflect.EnumNumber {
@@ -202,7 +303,7 @@ func (x DecorationsReply_Override_Kind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DecorationsReply_Override_Kind.Descriptor instead.
 func (DecorationsReply_Override_Kind) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 1, 0}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 2, 0}
 }
 
 type CrossReferencesRequest_DefinitionKind int32
@@ -241,11 +342,11 @@ func (x CrossReferencesRequest_DefinitionKind) String() string {
 }
 
 func (CrossReferencesRequest_DefinitionKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[4].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[6].Descriptor()
 }
 
 func (CrossReferencesRequest_DefinitionKind) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[4]
+	return &file_kythe_proto_xref_proto_enumTypes[6]
 }
 
 func (x CrossReferencesRequest_DefinitionKind) Number() protoreflect.EnumNumber {
@@ -254,7 +355,7 @@ func (x CrossReferencesRequest_DefinitionKind) Number() protoreflect.EnumNumber
 
 // Deprecated: Use CrossReferencesRequest_DefinitionKind.Descriptor instead.
 func (CrossReferencesRequest_DefinitionKind) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{4, 0}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{6, 0}
 }
 
 type CrossReferencesRequest_DeclarationKind int32
@@ -287,11 +388,11 @@ func (x CrossReferencesRequest_DeclarationKind) String() string {
 }
 
 func (CrossReferencesRequest_DeclarationKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[5].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[7].Descriptor()
 }
 
 func (CrossReferencesRequest_DeclarationKind) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[5]
+	return &file_kythe_proto_xref_proto_enumTypes[7]
 }
 
 func (x CrossReferencesRequest_DeclarationKind) Number() protoreflect.EnumNumber {
@@ -300,7 +401,7 @@ func (x CrossReferencesRequest_DeclarationKind) Number() protoreflect.EnumNumber
 
 // Deprecated: Use CrossReferencesRequest_DeclarationKind.Descriptor instead.
 func (CrossReferencesRequest_DeclarationKind) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{4, 1}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{6, 1}
 }
 
 type CrossReferencesRequest_ReferenceKind int32
@@ -339,11 +440,11 @@ func (x CrossReferencesRequest_ReferenceKind) String() string {
 }
 
 func (CrossReferencesRequest_ReferenceKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[6].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[8].Descriptor()
 }
 
 func (CrossReferencesRequest_ReferenceKind) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[6]
+	return &file_kythe_proto_xref_proto_enumTypes[8]
 }
 
 func (x CrossReferencesRequest_ReferenceKind) Number() protoreflect.EnumNumber {
@@ -352,7 +453,7 @@ func (x CrossReferencesRequest_ReferenceKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CrossReferencesRequest_ReferenceKind.Descriptor instead.
 func (CrossReferencesRequest_ReferenceKind) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{4, 2}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{6, 2}
 }
 
 type CrossReferencesRequest_CallerKind int32
@@ -388,11 +489,11 @@ func (x CrossReferencesRequest_CallerKind) String() string {
 }
 
 func (CrossReferencesRequest_CallerKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[7].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[9].Descriptor()
 }
 
 func (CrossReferencesRequest_CallerKind) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[7]
+	return &file_kythe_proto_xref_proto_enumTypes[9]
 }
 
 func (x CrossReferencesRequest_CallerKind) Number() protoreflect.EnumNumber {
@@ -401,7 +502,7 @@ func (x CrossReferencesRequest_CallerKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CrossReferencesRequest_CallerKind.Descriptor instead.
 func (CrossReferencesRequest_CallerKind) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{4, 3}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{6, 3}
 }
 
 type CrossReferencesRequest_TotalsQuality int32
@@ -437,11 +538,11 @@ func (x CrossReferencesRequest_TotalsQuality) String() string {
 }
 
 func (CrossReferencesRequest_TotalsQuality) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[8].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[10].Descriptor()
 }
 
 func (CrossReferencesRequest_TotalsQuality) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[8]
+	return &file_kythe_proto_xref_proto_enumTypes[10]
 }
 
 func (x CrossReferencesRequest_TotalsQuality) Number() protoreflect.EnumNumber {
@@ -450,7 +551,7 @@ func (x CrossReferencesRequest_TotalsQuality) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CrossReferencesRequest_TotalsQuality.Descriptor instead.
 func (CrossReferencesRequest_TotalsQuality) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{4, 4}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{6, 4}
 }
 
 type CorpusPathFilter_Type int32
@@ -486,11 +587,11 @@ func (x CorpusPathFilter_Type) String() string {
 }
 
 func (CorpusPathFilter_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_kythe_proto_xref_proto_enumTypes[9].Descriptor()
+	return file_kythe_proto_xref_proto_enumTypes[11].Descriptor()
 }
 
 func (CorpusPathFilter_Type) Type() protoreflect.EnumType {
-	return &file_kythe_proto_xref_proto_enumTypes[9]
+	return &file_kythe_proto_xref_proto_enumTypes[11]
 }
 
 func (x CorpusPathFilter_Type) Number() protoreflect.EnumNumber {
@@ -499,7 +600,71 @@ func (x CorpusPathFilter_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CorpusPathFilter_Type.Descriptor instead.
 func (CorpusPathFilter_Type) EnumDescriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{6, 0}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type Anchor_Classification int32
+
+const (
+	Anchor_UNCLASSIFIED Anchor_Classification = 0
+	Anchor_DEFINITION   Anchor_Classification = 1
+	Anchor_BINDING      Anchor_Classification = 2
+	Anchor_COMPLETION   Anchor_Classification = 3
+	Anchor_DECLARATION  Anchor_Classification = 4
+	Anchor_REFERENCE    Anchor_Classification = 5
+	Anchor_CALL         Anchor_Classification = 6
+	Anchor_DOC          Anchor_Classification = 7
+)
+
+// Enum value maps for Anchor_Classification.
+var (
+	Anchor_Classification_name = map[int32]string{
+		0: "UNCLASSIFIED",
+		1: "DEFINITION",
+		2: "BINDING",
+		3: "COMPLETION",
+		4: "DECLARATION",
+		5: "REFERENCE",
+		6: "CALL",
+		7: "DOC",
+	}
+	Anchor_Classification_value = map[string]int32{
+		"UNCLASSIFIED": 0,
+		"DEFINITION":   1,
+		"BINDING":      2,
+		"COMPLETION":   3,
+		"DECLARATION":  4,
+		"REFERENCE":    5,
+		"CALL":         6,
+		"DOC":          7,
+	}
+)
+
+func (x Anchor_Classification) Enum() *Anchor_Classification {
+	p := new(Anchor_Classification)
+	*p = x
+	return p
+}
+
+func (x Anchor_Classification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Anchor_Classification) Descriptor() protoreflect.EnumDescriptor {
+	return file_kythe_proto_xref_proto_enumTypes[12].Descriptor()
+}
+
+func (Anchor_Classification) Type() protoreflect.EnumType {
+	return &file_kythe_proto_xref_proto_enumTypes[12]
+}
+
+func (x Anchor_Classification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Anchor_Classification.Descriptor instead.
+func (Anchor_Classification) EnumDescriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{9, 0}
 }
 
 type Location struct {
@@ -570,20 +735,34 @@ type DecorationsRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Location              *Location                   `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
-	SpanKind              DecorationsRequest_SpanKind `protobuf:"varint,10,opt,name=span_kind,json=spanKind,proto3,enum=kythe.proto.DecorationsRequest_SpanKind" json:"span_kind,omitempty"`
-	DirtyBuffer           []byte                      `protobuf:"bytes,2,opt,name=dirty_buffer,json=dirtyBuffer,proto3" json:"dirty_buffer,omitempty"`
-	SourceText            bool                        `protobuf:"varint,3,opt,name=source_text,json=sourceText,proto3" json:"source_text,omitempty"`
-	References            bool                        `protobuf:"varint,4,opt,name=references,proto3" json:"references,omitempty"`
-	TargetDefinitions     bool                        `protobuf:"varint,6,opt,name=target_definitions,json=targetDefinitions,proto3" json:"target_definitions,omitempty"`
-	Filter                []string                    `protobuf:"bytes,5,rep,name=filter,proto3" json:"filter,omitempty"`
-	ExtendsOverrides      bool                        `protobuf:"varint,7,opt,name=extends_overrides,json=extendsOverrides,proto3" json:"extends_overrides,omitempty"`
-	Diagnostics           bool                        `protobuf:"varint,8,opt,name=diagnostics,proto3" json:"diagnostics,omitempty"`
-	Snippets              SnippetsKind                `protobuf:"varint,9,opt,name=snippets,proto3,enum=kythe.proto.SnippetsKind" json:"snippets,omitempty"`
-	BuildConfig           []string                    `protobuf:"bytes,11,rep,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
-	SemanticScopes        bool                        `protobuf:"varint,12,opt,name=semantic_scopes,json=semanticScopes,proto3" json:"semantic_scopes,omitempty"`
-	Workspace             *Workspace                  `protobuf:"bytes,13,opt,name=workspace,proto3" json:"workspace,omitempty"`
-	PatchAgainstWorkspace bool                        `protobuf:"varint,14,opt,name=patch_against_workspace,json=patchAgainstWorkspace,proto3" json:"patch_against_workspace,omitempty"`
+	Location              *Location                              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	SpanKind              DecorationsRequest_SpanKind            `protobuf:"varint,10,opt,name=span_kind,json=spanKind,proto3,enum=kythe.proto.DecorationsRequest_SpanKind" json:"span_kind,omitempty"`
+	DirtyBuffer           []byte                                 `protobuf:"bytes,2,opt,name=dirty_buffer,json=dirtyBuffer,proto3" json:"dirty_buffer,omitempty"`
+	SourceText            bool                                   `protobuf:"varint,3,opt,name=source_text,json=sourceText,proto3" json:"source_text,omitempty"`
+	References            bool                                   `protobuf:"varint,4,opt,name=references,proto3" json:"references,omitempty"`
+	TargetDefinitions     bool                                   `protobuf:"varint,6,opt,name=target_definitions,json=targetDefinitions,proto3" json:"target_definitions,omitempty"`
+	Filter                []string                               `protobuf:"bytes,5,rep,name=filter,proto3" json:"filter,omitempty"`
+	ExtendsOverrides      bool                                   `protobuf:"varint,7,opt,name=extends_overrides,json=extendsOverrides,proto3" json:"extends_overrides,omitempty"`
+	Diagnostics           bool                                   `protobuf:"varint,8,opt,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	Snippets              SnippetsKind                           `protobuf:"varint,9,opt,name=snippets,proto3,enum=kythe.proto.SnippetsKind" json:"snippets,omitempty"`
+	BuildConfig           []string                               `protobuf:"bytes,11,rep,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	SemanticScopes        bool                                   `protobuf:"varint,12,opt,name=semantic_scopes,json=semanticScopes,proto3" json:"semantic_scopes,omitempty"`
+	Workspace             *Workspace                             `protobuf:"bytes,13,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	PatchAgainstWorkspace bool                                   `protobuf:"varint,14,opt,name=patch_against_workspace,json=patchAgainstWorkspace,proto3" json:"patch_against_workspace,omitempty"`
+	DisplayNames          bool                                   `protobuf:"varint,15,opt,name=display_names,json=displayNames,proto3" json:"display_names,omitempty"`
+	DefinitionPreference  []TargetDefinitionKind                 `protobuf:"varint,16,rep,packed,name=definition_preference,json=definitionPreference,proto3,enum=kythe.proto.TargetDefinitionKind" json:"definition_preference,omitempty"`
+	CanonicalKinds        bool                                   `protobuf:"varint,17,opt,name=canonical_kinds,json=canonicalKinds,proto3" json:"canonical_kinds,omitempty"`
+	MaxDefinitionJumps    int32                                  `protobuf:"varint,18,opt,name=max_definition_jumps,json=maxDefinitionJumps,proto3" json:"max_definition_jumps,omitempty"`
+	OverrideMarkers       bool                                   `protobuf:"varint,19,opt,name=override_markers,json=overrideMarkers,proto3" json:"override_markers,omitempty"`
+	ReferenceCounts       bool                                   `protobuf:"varint,20,opt,name=reference_counts,json=referenceCounts,proto3" json:"reference_counts,omitempty"`
+	ReferenceMask         *fieldmaskpb.FieldMask                 `protobuf:"bytes,21,opt,name=reference_mask,json=referenceMask,proto3" json:"reference_mask,omitempty"`
+	LineTable             bool                                   `protobuf:"varint,22,opt,name=line_table,json=lineTable,proto3" json:"line_table,omitempty"`
+	DefinitionSelection   DecorationsRequest_DefinitionSelection `protobuf:"varint,23,opt,name=definition_selection,json=definitionSelection,proto3,enum=kythe.proto.DecorationsRequest_DefinitionSelection" json:"definition_selection,omitempty"`
+	TranscodeToUtf8       bool                                   `protobuf:"varint,24,opt,name=transcode_to_utf8,json=transcodeToUtf8,proto3" json:"transcode_to_utf8,omitempty"`
+	CanonicalNodes        bool                                   `protobuf:"varint,25,opt,name=canonical_nodes,json=canonicalNodes,proto3" json:"canonical_nodes,omitempty"`
+	BuildId               string                                 `protobuf:"bytes,26,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	GroupByBuildConfig    bool                                   `protobuf:"varint,27,opt,name=group_by_build_config,json=groupByBuildConfig,proto3" json:"group_by_build_config,omitempty"`
+	TargetTicket          []string                               `protobuf:"bytes,28,rep,name=target_ticket,json=targetTicket,proto3" json:"target_ticket,omitempty"`
 }
 
 func (x *DecorationsRequest) Reset() {
@@ -716,6 +895,104 @@ func (x *DecorationsRequest) GetPatchAgainstWorkspace() bool {
 	return false
 }
 
+func (x *DecorationsRequest) GetDisplayNames() bool {
+	if x != nil {
+		return x.DisplayNames
+	}
+	return false
+}
+
+func (x *DecorationsRequest) GetDefinitionPreference() []TargetDefinitionKind {
+	if x != nil {
+		return x.DefinitionPreference
+	}
+	return nil
+}
+
+func (x *DecorationsRequest) GetCanonicalKinds() bool {
+	if x != nil {
+		return x.CanonicalKinds
+	}
+	return false
+}
+
+func (x *DecorationsRequest) GetMaxDefinitionJumps() int32 {
+	if x != nil {
+		return x.MaxDefinitionJumps
+	}
+	return 0
+}
+
+func (x *DecorationsRequest) GetOverrideMarkers() bool {
+	if x != nil {
+		return x.OverrideMarkers
+	}
+	return false
+}
+
+func (x *DecorationsRequest) GetReferenceCounts() bool {
+	if x != nil {
+		return x.ReferenceCounts
+	}
+	return false
+}
+
+func (x *DecorationsRequest) GetReferenceMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReferenceMask
+	}
+	return nil
+}
+
+func (x *DecorationsRequest) GetLineTable() bool {
+	if x != nil {
+		return x.LineTable
+	}
+	return false
+}
+
+func (x *DecorationsRequest) GetDefinitionSelection() DecorationsRequest_DefinitionSelection {
+	if x != nil {
+		return x.DefinitionSelection
+	}
+	return DecorationsRequest_BEST_DEFINITION
+}
+
+func (x *DecorationsRequest) GetTranscodeToUtf8() bool {
+	if x != nil {
+		return x.TranscodeToUtf8
+	}
+	return false
+}
+
+func (x *DecorationsRequest) GetCanonicalNodes() bool {
+	if x != nil {
+		return x.CanonicalNodes
+	}
+	return false
+}
+
+func (x *DecorationsRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *DecorationsRequest) GetGroupByBuildConfig() bool {
+	if x != nil {
+		return x.GroupByBuildConfig
+	}
+	return false
+}
+
+func (x *DecorationsRequest) GetTargetTicket() []string {
+	if x != nil {
+		return x.TargetTicket
+	}
+	return nil
+}
+
 type File struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -781,12 +1058,17 @@ type DecorationsReply struct {
 	SourceText          []byte                                 `protobuf:"bytes,2,opt,name=source_text,json=sourceText,proto3" json:"source_text,omitempty"`
 	Encoding            string                                 `protobuf:"bytes,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
 	Reference           []*DecorationsReply_Reference          `protobuf:"bytes,4,rep,name=reference,proto3" json:"reference,omitempty"`
+	BuildConfigGroup    []*DecorationsReply_BuildConfigGroup   `protobuf:"bytes,25,rep,name=build_config_group,json=buildConfigGroup,proto3" json:"build_config_group,omitempty"`
 	Diagnostic          []*common_go_proto.Diagnostic          `protobuf:"bytes,5,rep,name=diagnostic,proto3" json:"diagnostic,omitempty"`
 	GeneratedByFile     []*File                                `protobuf:"bytes,19,rep,name=generated_by_file,json=generatedByFile,proto3" json:"generated_by_file,omitempty"`
 	Nodes               map[string]*common_go_proto.NodeInfo   `protobuf:"bytes,15,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	DefinitionLocations map[string]*Anchor                     `protobuf:"bytes,16,rep,name=definition_locations,json=definitionLocations,proto3" json:"definition_locations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	ExtendsOverrides    map[string]*DecorationsReply_Overrides `protobuf:"bytes,17,rep,name=extends_overrides,json=extendsOverrides,proto3" json:"extends_overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	BuildId             string                                 `protobuf:"bytes,20,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	BuildTime           *timestamppb.Timestamp                 `protobuf:"bytes,24,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	DefinitionJumps     map[string]int32                       `protobuf:"bytes,21,rep,name=definition_jumps,json=definitionJumps,proto3" json:"definition_jumps,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	LineStart           []int32                                `protobuf:"varint,22,rep,packed,name=line_start,json=lineStart,proto3" json:"line_start,omitempty"`
+	LineLength          []int32                                `protobuf:"varint,23,rep,packed,name=line_length,json=lineLength,proto3" json:"line_length,omitempty"`
 }
 
 func (x *DecorationsReply) Reset() {
@@ -856,6 +1138,13 @@ func (x *DecorationsReply) GetReference() []*DecorationsReply_Reference {
 	return nil
 }
 
+func (x *DecorationsReply) GetBuildConfigGroup() []*DecorationsReply_BuildConfigGroup {
+	if x != nil {
+		return x.BuildConfigGroup
+	}
+	return nil
+}
+
 func (x *DecorationsReply) GetDiagnostic() []*common_go_proto.Diagnostic {
 	if x != nil {
 		return x.Diagnostic
@@ -898,32 +1187,46 @@ func (x *DecorationsReply) GetBuildId() string {
 	return ""
 }
 
-type CrossReferencesRequest struct {
+func (x *DecorationsReply) GetBuildTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildTime
+	}
+	return nil
+}
+
+func (x *DecorationsReply) GetDefinitionJumps() map[string]int32 {
+	if x != nil {
+		return x.DefinitionJumps
+	}
+	return nil
+}
+
+func (x *DecorationsReply) GetLineStart() []int32 {
+	if x != nil {
+		return x.LineStart
+	}
+	return nil
+}
+
+func (x *DecorationsReply) GetLineLength() []int32 {
+	if x != nil {
+		return x.LineLength
+	}
+	return nil
+}
+
+type WatchDecorationsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket                []string                               `protobuf:"bytes,1,rep,name=ticket,proto3" json:"ticket,omitempty"`
-	DefinitionKind        CrossReferencesRequest_DefinitionKind  `protobuf:"varint,2,opt,name=definition_kind,json=definitionKind,proto3,enum=kythe.proto.CrossReferencesRequest_DefinitionKind" json:"definition_kind,omitempty"`
-	DeclarationKind       CrossReferencesRequest_DeclarationKind `protobuf:"varint,7,opt,name=declaration_kind,json=declarationKind,proto3,enum=kythe.proto.CrossReferencesRequest_DeclarationKind" json:"declaration_kind,omitempty"`
-	ReferenceKind         CrossReferencesRequest_ReferenceKind   `protobuf:"varint,3,opt,name=reference_kind,json=referenceKind,proto3,enum=kythe.proto.CrossReferencesRequest_ReferenceKind" json:"reference_kind,omitempty"`
-	CallerKind            CrossReferencesRequest_CallerKind      `protobuf:"varint,12,opt,name=caller_kind,json=callerKind,proto3,enum=kythe.proto.CrossReferencesRequest_CallerKind" json:"caller_kind,omitempty"`
-	Filter                []string                               `protobuf:"bytes,5,rep,name=filter,proto3" json:"filter,omitempty"`
-	RelatedNodeKind       []string                               `protobuf:"bytes,14,rep,name=related_node_kind,json=relatedNodeKind,proto3" json:"related_node_kind,omitempty"`
-	AnchorText            bool                                   `protobuf:"varint,6,opt,name=anchor_text,json=anchorText,proto3" json:"anchor_text,omitempty"`
-	NodeDefinitions       bool                                   `protobuf:"varint,8,opt,name=node_definitions,json=nodeDefinitions,proto3" json:"node_definitions,omitempty"`
-	TotalsQuality         CrossReferencesRequest_TotalsQuality   `protobuf:"varint,16,opt,name=totals_quality,json=totalsQuality,proto3,enum=kythe.proto.CrossReferencesRequest_TotalsQuality" json:"totals_quality,omitempty"`
-	PageSize              int32                                  `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken             string                                 `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	Snippets              SnippetsKind                           `protobuf:"varint,13,opt,name=snippets,proto3,enum=kythe.proto.SnippetsKind" json:"snippets,omitempty"`
-	BuildConfig           []string                               `protobuf:"bytes,15,rep,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
-	Workspace             *Workspace                             `protobuf:"bytes,17,opt,name=workspace,proto3" json:"workspace,omitempty"`
-	PatchAgainstWorkspace bool                                   `protobuf:"varint,18,opt,name=patch_against_workspace,json=patchAgainstWorkspace,proto3" json:"patch_against_workspace,omitempty"`
-	CorpusPathFilters     *CorpusPathFilters                     `protobuf:"bytes,19,opt,name=corpus_path_filters,json=corpusPathFilters,proto3" json:"corpus_path_filters,omitempty"`
+	Decorations *DecorationsRequest  `protobuf:"bytes,1,opt,name=decorations,proto3" json:"decorations,omitempty"`
+	Generation  int64                `protobuf:"varint,2,opt,name=generation,proto3" json:"generation,omitempty"`
+	Timeout     *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
 }
 
-func (x *CrossReferencesRequest) Reset() {
-	*x = CrossReferencesRequest{}
+func (x *WatchDecorationsRequest) Reset() {
+	*x = WatchDecorationsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_kythe_proto_xref_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -931,13 +1234,13 @@ func (x *CrossReferencesRequest) Reset() {
 	}
 }
 
-func (x *CrossReferencesRequest) String() string {
+func (x *WatchDecorationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CrossReferencesRequest) ProtoMessage() {}
+func (*WatchDecorationsRequest) ProtoMessage() {}
 
-func (x *CrossReferencesRequest) ProtoReflect() protoreflect.Message {
+func (x *WatchDecorationsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_kythe_proto_xref_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -949,56 +1252,213 @@ func (x *CrossReferencesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CrossReferencesRequest.ProtoReflect.Descriptor instead.
-func (*CrossReferencesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchDecorationsRequest.ProtoReflect.Descriptor instead.
+func (*WatchDecorationsRequest) Descriptor() ([]byte, []int) {
 	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CrossReferencesRequest) GetTicket() []string {
+func (x *WatchDecorationsRequest) GetDecorations() *DecorationsRequest {
 	if x != nil {
-		return x.Ticket
+		return x.Decorations
 	}
 	return nil
 }
 
-func (x *CrossReferencesRequest) GetDefinitionKind() CrossReferencesRequest_DefinitionKind {
+func (x *WatchDecorationsRequest) GetGeneration() int64 {
 	if x != nil {
-		return x.DefinitionKind
+		return x.Generation
 	}
-	return CrossReferencesRequest_NO_DEFINITIONS
+	return 0
 }
 
-func (x *CrossReferencesRequest) GetDeclarationKind() CrossReferencesRequest_DeclarationKind {
+func (x *WatchDecorationsRequest) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.DeclarationKind
+		return x.Timeout
 	}
-	return CrossReferencesRequest_NO_DECLARATIONS
+	return nil
 }
 
-func (x *CrossReferencesRequest) GetReferenceKind() CrossReferencesRequest_ReferenceKind {
-	if x != nil {
-		return x.ReferenceKind
-	}
-	return CrossReferencesRequest_NO_REFERENCES
+type WatchDecorationsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Decorations *DecorationsReply `protobuf:"bytes,1,opt,name=decorations,proto3" json:"decorations,omitempty"`
+	Generation  int64             `protobuf:"varint,2,opt,name=generation,proto3" json:"generation,omitempty"`
 }
 
-func (x *CrossReferencesRequest) GetCallerKind() CrossReferencesRequest_CallerKind {
-	if x != nil {
-		return x.CallerKind
+func (x *WatchDecorationsReply) Reset() {
+	*x = WatchDecorationsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return CrossReferencesRequest_NO_CALLERS
 }
 
-func (x *CrossReferencesRequest) GetFilter() []string {
-	if x != nil {
-		return x.Filter
-	}
-	return nil
+func (x *WatchDecorationsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *CrossReferencesRequest) GetRelatedNodeKind() []string {
-	if x != nil {
-		return x.RelatedNodeKind
+func (*WatchDecorationsReply) ProtoMessage() {}
+
+func (x *WatchDecorationsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDecorationsReply.ProtoReflect.Descriptor instead.
+func (*WatchDecorationsReply) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchDecorationsReply) GetDecorations() *DecorationsReply {
+	if x != nil {
+		return x.Decorations
+	}
+	return nil
+}
+
+func (x *WatchDecorationsReply) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+type CrossReferencesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket                    []string                               `protobuf:"bytes,1,rep,name=ticket,proto3" json:"ticket,omitempty"`
+	DefinitionKind            CrossReferencesRequest_DefinitionKind  `protobuf:"varint,2,opt,name=definition_kind,json=definitionKind,proto3,enum=kythe.proto.CrossReferencesRequest_DefinitionKind" json:"definition_kind,omitempty"`
+	DeclarationKind           CrossReferencesRequest_DeclarationKind `protobuf:"varint,7,opt,name=declaration_kind,json=declarationKind,proto3,enum=kythe.proto.CrossReferencesRequest_DeclarationKind" json:"declaration_kind,omitempty"`
+	ReferenceKind             CrossReferencesRequest_ReferenceKind   `protobuf:"varint,3,opt,name=reference_kind,json=referenceKind,proto3,enum=kythe.proto.CrossReferencesRequest_ReferenceKind" json:"reference_kind,omitempty"`
+	CallerKind                CrossReferencesRequest_CallerKind      `protobuf:"varint,12,opt,name=caller_kind,json=callerKind,proto3,enum=kythe.proto.CrossReferencesRequest_CallerKind" json:"caller_kind,omitempty"`
+	Filter                    []string                               `protobuf:"bytes,5,rep,name=filter,proto3" json:"filter,omitempty"`
+	RelatedNodeKind           []string                               `protobuf:"bytes,14,rep,name=related_node_kind,json=relatedNodeKind,proto3" json:"related_node_kind,omitempty"`
+	AnchorText                bool                                   `protobuf:"varint,6,opt,name=anchor_text,json=anchorText,proto3" json:"anchor_text,omitempty"`
+	NodeDefinitions           bool                                   `protobuf:"varint,8,opt,name=node_definitions,json=nodeDefinitions,proto3" json:"node_definitions,omitempty"`
+	TotalsQuality             CrossReferencesRequest_TotalsQuality   `protobuf:"varint,16,opt,name=totals_quality,json=totalsQuality,proto3,enum=kythe.proto.CrossReferencesRequest_TotalsQuality" json:"totals_quality,omitempty"`
+	PageSize                  int32                                  `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken                 string                                 `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Snippets                  SnippetsKind                           `protobuf:"varint,13,opt,name=snippets,proto3,enum=kythe.proto.SnippetsKind" json:"snippets,omitempty"`
+	BuildConfig               []string                               `protobuf:"bytes,15,rep,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	Workspace                 *Workspace                             `protobuf:"bytes,17,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	PatchAgainstWorkspace     bool                                   `protobuf:"varint,18,opt,name=patch_against_workspace,json=patchAgainstWorkspace,proto3" json:"patch_against_workspace,omitempty"`
+	CorpusPathFilters         *CorpusPathFilters                     `protobuf:"bytes,19,opt,name=corpus_path_filters,json=corpusPathFilters,proto3" json:"corpus_path_filters,omitempty"`
+	RelatedNodeExpansionDepth int32                                  `protobuf:"varint,20,opt,name=related_node_expansion_depth,json=relatedNodeExpansionDepth,proto3" json:"related_node_expansion_depth,omitempty"`
+	DisplayNames              bool                                   `protobuf:"varint,21,opt,name=display_names,json=displayNames,proto3" json:"display_names,omitempty"`
+	GroupRelatedNodeOrdinals  bool                                   `protobuf:"varint,22,opt,name=group_related_node_ordinals,json=groupRelatedNodeOrdinals,proto3" json:"group_related_node_ordinals,omitempty"`
+	Strict                    bool                                   `protobuf:"varint,23,opt,name=strict,proto3" json:"strict,omitempty"`
+	CountOnly                 bool                                   `protobuf:"varint,24,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+	CanonicalKinds            bool                                   `protobuf:"varint,25,opt,name=canonical_kinds,json=canonicalKinds,proto3" json:"canonical_kinds,omitempty"`
+	Documentation             bool                                   `protobuf:"varint,26,opt,name=documentation,proto3" json:"documentation,omitempty"`
+	AnchorMask                *fieldmaskpb.FieldMask                 `protobuf:"bytes,27,opt,name=anchor_mask,json=anchorMask,proto3" json:"anchor_mask,omitempty"`
+	GroupReferencesByKind     bool                                   `protobuf:"varint,28,opt,name=group_references_by_kind,json=groupReferencesByKind,proto3" json:"group_references_by_kind,omitempty"`
+	SkipMissingPages          bool                                   `protobuf:"varint,29,opt,name=skip_missing_pages,json=skipMissingPages,proto3" json:"skip_missing_pages,omitempty"`
+	RelatedNodePageSize       int32                                  `protobuf:"varint,30,opt,name=related_node_page_size,json=relatedNodePageSize,proto3" json:"related_node_page_size,omitempty"`
+	ResolveAnchorPaths        bool                                   `protobuf:"varint,31,opt,name=resolve_anchor_paths,json=resolveAnchorPaths,proto3" json:"resolve_anchor_paths,omitempty"`
+	AnchorTextPattern         string                                 `protobuf:"bytes,32,opt,name=anchor_text_pattern,json=anchorTextPattern,proto3" json:"anchor_text_pattern,omitempty"`
+	CallSitesByCaller         bool                                   `protobuf:"varint,33,opt,name=call_sites_by_caller,json=callSitesByCaller,proto3" json:"call_sites_by_caller,omitempty"`
+	MarkUnavailableFiles      bool                                   `protobuf:"varint,34,opt,name=mark_unavailable_files,json=markUnavailableFiles,proto3" json:"mark_unavailable_files,omitempty"`
+	CanonicalNodes            bool                                   `protobuf:"varint,35,opt,name=canonical_nodes,json=canonicalNodes,proto3" json:"canonical_nodes,omitempty"`
+	PhaseTimings              bool                                   `protobuf:"varint,36,opt,name=phase_timings,json=phaseTimings,proto3" json:"phase_timings,omitempty"`
+	BuildId                   string                                 `protobuf:"bytes,37,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	AcceptPartialTickets      bool                                   `protobuf:"varint,38,opt,name=accept_partial_tickets,json=acceptPartialTickets,proto3" json:"accept_partial_tickets,omitempty"`
+	ExcludeGenerated          bool                                   `protobuf:"varint,39,opt,name=exclude_generated,json=excludeGenerated,proto3" json:"exclude_generated,omitempty"`
+	EdgeFallback              bool                                   `protobuf:"varint,40,opt,name=edge_fallback,json=edgeFallback,proto3" json:"edge_fallback,omitempty"`
+	GroupByBuildConfig        bool                                   `protobuf:"varint,41,opt,name=group_by_build_config,json=groupByBuildConfig,proto3" json:"group_by_build_config,omitempty"`
+	CommittedAfter            *timestamppb.Timestamp                 `protobuf:"bytes,42,opt,name=committed_after,json=committedAfter,proto3" json:"committed_after,omitempty"`
+	CommittedBefore           *timestamppb.Timestamp                 `protobuf:"bytes,43,opt,name=committed_before,json=committedBefore,proto3" json:"committed_before,omitempty"`
+	AnchorFingerprints        bool                                   `protobuf:"varint,44,opt,name=anchor_fingerprints,json=anchorFingerprints,proto3" json:"anchor_fingerprints,omitempty"`
+}
+
+func (x *CrossReferencesRequest) Reset() {
+	*x = CrossReferencesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesRequest) ProtoMessage() {}
+
+func (x *CrossReferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesRequest.ProtoReflect.Descriptor instead.
+func (*CrossReferencesRequest) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CrossReferencesRequest) GetTicket() []string {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *CrossReferencesRequest) GetDefinitionKind() CrossReferencesRequest_DefinitionKind {
+	if x != nil {
+		return x.DefinitionKind
+	}
+	return CrossReferencesRequest_NO_DEFINITIONS
+}
+
+func (x *CrossReferencesRequest) GetDeclarationKind() CrossReferencesRequest_DeclarationKind {
+	if x != nil {
+		return x.DeclarationKind
+	}
+	return CrossReferencesRequest_NO_DECLARATIONS
+}
+
+func (x *CrossReferencesRequest) GetReferenceKind() CrossReferencesRequest_ReferenceKind {
+	if x != nil {
+		return x.ReferenceKind
+	}
+	return CrossReferencesRequest_NO_REFERENCES
+}
+
+func (x *CrossReferencesRequest) GetCallerKind() CrossReferencesRequest_CallerKind {
+	if x != nil {
+		return x.CallerKind
+	}
+	return CrossReferencesRequest_NO_CALLERS
+}
+
+func (x *CrossReferencesRequest) GetFilter() []string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CrossReferencesRequest) GetRelatedNodeKind() []string {
+	if x != nil {
+		return x.RelatedNodeKind
 	}
 	return nil
 }
@@ -1073,6 +1533,181 @@ func (x *CrossReferencesRequest) GetCorpusPathFilters() *CorpusPathFilters {
 	return nil
 }
 
+func (x *CrossReferencesRequest) GetRelatedNodeExpansionDepth() int32 {
+	if x != nil {
+		return x.RelatedNodeExpansionDepth
+	}
+	return 0
+}
+
+func (x *CrossReferencesRequest) GetDisplayNames() bool {
+	if x != nil {
+		return x.DisplayNames
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetGroupRelatedNodeOrdinals() bool {
+	if x != nil {
+		return x.GroupRelatedNodeOrdinals
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetCountOnly() bool {
+	if x != nil {
+		return x.CountOnly
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetCanonicalKinds() bool {
+	if x != nil {
+		return x.CanonicalKinds
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetDocumentation() bool {
+	if x != nil {
+		return x.Documentation
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetAnchorMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.AnchorMask
+	}
+	return nil
+}
+
+func (x *CrossReferencesRequest) GetGroupReferencesByKind() bool {
+	if x != nil {
+		return x.GroupReferencesByKind
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetSkipMissingPages() bool {
+	if x != nil {
+		return x.SkipMissingPages
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetRelatedNodePageSize() int32 {
+	if x != nil {
+		return x.RelatedNodePageSize
+	}
+	return 0
+}
+
+func (x *CrossReferencesRequest) GetResolveAnchorPaths() bool {
+	if x != nil {
+		return x.ResolveAnchorPaths
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetAnchorTextPattern() string {
+	if x != nil {
+		return x.AnchorTextPattern
+	}
+	return ""
+}
+
+func (x *CrossReferencesRequest) GetCallSitesByCaller() bool {
+	if x != nil {
+		return x.CallSitesByCaller
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetMarkUnavailableFiles() bool {
+	if x != nil {
+		return x.MarkUnavailableFiles
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetCanonicalNodes() bool {
+	if x != nil {
+		return x.CanonicalNodes
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetPhaseTimings() bool {
+	if x != nil {
+		return x.PhaseTimings
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *CrossReferencesRequest) GetAcceptPartialTickets() bool {
+	if x != nil {
+		return x.AcceptPartialTickets
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetExcludeGenerated() bool {
+	if x != nil {
+		return x.ExcludeGenerated
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetEdgeFallback() bool {
+	if x != nil {
+		return x.EdgeFallback
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetGroupByBuildConfig() bool {
+	if x != nil {
+		return x.GroupByBuildConfig
+	}
+	return false
+}
+
+func (x *CrossReferencesRequest) GetCommittedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommittedAfter
+	}
+	return nil
+}
+
+func (x *CrossReferencesRequest) GetCommittedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommittedBefore
+	}
+	return nil
+}
+
+func (x *CrossReferencesRequest) GetAnchorFingerprints() bool {
+	if x != nil {
+		return x.AnchorFingerprints
+	}
+	return false
+}
+
 type CorpusPathFilters struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1084,7 +1719,7 @@ type CorpusPathFilters struct {
 func (x *CorpusPathFilters) Reset() {
 	*x = CorpusPathFilters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[5]
+		mi := &file_kythe_proto_xref_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1097,7 +1732,7 @@ func (x *CorpusPathFilters) String() string {
 func (*CorpusPathFilters) ProtoMessage() {}
 
 func (x *CorpusPathFilters) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[5]
+	mi := &file_kythe_proto_xref_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,7 +1745,7 @@ func (x *CorpusPathFilters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CorpusPathFilters.ProtoReflect.Descriptor instead.
 func (*CorpusPathFilters) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{5}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CorpusPathFilters) GetFilter() []*CorpusPathFilter {
@@ -1135,7 +1770,7 @@ type CorpusPathFilter struct {
 func (x *CorpusPathFilter) Reset() {
 	*x = CorpusPathFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[6]
+		mi := &file_kythe_proto_xref_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1148,7 +1783,7 @@ func (x *CorpusPathFilter) String() string {
 func (*CorpusPathFilter) ProtoMessage() {}
 
 func (x *CorpusPathFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[6]
+	mi := &file_kythe_proto_xref_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1161,7 +1796,7 @@ func (x *CorpusPathFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CorpusPathFilter.ProtoReflect.Descriptor instead.
 func (*CorpusPathFilter) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{6}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CorpusPathFilter) GetType() CorpusPathFilter_Type {
@@ -1204,21 +1839,27 @@ type Anchor struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket      string                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
-	Kind        string                `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
-	Parent      string                `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
-	Span        *common_go_proto.Span `protobuf:"bytes,10,opt,name=span,proto3" json:"span,omitempty"`
-	Text        string                `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
-	Snippet     string                `protobuf:"bytes,7,opt,name=snippet,proto3" json:"snippet,omitempty"`
-	SnippetSpan *common_go_proto.Span `protobuf:"bytes,11,opt,name=snippet_span,json=snippetSpan,proto3" json:"snippet_span,omitempty"`
-	BuildConfig string                `protobuf:"bytes,12,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
-	Revision    string                `protobuf:"bytes,14,opt,name=revision,proto3" json:"revision,omitempty"`
+	Ticket           string                      `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind             string                      `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Parent           string                      `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	Span             *common_go_proto.Span       `protobuf:"bytes,10,opt,name=span,proto3" json:"span,omitempty"`
+	Text             string                      `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Snippet          string                      `protobuf:"bytes,7,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	SnippetSpan      *common_go_proto.Span       `protobuf:"bytes,11,opt,name=snippet_span,json=snippetSpan,proto3" json:"snippet_span,omitempty"`
+	SnippetHighlight *Anchor_SnippetRange        `protobuf:"bytes,16,opt,name=snippet_highlight,json=snippetHighlight,proto3" json:"snippet_highlight,omitempty"`
+	BuildConfig      string                      `protobuf:"bytes,12,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	Revision         string                      `protobuf:"bytes,14,opt,name=revision,proto3" json:"revision,omitempty"`
+	ParentPath       *common_go_proto.CorpusPath `protobuf:"bytes,15,opt,name=parent_path,json=parentPath,proto3" json:"parent_path,omitempty"`
+	Fingerprint      string                      `protobuf:"bytes,17,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	FileUnavailable  bool                        `protobuf:"varint,18,opt,name=file_unavailable,json=fileUnavailable,proto3" json:"file_unavailable,omitempty"`
+	CommitTime       *timestamppb.Timestamp      `protobuf:"bytes,19,opt,name=commit_time,json=commitTime,proto3" json:"commit_time,omitempty"`
+	Classification   Anchor_Classification       `protobuf:"varint,20,opt,name=classification,proto3,enum=kythe.proto.Anchor_Classification" json:"classification,omitempty"`
 }
 
 func (x *Anchor) Reset() {
 	*x = Anchor{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[7]
+		mi := &file_kythe_proto_xref_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1231,7 +1872,7 @@ func (x *Anchor) String() string {
 func (*Anchor) ProtoMessage() {}
 
 func (x *Anchor) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[7]
+	mi := &file_kythe_proto_xref_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1244,7 +1885,7 @@ func (x *Anchor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Anchor.ProtoReflect.Descriptor instead.
 func (*Anchor) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{7}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Anchor) GetTicket() string {
@@ -1296,9 +1937,16 @@ func (x *Anchor) GetSnippetSpan() *common_go_proto.Span {
 	return nil
 }
 
-func (x *Anchor) GetBuildConfig() string {
+func (x *Anchor) GetSnippetHighlight() *Anchor_SnippetRange {
 	if x != nil {
-		return x.BuildConfig
+		return x.SnippetHighlight
+	}
+	return nil
+}
+
+func (x *Anchor) GetBuildConfig() string {
+	if x != nil {
+		return x.BuildConfig
 	}
 	return ""
 }
@@ -1310,6 +1958,41 @@ func (x *Anchor) GetRevision() string {
 	return ""
 }
 
+func (x *Anchor) GetParentPath() *common_go_proto.CorpusPath {
+	if x != nil {
+		return x.ParentPath
+	}
+	return nil
+}
+
+func (x *Anchor) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *Anchor) GetFileUnavailable() bool {
+	if x != nil {
+		return x.FileUnavailable
+	}
+	return false
+}
+
+func (x *Anchor) GetCommitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommitTime
+	}
+	return nil
+}
+
+func (x *Anchor) GetClassification() Anchor_Classification {
+	if x != nil {
+		return x.Classification
+	}
+	return Anchor_UNCLASSIFIED
+}
+
 type Printable struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1322,7 +2005,7 @@ type Printable struct {
 func (x *Printable) Reset() {
 	*x = Printable{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[8]
+		mi := &file_kythe_proto_xref_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1335,7 +2018,7 @@ func (x *Printable) String() string {
 func (*Printable) ProtoMessage() {}
 
 func (x *Printable) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[8]
+	mi := &file_kythe_proto_xref_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1348,7 +2031,7 @@ func (x *Printable) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Printable.ProtoReflect.Descriptor instead.
 func (*Printable) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{8}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Printable) GetRawText() string {
@@ -1377,12 +2060,18 @@ type CrossReferencesReply struct {
 	DefinitionLocations map[string]*Anchor                                 `protobuf:"bytes,3,rep,name=definition_locations,json=definitionLocations,proto3" json:"definition_locations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	NextPageToken       string                                             `protobuf:"bytes,10,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	BuildId             string                                             `protobuf:"bytes,11,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	BuildTime           *timestamppb.Timestamp                             `protobuf:"bytes,15,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	MissingTicket       []string                                           `protobuf:"bytes,13,rep,name=missing_ticket,json=missingTicket,proto3" json:"missing_ticket,omitempty"`
+	RedirectedTicket    map[string]string                                  `protobuf:"bytes,14,rep,name=redirected_ticket,json=redirectedTicket,proto3" json:"redirected_ticket,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DebugInfo           *DebugInfo                                         `protobuf:"bytes,16,opt,name=debug_info,json=debugInfo,proto3" json:"debug_info,omitempty"`
+	InvalidTicket       map[string]string                                  `protobuf:"bytes,17,rep,name=invalid_ticket,json=invalidTicket,proto3" json:"invalid_ticket,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SlowPathTicket      []string                                           `protobuf:"bytes,18,rep,name=slow_path_ticket,json=slowPathTicket,proto3" json:"slow_path_ticket,omitempty"`
 }
 
 func (x *CrossReferencesReply) Reset() {
 	*x = CrossReferencesReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[9]
+		mi := &file_kythe_proto_xref_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1395,7 +2084,7 @@ func (x *CrossReferencesReply) String() string {
 func (*CrossReferencesReply) ProtoMessage() {}
 
 func (x *CrossReferencesReply) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[9]
+	mi := &file_kythe_proto_xref_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1408,7 +2097,7 @@ func (x *CrossReferencesReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CrossReferencesReply.ProtoReflect.Descriptor instead.
 func (*CrossReferencesReply) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{9}
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CrossReferencesReply) GetTotal() *CrossReferencesReply_Total {
@@ -1460,35 +2149,73 @@ func (x *CrossReferencesReply) GetBuildId() string {
 	return ""
 }
 
-type DocumentationRequest struct {
+func (x *CrossReferencesReply) GetBuildTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildTime
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply) GetMissingTicket() []string {
+	if x != nil {
+		return x.MissingTicket
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply) GetRedirectedTicket() map[string]string {
+	if x != nil {
+		return x.RedirectedTicket
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply) GetDebugInfo() *DebugInfo {
+	if x != nil {
+		return x.DebugInfo
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply) GetInvalidTicket() map[string]string {
+	if x != nil {
+		return x.InvalidTicket
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply) GetSlowPathTicket() []string {
+	if x != nil {
+		return x.SlowPathTicket
+	}
+	return nil
+}
+
+type DebugInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket                []string   `protobuf:"bytes,1,rep,name=ticket,proto3" json:"ticket,omitempty"`
-	Filter                []string   `protobuf:"bytes,2,rep,name=filter,proto3" json:"filter,omitempty"`
-	IncludeChildren       bool       `protobuf:"varint,3,opt,name=include_children,json=includeChildren,proto3" json:"include_children,omitempty"`
-	Workspace             *Workspace `protobuf:"bytes,4,opt,name=workspace,proto3" json:"workspace,omitempty"`
-	PatchAgainstWorkspace bool       `protobuf:"varint,5,opt,name=patch_against_workspace,json=patchAgainstWorkspace,proto3" json:"patch_against_workspace,omitempty"`
+	PhaseTiming []*DebugInfo_PhaseTiming `protobuf:"bytes,1,rep,name=phase_timing,json=phaseTiming,proto3" json:"phase_timing,omitempty"`
 }
 
-func (x *DocumentationRequest) Reset() {
-	*x = DocumentationRequest{}
+func (x *DebugInfo) Reset() {
+	*x = DebugInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[10]
+		mi := &file_kythe_proto_xref_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DocumentationRequest) String() string {
+func (x *DebugInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentationRequest) ProtoMessage() {}
+func (*DebugInfo) ProtoMessage() {}
 
-func (x *DocumentationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[10]
+func (x *DebugInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1499,74 +2226,46 @@ func (x *DocumentationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentationRequest.ProtoReflect.Descriptor instead.
-func (*DocumentationRequest) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *DocumentationRequest) GetTicket() []string {
-	if x != nil {
-		return x.Ticket
-	}
-	return nil
-}
-
-func (x *DocumentationRequest) GetFilter() []string {
-	if x != nil {
-		return x.Filter
-	}
-	return nil
-}
-
-func (x *DocumentationRequest) GetIncludeChildren() bool {
-	if x != nil {
-		return x.IncludeChildren
-	}
-	return false
+// Deprecated: Use DebugInfo.ProtoReflect.Descriptor instead.
+func (*DebugInfo) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DocumentationRequest) GetWorkspace() *Workspace {
+func (x *DebugInfo) GetPhaseTiming() []*DebugInfo_PhaseTiming {
 	if x != nil {
-		return x.Workspace
+		return x.PhaseTiming
 	}
 	return nil
 }
 
-func (x *DocumentationRequest) GetPatchAgainstWorkspace() bool {
-	if x != nil {
-		return x.PatchAgainstWorkspace
-	}
-	return false
-}
-
-type DocumentationReply struct {
+type MembersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Document            []*DocumentationReply_Document       `protobuf:"bytes,1,rep,name=document,proto3" json:"document,omitempty"`
-	Nodes               map[string]*common_go_proto.NodeInfo `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	DefinitionLocations map[string]*Anchor                   `protobuf:"bytes,3,rep,name=definition_locations,json=definitionLocations,proto3" json:"definition_locations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	BuildId             string                               `protobuf:"bytes,4,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	Container string `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	Kind      string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	PageSize  int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 }
 
-func (x *DocumentationReply) Reset() {
-	*x = DocumentationReply{}
+func (x *MembersRequest) Reset() {
+	*x = MembersRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[11]
+		mi := &file_kythe_proto_xref_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DocumentationReply) String() string {
+func (x *MembersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentationReply) ProtoMessage() {}
+func (*MembersRequest) ProtoMessage() {}
 
-func (x *DocumentationReply) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[11]
+func (x *MembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1577,64 +2276,66 @@ func (x *DocumentationReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentationReply.ProtoReflect.Descriptor instead.
-func (*DocumentationReply) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use MembersRequest.ProtoReflect.Descriptor instead.
+func (*MembersRequest) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *DocumentationReply) GetDocument() []*DocumentationReply_Document {
+func (x *MembersRequest) GetContainer() string {
 	if x != nil {
-		return x.Document
+		return x.Container
 	}
-	return nil
+	return ""
 }
 
-func (x *DocumentationReply) GetNodes() map[string]*common_go_proto.NodeInfo {
+func (x *MembersRequest) GetKind() string {
 	if x != nil {
-		return x.Nodes
+		return x.Kind
 	}
-	return nil
+	return ""
 }
 
-func (x *DocumentationReply) GetDefinitionLocations() map[string]*Anchor {
+func (x *MembersRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.DefinitionLocations
+		return x.PageSize
 	}
-	return nil
+	return 0
 }
 
-func (x *DocumentationReply) GetBuildId() string {
+func (x *MembersRequest) GetPageToken() string {
 	if x != nil {
-		return x.BuildId
+		return x.PageToken
 	}
 	return ""
 }
 
-type Workspace struct {
+type MembersReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Member        []*MembersReply_Member `protobuf:"bytes,1,rep,name=member,proto3" json:"member,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (x *Workspace) Reset() {
-	*x = Workspace{}
+func (x *MembersReply) Reset() {
+	*x = MembersReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[12]
+		mi := &file_kythe_proto_xref_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Workspace) String() string {
+func (x *MembersReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Workspace) ProtoMessage() {}
+func (*MembersReply) ProtoMessage() {}
 
-func (x *Workspace) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[12]
+func (x *MembersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1645,49 +2346,59 @@ func (x *Workspace) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
-func (*Workspace) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use MembersReply.ProtoReflect.Descriptor instead.
+func (*MembersReply) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *Workspace) GetUri() string {
+func (x *MembersReply) GetMember() []*MembersReply_Member {
 	if x != nil {
-		return x.Uri
+		return x.Member
+	}
+	return nil
+}
+
+func (x *MembersReply) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *MembersReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type DecorationsReply_Reference struct {
+type RollupRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TargetTicket     string                `protobuf:"bytes,2,opt,name=target_ticket,json=targetTicket,proto3" json:"target_ticket,omitempty"`
-	Kind             string                `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
-	TargetDefinition string                `protobuf:"bytes,4,opt,name=target_definition,json=targetDefinition,proto3" json:"target_definition,omitempty"`
-	Span             *common_go_proto.Span `protobuf:"bytes,5,opt,name=span,proto3" json:"span,omitempty"`
-	BuildConfig      string                `protobuf:"bytes,6,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
-	SemanticScope    string                `protobuf:"bytes,7,opt,name=semantic_scope,json=semanticScope,proto3" json:"semantic_scope,omitempty"`
-	TargetRevision   string                `protobuf:"bytes,8,opt,name=target_revision,json=targetRevision,proto3" json:"target_revision,omitempty"`
+	Container string `protobuf:"bytes,1,opt,name=container,proto3" json:"container,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 }
 
-func (x *DecorationsReply_Reference) Reset() {
-	*x = DecorationsReply_Reference{}
+func (x *RollupRequest) Reset() {
+	*x = RollupRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[13]
+		mi := &file_kythe_proto_xref_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DecorationsReply_Reference) String() string {
+func (x *RollupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DecorationsReply_Reference) ProtoMessage() {}
+func (*RollupRequest) ProtoMessage() {}
 
-func (x *DecorationsReply_Reference) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[13]
+func (x *RollupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1698,88 +2409,60 @@ func (x *DecorationsReply_Reference) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DecorationsReply_Reference.ProtoReflect.Descriptor instead.
-func (*DecorationsReply_Reference) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 0}
-}
-
-func (x *DecorationsReply_Reference) GetTargetTicket() string {
-	if x != nil {
-		return x.TargetTicket
-	}
-	return ""
-}
-
-func (x *DecorationsReply_Reference) GetKind() string {
-	if x != nil {
-		return x.Kind
-	}
-	return ""
-}
-
-func (x *DecorationsReply_Reference) GetTargetDefinition() string {
-	if x != nil {
-		return x.TargetDefinition
-	}
-	return ""
-}
-
-func (x *DecorationsReply_Reference) GetSpan() *common_go_proto.Span {
-	if x != nil {
-		return x.Span
-	}
-	return nil
+// Deprecated: Use RollupRequest.ProtoReflect.Descriptor instead.
+func (*RollupRequest) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *DecorationsReply_Reference) GetBuildConfig() string {
+func (x *RollupRequest) GetContainer() string {
 	if x != nil {
-		return x.BuildConfig
+		return x.Container
 	}
 	return ""
 }
 
-func (x *DecorationsReply_Reference) GetSemanticScope() string {
+func (x *RollupRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.SemanticScope
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *DecorationsReply_Reference) GetTargetRevision() string {
+func (x *RollupRequest) GetPageToken() string {
 	if x != nil {
-		return x.TargetRevision
+		return x.PageToken
 	}
 	return ""
 }
 
-type DecorationsReply_Override struct {
+type RollupReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Target           string                         `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	TargetDefinition string                         `protobuf:"bytes,5,opt,name=target_definition,json=targetDefinition,proto3" json:"target_definition,omitempty"`
-	Kind             DecorationsReply_Override_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=kythe.proto.DecorationsReply_Override_Kind" json:"kind,omitempty"`
-	MarkedSource     *common_go_proto.MarkedSource  `protobuf:"bytes,4,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	ReferenceCount int64                 `protobuf:"varint,1,opt,name=reference_count,json=referenceCount,proto3" json:"reference_count,omitempty"`
+	TotalTargets   int64                 `protobuf:"varint,2,opt,name=total_targets,json=totalTargets,proto3" json:"total_targets,omitempty"`
+	Target         []*RollupReply_Target `protobuf:"bytes,3,rep,name=target,proto3" json:"target,omitempty"`
+	NextPageToken  string                `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (x *DecorationsReply_Override) Reset() {
-	*x = DecorationsReply_Override{}
+func (x *RollupReply) Reset() {
+	*x = RollupReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[14]
+		mi := &file_kythe_proto_xref_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DecorationsReply_Override) String() string {
+func (x *RollupReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DecorationsReply_Override) ProtoMessage() {}
+func (*RollupReply) ProtoMessage() {}
 
-func (x *DecorationsReply_Override) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[14]
+func (x *RollupReply) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1790,64 +2473,64 @@ func (x *DecorationsReply_Override) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DecorationsReply_Override.ProtoReflect.Descriptor instead.
-func (*DecorationsReply_Override) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 1}
+// Deprecated: Use RollupReply.ProtoReflect.Descriptor instead.
+func (*RollupReply) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DecorationsReply_Override) GetTarget() string {
+func (x *RollupReply) GetReferenceCount() int64 {
 	if x != nil {
-		return x.Target
+		return x.ReferenceCount
 	}
-	return ""
+	return 0
 }
 
-func (x *DecorationsReply_Override) GetTargetDefinition() string {
+func (x *RollupReply) GetTotalTargets() int64 {
 	if x != nil {
-		return x.TargetDefinition
+		return x.TotalTargets
 	}
-	return ""
+	return 0
 }
 
-func (x *DecorationsReply_Override) GetKind() DecorationsReply_Override_Kind {
+func (x *RollupReply) GetTarget() []*RollupReply_Target {
 	if x != nil {
-		return x.Kind
+		return x.Target
 	}
-	return DecorationsReply_Override_OVERRIDES
+	return nil
 }
 
-func (x *DecorationsReply_Override) GetMarkedSource() *common_go_proto.MarkedSource {
+func (x *RollupReply) GetNextPageToken() string {
 	if x != nil {
-		return x.MarkedSource
+		return x.NextPageToken
 	}
-	return nil
+	return ""
 }
 
-type DecorationsReply_Overrides struct {
+type OutlineRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Override []*DecorationsReply_Override `protobuf:"bytes,1,rep,name=override,proto3" json:"override,omitempty"`
+	File string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
 }
 
-func (x *DecorationsReply_Overrides) Reset() {
-	*x = DecorationsReply_Overrides{}
+func (x *OutlineRequest) Reset() {
+	*x = OutlineRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[15]
+		mi := &file_kythe_proto_xref_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DecorationsReply_Overrides) String() string {
+func (x *OutlineRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DecorationsReply_Overrides) ProtoMessage() {}
+func (*OutlineRequest) ProtoMessage() {}
 
-func (x *DecorationsReply_Overrides) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[15]
+func (x *OutlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1858,45 +2541,43 @@ func (x *DecorationsReply_Overrides) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DecorationsReply_Overrides.ProtoReflect.Descriptor instead.
-func (*DecorationsReply_Overrides) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 2}
+// Deprecated: Use OutlineRequest.ProtoReflect.Descriptor instead.
+func (*OutlineRequest) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *DecorationsReply_Overrides) GetOverride() []*DecorationsReply_Override {
+func (x *OutlineRequest) GetFile() string {
 	if x != nil {
-		return x.Override
+		return x.File
 	}
-	return nil
+	return ""
 }
 
-type CrossReferencesReply_RelatedNode struct {
+type OutlineReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket       string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
-	RelationKind string `protobuf:"bytes,2,opt,name=relation_kind,json=relationKind,proto3" json:"relation_kind,omitempty"`
-	Ordinal      int32  `protobuf:"varint,3,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	Symbol []*OutlineReply_Symbol `protobuf:"bytes,1,rep,name=symbol,proto3" json:"symbol,omitempty"`
 }
 
-func (x *CrossReferencesReply_RelatedNode) Reset() {
-	*x = CrossReferencesReply_RelatedNode{}
+func (x *OutlineReply) Reset() {
+	*x = OutlineReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[19]
+		mi := &file_kythe_proto_xref_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CrossReferencesReply_RelatedNode) String() string {
+func (x *OutlineReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CrossReferencesReply_RelatedNode) ProtoMessage() {}
+func (*OutlineReply) ProtoMessage() {}
 
-func (x *CrossReferencesReply_RelatedNode) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[19]
+func (x *OutlineReply) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1907,60 +2588,48 @@ func (x *CrossReferencesReply_RelatedNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CrossReferencesReply_RelatedNode.ProtoReflect.Descriptor instead.
-func (*CrossReferencesReply_RelatedNode) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{9, 0}
-}
-
-func (x *CrossReferencesReply_RelatedNode) GetTicket() string {
-	if x != nil {
-		return x.Ticket
-	}
-	return ""
-}
-
-func (x *CrossReferencesReply_RelatedNode) GetRelationKind() string {
-	if x != nil {
-		return x.RelationKind
-	}
-	return ""
+// Deprecated: Use OutlineReply.ProtoReflect.Descriptor instead.
+func (*OutlineReply) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CrossReferencesReply_RelatedNode) GetOrdinal() int32 {
+func (x *OutlineReply) GetSymbol() []*OutlineReply_Symbol {
 	if x != nil {
-		return x.Ordinal
+		return x.Symbol
 	}
-	return 0
+	return nil
 }
 
-type CrossReferencesReply_RelatedAnchor struct {
+type DocumentationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Anchor       *Anchor                       `protobuf:"bytes,1,opt,name=anchor,proto3" json:"anchor,omitempty"`
-	MarkedSource *common_go_proto.MarkedSource `protobuf:"bytes,5,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
-	Site         []*Anchor                     `protobuf:"bytes,3,rep,name=site,proto3" json:"site,omitempty"`
-	Ticket       string                        `protobuf:"bytes,4,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Ticket                []string   `protobuf:"bytes,1,rep,name=ticket,proto3" json:"ticket,omitempty"`
+	Filter                []string   `protobuf:"bytes,2,rep,name=filter,proto3" json:"filter,omitempty"`
+	IncludeChildren       bool       `protobuf:"varint,3,opt,name=include_children,json=includeChildren,proto3" json:"include_children,omitempty"`
+	Workspace             *Workspace `protobuf:"bytes,4,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	PatchAgainstWorkspace bool       `protobuf:"varint,5,opt,name=patch_against_workspace,json=patchAgainstWorkspace,proto3" json:"patch_against_workspace,omitempty"`
+	BuildId               string     `protobuf:"bytes,6,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
 }
 
-func (x *CrossReferencesReply_RelatedAnchor) Reset() {
-	*x = CrossReferencesReply_RelatedAnchor{}
+func (x *DocumentationRequest) Reset() {
+	*x = DocumentationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[20]
+		mi := &file_kythe_proto_xref_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CrossReferencesReply_RelatedAnchor) String() string {
+func (x *DocumentationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CrossReferencesReply_RelatedAnchor) ProtoMessage() {}
+func (*DocumentationRequest) ProtoMessage() {}
 
-func (x *CrossReferencesReply_RelatedAnchor) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[20]
+func (x *DocumentationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1971,70 +2640,82 @@ func (x *CrossReferencesReply_RelatedAnchor) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CrossReferencesReply_RelatedAnchor.ProtoReflect.Descriptor instead.
-func (*CrossReferencesReply_RelatedAnchor) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{9, 1}
+// Deprecated: Use DocumentationRequest.ProtoReflect.Descriptor instead.
+func (*DocumentationRequest) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CrossReferencesReply_RelatedAnchor) GetAnchor() *Anchor {
+func (x *DocumentationRequest) GetTicket() []string {
 	if x != nil {
-		return x.Anchor
+		return x.Ticket
 	}
 	return nil
 }
 
-func (x *CrossReferencesReply_RelatedAnchor) GetMarkedSource() *common_go_proto.MarkedSource {
+func (x *DocumentationRequest) GetFilter() []string {
 	if x != nil {
-		return x.MarkedSource
+		return x.Filter
 	}
 	return nil
 }
 
-func (x *CrossReferencesReply_RelatedAnchor) GetSite() []*Anchor {
+func (x *DocumentationRequest) GetIncludeChildren() bool {
 	if x != nil {
-		return x.Site
+		return x.IncludeChildren
+	}
+	return false
+}
+
+func (x *DocumentationRequest) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
 	}
 	return nil
 }
 
-func (x *CrossReferencesReply_RelatedAnchor) GetTicket() string {
+func (x *DocumentationRequest) GetPatchAgainstWorkspace() bool {
 	if x != nil {
-		return x.Ticket
+		return x.PatchAgainstWorkspace
+	}
+	return false
+}
+
+func (x *DocumentationRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
 	}
 	return ""
 }
 
-type CrossReferencesReply_CrossReferenceSet struct {
+type DocumentationReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket       string                                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
-	MarkedSource *common_go_proto.MarkedSource         `protobuf:"bytes,8,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
-	Definition   []*CrossReferencesReply_RelatedAnchor `protobuf:"bytes,2,rep,name=definition,proto3" json:"definition,omitempty"`
-	Declaration  []*CrossReferencesReply_RelatedAnchor `protobuf:"bytes,5,rep,name=declaration,proto3" json:"declaration,omitempty"`
-	Reference    []*CrossReferencesReply_RelatedAnchor `protobuf:"bytes,3,rep,name=reference,proto3" json:"reference,omitempty"`
-	Caller       []*CrossReferencesReply_RelatedAnchor `protobuf:"bytes,6,rep,name=caller,proto3" json:"caller,omitempty"`
-	RelatedNode  []*CrossReferencesReply_RelatedNode   `protobuf:"bytes,10,rep,name=related_node,json=relatedNode,proto3" json:"related_node,omitempty"`
+	Document            []*DocumentationReply_Document       `protobuf:"bytes,1,rep,name=document,proto3" json:"document,omitempty"`
+	Nodes               map[string]*common_go_proto.NodeInfo `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DefinitionLocations map[string]*Anchor                   `protobuf:"bytes,3,rep,name=definition_locations,json=definitionLocations,proto3" json:"definition_locations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BuildId             string                               `protobuf:"bytes,4,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	BuildTime           *timestamppb.Timestamp               `protobuf:"bytes,5,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
 }
 
-func (x *CrossReferencesReply_CrossReferenceSet) Reset() {
-	*x = CrossReferencesReply_CrossReferenceSet{}
+func (x *DocumentationReply) Reset() {
+	*x = DocumentationReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[21]
+		mi := &file_kythe_proto_xref_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CrossReferencesReply_CrossReferenceSet) String() string {
+func (x *DocumentationReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CrossReferencesReply_CrossReferenceSet) ProtoMessage() {}
+func (*DocumentationReply) ProtoMessage() {}
 
-func (x *CrossReferencesReply_CrossReferenceSet) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[21]
+func (x *DocumentationReply) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2045,90 +2726,71 @@ func (x *CrossReferencesReply_CrossReferenceSet) ProtoReflect() protoreflect.Mes
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CrossReferencesReply_CrossReferenceSet.ProtoReflect.Descriptor instead.
-func (*CrossReferencesReply_CrossReferenceSet) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{9, 2}
-}
-
-func (x *CrossReferencesReply_CrossReferenceSet) GetTicket() string {
-	if x != nil {
-		return x.Ticket
-	}
-	return ""
-}
-
-func (x *CrossReferencesReply_CrossReferenceSet) GetMarkedSource() *common_go_proto.MarkedSource {
-	if x != nil {
-		return x.MarkedSource
-	}
-	return nil
+// Deprecated: Use DocumentationReply.ProtoReflect.Descriptor instead.
+func (*DocumentationReply) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CrossReferencesReply_CrossReferenceSet) GetDefinition() []*CrossReferencesReply_RelatedAnchor {
+func (x *DocumentationReply) GetDocument() []*DocumentationReply_Document {
 	if x != nil {
-		return x.Definition
+		return x.Document
 	}
 	return nil
 }
 
-func (x *CrossReferencesReply_CrossReferenceSet) GetDeclaration() []*CrossReferencesReply_RelatedAnchor {
+func (x *DocumentationReply) GetNodes() map[string]*common_go_proto.NodeInfo {
 	if x != nil {
-		return x.Declaration
+		return x.Nodes
 	}
 	return nil
 }
 
-func (x *CrossReferencesReply_CrossReferenceSet) GetReference() []*CrossReferencesReply_RelatedAnchor {
+func (x *DocumentationReply) GetDefinitionLocations() map[string]*Anchor {
 	if x != nil {
-		return x.Reference
+		return x.DefinitionLocations
 	}
 	return nil
 }
 
-func (x *CrossReferencesReply_CrossReferenceSet) GetCaller() []*CrossReferencesReply_RelatedAnchor {
+func (x *DocumentationReply) GetBuildId() string {
 	if x != nil {
-		return x.Caller
+		return x.BuildId
 	}
-	return nil
+	return ""
 }
 
-func (x *CrossReferencesReply_CrossReferenceSet) GetRelatedNode() []*CrossReferencesReply_RelatedNode {
+func (x *DocumentationReply) GetBuildTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.RelatedNode
+		return x.BuildTime
 	}
 	return nil
 }
 
-type CrossReferencesReply_Total struct {
+type BatchDocumentationReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Definitions            int64            `protobuf:"varint,1,opt,name=definitions,proto3" json:"definitions,omitempty"`
-	Declarations           int64            `protobuf:"varint,2,opt,name=declarations,proto3" json:"declarations,omitempty"`
-	References             int64            `protobuf:"varint,3,opt,name=references,proto3" json:"references,omitempty"`
-	Documentation          int64            `protobuf:"varint,4,opt,name=documentation,proto3" json:"documentation,omitempty"`
-	Callers                int64            `protobuf:"varint,5,opt,name=callers,proto3" json:"callers,omitempty"`
-	RelatedNodesByRelation map[string]int64 `protobuf:"bytes,6,rep,name=related_nodes_by_relation,json=relatedNodesByRelation,proto3" json:"related_nodes_by_relation,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Replies map[string]*DocumentationReply `protobuf:"bytes,1,rep,name=replies,proto3" json:"replies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *CrossReferencesReply_Total) Reset() {
-	*x = CrossReferencesReply_Total{}
+func (x *BatchDocumentationReply) Reset() {
+	*x = BatchDocumentationReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[22]
+		mi := &file_kythe_proto_xref_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CrossReferencesReply_Total) String() string {
+func (x *BatchDocumentationReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CrossReferencesReply_Total) ProtoMessage() {}
+func (*BatchDocumentationReply) ProtoMessage() {}
 
-func (x *CrossReferencesReply_Total) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[22]
+func (x *BatchDocumentationReply) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2139,81 +2801,99 @@ func (x *CrossReferencesReply_Total) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CrossReferencesReply_Total.ProtoReflect.Descriptor instead.
-func (*CrossReferencesReply_Total) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{9, 3}
+// Deprecated: Use BatchDocumentationReply.ProtoReflect.Descriptor instead.
+func (*BatchDocumentationReply) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *CrossReferencesReply_Total) GetDefinitions() int64 {
+func (x *BatchDocumentationReply) GetReplies() map[string]*DocumentationReply {
 	if x != nil {
-		return x.Definitions
+		return x.Replies
 	}
-	return 0
+	return nil
 }
 
-func (x *CrossReferencesReply_Total) GetDeclarations() int64 {
-	if x != nil {
-		return x.Declarations
-	}
-	return 0
+type ResolveFingerprintRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket      string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Fingerprint string `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
 }
 
-func (x *CrossReferencesReply_Total) GetReferences() int64 {
-	if x != nil {
-		return x.References
+func (x *ResolveFingerprintRequest) Reset() {
+	*x = ResolveFingerprintRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *CrossReferencesReply_Total) GetDocumentation() int64 {
-	if x != nil {
-		return x.Documentation
+func (x *ResolveFingerprintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveFingerprintRequest) ProtoMessage() {}
+
+func (x *ResolveFingerprintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CrossReferencesReply_Total) GetCallers() int64 {
+// Deprecated: Use ResolveFingerprintRequest.ProtoReflect.Descriptor instead.
+func (*ResolveFingerprintRequest) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResolveFingerprintRequest) GetTicket() string {
 	if x != nil {
-		return x.Callers
+		return x.Ticket
 	}
-	return 0
+	return ""
 }
 
-func (x *CrossReferencesReply_Total) GetRelatedNodesByRelation() map[string]int64 {
+func (x *ResolveFingerprintRequest) GetFingerprint() string {
 	if x != nil {
-		return x.RelatedNodesByRelation
+		return x.Fingerprint
 	}
-	return nil
+	return ""
 }
 
-type DocumentationReply_Document struct {
+type ResolveFingerprintReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ticket       string                         `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
-	Text         *Printable                     `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
-	MarkedSource *common_go_proto.MarkedSource  `protobuf:"bytes,8,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
-	Children     []*DocumentationReply_Document `protobuf:"bytes,9,rep,name=children,proto3" json:"children,omitempty"`
+	Anchor       *Anchor `protobuf:"bytes,1,opt,name=anchor,proto3" json:"anchor,omitempty"`
+	TargetTicket string  `protobuf:"bytes,2,opt,name=target_ticket,json=targetTicket,proto3" json:"target_ticket,omitempty"`
 }
 
-func (x *DocumentationReply_Document) Reset() {
-	*x = DocumentationReply_Document{}
+func (x *ResolveFingerprintReply) Reset() {
+	*x = ResolveFingerprintReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kythe_proto_xref_proto_msgTypes[27]
+		mi := &file_kythe_proto_xref_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DocumentationReply_Document) String() string {
+func (x *ResolveFingerprintReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DocumentationReply_Document) ProtoMessage() {}
+func (*ResolveFingerprintReply) ProtoMessage() {}
 
-func (x *DocumentationReply_Document) ProtoReflect() protoreflect.Message {
-	mi := &file_kythe_proto_xref_proto_msgTypes[27]
+func (x *ResolveFingerprintReply) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2224,14 +2904,1508 @@ func (x *DocumentationReply_Document) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DocumentationReply_Document.ProtoReflect.Descriptor instead.
-func (*DocumentationReply_Document) Descriptor() ([]byte, []int) {
-	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 0}
+// Deprecated: Use ResolveFingerprintReply.ProtoReflect.Descriptor instead.
+func (*ResolveFingerprintReply) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *DocumentationReply_Document) GetTicket() string {
+func (x *ResolveFingerprintReply) GetAnchor() *Anchor {
 	if x != nil {
-		return x.Ticket
+		return x.Anchor
+	}
+	return nil
+}
+
+func (x *ResolveFingerprintReply) GetTargetTicket() string {
+	if x != nil {
+		return x.TargetTicket
+	}
+	return ""
+}
+
+type Workspace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+}
+
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Workspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspace) ProtoMessage() {}
+
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Workspace) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+type DecorationsReply_Reference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetTicket              string                                  `protobuf:"bytes,2,opt,name=target_ticket,json=targetTicket,proto3" json:"target_ticket,omitempty"`
+	Kind                      string                                  `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	TargetDefinition          string                                  `protobuf:"bytes,4,opt,name=target_definition,json=targetDefinition,proto3" json:"target_definition,omitempty"`
+	Span                      *common_go_proto.Span                   `protobuf:"bytes,5,opt,name=span,proto3" json:"span,omitempty"`
+	BuildConfig               string                                  `protobuf:"bytes,6,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	SemanticScope             string                                  `protobuf:"bytes,7,opt,name=semantic_scope,json=semanticScope,proto3" json:"semantic_scope,omitempty"`
+	TargetRevision            string                                  `protobuf:"bytes,8,opt,name=target_revision,json=targetRevision,proto3" json:"target_revision,omitempty"`
+	TargetDisplayName         string                                  `protobuf:"bytes,9,opt,name=target_display_name,json=targetDisplayName,proto3" json:"target_display_name,omitempty"`
+	TargetDefinitionKind      TargetDefinitionKind                    `protobuf:"varint,12,opt,name=target_definition_kind,json=targetDefinitionKind,proto3,enum=kythe.proto.TargetDefinitionKind" json:"target_definition_kind,omitempty"`
+	Overrides                 []string                                `protobuf:"bytes,13,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	OverriddenBy              []string                                `protobuf:"bytes,14,rep,name=overridden_by,json=overriddenBy,proto3" json:"overridden_by,omitempty"`
+	ReferenceCount            int64                                   `protobuf:"varint,15,opt,name=reference_count,json=referenceCount,proto3" json:"reference_count,omitempty"`
+	TargetDefinitionCandidate []*DecorationsReply_DefinitionCandidate `protobuf:"bytes,16,rep,name=target_definition_candidate,json=targetDefinitionCandidate,proto3" json:"target_definition_candidate,omitempty"`
+	Fingerprint               string                                  `protobuf:"bytes,17,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	TargetDeprecated          bool                                    `protobuf:"varint,18,opt,name=target_deprecated,json=targetDeprecated,proto3" json:"target_deprecated,omitempty"`
+	TargetDeprecationMessage  string                                  `protobuf:"bytes,19,opt,name=target_deprecation_message,json=targetDeprecationMessage,proto3" json:"target_deprecation_message,omitempty"`
+	Classification            Anchor_Classification                   `protobuf:"varint,20,opt,name=classification,proto3,enum=kythe.proto.Anchor_Classification" json:"classification,omitempty"`
+}
+
+func (x *DecorationsReply_Reference) Reset() {
+	*x = DecorationsReply_Reference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecorationsReply_Reference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecorationsReply_Reference) ProtoMessage() {}
+
+func (x *DecorationsReply_Reference) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecorationsReply_Reference.ProtoReflect.Descriptor instead.
+func (*DecorationsReply_Reference) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *DecorationsReply_Reference) GetTargetTicket() string {
+	if x != nil {
+		return x.TargetTicket
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetTargetDefinition() string {
+	if x != nil {
+		return x.TargetDefinition
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetSpan() *common_go_proto.Span {
+	if x != nil {
+		return x.Span
+	}
+	return nil
+}
+
+func (x *DecorationsReply_Reference) GetBuildConfig() string {
+	if x != nil {
+		return x.BuildConfig
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetSemanticScope() string {
+	if x != nil {
+		return x.SemanticScope
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetTargetRevision() string {
+	if x != nil {
+		return x.TargetRevision
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetTargetDisplayName() string {
+	if x != nil {
+		return x.TargetDisplayName
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetTargetDefinitionKind() TargetDefinitionKind {
+	if x != nil {
+		return x.TargetDefinitionKind
+	}
+	return TargetDefinitionKind_UNKNOWN_TARGET_DEFINITION
+}
+
+func (x *DecorationsReply_Reference) GetOverrides() []string {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+func (x *DecorationsReply_Reference) GetOverriddenBy() []string {
+	if x != nil {
+		return x.OverriddenBy
+	}
+	return nil
+}
+
+func (x *DecorationsReply_Reference) GetReferenceCount() int64 {
+	if x != nil {
+		return x.ReferenceCount
+	}
+	return 0
+}
+
+func (x *DecorationsReply_Reference) GetTargetDefinitionCandidate() []*DecorationsReply_DefinitionCandidate {
+	if x != nil {
+		return x.TargetDefinitionCandidate
+	}
+	return nil
+}
+
+func (x *DecorationsReply_Reference) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetTargetDeprecated() bool {
+	if x != nil {
+		return x.TargetDeprecated
+	}
+	return false
+}
+
+func (x *DecorationsReply_Reference) GetTargetDeprecationMessage() string {
+	if x != nil {
+		return x.TargetDeprecationMessage
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Reference) GetClassification() Anchor_Classification {
+	if x != nil {
+		return x.Classification
+	}
+	return Anchor_UNCLASSIFIED
+}
+
+type DecorationsReply_DefinitionCandidate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket string               `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind   TargetDefinitionKind `protobuf:"varint,2,opt,name=kind,proto3,enum=kythe.proto.TargetDefinitionKind" json:"kind,omitempty"`
+}
+
+func (x *DecorationsReply_DefinitionCandidate) Reset() {
+	*x = DecorationsReply_DefinitionCandidate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecorationsReply_DefinitionCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecorationsReply_DefinitionCandidate) ProtoMessage() {}
+
+func (x *DecorationsReply_DefinitionCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecorationsReply_DefinitionCandidate.ProtoReflect.Descriptor instead.
+func (*DecorationsReply_DefinitionCandidate) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *DecorationsReply_DefinitionCandidate) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *DecorationsReply_DefinitionCandidate) GetKind() TargetDefinitionKind {
+	if x != nil {
+		return x.Kind
+	}
+	return TargetDefinitionKind_UNKNOWN_TARGET_DEFINITION
+}
+
+type DecorationsReply_Override struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target           string                         `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	TargetDefinition string                         `protobuf:"bytes,5,opt,name=target_definition,json=targetDefinition,proto3" json:"target_definition,omitempty"`
+	Kind             DecorationsReply_Override_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=kythe.proto.DecorationsReply_Override_Kind" json:"kind,omitempty"`
+	MarkedSource     *common_go_proto.MarkedSource  `protobuf:"bytes,4,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+}
+
+func (x *DecorationsReply_Override) Reset() {
+	*x = DecorationsReply_Override{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecorationsReply_Override) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecorationsReply_Override) ProtoMessage() {}
+
+func (x *DecorationsReply_Override) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecorationsReply_Override.ProtoReflect.Descriptor instead.
+func (*DecorationsReply_Override) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 2}
+}
+
+func (x *DecorationsReply_Override) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Override) GetTargetDefinition() string {
+	if x != nil {
+		return x.TargetDefinition
+	}
+	return ""
+}
+
+func (x *DecorationsReply_Override) GetKind() DecorationsReply_Override_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return DecorationsReply_Override_OVERRIDES
+}
+
+func (x *DecorationsReply_Override) GetMarkedSource() *common_go_proto.MarkedSource {
+	if x != nil {
+		return x.MarkedSource
+	}
+	return nil
+}
+
+type DecorationsReply_Overrides struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Override []*DecorationsReply_Override `protobuf:"bytes,1,rep,name=override,proto3" json:"override,omitempty"`
+}
+
+func (x *DecorationsReply_Overrides) Reset() {
+	*x = DecorationsReply_Overrides{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecorationsReply_Overrides) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecorationsReply_Overrides) ProtoMessage() {}
+
+func (x *DecorationsReply_Overrides) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecorationsReply_Overrides.ProtoReflect.Descriptor instead.
+func (*DecorationsReply_Overrides) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 3}
+}
+
+func (x *DecorationsReply_Overrides) GetOverride() []*DecorationsReply_Override {
+	if x != nil {
+		return x.Override
+	}
+	return nil
+}
+
+type DecorationsReply_BuildConfigGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildConfig string                        `protobuf:"bytes,1,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	Reference   []*DecorationsReply_Reference `protobuf:"bytes,2,rep,name=reference,proto3" json:"reference,omitempty"`
+}
+
+func (x *DecorationsReply_BuildConfigGroup) Reset() {
+	*x = DecorationsReply_BuildConfigGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecorationsReply_BuildConfigGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecorationsReply_BuildConfigGroup) ProtoMessage() {}
+
+func (x *DecorationsReply_BuildConfigGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecorationsReply_BuildConfigGroup.ProtoReflect.Descriptor instead.
+func (*DecorationsReply_BuildConfigGroup) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{3, 4}
+}
+
+func (x *DecorationsReply_BuildConfigGroup) GetBuildConfig() string {
+	if x != nil {
+		return x.BuildConfig
+	}
+	return ""
+}
+
+func (x *DecorationsReply_BuildConfigGroup) GetReference() []*DecorationsReply_Reference {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+type Anchor_SnippetRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *Anchor_SnippetRange) Reset() {
+	*x = Anchor_SnippetRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Anchor_SnippetRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Anchor_SnippetRange) ProtoMessage() {}
+
+func (x *Anchor_SnippetRange) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Anchor_SnippetRange.ProtoReflect.Descriptor instead.
+func (*Anchor_SnippetRange) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *Anchor_SnippetRange) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Anchor_SnippetRange) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type CrossReferencesReply_RelatedNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket             string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	RelationKind       string `protobuf:"bytes,2,opt,name=relation_kind,json=relationKind,proto3" json:"relation_kind,omitempty"`
+	Ordinal            int32  `protobuf:"varint,3,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	DisplayName        string `protobuf:"bytes,4,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Deprecated         bool   `protobuf:"varint,5,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	DeprecationMessage string `protobuf:"bytes,6,opt,name=deprecation_message,json=deprecationMessage,proto3" json:"deprecation_message,omitempty"`
+}
+
+func (x *CrossReferencesReply_RelatedNode) Reset() {
+	*x = CrossReferencesReply_RelatedNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesReply_RelatedNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesReply_RelatedNode) ProtoMessage() {}
+
+func (x *CrossReferencesReply_RelatedNode) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesReply_RelatedNode.ProtoReflect.Descriptor instead.
+func (*CrossReferencesReply_RelatedNode) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 0}
+}
+
+func (x *CrossReferencesReply_RelatedNode) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *CrossReferencesReply_RelatedNode) GetRelationKind() string {
+	if x != nil {
+		return x.RelationKind
+	}
+	return ""
+}
+
+func (x *CrossReferencesReply_RelatedNode) GetOrdinal() int32 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+func (x *CrossReferencesReply_RelatedNode) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *CrossReferencesReply_RelatedNode) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *CrossReferencesReply_RelatedNode) GetDeprecationMessage() string {
+	if x != nil {
+		return x.DeprecationMessage
+	}
+	return ""
+}
+
+type CrossReferencesReply_RelatedNodeList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelationKind string   `protobuf:"bytes,1,opt,name=relation_kind,json=relationKind,proto3" json:"relation_kind,omitempty"`
+	Ticket       []string `protobuf:"bytes,2,rep,name=ticket,proto3" json:"ticket,omitempty"`
+}
+
+func (x *CrossReferencesReply_RelatedNodeList) Reset() {
+	*x = CrossReferencesReply_RelatedNodeList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesReply_RelatedNodeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesReply_RelatedNodeList) ProtoMessage() {}
+
+func (x *CrossReferencesReply_RelatedNodeList) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesReply_RelatedNodeList.ProtoReflect.Descriptor instead.
+func (*CrossReferencesReply_RelatedNodeList) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 1}
+}
+
+func (x *CrossReferencesReply_RelatedNodeList) GetRelationKind() string {
+	if x != nil {
+		return x.RelationKind
+	}
+	return ""
+}
+
+func (x *CrossReferencesReply_RelatedNodeList) GetTicket() []string {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+type CrossReferencesReply_ReferenceGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind      string                                `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Reference []*CrossReferencesReply_RelatedAnchor `protobuf:"bytes,2,rep,name=reference,proto3" json:"reference,omitempty"`
+}
+
+func (x *CrossReferencesReply_ReferenceGroup) Reset() {
+	*x = CrossReferencesReply_ReferenceGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesReply_ReferenceGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesReply_ReferenceGroup) ProtoMessage() {}
+
+func (x *CrossReferencesReply_ReferenceGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesReply_ReferenceGroup.ProtoReflect.Descriptor instead.
+func (*CrossReferencesReply_ReferenceGroup) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 2}
+}
+
+func (x *CrossReferencesReply_ReferenceGroup) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CrossReferencesReply_ReferenceGroup) GetReference() []*CrossReferencesReply_RelatedAnchor {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+type CrossReferencesReply_BuildConfigGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BuildConfig string                                `protobuf:"bytes,1,opt,name=build_config,json=buildConfig,proto3" json:"build_config,omitempty"`
+	Definition  []*CrossReferencesReply_RelatedAnchor `protobuf:"bytes,2,rep,name=definition,proto3" json:"definition,omitempty"`
+	Declaration []*CrossReferencesReply_RelatedAnchor `protobuf:"bytes,3,rep,name=declaration,proto3" json:"declaration,omitempty"`
+	Reference   []*CrossReferencesReply_RelatedAnchor `protobuf:"bytes,4,rep,name=reference,proto3" json:"reference,omitempty"`
+}
+
+func (x *CrossReferencesReply_BuildConfigGroup) Reset() {
+	*x = CrossReferencesReply_BuildConfigGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesReply_BuildConfigGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesReply_BuildConfigGroup) ProtoMessage() {}
+
+func (x *CrossReferencesReply_BuildConfigGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesReply_BuildConfigGroup.ProtoReflect.Descriptor instead.
+func (*CrossReferencesReply_BuildConfigGroup) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 3}
+}
+
+func (x *CrossReferencesReply_BuildConfigGroup) GetBuildConfig() string {
+	if x != nil {
+		return x.BuildConfig
+	}
+	return ""
+}
+
+func (x *CrossReferencesReply_BuildConfigGroup) GetDefinition() []*CrossReferencesReply_RelatedAnchor {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_BuildConfigGroup) GetDeclaration() []*CrossReferencesReply_RelatedAnchor {
+	if x != nil {
+		return x.Declaration
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_BuildConfigGroup) GetReference() []*CrossReferencesReply_RelatedAnchor {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+type CrossReferencesReply_RelatedAnchor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Anchor       *Anchor                       `protobuf:"bytes,1,opt,name=anchor,proto3" json:"anchor,omitempty"`
+	MarkedSource *common_go_proto.MarkedSource `protobuf:"bytes,5,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	Site         []*Anchor                     `protobuf:"bytes,3,rep,name=site,proto3" json:"site,omitempty"`
+	Ticket       string                        `protobuf:"bytes,4,opt,name=ticket,proto3" json:"ticket,omitempty"`
+}
+
+func (x *CrossReferencesReply_RelatedAnchor) Reset() {
+	*x = CrossReferencesReply_RelatedAnchor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesReply_RelatedAnchor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesReply_RelatedAnchor) ProtoMessage() {}
+
+func (x *CrossReferencesReply_RelatedAnchor) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesReply_RelatedAnchor.ProtoReflect.Descriptor instead.
+func (*CrossReferencesReply_RelatedAnchor) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 4}
+}
+
+func (x *CrossReferencesReply_RelatedAnchor) GetAnchor() *Anchor {
+	if x != nil {
+		return x.Anchor
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_RelatedAnchor) GetMarkedSource() *common_go_proto.MarkedSource {
+	if x != nil {
+		return x.MarkedSource
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_RelatedAnchor) GetSite() []*Anchor {
+	if x != nil {
+		return x.Site
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_RelatedAnchor) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+type CrossReferencesReply_CallerCallSites struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Caller        string                        `protobuf:"bytes,1,opt,name=caller,proto3" json:"caller,omitempty"`
+	CallerAnchor  *Anchor                       `protobuf:"bytes,2,opt,name=caller_anchor,json=callerAnchor,proto3" json:"caller_anchor,omitempty"`
+	MarkedSource  *common_go_proto.MarkedSource `protobuf:"bytes,3,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	CallSiteCount int32                         `protobuf:"varint,4,opt,name=call_site_count,json=callSiteCount,proto3" json:"call_site_count,omitempty"`
+	CallSite      []*Anchor                     `protobuf:"bytes,5,rep,name=call_site,json=callSite,proto3" json:"call_site,omitempty"`
+}
+
+func (x *CrossReferencesReply_CallerCallSites) Reset() {
+	*x = CrossReferencesReply_CallerCallSites{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesReply_CallerCallSites) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesReply_CallerCallSites) ProtoMessage() {}
+
+func (x *CrossReferencesReply_CallerCallSites) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesReply_CallerCallSites.ProtoReflect.Descriptor instead.
+func (*CrossReferencesReply_CallerCallSites) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 5}
+}
+
+func (x *CrossReferencesReply_CallerCallSites) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *CrossReferencesReply_CallerCallSites) GetCallerAnchor() *Anchor {
+	if x != nil {
+		return x.CallerAnchor
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CallerCallSites) GetMarkedSource() *common_go_proto.MarkedSource {
+	if x != nil {
+		return x.MarkedSource
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CallerCallSites) GetCallSiteCount() int32 {
+	if x != nil {
+		return x.CallSiteCount
+	}
+	return 0
+}
+
+func (x *CrossReferencesReply_CallerCallSites) GetCallSite() []*Anchor {
+	if x != nil {
+		return x.CallSite
+	}
+	return nil
+}
+
+type CrossReferencesReply_CrossReferenceSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket           string                                   `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	MarkedSource     *common_go_proto.MarkedSource            `protobuf:"bytes,8,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	Definition       []*CrossReferencesReply_RelatedAnchor    `protobuf:"bytes,2,rep,name=definition,proto3" json:"definition,omitempty"`
+	Declaration      []*CrossReferencesReply_RelatedAnchor    `protobuf:"bytes,5,rep,name=declaration,proto3" json:"declaration,omitempty"`
+	Reference        []*CrossReferencesReply_RelatedAnchor    `protobuf:"bytes,3,rep,name=reference,proto3" json:"reference,omitempty"`
+	ReferenceGroup   []*CrossReferencesReply_ReferenceGroup   `protobuf:"bytes,13,rep,name=reference_group,json=referenceGroup,proto3" json:"reference_group,omitempty"`
+	Caller           []*CrossReferencesReply_RelatedAnchor    `protobuf:"bytes,6,rep,name=caller,proto3" json:"caller,omitempty"`
+	CallerCallSites  []*CrossReferencesReply_CallerCallSites  `protobuf:"bytes,15,rep,name=caller_call_sites,json=callerCallSites,proto3" json:"caller_call_sites,omitempty"`
+	RelatedNode      []*CrossReferencesReply_RelatedNode      `protobuf:"bytes,10,rep,name=related_node,json=relatedNode,proto3" json:"related_node,omitempty"`
+	RelatedNodeList  []*CrossReferencesReply_RelatedNodeList  `protobuf:"bytes,11,rep,name=related_node_list,json=relatedNodeList,proto3" json:"related_node_list,omitempty"`
+	Documentation    *Printable                               `protobuf:"bytes,12,opt,name=documentation,proto3" json:"documentation,omitempty"`
+	TruncatedKind    []string                                 `protobuf:"bytes,14,rep,name=truncated_kind,json=truncatedKind,proto3" json:"truncated_kind,omitempty"`
+	BuildConfigGroup []*CrossReferencesReply_BuildConfigGroup `protobuf:"bytes,16,rep,name=build_config_group,json=buildConfigGroup,proto3" json:"build_config_group,omitempty"`
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) Reset() {
+	*x = CrossReferencesReply_CrossReferenceSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesReply_CrossReferenceSet) ProtoMessage() {}
+
+func (x *CrossReferencesReply_CrossReferenceSet) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesReply_CrossReferenceSet.ProtoReflect.Descriptor instead.
+func (*CrossReferencesReply_CrossReferenceSet) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 6}
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetMarkedSource() *common_go_proto.MarkedSource {
+	if x != nil {
+		return x.MarkedSource
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetDefinition() []*CrossReferencesReply_RelatedAnchor {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetDeclaration() []*CrossReferencesReply_RelatedAnchor {
+	if x != nil {
+		return x.Declaration
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetReference() []*CrossReferencesReply_RelatedAnchor {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetReferenceGroup() []*CrossReferencesReply_ReferenceGroup {
+	if x != nil {
+		return x.ReferenceGroup
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetCaller() []*CrossReferencesReply_RelatedAnchor {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetCallerCallSites() []*CrossReferencesReply_CallerCallSites {
+	if x != nil {
+		return x.CallerCallSites
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetRelatedNode() []*CrossReferencesReply_RelatedNode {
+	if x != nil {
+		return x.RelatedNode
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetRelatedNodeList() []*CrossReferencesReply_RelatedNodeList {
+	if x != nil {
+		return x.RelatedNodeList
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetDocumentation() *Printable {
+	if x != nil {
+		return x.Documentation
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetTruncatedKind() []string {
+	if x != nil {
+		return x.TruncatedKind
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_CrossReferenceSet) GetBuildConfigGroup() []*CrossReferencesReply_BuildConfigGroup {
+	if x != nil {
+		return x.BuildConfigGroup
+	}
+	return nil
+}
+
+type CrossReferencesReply_Total struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Definitions            int64            `protobuf:"varint,1,opt,name=definitions,proto3" json:"definitions,omitempty"`
+	Declarations           int64            `protobuf:"varint,2,opt,name=declarations,proto3" json:"declarations,omitempty"`
+	References             int64            `protobuf:"varint,3,opt,name=references,proto3" json:"references,omitempty"`
+	Documentation          int64            `protobuf:"varint,4,opt,name=documentation,proto3" json:"documentation,omitempty"`
+	Callers                int64            `protobuf:"varint,5,opt,name=callers,proto3" json:"callers,omitempty"`
+	RelatedNodesByRelation map[string]int64 `protobuf:"bytes,6,rep,name=related_nodes_by_relation,json=relatedNodesByRelation,proto3" json:"related_nodes_by_relation,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	UncompressedPageBytes  int64            `protobuf:"varint,7,opt,name=uncompressed_page_bytes,json=uncompressedPageBytes,proto3" json:"uncompressed_page_bytes,omitempty"`
+}
+
+func (x *CrossReferencesReply_Total) Reset() {
+	*x = CrossReferencesReply_Total{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CrossReferencesReply_Total) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossReferencesReply_Total) ProtoMessage() {}
+
+func (x *CrossReferencesReply_Total) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossReferencesReply_Total.ProtoReflect.Descriptor instead.
+func (*CrossReferencesReply_Total) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{11, 7}
+}
+
+func (x *CrossReferencesReply_Total) GetDefinitions() int64 {
+	if x != nil {
+		return x.Definitions
+	}
+	return 0
+}
+
+func (x *CrossReferencesReply_Total) GetDeclarations() int64 {
+	if x != nil {
+		return x.Declarations
+	}
+	return 0
+}
+
+func (x *CrossReferencesReply_Total) GetReferences() int64 {
+	if x != nil {
+		return x.References
+	}
+	return 0
+}
+
+func (x *CrossReferencesReply_Total) GetDocumentation() int64 {
+	if x != nil {
+		return x.Documentation
+	}
+	return 0
+}
+
+func (x *CrossReferencesReply_Total) GetCallers() int64 {
+	if x != nil {
+		return x.Callers
+	}
+	return 0
+}
+
+func (x *CrossReferencesReply_Total) GetRelatedNodesByRelation() map[string]int64 {
+	if x != nil {
+		return x.RelatedNodesByRelation
+	}
+	return nil
+}
+
+func (x *CrossReferencesReply_Total) GetUncompressedPageBytes() int64 {
+	if x != nil {
+		return x.UncompressedPageBytes
+	}
+	return 0
+}
+
+type DebugInfo_PhaseTiming struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phase    string               `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	Duration *durationpb.Duration `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *DebugInfo_PhaseTiming) Reset() {
+	*x = DebugInfo_PhaseTiming{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugInfo_PhaseTiming) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugInfo_PhaseTiming) ProtoMessage() {}
+
+func (x *DebugInfo_PhaseTiming) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugInfo_PhaseTiming.ProtoReflect.Descriptor instead.
+func (*DebugInfo_PhaseTiming) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *DebugInfo_PhaseTiming) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *DebugInfo_PhaseTiming) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+type MembersReply_Member struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket      string  `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Subkind     string  `protobuf:"bytes,2,opt,name=subkind,proto3" json:"subkind,omitempty"`
+	DisplayName string  `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Definition  *Anchor `protobuf:"bytes,4,opt,name=definition,proto3" json:"definition,omitempty"`
+}
+
+func (x *MembersReply_Member) Reset() {
+	*x = MembersReply_Member{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MembersReply_Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembersReply_Member) ProtoMessage() {}
+
+func (x *MembersReply_Member) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembersReply_Member.ProtoReflect.Descriptor instead.
+func (*MembersReply_Member) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *MembersReply_Member) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *MembersReply_Member) GetSubkind() string {
+	if x != nil {
+		return x.Subkind
+	}
+	return ""
+}
+
+func (x *MembersReply_Member) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *MembersReply_Member) GetDefinition() *Anchor {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+type RollupReply_Target struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket         string `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind           string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	ReferenceCount int64  `protobuf:"varint,3,opt,name=reference_count,json=referenceCount,proto3" json:"reference_count,omitempty"`
+}
+
+func (x *RollupReply_Target) Reset() {
+	*x = RollupReply_Target{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RollupReply_Target) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollupReply_Target) ProtoMessage() {}
+
+func (x *RollupReply_Target) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollupReply_Target.ProtoReflect.Descriptor instead.
+func (*RollupReply_Target) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{16, 0}
+}
+
+func (x *RollupReply_Target) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *RollupReply_Target) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RollupReply_Target) GetReferenceCount() int64 {
+	if x != nil {
+		return x.ReferenceCount
+	}
+	return 0
+}
+
+type OutlineReply_Symbol struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket      string                 `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Kind        string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Subkind     string                 `protobuf:"bytes,3,opt,name=subkind,proto3" json:"subkind,omitempty"`
+	DisplayName string                 `protobuf:"bytes,4,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Definition  *Anchor                `protobuf:"bytes,5,opt,name=definition,proto3" json:"definition,omitempty"`
+	Child       []*OutlineReply_Symbol `protobuf:"bytes,6,rep,name=child,proto3" json:"child,omitempty"`
+}
+
+func (x *OutlineReply_Symbol) Reset() {
+	*x = OutlineReply_Symbol{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutlineReply_Symbol) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutlineReply_Symbol) ProtoMessage() {}
+
+func (x *OutlineReply_Symbol) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutlineReply_Symbol.ProtoReflect.Descriptor instead.
+func (*OutlineReply_Symbol) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{18, 0}
+}
+
+func (x *OutlineReply_Symbol) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *OutlineReply_Symbol) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *OutlineReply_Symbol) GetSubkind() string {
+	if x != nil {
+		return x.Subkind
+	}
+	return ""
+}
+
+func (x *OutlineReply_Symbol) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *OutlineReply_Symbol) GetDefinition() *Anchor {
+	if x != nil {
+		return x.Definition
+	}
+	return nil
+}
+
+func (x *OutlineReply_Symbol) GetChild() []*OutlineReply_Symbol {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type DocumentationReply_Document struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ticket       string                         `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Text         *Printable                     `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	MarkedSource *common_go_proto.MarkedSource  `protobuf:"bytes,8,opt,name=marked_source,json=markedSource,proto3" json:"marked_source,omitempty"`
+	Children     []*DocumentationReply_Document `protobuf:"bytes,9,rep,name=children,proto3" json:"children,omitempty"`
+}
+
+func (x *DocumentationReply_Document) Reset() {
+	*x = DocumentationReply_Document{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kythe_proto_xref_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DocumentationReply_Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentationReply_Document) ProtoMessage() {}
+
+func (x *DocumentationReply_Document) ProtoReflect() protoreflect.Message {
+	mi := &file_kythe_proto_xref_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentationReply_Document.ProtoReflect.Descriptor instead.
+func (*DocumentationReply_Document) Descriptor() ([]byte, []int) {
+	return file_kythe_proto_xref_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *DocumentationReply_Document) GetTicket() string {
+	if x != nil {
+		return x.Ticket
 	}
 	return ""
 }
@@ -2262,508 +4436,1012 @@ var File_kythe_proto_xref_proto protoreflect.FileDescriptor
 var file_kythe_proto_xref_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x78, 0x72,
 	0x65, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xa8, 0x01, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
-	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69,
-	0x63, 0x6b, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04,
-	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2c, 0x0a, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52, 0x04, 0x73, 0x70,
-	0x61, 0x6e, 0x22, 0x1a, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49,
-	0x4c, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x50, 0x41, 0x4e, 0x10, 0x01, 0x4a, 0x04,
-	0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0xa7, 0x05, 0x0a, 0x12, 0x44,
-	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x31, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x09, 0x73, 0x70, 0x61, 0x6e, 0x5f, 0x6b, 0x69, 0x6e,
-	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xa8, 0x01, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x69, 0x6e,
+	0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2c, 0x0a, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52,
+	0x04, 0x73, 0x70, 0x61, 0x6e, 0x22, 0x1a, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x08, 0x0a,
+	0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x50, 0x41, 0x4e, 0x10,
+	0x01, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0xa8, 0x0b,
+	0x0a, 0x12, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x09, 0x73, 0x70, 0x61, 0x6e, 0x5f,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x70, 0x61, 0x6e,
+	0x4b, 0x69, 0x6e, 0x64, 0x52, 0x08, 0x73, 0x70, 0x61, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x64, 0x69, 0x72, 0x74, 0x79, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x64, 0x69, 0x72, 0x74, 0x79, 0x42, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x65,
+	0x78, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x73, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x69, 0x61,
+	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x6e, 0x69, 0x70,
+	0x70, 0x65, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x73, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x5f, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x65, 0x6d,
+	0x61, 0x6e, 0x74, 0x69, 0x63, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x77,
+	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x36, 0x0a, 0x17, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x67, 0x61, 0x69, 0x6e,
+	0x73, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x15, 0x70, 0x61, 0x74, 0x63, 0x68, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x56,
+	0x0a, 0x15, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x21, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64,
+	0x52, 0x14, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69,
+	0x63, 0x61, 0x6c, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x4b, 0x69, 0x6e, 0x64, 0x73, 0x12,
+	0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6a, 0x75, 0x6d, 0x70, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6d,
+	0x61, 0x78, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x75, 0x6d, 0x70,
+	0x73, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x6d, 0x61,
+	0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x6f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x29, 0x0a, 0x10,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x41, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0d, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69,
+	0x6e, 0x65, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x6c, 0x69, 0x6e, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x66, 0x0a, 0x14, 0x64, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x4b, 0x69, 0x6e,
-	0x64, 0x52, 0x08, 0x73, 0x70, 0x61, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64,
-	0x69, 0x72, 0x74, 0x79, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0b, 0x64, 0x69, 0x72, 0x74, 0x79, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1f,
-	0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x65, 0x78, 0x74, 0x12,
-	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12,
-	0x2d, 0x0a, 0x12, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16,
-	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
-	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
-	0x73, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x10, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69,
-	0x64, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
-	0x63, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
-	0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
-	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x4b, 0x69,
-	0x6e, 0x64, 0x52, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c,
-	0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0b, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
-	0x27, 0x0a, 0x0f, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f, 0x70,
-	0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74,
-	0x69, 0x63, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x36,
-	0x0a, 0x17, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x5f,
-	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x15, 0x70, 0x61, 0x74, 0x63, 0x68, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x57, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x2c, 0x0a, 0x08, 0x53, 0x70, 0x61, 0x6e, 0x4b, 0x69,
-	0x6e, 0x64, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x49, 0x54, 0x48, 0x49, 0x4e, 0x5f, 0x53, 0x50, 0x41,
-	0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x53, 0x50,
-	0x41, 0x4e, 0x10, 0x01, 0x22, 0x63, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x0b,
-	0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74,
-	0x68, 0x52, 0x0a, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a,
-	0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xb1, 0x0c, 0x0a, 0x10, 0x44, 0x65,
-	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31,
-	0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a,
-	0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x65, 0x78, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x45, 0x0a, 0x09, 0x72, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e,
-	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x18,
-	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e,
-	0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
-	0x63, 0x12, 0x3d, 0x0a, 0x11, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62,
-	0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52,
-	0x0f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x65,
-	0x12, 0x3e, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x28, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65,
-	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e,
-	0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
-	0x12, 0x69, 0x0a, 0x14, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36,
-	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63,
-	0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x65,
-	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x60, 0x0a, 0x11, 0x65,
-	0x78, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73,
-	0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65,
-	0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x65, 0x78, 0x74,
-	0x65, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x19, 0x0a,
-	0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x1a, 0xa4, 0x02, 0x0a, 0x09, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
-	0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
-	0x2b, 0x0a, 0x11, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x04,
-	0x73, 0x70, 0x61, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74,
-	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
-	0x53, 0x70, 0x61, 0x6e, 0x52, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75,
-	0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x25, 0x0a,
-	0x0e, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x53,
-	0x63, 0x6f, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72,
-	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08,
-	0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x0a, 0x10, 0x0b, 0x4a, 0x04, 0x08, 0x0b, 0x10, 0x0c, 0x1a,
-	0x81, 0x02, 0x0a, 0x08, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64,
-	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x3f, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x2b, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65,
-	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4f,
-	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69,
-	0x6e, 0x64, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d,
-	0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72,
-	0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x22, 0x0a, 0x04, 0x4b, 0x69, 0x6e,
-	0x64, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52, 0x52, 0x49, 0x44, 0x45, 0x53, 0x10, 0x00,
-	0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x54, 0x45, 0x4e, 0x44, 0x53, 0x10, 0x01, 0x4a, 0x04, 0x08,
-	0x03, 0x10, 0x04, 0x1a, 0x4f, 0x0a, 0x09, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73,
-	0x12, 0x42, 0x0a, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x08, 0x6f, 0x76, 0x65, 0x72,
-	0x72, 0x69, 0x64, 0x65, 0x1a, 0x56, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x18,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x64, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x74,
+	0x6f, 0x5f, 0x75, 0x74, 0x66, 0x38, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x63, 0x6f, 0x64, 0x65, 0x54, 0x6f, 0x55, 0x74, 0x66, 0x38, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61,
+	0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49,
+	0x64, 0x12, 0x31, 0x0a, 0x15, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x5f, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x2c, 0x0a, 0x08, 0x53, 0x70, 0x61,
+	0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0f, 0x0a, 0x0b, 0x57, 0x49, 0x54, 0x48, 0x49, 0x4e, 0x5f,
+	0x53, 0x50, 0x41, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x52, 0x4f, 0x55, 0x4e, 0x44,
+	0x5f, 0x53, 0x50, 0x41, 0x4e, 0x10, 0x01, 0x22, 0x3f, 0x0a, 0x13, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x13,
+	0x0a, 0x0f, 0x42, 0x45, 0x53, 0x54, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f,
+	0x4e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x4c, 0x4c, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e,
+	0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x01, 0x22, 0x63, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x3f, 0x0a, 0x0b, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75,
+	0x73, 0x50, 0x61, 0x74, 0x68, 0x52, 0x0a, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xd2, 0x15,
+	0x0a, 0x10, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x31, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x65,
+	0x78, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x45,
+	0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x5c, 0x0a, 0x12, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x19, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x10, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x12, 0x3e, 0x0a, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x44, 0x69, 0x61,
+	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0a, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
+	0x74, 0x69, 0x63, 0x12, 0x3d, 0x0a, 0x11, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x62, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x0f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x3e, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x69, 0x0a, 0x14, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x36, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
 	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x6c, 0x0a, 0x15, 0x45, 0x78, 0x74,
-	0x65, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x3d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x60, 0x0a,
+	0x11, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x4f,
+	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12,
+	0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x5d, 0x0a, 0x10, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6a, 0x75, 0x6d, 0x70, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x32, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65,
+	0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x75, 0x6d, 0x70, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4a,
+	0x75, 0x6d, 0x70, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x16, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x18, 0x17, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x1a, 0xe5, 0x06, 0x0a, 0x09, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x04, 0x73, 0x70, 0x61, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e,
+	0x52, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x6d,
+	0x61, 0x6e, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x73, 0x65, 0x6d, 0x61, 0x6e, 0x74, 0x69, 0x63, 0x53, 0x63, 0x6f, 0x70, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x57, 0x0a, 0x16, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x14, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69,
+	0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18,
+	0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x5f, 0x62,
+	0x79, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x64, 0x65, 0x6e, 0x42, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x71,
+	0x0a, 0x1b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x18, 0x10, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x07, 0x22, 0x8d, 0x0b,
-	0x0a, 0x16, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b,
-	0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74,
-	0x12, 0x5b, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b,
-	0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x32, 0x2e, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44,
-	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x0e, 0x64,
-	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x5e, 0x0a,
-	0x10, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x69, 0x6e,
-	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72,
-	0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x65, 0x63,
-	0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x0f, 0x64, 0x65,
-	0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x58, 0x0a,
-	0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
-	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72,
-	0x65, 0x6e, 0x63, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x0d, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x4f, 0x0a, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x65,
-	0x72, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x6b,
+	0x6c, 0x79, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x19, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65,
+	0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x3c, 0x0a, 0x1a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x70, 0x72, 0x65,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x18, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x70, 0x72,
+	0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x4a,
+	0x0a, 0x0e, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x2e, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02,
+	0x4a, 0x04, 0x08, 0x0a, 0x10, 0x0b, 0x4a, 0x04, 0x08, 0x0b, 0x10, 0x0c, 0x1a, 0x64, 0x0a, 0x13,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x1a, 0x81, 0x02, 0x0a, 0x08, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c,
+	0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x22, 0x0a, 0x04,
+	0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52, 0x52, 0x49, 0x44, 0x45,
+	0x53, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x54, 0x45, 0x4e, 0x44, 0x53, 0x10, 0x01,
+	0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x1a, 0x4f, 0x0a, 0x09, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69,
+	0x64, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x08, 0x6f,
+	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x1a, 0x7c, 0x0a, 0x10, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x45,
+	0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x1a, 0x56, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a,
+	0x18, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x6c, 0x0a, 0x15, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x42, 0x0a, 0x14, 0x44, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x75, 0x6d, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a, 0x04, 0x08, 0x06,
+	0x10, 0x07, 0x22, 0xb1, 0x01, 0x0a, 0x17, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x63, 0x6f,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41,
+	0x0a, 0x0b, 0x64, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x78, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44,
+	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x3f, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0xbb, 0x14, 0x0a, 0x16, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x5b, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x32, 0x2e, 0x6b,
 	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73,
 	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x0a, 0x63, 0x61,
-	0x6c, 0x6c, 0x65, 0x72, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
-	0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6c,
-	0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
-	0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0a, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x54, 0x65, 0x78, 0x74, 0x12, 0x29, 0x0a,
-	0x10, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x6e, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x58, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x73, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x31, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
-	0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x51, 0x75, 0x61, 0x6c,
-	0x69, 0x74, 0x79, 0x52, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x51, 0x75, 0x61, 0x6c, 0x69,
-	0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
-	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x35,
-	0x0a, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x08, 0x73, 0x6e, 0x69,
-	0x70, 0x70, 0x65, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x75, 0x69,
-	0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x34, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x79,
-	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x36,
-	0x0a, 0x17, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x5f,
-	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x15, 0x70, 0x61, 0x74, 0x63, 0x68, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x57, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x13, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73,
-	0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x13, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x74, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64,
+	0x52, 0x0e, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64,
+	0x12, 0x5e, 0x0a, 0x10, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52,
+	0x0f, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64,
+	0x12, 0x58, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x0d, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x4f, 0x0a, 0x0b, 0x63, 0x61,
+	0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x2e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72,
+	0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x4b, 0x69, 0x6e, 0x64, 0x52,
+	0x0a, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x54, 0x65, 0x78, 0x74,
+	0x12, 0x29, 0x0a, 0x10, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x6e, 0x6f, 0x64, 0x65,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x58, 0x0a, 0x0e, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x73, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x51,
+	0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x51, 0x75,
+	0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x08,
+	0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x34, 0x0a, 0x09, 0x77,
+	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x36, 0x0a, 0x17, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x67, 0x61, 0x69, 0x6e,
+	0x73, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x15, 0x70, 0x61, 0x74, 0x63, 0x68, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74,
+	0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x13, 0x63, 0x6f, 0x72,
+	0x70, 0x75, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x11, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61,
+	0x74, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3f, 0x0a, 0x1c, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x19, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x78, 0x70, 0x61,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12,
+	0x3d, 0x0a, 0x1b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x73, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x4f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63,
+	0x61, 0x6c, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x4b, 0x69, 0x6e, 0x64, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x5f, 0x6d,
+	0x61, 0x73, 0x6b, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x4d, 0x61, 0x73,
+	0x6b, 0x12, 0x37, 0x0a, 0x18, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x1c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x15, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x42, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x6b,
+	0x69, 0x70, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x4d, 0x69, 0x73, 0x73,
+	0x69, 0x6e, 0x67, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65,
+	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x30, 0x0a,
+	0x14, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x5f, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6e,
+	0x63, 0x68, 0x6f, 0x72, 0x54, 0x65, 0x78, 0x74, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12,
+	0x2f, 0x0a, 0x14, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x69, 0x74, 0x65, 0x73, 0x5f, 0x62, 0x79,
+	0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x63,
+	0x61, 0x6c, 0x6c, 0x53, 0x69, 0x74, 0x65, 0x73, 0x42, 0x79, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72,
+	0x12, 0x34, 0x0a, 0x16, 0x6d, 0x61, 0x72, 0x6b, 0x5f, 0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x22, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x14, 0x6d, 0x61, 0x72, 0x6b, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69,
+	0x63, 0x61, 0x6c, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x23, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x68, 0x61, 0x73, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x24, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x70, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64,
+	0x18, 0x25, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12,
+	0x34, 0x0a, 0x16, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61,
+	0x6c, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x26, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x14, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x18, 0x27, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x66, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x18, 0x28, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x65, 0x64, 0x67, 0x65, 0x46,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x31, 0x0a, 0x15, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x5f, 0x62, 0x79, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x18, 0x29, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x43, 0x0a, 0x0f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x2a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12,
+	0x45, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x2c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x12, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x46, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x68, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x5f,
+	0x44, 0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x00, 0x12, 0x13, 0x0a,
+	0x0f, 0x41, 0x4c, 0x4c, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x55, 0x4c, 0x4c, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e,
+	0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x42, 0x49, 0x4e, 0x44,
+	0x49, 0x4e, 0x47, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10,
+	0x03, 0x22, 0x3c, 0x0a, 0x0f, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4b, 0x69, 0x6e, 0x64, 0x12, 0x13, 0x0a, 0x0f, 0x4e, 0x4f, 0x5f, 0x44, 0x45, 0x43, 0x4c, 0x41,
+	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x4c, 0x4c,
+	0x5f, 0x44, 0x45, 0x43, 0x4c, 0x41, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x01, 0x22,
+	0x64, 0x0a, 0x0d, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4b, 0x69, 0x6e, 0x64,
+	0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x5f, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43, 0x45,
+	0x53, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x46, 0x45,
+	0x52, 0x45, 0x4e, 0x43, 0x45, 0x53, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x4f, 0x4e, 0x5f,
+	0x43, 0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43, 0x45, 0x53, 0x10,
+	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e,
+	0x43, 0x45, 0x53, 0x10, 0x03, 0x22, 0x46, 0x0a, 0x0a, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x4f, 0x5f, 0x43, 0x41, 0x4c, 0x4c, 0x45, 0x52,
+	0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x5f, 0x43, 0x41,
+	0x4c, 0x4c, 0x45, 0x52, 0x53, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x56, 0x45, 0x52, 0x52,
+	0x49, 0x44, 0x45, 0x5f, 0x43, 0x41, 0x4c, 0x4c, 0x45, 0x52, 0x53, 0x10, 0x02, 0x22, 0x53, 0x0a,
+	0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x16,
+	0x0a, 0x12, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x5f, 0x54, 0x4f,
+	0x54, 0x41, 0x4c, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x45, 0x43, 0x49, 0x53,
+	0x45, 0x5f, 0x54, 0x4f, 0x54, 0x41, 0x4c, 0x53, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x50,
+	0x50, 0x52, 0x4f, 0x58, 0x49, 0x4d, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x4f, 0x54, 0x41, 0x4c, 0x53,
+	0x10, 0x02, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x64, 0x10, 0x65, 0x22, 0x4a,
+	0x0a, 0x11, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x35, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x73, 0x52, 0x11, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x68, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f, 0x5f, 0x44,
-	0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f,
-	0x41, 0x4c, 0x4c, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10,
-	0x01, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x55, 0x4c, 0x4c, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x49,
-	0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x42, 0x49, 0x4e, 0x44, 0x49,
-	0x4e, 0x47, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x03,
-	0x22, 0x3c, 0x0a, 0x0f, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b,
-	0x69, 0x6e, 0x64, 0x12, 0x13, 0x0a, 0x0f, 0x4e, 0x4f, 0x5f, 0x44, 0x45, 0x43, 0x4c, 0x41, 0x52,
-	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x4c, 0x4c, 0x5f,
-	0x44, 0x45, 0x43, 0x4c, 0x41, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x01, 0x22, 0x64,
-	0x0a, 0x0d, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12,
-	0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x5f, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43, 0x45, 0x53,
-	0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x46, 0x45, 0x52,
-	0x45, 0x4e, 0x43, 0x45, 0x53, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x4f, 0x4e, 0x5f, 0x43,
-	0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43, 0x45, 0x53, 0x10, 0x02,
-	0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43,
-	0x45, 0x53, 0x10, 0x03, 0x22, 0x46, 0x0a, 0x0a, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x4b, 0x69,
-	0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x4f, 0x5f, 0x43, 0x41, 0x4c, 0x4c, 0x45, 0x52, 0x53,
-	0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x5f, 0x43, 0x41, 0x4c,
-	0x4c, 0x45, 0x52, 0x53, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x56, 0x45, 0x52, 0x52, 0x49,
-	0x44, 0x45, 0x5f, 0x43, 0x41, 0x4c, 0x4c, 0x45, 0x52, 0x53, 0x10, 0x02, 0x22, 0x53, 0x0a, 0x0d,
-	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a,
-	0x12, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x5f, 0x54, 0x4f, 0x54,
-	0x41, 0x4c, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x45, 0x43, 0x49, 0x53, 0x45,
-	0x5f, 0x54, 0x4f, 0x54, 0x41, 0x4c, 0x53, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x50, 0x50,
-	0x52, 0x4f, 0x58, 0x49, 0x4d, 0x41, 0x54, 0x45, 0x5f, 0x54, 0x4f, 0x54, 0x41, 0x4c, 0x53, 0x10,
-	0x02, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x64, 0x10, 0x65, 0x22, 0x4a, 0x0a,
-	0x11, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x73, 0x12, 0x35, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0xe3, 0x01, 0x0a, 0x10, 0x43, 0x6f,
-	0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x36,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75,
-	0x73, 0x50, 0x61, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x2e, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x12, 0x12,
-	0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f,
-	0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76,
-	0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72,
-	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x50, 0x61, 0x74, 0x68, 0x22, 0x32, 0x0a, 0x04, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00,
-	0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x5f, 0x4f, 0x4e, 0x4c, 0x59,
-	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x02, 0x22,
-	0xbc, 0x02, 0x0a, 0x06, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69,
-	0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b,
-	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x2c,
-	0x0a, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
-	0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x3b, 0x0a, 0x0c, 0x73, 0x6e,
-	0x69, 0x70, 0x70, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x61, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52, 0x0b, 0x73, 0x6e, 0x69, 0x70,
-	0x70, 0x65, 0x74, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64,
+	0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0xe3, 0x01, 0x0a, 0x10, 0x43,
+	0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x36, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x72, 0x70,
+	0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x2e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x72, 0x70, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72,
+	0x6f, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x50, 0x61, 0x74, 0x68, 0x22, 0x32, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10,
+	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x5f, 0x4f, 0x4e, 0x4c,
+	0x59, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x02,
+	0x22, 0xdf, 0x06, 0x0a, 0x06, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12,
+	0x2c, 0x0a, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x3b, 0x0a, 0x0c, 0x73,
+	0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x61, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52, 0x0b, 0x73, 0x6e, 0x69,
+	0x70, 0x70, 0x65, 0x74, 0x53, 0x70, 0x61, 0x6e, 0x12, 0x4d, 0x0a, 0x11, 0x73, 0x6e, 0x69, 0x70,
+	0x70, 0x65, 0x74, 0x5f, 0x68, 0x69, 0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x2e, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x10, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x48, 0x69,
+	0x67, 0x68, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64,
 	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62,
 	0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
 	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
-	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x05,
-	0x10, 0x06, 0x4a, 0x04, 0x08, 0x08, 0x10, 0x09, 0x4a, 0x04, 0x08, 0x09, 0x10, 0x0a, 0x22, 0x54,
-	0x0a, 0x09, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x72,
-	0x61, 0x77, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72,
-	0x61, 0x77, 0x54, 0x65, 0x78, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x04,
-	0x6c, 0x69, 0x6e, 0x6b, 0x22, 0xde, 0x0f, 0x0a, 0x14, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3d, 0x0a,
-	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73,
-	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
-	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x43, 0x0a, 0x08,
-	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27,
-	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x43, 0x6f, 0x72, 0x70, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x52, 0x0a, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x75, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x4a, 0x0a, 0x0e, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x2e, 0x43,
+	0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x36, 0x0a,
+	0x0c, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x82, 0x01, 0x0a, 0x0e, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x4e, 0x43, 0x4c,
+	0x41, 0x53, 0x53, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45,
+	0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x49,
+	0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x4d, 0x50, 0x4c,
+	0x45, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x45, 0x43, 0x4c, 0x41,
+	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x46, 0x45,
+	0x52, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x41, 0x4c, 0x4c, 0x10,
+	0x06, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x4f, 0x43, 0x10, 0x07, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05,
+	0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04, 0x08, 0x08, 0x10, 0x09, 0x4a, 0x04, 0x08, 0x09,
+	0x10, 0x0a, 0x22, 0x54, 0x0a, 0x09, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x72, 0x61, 0x77, 0x54, 0x65, 0x78, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x6c, 0x69,
+	0x6e, 0x6b, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4c, 0x69,
+	0x6e, 0x6b, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x22, 0xef, 0x1e, 0x0a, 0x14, 0x43, 0x72, 0x6f,
 	0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65,
-	0x64, 0x12, 0x61, 0x0a, 0x10, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72,
-	0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x79,
+	0x79, 0x12, 0x3d, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x43, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x08, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x61, 0x0a, 0x10, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x36, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72,
+	0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x6d, 0x0a, 0x14,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x39,
+	0x0a, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x64, 0x0a, 0x11, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6b, 0x79,
 	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52,
-	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x43,
-	0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x0f, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52,
+	0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x0a, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x09, 0x64, 0x65, 0x62, 0x75, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x5b, 0x0a,
+	0x0e, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x69, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x6c,
+	0x6f, 0x77, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x12,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x6c, 0x6f, 0x77, 0x50, 0x61, 0x74, 0x68, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x74, 0x1a, 0xd8, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64,
+	0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x64,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2f,
+	0x0a, 0x13, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x64, 0x65, 0x70,
+	0x72, 0x65, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a,
+	0x4e, 0x0a, 0x0f, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x1a,
+	0x73, 0x0a, 0x0e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x4d, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x1a, 0xa8, 0x02, 0x0a, 0x10, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x4f, 0x0a, 0x0a,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a,
+	0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x63,
+	0x68, 0x6f, 0x72, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x4d, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
-	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x6d, 0x0a, 0x14, 0x64, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x13, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f,
-	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
-	0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x1a, 0x64, 0x0a, 0x0b, 0x52, 0x65,
-	0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63,
-	0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
-	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x69,
-	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
-	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c,
-	0x1a, 0xca, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68,
-	0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12,
-	0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
-	0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x69, 0x74, 0x65, 0x18, 0x03,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x41, 0x6e,
+	0x63, 0x68, 0x6f, 0x72, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x1a,
+	0xca, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x12, 0x2b, 0x0a, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x45,
+	0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65,
+	0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x69, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x1a, 0x84, 0x02, 0x0a,
+	0x0f, 0x43, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x53, 0x69, 0x74, 0x65, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x6c,
+	0x65, 0x72, 0x5f, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e,
+	0x63, 0x68, 0x6f, 0x72, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x41, 0x6e, 0x63, 0x68,
+	0x6f, 0x72, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d,
+	0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x61, 0x6c,
+	0x6c, 0x5f, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x63, 0x61, 0x6c, 0x6c, 0x53, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x30, 0x0a, 0x09, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x73, 0x69, 0x74, 0x65, 0x18, 0x05,
 	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x1a, 0x8c, 0x04,
-	0x0a, 0x11, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
-	0x53, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x45, 0x0a, 0x0d, 0x6d,
-	0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x08, 0x63, 0x61, 0x6c, 0x6c, 0x53,
+	0x69, 0x74, 0x65, 0x1a, 0xec, 0x07, 0x0a, 0x11, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61,
+	0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b,
+	0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x0a, 0x64,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x0b, 0x64, 0x65, 0x63,
+	0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f,
+	0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52,
+	0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4d, 0x0a, 0x09,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72,
+	0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x0f, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x0d,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x47, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
 	0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65,
-	0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65,
-	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61,
-	0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x61,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4d, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c,
-	0x61, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65,
-	0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18,
-	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
-	0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64,
-	0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x50,
+	0x64, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x12,
+	0x5d, 0x0a, 0x11, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x73,
+	0x69, 0x74, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x43, 0x61,
+	0x6c, 0x6c, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x53, 0x69, 0x74, 0x65, 0x73, 0x52, 0x0f, 0x63,
+	0x61, 0x6c, 0x6c, 0x65, 0x72, 0x43, 0x61, 0x6c, 0x6c, 0x53, 0x69, 0x74, 0x65, 0x73, 0x12, 0x50,
 	0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x0a,
 	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
 	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e,
 	0x6f, 0x64, 0x65, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
-	0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x1a, 0xf8, 0x02, 0x0a,
-	0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x64, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x63, 0x6c,
-	0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
-	0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
-	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d,
-	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x7e, 0x0a, 0x19,
-	0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x5f, 0x62, 0x79,
-	0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x43, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72,
-	0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64,
-	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x79, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64,
-	0x65, 0x73, 0x42, 0x79, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x49, 0x0a, 0x1b,
-	0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x79, 0x52, 0x65,
-	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x77, 0x0a, 0x14, 0x43, 0x72, 0x6f, 0x73, 0x73,
-	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x49, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x33, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x12, 0x5d, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x64, 0x65,
+	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52,
+	0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x0f,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x3c, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0d,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a,
+	0x0e, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x0e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x4b, 0x69, 0x6e, 0x64, 0x12, 0x60, 0x0a, 0x12, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x32, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
 	0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
-	0x63, 0x65, 0x53, 0x65, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x1a, 0x56, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x18, 0x44, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xdf, 0x01, 0x0a, 0x14, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
-	0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
-	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x29,
-	0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72,
-	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
-	0x65, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x34, 0x0a, 0x09, 0x77, 0x6f, 0x72,
-	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b,
-	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73,
-	0x70, 0x61, 0x63, 0x65, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
-	0x36, 0x0a, 0x17, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74,
-	0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x15, 0x70, 0x61, 0x74, 0x63, 0x68, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x57, 0x6f,
-	0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0xd5, 0x05, 0x0a, 0x12, 0x44, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x44,
-	0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x28, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
-	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x40, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x6b, 0x0a, 0x14, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13,
-	0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x1a, 0xf9,
-	0x01, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74,
-	0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63,
-	0x6b, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x50, 0x72, 0x69, 0x6e, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12,
-	0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
-	0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72,
-	0x65, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x4a, 0x04, 0x08, 0x03,
-	0x10, 0x04, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x4a, 0x04,
-	0x08, 0x06, 0x10, 0x07, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x1a, 0x56, 0x0a, 0x0a, 0x4e, 0x6f,
-	0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x4e,
-	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x18, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e,
-	0x63, 0x68, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x1d, 0x0a, 0x09, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03,
-	0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x2a, 0x25,
-	0x0a, 0x0c, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x08,
-	0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41,
-	0x55, 0x4c, 0x54, 0x10, 0x01, 0x32, 0x92, 0x02, 0x0a, 0x0b, 0x58, 0x52, 0x65, 0x66, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0b, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0f, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52,
-	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x70, 0x6c, 0x79, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x10, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x07,
+	0x10, 0x08, 0x1a, 0xb0, 0x03, 0x0a, 0x05, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x6c, 0x6c,
+	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65,
+	0x72, 0x73, 0x12, 0x7e, 0x0a, 0x19, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x2e, 0x52,
+	0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x79, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x16, 0x72, 0x65, 0x6c, 0x61,
+	0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x79, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x17, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x15, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x64, 0x50, 0x61, 0x67, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x1a, 0x49, 0x0a, 0x1b, 0x52, 0x65,
+	0x6c, 0x61, 0x74, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x79, 0x52, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x77, 0x0a, 0x14, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x49, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33,
 	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f,
 	0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0d, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x32, 0x0a, 0x1f, 0x63, 0x6f,
+	0x79, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x53, 0x65, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x56,
+	0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x18, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x40, 0x0a, 0x12, 0x49, 0x6e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xae, 0x01, 0x0a, 0x09, 0x44,
+	0x65, 0x62, 0x75, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x45, 0x0a, 0x0c, 0x70, 0x68, 0x61, 0x73,
+	0x65, 0x5f, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62,
+	0x75, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69,
+	0x6e, 0x67, 0x52, 0x0b, 0x70, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x1a,
+	0x5a, 0x0a, 0x0b, 0x50, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70,
+	0x68, 0x61, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7e, 0x0a, 0x0e, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x9b, 0x02, 0x0a, 0x0c,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x38, 0x0a, 0x06,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x06,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0f,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x92, 0x01, 0x0a, 0x06, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6b, 0x69, 0x6e,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x0a, 0x64,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x69, 0x0a, 0x0d, 0x52, 0x6f, 0x6c,
+	0x6c, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x9b, 0x02, 0x0a, 0x0b, 0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x1a, 0x5d, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x24, 0x0a, 0x0e, 0x4f, 0x75, 0x74, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x22, 0xa9, 0x02, 0x0a, 0x0c, 0x4f, 0x75, 0x74,
+	0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x38, 0x0a, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79, 0x74, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x75, 0x74, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x1a, 0xde, 0x01, 0x0a, 0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
+	0x62, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x05,
+	0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x75, 0x74, 0x6c, 0x69, 0x6e,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x05, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x22, 0xfa, 0x01, 0x0a, 0x14, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x29, 0x0a,
+	0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x34, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x36,
+	0x0a, 0x17, 0x70, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x5f,
+	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x15, 0x70, 0x61, 0x74, 0x63, 0x68, 0x41, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x57, 0x6f, 0x72,
+	0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49,
+	0x64, 0x22, 0x90, 0x06, 0x0a, 0x12, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x44, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x40,
+	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x12, 0x6b, 0x0a, 0x14, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
+	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x1a, 0xf9, 0x01, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x45, 0x0a, 0x0d, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6b, 0x79,
+	0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x6d,
+	0x61, 0x72, 0x6b, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x08, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65,
+	0x6e, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08,
+	0x05, 0x10, 0x06, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x07, 0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x1a,
+	0x56, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x18, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xc3, 0x01, 0x0a, 0x17, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x4b, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x1a, 0x5b, 0x0a,
+	0x0c, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x35, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x55, 0x0a, 0x19, 0x52, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e,
+	0x74, 0x22, 0x6b, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x46, 0x69, 0x6e, 0x67,
+	0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2b, 0x0a, 0x06,
+	0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6b,
+	0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x52, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x1d,
+	0x0a, 0x09, 0x57, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x2a, 0x25, 0x0a,
+	0x0c, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x08, 0x0a,
+	0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55,
+	0x4c, 0x54, 0x10, 0x01, 0x2a, 0x6b, 0x0a, 0x14, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x19,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x44,
+	0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x46,
+	0x55, 0x4c, 0x4c, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01,
+	0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02,
+	0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x45, 0x43, 0x4c, 0x41, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10,
+	0x03, 0x32, 0x92, 0x02, 0x0a, 0x0b, 0x58, 0x52, 0x65, 0x66, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x4f, 0x0a, 0x0b, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0f, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6b, 0x79, 0x74,
+	0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x55, 0x0a, 0x0d, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x21, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0x72, 0x0a, 0x10, 0x58, 0x52, 0x65, 0x66, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5e, 0x0a, 0x10, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24,
+	0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x32, 0x0a, 0x1f, 0x63, 0x6f,
 	0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x64, 0x65, 0x76, 0x74, 0x6f, 0x6f, 0x6c,
 	0x73, 0x2e, 0x6b, 0x79, 0x74, 0x68, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
 	0x0d, 0x78, 0x72, 0x65, 0x66, 0x5f, 0x67, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
@@ -2782,129 +5460,213 @@ func file_kythe_proto_xref_proto_rawDescGZIP() []byte {
 	return file_kythe_proto_xref_proto_rawDescData
 }
 
-var file_kythe_proto_xref_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
-var file_kythe_proto_xref_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_kythe_proto_xref_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_kythe_proto_xref_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_kythe_proto_xref_proto_goTypes = []interface{}{
-	(SnippetsKind)(0),                              // 0: kythe.proto.SnippetsKind
-	(Location_Kind)(0),                             // 1: kythe.proto.Location.Kind
-	(DecorationsRequest_SpanKind)(0),               // 2: kythe.proto.DecorationsRequest.SpanKind
-	(DecorationsReply_Override_Kind)(0),            // 3: kythe.proto.DecorationsReply.Override.Kind
-	(CrossReferencesRequest_DefinitionKind)(0),     // 4: kythe.proto.CrossReferencesRequest.DefinitionKind
-	(CrossReferencesRequest_DeclarationKind)(0),    // 5: kythe.proto.CrossReferencesRequest.DeclarationKind
-	(CrossReferencesRequest_ReferenceKind)(0),      // 6: kythe.proto.CrossReferencesRequest.ReferenceKind
-	(CrossReferencesRequest_CallerKind)(0),         // 7: kythe.proto.CrossReferencesRequest.CallerKind
-	(CrossReferencesRequest_TotalsQuality)(0),      // 8: kythe.proto.CrossReferencesRequest.TotalsQuality
-	(CorpusPathFilter_Type)(0),                     // 9: kythe.proto.CorpusPathFilter.Type
-	(*Location)(nil),                               // 10: kythe.proto.Location
-	(*DecorationsRequest)(nil),                     // 11: kythe.proto.DecorationsRequest
-	(*File)(nil),                                   // 12: kythe.proto.File
-	(*DecorationsReply)(nil),                       // 13: kythe.proto.DecorationsReply
-	(*CrossReferencesRequest)(nil),                 // 14: kythe.proto.CrossReferencesRequest
-	(*CorpusPathFilters)(nil),                      // 15: kythe.proto.CorpusPathFilters
-	(*CorpusPathFilter)(nil),                       // 16: kythe.proto.CorpusPathFilter
-	(*Anchor)(nil),                                 // 17: kythe.proto.Anchor
-	(*Printable)(nil),                              // 18: kythe.proto.Printable
-	(*CrossReferencesReply)(nil),                   // 19: kythe.proto.CrossReferencesReply
-	(*DocumentationRequest)(nil),                   // 20: kythe.proto.DocumentationRequest
-	(*DocumentationReply)(nil),                     // 21: kythe.proto.DocumentationReply
-	(*Workspace)(nil),                              // 22: kythe.proto.Workspace
-	(*DecorationsReply_Reference)(nil),             // 23: kythe.proto.DecorationsReply.Reference
-	(*DecorationsReply_Override)(nil),              // 24: kythe.proto.DecorationsReply.Override
-	(*DecorationsReply_Overrides)(nil),             // 25: kythe.proto.DecorationsReply.Overrides
-	nil,                                            // 26: kythe.proto.DecorationsReply.NodesEntry
-	nil,                                            // 27: kythe.proto.DecorationsReply.DefinitionLocationsEntry
-	nil,                                            // 28: kythe.proto.DecorationsReply.ExtendsOverridesEntry
-	(*CrossReferencesReply_RelatedNode)(nil),       // 29: kythe.proto.CrossReferencesReply.RelatedNode
-	(*CrossReferencesReply_RelatedAnchor)(nil),     // 30: kythe.proto.CrossReferencesReply.RelatedAnchor
-	(*CrossReferencesReply_CrossReferenceSet)(nil), // 31: kythe.proto.CrossReferencesReply.CrossReferenceSet
-	(*CrossReferencesReply_Total)(nil),             // 32: kythe.proto.CrossReferencesReply.Total
-	nil,                                            // 33: kythe.proto.CrossReferencesReply.CrossReferencesEntry
-	nil,                                            // 34: kythe.proto.CrossReferencesReply.NodesEntry
-	nil,                                            // 35: kythe.proto.CrossReferencesReply.DefinitionLocationsEntry
-	nil,                                            // 36: kythe.proto.CrossReferencesReply.Total.RelatedNodesByRelationEntry
-	(*DocumentationReply_Document)(nil),            // 37: kythe.proto.DocumentationReply.Document
-	nil,                                            // 38: kythe.proto.DocumentationReply.NodesEntry
-	nil,                                            // 39: kythe.proto.DocumentationReply.DefinitionLocationsEntry
-	(*common_go_proto.Span)(nil),                   // 40: kythe.proto.common.Span
-	(*common_go_proto.CorpusPath)(nil),             // 41: kythe.proto.common.CorpusPath
-	(*common_go_proto.Diagnostic)(nil),             // 42: kythe.proto.common.Diagnostic
-	(*common_go_proto.Link)(nil),                   // 43: kythe.proto.common.Link
-	(*common_go_proto.MarkedSource)(nil),           // 44: kythe.proto.common.MarkedSource
-	(*common_go_proto.NodeInfo)(nil),               // 45: kythe.proto.common.NodeInfo
+	(SnippetsKind)(0),                            // 0: kythe.proto.SnippetsKind
+	(TargetDefinitionKind)(0),                    // 1: kythe.proto.TargetDefinitionKind
+	(Location_Kind)(0),                           // 2: kythe.proto.Location.Kind
+	(DecorationsRequest_SpanKind)(0),             // 3: kythe.proto.DecorationsRequest.SpanKind
+	(DecorationsRequest_DefinitionSelection)(0),  // 4: kythe.proto.DecorationsRequest.DefinitionSelection
+	(DecorationsReply_Override_Kind)(0),          // 5: kythe.proto.DecorationsReply.Override.Kind
+	(CrossReferencesRequest_DefinitionKind)(0),   // 6: kythe.proto.CrossReferencesRequest.DefinitionKind
+	(CrossReferencesRequest_DeclarationKind)(0),  // 7: kythe.proto.CrossReferencesRequest.DeclarationKind
+	(CrossReferencesRequest_ReferenceKind)(0),    // 8: kythe.proto.CrossReferencesRequest.ReferenceKind
+	(CrossReferencesRequest_CallerKind)(0),       // 9: kythe.proto.CrossReferencesRequest.CallerKind
+	(CrossReferencesRequest_TotalsQuality)(0),    // 10: kythe.proto.CrossReferencesRequest.TotalsQuality
+	(CorpusPathFilter_Type)(0),                   // 11: kythe.proto.CorpusPathFilter.Type
+	(Anchor_Classification)(0),                   // 12: kythe.proto.Anchor.Classification
+	(*Location)(nil),                             // 13: kythe.proto.Location
+	(*DecorationsRequest)(nil),                   // 14: kythe.proto.DecorationsRequest
+	(*File)(nil),                                 // 15: kythe.proto.File
+	(*DecorationsReply)(nil),                     // 16: kythe.proto.DecorationsReply
+	(*WatchDecorationsRequest)(nil),              // 17: kythe.proto.WatchDecorationsRequest
+	(*WatchDecorationsReply)(nil),                // 18: kythe.proto.WatchDecorationsReply
+	(*CrossReferencesRequest)(nil),               // 19: kythe.proto.CrossReferencesRequest
+	(*CorpusPathFilters)(nil),                    // 20: kythe.proto.CorpusPathFilters
+	(*CorpusPathFilter)(nil),                     // 21: kythe.proto.CorpusPathFilter
+	(*Anchor)(nil),                               // 22: kythe.proto.Anchor
+	(*Printable)(nil),                            // 23: kythe.proto.Printable
+	(*CrossReferencesReply)(nil),                 // 24: kythe.proto.CrossReferencesReply
+	(*DebugInfo)(nil),                            // 25: kythe.proto.DebugInfo
+	(*MembersRequest)(nil),                       // 26: kythe.proto.MembersRequest
+	(*MembersReply)(nil),                         // 27: kythe.proto.MembersReply
+	(*RollupRequest)(nil),                        // 28: kythe.proto.RollupRequest
+	(*RollupReply)(nil),                          // 29: kythe.proto.RollupReply
+	(*OutlineRequest)(nil),                       // 30: kythe.proto.OutlineRequest
+	(*OutlineReply)(nil),                         // 31: kythe.proto.OutlineReply
+	(*DocumentationRequest)(nil),                 // 32: kythe.proto.DocumentationRequest
+	(*DocumentationReply)(nil),                   // 33: kythe.proto.DocumentationReply
+	(*BatchDocumentationReply)(nil),              // 34: kythe.proto.BatchDocumentationReply
+	(*ResolveFingerprintRequest)(nil),            // 35: kythe.proto.ResolveFingerprintRequest
+	(*ResolveFingerprintReply)(nil),              // 36: kythe.proto.ResolveFingerprintReply
+	(*Workspace)(nil),                            // 37: kythe.proto.Workspace
+	(*DecorationsReply_Reference)(nil),           // 38: kythe.proto.DecorationsReply.Reference
+	(*DecorationsReply_DefinitionCandidate)(nil), // 39: kythe.proto.DecorationsReply.DefinitionCandidate
+	(*DecorationsReply_Override)(nil),            // 40: kythe.proto.DecorationsReply.Override
+	(*DecorationsReply_Overrides)(nil),           // 41: kythe.proto.DecorationsReply.Overrides
+	(*DecorationsReply_BuildConfigGroup)(nil),    // 42: kythe.proto.DecorationsReply.BuildConfigGroup
+	nil,                                      // 43: kythe.proto.DecorationsReply.NodesEntry
+	nil,                                      // 44: kythe.proto.DecorationsReply.DefinitionLocationsEntry
+	nil,                                      // 45: kythe.proto.DecorationsReply.ExtendsOverridesEntry
+	nil,                                      // 46: kythe.proto.DecorationsReply.DefinitionJumpsEntry
+	(*Anchor_SnippetRange)(nil),              // 47: kythe.proto.Anchor.SnippetRange
+	(*CrossReferencesReply_RelatedNode)(nil), // 48: kythe.proto.CrossReferencesReply.RelatedNode
+	(*CrossReferencesReply_RelatedNodeList)(nil),   // 49: kythe.proto.CrossReferencesReply.RelatedNodeList
+	(*CrossReferencesReply_ReferenceGroup)(nil),    // 50: kythe.proto.CrossReferencesReply.ReferenceGroup
+	(*CrossReferencesReply_BuildConfigGroup)(nil),  // 51: kythe.proto.CrossReferencesReply.BuildConfigGroup
+	(*CrossReferencesReply_RelatedAnchor)(nil),     // 52: kythe.proto.CrossReferencesReply.RelatedAnchor
+	(*CrossReferencesReply_CallerCallSites)(nil),   // 53: kythe.proto.CrossReferencesReply.CallerCallSites
+	(*CrossReferencesReply_CrossReferenceSet)(nil), // 54: kythe.proto.CrossReferencesReply.CrossReferenceSet
+	(*CrossReferencesReply_Total)(nil),             // 55: kythe.proto.CrossReferencesReply.Total
+	nil,                                            // 56: kythe.proto.CrossReferencesReply.CrossReferencesEntry
+	nil,                                            // 57: kythe.proto.CrossReferencesReply.NodesEntry
+	nil,                                            // 58: kythe.proto.CrossReferencesReply.DefinitionLocationsEntry
+	nil,                                            // 59: kythe.proto.CrossReferencesReply.RedirectedTicketEntry
+	nil,                                            // 60: kythe.proto.CrossReferencesReply.InvalidTicketEntry
+	nil,                                            // 61: kythe.proto.CrossReferencesReply.Total.RelatedNodesByRelationEntry
+	(*DebugInfo_PhaseTiming)(nil),                  // 62: kythe.proto.DebugInfo.PhaseTiming
+	(*MembersReply_Member)(nil),                    // 63: kythe.proto.MembersReply.Member
+	(*RollupReply_Target)(nil),                     // 64: kythe.proto.RollupReply.Target
+	(*OutlineReply_Symbol)(nil),                    // 65: kythe.proto.OutlineReply.Symbol
+	(*DocumentationReply_Document)(nil),            // 66: kythe.proto.DocumentationReply.Document
+	nil,                                            // 67: kythe.proto.DocumentationReply.NodesEntry
+	nil,                                            // 68: kythe.proto.DocumentationReply.DefinitionLocationsEntry
+	nil,                                            // 69: kythe.proto.BatchDocumentationReply.RepliesEntry
+	(*common_go_proto.Span)(nil),                   // 70: kythe.proto.common.Span
+	(*fieldmaskpb.FieldMask)(nil),                  // 71: google.protobuf.FieldMask
+	(*common_go_proto.CorpusPath)(nil),             // 72: kythe.proto.common.CorpusPath
+	(*common_go_proto.Diagnostic)(nil),             // 73: kythe.proto.common.Diagnostic
+	(*timestamppb.Timestamp)(nil),                  // 74: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                    // 75: google.protobuf.Duration
+	(*common_go_proto.Link)(nil),                   // 76: kythe.proto.common.Link
+	(*common_go_proto.MarkedSource)(nil),           // 77: kythe.proto.common.MarkedSource
+	(*common_go_proto.NodeInfo)(nil),               // 78: kythe.proto.common.NodeInfo
 }
 var file_kythe_proto_xref_proto_depIdxs = []int32{
-	1,  // 0: kythe.proto.Location.kind:type_name -> kythe.proto.Location.Kind
-	40, // 1: kythe.proto.Location.span:type_name -> kythe.proto.common.Span
-	10, // 2: kythe.proto.DecorationsRequest.location:type_name -> kythe.proto.Location
-	2,  // 3: kythe.proto.DecorationsRequest.span_kind:type_name -> kythe.proto.DecorationsRequest.SpanKind
-	0,  // 4: kythe.proto.DecorationsRequest.snippets:type_name -> kythe.proto.SnippetsKind
-	22, // 5: kythe.proto.DecorationsRequest.workspace:type_name -> kythe.proto.Workspace
-	41, // 6: kythe.proto.File.corpus_path:type_name -> kythe.proto.common.CorpusPath
-	10, // 7: kythe.proto.DecorationsReply.location:type_name -> kythe.proto.Location
-	23, // 8: kythe.proto.DecorationsReply.reference:type_name -> kythe.proto.DecorationsReply.Reference
-	42, // 9: kythe.proto.DecorationsReply.diagnostic:type_name -> kythe.proto.common.Diagnostic
-	12, // 10: kythe.proto.DecorationsReply.generated_by_file:type_name -> kythe.proto.File
-	26, // 11: kythe.proto.DecorationsReply.nodes:type_name -> kythe.proto.DecorationsReply.NodesEntry
-	27, // 12: kythe.proto.DecorationsReply.definition_locations:type_name -> kythe.proto.DecorationsReply.DefinitionLocationsEntry
-	28, // 13: kythe.proto.DecorationsReply.extends_overrides:type_name -> kythe.proto.DecorationsReply.ExtendsOverridesEntry
-	4,  // 14: kythe.proto.CrossReferencesRequest.definition_kind:type_name -> kythe.proto.CrossReferencesRequest.DefinitionKind
-	5,  // 15: kythe.proto.CrossReferencesRequest.declaration_kind:type_name -> kythe.proto.CrossReferencesRequest.DeclarationKind
-	6,  // 16: kythe.proto.CrossReferencesRequest.reference_kind:type_name -> kythe.proto.CrossReferencesRequest.ReferenceKind
-	7,  // 17: kythe.proto.CrossReferencesRequest.caller_kind:type_name -> kythe.proto.CrossReferencesRequest.CallerKind
-	8,  // 18: kythe.proto.CrossReferencesRequest.totals_quality:type_name -> kythe.proto.CrossReferencesRequest.TotalsQuality
-	0,  // 19: kythe.proto.CrossReferencesRequest.snippets:type_name -> kythe.proto.SnippetsKind
-	22, // 20: kythe.proto.CrossReferencesRequest.workspace:type_name -> kythe.proto.Workspace
-	15, // 21: kythe.proto.CrossReferencesRequest.corpus_path_filters:type_name -> kythe.proto.CorpusPathFilters
-	16, // 22: kythe.proto.CorpusPathFilters.filter:type_name -> kythe.proto.CorpusPathFilter
-	9,  // 23: kythe.proto.CorpusPathFilter.type:type_name -> kythe.proto.CorpusPathFilter.Type
-	40, // 24: kythe.proto.Anchor.span:type_name -> kythe.proto.common.Span
-	40, // 25: kythe.proto.Anchor.snippet_span:type_name -> kythe.proto.common.Span
-	43, // 26: kythe.proto.Printable.link:type_name -> kythe.proto.common.Link
-	32, // 27: kythe.proto.CrossReferencesReply.total:type_name -> kythe.proto.CrossReferencesReply.Total
-	32, // 28: kythe.proto.CrossReferencesReply.filtered:type_name -> kythe.proto.CrossReferencesReply.Total
-	33, // 29: kythe.proto.CrossReferencesReply.cross_references:type_name -> kythe.proto.CrossReferencesReply.CrossReferencesEntry
-	34, // 30: kythe.proto.CrossReferencesReply.nodes:type_name -> kythe.proto.CrossReferencesReply.NodesEntry
-	35, // 31: kythe.proto.CrossReferencesReply.definition_locations:type_name -> kythe.proto.CrossReferencesReply.DefinitionLocationsEntry
-	22, // 32: kythe.proto.DocumentationRequest.workspace:type_name -> kythe.proto.Workspace
-	37, // 33: kythe.proto.DocumentationReply.document:type_name -> kythe.proto.DocumentationReply.Document
-	38, // 34: kythe.proto.DocumentationReply.nodes:type_name -> kythe.proto.DocumentationReply.NodesEntry
-	39, // 35: kythe.proto.DocumentationReply.definition_locations:type_name -> kythe.proto.DocumentationReply.DefinitionLocationsEntry
-	40, // 36: kythe.proto.DecorationsReply.Reference.span:type_name -> kythe.proto.common.Span
-	3,  // 37: kythe.proto.DecorationsReply.Override.kind:type_name -> kythe.proto.DecorationsReply.Override.Kind
-	44, // 38: kythe.proto.DecorationsReply.Override.marked_source:type_name -> kythe.proto.common.MarkedSource
-	24, // 39: kythe.proto.DecorationsReply.Overrides.override:type_name -> kythe.proto.DecorationsReply.Override
-	45, // 40: kythe.proto.DecorationsReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
-	17, // 41: kythe.proto.DecorationsReply.DefinitionLocationsEntry.value:type_name -> kythe.proto.Anchor
-	25, // 42: kythe.proto.DecorationsReply.ExtendsOverridesEntry.value:type_name -> kythe.proto.DecorationsReply.Overrides
-	17, // 43: kythe.proto.CrossReferencesReply.RelatedAnchor.anchor:type_name -> kythe.proto.Anchor
-	44, // 44: kythe.proto.CrossReferencesReply.RelatedAnchor.marked_source:type_name -> kythe.proto.common.MarkedSource
-	17, // 45: kythe.proto.CrossReferencesReply.RelatedAnchor.site:type_name -> kythe.proto.Anchor
-	44, // 46: kythe.proto.CrossReferencesReply.CrossReferenceSet.marked_source:type_name -> kythe.proto.common.MarkedSource
-	30, // 47: kythe.proto.CrossReferencesReply.CrossReferenceSet.definition:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
-	30, // 48: kythe.proto.CrossReferencesReply.CrossReferenceSet.declaration:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
-	30, // 49: kythe.proto.CrossReferencesReply.CrossReferenceSet.reference:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
-	30, // 50: kythe.proto.CrossReferencesReply.CrossReferenceSet.caller:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
-	29, // 51: kythe.proto.CrossReferencesReply.CrossReferenceSet.related_node:type_name -> kythe.proto.CrossReferencesReply.RelatedNode
-	36, // 52: kythe.proto.CrossReferencesReply.Total.related_nodes_by_relation:type_name -> kythe.proto.CrossReferencesReply.Total.RelatedNodesByRelationEntry
-	31, // 53: kythe.proto.CrossReferencesReply.CrossReferencesEntry.value:type_name -> kythe.proto.CrossReferencesReply.CrossReferenceSet
-	45, // 54: kythe.proto.CrossReferencesReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
-	17, // 55: kythe.proto.CrossReferencesReply.DefinitionLocationsEntry.value:type_name -> kythe.proto.Anchor
-	18, // 56: kythe.proto.DocumentationReply.Document.text:type_name -> kythe.proto.Printable
-	44, // 57: kythe.proto.DocumentationReply.Document.marked_source:type_name -> kythe.proto.common.MarkedSource
-	37, // 58: kythe.proto.DocumentationReply.Document.children:type_name -> kythe.proto.DocumentationReply.Document
-	45, // 59: kythe.proto.DocumentationReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
-	17, // 60: kythe.proto.DocumentationReply.DefinitionLocationsEntry.value:type_name -> kythe.proto.Anchor
-	11, // 61: kythe.proto.XRefService.Decorations:input_type -> kythe.proto.DecorationsRequest
-	14, // 62: kythe.proto.XRefService.CrossReferences:input_type -> kythe.proto.CrossReferencesRequest
-	20, // 63: kythe.proto.XRefService.Documentation:input_type -> kythe.proto.DocumentationRequest
-	13, // 64: kythe.proto.XRefService.Decorations:output_type -> kythe.proto.DecorationsReply
-	19, // 65: kythe.proto.XRefService.CrossReferences:output_type -> kythe.proto.CrossReferencesReply
-	21, // 66: kythe.proto.XRefService.Documentation:output_type -> kythe.proto.DocumentationReply
-	64, // [64:67] is the sub-list for method output_type
-	61, // [61:64] is the sub-list for method input_type
-	61, // [61:61] is the sub-list for extension type_name
-	61, // [61:61] is the sub-list for extension extendee
-	0,  // [0:61] is the sub-list for field type_name
+	2,   // 0: kythe.proto.Location.kind:type_name -> kythe.proto.Location.Kind
+	70,  // 1: kythe.proto.Location.span:type_name -> kythe.proto.common.Span
+	13,  // 2: kythe.proto.DecorationsRequest.location:type_name -> kythe.proto.Location
+	3,   // 3: kythe.proto.DecorationsRequest.span_kind:type_name -> kythe.proto.DecorationsRequest.SpanKind
+	0,   // 4: kythe.proto.DecorationsRequest.snippets:type_name -> kythe.proto.SnippetsKind
+	37,  // 5: kythe.proto.DecorationsRequest.workspace:type_name -> kythe.proto.Workspace
+	1,   // 6: kythe.proto.DecorationsRequest.definition_preference:type_name -> kythe.proto.TargetDefinitionKind
+	71,  // 7: kythe.proto.DecorationsRequest.reference_mask:type_name -> google.protobuf.FieldMask
+	4,   // 8: kythe.proto.DecorationsRequest.definition_selection:type_name -> kythe.proto.DecorationsRequest.DefinitionSelection
+	72,  // 9: kythe.proto.File.corpus_path:type_name -> kythe.proto.common.CorpusPath
+	13,  // 10: kythe.proto.DecorationsReply.location:type_name -> kythe.proto.Location
+	38,  // 11: kythe.proto.DecorationsReply.reference:type_name -> kythe.proto.DecorationsReply.Reference
+	42,  // 12: kythe.proto.DecorationsReply.build_config_group:type_name -> kythe.proto.DecorationsReply.BuildConfigGroup
+	73,  // 13: kythe.proto.DecorationsReply.diagnostic:type_name -> kythe.proto.common.Diagnostic
+	15,  // 14: kythe.proto.DecorationsReply.generated_by_file:type_name -> kythe.proto.File
+	43,  // 15: kythe.proto.DecorationsReply.nodes:type_name -> kythe.proto.DecorationsReply.NodesEntry
+	44,  // 16: kythe.proto.DecorationsReply.definition_locations:type_name -> kythe.proto.DecorationsReply.DefinitionLocationsEntry
+	45,  // 17: kythe.proto.DecorationsReply.extends_overrides:type_name -> kythe.proto.DecorationsReply.ExtendsOverridesEntry
+	74,  // 18: kythe.proto.DecorationsReply.build_time:type_name -> google.protobuf.Timestamp
+	46,  // 19: kythe.proto.DecorationsReply.definition_jumps:type_name -> kythe.proto.DecorationsReply.DefinitionJumpsEntry
+	14,  // 20: kythe.proto.WatchDecorationsRequest.decorations:type_name -> kythe.proto.DecorationsRequest
+	75,  // 21: kythe.proto.WatchDecorationsRequest.timeout:type_name -> google.protobuf.Duration
+	16,  // 22: kythe.proto.WatchDecorationsReply.decorations:type_name -> kythe.proto.DecorationsReply
+	6,   // 23: kythe.proto.CrossReferencesRequest.definition_kind:type_name -> kythe.proto.CrossReferencesRequest.DefinitionKind
+	7,   // 24: kythe.proto.CrossReferencesRequest.declaration_kind:type_name -> kythe.proto.CrossReferencesRequest.DeclarationKind
+	8,   // 25: kythe.proto.CrossReferencesRequest.reference_kind:type_name -> kythe.proto.CrossReferencesRequest.ReferenceKind
+	9,   // 26: kythe.proto.CrossReferencesRequest.caller_kind:type_name -> kythe.proto.CrossReferencesRequest.CallerKind
+	10,  // 27: kythe.proto.CrossReferencesRequest.totals_quality:type_name -> kythe.proto.CrossReferencesRequest.TotalsQuality
+	0,   // 28: kythe.proto.CrossReferencesRequest.snippets:type_name -> kythe.proto.SnippetsKind
+	37,  // 29: kythe.proto.CrossReferencesRequest.workspace:type_name -> kythe.proto.Workspace
+	20,  // 30: kythe.proto.CrossReferencesRequest.corpus_path_filters:type_name -> kythe.proto.CorpusPathFilters
+	71,  // 31: kythe.proto.CrossReferencesRequest.anchor_mask:type_name -> google.protobuf.FieldMask
+	74,  // 32: kythe.proto.CrossReferencesRequest.committed_after:type_name -> google.protobuf.Timestamp
+	74,  // 33: kythe.proto.CrossReferencesRequest.committed_before:type_name -> google.protobuf.Timestamp
+	21,  // 34: kythe.proto.CorpusPathFilters.filter:type_name -> kythe.proto.CorpusPathFilter
+	11,  // 35: kythe.proto.CorpusPathFilter.type:type_name -> kythe.proto.CorpusPathFilter.Type
+	70,  // 36: kythe.proto.Anchor.span:type_name -> kythe.proto.common.Span
+	70,  // 37: kythe.proto.Anchor.snippet_span:type_name -> kythe.proto.common.Span
+	47,  // 38: kythe.proto.Anchor.snippet_highlight:type_name -> kythe.proto.Anchor.SnippetRange
+	72,  // 39: kythe.proto.Anchor.parent_path:type_name -> kythe.proto.common.CorpusPath
+	74,  // 40: kythe.proto.Anchor.commit_time:type_name -> google.protobuf.Timestamp
+	12,  // 41: kythe.proto.Anchor.classification:type_name -> kythe.proto.Anchor.Classification
+	76,  // 42: kythe.proto.Printable.link:type_name -> kythe.proto.common.Link
+	55,  // 43: kythe.proto.CrossReferencesReply.total:type_name -> kythe.proto.CrossReferencesReply.Total
+	55,  // 44: kythe.proto.CrossReferencesReply.filtered:type_name -> kythe.proto.CrossReferencesReply.Total
+	56,  // 45: kythe.proto.CrossReferencesReply.cross_references:type_name -> kythe.proto.CrossReferencesReply.CrossReferencesEntry
+	57,  // 46: kythe.proto.CrossReferencesReply.nodes:type_name -> kythe.proto.CrossReferencesReply.NodesEntry
+	58,  // 47: kythe.proto.CrossReferencesReply.definition_locations:type_name -> kythe.proto.CrossReferencesReply.DefinitionLocationsEntry
+	74,  // 48: kythe.proto.CrossReferencesReply.build_time:type_name -> google.protobuf.Timestamp
+	59,  // 49: kythe.proto.CrossReferencesReply.redirected_ticket:type_name -> kythe.proto.CrossReferencesReply.RedirectedTicketEntry
+	25,  // 50: kythe.proto.CrossReferencesReply.debug_info:type_name -> kythe.proto.DebugInfo
+	60,  // 51: kythe.proto.CrossReferencesReply.invalid_ticket:type_name -> kythe.proto.CrossReferencesReply.InvalidTicketEntry
+	62,  // 52: kythe.proto.DebugInfo.phase_timing:type_name -> kythe.proto.DebugInfo.PhaseTiming
+	63,  // 53: kythe.proto.MembersReply.member:type_name -> kythe.proto.MembersReply.Member
+	64,  // 54: kythe.proto.RollupReply.target:type_name -> kythe.proto.RollupReply.Target
+	65,  // 55: kythe.proto.OutlineReply.symbol:type_name -> kythe.proto.OutlineReply.Symbol
+	37,  // 56: kythe.proto.DocumentationRequest.workspace:type_name -> kythe.proto.Workspace
+	66,  // 57: kythe.proto.DocumentationReply.document:type_name -> kythe.proto.DocumentationReply.Document
+	67,  // 58: kythe.proto.DocumentationReply.nodes:type_name -> kythe.proto.DocumentationReply.NodesEntry
+	68,  // 59: kythe.proto.DocumentationReply.definition_locations:type_name -> kythe.proto.DocumentationReply.DefinitionLocationsEntry
+	74,  // 60: kythe.proto.DocumentationReply.build_time:type_name -> google.protobuf.Timestamp
+	69,  // 61: kythe.proto.BatchDocumentationReply.replies:type_name -> kythe.proto.BatchDocumentationReply.RepliesEntry
+	22,  // 62: kythe.proto.ResolveFingerprintReply.anchor:type_name -> kythe.proto.Anchor
+	70,  // 63: kythe.proto.DecorationsReply.Reference.span:type_name -> kythe.proto.common.Span
+	1,   // 64: kythe.proto.DecorationsReply.Reference.target_definition_kind:type_name -> kythe.proto.TargetDefinitionKind
+	39,  // 65: kythe.proto.DecorationsReply.Reference.target_definition_candidate:type_name -> kythe.proto.DecorationsReply.DefinitionCandidate
+	12,  // 66: kythe.proto.DecorationsReply.Reference.classification:type_name -> kythe.proto.Anchor.Classification
+	1,   // 67: kythe.proto.DecorationsReply.DefinitionCandidate.kind:type_name -> kythe.proto.TargetDefinitionKind
+	5,   // 68: kythe.proto.DecorationsReply.Override.kind:type_name -> kythe.proto.DecorationsReply.Override.Kind
+	77,  // 69: kythe.proto.DecorationsReply.Override.marked_source:type_name -> kythe.proto.common.MarkedSource
+	40,  // 70: kythe.proto.DecorationsReply.Overrides.override:type_name -> kythe.proto.DecorationsReply.Override
+	38,  // 71: kythe.proto.DecorationsReply.BuildConfigGroup.reference:type_name -> kythe.proto.DecorationsReply.Reference
+	78,  // 72: kythe.proto.DecorationsReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
+	22,  // 73: kythe.proto.DecorationsReply.DefinitionLocationsEntry.value:type_name -> kythe.proto.Anchor
+	41,  // 74: kythe.proto.DecorationsReply.ExtendsOverridesEntry.value:type_name -> kythe.proto.DecorationsReply.Overrides
+	52,  // 75: kythe.proto.CrossReferencesReply.ReferenceGroup.reference:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
+	52,  // 76: kythe.proto.CrossReferencesReply.BuildConfigGroup.definition:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
+	52,  // 77: kythe.proto.CrossReferencesReply.BuildConfigGroup.declaration:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
+	52,  // 78: kythe.proto.CrossReferencesReply.BuildConfigGroup.reference:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
+	22,  // 79: kythe.proto.CrossReferencesReply.RelatedAnchor.anchor:type_name -> kythe.proto.Anchor
+	77,  // 80: kythe.proto.CrossReferencesReply.RelatedAnchor.marked_source:type_name -> kythe.proto.common.MarkedSource
+	22,  // 81: kythe.proto.CrossReferencesReply.RelatedAnchor.site:type_name -> kythe.proto.Anchor
+	22,  // 82: kythe.proto.CrossReferencesReply.CallerCallSites.caller_anchor:type_name -> kythe.proto.Anchor
+	77,  // 83: kythe.proto.CrossReferencesReply.CallerCallSites.marked_source:type_name -> kythe.proto.common.MarkedSource
+	22,  // 84: kythe.proto.CrossReferencesReply.CallerCallSites.call_site:type_name -> kythe.proto.Anchor
+	77,  // 85: kythe.proto.CrossReferencesReply.CrossReferenceSet.marked_source:type_name -> kythe.proto.common.MarkedSource
+	52,  // 86: kythe.proto.CrossReferencesReply.CrossReferenceSet.definition:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
+	52,  // 87: kythe.proto.CrossReferencesReply.CrossReferenceSet.declaration:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
+	52,  // 88: kythe.proto.CrossReferencesReply.CrossReferenceSet.reference:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
+	50,  // 89: kythe.proto.CrossReferencesReply.CrossReferenceSet.reference_group:type_name -> kythe.proto.CrossReferencesReply.ReferenceGroup
+	52,  // 90: kythe.proto.CrossReferencesReply.CrossReferenceSet.caller:type_name -> kythe.proto.CrossReferencesReply.RelatedAnchor
+	53,  // 91: kythe.proto.CrossReferencesReply.CrossReferenceSet.caller_call_sites:type_name -> kythe.proto.CrossReferencesReply.CallerCallSites
+	48,  // 92: kythe.proto.CrossReferencesReply.CrossReferenceSet.related_node:type_name -> kythe.proto.CrossReferencesReply.RelatedNode
+	49,  // 93: kythe.proto.CrossReferencesReply.CrossReferenceSet.related_node_list:type_name -> kythe.proto.CrossReferencesReply.RelatedNodeList
+	23,  // 94: kythe.proto.CrossReferencesReply.CrossReferenceSet.documentation:type_name -> kythe.proto.Printable
+	51,  // 95: kythe.proto.CrossReferencesReply.CrossReferenceSet.build_config_group:type_name -> kythe.proto.CrossReferencesReply.BuildConfigGroup
+	61,  // 96: kythe.proto.CrossReferencesReply.Total.related_nodes_by_relation:type_name -> kythe.proto.CrossReferencesReply.Total.RelatedNodesByRelationEntry
+	54,  // 97: kythe.proto.CrossReferencesReply.CrossReferencesEntry.value:type_name -> kythe.proto.CrossReferencesReply.CrossReferenceSet
+	78,  // 98: kythe.proto.CrossReferencesReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
+	22,  // 99: kythe.proto.CrossReferencesReply.DefinitionLocationsEntry.value:type_name -> kythe.proto.Anchor
+	75,  // 100: kythe.proto.DebugInfo.PhaseTiming.duration:type_name -> google.protobuf.Duration
+	22,  // 101: kythe.proto.MembersReply.Member.definition:type_name -> kythe.proto.Anchor
+	22,  // 102: kythe.proto.OutlineReply.Symbol.definition:type_name -> kythe.proto.Anchor
+	65,  // 103: kythe.proto.OutlineReply.Symbol.child:type_name -> kythe.proto.OutlineReply.Symbol
+	23,  // 104: kythe.proto.DocumentationReply.Document.text:type_name -> kythe.proto.Printable
+	77,  // 105: kythe.proto.DocumentationReply.Document.marked_source:type_name -> kythe.proto.common.MarkedSource
+	66,  // 106: kythe.proto.DocumentationReply.Document.children:type_name -> kythe.proto.DocumentationReply.Document
+	78,  // 107: kythe.proto.DocumentationReply.NodesEntry.value:type_name -> kythe.proto.common.NodeInfo
+	22,  // 108: kythe.proto.DocumentationReply.DefinitionLocationsEntry.value:type_name -> kythe.proto.Anchor
+	33,  // 109: kythe.proto.BatchDocumentationReply.RepliesEntry.value:type_name -> kythe.proto.DocumentationReply
+	14,  // 110: kythe.proto.XRefService.Decorations:input_type -> kythe.proto.DecorationsRequest
+	19,  // 111: kythe.proto.XRefService.CrossReferences:input_type -> kythe.proto.CrossReferencesRequest
+	32,  // 112: kythe.proto.XRefService.Documentation:input_type -> kythe.proto.DocumentationRequest
+	17,  // 113: kythe.proto.XRefWatchService.WatchDecorations:input_type -> kythe.proto.WatchDecorationsRequest
+	16,  // 114: kythe.proto.XRefService.Decorations:output_type -> kythe.proto.DecorationsReply
+	24,  // 115: kythe.proto.XRefService.CrossReferences:output_type -> kythe.proto.CrossReferencesReply
+	33,  // 116: kythe.proto.XRefService.Documentation:output_type -> kythe.proto.DocumentationReply
+	18,  // 117: kythe.proto.XRefWatchService.WatchDecorations:output_type -> kythe.proto.WatchDecorationsReply
+	114, // [114:118] is the sub-list for method output_type
+	110, // [110:114] is the sub-list for method input_type
+	110, // [110:110] is the sub-list for extension type_name
+	110, // [110:110] is the sub-list for extension extendee
+	0,   // [0:110] is the sub-list for field type_name
 }
 
 func init() { file_kythe_proto_xref_proto_init() }
@@ -2925,8 +5687,32 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DecorationsRequest); i {
+		file_kythe_proto_xref_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecorationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecorationsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2937,8 +5723,8 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*File); i {
+		file_kythe_proto_xref_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDecorationsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2949,8 +5735,8 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DecorationsReply); i {
+		file_kythe_proto_xref_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDecorationsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2961,7 +5747,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CrossReferencesRequest); i {
 			case 0:
 				return &v.state
@@ -2973,7 +5759,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CorpusPathFilters); i {
 			case 0:
 				return &v.state
@@ -2985,7 +5771,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CorpusPathFilter); i {
 			case 0:
 				return &v.state
@@ -2997,7 +5783,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Anchor); i {
 			case 0:
 				return &v.state
@@ -3009,7 +5795,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Printable); i {
 			case 0:
 				return &v.state
@@ -3021,7 +5807,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CrossReferencesReply); i {
 			case 0:
 				return &v.state
@@ -3033,7 +5819,91 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MembersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MembersReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollupReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutlineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutlineReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DocumentationRequest); i {
 			case 0:
 				return &v.state
@@ -3045,7 +5915,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DocumentationReply); i {
 			case 0:
 				return &v.state
@@ -3057,7 +5927,43 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchDocumentationReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveFingerprintRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveFingerprintReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Workspace); i {
 			case 0:
 				return &v.state
@@ -3069,7 +5975,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DecorationsReply_Reference); i {
 			case 0:
 				return &v.state
@@ -3081,7 +5987,19 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecorationsReply_DefinitionCandidate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DecorationsReply_Override); i {
 			case 0:
 				return &v.state
@@ -3093,7 +6011,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DecorationsReply_Overrides); i {
 			case 0:
 				return &v.state
@@ -3105,7 +6023,31 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecorationsReply_BuildConfigGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Anchor_SnippetRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CrossReferencesReply_RelatedNode); i {
 			case 0:
 				return &v.state
@@ -3117,7 +6059,43 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CrossReferencesReply_RelatedNodeList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CrossReferencesReply_ReferenceGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CrossReferencesReply_BuildConfigGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CrossReferencesReply_RelatedAnchor); i {
 			case 0:
 				return &v.state
@@ -3129,7 +6107,19 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CrossReferencesReply_CallerCallSites); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CrossReferencesReply_CrossReferenceSet); i {
 			case 0:
 				return &v.state
@@ -3141,7 +6131,7 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CrossReferencesReply_Total); i {
 			case 0:
 				return &v.state
@@ -3153,7 +6143,55 @@ func file_kythe_proto_xref_proto_init() {
 				return nil
 			}
 		}
-		file_kythe_proto_xref_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_kythe_proto_xref_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugInfo_PhaseTiming); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MembersReply_Member); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RollupReply_Target); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OutlineReply_Symbol); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kythe_proto_xref_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DocumentationReply_Document); i {
 			case 0:
 				return &v.state
@@ -3171,10 +6209,10 @@ func file_kythe_proto_xref_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kythe_proto_xref_proto_rawDesc,
-			NumEnums:      10,
-			NumMessages:   30,
+			NumEnums:      13,
+			NumMessages:   57,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_kythe_proto_xref_proto_goTypes,
 		DependencyIndexes: file_kythe_proto_xref_proto_depIdxs,